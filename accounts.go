@@ -0,0 +1,146 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+// memberTarget resolves a NativeID that cross-account discovery prefixed with
+// a member account into that account's target config and the resource's own
+// identifier. Identifiers without a prefix are returned unchanged with an
+// empty account.
+func memberTarget(targetConfig json.RawMessage, nativeID string) (json.RawMessage, string, string, error) {
+	account, id, ok := config.SplitAccountScopedID(nativeID)
+	if !ok {
+		return targetConfig, nativeID, "", nil
+	}
+	member, err := config.FromTargetConfig(targetConfig).MemberAccount(account)
+	if err != nil {
+		return nil, "", "", err
+	}
+	raw, err := json.Marshal(member)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return raw, id, account, nil
+}
+
+// scopeProgress prefixes the NativeID of a progress result from a member
+// account, so follow-up Status calls are routed back to that account.
+func scopeProgress(account string, progress *resource.ProgressResult) {
+	if account != "" && progress != nil && progress.NativeID != "" {
+		progress.NativeID = config.AccountScopedID(account, progress.NativeID)
+	}
+}
+
+// listMemberAccounts lists one page of request.ResourceType in every member
+// account concurrently. The page token records, per account, where its listing
+// continues; accounts whose listing is exhausted drop out of it.
+func (p *Plugin) listMemberAccounts(ctx context.Context, request *resource.ListRequest, targetConfig *config.Config) (*resource.ListResult, error) {
+	accounts, err := targetConfig.MemberAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make(map[string]string, len(accounts))
+	if request.PageToken == nil {
+		for _, account := range accounts {
+			pending[account] = ""
+		}
+	} else if pending, err = decodeAccountPageToken(*request.PageToken); err != nil {
+		return nil, err
+	}
+
+	type accountPage struct {
+		account string
+		result  *resource.ListResult
+		err     error
+	}
+	pages := make([]accountPage, 0, len(pending))
+	for account := range pending {
+		pages = append(pages, accountPage{account: account})
+	}
+	sort.Slice(pages, func(i, j int) bool { return pages[i].account < pages[j].account })
+
+	var wg sync.WaitGroup
+	for i := range pages {
+		wg.Add(1)
+		go func(page *accountPage) {
+			defer wg.Done()
+			member, err := targetConfig.MemberAccount(page.account)
+			if err != nil {
+				page.err = err
+				return
+			}
+			raw, err := json.Marshal(member)
+			if err != nil {
+				page.err = err
+				return
+			}
+			sub := *request
+			sub.TargetConfig = raw
+			sub.PageToken = nil
+			if token := pending[page.account]; token != "" {
+				sub.PageToken = &token
+			}
+			page.result, page.err = p.List(ctx, &sub)
+		}(&pages[i])
+	}
+	wg.Wait()
+
+	nativeIDs := []string{}
+	next := map[string]string{}
+	for _, page := range pages {
+		if page.err != nil {
+			return nil, fmt.Errorf("listing %s in account %s: %w", request.ResourceType, page.account, page.err)
+		}
+		for _, id := range page.result.NativeIDs {
+			nativeIDs = append(nativeIDs, config.AccountScopedID(page.account, id))
+		}
+		if token := page.result.NextPageToken; token != nil && *token != "" {
+			next[page.account] = *token
+		}
+	}
+
+	result := &resource.ListResult{NativeIDs: nativeIDs}
+	if len(next) > 0 {
+		token, err := encodeAccountPageToken(next)
+		if err != nil {
+			return nil, err
+		}
+		result.NextPageToken = &token
+	}
+	return result, nil
+}
+
+func encodeAccountPageToken(tokens map[string]string) (string, error) {
+	b, err := json.Marshal(tokens)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeAccountPageToken(token string) (map[string]string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+	tokens := map[string]string{}
+	if err := json.Unmarshal(b, &tokens); err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+	return tokens, nil
+}
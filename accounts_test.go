@@ -0,0 +1,62 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+func TestMemberTarget(t *testing.T) {
+	target := json.RawMessage(`{"Region":"us-east-1","MemberAccountRoleArns":["arn:aws:iam::111122223333:role/Discovery"]}`)
+
+	raw, id, account, err := memberTarget(target, "111122223333#vpc-0abc")
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-0abc", id)
+	assert.Equal(t, "111122223333", account)
+	member := config.FromTargetConfig(raw)
+	assert.Equal(t, "arn:aws:iam::111122223333:role/Discovery", member.RoleArn)
+	assert.Equal(t, "us-east-1", member.Region)
+
+	raw, id, account, err = memberTarget(target, "vpc-0abc")
+	require.NoError(t, err)
+	assert.Equal(t, target, raw)
+	assert.Equal(t, "vpc-0abc", id)
+	assert.Empty(t, account)
+
+	_, _, _, err = memberTarget(target, "444455556666#vpc-0abc")
+	assert.Error(t, err)
+}
+
+func TestScopeProgress(t *testing.T) {
+	progress := &resource.ProgressResult{NativeID: "vpc-0abc"}
+	scopeProgress("111122223333", progress)
+	assert.Equal(t, "111122223333#vpc-0abc", progress.NativeID)
+
+	progress = &resource.ProgressResult{NativeID: "vpc-0abc"}
+	scopeProgress("", progress)
+	assert.Equal(t, "vpc-0abc", progress.NativeID)
+}
+
+func TestAccountPageToken(t *testing.T) {
+	tokens := map[string]string{"111122223333": "next-a", "444455556666": "next-b"}
+
+	encoded, err := encodeAccountPageToken(tokens)
+	require.NoError(t, err)
+	decoded, err := decodeAccountPageToken(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, tokens, decoded)
+
+	_, err = decodeAccountPageToken("not a token")
+	assert.Error(t, err)
+}
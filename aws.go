@@ -7,14 +7,24 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
 	cctypes "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
 
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ccx"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfnstack"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/iam"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/helper"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/inventory"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/pricing"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ratelimit"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/tagging"
 	pkgmodel "github.com/platform-engineering-labs/formae/pkg/model"
 	"github.com/platform-engineering-labs/formae/pkg/plugin"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -53,11 +63,15 @@ var EKSAutomodeResourceTypes = []string{
 	"AWS::Logs::LogGroup",                       // If using CloudWatch logging
 }
 
-// RateLimit returns the rate limit configuration for this plugin
+// RateLimit returns the rate limit configuration for this plugin. The agent
+// applies it to plugin operations across every service, so it stays at
+// CloudControl's default rate, which most operations go through. The budgets
+// of individual services are enforced on the AWS calls themselves (see
+// ratelimit.DefaultServiceLimits).
 func (p *Plugin) RateLimit() pkgmodel.RateLimitConfig {
 	return pkgmodel.RateLimitConfig{
 		Scope:                            pkgmodel.RateLimitScopeNamespace,
-		MaxRequestsPerSecondForNamespace: 2,
+		MaxRequestsPerSecondForNamespace: int(ratelimit.DefaultServiceLimits["CloudControl"].RequestsPerSecond),
 	}
 }
 
@@ -102,6 +116,11 @@ func (p *Plugin) LabelConfig() pkgmodel.LabelConfig {
 			"AWS::S3::Object": "$.Key",
 			// CloudTrail trails have no Name tag; use the trail name as the label.
 			"AWS::CloudTrail::Trail": "$.TrailName",
+			// Functions, tables and queues are named on creation and are rarely
+			// given a Name tag as well.
+			"AWS::Lambda::Function": "$.FunctionName",
+			"AWS::DynamoDB::Table":  "$.TableName",
+			"AWS::SQS::Queue":       "$.QueueName",
 			// Resources that represent relationships use parent IDs
 			"AWS::EC2::VPCGatewayAttachment":          "$.VpcId",
 			"AWS::EC2::SubnetRouteTableAssociation":   "$.SubnetId",
@@ -110,28 +129,185 @@ func (p *Plugin) LabelConfig() pkgmodel.LabelConfig {
 	}
 }
 
+// healthCheckTimeout bounds checkHealth so an unreachable endpoint is
+// reported promptly instead of after the SDK's retry budget.
+const healthCheckTimeout = 15 * time.Second
+
+// healthyTargets records the targets, by Key, that have passed checkHealth so
+// each is probed once rather than before every operation.
+var (
+	healthyTargetsMu sync.Mutex
+	healthyTargets   = map[string]bool{}
+)
+
+// targetHealth describes where a healthy target's credentials lead.
+type targetHealth struct {
+	Account string
+	Arn     string
+	Region  string
+}
+
+// ensureHealthy probes a target the first time an operation uses it, so an
+// unreachable target or expired credentials are reported on the first
+// operation of a deploy rather than partway through. A healthy target is
+// remembered; a failing one isn't, so a target whose credentials are fixed is
+// probed again on its next operation.
+func ensureHealthy(ctx context.Context, cfg *config.Config) error {
+	key := cfg.Key()
+	healthyTargetsMu.Lock()
+	healthy := healthyTargets[key]
+	healthyTargetsMu.Unlock()
+	if healthy {
+		return nil
+	}
+
+	health, err := checkHealth(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	plugin.LoggerFromContext(ctx).Info("AWS target is healthy",
+		"account", health.Account,
+		"arn", health.Arn,
+		"region", health.Region)
+
+	healthyTargetsMu.Lock()
+	healthyTargets[key] = true
+	healthyTargetsMu.Unlock()
+	return nil
+}
+
+// checkHealth probes a target the way a deploy would use it: it validates the
+// target (see config.Config.Validate), then makes a read-only CloudControl
+// call. Failures are returned as a *config.ValidationError naming the failing
+// stage.
+func checkHealth(ctx context.Context, cfg *config.Config) (*targetHealth, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	identity, err := cfg.Validate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ccx.NewClient(cfg)
+	if err != nil {
+		return nil, &config.ValidationError{Stage: config.ValidationStageCredentials, Message: "loading AWS config", Err: err}
+	}
+	if err := client.Ping(ctx); err != nil {
+		return nil, &config.ValidationError{Stage: config.ValidationStageService, Message: "CloudControl probe failed", Err: err}
+	}
+
+	return &targetHealth{
+		Account: identity.Account,
+		Arn:     identity.Arn,
+		Region:  cfg.Region,
+	}, nil
+}
+
 func (p *Plugin) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	ctx = ratelimit.WithResourceType(ctx, request.ResourceType)
 	targetConfig := config.FromTargetConfig(request.TargetConfig)
+	ctx, cancel := context.WithTimeout(ctx, targetConfig.OperationTimeout(request.ResourceType))
+	defer cancel()
+	if err := ensureHealthy(ctx, targetConfig); err != nil {
+		return nil, err
+	}
+	if err := targetConfig.AssertAccount(ctx); err != nil {
+		return nil, err
+	}
+	properties, err := targetConfig.ApplyPermissionsBoundary(request.ResourceType, request.Properties)
+	if err != nil {
+		return nil, err
+	}
+	request.Properties = properties
+	if err := targetConfig.CheckPolicies(request.ResourceType, request.Properties); err != nil {
+		return nil, err
+	}
+	estimate := estimateCost(ctx, targetConfig, request)
+
+	var result *resource.CreateResult
 	if registry.HasProvisioner(request.ResourceType, resource.OperationCreate) {
 		provisioner := registry.Get(request.ResourceType, resource.OperationCreate, targetConfig)
-		return provisioner.Create(ctx, request)
+		result, err = provisioner.Create(ctx, request)
+	} else {
+		var client *ccx.Client
+		if client, err = ccx.NewClient(targetConfig); err != nil {
+			return nil, err
+		}
+		result, err = client.CreateResource(ctx, request)
 	}
 
-	client, err := ccx.NewClient(targetConfig)
-	if err != nil {
-		return nil, err
+	if err == nil && estimate != nil && result != nil && result.ProgressResult != nil {
+		if msg := result.ProgressResult.StatusMessage; msg != "" {
+			result.ProgressResult.StatusMessage = msg + "; " + estimate.String()
+		} else {
+			result.ProgressResult.StatusMessage = estimate.String()
+		}
 	}
+	return result, err
+}
 
-	return client.CreateResource(ctx, request)
+// estimateCost prices the resource about to be created when the target
+// enables EstimateCosts. An estimate is informational, so a failed lookup
+// (e.g. a role without pricing:GetProducts) is logged and the create proceeds.
+func estimateCost(ctx context.Context, cfg *config.Config, request *resource.CreateRequest) *pricing.Estimate {
+	if !cfg.EstimateCosts || !pricing.Supports(request.ResourceType) {
+		return nil
+	}
+	client, err := pricing.NewClient(cfg)
+	if err == nil {
+		var estimate *pricing.Estimate
+		if estimate, err = client.Estimate(ctx, request.ResourceType, request.Properties); err == nil {
+			return estimate
+		}
+	}
+	plugin.LoggerFromContext(ctx).Warn("cost estimate unavailable, continuing without it",
+		"resourceType", request.ResourceType,
+		"error", err)
+	return nil
 }
 
 func (p *Plugin) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	ctx = ratelimit.WithResourceType(ctx, request.ResourceType)
+	memberConfig, nativeID, account, err := memberTarget(request.TargetConfig, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if account != "" {
+		member := *request
+		member.TargetConfig, member.NativeID = memberConfig, nativeID
+		result, err := p.Update(ctx, &member)
+		if err == nil {
+			scopeProgress(account, result.ProgressResult)
+		}
+		return result, err
+	}
+
+	targetConfig := config.FromTargetConfig(request.TargetConfig)
+	ctx, cancel := context.WithTimeout(ctx, targetConfig.OperationTimeout(request.ResourceType))
+	defer cancel()
+	if err := ensureHealthy(ctx, targetConfig); err != nil {
+		return nil, err
+	}
+	if err := targetConfig.AssertAccount(ctx); err != nil {
+		return nil, err
+	}
+	desired, patchDoc, err := targetConfig.ApplyPermissionsBoundaryToUpdate(request.ResourceType,
+		request.PriorProperties, request.DesiredProperties, request.PatchDocument)
+	if err != nil {
+		return nil, err
+	}
+	request.DesiredProperties, request.PatchDocument = desired, patchDoc
+	if err := targetConfig.CheckUpdatePolicies(request.ResourceType, request.PriorProperties, request.DesiredProperties, request.PatchDocument); err != nil {
+		return nil, err
+	}
+	forgetPrefetched(targetConfig, request.ResourceType, request.NativeID)
 	if registry.HasProvisioner(request.ResourceType, resource.OperationUpdate) {
-		provisioner := registry.Get(request.ResourceType, resource.OperationUpdate, config.FromTargetConfig(request.TargetConfig))
+		provisioner := registry.Get(request.ResourceType, resource.OperationUpdate, targetConfig)
 		return provisioner.Update(ctx, request)
 	}
 
-	client, err := ccx.NewClient(config.FromTargetConfig(request.TargetConfig))
+	client, err := ccx.NewClient(targetConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -140,28 +316,109 @@ func (p *Plugin) Update(ctx context.Context, request *resource.UpdateRequest) (*
 }
 
 func (p *Plugin) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	ctx = ratelimit.WithResourceType(ctx, request.ResourceType)
+	memberConfig, nativeID, account, err := memberTarget(request.TargetConfig, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if account != "" {
+		member := *request
+		member.TargetConfig, member.NativeID = memberConfig, nativeID
+		result, err := p.Status(ctx, &member)
+		if err == nil {
+			scopeProgress(account, result.ProgressResult)
+		}
+		return result, err
+	}
+
+	targetConfig := config.FromTargetConfig(request.TargetConfig)
+	ctx, cancel := context.WithTimeout(ctx, targetConfig.OperationTimeout(request.ResourceType))
+	defer cancel()
 	if request.ResourceType != "" {
 		if registry.HasProvisioner(request.ResourceType, resource.OperationCheckStatus) {
-			provisioner := registry.Get(request.ResourceType, resource.OperationCheckStatus, config.FromTargetConfig(request.TargetConfig))
+			provisioner := registry.Get(request.ResourceType, resource.OperationCheckStatus, targetConfig)
 			return provisioner.Status(ctx, request)
 		}
 	}
 
-	client, err := ccx.NewClient(config.FromTargetConfig(request.TargetConfig))
+	client, err := ccx.NewClient(targetConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.StatusResource(ctx, request, p.Read)
 	if err != nil {
 		return nil, err
 	}
+	awaitIAMPropagation(ctx, targetConfig, request.ResourceType, result.ProgressResult)
+	return result, nil
+}
 
-	return client.StatusResource(ctx, request, p.Read)
+// forgetPrefetched drops a read discovery prefetched of a resource about to
+// change, so no later read serves the state from before the change. Native
+// provisioners change resources without going through ccx, so it's done here
+// for every update and delete.
+func forgetPrefetched(targetConfig *config.Config, resourceType, nativeID string) {
+	if client, err := ccx.NewClient(targetConfig); err == nil {
+		client.Forget(resourceType, nativeID)
+	}
+}
+
+// awaitIAMPropagation holds back the success of an IAM role, user, instance
+// profile, or managed policy create until IAM resolves the new resource
+// consistently, so the resources that depend on it aren't created against a
+// principal other services can't see yet. Until then the create is reported
+// as still in progress, and the next status check waits again. A failure to
+// check, such as a missing iam:GetRole permission, doesn't hold the create.
+func awaitIAMPropagation(ctx context.Context, targetConfig *config.Config, resourceType string, progress *resource.ProgressResult) {
+	if progress == nil || progress.Operation != resource.OperationCreate ||
+		progress.OperationStatus != resource.OperationStatusSuccess || !iam.WaitsForPropagation(resourceType) {
+		return
+	}
+	propagated, err := iam.WaitForPropagation(ctx, targetConfig, resourceType, progress.NativeID)
+	if err != nil {
+		plugin.LoggerFromContext(ctx).Warn("Status: could not check IAM propagation; reporting success",
+			"resourceType", resourceType, "nativeID", progress.NativeID, "error", err)
+		return
+	}
+	if !propagated {
+		progress.OperationStatus = resource.OperationStatusInProgress
+		progress.StatusMessage = fmt.Sprintf("waiting for IAM to propagate %s", progress.NativeID)
+	}
 }
 
 func (p *Plugin) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	ctx = ratelimit.WithResourceType(ctx, request.ResourceType)
+	memberConfig, nativeID, account, err := memberTarget(request.TargetConfig, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if account != "" {
+		member := *request
+		member.TargetConfig, member.NativeID = memberConfig, nativeID
+		result, err := p.Delete(ctx, &member)
+		if err == nil {
+			scopeProgress(account, result.ProgressResult)
+		}
+		return result, err
+	}
+
+	targetConfig := config.FromTargetConfig(request.TargetConfig)
+	ctx, cancel := context.WithTimeout(ctx, targetConfig.OperationTimeout(request.ResourceType))
+	defer cancel()
+	if err := ensureHealthy(ctx, targetConfig); err != nil {
+		return nil, err
+	}
+	if err := targetConfig.AssertAccount(ctx); err != nil {
+		return nil, err
+	}
+	forgetPrefetched(targetConfig, request.ResourceType, request.NativeID)
 	if registry.HasProvisioner(request.ResourceType, resource.OperationDelete) {
-		provisioner := registry.Get(request.ResourceType, resource.OperationDelete, config.FromTargetConfig(request.TargetConfig))
+		provisioner := registry.Get(request.ResourceType, resource.OperationDelete, targetConfig)
 		return provisioner.Delete(ctx, request)
 	}
 
-	client, err := ccx.NewClient(config.FromTargetConfig(request.TargetConfig))
+	client, err := ccx.NewClient(targetConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -170,12 +427,27 @@ func (p *Plugin) Delete(ctx context.Context, request *resource.DeleteRequest) (*
 }
 
 func (p *Plugin) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	ctx = ratelimit.WithResourceType(ctx, request.ResourceType)
+	memberConfig, nativeID, account, err := memberTarget(request.TargetConfig, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if account != "" {
+		member := *request
+		member.TargetConfig, member.NativeID = memberConfig, nativeID
+		return p.Read(ctx, &member)
+	}
+
+	targetConfig := config.FromTargetConfig(request.TargetConfig)
+	if err := ensureHealthy(ctx, targetConfig); err != nil {
+		return nil, err
+	}
 	if registry.HasProvisioner(request.ResourceType, resource.OperationRead) {
-		provisioner := registry.Get(request.ResourceType, resource.OperationRead, config.FromTargetConfig(request.TargetConfig))
+		provisioner := registry.Get(request.ResourceType, resource.OperationRead, targetConfig)
 		return provisioner.Read(ctx, request)
 	}
 
-	client, err := ccx.NewClient(config.FromTargetConfig(request.TargetConfig))
+	client, err := ccx.NewClient(targetConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -184,12 +456,84 @@ func (p *Plugin) Read(ctx context.Context, request *resource.ReadRequest) (*reso
 }
 
 func (p *Plugin) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	result, err := p.list(ctx, request)
+	if err != nil || result == nil {
+		return result, err
+	}
+	if request.ResourceType == "AWS::IAM::Role" && !config.FromTargetConfig(request.TargetConfig).DiscoverServiceLinkedRoles {
+		result.NativeIDs = withoutServiceLinkedRoles(result.NativeIDs)
+	}
+	return result, nil
+}
+
+func (p *Plugin) list(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	ctx = ratelimit.WithResourceType(ctx, request.ResourceType)
+	targetConfig := config.FromTargetConfig(request.TargetConfig)
+	if !targetConfig.Discovers(request.ResourceType) {
+		return &resource.ListResult{NativeIDs: []string{}}, nil
+	}
+	if err := ensureHealthy(ctx, targetConfig); err != nil {
+		return nil, err
+	}
+	if _, ok := request.AdditionalProperties[cfnstack.StackNameProperty]; ok {
+		return p.listStack(ctx, request, targetConfig)
+	}
+	scope := newDiscoveryScope(targetConfig, request)
+	// Config answers for every member account with one aggregator query, and
+	// for a single account from its own recorder; either way it falls back
+	// to listing through CloudControl when it can't.
+	if usesInventory(targetConfig, request) {
+		result, err := p.listInventory(ctx, request, targetConfig, scope)
+		if !errors.Is(err, inventory.ErrUnavailable) {
+			return result, err
+		}
+	}
+	if len(targetConfig.MemberAccountRoleArns) > 0 {
+		return p.listMemberAccounts(ctx, request, targetConfig)
+	}
+
 	if registry.HasProvisioner(request.ResourceType, resource.OperationList) {
-		provisioner := registry.Get(request.ResourceType, resource.OperationList, config.FromTargetConfig(request.TargetConfig))
-		return provisioner.List(ctx, request)
+		provisioner := registry.Get(request.ResourceType, resource.OperationList, targetConfig)
+		result, err := provisioner.List(ctx, request)
+		if err != nil || scope.isEmpty() {
+			return result, err
+		}
+		result.NativeIDs, err = filterListed(ctx, request, scope, result.NativeIDs, nil, p.reader(request.ResourceType, nil, false))
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	// The Tagging API can't list child resources under a parent, so those
+	// always go through CloudControl.
+	if targetConfig.DiscoveryMode == config.DiscoveryModeTagging &&
+		tagging.Supports(request.ResourceType) && len(request.AdditionalProperties) == 0 {
+		taggingClient, err := tagging.NewClient(targetConfig)
+		if err != nil {
+			return nil, err
+		}
+		result, err := taggingClient.List(ctx, request, scope.tags)
+		if err != nil || (len(scope.exclude) == 0 && !targetConfig.HydrateList) {
+			return result, err
+		}
+		client, err := ccx.NewClient(targetConfig)
+		if err != nil {
+			return nil, err
+		}
+		if len(scope.exclude) > 0 {
+			// The Tagging API has already applied the tag filter.
+			scope.tags = tagging.Filter{}
+			result.NativeIDs, err = filterListed(ctx, request, scope, result.NativeIDs, nil, p.reader(request.ResourceType, client, targetConfig.HydrateList))
+			if err != nil {
+				return nil, err
+			}
+		}
+		hydrate(ctx, request, targetConfig, client, result.NativeIDs)
+		return result, nil
 	}
 
-	client, err := ccx.NewClient(config.FromTargetConfig(request.TargetConfig))
+	client, err := ccx.NewClient(targetConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -204,6 +548,7 @@ func (p *Plugin) List(ctx context.Context, request *resource.ListRequest) (*reso
 		resourceModel = &resourceModelStr
 	}
 	var nativeIDs []string
+	listed := map[string]string{}
 	result, err := client.ListResources(ctx, &cloudcontrol.ListResourcesInput{TypeName: &request.ResourceType, MaxResults: &request.PageSize, NextToken: request.PageToken, ResourceModel: resourceModel})
 	if err != nil {
 		// If the parent resource doesn't exist (404), return an empty list instead of an error
@@ -225,7 +570,18 @@ func (p *Plugin) List(ctx context.Context, request *resource.ListRequest) (*reso
 			}
 		}
 		nativeIDs = append(nativeIDs, *r.Identifier)
+		if r.Properties != nil {
+			listed[*r.Identifier] = *r.Properties
+		}
+	}
+
+	if !scope.isEmpty() {
+		nativeIDs, err = filterListed(ctx, request, scope, nativeIDs, listed, p.reader(request.ResourceType, client, targetConfig.HydrateList))
+		if err != nil {
+			return nil, err
+		}
 	}
+	hydrate(ctx, request, targetConfig, client, nativeIDs)
 
 	return &resource.ListResult{
 		NativeIDs:     nativeIDs,
@@ -7,11 +7,36 @@
 package main
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
 )
 
+func TestEnsureHealthy_SkipsHealthyTarget(t *testing.T) {
+	cfg := &config.Config{Region: "nowhere"}
+	healthyTargetsMu.Lock()
+	healthyTargets[cfg.Key()] = true
+	healthyTargetsMu.Unlock()
+
+	assert.NoError(t, ensureHealthy(context.Background(), cfg))
+}
+
+func TestEnsureHealthy_DoesNotRememberFailure(t *testing.T) {
+	cfg := &config.Config{Region: "also-nowhere"}
+
+	var vErr *config.ValidationError
+	assert.ErrorAs(t, ensureHealthy(context.Background(), cfg), &vErr)
+	assert.Equal(t, config.ValidationStageRegion, vErr.Stage)
+	assert.Error(t, ensureHealthy(context.Background(), cfg))
+
+	healthyTargetsMu.Lock()
+	defer healthyTargetsMu.Unlock()
+	assert.False(t, healthyTargets[cfg.Key()])
+}
+
 func TestMatchesFilter(t *testing.T) {
 	t.Run("matches when all filter properties are present and equal", func(t *testing.T) {
 		properties := `{"VpcId":"vpc-123","SubnetId":"subnet-456","CidrBlock":"10.0.0.0/24"}`
@@ -0,0 +1,304 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/changes"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/drift"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/export"
+)
+
+// Started with a command as its first argument, the plugin binary runs that
+// command against a target and exits instead of serving the agent. Commands
+// are operator tools for work the agent doesn't drive, for example:
+//
+//	formae-plugin-aws import -target target.json arn:aws:sqs:us-east-1:123456789012:orders
+//
+// The target file holds the target configuration as JSON, the same fields an
+// aws.Config target renders to, such as {"Region":"us-east-1","Profile":"prod"}.
+
+// command runs with the arguments that follow its name and writes its result
+// to stdout.
+type command struct {
+	usage string
+	run   func(ctx context.Context, p *Plugin, flags *flag.FlagSet, args []string, stdout io.Writer) error
+}
+
+var commands = map[string]command{
+	"import":  {usage: "import -target <file> [-type <resource type>] <ARN or identifier>", run: runImport},
+	"export":  {usage: "export -target <file> [-format terraform|cloudformation] <resource type>...", run: runExport},
+	"changes": {usage: "changes -target <file> [-follow]", run: runChanges},
+	"drift":   {usage: "drift -target <file> [-watch <interval>] <resources file>", run: runDrift},
+}
+
+// errUsage reports command-line arguments the command can't run with.
+var errUsage = errors.New("invalid arguments")
+
+// isCommand reports whether the binary was started to run a command rather
+// than to serve the agent.
+func isCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	_, ok := commands[args[0]]
+	return ok
+}
+
+// runCommand runs the command args names and returns the process exit code:
+// 0 on success, 2 for invalid arguments and 1 for any other failure.
+func runCommand(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q, expected one of: %s\n", args[0], strings.Join(commandNames(), ", "))
+		return 2
+	}
+
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: %s %s\n", os.Args[0], cmd.usage)
+		flags.PrintDefaults()
+	}
+	if err := cmd.run(ctx, &Plugin{}, flags, args[1:], stdout); err != nil {
+		if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+			if !errors.Is(err, flag.ErrHelp) {
+				fmt.Fprintln(stderr, err)
+				flags.Usage()
+			}
+			return 2
+		}
+		fmt.Fprintf(stderr, "%s: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+func commandNames() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// targetFlag registers the -target flag every command takes.
+func targetFlag(flags *flag.FlagSet) *string {
+	return flags.String("target", "", "path to a JSON file holding the target configuration")
+}
+
+// readTarget reads the target configuration named by -target.
+func readTarget(path string) (json.RawMessage, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w: -target is required", errUsage)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading target configuration: %w", err)
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("target configuration %s is not valid JSON", path)
+	}
+	return json.RawMessage(data), nil
+}
+
+// writeJSON writes v to stdout as indented JSON.
+func writeJSON(stdout io.Writer, v any) error {
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func runImport(ctx context.Context, p *Plugin, flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	target := targetFlag(flags)
+	resourceType := flags.String("type", "", "resource type, required unless the identifier is an ARN of a type the Tagging API maps")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%w: expected one ARN or identifier", errUsage)
+	}
+	targetConfig, err := readTarget(*target)
+	if err != nil {
+		return err
+	}
+
+	imported, err := p.importResource(ctx, &ImportRequest{
+		ResourceType: *resourceType,
+		Identifier:   flags.Arg(0),
+		TargetConfig: targetConfig,
+	})
+	if err != nil {
+		return err
+	}
+	return writeJSON(stdout, imported)
+}
+
+func runExport(ctx context.Context, p *Plugin, flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	target := targetFlag(flags)
+	format := flags.String("format", export.FormatTerraform, "output format, terraform or cloudformation")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("%w: expected at least one resource type", errUsage)
+	}
+	if *format != export.FormatTerraform && *format != export.FormatCloudFormation {
+		return fmt.Errorf("%w: unknown format %q", errUsage, *format)
+	}
+	targetConfig, err := readTarget(*target)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := p.exportResources(ctx, &ExportRequest{
+		ResourceTypes: flags.Args(),
+		Format:        *format,
+		TargetConfig:  targetConfig,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(stdout, rendered)
+	return err
+}
+
+// runChanges drains the target's change queue and writes each out-of-band
+// change as a line of JSON. With -follow it keeps polling until interrupted,
+// so its output can be piped into whatever refreshes the changed resources.
+func runChanges(ctx context.Context, _ *Plugin, flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	target := targetFlag(flags)
+	follow := flags.Bool("follow", false, "keep polling the queue until interrupted")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("%w: unexpected argument %q", errUsage, flags.Arg(0))
+	}
+	targetConfig, err := readTarget(*target)
+	if err != nil {
+		return err
+	}
+	client, err := changes.NewClient(config.FromTargetConfig(targetConfig))
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(stdout)
+	for {
+		polled, err := client.Poll(ctx)
+		if err != nil {
+			if *follow && ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for _, change := range polled {
+			if err := encoder.Encode(change); err != nil {
+				return err
+			}
+		}
+		if !*follow || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// driftLine is how the drift command prints a drift.Report.
+type driftLine struct {
+	ResourceType string
+	NativeID     string
+	Deleted      bool               `json:",omitempty"`
+	Fields       []drift.FieldDrift `json:",omitempty"`
+	Error        string             `json:",omitempty"`
+}
+
+// runDrift checks the resources listed in a file, a DriftRequest without
+// TargetConfig, and writes a line of JSON for each one that drifted,
+// disappeared or couldn't be read. With -watch it sweeps again every interval
+// until interrupted; a sweep that fails is reported and retried at the next
+// interval.
+func runDrift(ctx context.Context, p *Plugin, flags *flag.FlagSet, args []string, stdout io.Writer) error {
+	target := targetFlag(flags)
+	watch := flags.Duration("watch", 0, "sweep again at this interval until interrupted, e.g. 15m")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("%w: expected one resources file", errUsage)
+	}
+	if *watch < 0 {
+		return fmt.Errorf("%w: -watch must be positive", errUsage)
+	}
+	targetConfig, err := readTarget(*target)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("reading resources: %w", err)
+	}
+	var request DriftRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return fmt.Errorf("parsing resources %s: %w", flags.Arg(0), err)
+	}
+	request.TargetConfig = targetConfig
+
+	encoder := json.NewEncoder(stdout)
+	sweep := func() error {
+		reports, err := p.detectDrift(ctx, &request)
+		if err != nil {
+			return err
+		}
+		for _, report := range reports {
+			if !report.Drifted() && report.Err == nil {
+				continue
+			}
+			line := driftLine{
+				ResourceType: report.ResourceType,
+				NativeID:     report.NativeID,
+				Deleted:      report.Deleted,
+				Fields:       report.Fields,
+			}
+			if report.Err != nil {
+				line.Error = report.Err.Error()
+			}
+			if err := encoder.Encode(line); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if *watch == 0 {
+		return sweep()
+	}
+	ticker := time.NewTicker(*watch)
+	defer ticker.Stop()
+	for {
+		if err := sweep(); err != nil && ctx.Err() == nil {
+			plugin.LoggerFromContext(ctx).Warn("drift sweep failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
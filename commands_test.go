@@ -0,0 +1,90 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCommand(t *testing.T) {
+	assert.True(t, isCommand([]string{"import", "vpc-0abc"}))
+	assert.False(t, isCommand(nil), "no arguments serves the agent")
+	assert.False(t, isCommand([]string{"-some-sdk-flag"}), "unknown arguments are left to the SDK")
+}
+
+func TestRunCommand_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := runCommand(context.Background(), []string{"import", "-target", "t.json"}, &stdout, &stderr)
+
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr.String(), "expected one ARN or identifier")
+	assert.Empty(t, stdout.String())
+}
+
+func TestRunCommand_ExportRejectsUnknownFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := runCommand(context.Background(), []string{"export", "-target", "t.json", "-format", "yaml", "AWS::SQS::Queue"}, &stdout, &stderr)
+
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr.String(), `unknown format "yaml"`)
+}
+
+func TestRunCommand_ChangesNeedsQueue(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "target.json")
+	require.NoError(t, os.WriteFile(target, []byte(`{"Region":"us-east-1"}`), 0o600))
+	var stdout, stderr bytes.Buffer
+
+	code := runCommand(context.Background(), []string{"changes", "-target", target}, &stdout, &stderr)
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "no ChangeQueueUrl")
+}
+
+func TestRunCommand_DriftNeedsResources(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := runCommand(context.Background(), []string{"drift", "-target", "t.json", "-watch", "15m"}, &stdout, &stderr)
+
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr.String(), "expected one resources file")
+}
+
+func TestRunCommand_TargetRequired(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := runCommand(context.Background(), []string{"import", "vpc-0abc"}, &stdout, &stderr)
+
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr.String(), "-target is required")
+}
+
+func TestReadTarget(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "target.json")
+	require.NoError(t, os.WriteFile(valid, []byte(`{"Region":"us-east-1"}`), 0o600))
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`region = "us-east-1"`), 0o600))
+
+	target, err := readTarget(valid)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Region":"us-east-1"}`, string(target))
+
+	_, err = readTarget(invalid)
+	assert.ErrorContains(t, err, "not valid JSON")
+
+	_, err = readTarget(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,249 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	pkgmodel "github.com/platform-engineering-labs/formae/pkg/model"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ccx"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfnstack"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/iam"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/inventory"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/tagging"
+)
+
+// discoveryScope holds the target's discovery filters that apply to one List
+// call. The agent only learns the plugin-wide filters from DiscoveryFilters,
+// which has no access to target config, so the target's own filters are
+// applied here, before the resources ever reach the agent.
+type discoveryScope struct {
+	tags tagging.Filter
+	// exclude holds the target's match filters for this resource type. They
+	// are evaluated like the agent evaluates DiscoveryFilters: a resource
+	// matching every condition of any filter is dropped.
+	exclude []pkgmodel.MatchFilter
+}
+
+func newDiscoveryScope(cfg *config.Config, request *resource.ListRequest) discoveryScope {
+	var scope discoveryScope
+	// Child resources are listed under a parent that has already passed the
+	// tag filter, and rarely carry tags of their own.
+	if len(request.AdditionalProperties) == 0 {
+		scope.tags = tagging.Filter{Include: cfg.DiscoveryIncludeTags, Exclude: cfg.DiscoveryExcludeTags}
+	}
+	for _, f := range cfg.DiscoveryFilters {
+		if slices.Contains(f.ResourceTypes, request.ResourceType) {
+			scope.exclude = append(scope.exclude, f)
+		}
+	}
+	return scope
+}
+
+func (s discoveryScope) isEmpty() bool {
+	return s.tags.IsEmpty() && len(s.exclude) == 0
+}
+
+// complete reports whether properties returned by a list call are enough to
+// decide on the resource without reading it. Match filters can refer to any
+// property, so they always need the full model.
+func (s discoveryScope) complete(properties string) bool {
+	if len(s.exclude) > 0 {
+		return false
+	}
+	_, ok := tagging.TagsOf(properties)
+	return ok
+}
+
+func (s discoveryScope) keep(properties string) bool {
+	tags, _ := tagging.TagsOf(properties)
+	if !s.tags.Matches(tags) {
+		return false
+	}
+	for _, f := range s.exclude {
+		if matchesAll(f.Conditions, properties) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAll reports whether properties satisfy every condition, using the
+// same JSONPath lookup the agent uses for DiscoveryFilters.
+func matchesAll(conditions []pkgmodel.FilterCondition, properties string) bool {
+	r := pkgmodel.Resource{Properties: json.RawMessage(properties)}
+	for _, c := range conditions {
+		value, found := r.GetPropertyJSONPath(c.PropertyPath)
+		if !found || (c.PropertyValue != "" && value != c.PropertyValue) {
+			return false
+		}
+	}
+	return true
+}
+
+// withoutServiceLinkedRoles drops service-linked roles from the NativeIDs of
+// listed IAM roles, which are role names, prefixed with the member account
+// in cross-account discovery. AWS services manage these roles, so they are
+// only discovered when a target asks for them.
+func withoutServiceLinkedRoles(nativeIDs []string) []string {
+	kept := make([]string, 0, len(nativeIDs))
+	for _, id := range nativeIDs {
+		_, roleName, _ := config.SplitAccountScopedID(id)
+		if !iam.IsServiceLinkedRole(roleName) {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
+
+// batchReader reads resources, returning the results in request order.
+type batchReader func(ctx context.Context, requests []resource.ReadRequest) []ccx.BatchReadResult
+
+// filterListed keeps the listed resources that scope accepts. Resources whose
+// list output is complete enough are decided from it; the rest are read with
+// read. Resources that disappeared between the list and the read are dropped.
+func filterListed(ctx context.Context, request *resource.ListRequest, scope discoveryScope, nativeIDs []string, listed map[string]string, read batchReader) ([]string, error) {
+	properties := make(map[string]string, len(nativeIDs))
+	var toRead []resource.ReadRequest
+	for _, id := range nativeIDs {
+		if props, ok := listed[id]; ok && scope.complete(props) {
+			properties[id] = props
+			continue
+		}
+		toRead = append(toRead, resource.ReadRequest{NativeID: id, ResourceType: request.ResourceType, TargetConfig: request.TargetConfig})
+	}
+
+	for _, r := range read(ctx, toRead) {
+		if r.Err != nil {
+			return nil, fmt.Errorf("reading %s %s to filter discovery: %w", request.ResourceType, r.Request.NativeID, r.Err)
+		}
+		switch r.Result.ErrorCode {
+		case "":
+			properties[r.Request.NativeID] = r.Result.Properties
+		case resource.OperationErrorCodeNotFound:
+			continue
+		default:
+			return nil, fmt.Errorf("reading %s %s to filter discovery: %s", request.ResourceType, r.Request.NativeID, r.Result.ErrorCode)
+		}
+	}
+
+	filtered := []string{}
+	for _, id := range nativeIDs {
+		props, ok := properties[id]
+		if ok && scope.keep(props) {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered, nil
+}
+
+// reader returns the batchReader for resourceType: CloudControl's batched
+// read, or one Read at a time for types with a Read provisioner. With
+// prefetch, CloudControl reads are kept for the agent's follow-up reads.
+func (p *Plugin) reader(resourceType string, client *ccx.Client, prefetch bool) batchReader {
+	if client != nil && !registry.HasProvisioner(resourceType, resource.OperationRead) {
+		if prefetch {
+			return client.Prefetch
+		}
+		return client.ReadResources
+	}
+	return func(ctx context.Context, requests []resource.ReadRequest) []ccx.BatchReadResult {
+		results := make([]ccx.BatchReadResult, len(requests))
+		for i := range requests {
+			results[i].Request = requests[i]
+			results[i].Result, results[i].Err = p.Read(ctx, &requests[i])
+		}
+		return results
+	}
+}
+
+// hydrate prefetches the listed resources when the target enables
+// HydrateList, so the agent's reads of the page are served from the client
+// instead of each costing a GetResource call. Resources that fail to read are
+// left for the agent to read (and retry) as usual.
+func hydrate(ctx context.Context, request *resource.ListRequest, cfg *config.Config, client *ccx.Client, nativeIDs []string) {
+	if !cfg.HydrateList || registry.HasProvisioner(request.ResourceType, resource.OperationRead) {
+		return
+	}
+	requests := make([]resource.ReadRequest, 0, len(nativeIDs))
+	for _, id := range nativeIDs {
+		requests = append(requests, resource.ReadRequest{NativeID: id, ResourceType: request.ResourceType, TargetConfig: request.TargetConfig})
+	}
+	client.Prefetch(ctx, requests)
+}
+
+// listStack lists one page of request.ResourceType among the resources of the
+// CloudFormation stack named in request.AdditionalProperties, so a stack can
+// be adopted as a whole. The target's match filters still apply.
+func (p *Plugin) listStack(ctx context.Context, request *resource.ListRequest, targetConfig *config.Config) (*resource.ListResult, error) {
+	stackClient, err := cfnstack.NewClient(targetConfig)
+	if err != nil {
+		return nil, err
+	}
+	result, err := stackClient.List(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := newDiscoveryScope(targetConfig, request)
+	if scope.isEmpty() {
+		return result, nil
+	}
+	client, err := ccx.NewClient(targetConfig)
+	if err != nil {
+		return nil, err
+	}
+	result.NativeIDs, err = filterListed(ctx, request, scope, result.NativeIDs, nil, p.reader(request.ResourceType, client, false))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// usesInventory reports whether List should try the AWS Config inventory.
+// Member accounts can only be listed through it with an aggregator; without
+// one, each member is listed through its own recorder after the fan-out.
+func usesInventory(cfg *config.Config, request *resource.ListRequest) bool {
+	if cfg.DiscoveryMode != config.DiscoveryModeConfig || !inventory.Supports(request.ResourceType) ||
+		len(request.AdditionalProperties) > 0 {
+		return false
+	}
+	return len(cfg.MemberAccountRoleArns) == 0 || cfg.ConfigAggregatorName != ""
+}
+
+// listInventory lists one page of request.ResourceType from AWS Config. Tag
+// filters are applied to the tags Config returns; match filters need the
+// resources to be read. Reads go through Read so account-prefixed IDs are
+// routed to their member account.
+func (p *Plugin) listInventory(ctx context.Context, request *resource.ListRequest, targetConfig *config.Config, scope discoveryScope) (*resource.ListResult, error) {
+	client, err := inventory.NewClient(targetConfig)
+	if err != nil {
+		return nil, err
+	}
+	var accounts []string
+	if len(targetConfig.MemberAccountRoleArns) > 0 {
+		if accounts, err = targetConfig.MemberAccounts(); err != nil {
+			return nil, err
+		}
+	}
+	result, err := client.List(ctx, request, scope.tags, accounts)
+	if err != nil || len(scope.exclude) == 0 {
+		return result, err
+	}
+
+	scope.tags = tagging.Filter{}
+	result.NativeIDs, err = filterListed(ctx, request, scope, result.NativeIDs, nil, p.reader(request.ResourceType, nil, false))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,131 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pkgmodel "github.com/platform-engineering-labs/formae/pkg/model"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ccx"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/tagging"
+)
+
+func stubReader(properties map[string]string, read *[]string) batchReader {
+	return func(_ context.Context, requests []resource.ReadRequest) []ccx.BatchReadResult {
+		results := make([]ccx.BatchReadResult, len(requests))
+		for i, r := range requests {
+			*read = append(*read, r.NativeID)
+			results[i].Request = r
+			if props, ok := properties[r.NativeID]; ok {
+				results[i].Result = &resource.ReadResult{Properties: props}
+			} else {
+				results[i].Result = &resource.ReadResult{ErrorCode: resource.OperationErrorCodeNotFound}
+			}
+		}
+		return results
+	}
+}
+
+func TestNewDiscoveryScope(t *testing.T) {
+	cfg := &config.Config{
+		DiscoveryIncludeTags: map[string][]string{"Team": {"payments"}},
+		DiscoveryFilters: []pkgmodel.MatchFilter{
+			{ResourceTypes: []string{"AWS::EC2::Instance"}, Conditions: []pkgmodel.FilterCondition{{PropertyPath: "$.InstanceType"}}},
+		},
+	}
+
+	scope := newDiscoveryScope(cfg, &resource.ListRequest{ResourceType: "AWS::EC2::Instance"})
+	assert.False(t, scope.tags.IsEmpty())
+	assert.Len(t, scope.exclude, 1)
+
+	scope = newDiscoveryScope(cfg, &resource.ListRequest{
+		ResourceType:         "AWS::EC2::Route",
+		AdditionalProperties: map[string]string{"RouteTableId": "rtb-1"},
+	})
+	assert.True(t, scope.isEmpty())
+}
+
+func TestFilterListed_Tags(t *testing.T) {
+	request := &resource.ListRequest{ResourceType: "AWS::EC2::VPC"}
+	scope := discoveryScope{tags: tagging.Filter{Include: map[string][]string{"Team": {"payments"}}}}
+
+	var read []string
+	reader := stubReader(map[string]string{
+		"vpc-read-match": `{"Tags":[{"Key":"Team","Value":"payments"}]}`,
+		"vpc-untagged":   `{"VpcId":"vpc-untagged"}`,
+	}, &read)
+	listed := map[string]string{
+		"vpc-listed-match": `{"Tags":[{"Key":"Team","Value":"payments"}]}`,
+		"vpc-listed-other": `{"Tags":[{"Key":"Team","Value":"search"}]}`,
+		"vpc-read-match":   `{"VpcId":"vpc-read-match"}`,
+	}
+	ids := []string{"vpc-listed-match", "vpc-listed-other", "vpc-read-match", "vpc-untagged", "vpc-gone"}
+
+	filtered, err := filterListed(context.Background(), request, scope, ids, listed, reader)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"vpc-listed-match", "vpc-read-match"}, filtered)
+	assert.Equal(t, []string{"vpc-read-match", "vpc-untagged", "vpc-gone"}, read)
+}
+
+func TestFilterListed_MatchFilters(t *testing.T) {
+	request := &resource.ListRequest{ResourceType: "AWS::EC2::Instance"}
+	scope := discoveryScope{exclude: []pkgmodel.MatchFilter{{
+		ResourceTypes: []string{"AWS::EC2::Instance"},
+		Conditions: []pkgmodel.FilterCondition{
+			{PropertyPath: `$.Tags[?match(@.Key, "karpenter\\.sh/nodepool")].Value`},
+		},
+	}}}
+
+	var read []string
+	reader := stubReader(map[string]string{
+		"i-karpenter": `{"Tags":[{"Key":"karpenter.sh/nodepool","Value":"default"}]}`,
+		"i-app":       `{"Tags":[{"Key":"Name","Value":"app"}]}`,
+	}, &read)
+	// Listed properties are never trusted for match filters.
+	listed := map[string]string{"i-karpenter": `{"Tags":[]}`}
+
+	filtered, err := filterListed(context.Background(), request, scope, []string{"i-karpenter", "i-app"}, listed, reader)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"i-app"}, filtered)
+	assert.Equal(t, []string{"i-karpenter", "i-app"}, read)
+}
+
+func TestMatchesAll(t *testing.T) {
+	props := `{"InstanceType":"m5.large","Tags":[{"Key":"aws:batch:compute-environment","Value":"ce-1"}]}`
+
+	assert.True(t, matchesAll([]pkgmodel.FilterCondition{{PropertyPath: "$.InstanceType", PropertyValue: "m5.large"}}, props))
+	assert.True(t, matchesAll([]pkgmodel.FilterCondition{{PropertyPath: `$.Tags[?(@.Key=='aws:batch:compute-environment')].Value`}}, props))
+	assert.False(t, matchesAll([]pkgmodel.FilterCondition{
+		{PropertyPath: "$.InstanceType", PropertyValue: "m5.large"},
+		{PropertyPath: "$.ImageId"},
+	}, props))
+}
+
+func TestWithoutServiceLinkedRoles(t *testing.T) {
+	ids := []string{"app-role", "AWSServiceRoleForECS", "111122223333#AWSServiceRoleForSupport", "111122223333#ops"}
+	assert.Equal(t, []string{"app-role", "111122223333#ops"}, withoutServiceLinkedRoles(ids))
+}
+
+func TestFilterListed_ReadError(t *testing.T) {
+	request := &resource.ListRequest{ResourceType: "AWS::EC2::VPC"}
+	scope := discoveryScope{tags: tagging.Filter{Exclude: map[string][]string{"Env": nil}}}
+	reader := func(_ context.Context, requests []resource.ReadRequest) []ccx.BatchReadResult {
+		return []ccx.BatchReadResult{{Request: requests[0], Err: errors.New("AccessDenied")}}
+	}
+
+	_, err := filterListed(context.Background(), request, scope, []string{"vpc-1"}, nil, reader)
+
+	assert.ErrorContains(t, err, "AccessDenied")
+}
@@ -0,0 +1,101 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ccx"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/drift"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ratelimit"
+)
+
+// DriftRequest lists the managed resources of a target to check for drift. The
+// drift command reads it, without TargetConfig, from a JSON file.
+type DriftRequest struct {
+	Resources []drift.Resource
+	// Ignore lists, per resource type, fields not to compare on top of the
+	// type's write-only properties, which are never compared.
+	Ignore       map[string][]string
+	TargetConfig json.RawMessage
+}
+
+// detectDrift reads every resource of request and reports, in request order,
+// how each differs from its recorded properties. Resources are read in
+// batches per account and type through the same bounded, throttle-aware
+// reader discovery uses. It backs the drift command.
+func (p *Plugin) detectDrift(ctx context.Context, request *DriftRequest) ([]drift.Report, error) {
+	type group struct {
+		account      string
+		resourceType string
+	}
+	reports := make([]drift.Report, len(request.Resources))
+	batches := map[group][]int{}
+	targets := map[group]json.RawMessage{}
+	reads := map[int]resource.ReadRequest{}
+	for i, r := range request.Resources {
+		reports[i] = drift.Report{ResourceType: r.ResourceType, NativeID: r.NativeID}
+		targetConfig, nativeID, account, err := memberTarget(request.TargetConfig, r.NativeID)
+		if err != nil {
+			return nil, err
+		}
+		g := group{account, r.ResourceType}
+		batches[g] = append(batches[g], i)
+		targets[g] = targetConfig
+		reads[i] = resource.ReadRequest{
+			NativeID:        nativeID,
+			ResourceType:    r.ResourceType,
+			PriorProperties: r.Properties,
+			TargetConfig:    targetConfig,
+		}
+	}
+
+	groups := make([]group, 0, len(batches))
+	for g := range batches {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].account != groups[j].account {
+			return groups[i].account < groups[j].account
+		}
+		return groups[i].resourceType < groups[j].resourceType
+	})
+
+	for _, g := range groups {
+		client, err := ccx.NewClient(config.FromTargetConfig(targets[g]))
+		if err != nil {
+			return nil, err
+		}
+		indexes := batches[g]
+		requests := make([]resource.ReadRequest, len(indexes))
+		for j, i := range indexes {
+			requests[j] = reads[i]
+		}
+
+		ignore := append(drift.IgnoredFields(client.WriteOnlyProperties(ctx, g.resourceType)), request.Ignore[g.resourceType]...)
+		read := p.reader(g.resourceType, client, false)
+		for j, result := range read(ratelimit.WithResourceType(ctx, g.resourceType), requests) {
+			i := indexes[j]
+			report := &reports[i]
+			switch {
+			case result.Err != nil:
+				report.Err = result.Err
+			case result.Result.ErrorCode == resource.OperationErrorCodeNotFound:
+				report.Deleted = true
+			case result.Result.ErrorCode != "":
+				report.Err = fmt.Errorf("reading %s %s: %s", g.resourceType, report.NativeID, result.Result.ErrorCode)
+			default:
+				report.Fields, report.Err = drift.Diff(request.Resources[i].Properties, result.Result.Properties, ignore)
+			}
+		}
+	}
+	return reports, nil
+}
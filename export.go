@@ -0,0 +1,77 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/export"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/helper"
+)
+
+// ExportRequest selects the discovered resources to export. Format is
+// export.FormatTerraform or export.FormatCloudFormation.
+type ExportRequest struct {
+	ResourceTypes []string
+	Format        string
+	TargetConfig  json.RawMessage
+}
+
+// exportPageSize is how many resources each List page asks for, the most
+// CloudControl returns per page.
+const exportPageSize = 100
+
+// exportResources lists every resource of the requested types the way
+// discovery does, reads each one as importResource does and renders them in
+// the requested format. It backs the export command.
+func (p *Plugin) exportResources(ctx context.Context, request *ExportRequest) (string, error) {
+	var resources []export.Resource
+	for _, resourceType := range request.ResourceTypes {
+		nativeIDs, err := p.listAll(ctx, resourceType, request.TargetConfig)
+		if err != nil {
+			return "", fmt.Errorf("listing %s to export: %w", resourceType, err)
+		}
+		for _, nativeID := range nativeIDs {
+			imported, err := p.importResource(ctx, &ImportRequest{
+				ResourceType: resourceType,
+				Identifier:   nativeID,
+				TargetConfig: request.TargetConfig,
+			})
+			if err != nil {
+				return "", err
+			}
+			resources = append(resources, export.Resource{
+				ResourceType: imported.ResourceType,
+				NativeID:     imported.NativeID,
+				Label:        imported.Label,
+				Properties:   imported.Properties,
+			})
+		}
+	}
+	export.Sort(resources)
+	return export.Render(request.Format, resources)
+}
+
+// listAll lists every resource of resourceType, following List's page tokens
+// with helper.Paginate.
+func (p *Plugin) listAll(ctx context.Context, resourceType string, targetConfig json.RawMessage) ([]string, error) {
+	return helper.Paginate(ctx, helper.DefaultPageInterval,
+		func(ctx context.Context, token *string) ([]string, *string, error) {
+			result, err := p.List(ctx, &resource.ListRequest{
+				ResourceType: resourceType,
+				TargetConfig: targetConfig,
+				PageSize:     exportPageSize,
+				PageToken:    token,
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			return result.NativeIDs, result.NextPageToken, nil
+		})
+}
@@ -0,0 +1,114 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	pkgmodel "github.com/platform-engineering-labs/formae/pkg/model"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/arn"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ccx"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/tagging"
+)
+
+// ImportRequest identifies an existing resource to adopt. Identifier is
+// either the resource's CloudControl identifier or its ARN; ResourceType may
+// be left empty for ARNs of the types the Tagging API maps (see
+// tagging.TypeForArn).
+type ImportRequest struct {
+	ResourceType string
+	Identifier   string
+	TargetConfig json.RawMessage
+}
+
+// ImportResult is what the agent needs to adopt a resource into a stack
+// without recreating it.
+type ImportResult struct {
+	ResourceType string
+	NativeID     string
+	Label        string
+	// Properties is the resource's current state as a desired state, with
+	// readOnly properties removed.
+	Properties json.RawMessage
+}
+
+// importResource reads an existing resource and returns it ready to be
+// adopted. It backs the import command.
+func (p *Plugin) importResource(ctx context.Context, request *ImportRequest) (*ImportResult, error) {
+	resourceType, nativeID, err := importIdentifier(request.ResourceType, request.Identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	read, err := p.Read(ctx, &resource.ReadRequest{
+		NativeID:     nativeID,
+		ResourceType: resourceType,
+		TargetConfig: request.TargetConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if read.ErrorCode != "" {
+		return nil, fmt.Errorf("reading %s %s to import it: %s", resourceType, nativeID, read.ErrorCode)
+	}
+
+	client, err := ccx.NewClient(config.FromTargetConfig(request.TargetConfig))
+	if err != nil {
+		return nil, err
+	}
+	properties, err := client.NormalizeForImport(ctx, resourceType, read.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImportResult{
+		ResourceType: resourceType,
+		NativeID:     nativeID,
+		Label:        importLabel(p.LabelConfig(), resourceType, nativeID, read.Properties),
+		Properties:   properties,
+	}, nil
+}
+
+// importIdentifier resolves the resource type and CloudControl identifier of
+// an import. Identifiers that aren't ARNs are used as they are.
+func importIdentifier(resourceType, identifier string) (string, string, error) {
+	if !arn.IsArn(identifier) {
+		if resourceType == "" {
+			return "", "", fmt.Errorf("a resource type is required to import %s", identifier)
+		}
+		return resourceType, identifier, nil
+	}
+
+	if resourceType == "" {
+		var ok bool
+		if resourceType, ok = tagging.TypeForArn(identifier); !ok {
+			return "", "", fmt.Errorf("cannot infer the resource type of %s", identifier)
+		}
+	}
+	nativeID, ok := tagging.IdentifierFromArn(resourceType, identifier)
+	if !ok {
+		return "", "", fmt.Errorf("cannot derive the %s identifier from %s, import it by identifier instead", resourceType, identifier)
+	}
+	return resourceType, nativeID, nil
+}
+
+// importLabel labels an imported resource the way discovery would, falling
+// back to its identifier when the label query finds nothing.
+func importLabel(labels pkgmodel.LabelConfig, resourceType, nativeID, properties string) string {
+	query := labels.DefaultQuery
+	if override, ok := labels.ResourceOverrides[resourceType]; ok {
+		query = override
+	}
+	r := pkgmodel.Resource{Properties: json.RawMessage(properties)}
+	if label, found := r.GetPropertyJSONPath(query); found && label != "" {
+		return label
+	}
+	return nativeID
+}
@@ -0,0 +1,40 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportIdentifier(t *testing.T) {
+	typ, id, err := importIdentifier("", "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0abc")
+	require.NoError(t, err)
+	assert.Equal(t, "AWS::EC2::VPC", typ)
+	assert.Equal(t, "vpc-0abc", id)
+
+	typ, id, err = importIdentifier("AWS::EC2::Subnet", "subnet-0abc")
+	require.NoError(t, err)
+	assert.Equal(t, "AWS::EC2::Subnet", typ)
+	assert.Equal(t, "subnet-0abc", id)
+
+	_, _, err = importIdentifier("", "subnet-0abc")
+	assert.Error(t, err, "identifiers need a resource type")
+
+	_, _, err = importIdentifier("AWS::EKS::Nodegroup", "arn:aws:eks:us-east-1:123456789012:nodegroup/c/ng/abc")
+	assert.Error(t, err, "ARN of a type without an identifier mapping")
+}
+
+func TestImportLabel(t *testing.T) {
+	labels := (&Plugin{}).LabelConfig()
+
+	assert.Equal(t, "web", importLabel(labels, "AWS::EC2::VPC", "vpc-0abc", `{"Tags":[{"Key":"Name","Value":"web"}]}`))
+	assert.Equal(t, "orders", importLabel(labels, "AWS::SQS::Queue", "https://sqs/orders", `{"QueueName":"orders"}`))
+	assert.Equal(t, "vpc-0abc", importLabel(labels, "AWS::EC2::VPC", "vpc-0abc", `{"CidrBlock":"10.0.0.0/16"}`))
+}
@@ -4,8 +4,21 @@
 
 package main
 
-import "github.com/platform-engineering-labs/formae/pkg/plugin/sdk"
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/sdk"
+)
 
 func main() {
+	if isCommand(os.Args[1:]) {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		code := runCommand(ctx, os.Args[1:], os.Stdout, os.Stderr)
+		stop()
+		os.Exit(code)
+	}
 	sdk.RunWithManifest(&Plugin{}, sdk.RunConfig{})
 }
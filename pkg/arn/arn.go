@@ -6,6 +6,14 @@ package arn
 
 import "strings"
 
+const (
+	PartitionAWS      = "aws"
+	PartitionChina    = "aws-cn"
+	PartitionGovCloud = "aws-us-gov"
+	PartitionISO      = "aws-iso"
+	PartitionISOB     = "aws-iso-b"
+)
+
 func IdFrom(arn string) string {
 	frags := strings.Split(arn, "/")
 	if len(frags) == 2 {
@@ -15,3 +23,35 @@ func IdFrom(arn string) string {
 	frags = strings.Split(arn, ":")
 	return frags[len(frags)-1]
 }
+
+// IsArn reports whether s looks like an ARN in any partition.
+func IsArn(s string) bool {
+	return strings.HasPrefix(s, "arn:")
+}
+
+// PartitionFrom returns the partition segment of an ARN (aws, aws-cn,
+// aws-us-gov, ...), defaulting to "aws" when s is not an ARN.
+func PartitionFrom(s string) string {
+	parts := strings.SplitN(s, ":", 3)
+	if len(parts) < 3 || parts[0] != "arn" || parts[1] == "" {
+		return PartitionAWS
+	}
+	return parts[1]
+}
+
+// PartitionForRegion returns the partition a region belongs to, so ARNs can
+// be built from the target's region when no existing ARN is at hand.
+func PartitionForRegion(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return PartitionChina
+	case strings.HasPrefix(region, "us-gov-"):
+		return PartitionGovCloud
+	case strings.HasPrefix(region, "us-isob-"):
+		return PartitionISOB
+	case strings.HasPrefix(region, "us-iso-"):
+		return PartitionISO
+	default:
+		return PartitionAWS
+	}
+}
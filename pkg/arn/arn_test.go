@@ -0,0 +1,36 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package arn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionFrom(t *testing.T) {
+	assert.Equal(t, "aws", PartitionFrom("arn:aws:lambda:us-east-1:123456789012:function:fn"))
+	assert.Equal(t, "aws-cn", PartitionFrom("arn:aws-cn:lambda:cn-north-1:123456789012:function:fn"))
+	assert.Equal(t, "aws-us-gov", PartitionFrom("arn:aws-us-gov:lambda:us-gov-west-1:123456789012:function:fn"))
+	assert.Equal(t, "aws", PartitionFrom("not-an-arn"))
+	assert.Equal(t, "aws", PartitionFrom(""))
+}
+
+func TestPartitionForRegion(t *testing.T) {
+	assert.Equal(t, "aws", PartitionForRegion("us-east-1"))
+	assert.Equal(t, "aws-cn", PartitionForRegion("cn-northwest-1"))
+	assert.Equal(t, "aws-us-gov", PartitionForRegion("us-gov-east-1"))
+	assert.Equal(t, "aws-iso", PartitionForRegion("us-iso-east-1"))
+	assert.Equal(t, "aws-iso-b", PartitionForRegion("us-isob-east-1"))
+	assert.Equal(t, "aws", PartitionForRegion(""))
+}
+
+func TestIsArn(t *testing.T) {
+	assert.True(t, IsArn("arn:aws:ecs:us-east-1:123456789012:cluster/c"))
+	assert.True(t, IsArn("arn:aws-cn:ecs:cn-north-1:123456789012:cluster/c"))
+	assert.False(t, IsArn("my-cluster"))
+}
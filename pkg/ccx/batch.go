@@ -0,0 +1,121 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ccx
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// defaultReadConcurrency bounds the number of GetResource calls ReadResources
+// keeps in flight. CloudControl's GetResource quota is shared by every caller
+// in the account and region, so this stays well below it.
+const defaultReadConcurrency = 8
+
+// BatchReadResult pairs the outcome of one read in a ReadResources batch with
+// its request. Exactly one of Result and Err is set.
+type BatchReadResult struct {
+	Request resource.ReadRequest
+	Result  *resource.ReadResult
+	Err     error
+}
+
+// ReadResources reads many resources with bounded concurrency, for discovery
+// and Status hydration where reading hundreds of resources one call at a time
+// is too slow. Each read goes through retryRead, and the workers share a
+// throttle gate: once one of them is throttled, none issues another call until
+// the backoff has passed, so the batch slows down as a whole instead of every
+// worker separately hammering an exhausted quota. Results are returned in
+// request order.
+func (c *Client) ReadResources(ctx context.Context, requests []resource.ReadRequest) []BatchReadResult {
+	return c.readResources(ctx, requests, defaultReadConcurrency, retryOpts{})
+}
+
+func (c *Client) readResources(ctx context.Context, requests []resource.ReadRequest, concurrency int, opts retryOpts) []BatchReadResult {
+	results := make([]BatchReadResult, len(requests))
+	gate := &throttleGate{}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := range requests {
+		results[i].Request = requests[i]
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			req := requests[i]
+			res, err := retryRead(ctx, opts, "ReadResources:"+req.ResourceType,
+				func(ctx context.Context) (*resource.ReadResult, error) {
+					if err := gate.wait(ctx); err != nil {
+						return nil, err
+					}
+					res, err := c.ReadResource(ctx, &req)
+					if isThrottled(res, err) {
+						gate.hold(opts.withDefaults().BaseDelay)
+					}
+					return res, err
+				})
+			results[i].Result = res
+			results[i].Err = err
+			if err != nil {
+				results[i].Result = nil
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func isThrottled(res *resource.ReadResult, err error) bool {
+	if res != nil && res.ErrorCode == resource.OperationErrorCodeThrottling {
+		return true
+	}
+	return err != nil && strings.Contains(err.Error(), "Throttling")
+}
+
+// throttleGate holds back callers until a shared deadline has passed.
+type throttleGate struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// hold pushes the gate's deadline out to at least d from now.
+func (g *throttleGate) hold(d time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if until := time.Now().Add(d); until.After(g.until) {
+		g.until = until
+	}
+}
+
+// wait blocks until the gate's deadline has passed or ctx is done.
+func (g *throttleGate) wait(ctx context.Context) error {
+	g.mu.Lock()
+	remaining := time.Until(g.until)
+	g.mu.Unlock()
+	if remaining <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(remaining):
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ccx
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	cctypes "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ptr"
+)
+
+// concurrencyTrackingAPI serves GetResource and records the peak number of
+// calls in flight.
+type concurrencyTrackingAPI struct {
+	mockCloudControlAPI
+	inFlight  atomic.Int32
+	peak      atomic.Int32
+	mu        sync.Mutex
+	throttled map[string]bool
+}
+
+func (a *concurrencyTrackingAPI) GetResource(ctx context.Context, params *cloudcontrol.GetResourceInput, optFns ...func(*cloudcontrol.Options)) (*cloudcontrol.GetResourceOutput, error) {
+	n := a.inFlight.Add(1)
+	defer a.inFlight.Add(-1)
+	for {
+		p := a.peak.Load()
+		if n <= p || a.peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	a.mu.Lock()
+	throttle := a.throttled != nil && !a.throttled[*params.Identifier]
+	if throttle {
+		a.throttled[*params.Identifier] = true
+	}
+	a.mu.Unlock()
+	if throttle {
+		return nil, &cctypes.ThrottlingException{Message: ptr.Of("Rate exceeded")}
+	}
+
+	return &cloudcontrol.GetResourceOutput{
+		TypeName: params.TypeName,
+		ResourceDescription: &cctypes.ResourceDescription{
+			Identifier: params.Identifier,
+			Properties: ptr.Of(fmt.Sprintf(`{"Id":%q}`, *params.Identifier)),
+		},
+	}, nil
+}
+
+func readRequests(n int) []resource.ReadRequest {
+	reqs := make([]resource.ReadRequest, n)
+	for i := range reqs {
+		reqs[i] = resource.ReadRequest{NativeID: fmt.Sprintf("r-%d", i), ResourceType: "AWS::SQS::Queue"}
+	}
+	return reqs
+}
+
+func TestReadResources_BoundedConcurrencyAndOrder(t *testing.T) {
+	api := &concurrencyTrackingAPI{}
+	client := &Client{api: api}
+
+	results := client.readResources(context.Background(), readRequests(20), 4, retryOpts{})
+
+	require.Len(t, results, 20)
+	for i, r := range results {
+		require.NoError(t, r.Err)
+		require.Equal(t, fmt.Sprintf("r-%d", i), r.Request.NativeID)
+		require.JSONEq(t, fmt.Sprintf(`{"Id":"r-%d"}`, i), r.Result.Properties)
+	}
+	require.LessOrEqual(t, api.peak.Load(), int32(4))
+	require.Greater(t, api.peak.Load(), int32(1))
+}
+
+func TestReadResources_RetriesThrottledReads(t *testing.T) {
+	api := &concurrencyTrackingAPI{throttled: map[string]bool{}}
+	client := &Client{api: api}
+
+	results := client.readResources(context.Background(), readRequests(5), 2,
+		retryOpts{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond})
+
+	for _, r := range results {
+		require.NoError(t, r.Err)
+		require.Empty(t, r.Result.ErrorCode)
+		require.NotEmpty(t, r.Result.Properties)
+	}
+}
+
+func TestThrottleGate_WaitHonoursContext(t *testing.T) {
+	gate := &throttleGate{}
+	gate.hold(time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.ErrorIs(t, gate.wait(ctx), context.Canceled)
+}
@@ -0,0 +1,94 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ccx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	cctypes "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+	"github.com/aws/smithy-go/middleware"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ptr"
+)
+
+// A target's circuit opens after throttleCircuitThreshold consecutive
+// CloudControl calls come back throttled, each already having exhausted the
+// SDK's own retries. While it is open, calls fail fast with a
+// ThrottlingException, which the PluginOperator treats as recoverable, so
+// queued operations back off instead of each spending its request budget on
+// an account that is already over its quota.
+const (
+	throttleCircuitThreshold       = 5
+	defaultThrottleCircuitCooldown = 30 * time.Second
+)
+
+// throttleCircuit is a per-Client (and so per-target) circuit breaker on
+// sustained CloudControl throttling.
+type throttleCircuit struct {
+	cooldown time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	throttled int
+	openUntil time.Time
+}
+
+func newThrottleCircuit(cfg *config.Config) *throttleCircuit {
+	cooldown := defaultThrottleCircuitCooldown
+	if cfg.ThrottleCooldownSeconds > 0 {
+		cooldown = time.Duration(cfg.ThrottleCooldownSeconds) * time.Second
+	}
+	return &throttleCircuit{cooldown: cooldown, now: time.Now}
+}
+
+// allow reports whether a call may proceed, and if not, how long the circuit
+// stays open.
+func (c *throttleCircuit) allow() (bool, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if remaining := c.openUntil.Sub(c.now()); remaining > 0 {
+		return false, remaining
+	}
+	return true, 0
+}
+
+// record updates the circuit with the outcome of a call that reached AWS.
+// After a cool-down the first throttled call re-opens the circuit straight
+// away; the first call that isn't throttled closes it.
+func (c *throttleCircuit) record(throttled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !throttled {
+		c.throttled = 0
+		return
+	}
+	c.throttled++
+	if c.throttled >= throttleCircuitThreshold {
+		c.openUntil = c.now().Add(c.cooldown)
+	}
+}
+
+// addMiddleware installs the circuit at the start of the Initialize step, so
+// it sees each call once, after the SDK retryer has given up.
+func (c *throttleCircuit) addMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("FormaeThrottleCircuit",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			if ok, remaining := c.allow(); !ok {
+				return middleware.InitializeOutput{}, middleware.Metadata{}, &cctypes.ThrottlingException{
+					Message: ptr.Of(fmt.Sprintf("CloudControl circuit open after sustained throttling, retry in %s", remaining.Round(time.Second))),
+				}
+			}
+
+			out, metadata, err := next.HandleInitialize(ctx, in)
+			var throttling *cctypes.ThrottlingException
+			c.record(errors.As(err, &throttling))
+			return out, metadata, err
+		}), middleware.Before)
+}
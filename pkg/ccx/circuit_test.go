@@ -0,0 +1,95 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ccx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	helper "github.com/platform-engineering-labs/formae-plugin-aws/pkg/helper"
+)
+
+func TestThrottleCircuit_OpensAfterThresholdAndCoolsDown(t *testing.T) {
+	at := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	c := newThrottleCircuit(&config.Config{ThrottleCooldownSeconds: 10})
+	c.now = func() time.Time { return at }
+
+	for range throttleCircuitThreshold - 1 {
+		c.record(true)
+	}
+	ok, _ := c.allow()
+	require.True(t, ok)
+
+	c.record(true)
+	ok, remaining := c.allow()
+	require.False(t, ok)
+	require.Equal(t, 10*time.Second, remaining)
+
+	at = at.Add(11 * time.Second)
+	ok, _ = c.allow()
+	require.True(t, ok)
+
+	// Half-open: one more throttle re-opens immediately.
+	c.record(true)
+	ok, _ = c.allow()
+	require.False(t, ok)
+}
+
+func TestThrottleCircuit_SuccessResets(t *testing.T) {
+	c := newThrottleCircuit(&config.Config{})
+	require.Equal(t, defaultThrottleCircuitCooldown, c.cooldown)
+
+	for range throttleCircuitThreshold - 1 {
+		c.record(true)
+	}
+	c.record(false)
+	c.record(true)
+
+	ok, _ := c.allow()
+	require.True(t, ok)
+}
+
+func TestThrottleCircuit_FailsFastWithRecoverableThrottling(t *testing.T) {
+	circuit := newThrottleCircuit(&config.Config{})
+	calls := 0
+	client := cloudcontrol.New(cloudcontrol.Options{
+		Region:           "us-east-1",
+		Credentials:      credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		HTTPClient:       countingHTTPClient{stub: stubHTTPClient{status: 400, body: `{"__type":"ThrottlingException","Message":"Rate exceeded"}`}, calls: &calls},
+		RetryMaxAttempts: 1,
+		APIOptions:       []func(*middleware.Stack) error{circuit.addMiddleware},
+	})
+	get := func() error {
+		_, err := client.GetResource(context.Background(), &cloudcontrol.GetResourceInput{
+			TypeName:   aws.String("AWS::S3::Bucket"),
+			Identifier: aws.String("b"),
+		})
+		return err
+	}
+
+	for range throttleCircuitThreshold {
+		require.Error(t, get())
+	}
+	require.Equal(t, throttleCircuitThreshold, calls)
+
+	err := get()
+	require.Error(t, err)
+	require.Equal(t, throttleCircuitThreshold, calls, "open circuit must not reach AWS")
+	require.Contains(t, err.Error(), "circuit open")
+	code, ok := helper.HandleCloudControlError(err)
+	require.True(t, ok)
+	require.True(t, resource.IsRecoverable(resource.OperationErrorCode(code)))
+}
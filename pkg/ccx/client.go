@@ -10,20 +10,22 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
 	cctypes "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
 	"github.com/platform-engineering-labs/formae/pkg/plugin"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
 
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/arn"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
 	helper "github.com/platform-engineering-labs/formae-plugin-aws/pkg/helper"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/props"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ptr"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/schema"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/status"
 )
 
@@ -42,15 +44,36 @@ type cloudControlAPI interface {
 	GetResource(ctx context.Context, params *cloudcontrol.GetResourceInput, optFns ...func(*cloudcontrol.Options)) (*cloudcontrol.GetResourceOutput, error)
 	GetResourceRequestStatus(ctx context.Context, params *cloudcontrol.GetResourceRequestStatusInput, optFns ...func(*cloudcontrol.Options)) (*cloudcontrol.GetResourceRequestStatusOutput, error)
 	ListResources(ctx context.Context, params *cloudcontrol.ListResourcesInput, optFns ...func(*cloudcontrol.Options)) (*cloudcontrol.ListResourcesOutput, error)
+	ListResourceRequests(ctx context.Context, params *cloudcontrol.ListResourceRequestsInput, optFns ...func(*cloudcontrol.Options)) (*cloudcontrol.ListResourceRequestsOutput, error)
+	CancelResourceRequest(ctx context.Context, params *cloudcontrol.CancelResourceRequestInput, optFns ...func(*cloudcontrol.Options)) (*cloudcontrol.CancelResourceRequestOutput, error)
+}
+
+// schemaSource provides CloudFormation registry schemas for resource types.
+type schemaSource interface {
+	Get(ctx context.Context, typeName string) (*schema.Schema, error)
 }
 
 type Client struct {
-	api cloudControlAPI
+	api     cloudControlAPI
+	schemas schemaSource
+	// prefetched holds reads done ahead of time by Prefetch.
+	prefetched prefetchCache
+	// tokens holds the ClientTokens of creates that haven't finished.
+	tokens clientTokens
+	// defaultTags are merged into the tags of every taggable resource on
+	// Create and Update.
+	defaultTags map[string]string
+	// tagUpdateMode is the target's TagUpdateMode.
+	tagUpdateMode string
+	// cancelAfter is how long a request may stay pending or in progress
+	// before StatusResource cancels it; zero never cancels.
+	cancelAfter time.Duration
 }
 
+// clientPool holds one Client per distinct target config.
+var clientPool config.Pool[*Client]
+
 var IgnoredFields = map[string][]string{
-	"AWS::EC2::SecurityGroup":                      {"$.SecurityGroupEgress", "$.SecurityGroupIngress"},
-	"AWS::IAM::Role":                               {"$.Policies"},
 	"AWS::ElasticBeanstalk::ConfigurationTemplate": {"$.OptionSettings"},
 	// Targets is populated at runtime by ECS Services (and other consumers
 	// calling register-targets); LoadBalancerArns is populated when a
@@ -62,6 +85,31 @@ var IgnoredFields = map[string][]string{
 	"AWS::ElasticLoadBalancingV2::TargetGroup": {"$.Targets", "$.LoadBalancerArns"},
 }
 
+// ReadOnlyFields lists, as JSON pointers, properties that are outputs of a
+// resource. Update patches touching them are dropped, since CloudControl
+// rejects them. Registry schemas extend this list at runtime; the entries here
+// keep the common cases working when cloudformation:DescribeType isn't
+// permitted.
+var ReadOnlyFields = map[string][]string{
+	"AWS::IAM::Role":        {"/Arn", "/RoleId"},
+	"AWS::Lambda::Function": {"/Arn", "/SnapStartResponse"},
+	"AWS::S3::Bucket":       {"/Arn", "/DomainName", "/DualStackDomainName", "/RegionalDomainName", "/WebsiteURL"},
+	"AWS::SNS::Topic":       {"/TopicArn"},
+	"AWS::SQS::Queue":       {"/Arn", "/QueueUrl"},
+}
+
+// WriteOnlyFields lists, as JSON pointers, properties CloudControl accepts but
+// never returns from a read. Update patches touching them need the replace→add
+// treatment in transformWriteOnlyPatch. Registry schemas extend this list at
+// runtime; the entries here keep the common cases working when
+// cloudformation:DescribeType isn't permitted.
+var WriteOnlyFields = map[string][]string{
+	"AWS::SecretsManager::Secret": {"/SecretString"},
+	"AWS::RDS::DBCluster":         {"/MasterUserPassword"},
+	"AWS::RDS::DBInstance":        {"/MasterUserPassword"},
+	"AWS::IAM::User":              {"/LoginProfile/Password"},
+}
+
 // normalizeCompositeIdentifier fixes inconsistencies in CloudControl composite
 // identifiers. CC Create/Status returns full ARNs in composite parts (e.g.
 // "service-arn|cluster-arn") but CC List returns short names (e.g.
@@ -74,7 +122,7 @@ func normalizeCompositeIdentifier(identifier string) string {
 		return identifier
 	}
 	for i := 1; i < len(parts); i++ {
-		if strings.HasPrefix(parts[i], "arn:aws:") {
+		if arn.IsArn(parts[i]) {
 			// Extract the resource name from the ARN (last segment after /)
 			if idx := strings.LastIndex(parts[i], "/"); idx >= 0 {
 				parts[i] = parts[i][idx+1:]
@@ -84,36 +132,81 @@ func normalizeCompositeIdentifier(identifier string) string {
 	return strings.Join(parts, "|")
 }
 
+// NewClient returns the Client for cfg. Clients are pooled by target config so
+// operations against the same target share credentials (including assumed-role
+// sessions), HTTP connections and retryer state such as the adaptive rate
+// limiter, rather than rebuilding them on every call.
 func NewClient(cfg *config.Config) (*Client, error) {
+	return clientPool.Get(cfg, newClient)
+}
+
+func newClient(cfg *config.Config) (*Client, error) {
 	awsCfg, err := cfg.ToAwsConfig(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("loading AWS config: %w", err)
 	}
 
-	// Create Cloud Control Client with custom retry configuration for throttling.
-	// AWS CloudControl API has strict rate limits, so we use:
-	// - Fewer max attempts (let PluginOperator handle retries at a higher level)
-	// - Longer max backoff to give AWS time to recover from throttling
-	retryer := retry.NewStandard(func(o *retry.StandardOptions) {
-		o.MaxAttempts = 2               // Reduce from default 3 to fail faster to PluginOperator
-		o.MaxBackoff = 30 * time.Second // Allow longer backoff for throttling
-	})
+	retryer, err := newRetryer(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	circuit := newThrottleCircuit(cfg)
+
+	var interceptors []Interceptor
+	if cfg.LogCloudControlCalls {
+		interceptors = append(interceptors, logCalls)
+	}
 
 	return &Client{
 		api: cloudcontrol.NewFromConfig(awsCfg, func(o *cloudcontrol.Options) {
 			o.Retryer = retryer
+			if len(interceptors) > 0 {
+				o.APIOptions = append(o.APIOptions, interceptMiddleware(interceptors...))
+			}
+			o.APIOptions = append(o.APIOptions, circuit.addMiddleware)
 		}),
+		schemas:       schema.NewRegistry(awsCfg),
+		defaultTags:   cfg.DefaultTags,
+		tagUpdateMode: cfg.TagUpdateMode,
+		cancelAfter:   time.Duration(cfg.CancelRequestsAfterSeconds) * time.Second,
 	}, nil
 }
 
+// schemaFor returns the registry schema for typeName, or nil if none is
+// available. Schema-driven handling is an improvement on top of the existing
+// behaviour rather than a requirement, so a failed lookup (e.g. a role without
+// cloudformation:DescribeType) is logged and the operation proceeds without it.
+func (c *Client) schemaFor(ctx context.Context, typeName string) *schema.Schema {
+	if c.schemas == nil {
+		return nil
+	}
+	s, err := c.schemas.Get(ctx, typeName)
+	if err != nil {
+		plugin.LoggerFromContext(ctx).Warn("resource schema unavailable, continuing without it",
+			"resourceType", typeName,
+			"error", err)
+		return nil
+	}
+	return s
+}
+
 // CreateResource creates a resource using CloudControl with full request handling
 func (c *Client) CreateResource(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
 	resourceProps := request.Properties
 
+	if injected := c.injectedTags(request.Label); len(injected) > 0 {
+		var err error
+		resourceProps, err = withDefaultTags(resourceProps, c.schemaFor(ctx, request.ResourceType), injected)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Handle map tags transformation if required
 	if props.RequiresMapTags(request.ResourceType) {
 		var properties map[string]any
-		if err := json.Unmarshal(request.Properties, &properties); err != nil {
+		if err := json.Unmarshal(resourceProps, &properties); err != nil {
 			return nil, err
 		}
 
@@ -137,16 +230,46 @@ func (c *Client) CreateResource(ctx context.Context, request *resource.CreateReq
 		return nil, fmt.Errorf("failed to strip empty collections: %w", err)
 	}
 
+	resourceSchema := c.schemaFor(ctx, request.ResourceType)
+	violations, err := schemaViolations(resourceSchema, resourceProps)
+	if err != nil {
+		return nil, err
+	}
+	if len(violations) > 0 {
+		return &resource.CreateResult{ProgressResult: invalidRequest(resource.OperationCreate, "", violations)}, nil
+	}
+
+	// readOnly properties are outputs; CloudControl rejects a desired state
+	// that sets them.
+	if s := resourceSchema; s != nil && len(s.ReadOnly) > 0 {
+		var properties map[string]any
+		if err = json.Unmarshal(resourceProps, &properties); err != nil {
+			return nil, err
+		}
+		s.StripReadOnly(properties)
+		if resourceProps, err = json.Marshal(properties); err != nil {
+			return nil, fmt.Errorf("failed to marshal properties: %w", err)
+		}
+	}
+
 	result, err := c.api.CreateResource(ctx, &cloudcontrol.CreateResourceInput{
+		ClientToken:  ptr.Of(c.tokens.issue(request, resourceProps)),
 		DesiredState: ptr.Of(string(resourceProps)),
 		TypeName:     &request.ResourceType,
 	})
 	if err != nil {
 		if pr, ok := classifyCloudControlError(err, resource.OperationCreate); ok {
+			c.tokens.done(request, resourceProps)
 			return &resource.CreateResult{ProgressResult: pr}, nil
 		}
 		return nil, err
 	}
+	if result.ProgressEvent.OperationStatus == cctypes.OperationStatusSuccess ||
+		result.ProgressEvent.OperationStatus == cctypes.OperationStatusFailed {
+		c.tokens.done(request, resourceProps)
+	} else if result.ProgressEvent.RequestToken != nil {
+		c.tokens.accepted(request, resourceProps, *result.ProgressEvent.RequestToken)
+	}
 
 	identifier := ""
 	if result.ProgressEvent.Identifier != nil {
@@ -175,8 +298,20 @@ func (c *Client) CreateResource(ctx context.Context, request *resource.CreateReq
 
 // UpdateResource updates a resource using CloudControl with full request handling
 func (c *Client) UpdateResource(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	// A prefetched read of this resource predates the change.
+	c.prefetched.take(request.ResourceType, request.NativeID)
+
+	resourceSchema := c.schemaFor(ctx, request.ResourceType)
+	violations, err := schemaViolations(resourceSchema, request.DesiredProperties)
+	if err != nil {
+		return nil, err
+	}
+	if len(violations) > 0 {
+		return &resource.UpdateResult{ProgressResult: invalidRequest(resource.OperationUpdate, request.NativeID, violations)}, nil
+	}
+
 	// Check if resource exists first
-	_, err := c.api.GetResource(ctx, &cloudcontrol.GetResourceInput{
+	current, err := c.api.GetResource(ctx, &cloudcontrol.GetResourceInput{
 		Identifier: &request.NativeID,
 		TypeName:   &request.ResourceType,
 	})
@@ -187,8 +322,59 @@ func (c *Client) UpdateResource(ctx context.Context, request *resource.UpdateReq
 		return nil, err
 	}
 
+	patchDoc := request.PatchDocument
+
+	// Callers that only send full desired state get a patch computed from the
+	// prior model. Changing a createOnly property can't be done in place, so
+	// report NotUpdatable instead of letting CloudControl reject the patch.
+	if patchDoc == nil && len(request.DesiredProperties) > 0 {
+		injected := c.injectedTags(request.Label)
+		desired, err := withDefaultTags(request.DesiredProperties, resourceSchema, injected)
+		if err != nil {
+			return nil, err
+		}
+		// Reads strip the injected tags, so prior lacks them too; add them back
+		// so an update that leaves the tags alone doesn't rewrite them.
+		prior := request.PriorProperties
+		if len(prior) > 0 {
+			if prior, err = withDefaultTags(prior, resourceSchema, injected); err != nil {
+				return nil, err
+			}
+		}
+		computed, createOnly, err := computePatch(prior, desired, resourceSchema)
+		if err != nil {
+			return nil, err
+		}
+		if len(createOnly) > 0 {
+			return &resource.UpdateResult{
+				ProgressResult: &resource.ProgressResult{
+					Operation:       resource.OperationUpdate,
+					OperationStatus: resource.OperationStatusFailure,
+					NativeID:        request.NativeID,
+					StatusMessage:   fmt.Sprintf("createOnly properties changed, resource must be replaced: %s", strings.Join(createOnly, ", ")),
+					ErrorCode:       resource.OperationErrorCodeNotUpdatable,
+				},
+			}, nil
+		}
+		patchDoc = &computed
+	} else if patchDoc != nil {
+		withDefaults, err := patchWithDefaultTags(*patchDoc, resourceSchema, c.injectedTags(request.Label))
+		if err != nil {
+			return nil, err
+		}
+		patchDoc = &withDefaults
+	}
+
+	if c.tagUpdateMode == config.TagUpdateModeMerge && patchDoc != nil && current.ResourceDescription != nil {
+		merged, err := patchPreservingTags(*patchDoc, resourceSchema, aws.ToString(current.ResourceDescription.Properties), request.PriorProperties, c.injectedTags(request.Label))
+		if err != nil {
+			return nil, err
+		}
+		patchDoc = &merged
+	}
+
 	// For resources where tags are maps, we do not support updates with patch documents
-	if props.RequiresMapTags(request.ResourceType) && request.PatchDocument != nil {
+	if props.RequiresMapTags(request.ResourceType) && patchDoc != nil {
 		errMsg := "update operations for resources with map tags are not supported"
 		return &resource.UpdateResult{
 			ProgressResult: &resource.ProgressResult{
@@ -200,8 +386,6 @@ func (c *Client) UpdateResource(ctx context.Context, request *resource.UpdateReq
 		}, errors.New(errMsg)
 	}
 
-	patchDoc := request.PatchDocument
-
 	// Filter out "add" operations with empty array/map values from the patch
 	// document. These arise from the PKL schema rendering unset nullable
 	// Listing/Mapping fields as []/{}. CloudControl may reject them (e.g.
@@ -213,10 +397,18 @@ func (c *Client) UpdateResource(ctx context.Context, request *resource.UpdateReq
 		}
 	}
 
-	if request.ResourceType == "AWS::SecretsManager::Secret" && patchDoc != nil {
-		transformedPatch, err := transformSecretStringPatch([]byte(*patchDoc))
+	if readOnly := readOnlyPaths(request.ResourceType, resourceSchema); len(readOnly) > 0 && patchDoc != nil {
+		filtered, err := filterReadOnlyOps(*patchDoc, &schema.Schema{ReadOnly: readOnly})
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter readOnly patch operations: %w", err)
+		}
+		patchDoc = &filtered
+	}
+
+	if writeOnly := writeOnlyPaths(request.ResourceType, resourceSchema); len(writeOnly) > 0 && patchDoc != nil {
+		transformedPatch, err := transformWriteOnlyPatch([]byte(*patchDoc), &schema.Schema{WriteOnly: writeOnly})
 		if err != nil {
-			return nil, fmt.Errorf("failed to transform SecretString patch: %w", err)
+			return nil, fmt.Errorf("failed to transform writeOnly patch: %w", err)
 		}
 		patchDoc = ptr.Of(string(transformedPatch))
 	}
@@ -227,6 +419,9 @@ func (c *Client) UpdateResource(ctx context.Context, request *resource.UpdateReq
 		TypeName:      ptr.Of(request.ResourceType),
 	})
 	if err != nil {
+		if pr := c.resumeInFlight(ctx, err, request.ResourceType, request.NativeID, resource.OperationUpdate); pr != nil {
+			return &resource.UpdateResult{ProgressResult: pr}, nil
+		}
 		if pr, ok := classifyCloudControlError(err, resource.OperationUpdate); ok {
 			return &resource.UpdateResult{ProgressResult: pr}, nil
 		}
@@ -258,11 +453,17 @@ func (c *Client) UpdateResource(ctx context.Context, request *resource.UpdateReq
 
 // DeleteResource deletes a resource using CloudControl with full request handling
 func (c *Client) DeleteResource(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	// A prefetched read of this resource predates the change.
+	c.prefetched.take(request.ResourceType, request.NativeID)
+
 	result, err := c.api.DeleteResource(ctx, &cloudcontrol.DeleteResourceInput{
 		Identifier: &request.NativeID,
 		TypeName:   ptr.Of(request.ResourceType),
 	})
 	if err != nil {
+		if pr := c.resumeInFlight(ctx, err, request.ResourceType, request.NativeID, resource.OperationDelete); pr != nil {
+			return &resource.DeleteResult{ProgressResult: pr}, nil
+		}
 		if pr, ok := classifyCloudControlError(err, resource.OperationDelete); ok {
 			return &resource.DeleteResult{ProgressResult: pr}, nil
 		}
@@ -295,6 +496,14 @@ func (c *Client) DeleteResource(ctx context.Context, request *resource.DeleteReq
 
 // ReadResource reads a resource using CloudControl with full request handling
 func (c *Client) ReadResource(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	// A read with prior properties comes from a sync of managed state, not
+	// from discovery, and must see current state.
+	if len(request.PriorProperties) == 0 {
+		if result, ok := c.prefetched.take(request.ResourceType, request.NativeID); ok {
+			return result, nil
+		}
+	}
+
 	result, err := c.api.GetResource(ctx, &cloudcontrol.GetResourceInput{
 		Identifier: &request.NativeID,
 		TypeName:   ptr.Of(request.ResourceType),
@@ -326,6 +535,18 @@ func (c *Client) ReadResource(ctx context.Context, request *resource.ReadRequest
 		return nil, fmt.Errorf("failed to strip ignored fields: %w", err)
 	}
 
+	// Write-only properties are carried over from the prior model using the
+	// same merged list as updates, so the hand-maintained entries still apply
+	// when the registry schema can't be fetched.
+	if len(request.PriorProperties) > 0 {
+		var prior map[string]any
+		if err = json.Unmarshal(request.PriorProperties, &prior); err == nil {
+			s := c.schemaFor(ctx, request.ResourceType)
+			(&schema.Schema{WriteOnly: writeOnlyPaths(request.ResourceType, s)}).CarryWriteOnly(prior, propsMap)
+			stripInjectedTags(propsMap, s, prior, c.defaultTags)
+		}
+	}
+
 	// CloudControl injects DestinationConfig:{OnFailure:{},OnSuccess:{}} into
 	// every AWS::Lambda::EventInvokeConfig read, even when the caller never set
 	// it. AWS requires Destination inside OnFailure/OnSuccess, so an empty {}
@@ -359,7 +580,12 @@ func (c *Client) StatusResource(ctx context.Context, request *resource.StatusReq
 		return nil, err
 	}
 
+	if c.overdue(result.ProgressEvent) {
+		return c.cancelOverdue(ctx, request.RequestID, result.ProgressEvent)
+	}
+
 	operation, operationStatus := status.FromProgress(result.ProgressEvent)
+	statusMessage := withHookFailures(aws.ToString(result.ProgressEvent.StatusMessage), result.HooksProgressEvent)
 	identifier := ""
 	if result.ProgressEvent.Identifier != nil {
 		identifier = normalizeCompositeIdentifier(*result.ProgressEvent.Identifier)
@@ -392,6 +618,11 @@ func (c *Client) StatusResource(ctx context.Context, request *resource.StatusReq
 		operationStatus = resource.OperationStatusInProgress
 	}
 
+	switch operationStatus {
+	case resource.OperationStatusSuccess, resource.OperationStatusFailure, resource.OperationStatusCanceled:
+		c.tokens.finish(request.RequestID)
+	}
+
 	// If the resource is not found, we return a success status when it is a delete operation
 	if result.ProgressEvent.Operation == cctypes.OperationDelete && result.ProgressEvent.ErrorCode == cctypes.HandlerErrorCodeNotFound {
 		return &resource.StatusResult{
@@ -400,7 +631,7 @@ func (c *Client) StatusResource(ctx context.Context, request *resource.StatusReq
 				OperationStatus: resource.OperationStatusSuccess,
 				RequestID:       request.RequestID,
 				NativeID:        identifier,
-				StatusMessage:   aws.ToString(result.ProgressEvent.StatusMessage),
+				StatusMessage:   statusMessage,
 				ErrorCode:       resource.OperationErrorCode(result.ProgressEvent.ErrorCode)},
 		}, nil
 	}
@@ -414,7 +645,7 @@ func (c *Client) StatusResource(ctx context.Context, request *resource.StatusReq
 		plugin.LoggerFromContext(ctx).Warn("StatusResource: CCAPI ProgressEvent reports Failure",
 			"operation", string(result.ProgressEvent.Operation),
 			"errorCode", string(result.ProgressEvent.ErrorCode),
-			"statusMessage", aws.ToString(result.ProgressEvent.StatusMessage),
+			"statusMessage", statusMessage,
 			"typeName", aws.ToString(result.ProgressEvent.TypeName),
 			"requestToken", aws.ToString(result.ProgressEvent.RequestToken),
 			"identifier", identifier)
@@ -426,7 +657,7 @@ func (c *Client) StatusResource(ctx context.Context, request *resource.StatusReq
 			OperationStatus: operationStatus,
 			RequestID:       request.RequestID,
 			NativeID:        identifier,
-			StatusMessage:   aws.ToString(result.ProgressEvent.StatusMessage),
+			StatusMessage:   statusMessage,
 			ErrorCode:       resource.OperationErrorCode(result.ProgressEvent.ErrorCode),
 		},
 	}
@@ -438,7 +669,7 @@ func (c *Client) StatusResource(ctx context.Context, request *resource.StatusReq
 	// exponential backoff so the agent doesn't persist a stale snapshot.
 	if operationStatus == resource.OperationStatusSuccess && result.ProgressEvent.Operation != cctypes.OperationDelete {
 		typeName := *result.ProgressEvent.TypeName
-		readResult, readErr := retryRead(ctx, retryOpts{}, "StatusResource:"+typeName,
+		readResult, readErr := retryRead(ctx, retryOpts{NotFoundAttempts: postSuccessNotFoundAttempts}, "StatusResource:"+typeName,
 			func(ctx context.Context) (*resource.ReadResult, error) {
 				return readFunc(ctx, &resource.ReadRequest{
 					NativeID:     identifier,
@@ -472,13 +703,116 @@ func (c *Client) StatusResource(ctx context.Context, request *resource.StatusReq
 	return statusResult, nil
 }
 
+// CancelResource cancels a pending or in-progress CloudControl request so an
+// aborted deploy doesn't leave the operation running against AWS. CloudControl
+// only cancels requests that have not started provisioning yet or whose
+// handler supports cancellation; the returned ProgressResult reflects the
+// request's state after the cancel was accepted (typically CANCEL_IN_PROGRESS,
+// which callers keep polling through StatusResource until it is Canceled).
+// A request token CloudControl no longer knows about is reported as NotFound
+// rather than as an error, since there is nothing left to cancel.
+func (c *Client) CancelResource(ctx context.Context, requestID string) (*resource.ProgressResult, error) {
+	result, err := c.api.CancelResourceRequest(ctx, &cloudcontrol.CancelResourceRequestInput{
+		RequestToken: &requestID,
+	})
+	if err != nil {
+		var notFound *cctypes.RequestTokenNotFoundException
+		if errors.As(err, &notFound) {
+			return &resource.ProgressResult{
+				OperationStatus: resource.OperationStatusFailure,
+				RequestID:       requestID,
+				StatusMessage:   err.Error(),
+				ErrorCode:       resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to cancel request %s: %w", requestID, err)
+	}
+
+	operation, operationStatus := status.FromProgress(result.ProgressEvent)
+	identifier := ""
+	if result.ProgressEvent.Identifier != nil {
+		identifier = normalizeCompositeIdentifier(*result.ProgressEvent.Identifier)
+	}
+
+	return &resource.ProgressResult{
+		Operation:       operation,
+		OperationStatus: operationStatus,
+		RequestID:       requestID,
+		NativeID:        identifier,
+		StatusMessage:   aws.ToString(result.ProgressEvent.StatusMessage),
+		ErrorCode:       resource.OperationErrorCode(result.ProgressEvent.ErrorCode),
+	}, nil
+}
+
+// now is the clock used to age in-progress requests; tests override it.
+var now = time.Now
+
+// overdue reports whether a request is still pending or in progress longer
+// than the target's CancelRequestsAfterSeconds since CloudControl accepted it.
+func (c *Client) overdue(event *cctypes.ProgressEvent) bool {
+	if c.cancelAfter <= 0 || event == nil || event.EventTime == nil {
+		return false
+	}
+	if event.OperationStatus != cctypes.OperationStatusPending && event.OperationStatus != cctypes.OperationStatusInProgress {
+		return false
+	}
+	return now().Sub(*event.EventTime) > c.cancelAfter
+}
+
+// cancelOverdue cancels a request that has outlived cancelAfter, so a request
+// stuck in progress doesn't keep provisioning after the operator has stopped
+// waiting for it. Status keeps being polled until CloudControl reports the
+// request Canceled. If CloudControl refuses to cancel it, for example because
+// the handler doesn't support cancellation, the request is reported as still
+// in progress and left to finish.
+func (c *Client) cancelOverdue(ctx context.Context, requestID string, event *cctypes.ProgressEvent) (*resource.StatusResult, error) {
+	progress, err := c.CancelResource(ctx, requestID)
+	if err != nil || progress.ErrorCode == resource.OperationErrorCodeNotFound {
+		plugin.LoggerFromContext(ctx).Warn("StatusResource: could not cancel overdue request",
+			"requestToken", requestID,
+			"typeName", aws.ToString(event.TypeName),
+			"error", err)
+		operation, operationStatus := status.FromProgress(event)
+		progress = &resource.ProgressResult{
+			Operation:       operation,
+			OperationStatus: operationStatus,
+			RequestID:       requestID,
+			StatusMessage:   aws.ToString(event.StatusMessage),
+		}
+		if event.Identifier != nil {
+			progress.NativeID = normalizeCompositeIdentifier(*event.Identifier)
+		}
+		return &resource.StatusResult{ProgressResult: progress}, nil
+	}
+
+	plugin.LoggerFromContext(ctx).Warn("StatusResource: cancelled overdue request",
+		"requestToken", requestID,
+		"typeName", aws.ToString(event.TypeName),
+		"startedAt", aws.ToTime(event.EventTime))
+	progress.StatusMessage = fmt.Sprintf("cancelling request still in progress after %s", c.cancelAfter)
+	return &resource.StatusResult{ProgressResult: progress}, nil
+}
+
+// Ping makes the cheapest CloudControl call there is, a one-item
+// ListResourceRequests, to check that the target's credentials are accepted
+// by CloudControl in its region. It changes nothing.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.api.ListResourceRequests(ctx, &cloudcontrol.ListResourceRequestsInput{
+		MaxResults: ptr.Of(int32(1)),
+	})
+	if err != nil {
+		return fmt.Errorf("cloudcontrol:ListResourceRequests failed: %w", err)
+	}
+	return nil
+}
+
 // populateResourceProperties performs a post-success Read to populate
 // ResourceProperties on a ProgressResult. Used when CloudControl returns
 // synchronous Success (no async polling, so StatusResource's Read loop
 // doesn't run). Wraps the call in retryRead so transient throttling
 // surfaced as ErrorCode doesn't leave the agent with a stale snapshot.
 func (c *Client) populateResourceProperties(ctx context.Context, pr *resource.ProgressResult, identifier, resourceType string) {
-	readResult, err := retryRead(ctx, retryOpts{}, "populateResourceProperties:"+resourceType,
+	readResult, err := retryRead(ctx, retryOpts{NotFoundAttempts: postSuccessNotFoundAttempts}, "populateResourceProperties:"+resourceType,
 		func(ctx context.Context) (*resource.ReadResult, error) {
 			return c.ReadResource(ctx, &resource.ReadRequest{
 				NativeID:     identifier,
@@ -520,9 +854,74 @@ func (c *Client) ListResources(ctx context.Context, input *cloudcontrol.ListReso
 		})
 }
 
-// transformSecretStringPatch transforms replace operations to add operations for SecretString
-// AWS CloudControl requires writeOnlyProperties like SecretString to use 'add' operation
-func transformSecretStringPatch(patchDoc []byte) ([]byte, error) {
+// filterReadOnlyOps drops patch operations that target readOnly properties.
+// Those are outputs of the resource, so a diff against them is never something
+// CloudControl can apply.
+func filterReadOnlyOps(patchDoc string, s *schema.Schema) (string, error) {
+	var ops []map[string]any
+	if err := json.Unmarshal([]byte(patchDoc), &ops); err != nil {
+		return patchDoc, err
+	}
+
+	kept := ops[:0]
+	for _, op := range ops {
+		if path, ok := op["path"].(string); ok && s.IsReadOnly(path) {
+			continue
+		}
+		kept = append(kept, op)
+	}
+	if len(kept) == len(ops) {
+		return patchDoc, nil
+	}
+
+	out, err := json.Marshal(kept)
+	if err != nil {
+		return patchDoc, err
+	}
+	return string(out), nil
+}
+
+// WriteOnlyProperties returns, as JSON pointers, the properties of
+// resourceType that a read never returns.
+func (c *Client) WriteOnlyProperties(ctx context.Context, resourceType string) []string {
+	return writeOnlyPaths(resourceType, c.schemaFor(ctx, resourceType))
+}
+
+// readOnlyPaths returns the readOnly property pointers for resourceType: the
+// hand-maintained ReadOnlyFields entry merged with the registry schema's list
+// when one is available.
+func readOnlyPaths(resourceType string, s *schema.Schema) []string {
+	paths := slices.Clone(ReadOnlyFields[resourceType])
+	if s != nil {
+		for _, p := range s.ReadOnly {
+			if !slices.Contains(paths, p) {
+				paths = append(paths, p)
+			}
+		}
+	}
+	return paths
+}
+
+// writeOnlyPaths returns the writeOnly property pointers for resourceType: the
+// hand-maintained WriteOnlyFields entry merged with the registry schema's list
+// when one is available.
+func writeOnlyPaths(resourceType string, s *schema.Schema) []string {
+	paths := slices.Clone(WriteOnlyFields[resourceType])
+	if s != nil {
+		for _, p := range s.WriteOnly {
+			if !slices.Contains(paths, p) {
+				paths = append(paths, p)
+			}
+		}
+	}
+	return paths
+}
+
+// transformWriteOnlyPatch turns replace operations on writeOnly properties into
+// add operations. CloudControl applies the patch to the resource's current
+// model, which never contains writeOnly values, so a replace of e.g.
+// /SecretString or /MasterUserPassword fails because the target doesn't exist.
+func transformWriteOnlyPatch(patchDoc []byte, s *schema.Schema) ([]byte, error) {
 	if len(patchDoc) == 0 {
 		return patchDoc, nil
 	}
@@ -535,7 +934,7 @@ func transformSecretStringPatch(patchDoc []byte) ([]byte, error) {
 	modified := false
 	for i, patch := range patches {
 		if op, ok := patch["op"].(string); ok && op == "replace" {
-			if path, ok := patch["path"].(string); ok && path == "/SecretString" {
+			if path, ok := patch["path"].(string); ok && s.IsWriteOnly(path) {
 				patches[i]["op"] = "add"
 				modified = true
 			}
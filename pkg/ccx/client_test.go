@@ -13,6 +13,7 @@ import (
 	"errors"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
@@ -22,7 +23,9 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ptr"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/schema"
 )
 
 func TestStripIgnoredFields(t *testing.T) {
@@ -80,6 +83,22 @@ func (m *mockCloudControlAPI) GetResourceRequestStatus(ctx context.Context, para
 	return args.Get(0).(*cloudcontrol.GetResourceRequestStatusOutput), args.Error(1)
 }
 
+func (m *mockCloudControlAPI) CancelResourceRequest(ctx context.Context, params *cloudcontrol.CancelResourceRequestInput, optFns ...func(*cloudcontrol.Options)) (*cloudcontrol.CancelResourceRequestOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudcontrol.CancelResourceRequestOutput), args.Error(1)
+}
+
+func (m *mockCloudControlAPI) ListResourceRequests(ctx context.Context, params *cloudcontrol.ListResourceRequestsInput, optFns ...func(*cloudcontrol.Options)) (*cloudcontrol.ListResourceRequestsOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudcontrol.ListResourceRequestsOutput), args.Error(1)
+}
+
 func (m *mockCloudControlAPI) ListResources(ctx context.Context, params *cloudcontrol.ListResourcesInput, optFns ...func(*cloudcontrol.Options)) (*cloudcontrol.ListResourcesOutput, error) {
 	args := m.Called(ctx, params)
 	return args.Get(0).(*cloudcontrol.ListResourcesOutput), args.Error(1)
@@ -573,6 +592,11 @@ func TestNormalizeCompositeIdentifier(t *testing.T) {
 			input:    "arn:aws:lambda:us-east-1:123456:function:my-func|arn:aws:lambda:us-east-1:123456:function:my-func/$LATEST",
 			expected: "arn:aws:lambda:us-east-1:123456:function:my-func|$LATEST",
 		},
+		{
+			name:     "composite with GovCloud ARN second part normalized",
+			input:    "arn:aws-us-gov:ecs:us-gov-west-1:123456:service/my-cluster/my-svc|arn:aws-us-gov:ecs:us-gov-west-1:123456:cluster/my-cluster",
+			expected: "arn:aws-us-gov:ecs:us-gov-west-1:123456:service/my-cluster/my-svc|my-cluster",
+		},
 	}
 
 	for _, tt := range tests {
@@ -849,3 +873,329 @@ func TestStatusResource_NotStabilizedRemap_DoesNotLog(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, buf.String(), "NotStabilized remaps to InProgress and must not warn-log")
 }
+
+func TestNewClient_PooledByTargetConfig(t *testing.T) {
+	a, err := NewClient(&config.Config{Region: "us-east-1"})
+	require.NoError(t, err)
+	b, err := NewClient(&config.Config{Region: "us-east-1"})
+	require.NoError(t, err)
+	c, err := NewClient(&config.Config{Region: "eu-west-1"})
+	require.NoError(t, err)
+
+	require.Same(t, a, b)
+	require.NotSame(t, a, c)
+}
+
+func TestCancelResource_InProgress(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI}
+
+	mockAPI.On("CancelResourceRequest", mock.Anything, mock.MatchedBy(func(in *cloudcontrol.CancelResourceRequestInput) bool {
+		return *in.RequestToken == "req-token-123"
+	})).Return(&cloudcontrol.CancelResourceRequestOutput{
+		ProgressEvent: &cctypes.ProgressEvent{
+			Operation:       cctypes.OperationCreate,
+			OperationStatus: cctypes.OperationStatusCancelInProgress,
+			RequestToken:    ptr.Of("req-token-123"),
+			TypeName:        ptr.Of("AWS::RDS::DBCluster"),
+		},
+	}, nil)
+
+	result, err := client.CancelResource(context.Background(), "req-token-123")
+
+	require.NoError(t, err)
+	require.Equal(t, resource.OperationCreate, result.Operation)
+	require.Equal(t, resource.OperationStatusInProgress, result.OperationStatus)
+	require.Equal(t, "req-token-123", result.RequestID)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestCancelResource_UnknownTokenReportsNotFound(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI}
+
+	mockAPI.On("CancelResourceRequest", mock.Anything, mock.Anything).
+		Return(nil, &cctypes.RequestTokenNotFoundException{Message: ptr.Of("token not found")})
+
+	result, err := client.CancelResource(context.Background(), "gone")
+
+	require.NoError(t, err)
+	require.Equal(t, resource.OperationStatusFailure, result.OperationStatus)
+	require.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+}
+
+func TestStatusResource_CancelsOverdueRequest(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI, cancelAfter: time.Hour}
+	started := now().Add(-2 * time.Hour)
+
+	mockAPI.On("GetResourceRequestStatus", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceRequestStatusOutput{
+		ProgressEvent: &cctypes.ProgressEvent{
+			Operation:       cctypes.OperationCreate,
+			OperationStatus: cctypes.OperationStatusInProgress,
+			RequestToken:    ptr.Of("req-token-123"),
+			TypeName:        ptr.Of("AWS::RDS::DBCluster"),
+			EventTime:       &started,
+		},
+	}, nil)
+	mockAPI.On("CancelResourceRequest", mock.Anything, mock.Anything).Return(&cloudcontrol.CancelResourceRequestOutput{
+		ProgressEvent: &cctypes.ProgressEvent{
+			Operation:       cctypes.OperationCreate,
+			OperationStatus: cctypes.OperationStatusCancelInProgress,
+			RequestToken:    ptr.Of("req-token-123"),
+		},
+	}, nil)
+
+	result, err := client.StatusResource(context.Background(), &resource.StatusRequest{RequestID: "req-token-123"}, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	require.Contains(t, result.ProgressResult.StatusMessage, "cancelling request")
+	mockAPI.AssertExpectations(t)
+}
+
+func TestStatusResource_LeavesRecentRequest(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI, cancelAfter: time.Hour}
+	started := now().Add(-time.Minute)
+
+	mockAPI.On("GetResourceRequestStatus", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceRequestStatusOutput{
+		ProgressEvent: &cctypes.ProgressEvent{
+			Operation:       cctypes.OperationCreate,
+			OperationStatus: cctypes.OperationStatusInProgress,
+			RequestToken:    ptr.Of("req-token-123"),
+			TypeName:        ptr.Of("AWS::RDS::DBCluster"),
+			EventTime:       &started,
+		},
+	}, nil)
+
+	result, err := client.StatusResource(context.Background(), &resource.StatusRequest{RequestID: "req-token-123"}, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	mockAPI.AssertNotCalled(t, "CancelResourceRequest", mock.Anything, mock.Anything)
+}
+
+func TestStatusResource_UncancellableOverdueRequestKeepsPolling(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI, cancelAfter: time.Hour}
+	started := now().Add(-2 * time.Hour)
+
+	mockAPI.On("GetResourceRequestStatus", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceRequestStatusOutput{
+		ProgressEvent: &cctypes.ProgressEvent{
+			Operation:       cctypes.OperationUpdate,
+			OperationStatus: cctypes.OperationStatusInProgress,
+			RequestToken:    ptr.Of("req-token-123"),
+			TypeName:        ptr.Of("AWS::RDS::DBCluster"),
+			Identifier:      ptr.Of("cluster-1"),
+			EventTime:       &started,
+		},
+	}, nil)
+	mockAPI.On("CancelResourceRequest", mock.Anything, mock.Anything).
+		Return(nil, &cctypes.ConcurrentModificationException{Message: ptr.Of("cannot cancel")})
+
+	result, err := client.StatusResource(context.Background(), &resource.StatusRequest{RequestID: "req-token-123"}, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, resource.OperationUpdate, result.ProgressResult.Operation)
+	require.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	require.Equal(t, "cluster-1", result.ProgressResult.NativeID)
+}
+
+func TestCancelResource_OtherErrorsPropagate(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI}
+
+	mockAPI.On("CancelResourceRequest", mock.Anything, mock.Anything).
+		Return(nil, &cctypes.ConcurrentModificationException{Message: ptr.Of("already completed")})
+
+	_, err := client.CancelResource(context.Background(), "done")
+
+	require.Error(t, err)
+}
+
+type staticSchemas map[string]*schema.Schema
+
+func (s staticSchemas) Get(_ context.Context, typeName string) (*schema.Schema, error) {
+	if sc, ok := s[typeName]; ok {
+		return sc, nil
+	}
+	return nil, errors.New("TypeNotFoundException")
+}
+
+func TestUpdateResource_DropsReadOnlyPatchOps(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI, schemas: staticSchemas{
+		"AWS::RDS::DBCluster": {ReadOnly: []string{"/DBClusterArn", "/Endpoint/Address"}},
+	}}
+
+	mockAPI.On("GetResource", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceOutput{}, nil)
+	mockAPI.On("UpdateResource", mock.Anything, mock.MatchedBy(func(in *cloudcontrol.UpdateResourceInput) bool {
+		return *in.PatchDocument == `[{"op":"replace","path":"/Engine","value":"aurora-postgresql"}]`
+	})).Return(&cloudcontrol.UpdateResourceOutput{
+		ProgressEvent: &cctypes.ProgressEvent{
+			OperationStatus: cctypes.OperationStatusInProgress,
+			RequestToken:    ptr.Of("req-token-123"),
+		},
+	}, nil)
+
+	_, err := client.UpdateResource(context.Background(), &resource.UpdateRequest{
+		NativeID:     "db",
+		ResourceType: "AWS::RDS::DBCluster",
+		PatchDocument: ptr.Of(`[{"op":"replace","path":"/DBClusterArn","value":"x"},` +
+			`{"op":"replace","path":"/Engine","value":"aurora-postgresql"},` +
+			`{"op":"add","path":"/Endpoint/Address","value":"y"}]`),
+	})
+
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestReadResource_CarriesWriteOnlyFromPriorProperties(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI, schemas: staticSchemas{
+		"AWS::RDS::DBCluster": {WriteOnly: []string{"/MasterUserPassword"}},
+	}}
+
+	mockAPI.On("GetResource", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceOutput{
+		TypeName: ptr.Of("AWS::RDS::DBCluster"),
+		ResourceDescription: &cctypes.ResourceDescription{
+			Properties: ptr.Of(`{"Engine":"aurora-postgresql"}`),
+		},
+	}, nil)
+
+	result, err := client.ReadResource(context.Background(), &resource.ReadRequest{
+		NativeID:        "db",
+		ResourceType:    "AWS::RDS::DBCluster",
+		PriorProperties: json.RawMessage(`{"Engine":"aurora-postgresql","MasterUserPassword":"hunter2"}`),
+	})
+
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Engine":"aurora-postgresql","MasterUserPassword":"hunter2"}`, result.Properties)
+}
+
+func TestReadResource_SchemaUnavailableFallsBack(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI, schemas: staticSchemas{}}
+
+	mockAPI.On("GetResource", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceOutput{
+		TypeName: ptr.Of("AWS::DocDB::DBCluster"),
+		ResourceDescription: &cctypes.ResourceDescription{
+			Properties: ptr.Of(`{"Engine":"docdb"}`),
+		},
+	}, nil)
+
+	result, err := client.ReadResource(context.Background(), &resource.ReadRequest{
+		NativeID:        "db",
+		ResourceType:    "AWS::DocDB::DBCluster",
+		PriorProperties: json.RawMessage(`{"MasterUserPassword":"hunter2"}`),
+	})
+
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Engine":"docdb"}`, result.Properties)
+}
+
+func TestReadResource_SchemaUnavailableCarriesStaticWriteOnlyFields(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI, schemas: staticSchemas{}}
+
+	mockAPI.On("GetResource", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceOutput{
+		TypeName: ptr.Of("AWS::RDS::DBCluster"),
+		ResourceDescription: &cctypes.ResourceDescription{
+			Properties: ptr.Of(`{"Engine":"aurora-postgresql"}`),
+		},
+	}, nil)
+
+	result, err := client.ReadResource(context.Background(), &resource.ReadRequest{
+		NativeID:        "db",
+		ResourceType:    "AWS::RDS::DBCluster",
+		PriorProperties: json.RawMessage(`{"MasterUserPassword":"hunter2"}`),
+	})
+
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Engine":"aurora-postgresql","MasterUserPassword":"hunter2"}`, result.Properties)
+}
+
+func TestTransformWriteOnlyPatch(t *testing.T) {
+	s := &schema.Schema{WriteOnly: writeOnlyPaths("AWS::IAM::User", nil)}
+	patch := `[{"op":"replace","path":"/LoginProfile/Password","value":"new"},{"op":"replace","path":"/Path","value":"/ops/"}]`
+
+	out, err := transformWriteOnlyPatch([]byte(patch), s)
+
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"op":"add","path":"/LoginProfile/Password","value":"new"},{"op":"replace","path":"/Path","value":"/ops/"}]`, string(out))
+}
+
+func TestWriteOnlyPaths_MergesSchema(t *testing.T) {
+	paths := writeOnlyPaths("AWS::RDS::DBCluster", &schema.Schema{WriteOnly: []string{"/MasterUserPassword", "/ManageMasterUserPassword"}})
+
+	require.Equal(t, []string{"/MasterUserPassword", "/ManageMasterUserPassword"}, paths)
+	require.Empty(t, writeOnlyPaths("AWS::S3::Bucket", nil))
+}
+
+func TestUpdateResource_RDSMasterUserPasswordUsesAdd(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI}
+
+	mockAPI.On("GetResource", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceOutput{}, nil)
+	mockAPI.On("UpdateResource", mock.Anything, mock.MatchedBy(func(in *cloudcontrol.UpdateResourceInput) bool {
+		return *in.PatchDocument == `[{"op":"add","path":"/MasterUserPassword","value":"rotated"}]`
+	})).Return(&cloudcontrol.UpdateResourceOutput{
+		ProgressEvent: &cctypes.ProgressEvent{
+			OperationStatus: cctypes.OperationStatusInProgress,
+			RequestToken:    ptr.Of("req-token-123"),
+		},
+	}, nil)
+
+	_, err := client.UpdateResource(context.Background(), &resource.UpdateRequest{
+		NativeID:      "db",
+		ResourceType:  "AWS::RDS::DBInstance",
+		PatchDocument: ptr.Of(`[{"op":"replace","path":"/MasterUserPassword","value":"rotated"}]`),
+	})
+
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestStatusResource_IncludesFailingHookInStatusMessage(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI}
+
+	mockAPI.On("GetResourceRequestStatus", mock.Anything, mock.Anything).Return(
+		&cloudcontrol.GetResourceRequestStatusOutput{
+			ProgressEvent: &cctypes.ProgressEvent{
+				Operation:       cctypes.OperationCreate,
+				OperationStatus: cctypes.OperationStatusFailed,
+				ErrorCode:       cctypes.HandlerErrorCodeInvalidRequest,
+				StatusMessage:   ptr.Of("Hook failure"),
+				TypeName:        ptr.Of("AWS::S3::Bucket"),
+			},
+			HooksProgressEvent: []cctypes.HookProgressEvent{{
+				HookTypeName:      ptr.Of("Acme::Security::EncryptionHook"),
+				HookStatus:        ptr.Of("HOOK_COMPLETE_FAILED"),
+				HookStatusMessage: ptr.Of("Bucket encryption must use aws:kms"),
+			}},
+		}, nil,
+	)
+
+	result, err := client.StatusResource(context.Background(), &resource.StatusRequest{RequestID: "req"}, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+	require.Contains(t, result.ProgressResult.StatusMessage, "Acme::Security::EncryptionHook")
+	require.Contains(t, result.ProgressResult.StatusMessage, "Bucket encryption must use aws:kms")
+}
+
+func TestPing(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI}
+
+	mockAPI.On("ListResourceRequests", mock.Anything, mock.MatchedBy(func(in *cloudcontrol.ListResourceRequestsInput) bool {
+		return *in.MaxResults == 1
+	})).Return(&cloudcontrol.ListResourceRequestsOutput{}, nil).Once()
+	require.NoError(t, client.Ping(context.Background()))
+
+	mockAPI.On("ListResourceRequests", mock.Anything, mock.Anything).
+		Return(nil, errors.New("ExpiredTokenException")).Once()
+	require.ErrorContains(t, client.Ping(context.Background()), "ExpiredTokenException")
+}
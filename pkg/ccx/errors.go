@@ -5,8 +5,11 @@
 package ccx
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	cctypes "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
 
@@ -72,3 +75,44 @@ func matchesEventualConsistency(msg string) bool {
 	}
 	return false
 }
+
+// hookFailureStatuses are the HookProgressEvent statuses of a hook that
+// rejected (or failed to evaluate) the request.
+var hookFailureStatuses = map[string]struct{}{
+	"HOOK_COMPLETE_FAILED": {},
+	"HOOK_FAILED":          {},
+}
+
+// withHookFailures appends the failing CloudFormation Hooks to msg. When a hook
+// blocks an operation CloudControl's own StatusMessage only says that a hook
+// failed; the hook's name and reason live in HooksProgressEvent. Hooks in WARN
+// failure mode are included too, since they explain warnings the user would
+// otherwise never see.
+func withHookFailures(msg string, events []cctypes.HookProgressEvent) string {
+	var failures []string
+	for _, e := range events {
+		if _, failed := hookFailureStatuses[aws.ToString(e.HookStatus)]; !failed {
+			continue
+		}
+		failure := fmt.Sprintf("hook %s", aws.ToString(e.HookTypeName))
+		if point := aws.ToString(e.InvocationPoint); point != "" {
+			failure += fmt.Sprintf(" (%s)", point)
+		}
+		if mode := aws.ToString(e.FailureMode); mode != "" {
+			failure += fmt.Sprintf(" [%s]", mode)
+		}
+		if reason := aws.ToString(e.HookStatusMessage); reason != "" {
+			failure += ": " + reason
+		}
+		failures = append(failures, failure)
+	}
+	if len(failures) == 0 {
+		return msg
+	}
+
+	hooks := strings.Join(failures, "; ")
+	if msg == "" {
+		return hooks
+	}
+	return msg + " (" + hooks + ")"
+}
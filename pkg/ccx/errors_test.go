@@ -116,3 +116,27 @@ func TestMatchesEventualConsistency(t *testing.T) {
 		})
 	}
 }
+
+func TestWithHookFailures(t *testing.T) {
+	events := []cctypes.HookProgressEvent{
+		{
+			HookTypeName:      aws.String("Acme::Security::EncryptionHook"),
+			HookStatus:        aws.String("HOOK_COMPLETE_FAILED"),
+			HookStatusMessage: aws.String("Bucket encryption must use aws:kms"),
+			InvocationPoint:   aws.String("PRE_PROVISION"),
+			FailureMode:       aws.String("FAIL"),
+		},
+		{
+			HookTypeName: aws.String("Acme::Tags::RequiredTags"),
+			HookStatus:   aws.String("HOOK_COMPLETE_SUCCEEDED"),
+		},
+	}
+
+	msg := withHookFailures("Hook failed", events)
+
+	require.Equal(t, "Hook failed (hook Acme::Security::EncryptionHook (PRE_PROVISION) [FAIL]: Bucket encryption must use aws:kms)", msg)
+	require.Equal(t, "unchanged", withHookFailures("unchanged", events[1:]))
+	require.Equal(t, "hook Acme::Tags::RequiredTags", withHookFailures("", []cctypes.HookProgressEvent{
+		{HookTypeName: aws.String("Acme::Tags::RequiredTags"), HookStatus: aws.String("HOOK_FAILED")},
+	}))
+}
@@ -0,0 +1,99 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ccx
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// clientTokens hands out the CloudControl idempotency tokens for creates.
+//
+// CloudControl treats a repeated ClientToken as the same request and returns
+// the original ProgressEvent instead of creating a second resource, which is
+// what we want when the PluginOperator re-invokes Create after a network error
+// that lost the first response. Each create gets a fresh random token that
+// every retry of it reuses, however long the retries take, until StatusResource
+// sees the request CloudControl accepted under it finish. Destroying a
+// resource and re-applying the identical forma later then issues a new Create
+// rather than replaying the old (now stale) request. The zero value is ready
+// to use.
+type clientTokens struct {
+	mu sync.Mutex
+	// byCreate maps a create (see createKey) to its token.
+	byCreate map[string]string
+	// byRequest maps the RequestToken CloudControl returned for a token back
+	// to its create.
+	byRequest map[string]string
+}
+
+// issue returns the token for a create of desiredState, reusing the one an
+// unfinished earlier attempt was given. The hex digest satisfies
+// CloudControl's token constraints (1-128 chars of [-A-Za-z0-9+/=]).
+func (t *clientTokens) issue(request *resource.CreateRequest, desiredState []byte) string {
+	key := createKey(request, desiredState)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if token, ok := t.byCreate[key]; ok {
+		return token
+	}
+	if t.byCreate == nil {
+		t.byCreate = map[string]string{}
+	}
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	token := hex.EncodeToString(b)
+	t.byCreate[key] = token
+	return token
+}
+
+// accepted records the RequestToken CloudControl returned for the create of
+// desiredState, so finish can retire its token.
+func (t *clientTokens) accepted(request *resource.CreateRequest, desiredState []byte, requestToken string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.byRequest == nil {
+		t.byRequest = map[string]string{}
+	}
+	t.byRequest[requestToken] = createKey(request, desiredState)
+}
+
+// done retires the token of the create of desiredState, which has finished.
+func (t *clientTokens) done(request *resource.CreateRequest, desiredState []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.byCreate, createKey(request, desiredState))
+}
+
+// finish retires the token of the create whose request is requestToken, if
+// any.
+func (t *clientTokens) finish(requestToken string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if key, ok := t.byRequest[requestToken]; ok {
+		delete(t.byCreate, key)
+		delete(t.byRequest, requestToken)
+	}
+}
+
+// createKey identifies a create by the resource type, the formae label and the
+// desired properties, the same for every retry of it.
+func createKey(request *resource.CreateRequest, desiredState []byte) string {
+	h := sha256.New()
+	for _, part := range [][]byte{
+		[]byte(request.ResourceType),
+		[]byte(request.Label),
+		desiredState,
+	} {
+		h.Write(part)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
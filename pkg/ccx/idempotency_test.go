@@ -0,0 +1,142 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ccx
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	cctypes "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ptr"
+)
+
+var clientTokenPattern = regexp.MustCompile(`^[-A-Za-z0-9+/=]{1,128}$`)
+
+func inProgress(requestToken string) *cloudcontrol.CreateResourceOutput {
+	return &cloudcontrol.CreateResourceOutput{
+		ProgressEvent: &cctypes.ProgressEvent{
+			OperationStatus: cctypes.OperationStatusInProgress,
+			RequestToken:    ptr.Of(requestToken),
+		},
+	}
+}
+
+func TestClientTokens_StableUntilFinished(t *testing.T) {
+	var tokens clientTokens
+	req := &resource.CreateRequest{ResourceType: "AWS::S3::Bucket", Label: "assets"}
+	props := []byte(`{"BucketName":"assets"}`)
+
+	first := tokens.issue(req, props)
+	assert.Regexp(t, clientTokenPattern, first)
+	assert.Equal(t, first, tokens.issue(req, props))
+
+	tokens.accepted(req, props, "req-token-123")
+	assert.Equal(t, first, tokens.issue(req, props))
+
+	tokens.finish("req-token-123")
+	assert.NotEqual(t, first, tokens.issue(req, props))
+}
+
+func TestClientTokens_DistinctPerCreate(t *testing.T) {
+	var tokens clientTokens
+	req := &resource.CreateRequest{ResourceType: "AWS::S3::Bucket", Label: "assets"}
+	props := []byte(`{"BucketName":"assets"}`)
+
+	base := tokens.issue(req, props)
+
+	assert.NotEqual(t, base, tokens.issue(&resource.CreateRequest{ResourceType: "AWS::S3::Bucket", Label: "logs"}, props))
+	assert.NotEqual(t, base, tokens.issue(req, []byte(`{"BucketName":"other"}`)))
+}
+
+func TestCreateResource_RetryReusesClientToken(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI}
+	req := &resource.CreateRequest{
+		ResourceType: "AWS::EC2::FlowLog",
+		Label:        "flow",
+		Properties:   json.RawMessage(`{"LogGroupName":"test"}`),
+	}
+
+	var tokens []string
+	mockAPI.On("CreateResource", mock.Anything, mock.MatchedBy(func(in *cloudcontrol.CreateResourceInput) bool {
+		tokens = append(tokens, aws.ToString(in.ClientToken))
+		return true
+	})).Return(inProgress("req-token-123"), nil)
+
+	_, err := client.CreateResource(context.Background(), req)
+	require.NoError(t, err)
+	_, err = client.CreateResource(context.Background(), req)
+	require.NoError(t, err)
+
+	require.Len(t, tokens, 2)
+	assert.Regexp(t, clientTokenPattern, tokens[0])
+	assert.Equal(t, tokens[0], tokens[1])
+}
+
+func TestCreateResource_NewClientTokenOnceFinished(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI}
+	req := &resource.CreateRequest{
+		ResourceType: "AWS::EC2::FlowLog",
+		Label:        "flow",
+		Properties:   json.RawMessage(`{"LogGroupName":"test"}`),
+	}
+
+	var tokens []string
+	mockAPI.On("CreateResource", mock.Anything, mock.MatchedBy(func(in *cloudcontrol.CreateResourceInput) bool {
+		tokens = append(tokens, aws.ToString(in.ClientToken))
+		return true
+	})).Return(inProgress("req-token-123"), nil)
+	mockAPI.On("GetResourceRequestStatus", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceRequestStatusOutput{
+		ProgressEvent: &cctypes.ProgressEvent{
+			Operation:       cctypes.OperationCreate,
+			OperationStatus: cctypes.OperationStatusFailed,
+			ErrorCode:       cctypes.HandlerErrorCodeGeneralServiceException,
+			RequestToken:    ptr.Of("req-token-123"),
+		},
+	}, nil)
+
+	_, err := client.CreateResource(context.Background(), req)
+	require.NoError(t, err)
+	_, err = client.StatusResource(context.Background(), &resource.StatusRequest{RequestID: "req-token-123"}, nil)
+	require.NoError(t, err)
+	_, err = client.CreateResource(context.Background(), req)
+	require.NoError(t, err)
+
+	require.Len(t, tokens, 2)
+	assert.NotEqual(t, tokens[0], tokens[1])
+}
+
+func TestStatusResource_PendingCreateKeepsClientToken(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI}
+	req := &resource.CreateRequest{ResourceType: "AWS::EC2::FlowLog", Properties: json.RawMessage(`{"LogGroupName":"test"}`)}
+	token := client.tokens.issue(req, req.Properties)
+	client.tokens.accepted(req, req.Properties, "req-token-123")
+
+	mockAPI.On("GetResourceRequestStatus", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceRequestStatusOutput{
+		ProgressEvent: &cctypes.ProgressEvent{
+			Operation:       cctypes.OperationCreate,
+			OperationStatus: cctypes.OperationStatusPending,
+			RequestToken:    ptr.Of("req-token-123"),
+		},
+	}, nil)
+
+	_, err := client.StatusResource(context.Background(), &resource.StatusRequest{RequestID: "req-token-123"}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, token, client.tokens.issue(req, req.Properties))
+}
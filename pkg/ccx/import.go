@@ -0,0 +1,33 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ccx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// NormalizeForImport turns the properties of a read into a desired state that
+// can adopt the resource: readOnly properties, which are outputs CloudControl
+// rejects in a desired state, are removed. Without a schema the properties
+// are returned as read.
+func (c *Client) NormalizeForImport(ctx context.Context, resourceType, properties string) (json.RawMessage, error) {
+	s := c.schemaFor(ctx, resourceType)
+	if s == nil || len(s.ReadOnly) == 0 {
+		return json.RawMessage(properties), nil
+	}
+
+	var propsMap map[string]any
+	if err := json.Unmarshal([]byte(properties), &propsMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal properties: %w", err)
+	}
+	s.StripReadOnly(propsMap)
+	out, err := json.Marshal(propsMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal properties: %w", err)
+	}
+	return out, nil
+}
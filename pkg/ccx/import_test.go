@@ -0,0 +1,26 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ccx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeForImport(t *testing.T) {
+	client := &Client{api: new(mockCloudControlAPI), schemas: staticSchemas{
+		"AWS::SQS::Queue": {ReadOnly: []string{"/Arn", "/QueueUrl"}},
+	}}
+
+	out, err := client.NormalizeForImport(context.Background(), "AWS::SQS::Queue",
+		`{"QueueName":"q","Arn":"arn:aws:sqs:us-east-1:123456789012:q","QueueUrl":"https://sqs/q"}`)
+
+	require.NoError(t, err)
+	require.JSONEq(t, `{"QueueName":"q"}`, string(out))
+}
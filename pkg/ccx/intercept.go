@@ -0,0 +1,163 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ccx
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	cctypes "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/platform-engineering-labs/formae/pkg/plugin"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+
+	helper "github.com/platform-engineering-labs/formae-plugin-aws/pkg/helper"
+)
+
+// Call describes one completed CloudControl API call, including any SDK
+// retries it went through.
+type Call struct {
+	// Operation is the CloudControl API name, e.g. "CreateResource".
+	Operation string
+	// ResourceType is the CloudFormation type name the call targeted. Empty
+	// for calls that aren't scoped to a type (GetResourceRequestStatus,
+	// CancelResourceRequest, ListResourceRequests).
+	ResourceType string
+	Duration     time.Duration
+	// RequestID is the AWS request ID of the last attempt, for correlating
+	// with CloudTrail or an AWS support case.
+	RequestID string
+	// ErrorCode classifies the failure: the CloudControl error of a failed
+	// call, or the handler error reported in the returned ProgressEvent.
+	ErrorCode resource.OperationErrorCode
+	Err       error
+}
+
+// Interceptor observes CloudControl calls. Intercept runs synchronously on the
+// calling goroutine after each call completes, so implementations should be
+// quick and must be safe for concurrent use. A Client runs the interceptors
+// its target's configuration asks for (see NewClient).
+type Interceptor interface {
+	Intercept(ctx context.Context, call Call)
+}
+
+// InterceptorFunc adapts a function to the Interceptor interface.
+type InterceptorFunc func(ctx context.Context, call Call)
+
+func (f InterceptorFunc) Intercept(ctx context.Context, call Call) {
+	f(ctx, call)
+}
+
+// logCalls logs every CloudControl call at debug level, and calls that
+// failed or reported a handler error as warnings. NewClient installs it for
+// targets that set LogCloudControlCalls.
+var logCalls = InterceptorFunc(func(ctx context.Context, call Call) {
+	args := []any{
+		"operation", call.Operation,
+		"resourceType", call.ResourceType,
+		"duration", call.Duration,
+		"requestId", call.RequestID,
+	}
+	if call.ErrorCode == "" {
+		plugin.LoggerFromContext(ctx).Debug("CloudControl call", args...)
+		return
+	}
+	args = append(args, "errorCode", call.ErrorCode)
+	if call.Err != nil {
+		args = append(args, "error", call.Err)
+	}
+	plugin.LoggerFromContext(ctx).Warn("CloudControl call failed", args...)
+})
+
+// interceptMiddleware returns the middleware that reports calls to
+// interceptors. It sits at the start of the Initialize step so the measured
+// duration covers SDK retries.
+func interceptMiddleware(interceptors ...Interceptor) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("FormaeInterceptors",
+			func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+				start := time.Now()
+				out, metadata, err := next.HandleInitialize(ctx, in)
+
+				call := Call{
+					Operation:    middleware.GetOperationName(ctx),
+					ResourceType: typeNameOf(in.Parameters),
+					Duration:     time.Since(start),
+					Err:          err,
+				}
+				if id, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok {
+					call.RequestID = id
+				} else {
+					var respErr *awshttp.ResponseError
+					if errors.As(err, &respErr) {
+						call.RequestID = respErr.ServiceRequestID()
+					}
+				}
+				if err != nil {
+					// The SDK wraps errors in an OperationError only after the
+					// middleware stack returns; do the same so the classifier
+					// recognises them.
+					code, ok := helper.HandleCloudControlError(&smithy.OperationError{
+						ServiceID:     cloudcontrol.ServiceID,
+						OperationName: call.Operation,
+						Err:           err,
+					})
+					if ok {
+						call.ErrorCode = resource.OperationErrorCode(code)
+					} else {
+						call.ErrorCode = resource.OperationErrorCodeInternalFailure
+					}
+				} else if event := progressEventOf(out.Result); event != nil && event.ErrorCode != "" {
+					call.ErrorCode = resource.OperationErrorCode(event.ErrorCode)
+				}
+
+				for _, i := range interceptors {
+					i.Intercept(ctx, call)
+				}
+				return out, metadata, err
+			}), middleware.Before)
+	}
+}
+
+func typeNameOf(params any) string {
+	var typeName *string
+	switch p := params.(type) {
+	case *cloudcontrol.CreateResourceInput:
+		typeName = p.TypeName
+	case *cloudcontrol.UpdateResourceInput:
+		typeName = p.TypeName
+	case *cloudcontrol.DeleteResourceInput:
+		typeName = p.TypeName
+	case *cloudcontrol.GetResourceInput:
+		typeName = p.TypeName
+	case *cloudcontrol.ListResourcesInput:
+		typeName = p.TypeName
+	}
+	if typeName == nil {
+		return ""
+	}
+	return *typeName
+}
+
+func progressEventOf(result any) *cctypes.ProgressEvent {
+	switch r := result.(type) {
+	case *cloudcontrol.CreateResourceOutput:
+		return r.ProgressEvent
+	case *cloudcontrol.UpdateResourceOutput:
+		return r.ProgressEvent
+	case *cloudcontrol.DeleteResourceOutput:
+		return r.ProgressEvent
+	case *cloudcontrol.GetResourceRequestStatusOutput:
+		return r.ProgressEvent
+	case *cloudcontrol.CancelResourceRequestOutput:
+		return r.ProgressEvent
+	}
+	return nil
+}
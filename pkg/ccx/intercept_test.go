@@ -0,0 +1,153 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ccx
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/platform-engineering-labs/formae/pkg/plugin"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/require"
+)
+
+// stubHTTPClient answers every request with a fixed status and body.
+type stubHTTPClient struct {
+	status int
+	body   string
+}
+
+func (s stubHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: s.status,
+		Header: http.Header{
+			"Content-Type":     []string{"application/x-amz-json-1.0"},
+			"X-Amzn-Requestid": []string{"req-abc"},
+		},
+		Body:    io.NopCloser(strings.NewReader(s.body)),
+		Request: req,
+	}, nil
+}
+
+func interceptedClient(t *testing.T, httpClient stubHTTPClient) (*cloudcontrol.Client, *[]Call) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var calls []Call
+	record := InterceptorFunc(func(_ context.Context, call Call) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, call)
+	})
+
+	client := cloudcontrol.New(cloudcontrol.Options{
+		Region:           "us-east-1",
+		Credentials:      credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		HTTPClient:       httpClient,
+		RetryMaxAttempts: 1,
+		APIOptions:       []func(*middleware.Stack) error{interceptMiddleware(record)},
+	})
+	return client, &calls
+}
+
+func TestIntercept_ReportsSuccessfulCall(t *testing.T) {
+	client, calls := interceptedClient(t, stubHTTPClient{
+		status: 200,
+		body:   `{"TypeName":"AWS::S3::Bucket","ResourceDescription":{"Identifier":"b","Properties":"{}"}}`,
+	})
+
+	_, err := client.GetResource(context.Background(), &cloudcontrol.GetResourceInput{
+		TypeName:   aws.String("AWS::S3::Bucket"),
+		Identifier: aws.String("b"),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, *calls, 1)
+	call := (*calls)[0]
+	require.Equal(t, "GetResource", call.Operation)
+	require.Equal(t, "AWS::S3::Bucket", call.ResourceType)
+	require.Equal(t, "req-abc", call.RequestID)
+	require.Empty(t, call.ErrorCode)
+	require.NoError(t, call.Err)
+	require.Positive(t, call.Duration)
+}
+
+func TestIntercept_ClassifiesErrors(t *testing.T) {
+	client, calls := interceptedClient(t, stubHTTPClient{
+		status: 400,
+		body:   `{"__type":"ThrottlingException","Message":"Rate exceeded"}`,
+	})
+
+	_, err := client.GetResource(context.Background(), &cloudcontrol.GetResourceInput{
+		TypeName:   aws.String("AWS::S3::Bucket"),
+		Identifier: aws.String("b"),
+	})
+
+	require.Error(t, err)
+	require.Len(t, *calls, 1)
+	call := (*calls)[0]
+	require.Equal(t, resource.OperationErrorCodeThrottling, call.ErrorCode)
+	require.Equal(t, "req-abc", call.RequestID)
+	require.Error(t, call.Err)
+}
+
+func TestIntercept_ReportsProgressEventErrorCode(t *testing.T) {
+	client, calls := interceptedClient(t, stubHTTPClient{
+		status: 200,
+		body:   `{"ProgressEvent":{"Operation":"CREATE","OperationStatus":"FAILED","ErrorCode":"AlreadyExists","RequestToken":"tok"}}`,
+	})
+
+	_, err := client.GetResourceRequestStatus(context.Background(), &cloudcontrol.GetResourceRequestStatusInput{
+		RequestToken: aws.String("tok"),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, *calls, 1)
+	require.Equal(t, "GetResourceRequestStatus", (*calls)[0].Operation)
+	require.Empty(t, (*calls)[0].ResourceType)
+	require.Equal(t, resource.OperationErrorCodeAlreadyExists, (*calls)[0].ErrorCode)
+}
+
+func TestLogCalls_WarnsOnFailedCall(t *testing.T) {
+	var buf bytes.Buffer
+	logger := plugin.NewPluginLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})))
+	ctx := plugin.WithLogger(context.Background(), logger)
+
+	logCalls.Intercept(ctx, Call{Operation: "GetResource", ResourceType: "AWS::S3::Bucket", RequestID: "req-abc"})
+	require.Empty(t, buf.String())
+
+	logCalls.Intercept(ctx, Call{
+		Operation:    "GetResource",
+		ResourceType: "AWS::S3::Bucket",
+		RequestID:    "req-abc",
+		ErrorCode:    resource.OperationErrorCodeNotFound,
+	})
+	require.Contains(t, buf.String(), "CloudControl call failed")
+	require.Contains(t, buf.String(), "requestId=req-abc")
+	require.Contains(t, buf.String(), "errorCode=NotFound")
+}
+
+// countingHTTPClient wraps stubHTTPClient and counts requests that reach it.
+type countingHTTPClient struct {
+	stub  stubHTTPClient
+	calls *int
+}
+
+func (c countingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	*c.calls++
+	return c.stub.Do(req)
+}
@@ -0,0 +1,105 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ccx
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/schema"
+)
+
+// patchOp is a single RFC 6902 operation.
+type patchOp struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value *any   `json:"value,omitempty"`
+}
+
+// computePatch builds an RFC 6902 patch document that turns prior into
+// desired, for callers that send full desired state instead of a
+// PatchDocument. Objects are diffed key by key; arrays and scalars that differ
+// are replaced whole, which is what CloudControl expects for list-valued
+// properties anyway. When s is non-nil, changes to createOnly properties are
+// returned separately: CloudControl can't apply them in place, the resource
+// has to be replaced.
+//
+// prior is a read of the resource, so it also holds values AWS assigned or
+// defaulted that the model never declared. A property desired leaves out is
+// therefore left as it is; only one desired sets to null is removed.
+func computePatch(prior, desired json.RawMessage, s *schema.Schema) (patch string, createOnly []string, err error) {
+	priorMap := map[string]any{}
+	if len(prior) > 0 {
+		if err = json.Unmarshal(prior, &priorMap); err != nil {
+			return "", nil, fmt.Errorf("failed to unmarshal prior properties: %w", err)
+		}
+	}
+	var desiredMap map[string]any
+	if err = json.Unmarshal(desired, &desiredMap); err != nil {
+		return "", nil, fmt.Errorf("failed to unmarshal desired properties: %w", err)
+	}
+
+	ops := diffObjects("", priorMap, desiredMap, []patchOp{})
+
+	if s != nil {
+		for _, op := range ops {
+			if s.IsCreateOnly(op.Path) {
+				createOnly = append(createOnly, op.Path)
+			}
+		}
+	}
+
+	out, err := json.Marshal(ops)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal patch document: %w", err)
+	}
+	return string(out), createOnly, nil
+}
+
+func diffObjects(base string, prior, desired map[string]any, ops []patchOp) []patchOp {
+	keys := make([]string, 0, len(prior)+len(desired))
+	for k := range prior {
+		keys = append(keys, k)
+	}
+	for k := range desired {
+		if _, ok := prior[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		path := base + "/" + escapePointer(k)
+		pv, inPrior := prior[k]
+		dv, inDesired := desired[k]
+		switch {
+		case !inDesired:
+			// Undeclared, possibly a server-assigned value.
+		case dv == nil:
+			if inPrior {
+				ops = append(ops, patchOp{Op: "remove", Path: path})
+			}
+		case !inPrior:
+			ops = append(ops, patchOp{Op: "add", Path: path, Value: &dv})
+		default:
+			pm, pIsMap := pv.(map[string]any)
+			dm, dIsMap := dv.(map[string]any)
+			if pIsMap && dIsMap {
+				ops = diffObjects(path, pm, dm, ops)
+			} else if !reflect.DeepEqual(pv, dv) {
+				ops = append(ops, patchOp{Op: "replace", Path: path, Value: &dv})
+			}
+		}
+	}
+	return ops
+}
+
+// escapePointer escapes a property name for use as a JSON pointer segment.
+func escapePointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
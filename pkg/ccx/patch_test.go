@@ -0,0 +1,141 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ccx
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	cctypes "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ptr"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/schema"
+)
+
+func TestComputePatch(t *testing.T) {
+	prior := json.RawMessage(`{"Name":"q","Delay":0,"Redrive":{"Max":3,"Dlq":"a"},"Tags":[{"Key":"a","Value":"1"}],"Old":"x"}`)
+	desired := json.RawMessage(`{"Name":"q","Delay":5,"Redrive":{"Max":3,"Dlq":"b"},"Tags":[{"Key":"a","Value":"2"}],"Old":null,"New":null,"a/b":true}`)
+
+	patch, createOnly, err := computePatch(prior, desired, nil)
+
+	require.NoError(t, err)
+	require.Empty(t, createOnly)
+	require.JSONEq(t, `[
+		{"op":"replace","path":"/Delay","value":5},
+		{"op":"remove","path":"/Old"},
+		{"op":"replace","path":"/Redrive/Dlq","value":"b"},
+		{"op":"replace","path":"/Tags","value":[{"Key":"a","Value":"2"}]},
+		{"op":"add","path":"/a~1b","value":true}
+	]`, patch)
+}
+
+func TestComputePatch_LeavesUndeclaredPriorValues(t *testing.T) {
+	s := &schema.Schema{CreateOnly: []string{"/QueueName"}, ReadOnly: []string{"/Arn"}}
+
+	patch, createOnly, err := computePatch(
+		json.RawMessage(`{"QueueName":"generated-42","Arn":"arn:aws:sqs:us-east-1:123456789012:generated-42","VisibilityTimeout":30,"Delay":1}`),
+		json.RawMessage(`{"Delay":2}`),
+		s)
+
+	require.NoError(t, err)
+	require.Empty(t, createOnly)
+	require.JSONEq(t, `[{"op":"replace","path":"/Delay","value":2}]`, patch)
+}
+
+func TestComputePatch_NoChanges(t *testing.T) {
+	patch, _, err := computePatch(json.RawMessage(`{"A":1}`), json.RawMessage(`{"A":1}`), nil)
+
+	require.NoError(t, err)
+	require.Equal(t, `[]`, patch)
+}
+
+func TestComputePatch_ReportsCreateOnlyChanges(t *testing.T) {
+	s := &schema.Schema{CreateOnly: []string{"/QueueName", "/Config/Fifo"}}
+
+	_, createOnly, err := computePatch(
+		json.RawMessage(`{"QueueName":"a","Config":{"Fifo":false},"Delay":1}`),
+		json.RawMessage(`{"QueueName":"b","Config":{"Fifo":true},"Delay":2}`),
+		s)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"/Config/Fifo", "/QueueName"}, createOnly)
+}
+
+func TestUpdateResource_ComputesPatchFromDesiredState(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI}
+
+	mockAPI.On("GetResource", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceOutput{}, nil)
+	mockAPI.On("UpdateResource", mock.Anything, mock.MatchedBy(func(in *cloudcontrol.UpdateResourceInput) bool {
+		return *in.PatchDocument == `[{"op":"replace","path":"/DelaySeconds","value":5}]`
+	})).Return(&cloudcontrol.UpdateResourceOutput{
+		ProgressEvent: &cctypes.ProgressEvent{
+			OperationStatus: cctypes.OperationStatusInProgress,
+			RequestToken:    ptr.Of("req-token-123"),
+		},
+	}, nil)
+
+	_, err := client.UpdateResource(context.Background(), &resource.UpdateRequest{
+		NativeID:          "q",
+		ResourceType:      "AWS::SQS::Queue",
+		PriorProperties:   json.RawMessage(`{"QueueName":"q","DelaySeconds":0}`),
+		DesiredProperties: json.RawMessage(`{"QueueName":"q","DelaySeconds":5}`),
+	})
+
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestUpdateResource_CreateOnlyChangeIsNotUpdatable(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI, schemas: staticSchemas{
+		"AWS::SQS::Queue": {CreateOnly: []string{"/QueueName"}},
+	}}
+
+	mockAPI.On("GetResource", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceOutput{}, nil)
+
+	result, err := client.UpdateResource(context.Background(), &resource.UpdateRequest{
+		NativeID:          "q",
+		ResourceType:      "AWS::SQS::Queue",
+		PriorProperties:   json.RawMessage(`{"QueueName":"q"}`),
+		DesiredProperties: json.RawMessage(`{"QueueName":"renamed"}`),
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+	require.Equal(t, resource.OperationErrorCodeNotUpdatable, result.ProgressResult.ErrorCode)
+	mockAPI.AssertNotCalled(t, "UpdateResource", mock.Anything, mock.Anything)
+}
+
+func TestUpdateResource_DropsReadOnlyOpsWithoutSchema(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI}
+
+	mockAPI.On("GetResource", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceOutput{}, nil)
+	mockAPI.On("UpdateResource", mock.Anything, mock.MatchedBy(func(in *cloudcontrol.UpdateResourceInput) bool {
+		return *in.PatchDocument == `[{"op":"replace","path":"/DelaySeconds","value":5}]`
+	})).Return(&cloudcontrol.UpdateResourceOutput{
+		ProgressEvent: &cctypes.ProgressEvent{
+			OperationStatus: cctypes.OperationStatusInProgress,
+			RequestToken:    ptr.Of("req-token-123"),
+		},
+	}, nil)
+
+	_, err := client.UpdateResource(context.Background(), &resource.UpdateRequest{
+		NativeID:      "q",
+		ResourceType:  "AWS::SQS::Queue",
+		PatchDocument: ptr.Of(`[{"op":"remove","path":"/Arn"},{"op":"replace","path":"/DelaySeconds","value":5}]`),
+	})
+
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
@@ -0,0 +1,109 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ccx
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// prefetchTTL bounds how long a prefetched read may be served. The agent
+// reads discovered resources shortly after listing them; anything older is
+// read again rather than risk reporting stale state.
+const prefetchTTL = 10 * time.Minute
+
+// Prefetch reads resources through ReadResources and keeps the successful
+// results, so that the next ReadResource of each one is answered without
+// another GetResource call. Discovery uses it to hydrate a List page with
+// bounded, throttle-coordinated concurrency instead of leaving the agent to
+// read every resource one call at a time. Resources already prefetched are not
+// read again. Each prefetched result is served once.
+func (c *Client) Prefetch(ctx context.Context, requests []resource.ReadRequest) []BatchReadResult {
+	var toRead []resource.ReadRequest
+	var results []BatchReadResult
+	for _, req := range requests {
+		if res, ok := c.prefetched.peek(req.ResourceType, req.NativeID); ok {
+			results = append(results, BatchReadResult{Request: req, Result: res})
+			continue
+		}
+		toRead = append(toRead, req)
+	}
+
+	for _, r := range c.ReadResources(ctx, toRead) {
+		if r.Err == nil && r.Result.ErrorCode == "" {
+			c.prefetched.put(r.Request.ResourceType, r.Request.NativeID, r.Result)
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// Forget drops the prefetched read of a resource, if any. Changes made
+// outside ccx, such as by a native provisioner, call it so the next read sees
+// them rather than the state from before.
+func (c *Client) Forget(resourceType, nativeID string) {
+	c.prefetched.take(resourceType, nativeID)
+}
+
+type prefetchKey struct {
+	resourceType string
+	nativeID     string
+}
+
+type prefetchEntry struct {
+	result  *resource.ReadResult
+	expires time.Time
+}
+
+// prefetchCache holds prefetched reads until they are taken or expire. The
+// zero value is ready to use.
+type prefetchCache struct {
+	mu      sync.Mutex
+	entries map[prefetchKey]prefetchEntry
+}
+
+func (p *prefetchCache) put(resourceType, nativeID string, result *resource.ReadResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.entries == nil {
+		p.entries = map[prefetchKey]prefetchEntry{}
+	}
+	now := time.Now()
+	for k, e := range p.entries {
+		if now.After(e.expires) {
+			delete(p.entries, k)
+		}
+	}
+	p.entries[prefetchKey{resourceType, nativeID}] = prefetchEntry{result: result, expires: now.Add(prefetchTTL)}
+}
+
+func (p *prefetchCache) peek(resourceType, nativeID string) (*resource.ReadResult, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e, ok := p.entries[prefetchKey{resourceType, nativeID}]
+	if !ok || time.Now().After(e.expires) {
+		return nil, false
+	}
+	return e.result, true
+}
+
+// take returns and removes a prefetched result.
+func (p *prefetchCache) take(resourceType, nativeID string) (*resource.ReadResult, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := prefetchKey{resourceType, nativeID}
+	e, ok := p.entries[key]
+	if !ok {
+		return nil, false
+	}
+	delete(p.entries, key)
+	if time.Now().After(e.expires) {
+		return nil, false
+	}
+	return e.result, true
+}
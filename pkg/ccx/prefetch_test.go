@@ -0,0 +1,98 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ccx
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	cctypes "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ptr"
+)
+
+func getResourceOutput(id string) *cloudcontrol.GetResourceOutput {
+	return &cloudcontrol.GetResourceOutput{
+		TypeName: ptr.Of("AWS::SQS::Queue"),
+		ResourceDescription: &cctypes.ResourceDescription{
+			Identifier: ptr.Of(id),
+			Properties: ptr.Of(`{"QueueName":"` + id + `"}`),
+		},
+	}
+}
+
+func TestPrefetch_ServesNextReadOnce(t *testing.T) {
+	mockAPI := &mockCloudControlAPI{}
+	client := &Client{api: mockAPI}
+	mockAPI.On("GetResource", mock.Anything, mock.Anything).Return(getResourceOutput("q1"), nil).Once()
+
+	requests := []resource.ReadRequest{{NativeID: "q1", ResourceType: "AWS::SQS::Queue"}}
+	results := client.Prefetch(context.Background(), requests)
+	require.Len(t, results, 1)
+	require.NoError(t, results[0].Err)
+
+	// Prefetching again doesn't read again.
+	client.Prefetch(context.Background(), requests)
+
+	read, err := client.ReadResource(context.Background(), &requests[0])
+	require.NoError(t, err)
+	require.JSONEq(t, `{"QueueName":"q1"}`, read.Properties)
+	mockAPI.AssertNumberOfCalls(t, "GetResource", 1)
+
+	// The prefetched result is served once; the next read goes to AWS.
+	mockAPI.On("GetResource", mock.Anything, mock.Anything).Return(getResourceOutput("q1"), nil).Once()
+	_, err = client.ReadResource(context.Background(), &requests[0])
+	require.NoError(t, err)
+	mockAPI.AssertNumberOfCalls(t, "GetResource", 2)
+}
+
+func TestForget_DropsPrefetchedRead(t *testing.T) {
+	mockAPI := &mockCloudControlAPI{}
+	client := &Client{api: mockAPI}
+	mockAPI.On("GetResource", mock.Anything, mock.Anything).Return(getResourceOutput("q1"), nil).Twice()
+
+	requests := []resource.ReadRequest{{NativeID: "q1", ResourceType: "AWS::SQS::Queue"}}
+	client.Prefetch(context.Background(), requests)
+	client.Forget("AWS::SQS::Queue", "q1")
+
+	_, err := client.ReadResource(context.Background(), &requests[0])
+	require.NoError(t, err)
+	mockAPI.AssertNumberOfCalls(t, "GetResource", 2)
+}
+
+func TestPrefetch_NotServedToReadsWithPriorProperties(t *testing.T) {
+	mockAPI := &mockCloudControlAPI{}
+	client := &Client{api: mockAPI}
+	mockAPI.On("GetResource", mock.Anything, mock.Anything).Return(getResourceOutput("q1"), nil)
+
+	client.Prefetch(context.Background(), []resource.ReadRequest{{NativeID: "q1", ResourceType: "AWS::SQS::Queue"}})
+	_, err := client.ReadResource(context.Background(), &resource.ReadRequest{
+		NativeID:        "q1",
+		ResourceType:    "AWS::SQS::Queue",
+		PriorProperties: json.RawMessage(`{"QueueName":"q1"}`),
+	})
+
+	require.NoError(t, err)
+	mockAPI.AssertNumberOfCalls(t, "GetResource", 2)
+}
+
+func TestPrefetchCache_Expires(t *testing.T) {
+	var cache prefetchCache
+	cache.put("AWS::SQS::Queue", "q1", &resource.ReadResult{})
+	cache.entries[prefetchKey{"AWS::SQS::Queue", "q1"}] = prefetchEntry{result: &resource.ReadResult{}, expires: time.Now().Add(-time.Second)}
+
+	_, ok := cache.peek("AWS::SQS::Queue", "q1")
+	require.False(t, ok)
+	_, ok = cache.take("AWS::SQS::Queue", "q1")
+	require.False(t, ok)
+}
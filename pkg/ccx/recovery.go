@@ -0,0 +1,122 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ccx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	cctypes "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/status"
+)
+
+// inFlightStatuses are the CloudControl request states that still have work
+// outstanding against AWS.
+var inFlightStatuses = []cctypes.OperationStatus{
+	cctypes.OperationStatusPending,
+	cctypes.OperationStatusInProgress,
+	cctypes.OperationStatusCancelInProgress,
+}
+
+// InFlightRequests rediscovers CloudControl requests that are still pending or
+// in progress for this target. Request tokens only live in the plugin's
+// memory between a Create/Update/Delete and the operator's Status polling, so
+// they are lost when the plugin restarts mid-deploy. CloudControl keeps its own
+// record of every request for seven days, which resumeInFlight uses to pick a
+// lost request back up. resourceTypes optionally narrows the result to the
+// given types; nil returns requests of every type.
+func (c *Client) InFlightRequests(ctx context.Context, resourceTypes []string) ([]resource.ProgressResult, error) {
+	wanted := make(map[string]struct{}, len(resourceTypes))
+	for _, t := range resourceTypes {
+		wanted[t] = struct{}{}
+	}
+
+	var results []resource.ProgressResult
+	input := &cloudcontrol.ListResourceRequestsInput{
+		ResourceRequestStatusFilter: &cctypes.ResourceRequestStatusFilter{
+			OperationStatuses: inFlightStatuses,
+		},
+	}
+	for {
+		out, err := c.api.ListResourceRequests(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list in-flight resource requests: %w", err)
+		}
+
+		for _, event := range out.ResourceRequestStatusSummaries {
+			if len(wanted) > 0 {
+				if _, ok := wanted[aws.ToString(event.TypeName)]; !ok {
+					continue
+				}
+			}
+			results = append(results, progressFromEvent(&event))
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+
+	return results, nil
+}
+
+// resumeInFlight looks for the request CloudControl is already running for op
+// on nativeID when an Update or Delete is refused because another operation
+// is in progress on the resource. That happens when the plugin restarted
+// after submitting the request and the operator issued it again; returning
+// the earlier request lets Status resume polling it instead of the operation
+// failing. It returns nil if err isn't such a refusal or no matching request
+// is found.
+func (c *Client) resumeInFlight(ctx context.Context, err error, resourceType, nativeID string, op resource.Operation) *resource.ProgressResult {
+	var concurrent *cctypes.ConcurrentOperationException
+	if !errors.As(err, &concurrent) {
+		return nil
+	}
+
+	inFlight, listErr := c.InFlightRequests(ctx, []string{resourceType})
+	if listErr != nil {
+		plugin.LoggerFromContext(ctx).Warn("could not look up in-flight request to resume",
+			"resourceType", resourceType,
+			"nativeID", nativeID,
+			"error", listErr)
+		return nil
+	}
+	for i := range inFlight {
+		if inFlight[i].NativeID == nativeID && inFlight[i].Operation == op {
+			plugin.LoggerFromContext(ctx).Info("resuming in-flight request",
+				"resourceType", resourceType,
+				"nativeID", nativeID,
+				"requestToken", inFlight[i].RequestID)
+			return &inFlight[i]
+		}
+	}
+	return nil
+}
+
+// progressFromEvent converts a CloudControl ProgressEvent into the
+// ProgressResult the operator tracks, keyed by the event's own request token.
+func progressFromEvent(event *cctypes.ProgressEvent) resource.ProgressResult {
+	operation, operationStatus := status.FromProgress(event)
+	identifier := ""
+	if event.Identifier != nil {
+		identifier = normalizeCompositeIdentifier(*event.Identifier)
+	}
+
+	return resource.ProgressResult{
+		Operation:       operation,
+		OperationStatus: operationStatus,
+		RequestID:       aws.ToString(event.RequestToken),
+		NativeID:        identifier,
+		StatusMessage:   aws.ToString(event.StatusMessage),
+		ErrorCode:       resource.OperationErrorCode(event.ErrorCode),
+	}
+}
@@ -0,0 +1,136 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ccx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	cctypes "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ptr"
+)
+
+func TestInFlightRequests_PaginatesAndFiltersByType(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI}
+
+	mockAPI.On("ListResourceRequests", mock.Anything, mock.MatchedBy(func(in *cloudcontrol.ListResourceRequestsInput) bool {
+		return in.NextToken == nil && len(in.ResourceRequestStatusFilter.OperationStatuses) == len(inFlightStatuses)
+	})).Return(&cloudcontrol.ListResourceRequestsOutput{
+		ResourceRequestStatusSummaries: []cctypes.ProgressEvent{
+			{
+				Operation:       cctypes.OperationCreate,
+				OperationStatus: cctypes.OperationStatusInProgress,
+				RequestToken:    ptr.Of("tok-1"),
+				TypeName:        ptr.Of("AWS::RDS::DBCluster"),
+				Identifier:      ptr.Of("my-cluster"),
+			},
+			{
+				Operation:       cctypes.OperationDelete,
+				OperationStatus: cctypes.OperationStatusPending,
+				RequestToken:    ptr.Of("tok-2"),
+				TypeName:        ptr.Of("AWS::S3::Bucket"),
+			},
+		},
+		NextToken: ptr.Of("page-2"),
+	}, nil).Once()
+	mockAPI.On("ListResourceRequests", mock.Anything, mock.MatchedBy(func(in *cloudcontrol.ListResourceRequestsInput) bool {
+		return in.NextToken != nil && *in.NextToken == "page-2"
+	})).Return(&cloudcontrol.ListResourceRequestsOutput{
+		ResourceRequestStatusSummaries: []cctypes.ProgressEvent{
+			{
+				Operation:       cctypes.OperationUpdate,
+				OperationStatus: cctypes.OperationStatusInProgress,
+				RequestToken:    ptr.Of("tok-3"),
+				TypeName:        ptr.Of("AWS::RDS::DBCluster"),
+				Identifier:      ptr.Of("other-cluster"),
+			},
+		},
+	}, nil).Once()
+
+	results, err := client.InFlightRequests(context.Background(), []string{"AWS::RDS::DBCluster"})
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, "tok-1", results[0].RequestID)
+	require.Equal(t, resource.OperationCreate, results[0].Operation)
+	require.Equal(t, resource.OperationStatusInProgress, results[0].OperationStatus)
+	require.Equal(t, "my-cluster", results[0].NativeID)
+	require.Equal(t, "tok-3", results[1].RequestID)
+	require.Equal(t, resource.OperationUpdate, results[1].Operation)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestInFlightRequests_Error(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI}
+
+	mockAPI.On("ListResourceRequests", mock.Anything, mock.Anything).Return(nil, errors.New("AccessDenied"))
+
+	_, err := client.InFlightRequests(context.Background(), nil)
+
+	require.Error(t, err)
+}
+
+func TestDeleteResource_ResumesInFlightRequest(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI}
+
+	mockAPI.On("DeleteResource", mock.Anything, mock.Anything).
+		Return((*cloudcontrol.DeleteResourceOutput)(nil), ccOpError(&cctypes.ConcurrentOperationException{Message: ptr.Of("another operation is in progress")}))
+	mockAPI.On("ListResourceRequests", mock.Anything, mock.Anything).Return(&cloudcontrol.ListResourceRequestsOutput{
+		ResourceRequestStatusSummaries: []cctypes.ProgressEvent{
+			{
+				Operation:       cctypes.OperationUpdate,
+				OperationStatus: cctypes.OperationStatusInProgress,
+				RequestToken:    ptr.Of("tok-update"),
+				TypeName:        ptr.Of("AWS::RDS::DBCluster"),
+				Identifier:      ptr.Of("my-cluster"),
+			},
+			{
+				Operation:       cctypes.OperationDelete,
+				OperationStatus: cctypes.OperationStatusInProgress,
+				RequestToken:    ptr.Of("tok-delete"),
+				TypeName:        ptr.Of("AWS::RDS::DBCluster"),
+				Identifier:      ptr.Of("my-cluster"),
+			},
+		},
+	}, nil)
+
+	result, err := client.DeleteResource(context.Background(), &resource.DeleteRequest{
+		NativeID:     "my-cluster",
+		ResourceType: "AWS::RDS::DBCluster",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "tok-delete", result.ProgressResult.RequestID)
+	require.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+}
+
+func TestDeleteResource_ConcurrentOperationWithoutInFlightRequestFails(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI}
+
+	mockAPI.On("DeleteResource", mock.Anything, mock.Anything).
+		Return((*cloudcontrol.DeleteResourceOutput)(nil), ccOpError(&cctypes.ConcurrentOperationException{Message: ptr.Of("another operation is in progress")}))
+	mockAPI.On("ListResourceRequests", mock.Anything, mock.Anything).Return(&cloudcontrol.ListResourceRequestsOutput{}, nil)
+
+	result, err := client.DeleteResource(context.Background(), &resource.DeleteRequest{
+		NativeID:     "my-cluster",
+		ResourceType: "AWS::RDS::DBCluster",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+	require.Equal(t, resource.OperationErrorCodeResourceConflict, result.ProgressResult.ErrorCode)
+}
@@ -7,12 +7,17 @@ package ccx
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/rand/v2"
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/platform-engineering-labs/formae/pkg/plugin"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
 )
 
 // Recoverable-error retry budget for ccx-layer calls that don't benefit from
@@ -45,12 +50,24 @@ const (
 	defaultRetryMaxDelay    = 30 * time.Second
 )
 
+// postSuccessNotFoundAttempts bounds how many attempts a read-after-success
+// keeps retrying NotFound. IAM, Route53 and S3 commonly return NotFound for a
+// few seconds after CloudControl reports a create as complete; 5 attempts on
+// the default backoff cover roughly 15 seconds of propagation delay without
+// stalling on a resource that really is gone.
+const postSuccessNotFoundAttempts = 5
+
 // retryOpts allows callers to override the default budget for tests or for
 // call sites with different tolerance for latency.
 type retryOpts struct {
 	MaxAttempts int
 	BaseDelay   time.Duration
 	MaxDelay    time.Duration
+	// NotFoundAttempts, when positive, treats a NotFound ErrorCode as
+	// recoverable for that many attempts (capped by MaxAttempts). Used for
+	// reads right after a successful create, where NotFound means the resource
+	// hasn't propagated yet rather than that it doesn't exist.
+	NotFoundAttempts int
 }
 
 func (o retryOpts) withDefaults() retryOpts {
@@ -93,6 +110,8 @@ func retryRead(
 			return res, err
 		case err == nil && res != nil && res.ErrorCode == "" && res.Properties != "":
 			return res, nil
+		case err == nil && res != nil && res.ErrorCode == resource.OperationErrorCodeNotFound && attempt < opts.NotFoundAttempts:
+			// Not yet propagated; fall through to the backoff below.
 		case err == nil && res != nil && res.ErrorCode != "" && !isRecoverable(nil, string(res.ErrorCode)):
 			// Non-recoverable CCAPI error code (e.g. NotFound) — surface
 			// without further retries.
@@ -243,3 +262,45 @@ func backoffDelay(attempt int, base, maxDelay time.Duration) time.Duration {
 	jitter := time.Duration(rand.Int64N(int64(delay) / 4))
 	return delay + jitter
 }
+
+// Default SDK retry settings for CloudControl. AWS CloudControl API has strict
+// rate limits, so we use:
+//   - Fewer max attempts (let PluginOperator handle retries at a higher level)
+//   - Longer max backoff to give AWS time to recover from throttling
+//   - Adaptive mode, whose client-side token bucket shrinks on every
+//     ThrottlingException and slows request issuance before AWS has to reject
+//     more calls. Clients are pooled per target, so the bucket is shared by
+//     all operations against that target.
+const (
+	defaultSDKMaxAttempts = 2 // Reduce from default 3 to fail faster to PluginOperator
+	defaultSDKMaxBackoff  = 30 * time.Second
+
+	retryModeStandard = "standard"
+	retryModeAdaptive = "adaptive"
+)
+
+// newRetryer builds the SDK retryer for CloudControl from the target config,
+// falling back to the defaults above for any setting the target leaves unset.
+func newRetryer(cfg *config.Config) (aws.Retryer, error) {
+	standardOpts := func(o *retry.StandardOptions) {
+		o.MaxAttempts = defaultSDKMaxAttempts
+		if cfg.RetryMaxAttempts > 0 {
+			o.MaxAttempts = cfg.RetryMaxAttempts
+		}
+		o.MaxBackoff = defaultSDKMaxBackoff
+		if cfg.RetryMaxBackoffSeconds > 0 {
+			o.MaxBackoff = time.Duration(cfg.RetryMaxBackoffSeconds) * time.Second
+		}
+	}
+
+	switch strings.ToLower(cfg.RetryMode) {
+	case retryModeStandard:
+		return retry.NewStandard(standardOpts), nil
+	case "", retryModeAdaptive:
+		return retry.NewAdaptiveMode(func(o *retry.AdaptiveModeOptions) {
+			o.StandardOptions = append(o.StandardOptions, standardOpts)
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported RetryMode %q: expected %q or %q", cfg.RetryMode, retryModeStandard, retryModeAdaptive)
+	}
+}
@@ -14,8 +14,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/platform-engineering-labs/formae/pkg/plugin"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
 )
 
 // countingHandler is a slog.Handler that counts the records routed to it,
@@ -149,6 +154,49 @@ func TestRetryRead_NonRecoverableExitsImmediately(t *testing.T) {
 	}
 }
 
+func TestRetryRead_NotFoundRetriedWithinPropagationBudget(t *testing.T) {
+	calls := 0
+	opts := testOpts(10)
+	opts.NotFoundAttempts = 5
+	res, err := retryRead(context.Background(), opts, "test",
+		func(ctx context.Context) (*resource.ReadResult, error) {
+			calls++
+			if calls < 3 {
+				return &resource.ReadResult{ErrorCode: resource.OperationErrorCodeNotFound}, nil
+			}
+			return &resource.ReadResult{Properties: `{"Arn":"x"}`}, nil
+		})
+	if err != nil {
+		t.Fatalf("retryRead: %v", err)
+	}
+	if res == nil || res.Properties == "" {
+		t.Errorf("expected properties once the resource propagated, got %+v", res)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryRead_NotFoundBudgetIsBounded(t *testing.T) {
+	calls := 0
+	opts := testOpts(10)
+	opts.NotFoundAttempts = 3
+	res, err := retryRead(context.Background(), opts, "test",
+		func(ctx context.Context) (*resource.ReadResult, error) {
+			calls++
+			return &resource.ReadResult{ErrorCode: resource.OperationErrorCodeNotFound}, nil
+		})
+	if err != nil {
+		t.Fatalf("retryRead: %v", err)
+	}
+	if res == nil || res.ErrorCode != resource.OperationErrorCodeNotFound {
+		t.Errorf("expected NotFound after the budget, got %+v", res)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
 func TestRetryRead_ContextCancelExitsCleanly(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	calls := 0
@@ -261,3 +309,39 @@ func TestBackoffDelay_ExponentialWithJitter(t *testing.T) {
 		last = d
 	}
 }
+
+func TestNewRetryer_Defaults(t *testing.T) {
+	retryer, err := newRetryer(&config.Config{})
+	require.NoError(t, err)
+
+	assert.IsType(t, &retry.AdaptiveMode{}, retryer)
+	assert.Equal(t, defaultSDKMaxAttempts, retryer.MaxAttempts())
+}
+
+func TestNewRetryer_StandardModeOptOut(t *testing.T) {
+	retryer, err := newRetryer(&config.Config{RetryMode: "standard"})
+	require.NoError(t, err)
+
+	assert.IsType(t, &retry.Standard{}, retryer)
+}
+
+func TestNewRetryer_OverridesFromConfig(t *testing.T) {
+	retryer, err := newRetryer(&config.Config{RetryMaxAttempts: 5, RetryMaxBackoffSeconds: 60})
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, retryer.MaxAttempts())
+}
+
+func TestNewRetryer_AdaptiveMode(t *testing.T) {
+	retryer, err := newRetryer(&config.Config{RetryMode: "Adaptive", RetryMaxAttempts: 4})
+	require.NoError(t, err)
+
+	assert.IsType(t, &retry.AdaptiveMode{}, retryer)
+	assert.Equal(t, 4, retryer.MaxAttempts())
+}
+
+func TestNewRetryer_UnknownMode(t *testing.T) {
+	_, err := newRetryer(&config.Config{RetryMode: "legacy"})
+
+	assert.ErrorContains(t, err, `unsupported RetryMode "legacy"`)
+}
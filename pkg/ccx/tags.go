@@ -0,0 +1,164 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ccx
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/props"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/schema"
+)
+
+// injectedTags returns the tags the plugin adds to every taggable resource on
+// its own: the target's DefaultTags and, when label is set, the resource's
+// formae label. Stack labels are stamped by the agent.
+func (c *Client) injectedTags(label string) map[string]string {
+	if label == "" {
+		return c.defaultTags
+	}
+	tags := make(map[string]string, len(c.defaultTags)+1)
+	maps.Copy(tags, c.defaultTags)
+	tags[props.ResourceLabelTag] = label
+	return tags
+}
+
+// stripInjectedTags removes the tags the plugin or the agent injected from a
+// read of a managed resource: formae's label tags and the keys of defaults,
+// the target's DefaultTags. A key prior sets too is kept, so only tags the
+// model never declared are dropped and they don't show up as drift.
+func stripInjectedTags(propsMap map[string]any, s *schema.Schema, prior map[string]any, defaults map[string]string) {
+	if s == nil || s.TagProperty == "" {
+		return
+	}
+	field := s.TagProperty[1:]
+	tags, ok := propsMap[field]
+	if !ok {
+		return
+	}
+
+	declared := props.TagsToMap(prior[field])
+	var injected []string
+	for _, key := range append([]string{props.ResourceLabelTag, props.StackLabelTag}, slices.Sorted(maps.Keys(defaults))...) {
+		if _, ok := declared[key]; !ok {
+			injected = append(injected, key)
+		}
+	}
+	propsMap[field] = props.RemoveTags(tags, injected...)
+}
+
+// withDefaultTags merges defaults into the tag property of properties. Tags
+// set in properties win over defaults with the same key. Types the schema
+// doesn't mark as taggable are returned unchanged.
+func withDefaultTags(properties json.RawMessage, s *schema.Schema, defaults map[string]string) (json.RawMessage, error) {
+	if len(defaults) == 0 || s == nil || s.TagProperty == "" {
+		return properties, nil
+	}
+
+	var propsMap map[string]any
+	if err := json.Unmarshal(properties, &propsMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal properties: %w", err)
+	}
+	field := s.TagProperty[1:]
+	propsMap[field] = props.MergeTags(propsMap[field], defaults, s.TagsAsMap)
+
+	out, err := json.Marshal(propsMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal properties: %w", err)
+	}
+	return out, nil
+}
+
+// patchWithDefaultTags rewrites the operations of patchDoc that set the tag
+// property as a whole so the defaults survive them (see mergeTagOps).
+func patchWithDefaultTags(patchDoc string, s *schema.Schema, defaults map[string]string) (string, error) {
+	if len(defaults) == 0 || s == nil || s.TagProperty == "" {
+		return patchDoc, nil
+	}
+	return mergeTagOps(patchDoc, s, defaults)
+}
+
+// patchPreservingTags rewrites the operations of patchDoc that set the tag
+// property as a whole so tags on the live resource that formae doesn't manage
+// survive them. A tag is managed when its key appears in the prior
+// properties, in injected, or in the operation's value; unmanaged tags are
+// added back, and a remove becomes a replace with the unmanaged tags alone.
+// Reads strip the injected tags a model never declared, so prior alone would
+// pass them off as set outside formae.
+func patchPreservingTags(patchDoc string, s *schema.Schema, current string, prior json.RawMessage, injected map[string]string) (string, error) {
+	if s == nil || s.TagProperty == "" || current == "" {
+		return patchDoc, nil
+	}
+	field := s.TagProperty[1:]
+
+	unmanaged, err := tagsOf([]byte(current), field)
+	if err != nil {
+		return "", err
+	}
+	if len(prior) > 0 {
+		managed, err := tagsOf(prior, field)
+		if err != nil {
+			return "", err
+		}
+		for key := range managed {
+			delete(unmanaged, key)
+		}
+	}
+	for key := range injected {
+		delete(unmanaged, key)
+	}
+	if len(unmanaged) == 0 {
+		return patchDoc, nil
+	}
+
+	return mergeTagOps(patchDoc, s, unmanaged)
+}
+
+// mergeTagOps merges tags into every operation of patchDoc that sets the tag
+// property as a whole: an add or replace gets them merged into its value, and
+// a remove becomes a replace with tags alone. Keys the operation already sets
+// keep its value. Operations on individual tags are left as they are.
+func mergeTagOps(patchDoc string, s *schema.Schema, tags map[string]string) (string, error) {
+	var ops []map[string]any
+	if err := json.Unmarshal([]byte(patchDoc), &ops); err != nil {
+		return "", fmt.Errorf("failed to unmarshal patch document: %w", err)
+	}
+
+	changed := false
+	for _, op := range ops {
+		if op["path"] != s.TagProperty {
+			continue
+		}
+		switch op["op"] {
+		case "add", "replace":
+		case "remove":
+			op["op"] = "replace"
+		default:
+			continue
+		}
+		op["value"] = props.MergeTags(op["value"], tags, s.TagsAsMap)
+		changed = true
+	}
+	if !changed {
+		return patchDoc, nil
+	}
+
+	out, err := json.Marshal(ops)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal patch document: %w", err)
+	}
+	return string(out), nil
+}
+
+// tagsOf returns the tags held in field of a properties document.
+func tagsOf(properties []byte, field string) (map[string]string, error) {
+	var propsMap map[string]any
+	if err := json.Unmarshal(properties, &propsMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal properties: %w", err)
+	}
+	return props.TagsToMap(propsMap[field]), nil
+}
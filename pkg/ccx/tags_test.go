@@ -0,0 +1,235 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ccx
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	cctypes "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ptr"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/schema"
+)
+
+var defaultTags = map[string]string{"CostCenter": "42", "Owner": "platform"}
+
+func TestWithDefaultTags(t *testing.T) {
+	s := &schema.Schema{TagProperty: "/Tags"}
+
+	out, err := withDefaultTags(json.RawMessage(`{"Name":"q","Tags":[{"Key":"Owner","Value":"me"}]}`), s, defaultTags)
+
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Name":"q","Tags":[{"Key":"Owner","Value":"me"},{"Key":"CostCenter","Value":"42"}]}`, string(out))
+}
+
+func TestWithDefaultTags_MapFormat(t *testing.T) {
+	s := &schema.Schema{TagProperty: "/Tags", TagsAsMap: true}
+
+	out, err := withDefaultTags(json.RawMessage(`{"Name":"p"}`), s, defaultTags)
+
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Name":"p","Tags":{"CostCenter":"42","Owner":"platform"}}`, string(out))
+}
+
+func TestWithDefaultTags_NotTaggable(t *testing.T) {
+	in := json.RawMessage(`{"Name":"p"}`)
+
+	out, err := withDefaultTags(in, &schema.Schema{}, defaultTags)
+	require.NoError(t, err)
+	require.Equal(t, in, out)
+
+	out, err = withDefaultTags(in, nil, defaultTags)
+	require.NoError(t, err)
+	require.Equal(t, in, out)
+}
+
+func TestPatchWithDefaultTags(t *testing.T) {
+	s := &schema.Schema{TagProperty: "/Tags"}
+
+	out, err := patchWithDefaultTags(`[
+		{"op":"replace","path":"/Tags","value":[{"Key":"Env","Value":"prod"}]},
+		{"op":"replace","path":"/Delay","value":5}
+	]`, s, defaultTags)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"op":"replace","path":"/Tags","value":[{"Key":"Env","Value":"prod"},{"Key":"CostCenter","Value":"42"},{"Key":"Owner","Value":"platform"}]},
+		{"op":"replace","path":"/Delay","value":5}
+	]`, out)
+
+	out, err = patchWithDefaultTags(`[{"op":"remove","path":"/Tags"}]`, s, defaultTags)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"op":"replace","path":"/Tags","value":[{"Key":"CostCenter","Value":"42"},{"Key":"Owner","Value":"platform"}]}]`, out)
+
+	patch := `[{"op":"remove","path":"/Tags/0"}]`
+	out, err = patchWithDefaultTags(patch, s, defaultTags)
+	require.NoError(t, err)
+	require.Equal(t, patch, out)
+}
+
+func TestPatchPreservingTags(t *testing.T) {
+	s := &schema.Schema{TagProperty: "/Tags"}
+	current := `{"Name":"q","Tags":[{"Key":"Env","Value":"dev"},{"Key":"Old","Value":"x"},{"Key":"cost:center","Value":"42"}]}`
+	prior := json.RawMessage(`{"Name":"q","Tags":[{"Key":"Env","Value":"dev"},{"Key":"Old","Value":"x"}]}`)
+
+	out, err := patchPreservingTags(`[{"op":"replace","path":"/Tags","value":[{"Key":"Env","Value":"prod"}]}]`, s, current, prior, nil)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"op":"replace","path":"/Tags","value":[{"Key":"Env","Value":"prod"},{"Key":"cost:center","Value":"42"}]}]`, out)
+
+	out, err = patchPreservingTags(`[{"op":"remove","path":"/Tags"}]`, s, current, prior, nil)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"op":"replace","path":"/Tags","value":[{"Key":"cost:center","Value":"42"}]}]`, out)
+}
+
+func TestPatchPreservingTags_NothingUnmanaged(t *testing.T) {
+	s := &schema.Schema{TagProperty: "/Tags"}
+	patch := `[{"op":"remove","path":"/Tags"}]`
+
+	out, err := patchPreservingTags(patch, s, `{"Tags":[{"Key":"Env","Value":"dev"}]}`, json.RawMessage(`{"Tags":[{"Key":"Env","Value":"dev"}]}`), nil)
+
+	require.NoError(t, err)
+	require.Equal(t, patch, out)
+}
+
+func TestPatchPreservingTags_InjectedTagsAreManaged(t *testing.T) {
+	s := &schema.Schema{TagProperty: "/Tags"}
+	current := `{"Tags":[{"Key":"Env","Value":"dev"},{"Key":"CostCenter","Value":"41"},{"Key":"cost:center","Value":"42"}]}`
+	prior := json.RawMessage(`{"Tags":[{"Key":"Env","Value":"dev"}]}`)
+
+	out, err := patchPreservingTags(`[{"op":"remove","path":"/Tags"}]`, s, current, prior, defaultTags)
+
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"op":"replace","path":"/Tags","value":[{"Key":"cost:center","Value":"42"}]}]`, out)
+}
+
+func TestUpdateResource_MergeModeLeavesUnchangedDefaultTags(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI, defaultTags: defaultTags, tagUpdateMode: config.TagUpdateModeMerge, schemas: staticSchemas{
+		"AWS::SQS::Queue": {TagProperty: "/Tags"},
+	}}
+
+	mockAPI.On("GetResource", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceOutput{
+		ResourceDescription: &cctypes.ResourceDescription{
+			Properties: ptr.Of(`{"QueueName":"q","DelaySeconds":0,"Tags":[` +
+				`{"Key":"Env","Value":"dev"},{"Key":"CostCenter","Value":"42"},{"Key":"Owner","Value":"platform"},{"Key":"cost:center","Value":"42"}]}`),
+		},
+	}, nil)
+	mockAPI.On("UpdateResource", mock.Anything, mock.MatchedBy(func(in *cloudcontrol.UpdateResourceInput) bool {
+		return *in.PatchDocument == `[{"op":"replace","path":"/DelaySeconds","value":5}]`
+	})).Return(&cloudcontrol.UpdateResourceOutput{
+		ProgressEvent: &cctypes.ProgressEvent{
+			OperationStatus: cctypes.OperationStatusInProgress,
+			RequestToken:    ptr.Of("req-token-123"),
+		},
+	}, nil)
+
+	_, err := client.UpdateResource(context.Background(), &resource.UpdateRequest{
+		NativeID:          "q",
+		ResourceType:      "AWS::SQS::Queue",
+		PriorProperties:   json.RawMessage(`{"QueueName":"q","DelaySeconds":0,"Tags":[{"Key":"Env","Value":"dev"}]}`),
+		DesiredProperties: json.RawMessage(`{"QueueName":"q","DelaySeconds":5,"Tags":[{"Key":"Env","Value":"dev"}]}`),
+	})
+
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestCreateResource_InjectsResourceLabelTag(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI, schemas: staticSchemas{
+		"AWS::SQS::Queue": {TagProperty: "/Tags"},
+	}}
+
+	mockAPI.On("CreateResource", mock.Anything, mock.MatchedBy(func(in *cloudcontrol.CreateResourceInput) bool {
+		return *in.DesiredState == `{"QueueName":"q","Tags":[{"Key":"FormaeResourceLabel","Value":"orders"}]}`
+	})).Return(&cloudcontrol.CreateResourceOutput{
+		ProgressEvent: &cctypes.ProgressEvent{
+			OperationStatus: cctypes.OperationStatusInProgress,
+			RequestToken:    ptr.Of("req-token-123"),
+		},
+	}, nil)
+
+	_, err := client.CreateResource(context.Background(), &resource.CreateRequest{
+		ResourceType: "AWS::SQS::Queue",
+		Label:        "orders",
+		Properties:   json.RawMessage(`{"QueueName":"q"}`),
+	})
+
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestReadResource_StripsUndeclaredLabelTags(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI, schemas: staticSchemas{
+		"AWS::SQS::Queue": {TagProperty: "/Tags"},
+	}}
+
+	mockAPI.On("GetResource", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceOutput{
+		TypeName: ptr.Of("AWS::SQS::Queue"),
+		ResourceDescription: &cctypes.ResourceDescription{
+			Properties: ptr.Of(`{"QueueName":"q","Tags":[` +
+				`{"Key":"FormaeResourceLabel","Value":"orders"},` +
+				`{"Key":"FormaeStackLabel","Value":"shop"},` +
+				`{"Key":"Env","Value":"prod"}]}`),
+		},
+	}, nil)
+
+	result, err := client.ReadResource(context.Background(), &resource.ReadRequest{
+		NativeID:        "q",
+		ResourceType:    "AWS::SQS::Queue",
+		PriorProperties: json.RawMessage(`{"QueueName":"q","Tags":[{"Key":"FormaeStackLabel","Value":"shop"},{"Key":"Env","Value":"prod"}]}`),
+	})
+
+	require.NoError(t, err)
+	require.JSONEq(t, `{"QueueName":"q","Tags":[{"Key":"FormaeStackLabel","Value":"shop"},{"Key":"Env","Value":"prod"}]}`, result.Properties)
+}
+
+func TestReadResource_StripsUndeclaredDefaultTags(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI, defaultTags: defaultTags, schemas: staticSchemas{
+		"AWS::SQS::Queue": {TagProperty: "/Tags"},
+	}}
+	declared := json.RawMessage(`{"QueueName":"q","Tags":[{"Key":"Owner","Value":"me"}]}`)
+
+	var created string
+	mockAPI.On("CreateResource", mock.Anything, mock.MatchedBy(func(in *cloudcontrol.CreateResourceInput) bool {
+		created = *in.DesiredState
+		return true
+	})).Return(&cloudcontrol.CreateResourceOutput{
+		ProgressEvent: &cctypes.ProgressEvent{
+			OperationStatus: cctypes.OperationStatusInProgress,
+			RequestToken:    ptr.Of("req-token-123"),
+		},
+	}, nil)
+	_, err := client.CreateResource(context.Background(), &resource.CreateRequest{
+		ResourceType: "AWS::SQS::Queue",
+		Properties:   declared,
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"QueueName":"q","Tags":[{"Key":"Owner","Value":"me"},{"Key":"CostCenter","Value":"42"}]}`, created)
+
+	mockAPI.On("GetResource", mock.Anything, mock.Anything).Return(&cloudcontrol.GetResourceOutput{
+		TypeName:            ptr.Of("AWS::SQS::Queue"),
+		ResourceDescription: &cctypes.ResourceDescription{Identifier: ptr.Of("q"), Properties: ptr.Of(created)},
+	}, nil)
+
+	result, err := client.ReadResource(context.Background(), &resource.ReadRequest{
+		NativeID:        "q",
+		ResourceType:    "AWS::SQS::Queue",
+		PriorProperties: declared,
+	})
+
+	require.NoError(t, err)
+	require.JSONEq(t, string(declared), result.Properties)
+}
@@ -0,0 +1,53 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ccx
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/schema"
+)
+
+// schemaViolations checks desired properties against the registry schema:
+// required properties must be set and enum-restricted properties must hold an
+// allowed value. Without a schema nothing is checked.
+func schemaViolations(s *schema.Schema, desired json.RawMessage) ([]schema.Violation, error) {
+	if s == nil || len(desired) == 0 {
+		return nil, nil
+	}
+
+	// Validate what CloudControl would be sent: unset PKL collections are
+	// stripped before they reach it.
+	desired, err := stripEmptyCollections(desired)
+	if err != nil {
+		return nil, fmt.Errorf("failed to strip empty collections: %w", err)
+	}
+	var desiredMap map[string]any
+	if err = json.Unmarshal(desired, &desiredMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal desired properties: %w", err)
+	}
+	return s.Validate(desiredMap), nil
+}
+
+// invalidRequest reports schema violations as a failed operation, so a
+// desired state CloudControl would reject fails before anything is sent to
+// AWS rather than partway through an apply.
+func invalidRequest(operation resource.Operation, nativeID string, violations []schema.Violation) *resource.ProgressResult {
+	messages := make([]string, len(violations))
+	for i, v := range violations {
+		messages[i] = v.String()
+	}
+	return &resource.ProgressResult{
+		Operation:       operation,
+		OperationStatus: resource.OperationStatusFailure,
+		NativeID:        nativeID,
+		StatusMessage:   "desired state violates the resource schema: " + strings.Join(messages, "; "),
+		ErrorCode:       resource.OperationErrorCodeInvalidRequest,
+	}
+}
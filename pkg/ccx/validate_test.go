@@ -0,0 +1,78 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ccx
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/schema"
+)
+
+var queueSchemas = staticSchemas{
+	"AWS::SQS::Queue": {
+		Required: []string{"QueueName"},
+		Enums:    map[string][]any{"DeduplicationScope": {"queue", "messageGroup"}},
+	},
+}
+
+func TestSchemaViolations(t *testing.T) {
+	violations, err := schemaViolations(queueSchemas["AWS::SQS::Queue"], json.RawMessage(`{"DeduplicationScope":"topic","Tags":[]}`))
+
+	require.NoError(t, err)
+	require.Equal(t, []schema.Violation{
+		{Path: "/QueueName", Message: "required property is missing"},
+		{Path: "/DeduplicationScope", Message: "topic is not one of [queue messageGroup]"},
+	}, violations)
+}
+
+func TestSchemaViolations_NoSchema(t *testing.T) {
+	violations, err := schemaViolations(nil, json.RawMessage(`{}`))
+
+	require.NoError(t, err)
+	require.Empty(t, violations)
+}
+
+func TestCreateResource_SchemaViolationIsInvalidRequest(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI, schemas: queueSchemas}
+
+	result, err := client.CreateResource(context.Background(), &resource.CreateRequest{
+		ResourceType: "AWS::SQS::Queue",
+		Properties:   json.RawMessage(`{"DeduplicationScope":"queue"}`),
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+	require.Equal(t, resource.OperationErrorCodeInvalidRequest, result.ProgressResult.ErrorCode)
+	require.Contains(t, result.ProgressResult.StatusMessage, "/QueueName: required property is missing")
+	mockAPI.AssertNotCalled(t, "CreateResource", mock.Anything, mock.Anything)
+}
+
+func TestUpdateResource_SchemaViolationIsInvalidRequest(t *testing.T) {
+	mockAPI := new(mockCloudControlAPI)
+	client := &Client{api: mockAPI, schemas: queueSchemas}
+
+	result, err := client.UpdateResource(context.Background(), &resource.UpdateRequest{
+		NativeID:          "q",
+		ResourceType:      "AWS::SQS::Queue",
+		PriorProperties:   json.RawMessage(`{"QueueName":"q"}`),
+		DesiredProperties: json.RawMessage(`{"QueueName":"q","DeduplicationScope":"topic"}`),
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, resource.OperationErrorCodeInvalidRequest, result.ProgressResult.ErrorCode)
+	require.Equal(t, "q", result.ProgressResult.NativeID)
+	mockAPI.AssertNotCalled(t, "GetResource", mock.Anything, mock.Anything)
+	mockAPI.AssertNotCalled(t, "UpdateResource", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,107 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+// Package cfnstack discovers the resources of an existing CloudFormation
+// stack, so infrastructure managed by CloudFormation can be adopted wholesale.
+// A List request opts in by naming the stack under StackNameProperty in its
+// AdditionalProperties.
+package cfnstack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+// StackNameProperty is the ListRequest.AdditionalProperties key naming the
+// stack whose resources to list. It accepts a stack name or ID.
+const StackNameProperty = "CloudFormationStackName"
+
+// StackResource ties a resource's logical ID in the stack to its
+// CloudControl identifier. CloudFormation's physical ID is the primary
+// identifier for every type with a single-property identifier; types with a
+// composite identifier can't be adopted this way.
+type StackResource struct {
+	LogicalID    string
+	NativeID     string
+	ResourceType string
+}
+
+// cloudFormationAPI defines the CloudFormation operations used by Client.
+type cloudFormationAPI interface {
+	ListStackResources(ctx context.Context, params *cloudformation.ListStackResourcesInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackResourcesOutput, error)
+}
+
+type Client struct {
+	api cloudFormationAPI
+}
+
+var clientPool config.Pool[*Client]
+
+// NewClient returns the Client for cfg, pooled by target config like
+// ccx.NewClient.
+func NewClient(cfg *config.Config) (*Client, error) {
+	return clientPool.Get(cfg, func(cfg *config.Config) (*Client, error) {
+		awsCfg, err := cfg.ToAwsConfig(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("loading AWS config: %w", err)
+		}
+		return &Client{api: cloudformation.NewFromConfig(awsCfg)}, nil
+	})
+}
+
+// Resources returns one page of the live resources of a stack, skipping those
+// that were deleted or never got a physical ID.
+func (c *Client) Resources(ctx context.Context, stackName string, pageToken *string) ([]StackResource, *string, error) {
+	out, err := c.api.ListStackResources(ctx, &cloudformation.ListStackResourcesInput{
+		StackName: aws.String(stackName),
+		NextToken: pageToken,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list resources of stack %s: %w", stackName, err)
+	}
+
+	var resources []StackResource
+	for _, r := range out.StackResourceSummaries {
+		if aws.ToString(r.PhysicalResourceId) == "" || r.ResourceStatus == cfntypes.ResourceStatusDeleteComplete {
+			continue
+		}
+		resources = append(resources, StackResource{
+			LogicalID:    aws.ToString(r.LogicalResourceId),
+			NativeID:     aws.ToString(r.PhysicalResourceId),
+			ResourceType: aws.ToString(r.ResourceType),
+		})
+	}
+	return resources, out.NextToken, nil
+}
+
+// List returns one page of the CloudControl identifiers of the resources of
+// request.ResourceType in the stack named in request.AdditionalProperties.
+func (c *Client) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	stackName := request.AdditionalProperties[StackNameProperty]
+	if stackName == "" {
+		return nil, fmt.Errorf("%s must be provided in AdditionalProperties to list stack resources", StackNameProperty)
+	}
+
+	resources, next, err := c.Resources(ctx, stackName, request.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	nativeIDs := []string{}
+	for _, r := range resources {
+		if r.ResourceType == request.ResourceType {
+			nativeIDs = append(nativeIDs, r.NativeID)
+		}
+	}
+	return &resource.ListResult{
+		NativeIDs:     nativeIDs,
+		NextPageToken: next,
+	}, nil
+}
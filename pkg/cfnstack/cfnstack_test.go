@@ -0,0 +1,87 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package cfnstack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCloudFormationClient struct {
+	mock.Mock
+}
+
+func (m *mockCloudFormationClient) ListStackResources(ctx context.Context, input *cloudformation.ListStackResourcesInput, optFns ...func(*cloudformation.Options)) (*cloudformation.ListStackResourcesOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudformation.ListStackResourcesOutput), args.Error(1)
+}
+
+func summary(logicalID, physicalID, resourceType string, status cfntypes.ResourceStatus) cfntypes.StackResourceSummary {
+	return cfntypes.StackResourceSummary{
+		LogicalResourceId:  aws.String(logicalID),
+		PhysicalResourceId: aws.String(physicalID),
+		ResourceType:       aws.String(resourceType),
+		ResourceStatus:     status,
+	}
+}
+
+func TestList_FiltersByType(t *testing.T) {
+	api := &mockCloudFormationClient{}
+	api.On("ListStackResources", mock.Anything, mock.MatchedBy(func(in *cloudformation.ListStackResourcesInput) bool {
+		return aws.ToString(in.StackName) == "legacy" && aws.ToString(in.NextToken) == "page-2"
+	})).Return(&cloudformation.ListStackResourcesOutput{
+		StackResourceSummaries: []cfntypes.StackResourceSummary{
+			summary("Vpc", "vpc-1", "AWS::EC2::VPC", cfntypes.ResourceStatusCreateComplete),
+			summary("Bucket", "legacy-bucket", "AWS::S3::Bucket", cfntypes.ResourceStatusUpdateComplete),
+			summary("OldBucket", "old-bucket", "AWS::S3::Bucket", cfntypes.ResourceStatusDeleteComplete),
+			summary("Pending", "", "AWS::S3::Bucket", cfntypes.ResourceStatusCreateInProgress),
+		},
+		NextToken: aws.String("page-3"),
+	}, nil)
+
+	client := &Client{api: api}
+	result, err := client.List(context.Background(), &resource.ListRequest{
+		ResourceType:         "AWS::S3::Bucket",
+		PageToken:            aws.String("page-2"),
+		AdditionalProperties: map[string]string{StackNameProperty: "legacy"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"legacy-bucket"}, result.NativeIDs)
+	assert.Equal(t, "page-3", aws.ToString(result.NextPageToken))
+}
+
+func TestResources_MapsLogicalIDs(t *testing.T) {
+	api := &mockCloudFormationClient{}
+	api.On("ListStackResources", mock.Anything, mock.Anything).Return(&cloudformation.ListStackResourcesOutput{
+		StackResourceSummaries: []cfntypes.StackResourceSummary{
+			summary("Vpc", "vpc-1", "AWS::EC2::VPC", cfntypes.ResourceStatusCreateComplete),
+		},
+	}, nil)
+
+	client := &Client{api: api}
+	resources, next, err := client.Resources(context.Background(), "legacy", nil)
+	require.NoError(t, err)
+	assert.Nil(t, next)
+	assert.Equal(t, []StackResource{{LogicalID: "Vpc", NativeID: "vpc-1", ResourceType: "AWS::EC2::VPC"}}, resources)
+}
+
+func TestList_RequiresStackName(t *testing.T) {
+	client := &Client{api: &mockCloudFormationClient{}}
+	_, err := client.List(context.Background(), &resource.ListRequest{ResourceType: "AWS::S3::Bucket"})
+	assert.Error(t, err)
+}
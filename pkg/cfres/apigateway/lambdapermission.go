@@ -0,0 +1,241 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package apigateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+// lambdaPermissionClient is the narrow Lambda surface used to grant and revoke
+// API Gateway's permission to invoke an integration's function. Defined here so
+// unit tests can inject a mock.
+type lambdaPermissionClient interface {
+	AddPermission(ctx context.Context, params *lambda.AddPermissionInput, optFns ...func(*lambda.Options)) (*lambda.AddPermissionOutput, error)
+	RemovePermission(ctx context.Context, params *lambda.RemovePermissionInput, optFns ...func(*lambda.Options)) (*lambda.RemovePermissionOutput, error)
+}
+
+func defaultLambdaClientFactory(cfg *config.Config) (lambdaPermissionClient, error) {
+	awsCfg, err := cfg.ToAwsConfig(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("apigateway method: build AWS config: %w", err)
+	}
+	return lambda.NewFromConfig(awsCfg), nil
+}
+
+// methodKey identifies a Method: the RestApiId, ResourceId and HttpMethod that
+// also make up its NativeID, "<restApiId>|<resourceId>|<httpMethod>".
+type methodKey struct {
+	restApiID  string
+	resourceID string
+	httpMethod string
+}
+
+func methodKeyFromProperties(props map[string]any) (methodKey, bool) {
+	key := methodKey{}
+	key.restApiID, _ = props["RestApiId"].(string)
+	key.resourceID, _ = props["ResourceId"].(string)
+	key.httpMethod, _ = props["HttpMethod"].(string)
+	return key, key.restApiID != "" && key.resourceID != "" && key.httpMethod != ""
+}
+
+func methodKeyFromNativeID(nativeID string) (methodKey, bool) {
+	parts := strings.Split(nativeID, "|")
+	if len(parts) != 3 {
+		return methodKey{}, false
+	}
+	return methodKeyFromProperties(map[string]any{"RestApiId": parts[0], "ResourceId": parts[1], "HttpMethod": parts[2]})
+}
+
+// statementID names the function policy statement granted for a method, so
+// the same method always maps to the same statement.
+func (k methodKey) statementID() string {
+	return fmt.Sprintf("formae-apigateway-%s-%s-%s", k.restApiID, k.resourceID, strings.ToUpper(k.httpMethod))
+}
+
+// sourceArn scopes the grant to the method's API and HTTP method, on any
+// stage and resource path. An ANY method is granted every HTTP method.
+func (k methodKey) sourceArn(identity callerIdentity, region string) string {
+	method := strings.ToUpper(k.httpMethod)
+	if method == "ANY" {
+		method = "*"
+	}
+	return fmt.Sprintf("arn:%s:execute-api:%s:%s:%s/*/%s/*", identity.partition, region, identity.account, k.restApiID, method)
+}
+
+// integrationLambdaArn returns the LambdaFunctionArn of an Integration, or ""
+// for an integration that isn't a Lambda one.
+func integrationLambdaArn(integration any) string {
+	fields, _ := integration.(map[string]any)
+	lambdaArn, _ := fields["LambdaFunctionArn"].(string)
+	return lambdaArn
+}
+
+// propertiesLambdaArn returns the LambdaFunctionArn of the Integration in a
+// Method's properties, or "" if there is none.
+func propertiesLambdaArn(properties []byte) string {
+	var props map[string]any
+	if len(properties) == 0 || json.Unmarshal(properties, &props) != nil {
+		return ""
+	}
+	return integrationLambdaArn(props["Integration"])
+}
+
+// patchLambdaArn returns the LambdaFunctionArn an update patch sets the
+// Integration to, and whether the patch changes the Integration at all.
+func patchLambdaArn(patchDoc string) (string, bool) {
+	var ops []map[string]any
+	if err := json.Unmarshal([]byte(patchDoc), &ops); err != nil {
+		return "", false
+	}
+	lambdaArn, touched := "", false
+	for _, op := range ops {
+		if path, _ := op["path"].(string); path != "/Integration" {
+			continue
+		}
+		touched = true
+		if name, _ := op["op"].(string); name == "add" || name == "replace" {
+			lambdaArn = integrationLambdaArn(op["value"])
+		} else {
+			lambdaArn = ""
+		}
+	}
+	return lambdaArn, touched
+}
+
+// grantInvoke adds the permission for API Gateway to invoke lambdaArn through
+// the method. A statement that already exists is taken as granted.
+func grantInvoke(ctx context.Context, client lambdaPermissionClient, key methodKey, identity callerIdentity, region, lambdaArn string) error {
+	_, err := client.AddPermission(ctx, &lambda.AddPermissionInput{
+		FunctionName: aws.String(lambdaArn),
+		StatementId:  aws.String(key.statementID()),
+		Action:       aws.String("lambda:InvokeFunction"),
+		Principal:    aws.String("apigateway.amazonaws.com"),
+		SourceArn:    aws.String(key.sourceArn(identity, region)),
+	})
+	var conflict *lambdatypes.ResourceConflictException
+	if err != nil && !errors.As(err, &conflict) {
+		return fmt.Errorf("granting API Gateway permission to invoke %s: %w", lambdaArn, err)
+	}
+	return nil
+}
+
+// revokeInvoke removes the permission grantInvoke added. A leftover grant only
+// lets the method's own API invoke the function, so a failure is logged rather
+// than failing the operation.
+func revokeInvoke(ctx context.Context, client lambdaPermissionClient, key methodKey, lambdaArn string) {
+	_, err := client.RemovePermission(ctx, &lambda.RemovePermissionInput{
+		FunctionName: aws.String(lambdaArn),
+		StatementId:  aws.String(key.statementID()),
+	})
+	var notFound *lambdatypes.ResourceNotFoundException
+	if err != nil && !errors.As(err, &notFound) {
+		plugin.LoggerFromContext(ctx).Warn("ApiGateway::Method: failed to revoke Lambda invoke permission",
+			"function", lambdaArn, "statementId", key.statementID(), "error", err)
+	}
+}
+
+// permissionsRequestIDPrefix marks the RequestID of a create, update or
+// delete whose invoke permissions are settled once it succeeds. The RequestID
+// is the prefix, the function the method invoked before the operation ("" if
+// none) and the CloudControl RequestID, joined by "|".
+const permissionsRequestIDPrefix = "lambda-permissions|"
+
+func permissionsRequestID(previousArn, requestID string) string {
+	return permissionsRequestIDPrefix + previousArn + "|" + requestID
+}
+
+// splitPermissionsRequestID undoes permissionsRequestID. It reports false for
+// a RequestID that carries no permissions to settle.
+func splitPermissionsRequestID(requestID string) (previousArn, inner string, ok bool) {
+	rest, ok := strings.CutPrefix(requestID, permissionsRequestIDPrefix)
+	if !ok {
+		return "", "", false
+	}
+	return strings.Cut(rest, "|")
+}
+
+// permissionsAfter settles the invoke permissions of an operation on the
+// method nativeID once it has succeeded: right away if pr reports it already
+// has, or, while it is pending or in progress, from Status by marking pr's
+// RequestID. A failed operation leaves the permissions as they were, so a
+// create that fails leaves no grant behind and an update that fails keeps the
+// function it still invokes reachable.
+func (m *Method) permissionsAfter(ctx context.Context, pr *resource.ProgressResult, nativeID, previousArn string) error {
+	switch pr.OperationStatus {
+	case resource.OperationStatusSuccess:
+		return m.settlePermissions(ctx, pr, nativeID, previousArn)
+	case resource.OperationStatusPending, resource.OperationStatusInProgress:
+		pr.RequestID = permissionsRequestID(previousArn, pr.RequestID)
+	}
+	return nil
+}
+
+// settlePermissions grants the invoke permission to the function a created or
+// updated method now invokes, and revokes it from previousArn, the function
+// it invoked before, if that changed or the method was deleted.
+func (m *Method) settlePermissions(ctx context.Context, pr *resource.ProgressResult, nativeID, previousArn string) error {
+	if pr.NativeID != "" {
+		nativeID = pr.NativeID
+	}
+	key, ok := methodKeyFromNativeID(nativeID)
+	if !ok {
+		return fmt.Errorf("apigateway method: unexpected NativeID %q", nativeID)
+	}
+
+	var lambdaArn string
+	if pr.Operation != resource.OperationDelete {
+		properties := string(pr.ResourceProperties)
+		if properties == "" {
+			read, err := m.Read(ctx, &resource.ReadRequest{NativeID: nativeID, ResourceType: "AWS::ApiGateway::Method"})
+			if err != nil {
+				return fmt.Errorf("apigateway method: reading %s to grant the Lambda invoke permission: %w", nativeID, err)
+			}
+			properties = read.Properties
+		}
+		// A read straight from CloudControl carries the invocation Uri rather
+		// than LambdaFunctionArn.
+		if normalized, err := normalizeIntegrationOnRead(properties); err == nil {
+			properties = normalized
+		}
+		if lambdaArn = propertiesLambdaArn([]byte(properties)); lambdaArn != "" {
+			if err := m.grant(ctx, key, lambdaArn); err != nil {
+				return err
+			}
+		}
+	}
+
+	if previousArn != "" && previousArn != lambdaArn {
+		client, err := m.lambdaClientFactory(m.cfg)
+		if err != nil {
+			return err
+		}
+		revokeInvoke(ctx, client, key, previousArn)
+	}
+	return nil
+}
+
+func (m *Method) grant(ctx context.Context, key methodKey, lambdaArn string) error {
+	identity, err := resolveCallerIdentity(ctx, m.cfg, m.stsClientFactory)
+	if err != nil {
+		return fmt.Errorf("apigateway method: resolve caller identity: %w", err)
+	}
+	client, err := m.lambdaClientFactory(m.cfg)
+	if err != nil {
+		return err
+	}
+	return grantInvoke(ctx, client, key, identity, m.cfg.Region, lambdaArn)
+}
@@ -0,0 +1,34 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package apigateway
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockLambdaClient struct {
+	mock.Mock
+}
+
+func (m *mockLambdaClient) AddPermission(ctx context.Context, input *lambda.AddPermissionInput, optFns ...func(*lambda.Options)) (*lambda.AddPermissionOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*lambda.AddPermissionOutput), args.Error(1)
+}
+
+func (m *mockLambdaClient) RemovePermission(ctx context.Context, input *lambda.RemovePermissionInput, optFns ...func(*lambda.Options)) (*lambda.RemovePermissionOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*lambda.RemovePermissionOutput), args.Error(1)
+}
@@ -0,0 +1,215 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package apigateway
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+const (
+	fleetArn = "arn:aws:lambda:eu-west-1:123456789012:function:Fleet"
+	otherArn = "arn:aws:lambda:eu-west-1:123456789012:function:Other"
+)
+
+func newPermissionMethod(t *testing.T, client *mockLambdaClient) *Method {
+	t.Helper()
+	resetIdentityCache()
+	stsClient := &mockStsClient{}
+	stsClient.On("GetCallerIdentity", mock.Anything, mock.Anything).Return(&sts.GetCallerIdentityOutput{
+		Account: aws.String("123456789012"),
+		Arn:     aws.String("arn:aws:iam::123456789012:role/formae"),
+	}, nil)
+	return &Method{
+		cfg:                 &config.Config{Region: "eu-west-1", ApiGatewayLambdaPermissions: true},
+		stsClientFactory:    func(*config.Config) (stsClientInterface, error) { return stsClient, nil },
+		lambdaClientFactory: func(*config.Config) (lambdaPermissionClient, error) { return client, nil },
+	}
+}
+
+func TestMethodKey_SourceArn(t *testing.T) {
+	identity := callerIdentity{account: "123456789012", partition: "aws"}
+
+	get := methodKey{restApiID: "a1b2c3", resourceID: "r1", httpMethod: "get"}
+	assert.Equal(t, "arn:aws:execute-api:eu-west-1:123456789012:a1b2c3/*/GET/*", get.sourceArn(identity, "eu-west-1"))
+	assert.Equal(t, "formae-apigateway-a1b2c3-r1-GET", get.statementID())
+
+	anyMethod := methodKey{restApiID: "a1b2c3", resourceID: "r1", httpMethod: "ANY"}
+	assert.Equal(t, "arn:aws:execute-api:eu-west-1:123456789012:a1b2c3/*/*/*", anyMethod.sourceArn(identity, "eu-west-1"))
+}
+
+func created(properties string) *resource.ProgressResult {
+	return &resource.ProgressResult{
+		Operation:          resource.OperationCreate,
+		OperationStatus:    resource.OperationStatusSuccess,
+		NativeID:           "a1b2c3|r1|POST",
+		ResourceProperties: json.RawMessage(properties),
+	}
+}
+
+func TestMethod_SettlePermissions_Create(t *testing.T) {
+	ctx := context.Background()
+	client := &mockLambdaClient{}
+	client.On("AddPermission", ctx, &lambda.AddPermissionInput{
+		FunctionName: aws.String(fleetArn),
+		StatementId:  aws.String("formae-apigateway-a1b2c3-r1-POST"),
+		Action:       aws.String("lambda:InvokeFunction"),
+		Principal:    aws.String("apigateway.amazonaws.com"),
+		SourceArn:    aws.String("arn:aws:execute-api:eu-west-1:123456789012:a1b2c3/*/POST/*"),
+	}).Return(&lambda.AddPermissionOutput{}, nil)
+
+	err := newPermissionMethod(t, client).settlePermissions(ctx, created(`{"RestApiId":"a1b2c3","ResourceId":"r1","HttpMethod":"POST",`+
+		`"Integration":{"Type":"AWS_PROXY","Uri":"arn:aws:apigateway:eu-west-1:lambda:path/2015-03-31/functions/`+fleetArn+`/invocations"}}`), "", "")
+
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestMethod_SettlePermissions_AlreadyGranted(t *testing.T) {
+	ctx := context.Background()
+	client := &mockLambdaClient{}
+	client.On("AddPermission", ctx, mock.Anything).Return(nil, &lambdatypes.ResourceConflictException{Message: aws.String("exists")})
+
+	err := newPermissionMethod(t, client).settlePermissions(ctx, created(`{"Integration":{"Type":"AWS_PROXY","LambdaFunctionArn":"`+fleetArn+`"}}`), "", "")
+
+	assert.NoError(t, err)
+}
+
+func TestMethod_SettlePermissions_NotLambda(t *testing.T) {
+	client := &mockLambdaClient{}
+
+	err := newPermissionMethod(t, client).settlePermissions(context.Background(),
+		created(`{"Integration":{"Type":"HTTP","Uri":"https://example.com"}}`), "", "")
+
+	require.NoError(t, err)
+	client.AssertNotCalled(t, "AddPermission", mock.Anything, mock.Anything)
+}
+
+func TestMethod_SettlePermissions_Repointed(t *testing.T) {
+	ctx := context.Background()
+	client := &mockLambdaClient{}
+	client.On("AddPermission", ctx, mock.MatchedBy(func(input *lambda.AddPermissionInput) bool {
+		return aws.ToString(input.FunctionName) == otherArn
+	})).Return(&lambda.AddPermissionOutput{}, nil)
+	client.On("RemovePermission", ctx, &lambda.RemovePermissionInput{
+		FunctionName: aws.String(fleetArn),
+		StatementId:  aws.String("formae-apigateway-a1b2c3-r1-POST"),
+	}).Return(&lambda.RemovePermissionOutput{}, nil)
+
+	pr := created(`{"Integration":{"Type":"AWS_PROXY","LambdaFunctionArn":"` + otherArn + `"}}`)
+	pr.Operation = resource.OperationUpdate
+	err := newPermissionMethod(t, client).settlePermissions(ctx, pr, "a1b2c3|r1|POST", fleetArn)
+
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestMethod_PrepareUpdate_DesiredPropertiesRepointed(t *testing.T) {
+	request := &resource.UpdateRequest{
+		NativeID:          "a1b2c3|r1|POST",
+		PriorProperties:   json.RawMessage(`{"Integration":{"Type":"AWS_PROXY","LambdaFunctionArn":"` + fleetArn + `"}}`),
+		DesiredProperties: json.RawMessage(`{"Integration":{"Type":"AWS_PROXY","LambdaFunctionArn":"` + otherArn + `"}}`),
+	}
+
+	repointed, previousArn, err := newPermissionMethod(t, &mockLambdaClient{}).prepareUpdate(context.Background(), request)
+
+	require.NoError(t, err)
+	assert.True(t, repointed)
+	assert.Equal(t, fleetArn, previousArn)
+	assert.NotContains(t, string(request.DesiredProperties), "LambdaFunctionArn")
+	assert.Contains(t, string(request.DesiredProperties), otherArn+"/invocations")
+}
+
+func TestMethod_PrepareUpdate_DesiredPropertiesSameFunction(t *testing.T) {
+	request := &resource.UpdateRequest{
+		NativeID:          "a1b2c3|r1|POST",
+		PriorProperties:   json.RawMessage(`{"AuthorizationType":"NONE","Integration":{"Type":"AWS_PROXY","LambdaFunctionArn":"` + fleetArn + `"}}`),
+		DesiredProperties: json.RawMessage(`{"AuthorizationType":"AWS_IAM","Integration":{"Type":"AWS_PROXY","LambdaFunctionArn":"` + fleetArn + `"}}`),
+	}
+
+	repointed, _, err := newPermissionMethod(t, &mockLambdaClient{}).prepareUpdate(context.Background(), request)
+
+	require.NoError(t, err)
+	assert.False(t, repointed)
+}
+
+func TestMethod_SettlePermissions_Deleted(t *testing.T) {
+	ctx := context.Background()
+	client := &mockLambdaClient{}
+	client.On("RemovePermission", ctx, mock.MatchedBy(func(input *lambda.RemovePermissionInput) bool {
+		return aws.ToString(input.FunctionName) == fleetArn
+	})).Return(&lambda.RemovePermissionOutput{}, nil)
+
+	pr := &resource.ProgressResult{Operation: resource.OperationDelete, OperationStatus: resource.OperationStatusSuccess}
+	err := newPermissionMethod(t, client).settlePermissions(ctx, pr, "a1b2c3|r1|POST", fleetArn)
+
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "AddPermission", mock.Anything, mock.Anything)
+}
+
+func TestMethod_PermissionsAfter_InProgressDefersToStatus(t *testing.T) {
+	client := &mockLambdaClient{}
+	pr := &resource.ProgressResult{
+		Operation:       resource.OperationUpdate,
+		OperationStatus: resource.OperationStatusInProgress,
+		RequestID:       "req-token-123",
+	}
+
+	err := newPermissionMethod(t, client).permissionsAfter(context.Background(), pr, "a1b2c3|r1|POST", fleetArn)
+
+	require.NoError(t, err)
+	client.AssertNotCalled(t, "AddPermission", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "RemovePermission", mock.Anything, mock.Anything)
+	previousArn, requestID, ok := splitPermissionsRequestID(pr.RequestID)
+	require.True(t, ok)
+	assert.Equal(t, fleetArn, previousArn)
+	assert.Equal(t, "req-token-123", requestID)
+}
+
+func TestMethod_PermissionsAfter_FailureLeavesPermissions(t *testing.T) {
+	client := &mockLambdaClient{}
+	pr := &resource.ProgressResult{
+		Operation:       resource.OperationCreate,
+		OperationStatus: resource.OperationStatusFailure,
+		RequestID:       "req-token-123",
+	}
+
+	err := newPermissionMethod(t, client).permissionsAfter(context.Background(), pr, "", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "req-token-123", pr.RequestID)
+	client.AssertNotCalled(t, "AddPermission", mock.Anything, mock.Anything)
+}
+
+func TestSplitPermissionsRequestID_Unmarked(t *testing.T) {
+	_, _, ok := splitPermissionsRequestID("req-token-123")
+
+	assert.False(t, ok)
+}
+
+func TestRevokeInvoke_AlreadyGone(t *testing.T) {
+	ctx := context.Background()
+	client := &mockLambdaClient{}
+	client.On("RemovePermission", ctx, mock.Anything).Return(nil, &lambdatypes.ResourceNotFoundException{Message: aws.String("gone")})
+
+	revokeInvoke(ctx, client, methodKey{restApiID: "a1b2c3", resourceID: "r1", httpMethod: "GET"}, fleetArn)
+
+	client.AssertExpectations(t)
+}
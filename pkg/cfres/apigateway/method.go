@@ -13,14 +13,24 @@ import (
 
 	"github.com/platform-engineering-labs/formae/pkg/plugin"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/arn"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ccx"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
 )
 
+// Method transforms Lambda integrations between the formae-only
+// LambdaFunctionArn and the invocation Uri CloudControl expects. When the
+// target sets ApiGatewayLambdaPermissions, it also grants API Gateway
+// permission to invoke the integration's function, and revokes it when the
+// integration is re-pointed or the method deleted, once the change succeeds.
 type Method struct {
 	cfg *config.Config
+	// stsClientFactory and lambdaClientFactory build the clients used to grant
+	// Lambda permissions. Tests inject fakes.
+	stsClientFactory    func(cfg *config.Config) (stsClientInterface, error)
+	lambdaClientFactory func(cfg *config.Config) (lambdaPermissionClient, error)
 }
 
 var _ prov.Provisioner = &Method{}
@@ -33,11 +43,16 @@ func init() {
 			resource.OperationUpdate,
 			resource.OperationDelete},
 		func(cfg *config.Config) prov.Provisioner {
-			return &Method{cfg: cfg}
+			return &Method{cfg: cfg, stsClientFactory: defaultStsClientFactory, lambdaClientFactory: defaultLambdaClientFactory}
 		})
 }
 
 func (m *Method) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	var lambdaArn string
+	if m.cfg.ApiGatewayLambdaPermissions {
+		lambdaArn = propertiesLambdaArn(request.Properties)
+	}
+
 	transformedProperties, err := m.handleLambdaIntegration(ctx, request.Properties)
 	if err != nil {
 		plugin.LoggerFromContext(ctx).Error("ApiGateway::Method: Failed to transform Lambda integration", "error", err)
@@ -51,27 +66,62 @@ func (m *Method) Create(ctx context.Context, request *resource.CreateRequest) (*
 		return nil, err
 	}
 
-	return ccxClient.CreateResource(ctx, request)
+	result, err := ccxClient.CreateResource(ctx, request)
+	if err != nil || lambdaArn == "" || result.ProgressResult == nil {
+		return result, err
+	}
+	return result, m.permissionsAfter(ctx, result.ProgressResult, "", "")
 }
 
 func (m *Method) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
-	// CloudControl updates apply the patch document, not DesiredProperties, so the
-	// Lambda integration transform has to run on the patch the same way Create
-	// runs it on the properties.
+	repointed, previousArn, err := m.prepareUpdate(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	ccxClient, err := ccx.NewClient(m.cfg)
+	if err != nil {
+		return nil, err
+	}
+	result, err := ccxClient.UpdateResource(ctx, request)
+	if err != nil || !repointed || result.ProgressResult == nil {
+		return result, err
+	}
+	return result, m.permissionsAfter(ctx, result.ProgressResult, request.NativeID, previousArn)
+}
+
+// prepareUpdate runs the Lambda integration transform on an update. CloudControl
+// applies the patch document when there is one, and a patch computed from
+// DesiredProperties otherwise, so the transform runs on whichever the update
+// carries, the same way Create runs it on the properties. It reports whether
+// the update changes the function the method invokes, and the function it
+// invoked before.
+func (m *Method) prepareUpdate(ctx context.Context, request *resource.UpdateRequest) (repointed bool, previousArn string, err error) {
+	if m.cfg.ApiGatewayLambdaPermissions {
+		previousArn = propertiesLambdaArn(request.PriorProperties)
+	}
 	if request.PatchDocument != nil {
+		if m.cfg.ApiGatewayLambdaPermissions {
+			_, repointed = patchLambdaArn(*request.PatchDocument)
+		}
 		transformedPatch, err := m.transformLambdaIntegrationPatch(*request.PatchDocument)
 		if err != nil {
 			plugin.LoggerFromContext(ctx).Error("ApiGateway::Method: Failed to transform Lambda integration patch", "error", err)
-			return nil, err
+			return false, "", err
 		}
 		request.PatchDocument = &transformedPatch
+	} else if len(request.DesiredProperties) > 0 {
+		if m.cfg.ApiGatewayLambdaPermissions {
+			repointed = propertiesLambdaArn(request.DesiredProperties) != previousArn
+		}
+		transformedProperties, err := m.handleLambdaIntegration(ctx, request.DesiredProperties)
+		if err != nil {
+			plugin.LoggerFromContext(ctx).Error("ApiGateway::Method: Failed to transform Lambda integration", "error", err)
+			return false, "", err
+		}
+		request.DesiredProperties = transformedProperties
 	}
-
-	ccxClient, err := ccx.NewClient(m.cfg)
-	if err != nil {
-		return nil, err
-	}
-	return ccxClient.UpdateResource(ctx, request)
+	return repointed, previousArn, nil
 }
 
 func (m *Method) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
@@ -99,7 +149,21 @@ func (m *Method) Delete(ctx context.Context, request *resource.DeleteRequest) (*
 	if err != nil {
 		return nil, err
 	}
-	return ccxClient.DeleteResource(ctx, request)
+	if !m.cfg.ApiGatewayLambdaPermissions {
+		return ccxClient.DeleteResource(ctx, request)
+	}
+
+	// The function is only known from the method itself, so it is read before
+	// the method is deleted.
+	var lambdaArn string
+	if read, err := m.Read(ctx, &resource.ReadRequest{NativeID: request.NativeID, ResourceType: request.ResourceType}); err == nil && read != nil {
+		lambdaArn = propertiesLambdaArn([]byte(read.Properties))
+	}
+	result, err := ccxClient.DeleteResource(ctx, request)
+	if err != nil || lambdaArn == "" || result.ProgressResult == nil {
+		return result, err
+	}
+	return result, m.permissionsAfter(ctx, result.ProgressResult, request.NativeID, lambdaArn)
 }
 
 func (m *Method) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
@@ -107,7 +171,24 @@ func (m *Method) Status(ctx context.Context, request *resource.StatusRequest) (*
 	if err != nil {
 		return nil, err
 	}
-	return ccxClient.StatusResource(ctx, request, m.Read)
+
+	previousArn, requestID, settle := splitPermissionsRequestID(request.RequestID)
+	if !settle {
+		return ccxClient.StatusResource(ctx, request, m.Read)
+	}
+	inner := *request
+	inner.RequestID = requestID
+	result, err := ccxClient.StatusResource(ctx, &inner, m.Read)
+	if err != nil || result.ProgressResult == nil {
+		return result, err
+	}
+	result.ProgressResult.RequestID = request.RequestID
+	if result.ProgressResult.OperationStatus == resource.OperationStatusSuccess {
+		if err := m.settlePermissions(ctx, result.ProgressResult, request.NativeID, previousArn); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
 }
 
 func (m *Method) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
@@ -168,8 +249,10 @@ func (m *Method) integrationLambdaArnToURI(integration map[string]any) error {
 		return fmt.Errorf("failed to extract region from Lambda ARN: %w", err)
 	}
 
-	integration["Uri"] = fmt.Sprintf("arn:aws:apigateway:%s:lambda:path/2015-03-31/functions/%s/invocations",
-		region, lambdaArnStr)
+	// The invocation Uri lives in the same partition as the function, so
+	// China and GovCloud functions get arn:aws-cn / arn:aws-us-gov URIs.
+	integration["Uri"] = fmt.Sprintf("arn:%s:apigateway:%s:lambda:path/2015-03-31/functions/%s/invocations",
+		arn.PartitionFrom(lambdaArnStr), region, lambdaArnStr)
 	delete(integration, "LambdaFunctionArn")
 
 	return nil
@@ -221,14 +304,14 @@ func (m *Method) transformLambdaIntegrationPatch(patchDoc string) (string, error
 	return string(transformed), nil
 }
 
-func (m *Method) extractRegionFromLambdaArn(arn string) (string, error) {
+func (m *Method) extractRegionFromLambdaArn(lambdaArn string) (string, error) {
 
-	// Note: Lambda ARN format = arn:aws:lambda:region:account:function:name
-	parts := strings.Split(arn, ":")
-	if len(parts) >= 4 && parts[0] == "arn" && parts[1] == "aws" && parts[2] == "lambda" {
+	// Note: Lambda ARN format = arn:<partition>:lambda:region:account:function:name
+	parts := strings.Split(lambdaArn, ":")
+	if len(parts) >= 4 && parts[0] == "arn" && strings.HasPrefix(parts[1], "aws") && parts[2] == "lambda" {
 		return parts[3], nil
 	}
-	return "", fmt.Errorf("invalid Lambda ARN format: %s", arn)
+	return "", fmt.Errorf("invalid Lambda ARN format: %s", lambdaArn)
 }
 
 // lambdaInvocationURIPattern matches a Lambda-proxy integration Uri of the form
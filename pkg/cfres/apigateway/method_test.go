@@ -184,6 +184,29 @@ func TestTransformLambdaIntegrationPatch_AtomicAdd(t *testing.T) {
 	assert.False(t, hasArn)
 }
 
+func TestIntegrationLambdaArnToURI_ChinaPartition(t *testing.T) {
+	m := &Method{}
+	integration := map[string]any{
+		"Type":              "AWS_PROXY",
+		"LambdaFunctionArn": "arn:aws-cn:lambda:cn-north-1:123456789012:function:Fleet",
+	}
+
+	require.NoError(t, m.integrationLambdaArnToURI(integration))
+
+	assert.Equal(t, "arn:aws-cn:apigateway:cn-north-1:lambda:path/2015-03-31/functions/arn:aws-cn:lambda:cn-north-1:123456789012:function:Fleet/invocations", integration["Uri"])
+}
+
+func TestIntegrationLambdaArnToURI_GovPartitionRoundTrips(t *testing.T) {
+	m := &Method{}
+	lambdaArn := "arn:aws-us-gov:lambda:us-gov-west-1:123456789012:function:Fleet"
+	integration := map[string]any{"Type": "AWS_PROXY", "LambdaFunctionArn": lambdaArn}
+
+	require.NoError(t, m.integrationLambdaArnToURI(integration))
+	reverseLambdaIntegrationURI(integration)
+
+	assert.Equal(t, lambdaArn, integration["LambdaFunctionArn"])
+}
+
 func TestTransformLambdaIntegrationPatch_HttpIntegrationUntouched(t *testing.T) {
 	m := &Method{}
 	in := `[{"op":"replace","path":"/Integration","value":{"Type":"HTTP_PROXY","Uri":"https://example.com/orders"}}]`
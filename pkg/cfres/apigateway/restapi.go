@@ -97,14 +97,11 @@ type callerIdentity struct {
 	partition string
 }
 
-// identityCacheKey scopes the memo to a credential set. The plugin Config
-// exposes only Profile and Region, which together determine the account
-// (credentials) and partition (region) — so this is the finest credential
-// distinction available, and keying by it keeps the cache multi-account-safe.
-type identityCacheKey struct {
-	profile string
-	region  string
-}
+// identityCacheKey scopes the memo to a credential set. Profile and Region
+// alone no longer determine the account once a target assumes a role, so the
+// key is the full target config (config.Config.Key), which keeps the cache
+// multi-account-safe.
+type identityCacheKey string
 
 var (
 	identityCacheMu sync.Mutex
@@ -121,7 +118,7 @@ func (r *RestApi) enrichWithExecuteApiArn(ctx context.Context, props map[string]
 		// Never overwrite a value a future CloudControl schema might supply.
 		return
 	}
-	identity, err := r.resolveCallerIdentity(ctx)
+	identity, err := resolveCallerIdentity(ctx, r.cfg, r.stsClientFactory)
 	if err != nil {
 		plugin.LoggerFromContext(ctx).Warn("apigateway restapi: caller identity unavailable; execute-api ARN not derived", "error", err)
 		return
@@ -136,9 +133,10 @@ func (r *RestApi) enrichWithExecuteApiArn(ctx context.Context, props map[string]
 // credentials, memoized by (profile, region). On a cache miss it builds the STS
 // client and calls GetCallerIdentity once; the result is invariant for the
 // credential set, so every subsequent Read reuses it. A failed lookup is not
-// cached, so a transient STS error can be retried on the next Read.
-func (r *RestApi) resolveCallerIdentity(ctx context.Context) (callerIdentity, error) {
-	key := identityCacheKey{profile: r.cfg.Profile, region: r.cfg.Region}
+// cached, so a transient STS error can be retried on the next Read. Method
+// shares the memo to scope the Lambda permissions it grants.
+func resolveCallerIdentity(ctx context.Context, cfg *config.Config, stsClientFactory func(cfg *config.Config) (stsClientInterface, error)) (callerIdentity, error) {
+	key := identityCacheKey(cfg.Key())
 
 	identityCacheMu.Lock()
 	cached, ok := identityCache[key]
@@ -147,7 +145,7 @@ func (r *RestApi) resolveCallerIdentity(ctx context.Context) (callerIdentity, er
 		return cached, nil
 	}
 
-	stsClient, err := r.stsClientFactory(r.cfg)
+	stsClient, err := stsClientFactory(cfg)
 	if err != nil {
 		return callerIdentity{}, fmt.Errorf("build STS client: %w", err)
 	}
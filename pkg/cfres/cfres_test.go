@@ -17,7 +17,46 @@ func TestGetProvisioner(t *testing.T) {
 }
 
 func TestRolePolicyRegistration(t *testing.T) {
-	assert.NotNil(t, GetProvisionerForOperation("AWS::IAM::RolePolicy", resource.OperationList, &config.Config{Region: "us-east-1"}))
+	cfg := &config.Config{Region: "us-east-1"}
+	assert.NotNil(t, GetProvisionerForOperation("AWS::IAM::RolePolicy", resource.OperationCreate, cfg))
+	assert.NotNil(t, GetProvisionerForOperation("AWS::IAM::RolePolicy", resource.OperationRead, cfg))
+	assert.NotNil(t, GetProvisionerForOperation("AWS::IAM::RolePolicy", resource.OperationUpdate, cfg))
+	assert.NotNil(t, GetProvisionerForOperation("AWS::IAM::RolePolicy", resource.OperationDelete, cfg))
+	assert.NotNil(t, GetProvisionerForOperation("AWS::IAM::RolePolicy", resource.OperationList, cfg))
+	assert.Nil(t, GetProvisionerForOperation("AWS::IAM::RolePolicy", resource.OperationCheckStatus, cfg))
+}
+
+func TestRoleRegistration(t *testing.T) {
+	cfg := &config.Config{Region: "us-east-1"}
+	assert.NotNil(t, GetProvisionerForOperation("AWS::IAM::Role", resource.OperationRead, cfg))
+	assert.NotNil(t, GetProvisionerForOperation("AWS::IAM::Role", resource.OperationDelete, cfg))
+	assert.Nil(t, GetProvisionerForOperation("AWS::IAM::Role", resource.OperationCreate, cfg))
+	assert.NotNil(t, GetProvisionerForOperation("AWS::IAM::Role", resource.OperationList, cfg))
+}
+
+func TestOIDCProviderRegistration(t *testing.T) {
+	cfg := &config.Config{Region: "us-east-1"}
+	assert.NotNil(t, GetProvisionerForOperation("AWS::IAM::OIDCProvider", resource.OperationCreate, cfg))
+	assert.Nil(t, GetProvisionerForOperation("AWS::IAM::OIDCProvider", resource.OperationRead, cfg))
+	assert.Nil(t, GetProvisionerForOperation("AWS::IAM::OIDCProvider", resource.OperationUpdate, cfg))
+}
+
+func TestLoginProfileRegistration(t *testing.T) {
+	cfg := &config.Config{Region: "us-east-1"}
+	assert.NotNil(t, GetProvisionerForOperation("AWS::IAM::LoginProfile", resource.OperationCreate, cfg))
+	assert.NotNil(t, GetProvisionerForOperation("AWS::IAM::LoginProfile", resource.OperationRead, cfg))
+	assert.NotNil(t, GetProvisionerForOperation("AWS::IAM::LoginProfile", resource.OperationUpdate, cfg))
+	assert.NotNil(t, GetProvisionerForOperation("AWS::IAM::LoginProfile", resource.OperationDelete, cfg))
+	assert.NotNil(t, GetProvisionerForOperation("AWS::IAM::LoginProfile", resource.OperationCheckStatus, cfg))
+	assert.Nil(t, GetProvisionerForOperation("AWS::IAM::LoginProfile", resource.OperationList, cfg))
+}
+
+func TestIAMPathListingRegistration(t *testing.T) {
+	cfg := &config.Config{Region: "us-east-1"}
+	for _, resourceType := range []string{"AWS::IAM::User", "AWS::IAM::ManagedPolicy"} {
+		assert.NotNil(t, GetProvisionerForOperation(resourceType, resource.OperationList, cfg))
+		assert.Nil(t, GetProvisionerForOperation(resourceType, resource.OperationRead, cfg))
+	}
 }
 
 func TestS3ObjectRegistration(t *testing.T) {
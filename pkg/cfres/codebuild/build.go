@@ -17,6 +17,8 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/arn"
 )
 
 // generatorVersion is bumped whenever the generated buildspec changes shape, so
@@ -297,8 +299,9 @@ func buildTrustPolicy() string {
 // this covers a public base image (no auth needed) or a same-account ECR base image
 // (pulled through the same repository-scoped grant).
 func buildInlinePolicy(ref ecrRepositoryRef, projectName string) string {
-	ecrRepoArn := fmt.Sprintf("arn:aws:ecr:%s:%s:repository/%s", ref.Region, ref.AccountID, ref.RepoName)
-	logGroupArn := fmt.Sprintf("arn:aws:logs:%s:%s:log-group:/aws/codebuild/%s", ref.Region, ref.AccountID, projectName)
+	partition := arn.PartitionForRegion(ref.Region)
+	ecrRepoArn := fmt.Sprintf("arn:%s:ecr:%s:%s:repository/%s", partition, ref.Region, ref.AccountID, ref.RepoName)
+	logGroupArn := fmt.Sprintf("arn:%s:logs:%s:%s:log-group:/aws/codebuild/%s", partition, ref.Region, ref.AccountID, projectName)
 	doc := policyDocument{
 		Version: "2012-10-17",
 		Statement: []policyStatement{
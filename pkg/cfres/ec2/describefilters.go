@@ -0,0 +1,55 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ec2
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// EC2 Describe* calls accept between 5 and 1000 results per page.
+const (
+	minDescribeResults = 5
+	maxDescribeResults = 1000
+)
+
+// describeFilters translates the parent properties of a child-resource List
+// (ListRequest.AdditionalProperties) into EC2 Describe* filters, so EC2 only
+// returns the children of that parent instead of every resource of the type
+// in the region. names maps each supported property to its filter name.
+func describeFilters(resourceType string, properties map[string]string, names map[string]string) ([]ec2types.Filter, error) {
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	filters := make([]ec2types.Filter, 0, len(keys))
+	for _, k := range keys {
+		name, ok := names[k]
+		if !ok {
+			return nil, fmt.Errorf("cannot list %s filtered by %s", resourceType, k)
+		}
+		filters = append(filters, ec2types.Filter{Name: aws.String(name), Values: []string{properties[k]}})
+	}
+	return filters, nil
+}
+
+// describeMaxResults clamps a ListRequest page size into the range EC2
+// accepts.
+func describeMaxResults(pageSize int32) *int32 {
+	switch {
+	case pageSize <= 0:
+		return nil
+	case pageSize < minDescribeResults:
+		return aws.Int32(minDescribeResults)
+	case pageSize > maxDescribeResults:
+		return aws.Int32(maxDescribeResults)
+	}
+	return aws.Int32(pageSize)
+}
@@ -0,0 +1,302 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ec2
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ccx"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+const instanceType = "AWS::EC2::Instance"
+
+// Properties an instance's user data is given and recorded by. UserDataFile
+// and UserDataHash are the plugin's own; CloudControl only knows UserData.
+const (
+	userDataKey     = "UserData"
+	userDataFileKey = "UserDataFile"
+	userDataHashKey = "UserDataHash"
+)
+
+// instanceCCXClient is the generic CloudControl path the custom Instance
+// operations delegate to. *ccx.Client satisfies it.
+type instanceCCXClient interface {
+	CreateResource(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error)
+	UpdateResource(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error)
+	ReadResource(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error)
+}
+
+// Instance wraps the CloudControl path for AWS::EC2::Instance to handle user
+// data. CloudControl wants UserData base64-encoded, and changing it only takes
+// effect on a stopped instance, so without this a script given as plain text
+// is rejected and an edited script is never applied.
+//
+// User data is given inline as UserData, in plain text or base64, or as
+// UserDataFile, the path of a file on the host running the formae agent. It is
+// base64-encoded on create, and reads record the SHA-256 of its decoded
+// content as UserDataHash. An update that changes the content fails as
+// NotUpdatable, so the instance is replaced. A read of an instance whose
+// UserDataFile no longer hashes to the live user data leaves UserDataFile out,
+// so an edit to the file shows up as a change.
+//
+// Delete, List and Status fall through to the generic CloudControl path; the
+// status path's post-success read routes through this Read.
+type Instance struct {
+	cfg *config.Config
+	// ccxClient is injectable for testing; nil means construct the real client.
+	ccxClient instanceCCXClient
+}
+
+var _ prov.Provisioner = &Instance{}
+
+func init() {
+	registry.Register(instanceType,
+		[]resource.Operation{
+			resource.OperationRead,
+			resource.OperationCreate,
+			resource.OperationUpdate,
+		},
+		func(cfg *config.Config) prov.Provisioner {
+			return &Instance{cfg: cfg}
+		})
+}
+
+func (i *Instance) getCCXClient() (instanceCCXClient, error) {
+	if i.ccxClient != nil {
+		return i.ccxClient, nil
+	}
+	return ccx.NewClient(i.cfg)
+}
+
+// userDataContent returns the user data a model gives, decoded, and whether
+// it gives any. UserData that decodes as base64 is taken as encoded; a script
+// in plain text never does, since it holds characters such as spaces and #
+// that base64 doesn't use. A short bare word whose length is a multiple of
+// four, such as "echo", is valid base64 too and is taken as encoded, which is
+// why the schema asks for such values base64-encoded.
+func userDataContent(props map[string]any) ([]byte, bool, error) {
+	file, _ := props[userDataFileKey].(string)
+	inline, _ := props[userDataKey].(string)
+	switch {
+	case file != "" && inline != "":
+		return nil, false, fmt.Errorf("%s and %s are mutually exclusive", userDataKey, userDataFileKey)
+	case file != "":
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read %s: %w", userDataFileKey, err)
+		}
+		return content, true, nil
+	case inline != "":
+		if decoded, err := base64.StdEncoding.DecodeString(inline); err == nil {
+			return decoded, true, nil
+		}
+		return []byte(inline), true, nil
+	}
+	return nil, false, nil
+}
+
+func userDataHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// recordedUserDataHash returns the hash of the user data a prior model
+// recorded, computing it from UserData for models recorded without one.
+func recordedUserDataHash(props map[string]any) string {
+	if hash, _ := props[userDataHashKey].(string); hash != "" {
+		return hash
+	}
+	inline, _ := props[userDataKey].(string)
+	if inline == "" {
+		return ""
+	}
+	content, _, _ := userDataContent(map[string]any{userDataKey: inline})
+	return userDataHash(content)
+}
+
+// withoutUserData returns the properties with the user data ones removed.
+func withoutUserData(props map[string]any) map[string]any {
+	out := make(map[string]any, len(props))
+	for k, v := range props {
+		switch k {
+		case userDataKey, userDataFileKey, userDataHashKey:
+		default:
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func (i *Instance) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := i.getCCXClient()
+	if err != nil {
+		return nil, fmt.Errorf("creating cloudcontrol client: %w", err)
+	}
+	return i.createWithClient(ctx, client, request)
+}
+
+func (i *Instance) createWithClient(ctx context.Context, client instanceCCXClient, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+	content, ok, err := userDataContent(props)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := withoutUserData(props)
+	if ok {
+		desired[userDataKey] = base64.StdEncoding.EncodeToString(content)
+	}
+	properties, err := json.Marshal(desired)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal properties: %w", err)
+	}
+
+	create := *request
+	create.Properties = properties
+	return client.CreateResource(ctx, &create)
+}
+
+func (i *Instance) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := i.getCCXClient()
+	if err != nil {
+		return nil, fmt.Errorf("creating cloudcontrol client: %w", err)
+	}
+	return i.updateWithClient(ctx, client, request)
+}
+
+// updateWithClient reports a change to the user data as NotUpdatable and
+// sends every other change through CloudControl with the user data left out.
+func (i *Instance) updateWithClient(ctx context.Context, client instanceCCXClient, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	var desired, prior map[string]any
+	if err := json.Unmarshal(request.DesiredProperties, &desired); err != nil {
+		return nil, fmt.Errorf("failed to parse desired state properties: %w", err)
+	}
+	if len(request.PriorProperties) > 0 {
+		if err := json.Unmarshal(request.PriorProperties, &prior); err != nil {
+			return nil, fmt.Errorf("failed to parse prior state properties: %w", err)
+		}
+	}
+
+	content, ok, err := userDataContent(desired)
+	if err != nil {
+		return nil, err
+	}
+	var desiredHash string
+	if ok {
+		desiredHash = userDataHash(content)
+	}
+	if desiredHash != recordedUserDataHash(prior) {
+		return &resource.UpdateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationUpdate,
+				OperationStatus: resource.OperationStatusFailure,
+				NativeID:        request.NativeID,
+				StatusMessage:   "user data changed, resource must be replaced",
+				ErrorCode:       resource.OperationErrorCodeNotUpdatable,
+			},
+		}, nil
+	}
+
+	// Only the user data properties differ, such as a UserDataFile a read
+	// without the prior model couldn't report; there is nothing to send.
+	desired, prior = withoutUserData(desired), withoutUserData(prior)
+	if request.PatchDocument == nil && reflect.DeepEqual(desired, prior) {
+		return &resource.UpdateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationUpdate,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	update := *request
+	if update.DesiredProperties, err = json.Marshal(desired); err != nil {
+		return nil, fmt.Errorf("failed to marshal desired state properties: %w", err)
+	}
+	if len(request.PriorProperties) > 0 {
+		if update.PriorProperties, err = json.Marshal(prior); err != nil {
+			return nil, fmt.Errorf("failed to marshal prior state properties: %w", err)
+		}
+	}
+	return client.UpdateResource(ctx, &update)
+}
+
+func (i *Instance) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := i.getCCXClient()
+	if err != nil {
+		return nil, fmt.Errorf("creating cloudcontrol client: %w", err)
+	}
+	return i.readWithClient(ctx, client, request)
+}
+
+// readWithClient records the hash of the live user data and, when the prior
+// model gave the same content, reports the user data the way that model gave
+// it: as its UserDataFile, or as its inline UserData in plain text.
+func (i *Instance) readWithClient(ctx context.Context, client instanceCCXClient, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	result, err := client.ReadResource(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if result.ErrorCode != "" {
+		return result, nil
+	}
+
+	var props map[string]any
+	if err = json.Unmarshal([]byte(result.Properties), &props); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal instance properties: %w", err)
+	}
+	live, ok, _ := userDataContent(map[string]any{userDataKey: props[userDataKey]})
+	if !ok {
+		return result, nil
+	}
+	hash := userDataHash(live)
+	props[userDataHashKey] = hash
+
+	var prior map[string]any
+	if len(request.PriorProperties) > 0 && json.Unmarshal(request.PriorProperties, &prior) == nil {
+		if given, ok, err := userDataContent(prior); err == nil && ok && userDataHash(given) == hash {
+			if file, _ := prior[userDataFileKey].(string); file != "" {
+				delete(props, userDataKey)
+				props[userDataFileKey] = file
+			} else if inline, _ := prior[userDataKey].(string); inline != "" {
+				props[userDataKey] = inline
+			}
+		}
+	}
+
+	out, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal instance properties: %w", err)
+	}
+	result.Properties = string(out)
+	return result, nil
+}
+
+func (i *Instance) Delete(_ context.Context, _ *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	return nil, fmt.Errorf("delete not implemented - cloudcontrol handles this operation")
+}
+
+func (i *Instance) Status(_ context.Context, _ *resource.StatusRequest) (*resource.StatusResult, error) {
+	return nil, fmt.Errorf("status not implemented - cloudcontrol handles this operation")
+}
+
+func (i *Instance) List(_ context.Context, _ *resource.ListRequest) (*resource.ListResult, error) {
+	return nil, fmt.Errorf("list not implemented - cloudcontrol handles this operation")
+}
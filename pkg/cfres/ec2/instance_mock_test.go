@@ -0,0 +1,34 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ec2
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type mockInstanceCCXClient struct {
+	mock.Mock
+}
+
+func (m *mockInstanceCCXClient) CreateResource(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	args := m.Called(ctx, request)
+	return args.Get(0).(*resource.CreateResult), args.Error(1)
+}
+
+func (m *mockInstanceCCXClient) UpdateResource(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	args := m.Called(ctx, request)
+	return args.Get(0).(*resource.UpdateResult), args.Error(1)
+}
+
+func (m *mockInstanceCCXClient) ReadResource(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	args := m.Called(ctx, request)
+	return args.Get(0).(*resource.ReadResult), args.Error(1)
+}
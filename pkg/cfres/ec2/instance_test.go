@@ -0,0 +1,201 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ec2
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+const userDataScript = "#!/bin/bash\necho hello\n"
+
+func writeUserDataFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "user-data.sh")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestInstance_Create_EncodesPlainUserData(t *testing.T) {
+	ctx := context.Background()
+	client := &mockInstanceCCXClient{}
+	client.On("CreateResource", ctx, mock.MatchedBy(func(request *resource.CreateRequest) bool {
+		var props map[string]any
+		_ = json.Unmarshal(request.Properties, &props)
+		return props["UserData"] == base64.StdEncoding.EncodeToString([]byte(userDataScript)) &&
+			props["ImageId"] == "ami-123"
+	})).Return(&resource.CreateResult{ProgressResult: &resource.ProgressResult{RequestID: "req-1"}}, nil)
+
+	properties, _ := json.Marshal(map[string]any{"ImageId": "ami-123", "UserData": userDataScript})
+	result, err := (&Instance{}).createWithClient(ctx, client, &resource.CreateRequest{Properties: properties})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "req-1", result.ProgressResult.RequestID)
+	client.AssertExpectations(t)
+}
+
+func TestInstance_Create_KeepsEncodedUserData(t *testing.T) {
+	ctx := context.Background()
+	encoded := base64.StdEncoding.EncodeToString([]byte(userDataScript))
+	client := &mockInstanceCCXClient{}
+	client.On("CreateResource", ctx, mock.MatchedBy(func(request *resource.CreateRequest) bool {
+		var props map[string]any
+		_ = json.Unmarshal(request.Properties, &props)
+		return props["UserData"] == encoded
+	})).Return(&resource.CreateResult{ProgressResult: &resource.ProgressResult{}}, nil)
+
+	properties, _ := json.Marshal(map[string]any{"UserData": encoded})
+	_, err := (&Instance{}).createWithClient(ctx, client, &resource.CreateRequest{Properties: properties})
+
+	assert.NoError(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestInstance_Create_ReadsUserDataFile(t *testing.T) {
+	ctx := context.Background()
+	path := writeUserDataFile(t, userDataScript)
+	client := &mockInstanceCCXClient{}
+	client.On("CreateResource", ctx, mock.MatchedBy(func(request *resource.CreateRequest) bool {
+		var props map[string]any
+		_ = json.Unmarshal(request.Properties, &props)
+		_, hasFile := props["UserDataFile"]
+		return !hasFile && props["UserData"] == base64.StdEncoding.EncodeToString([]byte(userDataScript))
+	})).Return(&resource.CreateResult{ProgressResult: &resource.ProgressResult{}}, nil)
+
+	properties, _ := json.Marshal(map[string]any{"UserDataFile": path})
+	_, err := (&Instance{}).createWithClient(ctx, client, &resource.CreateRequest{Properties: properties})
+
+	assert.NoError(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestInstance_Create_RejectsUserDataAndFile(t *testing.T) {
+	properties, _ := json.Marshal(map[string]any{"UserData": userDataScript, "UserDataFile": "/tmp/user-data.sh"})
+	_, err := (&Instance{}).createWithClient(context.Background(), &mockInstanceCCXClient{}, &resource.CreateRequest{Properties: properties})
+
+	assert.ErrorContains(t, err, "UserData and UserDataFile are mutually exclusive")
+}
+
+func TestInstance_Read_RecordsHashAndPriorSpelling(t *testing.T) {
+	ctx := context.Background()
+	encoded := base64.StdEncoding.EncodeToString([]byte(userDataScript))
+	client := &mockInstanceCCXClient{}
+	client.On("ReadResource", ctx, mock.Anything).Return(&resource.ReadResult{
+		ResourceType: instanceType,
+		Properties:   `{"InstanceId": "i-123", "UserData": "` + encoded + `"}`,
+	}, nil)
+
+	prior, _ := json.Marshal(map[string]any{"UserData": userDataScript})
+	result, err := (&Instance{}).readWithClient(ctx, client, &resource.ReadRequest{NativeID: "i-123", PriorProperties: prior})
+
+	assert.NoError(t, err)
+	var props map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, userDataScript, props["UserData"])
+	assert.Equal(t, userDataHash([]byte(userDataScript)), props["UserDataHash"])
+}
+
+func TestInstance_Read_ReportsUnchangedUserDataFile(t *testing.T) {
+	ctx := context.Background()
+	path := writeUserDataFile(t, userDataScript)
+	client := &mockInstanceCCXClient{}
+	client.On("ReadResource", ctx, mock.Anything).Return(&resource.ReadResult{
+		ResourceType: instanceType,
+		Properties:   `{"UserData": "` + base64.StdEncoding.EncodeToString([]byte(userDataScript)) + `"}`,
+	}, nil)
+
+	prior, _ := json.Marshal(map[string]any{"UserDataFile": path})
+	result, err := (&Instance{}).readWithClient(ctx, client, &resource.ReadRequest{NativeID: "i-123", PriorProperties: prior})
+
+	assert.NoError(t, err)
+	var props map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, path, props["UserDataFile"])
+	assert.NotContains(t, props, "UserData")
+}
+
+func TestInstance_Read_EditedUserDataFileShowsAsChange(t *testing.T) {
+	ctx := context.Background()
+	path := writeUserDataFile(t, "#!/bin/bash\necho edited\n")
+	client := &mockInstanceCCXClient{}
+	client.On("ReadResource", ctx, mock.Anything).Return(&resource.ReadResult{
+		ResourceType: instanceType,
+		Properties:   `{"UserData": "` + base64.StdEncoding.EncodeToString([]byte(userDataScript)) + `"}`,
+	}, nil)
+
+	prior, _ := json.Marshal(map[string]any{"UserDataFile": path})
+	result, err := (&Instance{}).readWithClient(ctx, client, &resource.ReadRequest{NativeID: "i-123", PriorProperties: prior})
+
+	assert.NoError(t, err)
+	var props map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.NotContains(t, props, "UserDataFile")
+	assert.Equal(t, userDataHash([]byte(userDataScript)), props["UserDataHash"])
+}
+
+func TestInstance_Update_ChangedUserDataIsNotUpdatable(t *testing.T) {
+	client := &mockInstanceCCXClient{}
+	prior, _ := json.Marshal(map[string]any{"UserDataHash": userDataHash([]byte(userDataScript))})
+	desired, _ := json.Marshal(map[string]any{"UserData": "#!/bin/bash\necho edited\n"})
+
+	result, err := (&Instance{}).updateWithClient(context.Background(), client, &resource.UpdateRequest{
+		NativeID:          "i-123",
+		PriorProperties:   prior,
+		DesiredProperties: desired,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+	assert.Equal(t, resource.OperationErrorCodeNotUpdatable, result.ProgressResult.ErrorCode)
+	client.AssertNotCalled(t, "UpdateResource", mock.Anything, mock.Anything)
+}
+
+func TestInstance_Update_SendsOtherChangesWithoutUserData(t *testing.T) {
+	ctx := context.Background()
+	client := &mockInstanceCCXClient{}
+	client.On("UpdateResource", ctx, mock.MatchedBy(func(request *resource.UpdateRequest) bool {
+		return string(request.DesiredProperties) == `{"InstanceType":"t3.large"}` &&
+			string(request.PriorProperties) == `{"InstanceType":"t3.small"}`
+	})).Return(&resource.UpdateResult{ProgressResult: &resource.ProgressResult{RequestID: "req-2"}}, nil)
+
+	prior, _ := json.Marshal(map[string]any{"InstanceType": "t3.small", "UserData": userDataScript, "UserDataHash": userDataHash([]byte(userDataScript))})
+	desired, _ := json.Marshal(map[string]any{"InstanceType": "t3.large", "UserData": base64.StdEncoding.EncodeToString([]byte(userDataScript))})
+	result, err := (&Instance{}).updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "i-123",
+		PriorProperties:   prior,
+		DesiredProperties: desired,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "req-2", result.ProgressResult.RequestID)
+	client.AssertExpectations(t)
+}
+
+func TestInstance_Update_OnlyUserDataSpellingIsNoop(t *testing.T) {
+	client := &mockInstanceCCXClient{}
+	path := writeUserDataFile(t, userDataScript)
+	prior, _ := json.Marshal(map[string]any{"InstanceType": "t3.small", "UserData": base64.StdEncoding.EncodeToString([]byte(userDataScript))})
+	desired, _ := json.Marshal(map[string]any{"InstanceType": "t3.small", "UserDataFile": path})
+
+	result, err := (&Instance{}).updateWithClient(context.Background(), client, &resource.UpdateRequest{
+		NativeID:          "i-123",
+		PriorProperties:   prior,
+		DesiredProperties: desired,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertNotCalled(t, "UpdateResource", mock.Anything, mock.Anything)
+}
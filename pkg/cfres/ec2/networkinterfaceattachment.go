@@ -0,0 +1,575 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ec2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2sdk "github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/utils"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+// AWS::EC2::NetworkInterfaceAttachment goes through a custom provisioner
+// driving AttachNetworkInterface and DetachNetworkInterface directly. An
+// attachment takes a while to reach "attached" (and "detached"), and the
+// Cloud Control handler neither checks that the device index is free nor
+// waits for the attachment to settle, so a dependent resource could run
+// against an interface that isn't usable yet.
+//
+// Create and Delete return InProgress and Status waits for the attachment
+// to settle. The attachment id (eni-attach-...) is the NativeID.
+const networkInterfaceAttachmentType = "AWS::EC2::NetworkInterfaceAttachment"
+
+// errCodeAttachmentNotFound is the EC2 error code returned when an attachment
+// id does not exist.
+const errCodeAttachmentNotFound = "InvalidAttachmentID.NotFound"
+
+// networkInterfaceAttachmentFilters maps the parent properties a List can be
+// filtered by to their DescribeNetworkInterfaces filters.
+var networkInterfaceAttachmentFilters = map[string]string{
+	"InstanceId": "attachment.instance-id",
+}
+
+type networkInterfaceAttachmentClientInterface interface {
+	AttachNetworkInterface(ctx context.Context, params *ec2sdk.AttachNetworkInterfaceInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.AttachNetworkInterfaceOutput, error)
+	DetachNetworkInterface(ctx context.Context, params *ec2sdk.DetachNetworkInterfaceInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.DetachNetworkInterfaceOutput, error)
+	DescribeNetworkInterfaces(ctx context.Context, params *ec2sdk.DescribeNetworkInterfacesInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.DescribeNetworkInterfacesOutput, error)
+	ModifyNetworkInterfaceAttribute(ctx context.Context, params *ec2sdk.ModifyNetworkInterfaceAttributeInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.ModifyNetworkInterfaceAttributeOutput, error)
+}
+
+type NetworkInterfaceAttachment struct {
+	cfg *config.Config
+}
+
+var _ prov.Provisioner = &NetworkInterfaceAttachment{}
+
+func init() {
+	registry.Register(networkInterfaceAttachmentType,
+		[]resource.Operation{
+			resource.OperationRead,
+			resource.OperationCreate,
+			resource.OperationUpdate,
+			resource.OperationCheckStatus,
+			resource.OperationDelete,
+			resource.OperationList,
+		},
+		func(cfg *config.Config) prov.Provisioner {
+			return &NetworkInterfaceAttachment{cfg: cfg}
+		})
+}
+
+func isAttachmentNotFound(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return strings.EqualFold(apiErr.ErrorCode(), errCodeAttachmentNotFound)
+	}
+	return false
+}
+
+// parseDeviceIndex parses the DeviceIndex property, which the schema types as
+// a string. Index 0 is the instance's primary network interface, which is
+// created with the instance and can never be attached or detached.
+func parseDeviceIndex(props map[string]any) (int32, error) {
+	var raw string
+	switch v := props["DeviceIndex"].(type) {
+	case string:
+		raw = v
+	case float64:
+		raw = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	if raw == "" {
+		return 0, fmt.Errorf("DeviceIndex is required")
+	}
+	index, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("invalid DeviceIndex %q: must be a non-negative integer", raw)
+	}
+	if index == 0 {
+		return 0, fmt.Errorf("invalid DeviceIndex 0: it is the instance's primary network interface, which cannot be attached")
+	}
+	return int32(index), nil
+}
+
+// enaSrdSpecification returns the EnaSrdSpecification a model gives, or nil.
+func enaSrdSpecification(props map[string]any) *ec2types.EnaSrdSpecification {
+	spec, ok := props["EnaSrdSpecification"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	out := &ec2types.EnaSrdSpecification{}
+	if enabled, ok := spec["EnaSrdEnabled"].(bool); ok {
+		out.EnaSrdEnabled = aws.Bool(enabled)
+	}
+	if udp, ok := spec["EnaSrdUdpSpecification"].(map[string]any); ok {
+		if enabled, ok := udp["EnaSrdUdpEnabled"].(bool); ok {
+			out.EnaSrdUdpSpecification = &ec2types.EnaSrdUdpSpecification{EnaSrdUdpEnabled: aws.Bool(enabled)}
+		}
+	}
+	return out
+}
+
+// encodeAttachmentRequestID records the operation Status waits for and the
+// attachment id, plus the DeleteOnTermination a Create has to apply once the
+// interface is attached; StatusRequest carries no properties.
+func encodeAttachmentRequestID(op resource.Operation, attachmentID string, deleteOnTermination *bool) string {
+	requestID := string(op) + "|" + attachmentID
+	if deleteOnTermination != nil {
+		requestID += "|" + strconv.FormatBool(*deleteOnTermination)
+	}
+	return requestID
+}
+
+// decodeAttachmentRequestID parses a RequestID built by
+// encodeAttachmentRequestID. A RequestID in any other form is taken as a bare
+// attachment id with no operation to wait for.
+func decodeAttachmentRequestID(requestID string) (op resource.Operation, attachmentID string, deleteOnTermination *bool) {
+	parts := strings.Split(requestID, "|")
+	if len(parts) < 2 || len(parts) > 3 {
+		return "", requestID, nil
+	}
+	if len(parts) == 3 {
+		if b, err := strconv.ParseBool(parts[2]); err == nil {
+			deleteOnTermination = aws.Bool(b)
+		}
+	}
+	return resource.Operation(parts[0]), parts[1], deleteOnTermination
+}
+
+// describeAttachment returns the network interface holding attachmentID, or
+// nil when there is none.
+func describeAttachment(ctx context.Context, client networkInterfaceAttachmentClientInterface, attachmentID string) (*ec2types.NetworkInterface, error) {
+	resp, err := client.DescribeNetworkInterfaces(ctx, &ec2sdk.DescribeNetworkInterfacesInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("attachment.attachment-id"), Values: []string{attachmentID}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing network interface attachment %s: %w", attachmentID, err)
+	}
+	for i := range resp.NetworkInterfaces {
+		eni := &resp.NetworkInterfaces[i]
+		if eni.Attachment != nil && aws.ToString(eni.Attachment.AttachmentId) == attachmentID {
+			return eni, nil
+		}
+	}
+	return nil, nil
+}
+
+// attachedAt returns the network interface attached to instanceID at
+// deviceIndex, or nil when the index is free.
+func attachedAt(ctx context.Context, client networkInterfaceAttachmentClientInterface, instanceID string, deviceIndex int32) (*ec2types.NetworkInterface, error) {
+	resp, err := client.DescribeNetworkInterfaces(ctx, &ec2sdk.DescribeNetworkInterfacesInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("attachment.instance-id"), Values: []string{instanceID}},
+			{Name: aws.String("attachment.device-index"), Values: []string{strconv.Itoa(int(deviceIndex))}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing network interfaces of instance %s: %w", instanceID, err)
+	}
+	for i := range resp.NetworkInterfaces {
+		eni := &resp.NetworkInterfaces[i]
+		if eni.Attachment != nil && eni.Attachment.Status != ec2types.AttachmentStatusDetached {
+			return eni, nil
+		}
+	}
+	return nil, nil
+}
+
+func networkInterfaceAttachmentProps(eni *ec2types.NetworkInterface) map[string]any {
+	attachment := eni.Attachment
+	props := map[string]any{
+		"AttachmentId":        aws.ToString(attachment.AttachmentId),
+		"InstanceId":          aws.ToString(attachment.InstanceId),
+		"NetworkInterfaceId":  aws.ToString(eni.NetworkInterfaceId),
+		"DeviceIndex":         strconv.Itoa(int(aws.ToInt32(attachment.DeviceIndex))),
+		"DeleteOnTermination": aws.ToBool(attachment.DeleteOnTermination),
+	}
+	if srd := attachment.EnaSrdSpecification; srd != nil {
+		spec := map[string]any{"EnaSrdEnabled": aws.ToBool(srd.EnaSrdEnabled)}
+		if srd.EnaSrdUdpSpecification != nil {
+			spec["EnaSrdUdpSpecification"] = map[string]any{
+				"EnaSrdUdpEnabled": aws.ToBool(srd.EnaSrdUdpSpecification.EnaSrdUdpEnabled),
+			}
+		}
+		props["EnaSrdSpecification"] = spec
+	}
+	return props
+}
+
+func (a *NetworkInterfaceAttachment) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	awsCfg, err := a.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return a.createWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+// createWithClient attaches the interface and returns InProgress for Status
+// to wait on. The device index must be free: an interface already at it is
+// adopted when it is the one being attached (a retried Create), and otherwise
+// fails the Create, as a conflict to retry when that interface is on its way
+// out.
+func (a *NetworkInterfaceAttachment) createWithClient(ctx context.Context, client networkInterfaceAttachmentClientInterface, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("parsing properties: %w", err)
+	}
+	instanceID, err := utils.GetStringProperty(props, "InstanceId")
+	if err != nil {
+		return nil, fmt.Errorf("invalid InstanceId: %w", err)
+	}
+	networkInterfaceID, err := utils.GetStringProperty(props, "NetworkInterfaceId")
+	if err != nil {
+		return nil, fmt.Errorf("invalid NetworkInterfaceId: %w", err)
+	}
+	deviceIndex, err := parseDeviceIndex(props)
+	if err != nil {
+		return nil, err
+	}
+	var deleteOnTermination *bool
+	if v, ok := props["DeleteOnTermination"].(bool); ok {
+		deleteOnTermination = aws.Bool(v)
+	}
+
+	occupant, err := attachedAt(ctx, client, instanceID, deviceIndex)
+	if err != nil {
+		return nil, err
+	}
+	var attachmentID string
+	switch {
+	case occupant == nil:
+		output, err := client.AttachNetworkInterface(ctx, &ec2sdk.AttachNetworkInterfaceInput{
+			InstanceId:          aws.String(instanceID),
+			NetworkInterfaceId:  aws.String(networkInterfaceID),
+			DeviceIndex:         aws.Int32(deviceIndex),
+			EnaSrdSpecification: enaSrdSpecification(props),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("attaching network interface %s to instance %s: %w", networkInterfaceID, instanceID, err)
+		}
+		if output.AttachmentId == nil {
+			return nil, fmt.Errorf("attaching network interface %s: response did not include an attachment id", networkInterfaceID)
+		}
+		attachmentID = *output.AttachmentId
+	case aws.ToString(occupant.NetworkInterfaceId) == networkInterfaceID &&
+		occupant.Attachment.Status != ec2types.AttachmentStatusDetaching:
+		attachmentID = aws.ToString(occupant.Attachment.AttachmentId)
+	default:
+		errorCode := resource.OperationErrorCodeAlreadyExists
+		if occupant.Attachment.Status == ec2types.AttachmentStatusDetaching {
+			errorCode = resource.OperationErrorCodeResourceConflict
+		}
+		return &resource.CreateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationCreate,
+				OperationStatus: resource.OperationStatusFailure,
+				ErrorCode:       errorCode,
+				StatusMessage: fmt.Sprintf("device index %d of instance %s is taken by network interface %s (%s)",
+					deviceIndex, instanceID, aws.ToString(occupant.NetworkInterfaceId), occupant.Attachment.Status),
+			},
+		}, nil
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        attachmentID,
+			RequestID:       encodeAttachmentRequestID(resource.OperationCreate, attachmentID, deleteOnTermination),
+		},
+	}, nil
+}
+
+func (a *NetworkInterfaceAttachment) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	awsCfg, err := a.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return a.statusWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+// statusWithClient waits for the attachment a Create or Delete started to
+// reach "attached" or go away. Once attached, a Create applies the requested
+// DeleteOnTermination, which AttachNetworkInterface does not take.
+func (a *NetworkInterfaceAttachment) statusWithClient(ctx context.Context, client networkInterfaceAttachmentClientInterface, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	op, attachmentID, deleteOnTermination := decodeAttachmentRequestID(request.RequestID)
+	if attachmentID == "" {
+		attachmentID = request.NativeID
+	}
+	if op == "" {
+		op = resource.OperationCheckStatus
+	}
+
+	eni, err := describeAttachment(ctx, client, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	var status ec2types.AttachmentStatus
+	if eni != nil {
+		status = eni.Attachment.Status
+	}
+
+	pr := &resource.ProgressResult{
+		Operation:       op,
+		OperationStatus: resource.OperationStatusInProgress,
+		RequestID:       request.RequestID,
+		NativeID:        attachmentID,
+		StatusMessage:   fmt.Sprintf("network interface attachment %s is %s", attachmentID, status),
+	}
+	if op == resource.OperationDelete {
+		if eni == nil || status == ec2types.AttachmentStatusDetached {
+			pr.OperationStatus = resource.OperationStatusSuccess
+			pr.StatusMessage = ""
+		}
+		return &resource.StatusResult{ProgressResult: pr}, nil
+	}
+
+	switch status {
+	case ec2types.AttachmentStatusAttaching:
+		return &resource.StatusResult{ProgressResult: pr}, nil
+	case ec2types.AttachmentStatusAttached:
+	case "":
+		pr.OperationStatus = resource.OperationStatusFailure
+		pr.ErrorCode = resource.OperationErrorCodeNotFound
+		pr.StatusMessage = fmt.Sprintf("network interface attachment %s not found", attachmentID)
+		return &resource.StatusResult{ProgressResult: pr}, nil
+	default:
+		pr.OperationStatus = resource.OperationStatusFailure
+		pr.ErrorCode = resource.OperationErrorCodeGeneralServiceException
+		return &resource.StatusResult{ProgressResult: pr}, nil
+	}
+
+	if deleteOnTermination != nil && aws.ToBool(eni.Attachment.DeleteOnTermination) != *deleteOnTermination {
+		if _, err := client.ModifyNetworkInterfaceAttribute(ctx, &ec2sdk.ModifyNetworkInterfaceAttributeInput{
+			NetworkInterfaceId: eni.NetworkInterfaceId,
+			Attachment: &ec2types.NetworkInterfaceAttachmentChanges{
+				AttachmentId:        aws.String(attachmentID),
+				DeleteOnTermination: deleteOnTermination,
+			},
+		}); err != nil {
+			return nil, fmt.Errorf("setting DeleteOnTermination on attachment %s: %w", attachmentID, err)
+		}
+		eni.Attachment.DeleteOnTermination = deleteOnTermination
+	}
+
+	propsJSON, err := json.Marshal(networkInterfaceAttachmentProps(eni))
+	if err != nil {
+		return nil, fmt.Errorf("marshaling properties: %w", err)
+	}
+	pr.OperationStatus = resource.OperationStatusSuccess
+	pr.StatusMessage = ""
+	pr.ResourceProperties = propsJSON
+	return &resource.StatusResult{ProgressResult: pr}, nil
+}
+
+func (a *NetworkInterfaceAttachment) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	awsCfg, err := a.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return a.readWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+// readWithClient reports an attachment that is detached, or on its way
+// there, as NotFound.
+func (a *NetworkInterfaceAttachment) readWithClient(ctx context.Context, client networkInterfaceAttachmentClientInterface, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	if request.NativeID == "" {
+		return nil, fmt.Errorf("invalid NativeID: attachment id is empty")
+	}
+	eni, err := describeAttachment(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if eni == nil ||
+		eni.Attachment.Status == ec2types.AttachmentStatusDetaching ||
+		eni.Attachment.Status == ec2types.AttachmentStatusDetached {
+		return &resource.ReadResult{
+			ResourceType: request.ResourceType,
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	propsJSON, err := json.Marshal(networkInterfaceAttachmentProps(eni))
+	if err != nil {
+		return nil, fmt.Errorf("marshaling properties: %w", err)
+	}
+	return &resource.ReadResult{
+		ResourceType: request.ResourceType,
+		Properties:   string(propsJSON),
+	}, nil
+}
+
+func (a *NetworkInterfaceAttachment) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	awsCfg, err := a.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return a.updateWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+// updateWithClient changes DeleteOnTermination and EnaSrdSpecification, the
+// only properties that aren't createOnly, in place.
+func (a *NetworkInterfaceAttachment) updateWithClient(ctx context.Context, client networkInterfaceAttachmentClientInterface, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	var desired map[string]any
+	if err := json.Unmarshal(request.DesiredProperties, &desired); err != nil {
+		return nil, fmt.Errorf("parsing desired properties: %w", err)
+	}
+
+	eni, err := describeAttachment(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if eni == nil {
+		return &resource.UpdateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationUpdate,
+				OperationStatus: resource.OperationStatusFailure,
+				NativeID:        request.NativeID,
+				ErrorCode:       resource.OperationErrorCodeNotFound,
+				StatusMessage:   fmt.Sprintf("network interface attachment %s not found", request.NativeID),
+			},
+		}, nil
+	}
+
+	deleteOnTermination, _ := desired["DeleteOnTermination"].(bool)
+	if aws.ToBool(eni.Attachment.DeleteOnTermination) != deleteOnTermination {
+		if _, err := client.ModifyNetworkInterfaceAttribute(ctx, &ec2sdk.ModifyNetworkInterfaceAttributeInput{
+			NetworkInterfaceId: eni.NetworkInterfaceId,
+			Attachment: &ec2types.NetworkInterfaceAttachmentChanges{
+				AttachmentId:        aws.String(request.NativeID),
+				DeleteOnTermination: aws.Bool(deleteOnTermination),
+			},
+		}); err != nil {
+			return nil, fmt.Errorf("updating DeleteOnTermination on attachment %s: %w", request.NativeID, err)
+		}
+	}
+	if spec := enaSrdSpecification(desired); spec != nil {
+		if _, err := client.ModifyNetworkInterfaceAttribute(ctx, &ec2sdk.ModifyNetworkInterfaceAttributeInput{
+			NetworkInterfaceId:  eni.NetworkInterfaceId,
+			EnaSrdSpecification: spec,
+		}); err != nil {
+			return nil, fmt.Errorf("updating EnaSrdSpecification on attachment %s: %w", request.NativeID, err)
+		}
+	}
+
+	readResult, err := a.readWithClient(ctx, client, &resource.ReadRequest{
+		NativeID:     request.NativeID,
+		ResourceType: request.ResourceType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:          resource.OperationUpdate,
+			OperationStatus:    resource.OperationStatusSuccess,
+			NativeID:           request.NativeID,
+			ResourceProperties: json.RawMessage(readResult.Properties),
+		},
+	}, nil
+}
+
+func (a *NetworkInterfaceAttachment) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	awsCfg, err := a.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return a.deleteWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+// deleteWithClient detaches the interface and returns InProgress for Status
+// to wait on. An attachment that is already gone is a successful delete.
+func (a *NetworkInterfaceAttachment) deleteWithClient(ctx context.Context, client networkInterfaceAttachmentClientInterface, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	success := &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}
+
+	eni, err := describeAttachment(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if eni == nil || eni.Attachment.Status == ec2types.AttachmentStatusDetached {
+		return success, nil
+	}
+	if eni.Attachment.Status != ec2types.AttachmentStatusDetaching {
+		if _, err := client.DetachNetworkInterface(ctx, &ec2sdk.DetachNetworkInterfaceInput{
+			AttachmentId: aws.String(request.NativeID),
+		}); err != nil {
+			if isAttachmentNotFound(err) {
+				return success, nil
+			}
+			return nil, fmt.Errorf("detaching network interface attachment %s: %w", request.NativeID, err)
+		}
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        request.NativeID,
+			RequestID:       encodeAttachmentRequestID(resource.OperationDelete, request.NativeID, nil),
+		},
+	}, nil
+}
+
+func (a *NetworkInterfaceAttachment) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	awsCfg, err := a.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return a.listWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+// listWithClient lists the attachments of secondary interfaces to instances.
+// Primary interfaces, at device index 0, and interfaces attached to anything
+// other than an instance can't be managed as attachments and are left out.
+func (a *NetworkInterfaceAttachment) listWithClient(ctx context.Context, client networkInterfaceAttachmentClientInterface, request *resource.ListRequest) (*resource.ListResult, error) {
+	filters, err := describeFilters(request.ResourceType, request.AdditionalProperties, networkInterfaceAttachmentFilters)
+	if err != nil {
+		return nil, err
+	}
+	filters = append(filters, ec2types.Filter{Name: aws.String("attachment.status"), Values: []string{string(ec2types.AttachmentStatusAttached)}})
+
+	resp, err := client.DescribeNetworkInterfaces(ctx, &ec2sdk.DescribeNetworkInterfacesInput{
+		Filters:    filters,
+		MaxResults: describeMaxResults(request.PageSize),
+		NextToken:  request.PageToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network interface attachments: %w", err)
+	}
+
+	nativeIDs := make([]string, 0, len(resp.NetworkInterfaces))
+	for _, eni := range resp.NetworkInterfaces {
+		attachment := eni.Attachment
+		if attachment == nil || attachment.InstanceId == nil || aws.ToInt32(attachment.DeviceIndex) == 0 {
+			continue
+		}
+		nativeIDs = append(nativeIDs, aws.ToString(attachment.AttachmentId))
+	}
+
+	return &resource.ListResult{
+		NativeIDs:     nativeIDs,
+		NextPageToken: resp.NextToken,
+	}, nil
+}
@@ -0,0 +1,38 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockNetworkInterfaceAttachmentClient struct {
+	mock.Mock
+}
+
+func (m *mockNetworkInterfaceAttachmentClient) AttachNetworkInterface(ctx context.Context, input *ec2.AttachNetworkInterfaceInput, optFns ...func(*ec2.Options)) (*ec2.AttachNetworkInterfaceOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2.AttachNetworkInterfaceOutput), args.Error(1)
+}
+
+func (m *mockNetworkInterfaceAttachmentClient) DetachNetworkInterface(ctx context.Context, input *ec2.DetachNetworkInterfaceInput, optFns ...func(*ec2.Options)) (*ec2.DetachNetworkInterfaceOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2.DetachNetworkInterfaceOutput), args.Error(1)
+}
+
+func (m *mockNetworkInterfaceAttachmentClient) DescribeNetworkInterfaces(ctx context.Context, input *ec2.DescribeNetworkInterfacesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2.DescribeNetworkInterfacesOutput), args.Error(1)
+}
+
+func (m *mockNetworkInterfaceAttachmentClient) ModifyNetworkInterfaceAttribute(ctx context.Context, input *ec2.ModifyNetworkInterfaceAttributeInput, optFns ...func(*ec2.Options)) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2.ModifyNetworkInterfaceAttributeOutput), args.Error(1)
+}
@@ -0,0 +1,316 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ec2
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	ec2sdk "github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+func attachmentENI(eniID, attachmentID string, deviceIndex int32, status ec2types.AttachmentStatus) ec2types.NetworkInterface {
+	return ec2types.NetworkInterface{
+		NetworkInterfaceId: strPtr(eniID),
+		Attachment: &ec2types.NetworkInterfaceAttachment{
+			AttachmentId:        strPtr(attachmentID),
+			InstanceId:          strPtr("i-123"),
+			DeviceIndex:         intPtr(deviceIndex),
+			DeleteOnTermination: boolPtr(false),
+			Status:              status,
+		},
+	}
+}
+
+func hasFilter(filters []ec2types.Filter, name, value string) bool {
+	for _, f := range filters {
+		if f.Name != nil && *f.Name == name && len(f.Values) == 1 && f.Values[0] == value {
+			return true
+		}
+	}
+	return false
+}
+
+func attachmentCreateProps(extra map[string]any) json.RawMessage {
+	props := map[string]any{
+		"InstanceId":         "i-123",
+		"NetworkInterfaceId": "eni-123",
+		"DeviceIndex":        "1",
+	}
+	for k, v := range extra {
+		props[k] = v
+	}
+	b, _ := json.Marshal(props)
+	return b
+}
+
+func TestNetworkInterfaceAttachment_Create_Attaches(t *testing.T) {
+	ctx := context.Background()
+	client := &mockNetworkInterfaceAttachmentClient{}
+
+	client.On("DescribeNetworkInterfaces", ctx, mock.MatchedBy(func(input *ec2sdk.DescribeNetworkInterfacesInput) bool {
+		return hasFilter(input.Filters, "attachment.instance-id", "i-123") &&
+			hasFilter(input.Filters, "attachment.device-index", "1")
+	})).Return(&ec2sdk.DescribeNetworkInterfacesOutput{}, nil)
+	client.On("AttachNetworkInterface", ctx, mock.MatchedBy(func(input *ec2sdk.AttachNetworkInterfaceInput) bool {
+		return *input.InstanceId == "i-123" && *input.NetworkInterfaceId == "eni-123" && *input.DeviceIndex == 1
+	})).Return(&ec2sdk.AttachNetworkInterfaceOutput{AttachmentId: strPtr("eni-attach-1")}, nil)
+
+	a := &NetworkInterfaceAttachment{}
+	result, err := a.createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: attachmentCreateProps(map[string]any{"DeleteOnTermination": true}),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "eni-attach-1", result.ProgressResult.NativeID)
+	op, id, deleteOnTermination := decodeAttachmentRequestID(result.ProgressResult.RequestID)
+	assert.Equal(t, resource.OperationCreate, op)
+	assert.Equal(t, "eni-attach-1", id)
+	assert.True(t, *deleteOnTermination)
+	client.AssertExpectations(t)
+}
+
+func TestNetworkInterfaceAttachment_Create_AdoptsSameInterface(t *testing.T) {
+	ctx := context.Background()
+	client := &mockNetworkInterfaceAttachmentClient{}
+
+	client.On("DescribeNetworkInterfaces", ctx, mock.Anything).Return(&ec2sdk.DescribeNetworkInterfacesOutput{
+		NetworkInterfaces: []ec2types.NetworkInterface{attachmentENI("eni-123", "eni-attach-1", 1, ec2types.AttachmentStatusAttached)},
+	}, nil)
+
+	a := &NetworkInterfaceAttachment{}
+	result, err := a.createWithClient(ctx, client, &resource.CreateRequest{Properties: attachmentCreateProps(nil)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "eni-attach-1", result.ProgressResult.NativeID)
+	client.AssertNotCalled(t, "AttachNetworkInterface", mock.Anything, mock.Anything)
+}
+
+func TestNetworkInterfaceAttachment_Create_DeviceIndexTaken(t *testing.T) {
+	tests := []struct {
+		name   string
+		status ec2types.AttachmentStatus
+		code   resource.OperationErrorCode
+	}{
+		{"attached", ec2types.AttachmentStatusAttached, resource.OperationErrorCodeAlreadyExists},
+		{"detaching", ec2types.AttachmentStatusDetaching, resource.OperationErrorCodeResourceConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &mockNetworkInterfaceAttachmentClient{}
+			client.On("DescribeNetworkInterfaces", ctx, mock.Anything).Return(&ec2sdk.DescribeNetworkInterfacesOutput{
+				NetworkInterfaces: []ec2types.NetworkInterface{attachmentENI("eni-other", "eni-attach-9", 1, tt.status)},
+			}, nil)
+
+			a := &NetworkInterfaceAttachment{}
+			result, err := a.createWithClient(ctx, client, &resource.CreateRequest{Properties: attachmentCreateProps(nil)})
+
+			assert.NoError(t, err)
+			assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+			assert.Equal(t, tt.code, result.ProgressResult.ErrorCode)
+			assert.Contains(t, result.ProgressResult.StatusMessage, "eni-other")
+			client.AssertNotCalled(t, "AttachNetworkInterface", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestNetworkInterfaceAttachment_Create_InvalidDeviceIndex(t *testing.T) {
+	for _, index := range []string{"0", "-1", "eth1"} {
+		a := &NetworkInterfaceAttachment{}
+		_, err := a.createWithClient(context.Background(), &mockNetworkInterfaceAttachmentClient{}, &resource.CreateRequest{
+			Properties: attachmentCreateProps(map[string]any{"DeviceIndex": index}),
+		})
+		assert.Error(t, err, index)
+	}
+}
+
+func TestNetworkInterfaceAttachment_Status_WaitsForAttached(t *testing.T) {
+	ctx := context.Background()
+	client := &mockNetworkInterfaceAttachmentClient{}
+	client.On("DescribeNetworkInterfaces", ctx, mock.MatchedBy(func(input *ec2sdk.DescribeNetworkInterfacesInput) bool {
+		return hasFilter(input.Filters, "attachment.attachment-id", "eni-attach-1")
+	})).Return(&ec2sdk.DescribeNetworkInterfacesOutput{
+		NetworkInterfaces: []ec2types.NetworkInterface{attachmentENI("eni-123", "eni-attach-1", 1, ec2types.AttachmentStatusAttaching)},
+	}, nil)
+
+	a := &NetworkInterfaceAttachment{}
+	result, err := a.statusWithClient(ctx, client, &resource.StatusRequest{
+		RequestID: encodeAttachmentRequestID(resource.OperationCreate, "eni-attach-1", nil),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, resource.OperationCreate, result.ProgressResult.Operation)
+	assert.Equal(t, "eni-attach-1", result.ProgressResult.NativeID)
+}
+
+func TestNetworkInterfaceAttachment_Status_AttachedAppliesDeleteOnTermination(t *testing.T) {
+	ctx := context.Background()
+	client := &mockNetworkInterfaceAttachmentClient{}
+	client.On("DescribeNetworkInterfaces", ctx, mock.Anything).Return(&ec2sdk.DescribeNetworkInterfacesOutput{
+		NetworkInterfaces: []ec2types.NetworkInterface{attachmentENI("eni-123", "eni-attach-1", 1, ec2types.AttachmentStatusAttached)},
+	}, nil)
+	client.On("ModifyNetworkInterfaceAttribute", ctx, mock.MatchedBy(func(input *ec2sdk.ModifyNetworkInterfaceAttributeInput) bool {
+		return *input.NetworkInterfaceId == "eni-123" &&
+			*input.Attachment.AttachmentId == "eni-attach-1" && *input.Attachment.DeleteOnTermination
+	})).Return(&ec2sdk.ModifyNetworkInterfaceAttributeOutput{}, nil)
+
+	a := &NetworkInterfaceAttachment{}
+	result, err := a.statusWithClient(ctx, client, &resource.StatusRequest{
+		RequestID: encodeAttachmentRequestID(resource.OperationCreate, "eni-attach-1", boolPtr(true)),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	var props map[string]any
+	assert.NoError(t, json.Unmarshal(result.ProgressResult.ResourceProperties, &props))
+	assert.Equal(t, "1", props["DeviceIndex"])
+	assert.Equal(t, true, props["DeleteOnTermination"])
+	client.AssertExpectations(t)
+}
+
+func TestNetworkInterfaceAttachment_Status_Delete(t *testing.T) {
+	tests := []struct {
+		name string
+		enis []ec2types.NetworkInterface
+		want resource.OperationStatus
+	}{
+		{"detaching", []ec2types.NetworkInterface{attachmentENI("eni-123", "eni-attach-1", 1, ec2types.AttachmentStatusDetaching)}, resource.OperationStatusInProgress},
+		{"detached", []ec2types.NetworkInterface{attachmentENI("eni-123", "eni-attach-1", 1, ec2types.AttachmentStatusDetached)}, resource.OperationStatusSuccess},
+		{"gone", nil, resource.OperationStatusSuccess},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &mockNetworkInterfaceAttachmentClient{}
+			client.On("DescribeNetworkInterfaces", ctx, mock.Anything).Return(&ec2sdk.DescribeNetworkInterfacesOutput{NetworkInterfaces: tt.enis}, nil)
+
+			a := &NetworkInterfaceAttachment{}
+			result, err := a.statusWithClient(ctx, client, &resource.StatusRequest{
+				RequestID: encodeAttachmentRequestID(resource.OperationDelete, "eni-attach-1", nil),
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result.ProgressResult.OperationStatus)
+			assert.Equal(t, resource.OperationDelete, result.ProgressResult.Operation)
+		})
+	}
+}
+
+func TestNetworkInterfaceAttachment_Read(t *testing.T) {
+	ctx := context.Background()
+	client := &mockNetworkInterfaceAttachmentClient{}
+	client.On("DescribeNetworkInterfaces", ctx, mock.Anything).Return(&ec2sdk.DescribeNetworkInterfacesOutput{
+		NetworkInterfaces: []ec2types.NetworkInterface{attachmentENI("eni-123", "eni-attach-1", 2, ec2types.AttachmentStatusAttached)},
+	}, nil)
+
+	a := &NetworkInterfaceAttachment{}
+	result, err := a.readWithClient(ctx, client, &resource.ReadRequest{NativeID: "eni-attach-1"})
+
+	assert.NoError(t, err)
+	var props map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, "eni-attach-1", props["AttachmentId"])
+	assert.Equal(t, "i-123", props["InstanceId"])
+	assert.Equal(t, "eni-123", props["NetworkInterfaceId"])
+	assert.Equal(t, "2", props["DeviceIndex"])
+}
+
+func TestNetworkInterfaceAttachment_Read_NotFound(t *testing.T) {
+	ctx := context.Background()
+	client := &mockNetworkInterfaceAttachmentClient{}
+	client.On("DescribeNetworkInterfaces", ctx, mock.Anything).Return(&ec2sdk.DescribeNetworkInterfacesOutput{}, nil)
+
+	a := &NetworkInterfaceAttachment{}
+	result, err := a.readWithClient(ctx, client, &resource.ReadRequest{NativeID: "eni-attach-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+}
+
+func TestNetworkInterfaceAttachment_Update_DeleteOnTermination(t *testing.T) {
+	ctx := context.Background()
+	client := &mockNetworkInterfaceAttachmentClient{}
+	client.On("DescribeNetworkInterfaces", ctx, mock.Anything).Return(&ec2sdk.DescribeNetworkInterfacesOutput{
+		NetworkInterfaces: []ec2types.NetworkInterface{attachmentENI("eni-123", "eni-attach-1", 1, ec2types.AttachmentStatusAttached)},
+	}, nil)
+	client.On("ModifyNetworkInterfaceAttribute", ctx, mock.MatchedBy(func(input *ec2sdk.ModifyNetworkInterfaceAttributeInput) bool {
+		return input.Attachment != nil && *input.Attachment.DeleteOnTermination
+	})).Return(&ec2sdk.ModifyNetworkInterfaceAttributeOutput{}, nil)
+
+	a := &NetworkInterfaceAttachment{}
+	result, err := a.updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "eni-attach-1",
+		DesiredProperties: attachmentCreateProps(map[string]any{"DeleteOnTermination": true}),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertExpectations(t)
+}
+
+func TestNetworkInterfaceAttachment_Delete_Detaches(t *testing.T) {
+	ctx := context.Background()
+	client := &mockNetworkInterfaceAttachmentClient{}
+	client.On("DescribeNetworkInterfaces", ctx, mock.Anything).Return(&ec2sdk.DescribeNetworkInterfacesOutput{
+		NetworkInterfaces: []ec2types.NetworkInterface{attachmentENI("eni-123", "eni-attach-1", 1, ec2types.AttachmentStatusAttached)},
+	}, nil)
+	client.On("DetachNetworkInterface", ctx, mock.MatchedBy(func(input *ec2sdk.DetachNetworkInterfaceInput) bool {
+		return *input.AttachmentId == "eni-attach-1"
+	})).Return(&ec2sdk.DetachNetworkInterfaceOutput{}, nil)
+
+	a := &NetworkInterfaceAttachment{}
+	result, err := a.deleteWithClient(ctx, client, &resource.DeleteRequest{NativeID: "eni-attach-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	op, id, _ := decodeAttachmentRequestID(result.ProgressResult.RequestID)
+	assert.Equal(t, resource.OperationDelete, op)
+	assert.Equal(t, "eni-attach-1", id)
+	client.AssertExpectations(t)
+}
+
+func TestNetworkInterfaceAttachment_Delete_AlreadyGone(t *testing.T) {
+	ctx := context.Background()
+	client := &mockNetworkInterfaceAttachmentClient{}
+	client.On("DescribeNetworkInterfaces", ctx, mock.Anything).Return(&ec2sdk.DescribeNetworkInterfacesOutput{}, nil)
+
+	a := &NetworkInterfaceAttachment{}
+	result, err := a.deleteWithClient(ctx, client, &resource.DeleteRequest{NativeID: "eni-attach-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertNotCalled(t, "DetachNetworkInterface", mock.Anything, mock.Anything)
+}
+
+func TestNetworkInterfaceAttachment_List_SkipsPrimaryInterfaces(t *testing.T) {
+	ctx := context.Background()
+	client := &mockNetworkInterfaceAttachmentClient{}
+	client.On("DescribeNetworkInterfaces", ctx, mock.MatchedBy(func(input *ec2sdk.DescribeNetworkInterfacesInput) bool {
+		return hasFilter(input.Filters, "attachment.status", "attached")
+	})).Return(&ec2sdk.DescribeNetworkInterfacesOutput{
+		NetworkInterfaces: []ec2types.NetworkInterface{
+			attachmentENI("eni-primary", "eni-attach-0", 0, ec2types.AttachmentStatusAttached),
+			attachmentENI("eni-123", "eni-attach-1", 1, ec2types.AttachmentStatusAttached),
+		},
+	}, nil)
+
+	a := &NetworkInterfaceAttachment{}
+	result, err := a.listWithClient(ctx, client, &resource.ListRequest{ResourceType: networkInterfaceAttachmentType})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"eni-attach-1"}, result.NativeIDs)
+}
@@ -7,12 +7,17 @@ package ec2
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"net/netip"
+	"sort"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
@@ -20,8 +25,25 @@ import (
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
 )
 
+type routeClientInterface interface {
+	CreateRoute(ctx context.Context, params *ec2.CreateRouteInput, optFns ...func(*ec2.Options)) (*ec2.CreateRouteOutput, error)
+	DeleteRoute(ctx context.Context, params *ec2.DeleteRouteInput, optFns ...func(*ec2.Options)) (*ec2.DeleteRouteOutput, error)
+	ReplaceRoute(ctx context.Context, params *ec2.ReplaceRouteInput, optFns ...func(*ec2.Options)) (*ec2.ReplaceRouteOutput, error)
+	DescribeRouteTables(ctx context.Context, params *ec2.DescribeRouteTablesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error)
+	vpcPeeringConnectionsClient
+}
+
+// vpcPeeringConnectionsClient reads the peering connection a route targets,
+// in the target's account or a peer account.
+type vpcPeeringConnectionsClient interface {
+	DescribeVpcPeeringConnections(ctx context.Context, params *ec2.DescribeVpcPeeringConnectionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcPeeringConnectionsOutput, error)
+}
+
 type Route struct {
 	cfg *config.Config
+	// peerClient is injectable for testing; nil means a client assuming the
+	// target's role in the peer account, from PeerAccountRoleArns.
+	peerClient func(ctx context.Context, accountID string) (vpcPeeringConnectionsClient, error)
 }
 
 var _ prov.Provisioner = &Route{}
@@ -33,30 +55,194 @@ func init() {
 			resource.OperationCreate,
 			resource.OperationUpdate,
 			resource.OperationCheckStatus,
-			resource.OperationDelete},
+			resource.OperationDelete,
+			resource.OperationList},
 		func(cfg *config.Config) prov.Provisioner {
 			return &Route{cfg: cfg}
 		})
 }
 
+// routeFilters maps the parent properties a Route List accepts to their
+// DescribeRouteTables filter names. A List can also be scoped with
+// RouteTableTags, which become tag filters.
+var routeFilters = map[string]string{
+	"RouteTableId": "route-table-id",
+	"VpcId":        "vpc-id",
+}
+
+// Properties a route's table can be looked up by in place of RouteTableId,
+// for route tables created outside the stack.
+const (
+	routeTableVpcId = "VpcId"
+	routeTableTags  = "RouteTableTags"
+)
+
+// resolveRouteTable sets RouteTableId from VpcId and RouteTableTags when the
+// route is declared against a route table it doesn't name. The tags select
+// the table among the VPC's, or the region's when VpcId isn't given; VpcId
+// alone selects the VPC's main route table. Exactly one table must match.
+func resolveRouteTable(ctx context.Context, client routeClientInterface, props map[string]any) error {
+	if id, _ := utils.GetStringProperty(props, "RouteTableId"); id != "" {
+		return nil
+	}
+	vpcID, _ := utils.GetStringProperty(props, routeTableVpcId)
+	tags, err := parseRouteTableTags(props[routeTableTags])
+	if err != nil {
+		return err
+	}
+	if vpcID == "" && len(tags) == 0 {
+		return fmt.Errorf("invalid RouteTableId: one of RouteTableId, %s, %s is required", routeTableVpcId, routeTableTags)
+	}
+
+	filters := routeTableTagFilters(tags)
+	lookup := fmt.Sprintf("tags %v", tags)
+	if vpcID != "" {
+		filters = append(filters, ec2types.Filter{Name: aws.String("vpc-id"), Values: []string{vpcID}})
+		lookup = fmt.Sprintf("VPC %s with %s", vpcID, lookup)
+	}
+	if len(tags) == 0 {
+		filters = append(filters, ec2types.Filter{Name: aws.String("association.main"), Values: []string{"true"}})
+		lookup = fmt.Sprintf("main route table of VPC %s", vpcID)
+	}
+
+	resp, err := client.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{Filters: filters})
+	if err != nil {
+		return fmt.Errorf("failed to look up route table: %w", err)
+	}
+	switch len(resp.RouteTables) {
+	case 0:
+		return fmt.Errorf("no route table found for %s", lookup)
+	case 1:
+		props["RouteTableId"] = aws.ToString(resp.RouteTables[0].RouteTableId)
+		return nil
+	default:
+		return fmt.Errorf("%d route tables found for %s; RouteTableTags must select one", len(resp.RouteTables), lookup)
+	}
+}
+
+// parseRouteTableTags reads RouteTableTags, given as an object of tag keys
+// and values, or as that object in JSON in a List's AdditionalProperties.
+func parseRouteTableTags(value any) (map[string]string, error) {
+	if s, ok := value.(string); ok {
+		if s == "" {
+			return nil, nil
+		}
+		var tags map[string]string
+		if err := json.Unmarshal([]byte(s), &tags); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", routeTableTags, err)
+		}
+		return tags, nil
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		if value != nil {
+			return nil, fmt.Errorf("invalid %s: expected an object of tag keys and values", routeTableTags)
+		}
+		return nil, nil
+	}
+	tags := make(map[string]string, len(object))
+	for k, v := range object {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s: value of %s is not a string", routeTableTags, k)
+		}
+		tags[k] = s
+	}
+	return tags, nil
+}
+
+func routeTableTagFilters(tags map[string]string) []ec2types.Filter {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	filters := make([]ec2types.Filter, 0, len(keys))
+	for _, k := range keys {
+		filters = append(filters, ec2types.Filter{Name: aws.String("tag:" + k), Values: []string{tags[k]}})
+	}
+	return filters
+}
+
+// Properties a route's destination can be given by; exactly one is set.
+const (
+	destinationCidrBlock     = "DestinationCidrBlock"
+	destinationIpv6CidrBlock = "DestinationIpv6CidrBlock"
+	destinationPrefixListId  = "DestinationPrefixListId"
+)
+
+var destinationKeys = []string{destinationCidrBlock, destinationIpv6CidrBlock, destinationPrefixListId}
+
+// routeDestination returns the destination property a route is given by and
+// its value: an IPv4 CIDR block, an IPv6 CIDR block, or a managed prefix list.
+func routeDestination(props map[string]any) (string, string, error) {
+	var key, value string
+	for _, k := range destinationKeys {
+		if v, _ := utils.GetStringProperty(props, k); v != "" {
+			if key != "" {
+				return "", "", fmt.Errorf("%s and %s are mutually exclusive", key, k)
+			}
+			key, value = k, v
+		}
+	}
+	if key == "" {
+		return "", "", fmt.Errorf("one of %s is required", strings.Join(destinationKeys, ", "))
+	}
+	return key, value, nil
+}
+
+// destinationKey returns the property the destination in a route's NativeID
+// was given by, which its form tells apart.
+func destinationKey(destination string) string {
+	switch {
+	case strings.HasPrefix(destination, "pl-"):
+		return destinationPrefixListId
+	case strings.Contains(destination, ":"):
+		return destinationIpv6CidrBlock
+	default:
+		return destinationCidrBlock
+	}
+}
+
+// sameDestination compares CIDR blocks by the prefix they denote, since EC2
+// reports IPv6 blocks in their compressed form. Prefix list IDs are compared
+// as they are.
+func sameDestination(want string, got *string) bool {
+	if got == nil {
+		return false
+	}
+	wantPrefix, wantErr := netip.ParsePrefix(want)
+	gotPrefix, gotErr := netip.ParsePrefix(*got)
+	if wantErr != nil || gotErr != nil {
+		return want == *got
+	}
+	return wantPrefix == gotPrefix
+}
+
+// NativeID format: routeTableId|destination|targetKey=targetValue, where the
+// destination is the route's IPv4 or IPv6 CIDR block or prefix list ID.
 func buildNativeID(props map[string]any) (string, string, error) {
 	routeTableID, err := utils.GetStringProperty(props, "RouteTableId")
 	if err != nil {
 		return "", "", fmt.Errorf("invalid RouteTableId: %w", err)
 	}
-	destinationCidrBlock, err := utils.GetStringProperty(props, "DestinationCidrBlock")
+	_, destination, err := routeDestination(props)
 	if err != nil {
-		return "", "", fmt.Errorf("invalid DestinationCidrBlock: %w", err)
+		return "", "", err
 	}
 
 	targetKeys := []string{
 		"GatewayId",
+		"EgressOnlyInternetGatewayId",
 		"NatGatewayId",
 		"NetworkInterfaceId",
 		"InstanceId",
 		"TransitGatewayId",
 		"VpcEndpointId",
 		"VpcPeeringConnectionId",
+		"CarrierGatewayId",
+		"LocalGatewayId",
+		"CoreNetworkArn",
 	}
 	var targetKey, targetValue string
 	for _, key := range targetKeys {
@@ -71,7 +257,7 @@ func buildNativeID(props map[string]any) (string, string, error) {
 	if targetKey == "" {
 		return "", "", fmt.Errorf("no route target set")
 	}
-	nativeID := fmt.Sprintf("%s|%s|%s=%s", routeTableID, destinationCidrBlock, targetKey, targetValue)
+	nativeID := fmt.Sprintf("%s|%s|%s=%s", routeTableID, destination, targetKey, targetValue)
 	return nativeID, targetKey, nil
 }
 
@@ -80,31 +266,46 @@ func (r Route) Create(ctx context.Context, request *resource.CreateRequest) (*re
 	if err != nil {
 		return nil, fmt.Errorf("unable to load AWS config: %w", err)
 	}
-	client := ec2.NewFromConfig(cfg)
+	return r.createWithClient(ctx, ec2.NewFromConfig(cfg), request)
+}
 
+func (r Route) createWithClient(ctx context.Context, client routeClientInterface, request *resource.CreateRequest) (*resource.CreateResult, error) {
 	var props map[string]any
 	if err := json.Unmarshal(request.Properties, &props); err != nil {
 		return nil, fmt.Errorf("failed to parse properties: %w", err)
 	}
+	if err := resolveRouteTable(ctx, client, props); err != nil {
+		return nil, err
+	}
 
 	routeTableID, err := utils.GetStringProperty(props, "RouteTableId")
 	if err != nil {
 		return nil, fmt.Errorf("invalid RouteTableId: %w", err)
 	}
-	destinationCidrBlock, err := utils.GetStringProperty(props, "DestinationCidrBlock")
+	key, destination, err := routeDestination(props)
 	if err != nil {
-		return nil, fmt.Errorf("invalid DestinationCidrBlock: %w", err)
+		return nil, err
 	}
 
 	input := &ec2.CreateRouteInput{
-		RouteTableId:         aws.String(routeTableID),
-		DestinationCidrBlock: aws.String(destinationCidrBlock),
+		RouteTableId: aws.String(routeTableID),
+	}
+	switch key {
+	case destinationIpv6CidrBlock:
+		input.DestinationIpv6CidrBlock = aws.String(destination)
+	case destinationPrefixListId:
+		input.DestinationPrefixListId = aws.String(destination)
+	default:
+		input.DestinationCidrBlock = aws.String(destination)
 	}
 
 	// Optional targets
 	if gw, _ := utils.GetStringProperty(props, "GatewayId"); gw != "" {
 		input.GatewayId = aws.String(gw)
 	}
+	if eigw, _ := utils.GetStringProperty(props, "EgressOnlyInternetGatewayId"); eigw != "" {
+		input.EgressOnlyInternetGatewayId = aws.String(eigw)
+	}
 	if nat, _ := utils.GetStringProperty(props, "NatGatewayId"); nat != "" {
 		input.NatGatewayId = aws.String(nat)
 	}
@@ -123,6 +324,25 @@ func (r Route) Create(ctx context.Context, request *resource.CreateRequest) (*re
 	if vpcPeering, _ := utils.GetStringProperty(props, "VpcPeeringConnectionId"); vpcPeering != "" {
 		input.VpcPeeringConnectionId = aws.String(vpcPeering)
 	}
+	if carrier, _ := utils.GetStringProperty(props, "CarrierGatewayId"); carrier != "" {
+		input.CarrierGatewayId = aws.String(carrier)
+	}
+	if localGateway, _ := utils.GetStringProperty(props, "LocalGatewayId"); localGateway != "" {
+		input.LocalGatewayId = aws.String(localGateway)
+	}
+	if coreNetwork, _ := utils.GetStringProperty(props, "CoreNetworkArn"); coreNetwork != "" {
+		input.CoreNetworkArn = aws.String(coreNetwork)
+	}
+
+	if input.VpcPeeringConnectionId != nil {
+		pr, err := r.checkPeeringConnection(ctx, client, resource.OperationCreate, *input.VpcPeeringConnectionId)
+		if err != nil {
+			return nil, err
+		}
+		if pr != nil {
+			return &resource.CreateResult{ProgressResult: pr}, nil
+		}
+	}
 
 	_, err = client.CreateRoute(ctx, input)
 	if err != nil {
@@ -143,9 +363,226 @@ func (r Route) Create(ctx context.Context, request *resource.CreateRequest) (*re
 	}, nil
 }
 
+// checkPeeringConnection makes sure the peering connection a route targets
+// can carry traffic before the route points at it, since EC2 rejects a route
+// to a connection that isn't active. A connection with another account only
+// becomes active once that account accepts it, which in a hub-and-spoke stack
+// may happen later in the same apply; the route then fails with a recoverable
+// ResourceConflict rather than EC2's error. The result is nil when the
+// connection is active.
+//
+// A connection the target's account doesn't see as active is read again from
+// the account on its other side, when PeerAccountRoleArns has a role there:
+// that account's view of a connection it owns, or has just accepted, is
+// ahead of the target's.
+func (r Route) checkPeeringConnection(ctx context.Context, client vpcPeeringConnectionsClient, operation resource.Operation, pcxID string) (*resource.ProgressResult, error) {
+	pcx, err := describePeeringConnection(ctx, client, pcxID)
+	if err != nil {
+		return nil, err
+	}
+	if pcx != nil && peeringStatus(pcx) != ec2types.VpcPeeringConnectionStateReasonCodeActive {
+		for _, info := range []*ec2types.VpcPeeringConnectionVpcInfo{pcx.AccepterVpcInfo, pcx.RequesterVpcInfo} {
+			if info == nil || info.OwnerId == nil {
+				continue
+			}
+			peerClient, err := r.peerAccountClient(ctx, *info.OwnerId)
+			if err != nil {
+				return nil, err
+			}
+			if peerClient == nil {
+				continue
+			}
+			peerPcx, err := describePeeringConnection(ctx, peerClient, pcxID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read VPC peering connection %s from account %s: %w", pcxID, *info.OwnerId, err)
+			}
+			if peerPcx != nil {
+				pcx = peerPcx
+			}
+			break
+		}
+	}
+
+	pr := &resource.ProgressResult{
+		Operation:       operation,
+		OperationStatus: resource.OperationStatusFailure,
+	}
+	switch status := peeringStatus(pcx); status {
+	case ec2types.VpcPeeringConnectionStateReasonCodeActive:
+		return nil, nil
+	case "":
+		pr.ErrorCode = resource.OperationErrorCodeNotFound
+		pr.StatusMessage = fmt.Sprintf("VPC peering connection %s not found", pcxID)
+	case ec2types.VpcPeeringConnectionStateReasonCodeInitiatingRequest,
+		ec2types.VpcPeeringConnectionStateReasonCodePendingAcceptance,
+		ec2types.VpcPeeringConnectionStateReasonCodeProvisioning:
+		pr.ErrorCode = resource.OperationErrorCodeResourceConflict
+		pr.StatusMessage = fmt.Sprintf("VPC peering connection %s is %s", pcxID, status)
+		if pcx.AccepterVpcInfo != nil && pcx.AccepterVpcInfo.OwnerId != nil {
+			pr.StatusMessage += fmt.Sprintf("; routes to it can be created once account %s accepts it", *pcx.AccepterVpcInfo.OwnerId)
+		}
+	default:
+		pr.ErrorCode = resource.OperationErrorCodeInvalidRequest
+		pr.StatusMessage = fmt.Sprintf("VPC peering connection %s is %s", pcxID, status)
+	}
+	return pr, nil
+}
+
+// peerAccountClient returns a client reading from accountID with the
+// target's role there, or nil when PeerAccountRoleArns has none.
+func (r Route) peerAccountClient(ctx context.Context, accountID string) (vpcPeeringConnectionsClient, error) {
+	if r.peerClient != nil {
+		return r.peerClient(ctx, accountID)
+	}
+	if r.cfg == nil {
+		return nil, nil
+	}
+	peer, ok := r.cfg.PeerAccount(accountID)
+	if !ok {
+		return nil, nil
+	}
+	awsCfg, err := peer.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config for peer account %s: %w", accountID, err)
+	}
+	return ec2.NewFromConfig(awsCfg), nil
+}
+
+// describePeeringConnection returns the peering connection, or nil when it
+// doesn't exist.
+func describePeeringConnection(ctx context.Context, client vpcPeeringConnectionsClient, pcxID string) (*ec2types.VpcPeeringConnection, error) {
+	resp, err := client.DescribeVpcPeeringConnections(ctx, &ec2.DescribeVpcPeeringConnectionsInput{
+		VpcPeeringConnectionIds: []string{pcxID},
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "InvalidVpcPeeringConnectionID.NotFound" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to describe VPC peering connection %s: %w", pcxID, err)
+	}
+	if len(resp.VpcPeeringConnections) == 0 {
+		return nil, nil
+	}
+	return &resp.VpcPeeringConnections[0], nil
+}
+
+func peeringStatus(pcx *ec2types.VpcPeeringConnection) ec2types.VpcPeeringConnectionStateReasonCode {
+	if pcx == nil || pcx.Status == nil {
+		return ""
+	}
+	return pcx.Status.Code
+}
+
 func (r Route) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
-	// EC2 Route resources cannot be updated in-place; must delete and recreate.
-	return nil, fmt.Errorf("update is not supported for AWS::EC2::Route resources; delete and recreate instead")
+	cfg, err := r.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return r.updateWithClient(ctx, ec2.NewFromConfig(cfg), request)
+}
+
+// updateWithClient points the route at its new target with ReplaceRoute, so a
+// target swap such as an internet gateway to a NAT gateway doesn't take the
+// route away. A route moved to another table or destination is created in its
+// new place first, and the old route is deleted only once that succeeds, so a
+// failed create leaves the original route serving traffic.
+func (r Route) updateWithClient(ctx context.Context, client routeClientInterface, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	var props map[string]any
+	if err := json.Unmarshal(request.DesiredProperties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse desired state properties: %w", err)
+	}
+	if err := resolveRouteTable(ctx, client, props); err != nil {
+		return nil, err
+	}
+	nativeID, targetKey, err := buildNativeID(props)
+	if err != nil {
+		return nil, err
+	}
+	if targetKey == "VpcPeeringConnectionId" {
+		pcxID, _ := utils.GetStringProperty(props, targetKey)
+		pr, err := r.checkPeeringConnection(ctx, client, resource.OperationUpdate, pcxID)
+		if err != nil {
+			return nil, err
+		}
+		if pr != nil {
+			pr.NativeID = request.NativeID
+			return &resource.UpdateResult{ProgressResult: pr}, nil
+		}
+	}
+
+	parts := strings.SplitN(request.NativeID, "|", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid NativeID format: expected RouteTableId|Destination|target, got: %s", request.NativeID)
+	}
+	routeTableID, _ := utils.GetStringProperty(props, "RouteTableId")
+	key, destination, _ := routeDestination(props)
+
+	if routeTableID != parts[0] || key != destinationKey(parts[1]) || !sameDestination(destination, &parts[1]) {
+		created, err := r.createWithClient(ctx, client, &resource.CreateRequest{Properties: request.DesiredProperties})
+		if err != nil {
+			return nil, err
+		}
+		if created.ProgressResult.OperationStatus != resource.OperationStatusSuccess {
+			pr := created.ProgressResult
+			pr.Operation = resource.OperationUpdate
+			pr.NativeID = request.NativeID
+			return &resource.UpdateResult{ProgressResult: pr}, nil
+		}
+		if _, err := r.deleteWithClient(ctx, client, &resource.DeleteRequest{NativeID: request.NativeID}); err != nil {
+			return nil, fmt.Errorf("created route %s but failed to delete the route it replaces: %w", nativeID, err)
+		}
+	} else {
+		input := &ec2.ReplaceRouteInput{
+			RouteTableId: aws.String(routeTableID),
+		}
+		switch key {
+		case destinationIpv6CidrBlock:
+			input.DestinationIpv6CidrBlock = aws.String(parts[1])
+		case destinationPrefixListId:
+			input.DestinationPrefixListId = aws.String(parts[1])
+		default:
+			input.DestinationCidrBlock = aws.String(parts[1])
+		}
+
+		target, _ := utils.GetStringProperty(props, targetKey)
+		switch targetKey {
+		case "GatewayId":
+			input.GatewayId = aws.String(target)
+		case "EgressOnlyInternetGatewayId":
+			input.EgressOnlyInternetGatewayId = aws.String(target)
+		case "NatGatewayId":
+			input.NatGatewayId = aws.String(target)
+		case "NetworkInterfaceId":
+			input.NetworkInterfaceId = aws.String(target)
+		case "InstanceId":
+			input.InstanceId = aws.String(target)
+		case "TransitGatewayId":
+			input.TransitGatewayId = aws.String(target)
+		case "VpcEndpointId":
+			input.VpcEndpointId = aws.String(target)
+		case "VpcPeeringConnectionId":
+			input.VpcPeeringConnectionId = aws.String(target)
+		case "CarrierGatewayId":
+			input.CarrierGatewayId = aws.String(target)
+		case "LocalGatewayId":
+			input.LocalGatewayId = aws.String(target)
+		case "CoreNetworkArn":
+			input.CoreNetworkArn = aws.String(target)
+		}
+
+		if _, err := client.ReplaceRoute(ctx, input); err != nil {
+			return nil, fmt.Errorf("failed to replace route: %w", err)
+		}
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        nativeID,
+		},
+	}, nil
 }
 
 func (r Route) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
@@ -153,9 +590,11 @@ func (r Route) Delete(ctx context.Context, request *resource.DeleteRequest) (*re
 	if err != nil {
 		return nil, fmt.Errorf("unable to load AWS config: %w", err)
 	}
-	client := ec2.NewFromConfig(cfg)
+	return r.deleteWithClient(ctx, ec2.NewFromConfig(cfg), request)
+}
 
-	readRes, err := r.Read(ctx, &resource.ReadRequest{
+func (r Route) deleteWithClient(ctx context.Context, client routeClientInterface, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	readRes, err := r.readWithClient(ctx, client, &resource.ReadRequest{
 		NativeID: request.NativeID,
 	})
 	if err != nil {
@@ -174,15 +613,22 @@ func (r Route) Delete(ctx context.Context, request *resource.DeleteRequest) (*re
 
 	parts := strings.SplitN(request.NativeID, "|", 3)
 	if len(parts) != 3 {
-		return nil, fmt.Errorf("invalid NativeID format: expected RouteTableId|DestinationCidrBlock|target, got: %s", request.NativeID)
+		return nil, fmt.Errorf("invalid NativeID format: expected RouteTableId|Destination|target, got: %s", request.NativeID)
 	}
 
 	routeTableID := parts[0]
-	destinationCidrBlock := parts[1]
+	destination := parts[1]
 
 	input := &ec2.DeleteRouteInput{
-		RouteTableId:         aws.String(routeTableID),
-		DestinationCidrBlock: aws.String(destinationCidrBlock),
+		RouteTableId: aws.String(routeTableID),
+	}
+	switch destinationKey(destination) {
+	case destinationIpv6CidrBlock:
+		input.DestinationIpv6CidrBlock = aws.String(destination)
+	case destinationPrefixListId:
+		input.DestinationPrefixListId = aws.String(destination)
+	default:
+		input.DestinationCidrBlock = aws.String(destination)
 	}
 
 	_, err = client.DeleteRoute(ctx, input)
@@ -190,7 +636,7 @@ func (r Route) Delete(ctx context.Context, request *resource.DeleteRequest) (*re
 		return nil, fmt.Errorf("failed to delete route: %w", err)
 	}
 
-	nativeID := fmt.Sprintf("%s|%s", routeTableID, destinationCidrBlock)
+	nativeID := fmt.Sprintf("%s|%s", routeTableID, destination)
 	return &resource.DeleteResult{
 		ProgressResult: &resource.ProgressResult{
 			Operation:       resource.OperationDelete,
@@ -201,7 +647,51 @@ func (r Route) Delete(ctx context.Context, request *resource.DeleteRequest) (*re
 }
 
 func (r Route) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	return nil, fmt.Errorf("status check is not implemented for AWS::EC2::Route resources")
+	cfg, err := r.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return r.statusWithClient(ctx, ec2.NewFromConfig(cfg), request)
+}
+
+// statusWithClient reports the state of the route. Routes are created and
+// replaced synchronously, so there is nothing to wait for; an active route
+// succeeds and a blackhole route, whose target has been deleted or detached,
+// fails.
+func (r Route) statusWithClient(ctx context.Context, client routeClientInterface, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	nativeID := request.NativeID
+	if nativeID == "" {
+		nativeID = request.RequestID
+	}
+	route, err := findRoute(ctx, client, nativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := &resource.ProgressResult{
+		Operation:       resource.OperationCheckStatus,
+		OperationStatus: resource.OperationStatusSuccess,
+		RequestID:       request.RequestID,
+		NativeID:        nativeID,
+	}
+	switch {
+	case route == nil:
+		pr.OperationStatus = resource.OperationStatusFailure
+		pr.ErrorCode = resource.OperationErrorCodeNotFound
+		pr.StatusMessage = fmt.Sprintf("route %s not found", nativeID)
+	case route.State == ec2types.RouteStateBlackhole:
+		targetKey, target := routeTarget(*route)
+		pr.OperationStatus = resource.OperationStatusFailure
+		pr.ErrorCode = resource.OperationErrorCodeDependencyFailure
+		pr.StatusMessage = fmt.Sprintf("route is a blackhole: its target %s %s is unavailable", targetKey, target)
+	default:
+		props, err := json.Marshal(routeProperties(nativeID, *route))
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal route properties: %w", err)
+		}
+		pr.ResourceProperties = props
+	}
+	return &resource.StatusResult{ProgressResult: pr}, nil
 }
 
 func (r Route) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
@@ -209,92 +699,205 @@ func (r Route) Read(ctx context.Context, request *resource.ReadRequest) (*resour
 	if err != nil {
 		return nil, fmt.Errorf("unable to load AWS config: %w", err)
 	}
-	client := ec2.NewFromConfig(cfg)
+	return r.readWithClient(ctx, ec2.NewFromConfig(cfg), request)
+}
 
-	// Parse NativeID
-	var routeTableID, destinationCidrBlock string
+func (r Route) readWithClient(ctx context.Context, client routeClientInterface, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	route, err := findRoute(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if route == nil {
+		return &resource.ReadResult{
+			ResourceType: "AWS::EC2::Route",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
 
-	parts := strings.SplitN(request.NativeID, "|", 3)
-	if len(parts) != 3 {
-		return nil, fmt.Errorf("invalid NativeID format: expected RouteTableId|DestinationCidrBlock|target, got: %s", request.NativeID)
+	props := routeProperties(request.NativeID, *route)
+	// A route declared by a route table lookup keeps reporting it.
+	var prior map[string]any
+	if len(request.PriorProperties) > 0 && json.Unmarshal(request.PriorProperties, &prior) == nil {
+		for _, key := range []string{routeTableVpcId, routeTableTags} {
+			if v, ok := prior[key]; ok {
+				props[key] = v
+			}
+		}
+	}
+	propBytes, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal route properties: %w", err)
 	}
 
-	routeTableID = parts[0]
-	destinationCidrBlock = parts[1]
-	//target = parts[2]
+	return &resource.ReadResult{
+		ResourceType: "AWS::EC2::Route",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+// errCodeRouteTableNotFound is the EC2 error code returned when a route table
+// doesn't exist.
+const errCodeRouteTableNotFound = "InvalidRouteTableID.NotFound"
+
+// isRouteTableNotFound reports whether err is the EC2 route-table-not-found
+// error.
+func isRouteTableNotFound(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return strings.EqualFold(apiErr.ErrorCode(), errCodeRouteTableNotFound)
+	}
+	return false
+}
+
+// findRoute returns the route with the given NativeID, or nil when it or its
+// route table can't be found.
+func findRoute(ctx context.Context, client routeClientInterface, nativeID string) (*ec2types.Route, error) {
+	parts := strings.SplitN(nativeID, "|", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid NativeID format: expected RouteTableId|Destination|target, got: %s", nativeID)
+	}
+	routeTableID, destination := parts[0], parts[1]
 
 	resp, err := client.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{
 		RouteTableIds: []string{routeTableID},
 	})
-
 	if err != nil {
-		//return nil, fmt.Errorf("failed to describe route table: %w", err)
-		return &resource.ReadResult{
-			ResourceType: "AWS::EC2::Route",
-			ErrorCode:    resource.OperationErrorCodeNotFound,
-		}, nil
+		// A route table that is gone fails to describe; any other failure
+		// says nothing about whether the route exists.
+		if isRouteTableNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to describe route table %s: %w", routeTableID, err)
 	}
 	if len(resp.RouteTables) == 0 {
-		//return nil, fmt.Errorf("route table %s not found", routeTableID)
-		return &resource.ReadResult{
-			ResourceType: "AWS::EC2::Route",
-			ErrorCode:    resource.OperationErrorCodeNotFound,
-		}, nil
+		return nil, nil
 	}
 
-	var matchedRoute ec2types.Route
-	found := false
+	key := destinationKey(destination)
 	for _, route := range resp.RouteTables[0].Routes {
-		if route.DestinationCidrBlock != nil && *route.DestinationCidrBlock == destinationCidrBlock {
-			matchedRoute = route
-			found = true
-			break
+		got := route.DestinationCidrBlock
+		switch key {
+		case destinationIpv6CidrBlock:
+			got = route.DestinationIpv6CidrBlock
+		case destinationPrefixListId:
+			got = route.DestinationPrefixListId
+		}
+		if sameDestination(destination, got) {
+			return &route, nil
 		}
 	}
-	if !found {
-		//return nil, fmt.Errorf("route for %s not found in route table %s", destinationCidrBlock, routeTableID)
-		return &resource.ReadResult{
-			ResourceType: "AWS::EC2::Route",
-			ErrorCode:    resource.OperationErrorCodeNotFound,
-		}, nil
-	}
+	return nil, nil
+}
 
-	// Build properties map
+// routeProperties builds the properties of a route found by findRoute.
+func routeProperties(nativeID string, route ec2types.Route) map[string]any {
+	parts := strings.SplitN(nativeID, "|", 3)
 	props := map[string]any{
-		"RouteTableId":         routeTableID,
-		"DestinationCidrBlock": destinationCidrBlock,
+		"RouteTableId":           parts[0],
+		destinationKey(parts[1]): parts[1],
 	}
 
 	// Add the target (only one is allowed)
+	if targetKey, target := routeTarget(route); targetKey != "" {
+		props[targetKey] = target
+	}
+	return props
+}
+
+// routeTarget returns the target property of a described route and its value,
+// or empty strings when the route has none of the targets a Route manages.
+func routeTarget(route ec2types.Route) (string, string) {
+	switch {
+	case route.GatewayId != nil && strings.HasPrefix(*route.GatewayId, "vpce-"):
+		// EC2 reports a VPC endpoint target, common on prefix list routes, as
+		// a gateway.
+		return "VpcEndpointId", *route.GatewayId
+	case route.GatewayId != nil:
+		return "GatewayId", *route.GatewayId
+	case route.EgressOnlyInternetGatewayId != nil:
+		return "EgressOnlyInternetGatewayId", *route.EgressOnlyInternetGatewayId
+	case route.NatGatewayId != nil:
+		return "NatGatewayId", *route.NatGatewayId
+	case route.NetworkInterfaceId != nil:
+		return "NetworkInterfaceId", *route.NetworkInterfaceId
+	case route.InstanceId != nil:
+		return "InstanceId", *route.InstanceId
+	case route.TransitGatewayId != nil:
+		return "TransitGatewayId", *route.TransitGatewayId
+	case route.VpcPeeringConnectionId != nil:
+		return "VpcPeeringConnectionId", *route.VpcPeeringConnectionId
+	case route.CarrierGatewayId != nil:
+		return "CarrierGatewayId", *route.CarrierGatewayId
+	case route.LocalGatewayId != nil:
+		return "LocalGatewayId", *route.LocalGatewayId
+	case route.CoreNetworkArn != nil:
+		return "CoreNetworkArn", *route.CoreNetworkArn
+	}
+	return "", ""
+}
+
+// describedRouteDestination returns the destination of a described route.
+func describedRouteDestination(route ec2types.Route) string {
 	switch {
-	case matchedRoute.GatewayId != nil:
-		props["GatewayId"] = *matchedRoute.GatewayId
-	case matchedRoute.NatGatewayId != nil:
-		props["NatGatewayId"] = *matchedRoute.NatGatewayId
-	case matchedRoute.NetworkInterfaceId != nil:
-		props["NetworkInterfaceId"] = *matchedRoute.NetworkInterfaceId
-	case matchedRoute.InstanceId != nil:
-		props["InstanceId"] = *matchedRoute.InstanceId
-	case matchedRoute.TransitGatewayId != nil:
-		props["TransitGatewayId"] = *matchedRoute.TransitGatewayId
-	case matchedRoute.VpcPeeringConnectionId != nil:
-		props["VpcPeeringConnectionId"] = *matchedRoute.VpcPeeringConnectionId
+	case route.DestinationCidrBlock != nil:
+		return *route.DestinationCidrBlock
+	case route.DestinationIpv6CidrBlock != nil:
+		return *route.DestinationIpv6CidrBlock
 	}
+	return aws.ToString(route.DestinationPrefixListId)
+}
 
-	propBytes, err := json.Marshal(props)
+func (r Route) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	cfg, err := r.cfg.ToAwsConfig(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal route properties: %w", err)
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
 	}
-
-	return &resource.ReadResult{
-		ResourceType: "AWS::EC2::Route",
-		Properties:   string(propBytes),
-	}, nil
+	return r.listWithClient(ctx, ec2.NewFromConfig(cfg), request)
 }
 
-func (r Route) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
-	// Future feature
+// listWithClient lists the routes of every route table, or of the route table
+// or VPC given in AdditionalProperties. The local route of each table and
+// routes propagated from a virtual private gateway aren't created with
+// CreateRoute, so they are left out.
+func (r Route) listWithClient(ctx context.Context, client routeClientInterface, request *resource.ListRequest) (*resource.ListResult, error) {
+	properties := maps.Clone(request.AdditionalProperties)
+	tags, err := parseRouteTableTags(properties[routeTableTags])
+	if err != nil {
+		return nil, err
+	}
+	delete(properties, routeTableTags)
+	filters, err := describeFilters(request.ResourceType, properties, routeFilters)
+	if err != nil {
+		return nil, err
+	}
+	filters = append(filters, routeTableTagFilters(tags)...)
+
+	resp, err := client.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{
+		Filters:    filters,
+		MaxResults: describeMaxResults(request.PageSize),
+		NextToken:  request.PageToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list route tables: %w", err)
+	}
+
+	nativeIDs := []string{}
+	for _, table := range resp.RouteTables {
+		for _, route := range table.Routes {
+			if route.Origin != ec2types.RouteOriginCreateRoute {
+				continue
+			}
+			targetKey, target := routeTarget(route)
+			if targetKey == "" || target == "local" {
+				continue
+			}
+			nativeIDs = append(nativeIDs, fmt.Sprintf("%s|%s|%s=%s",
+				aws.ToString(table.RouteTableId), describedRouteDestination(route), targetKey, target))
+		}
+	}
+
 	return &resource.ListResult{
-		NativeIDs: []string{},
+		NativeIDs:     nativeIDs,
+		NextPageToken: resp.NextToken,
 	}, nil
 }
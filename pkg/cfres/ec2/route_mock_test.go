@@ -0,0 +1,43 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockRouteClient struct {
+	mock.Mock
+}
+
+func (m *mockRouteClient) CreateRoute(ctx context.Context, input *ec2.CreateRouteInput, optFns ...func(*ec2.Options)) (*ec2.CreateRouteOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2.CreateRouteOutput), args.Error(1)
+}
+
+func (m *mockRouteClient) DeleteRoute(ctx context.Context, input *ec2.DeleteRouteInput, optFns ...func(*ec2.Options)) (*ec2.DeleteRouteOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2.DeleteRouteOutput), args.Error(1)
+}
+
+func (m *mockRouteClient) DescribeRouteTables(ctx context.Context, input *ec2.DescribeRouteTablesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2.DescribeRouteTablesOutput), args.Error(1)
+}
+
+func (m *mockRouteClient) ReplaceRoute(ctx context.Context, input *ec2.ReplaceRouteInput, optFns ...func(*ec2.Options)) (*ec2.ReplaceRouteOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2.ReplaceRouteOutput), args.Error(1)
+}
+
+func (m *mockRouteClient) DescribeVpcPeeringConnections(ctx context.Context, input *ec2.DescribeVpcPeeringConnectionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcPeeringConnectionsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2.DescribeVpcPeeringConnectionsOutput), args.Error(1)
+}
@@ -0,0 +1,670 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ec2
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2sdk "github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+func routeTable(routes ...ec2types.Route) *ec2sdk.DescribeRouteTablesOutput {
+	return &ec2sdk.DescribeRouteTablesOutput{
+		RouteTables: []ec2types.RouteTable{{RouteTableId: aws.String("rtb-123"), Routes: routes}},
+	}
+}
+
+func TestRoute_Create_Ipv4(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("CreateRoute", ctx, mock.MatchedBy(func(input *ec2sdk.CreateRouteInput) bool {
+		return aws.ToString(input.DestinationCidrBlock) == "0.0.0.0/0" &&
+			input.DestinationIpv6CidrBlock == nil &&
+			aws.ToString(input.GatewayId) == "igw-123"
+	})).Return(&ec2sdk.CreateRouteOutput{}, nil)
+
+	result, err := Route{}.createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"RouteTableId": "rtb-123", "DestinationCidrBlock": "0.0.0.0/0", "GatewayId": "igw-123"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "rtb-123|0.0.0.0/0|GatewayId=igw-123", result.ProgressResult.NativeID)
+	client.AssertExpectations(t)
+}
+
+func TestRoute_Create_Ipv6DefaultRoute(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("CreateRoute", ctx, mock.MatchedBy(func(input *ec2sdk.CreateRouteInput) bool {
+		return aws.ToString(input.DestinationIpv6CidrBlock) == "::/0" &&
+			input.DestinationCidrBlock == nil &&
+			aws.ToString(input.EgressOnlyInternetGatewayId) == "eigw-123"
+	})).Return(&ec2sdk.CreateRouteOutput{}, nil)
+
+	result, err := Route{}.createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"RouteTableId": "rtb-123", "DestinationIpv6CidrBlock": "::/0", "EgressOnlyInternetGatewayId": "eigw-123"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "rtb-123|::/0|EgressOnlyInternetGatewayId=eigw-123", result.ProgressResult.NativeID)
+	client.AssertExpectations(t)
+}
+
+func TestRoute_Create_RejectsBothDestinations(t *testing.T) {
+	client := &mockRouteClient{}
+
+	_, err := Route{}.createWithClient(context.Background(), client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"RouteTableId": "rtb-123", "DestinationCidrBlock": "0.0.0.0/0", "DestinationIpv6CidrBlock": "::/0", "GatewayId": "igw-123"}`),
+	})
+
+	assert.ErrorContains(t, err, "mutually exclusive")
+	client.AssertNotCalled(t, "CreateRoute", mock.Anything, mock.Anything)
+}
+
+func TestRoute_Create_RequiresDestination(t *testing.T) {
+	_, err := Route{}.createWithClient(context.Background(), &mockRouteClient{}, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"RouteTableId": "rtb-123", "GatewayId": "igw-123"}`),
+	})
+
+	assert.ErrorContains(t, err, "DestinationIpv6CidrBlock")
+}
+
+// EC2 reports IPv6 blocks compressed, so a block written out in full still
+// matches.
+func TestRoute_Read_Ipv6(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.Anything).Return(routeTable(
+		ec2types.Route{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")},
+		ec2types.Route{DestinationIpv6CidrBlock: aws.String("2600:1f18:abcd::/56"), GatewayId: aws.String("local")},
+		ec2types.Route{DestinationIpv6CidrBlock: aws.String("::/0"), EgressOnlyInternetGatewayId: aws.String("eigw-123")},
+	), nil)
+
+	result, err := Route{}.readWithClient(ctx, client, &resource.ReadRequest{
+		NativeID: "rtb-123|0:0:0:0:0:0:0:0/0|EgressOnlyInternetGatewayId=eigw-123",
+	})
+
+	assert.NoError(t, err)
+	var props map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, "0:0:0:0:0:0:0:0/0", props["DestinationIpv6CidrBlock"])
+	assert.Equal(t, "eigw-123", props["EgressOnlyInternetGatewayId"])
+	assert.NotContains(t, props, "DestinationCidrBlock")
+}
+
+// An IPv4 route doesn't match an IPv6 route in the same table.
+func TestRoute_Read_Ipv4IgnoresIpv6Routes(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.Anything).Return(routeTable(
+		ec2types.Route{DestinationIpv6CidrBlock: aws.String("::/0"), GatewayId: aws.String("igw-123")},
+	), nil)
+
+	result, err := Route{}.readWithClient(ctx, client, &resource.ReadRequest{
+		NativeID: "rtb-123|0.0.0.0/0|GatewayId=igw-123",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+}
+
+func TestRoute_Delete_Ipv6(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.Anything).Return(routeTable(
+		ec2types.Route{DestinationIpv6CidrBlock: aws.String("::/0"), GatewayId: aws.String("igw-123")},
+	), nil)
+	client.On("DeleteRoute", ctx, mock.MatchedBy(func(input *ec2sdk.DeleteRouteInput) bool {
+		return aws.ToString(input.DestinationIpv6CidrBlock) == "::/0" && input.DestinationCidrBlock == nil
+	})).Return(&ec2sdk.DeleteRouteOutput{}, nil)
+
+	result, err := Route{}.deleteWithClient(ctx, client, &resource.DeleteRequest{
+		NativeID: "rtb-123|::/0|GatewayId=igw-123",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertExpectations(t)
+}
+
+func TestRoute_Create_PrefixListDestination(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("CreateRoute", ctx, mock.MatchedBy(func(input *ec2sdk.CreateRouteInput) bool {
+		return aws.ToString(input.DestinationPrefixListId) == "pl-63a5400a" &&
+			input.DestinationCidrBlock == nil && input.DestinationIpv6CidrBlock == nil &&
+			aws.ToString(input.VpcEndpointId) == "vpce-123"
+	})).Return(&ec2sdk.CreateRouteOutput{}, nil)
+
+	result, err := Route{}.createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"RouteTableId": "rtb-123", "DestinationPrefixListId": "pl-63a5400a", "VpcEndpointId": "vpce-123"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "rtb-123|pl-63a5400a|VpcEndpointId=vpce-123", result.ProgressResult.NativeID)
+	client.AssertExpectations(t)
+}
+
+func TestRoute_Create_RejectsPrefixListWithCidrBlock(t *testing.T) {
+	_, err := Route{}.createWithClient(context.Background(), &mockRouteClient{}, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"RouteTableId": "rtb-123", "DestinationCidrBlock": "10.0.0.0/8", "DestinationPrefixListId": "pl-123", "GatewayId": "igw-123"}`),
+	})
+
+	assert.ErrorContains(t, err, "DestinationCidrBlock and DestinationPrefixListId are mutually exclusive")
+}
+
+func TestRoute_Read_PrefixListDestination(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.Anything).Return(routeTable(
+		ec2types.Route{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")},
+		ec2types.Route{DestinationPrefixListId: aws.String("pl-63a5400a"), GatewayId: aws.String("vpce-123")},
+	), nil)
+
+	result, err := Route{}.readWithClient(ctx, client, &resource.ReadRequest{
+		NativeID: "rtb-123|pl-63a5400a|VpcEndpointId=vpce-123",
+	})
+
+	assert.NoError(t, err)
+	var props map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, "pl-63a5400a", props["DestinationPrefixListId"])
+	assert.Equal(t, "vpce-123", props["VpcEndpointId"])
+	assert.NotContains(t, props, "GatewayId")
+}
+
+func TestRoute_Delete_PrefixListDestination(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.Anything).Return(routeTable(
+		ec2types.Route{DestinationPrefixListId: aws.String("pl-123"), TransitGatewayId: aws.String("tgw-123")},
+	), nil)
+	client.On("DeleteRoute", ctx, mock.MatchedBy(func(input *ec2sdk.DeleteRouteInput) bool {
+		return aws.ToString(input.DestinationPrefixListId) == "pl-123" && input.DestinationCidrBlock == nil
+	})).Return(&ec2sdk.DeleteRouteOutput{}, nil)
+
+	_, err := Route{}.deleteWithClient(ctx, client, &resource.DeleteRequest{
+		NativeID: "rtb-123|pl-123|TransitGatewayId=tgw-123",
+	})
+
+	assert.NoError(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestRoute_Update_ReplacesTarget(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("ReplaceRoute", ctx, mock.MatchedBy(func(input *ec2sdk.ReplaceRouteInput) bool {
+		return aws.ToString(input.RouteTableId) == "rtb-123" &&
+			aws.ToString(input.DestinationCidrBlock) == "0.0.0.0/0" &&
+			aws.ToString(input.NatGatewayId) == "nat-123" &&
+			input.GatewayId == nil
+	})).Return(&ec2sdk.ReplaceRouteOutput{}, nil)
+
+	result, err := Route{}.updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "rtb-123|0.0.0.0/0|GatewayId=igw-123",
+		DesiredProperties: json.RawMessage(`{"RouteTableId": "rtb-123", "DestinationCidrBlock": "0.0.0.0/0", "NatGatewayId": "nat-123"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "rtb-123|0.0.0.0/0|NatGatewayId=nat-123", result.ProgressResult.NativeID)
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "DeleteRoute", mock.Anything, mock.Anything)
+}
+
+func TestRoute_Update_RecreatesForNewDestination(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.Anything).Return(routeTable(
+		ec2types.Route{DestinationCidrBlock: aws.String("10.1.0.0/16"), TransitGatewayId: aws.String("tgw-123")},
+	), nil)
+	client.On("DeleteRoute", ctx, mock.MatchedBy(func(input *ec2sdk.DeleteRouteInput) bool {
+		return aws.ToString(input.DestinationCidrBlock) == "10.1.0.0/16"
+	})).Return(&ec2sdk.DeleteRouteOutput{}, nil)
+	client.On("CreateRoute", ctx, mock.MatchedBy(func(input *ec2sdk.CreateRouteInput) bool {
+		return aws.ToString(input.DestinationCidrBlock) == "10.2.0.0/16" &&
+			aws.ToString(input.TransitGatewayId) == "tgw-123"
+	})).Return(&ec2sdk.CreateRouteOutput{}, nil)
+
+	result, err := Route{}.updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "rtb-123|10.1.0.0/16|TransitGatewayId=tgw-123",
+		DesiredProperties: json.RawMessage(`{"RouteTableId": "rtb-123", "DestinationCidrBlock": "10.2.0.0/16", "TransitGatewayId": "tgw-123"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "rtb-123|10.2.0.0/16|TransitGatewayId=tgw-123", result.ProgressResult.NativeID)
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "ReplaceRoute", mock.Anything, mock.Anything)
+}
+
+func TestRoute_Update_CreatesNewRouteBeforeDeletingOld(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	var calls []string
+	client.On("DescribeRouteTables", ctx, mock.Anything).Return(routeTable(
+		ec2types.Route{DestinationCidrBlock: aws.String("10.1.0.0/16"), TransitGatewayId: aws.String("tgw-123")},
+	), nil)
+	client.On("CreateRoute", ctx, mock.Anything).Return(&ec2sdk.CreateRouteOutput{}, nil).
+		Run(func(mock.Arguments) { calls = append(calls, "create") })
+	client.On("DeleteRoute", ctx, mock.Anything).Return(&ec2sdk.DeleteRouteOutput{}, nil).
+		Run(func(mock.Arguments) { calls = append(calls, "delete") })
+
+	_, err := Route{}.updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "rtb-123|10.1.0.0/16|TransitGatewayId=tgw-123",
+		DesiredProperties: json.RawMessage(`{"RouteTableId": "rtb-123", "DestinationCidrBlock": "10.2.0.0/16", "TransitGatewayId": "tgw-123"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"create", "delete"}, calls)
+}
+
+func TestRoute_Update_FailedCreateKeepsOldRoute(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("CreateRoute", ctx, mock.Anything).
+		Return((*ec2sdk.CreateRouteOutput)(nil), &smithy.GenericAPIError{Code: "InvalidTransitGatewayID.NotFound"})
+
+	_, err := Route{}.updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "rtb-123|10.1.0.0/16|TransitGatewayId=tgw-123",
+		DesiredProperties: json.RawMessage(`{"RouteTableId": "rtb-123", "DestinationCidrBlock": "10.2.0.0/16", "TransitGatewayId": "tgw-456"}`),
+	})
+
+	assert.Error(t, err)
+	client.AssertNotCalled(t, "DeleteRoute", mock.Anything, mock.Anything)
+}
+
+func TestRoute_List_SkipsLocalAndPropagatedRoutes(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.MatchedBy(func(input *ec2sdk.DescribeRouteTablesInput) bool {
+		return len(input.Filters) == 1 &&
+			aws.ToString(input.Filters[0].Name) == "vpc-id" &&
+			input.Filters[0].Values[0] == "vpc-123" &&
+			aws.ToString(input.NextToken) == "page-2"
+	})).Return(&ec2sdk.DescribeRouteTablesOutput{
+		RouteTables: []ec2types.RouteTable{
+			{
+				RouteTableId: aws.String("rtb-1"),
+				Routes: []ec2types.Route{
+					{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local"), Origin: ec2types.RouteOriginCreateRouteTable},
+					{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1"), Origin: ec2types.RouteOriginCreateRoute},
+					{DestinationCidrBlock: aws.String("192.168.0.0/16"), GatewayId: aws.String("vgw-1"), Origin: ec2types.RouteOriginEnableVgwRoutePropagation},
+				},
+			},
+			{
+				RouteTableId: aws.String("rtb-2"),
+				Routes: []ec2types.Route{
+					{DestinationIpv6CidrBlock: aws.String("::/0"), EgressOnlyInternetGatewayId: aws.String("eigw-1"), Origin: ec2types.RouteOriginCreateRoute},
+					{DestinationPrefixListId: aws.String("pl-1"), GatewayId: aws.String("vpce-1"), Origin: ec2types.RouteOriginCreateRoute},
+				},
+			},
+		},
+		NextToken: aws.String("page-3"),
+	}, nil)
+
+	result, err := Route{}.listWithClient(ctx, client, &resource.ListRequest{
+		ResourceType:         "AWS::EC2::Route",
+		AdditionalProperties: map[string]string{"VpcId": "vpc-123"},
+		PageToken:            aws.String("page-2"),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"rtb-1|0.0.0.0/0|NatGatewayId=nat-1",
+		"rtb-2|::/0|EgressOnlyInternetGatewayId=eigw-1",
+		"rtb-2|pl-1|VpcEndpointId=vpce-1",
+	}, result.NativeIDs)
+	assert.Equal(t, "page-3", aws.ToString(result.NextPageToken))
+}
+
+func TestRoute_List_RejectsUnknownFilter(t *testing.T) {
+	_, err := Route{}.listWithClient(context.Background(), &mockRouteClient{}, &resource.ListRequest{
+		ResourceType:         "AWS::EC2::Route",
+		AdditionalProperties: map[string]string{"SubnetId": "subnet-123"},
+	})
+
+	assert.Error(t, err)
+}
+
+func TestRoute_Status_Active(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.Anything).Return(routeTable(
+		ec2types.Route{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-123"), State: ec2types.RouteStateActive},
+	), nil)
+
+	result, err := Route{}.statusWithClient(ctx, client, &resource.StatusRequest{
+		NativeID: "rtb-123|0.0.0.0/0|NatGatewayId=nat-123",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	assert.JSONEq(t, `{"RouteTableId": "rtb-123", "DestinationCidrBlock": "0.0.0.0/0", "NatGatewayId": "nat-123"}`,
+		string(result.ProgressResult.ResourceProperties))
+}
+
+func TestRoute_Status_Blackhole(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.Anything).Return(routeTable(
+		ec2types.Route{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-123"), State: ec2types.RouteStateBlackhole},
+	), nil)
+
+	result, err := Route{}.statusWithClient(ctx, client, &resource.StatusRequest{
+		NativeID: "rtb-123|0.0.0.0/0|NatGatewayId=nat-123",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+	assert.Equal(t, resource.OperationErrorCodeDependencyFailure, result.ProgressResult.ErrorCode)
+	assert.Contains(t, result.ProgressResult.StatusMessage, "nat-123")
+}
+
+func TestRoute_Status_NotFound(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.Anything).Return(routeTable(), nil)
+
+	result, err := Route{}.statusWithClient(ctx, client, &resource.StatusRequest{
+		RequestID: "rtb-123|0.0.0.0/0|GatewayId=igw-123",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+	assert.Equal(t, resource.OperationErrorCodeNotFound, result.ProgressResult.ErrorCode)
+}
+
+func TestRoute_Status_RouteTableNotFound(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.Anything).
+		Return((*ec2sdk.DescribeRouteTablesOutput)(nil), &smithy.GenericAPIError{Code: "InvalidRouteTableID.NotFound"})
+
+	result, err := Route{}.statusWithClient(ctx, client, &resource.StatusRequest{
+		NativeID: "rtb-123|0.0.0.0/0|GatewayId=igw-123",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationErrorCodeNotFound, result.ProgressResult.ErrorCode)
+}
+
+func TestRoute_Status_DescribeFailureIsNotNotFound(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.Anything).
+		Return((*ec2sdk.DescribeRouteTablesOutput)(nil), &smithy.GenericAPIError{Code: "RequestLimitExceeded"})
+
+	_, err := Route{}.statusWithClient(ctx, client, &resource.StatusRequest{
+		NativeID: "rtb-123|0.0.0.0/0|GatewayId=igw-123",
+	})
+
+	assert.ErrorContains(t, err, "RequestLimitExceeded")
+}
+
+func TestRoute_Delete_DescribeFailureIsNotSuccess(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.Anything).
+		Return((*ec2sdk.DescribeRouteTablesOutput)(nil), &smithy.GenericAPIError{Code: "UnauthorizedOperation"})
+
+	_, err := Route{}.deleteWithClient(ctx, client, &resource.DeleteRequest{
+		NativeID: "rtb-123|0.0.0.0/0|GatewayId=igw-123",
+	})
+
+	assert.ErrorContains(t, err, "UnauthorizedOperation")
+	client.AssertNotCalled(t, "DeleteRoute", mock.Anything, mock.Anything)
+}
+
+func TestRoute_Create_AdditionalTargets(t *testing.T) {
+	tests := []struct {
+		target string
+		value  string
+		sent   func(*ec2sdk.CreateRouteInput) *string
+	}{
+		{"CarrierGatewayId", "cagw-123", func(in *ec2sdk.CreateRouteInput) *string { return in.CarrierGatewayId }},
+		{"LocalGatewayId", "lgw-123", func(in *ec2sdk.CreateRouteInput) *string { return in.LocalGatewayId }},
+		{"CoreNetworkArn", "arn:aws:networkmanager::123456789012:core-network/core-network-123", func(in *ec2sdk.CreateRouteInput) *string { return in.CoreNetworkArn }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			ctx := context.Background()
+			client := &mockRouteClient{}
+			client.On("CreateRoute", ctx, mock.MatchedBy(func(input *ec2sdk.CreateRouteInput) bool {
+				return aws.ToString(tt.sent(input)) == tt.value
+			})).Return(&ec2sdk.CreateRouteOutput{}, nil)
+
+			props, _ := json.Marshal(map[string]any{
+				"RouteTableId":         "rtb-123",
+				"DestinationCidrBlock": "0.0.0.0/0",
+				tt.target:              tt.value,
+			})
+			result, err := Route{}.createWithClient(ctx, client, &resource.CreateRequest{Properties: props})
+
+			assert.NoError(t, err)
+			assert.Equal(t, "rtb-123|0.0.0.0/0|"+tt.target+"="+tt.value, result.ProgressResult.NativeID)
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRoute_Read_CoreNetworkTarget(t *testing.T) {
+	ctx := context.Background()
+	arn := "arn:aws:networkmanager::123456789012:core-network/core-network-123"
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.Anything).Return(routeTable(
+		ec2types.Route{DestinationCidrBlock: aws.String("10.0.0.0/8"), CoreNetworkArn: aws.String(arn)},
+	), nil)
+
+	result, err := Route{}.readWithClient(ctx, client, &resource.ReadRequest{
+		NativeID: "rtb-123|10.0.0.0/8|CoreNetworkArn=" + arn,
+	})
+
+	assert.NoError(t, err)
+	var props map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, arn, props["CoreNetworkArn"])
+}
+
+func peeringConnection(status ec2types.VpcPeeringConnectionStateReasonCode) *ec2sdk.DescribeVpcPeeringConnectionsOutput {
+	return &ec2sdk.DescribeVpcPeeringConnectionsOutput{
+		VpcPeeringConnections: []ec2types.VpcPeeringConnection{{
+			VpcPeeringConnectionId: aws.String("pcx-123"),
+			Status:                 &ec2types.VpcPeeringConnectionStateReason{Code: status},
+			RequesterVpcInfo:       &ec2types.VpcPeeringConnectionVpcInfo{OwnerId: aws.String("111122223333")},
+			AccepterVpcInfo:        &ec2types.VpcPeeringConnectionVpcInfo{OwnerId: aws.String("444455556666")},
+		}},
+	}
+}
+
+func TestRoute_Create_ActivePeeringConnection(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeVpcPeeringConnections", ctx, mock.Anything).
+		Return(peeringConnection(ec2types.VpcPeeringConnectionStateReasonCodeActive), nil)
+	client.On("CreateRoute", ctx, mock.MatchedBy(func(input *ec2sdk.CreateRouteInput) bool {
+		return aws.ToString(input.VpcPeeringConnectionId) == "pcx-123"
+	})).Return(&ec2sdk.CreateRouteOutput{}, nil)
+
+	result, err := Route{}.createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"RouteTableId": "rtb-123", "DestinationCidrBlock": "10.1.0.0/16", "VpcPeeringConnectionId": "pcx-123"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "rtb-123|10.1.0.0/16|VpcPeeringConnectionId=pcx-123", result.ProgressResult.NativeID)
+	client.AssertExpectations(t)
+}
+
+func TestRoute_Create_PendingPeeringConnectionIsRecoverable(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeVpcPeeringConnections", ctx, mock.Anything).
+		Return(peeringConnection(ec2types.VpcPeeringConnectionStateReasonCodePendingAcceptance), nil)
+
+	result, err := Route{}.createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"RouteTableId": "rtb-123", "DestinationCidrBlock": "10.1.0.0/16", "VpcPeeringConnectionId": "pcx-123"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+	assert.Equal(t, resource.OperationErrorCodeResourceConflict, result.ProgressResult.ErrorCode)
+	assert.Contains(t, result.ProgressResult.StatusMessage, "once account 444455556666 accepts it")
+	client.AssertNotCalled(t, "CreateRoute", mock.Anything, mock.Anything)
+}
+
+func TestRoute_Create_PeeringConnectionReadFromPeerAccount(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeVpcPeeringConnections", ctx, mock.Anything).
+		Return(peeringConnection(ec2types.VpcPeeringConnectionStateReasonCodePendingAcceptance), nil)
+	client.On("CreateRoute", ctx, mock.Anything).Return(&ec2sdk.CreateRouteOutput{}, nil)
+	peer := &mockRouteClient{}
+	peer.On("DescribeVpcPeeringConnections", ctx, mock.Anything).
+		Return(peeringConnection(ec2types.VpcPeeringConnectionStateReasonCodeActive), nil)
+
+	var accounts []string
+	route := Route{peerClient: func(_ context.Context, accountID string) (vpcPeeringConnectionsClient, error) {
+		accounts = append(accounts, accountID)
+		if accountID == "444455556666" {
+			return peer, nil
+		}
+		return nil, nil
+	}}
+	result, err := route.createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"RouteTableId": "rtb-123", "DestinationCidrBlock": "10.1.0.0/16", "VpcPeeringConnectionId": "pcx-123"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	assert.Equal(t, []string{"444455556666"}, accounts)
+	client.AssertExpectations(t)
+	peer.AssertExpectations(t)
+}
+
+func TestRoute_Create_RejectedPeeringConnection(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeVpcPeeringConnections", ctx, mock.Anything).
+		Return(peeringConnection(ec2types.VpcPeeringConnectionStateReasonCodeRejected), nil)
+
+	result, err := Route{}.createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"RouteTableId": "rtb-123", "DestinationCidrBlock": "10.1.0.0/16", "VpcPeeringConnectionId": "pcx-123"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationErrorCodeInvalidRequest, result.ProgressResult.ErrorCode)
+	client.AssertNotCalled(t, "CreateRoute", mock.Anything, mock.Anything)
+}
+
+func TestRoute_Create_LooksUpRouteTableByTags(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.MatchedBy(func(input *ec2sdk.DescribeRouteTablesInput) bool {
+		return len(input.Filters) == 3 &&
+			aws.ToString(input.Filters[0].Name) == "tag:Name" && input.Filters[0].Values[0] == "private-a" &&
+			aws.ToString(input.Filters[1].Name) == "tag:Tier" && input.Filters[1].Values[0] == "private" &&
+			aws.ToString(input.Filters[2].Name) == "vpc-id" && input.Filters[2].Values[0] == "vpc-123"
+	})).Return(routeTable(), nil)
+	client.On("CreateRoute", ctx, mock.MatchedBy(func(input *ec2sdk.CreateRouteInput) bool {
+		return aws.ToString(input.RouteTableId) == "rtb-123"
+	})).Return(&ec2sdk.CreateRouteOutput{}, nil)
+
+	result, err := Route{}.createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"VpcId": "vpc-123", "RouteTableTags": {"Tier": "private", "Name": "private-a"}, "DestinationCidrBlock": "0.0.0.0/0", "NatGatewayId": "nat-123"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "rtb-123|0.0.0.0/0|NatGatewayId=nat-123", result.ProgressResult.NativeID)
+	client.AssertExpectations(t)
+}
+
+func TestRoute_Create_VpcIdSelectsMainRouteTable(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.MatchedBy(func(input *ec2sdk.DescribeRouteTablesInput) bool {
+		return len(input.Filters) == 2 &&
+			aws.ToString(input.Filters[0].Name) == "vpc-id" &&
+			aws.ToString(input.Filters[1].Name) == "association.main" && input.Filters[1].Values[0] == "true"
+	})).Return(routeTable(), nil)
+	client.On("CreateRoute", ctx, mock.Anything).Return(&ec2sdk.CreateRouteOutput{}, nil)
+
+	result, err := Route{}.createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"VpcId": "vpc-123", "DestinationCidrBlock": "0.0.0.0/0", "GatewayId": "igw-123"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "rtb-123|0.0.0.0/0|GatewayId=igw-123", result.ProgressResult.NativeID)
+	client.AssertExpectations(t)
+}
+
+func TestRoute_Create_RouteTableLookupMustBeUnique(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.Anything).Return(&ec2sdk.DescribeRouteTablesOutput{
+		RouteTables: []ec2types.RouteTable{{RouteTableId: aws.String("rtb-1")}, {RouteTableId: aws.String("rtb-2")}},
+	}, nil)
+
+	_, err := Route{}.createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"RouteTableTags": {"Tier": "private"}, "DestinationCidrBlock": "0.0.0.0/0", "GatewayId": "igw-123"}`),
+	})
+
+	assert.ErrorContains(t, err, "2 route tables found")
+	client.AssertNotCalled(t, "CreateRoute", mock.Anything, mock.Anything)
+}
+
+func TestRoute_Create_RequiresRouteTable(t *testing.T) {
+	_, err := Route{}.createWithClient(context.Background(), &mockRouteClient{}, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"DestinationCidrBlock": "0.0.0.0/0", "GatewayId": "igw-123"}`),
+	})
+
+	assert.ErrorContains(t, err, "one of RouteTableId, VpcId, RouteTableTags is required")
+}
+
+func TestRoute_Read_KeepsRouteTableLookup(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.Anything).Return(routeTable(
+		ec2types.Route{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-123")},
+	), nil)
+
+	result, err := Route{}.readWithClient(ctx, client, &resource.ReadRequest{
+		NativeID:        "rtb-123|0.0.0.0/0|NatGatewayId=nat-123",
+		PriorProperties: json.RawMessage(`{"VpcId": "vpc-123", "RouteTableTags": {"Tier": "private"}, "DestinationCidrBlock": "0.0.0.0/0", "NatGatewayId": "nat-123"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"RouteTableId": "rtb-123", "VpcId": "vpc-123", "RouteTableTags": {"Tier": "private"}, "DestinationCidrBlock": "0.0.0.0/0", "NatGatewayId": "nat-123"}`, result.Properties)
+}
+
+func TestRoute_List_ByRouteTableTags(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRouteClient{}
+	client.On("DescribeRouteTables", ctx, mock.MatchedBy(func(input *ec2sdk.DescribeRouteTablesInput) bool {
+		return len(input.Filters) == 2 &&
+			aws.ToString(input.Filters[0].Name) == "vpc-id" &&
+			aws.ToString(input.Filters[1].Name) == "tag:Tier" && input.Filters[1].Values[0] == "private"
+	})).Return(routeTable(
+		ec2types.Route{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1"), Origin: ec2types.RouteOriginCreateRoute},
+	), nil)
+
+	result, err := Route{}.listWithClient(ctx, client, &resource.ListRequest{
+		ResourceType:         "AWS::EC2::Route",
+		AdditionalProperties: map[string]string{"VpcId": "vpc-123", "RouteTableTags": `{"Tier": "private"}`},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"rtb-123|0.0.0.0/0|NatGatewayId=nat-1"}, result.NativeIDs)
+}
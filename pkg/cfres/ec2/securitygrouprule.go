@@ -0,0 +1,637 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ec2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2sdk "github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/utils"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+const (
+	securityGroupIngressType = "AWS::EC2::SecurityGroupIngress"
+	securityGroupEgressType  = "AWS::EC2::SecurityGroupEgress"
+)
+
+const errCodeDuplicatePermission = "InvalidPermission.Duplicate"
+
+// securityGroupRuleNotFoundCodes are the EC2 errors for a rule, or the group
+// it belongs to, that no longer exists.
+var securityGroupRuleNotFoundCodes = []string{
+	"InvalidGroup.NotFound",
+	"InvalidSecurityGroupRuleId.NotFound",
+	"InvalidPermission.NotFound",
+}
+
+// SecurityGroupRule manages a single SecurityGroupIngress or
+// SecurityGroupEgress rule with the EC2 API.
+//
+// A rule's NativeID is derived from what the rule allows rather than the
+// sgr- ID EC2 assigns it: GroupId|IpProtocol|FromPort|ToPort|Peer, where the
+// peer is the rule's CIDR block, prefix list ID or security group ID. The
+// same rule always has the same NativeID, so it can be found and compared on
+// read without state from its create. NativeIDs that are rule IDs, recorded
+// when CloudControl managed these types, are still accepted.
+//
+// Both types are discovered per security group; DescribeSecurityGroupRules
+// returns just that group's rules, where CloudControl would page through
+// every rule in the region.
+type SecurityGroupRule struct {
+	cfg    *config.Config
+	egress bool
+}
+
+type describeSecurityGroupRulesClient interface {
+	DescribeSecurityGroupRules(ctx context.Context, params *ec2sdk.DescribeSecurityGroupRulesInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.DescribeSecurityGroupRulesOutput, error)
+}
+
+type securityGroupRuleClientInterface interface {
+	describeSecurityGroupRulesClient
+	AuthorizeSecurityGroupIngress(ctx context.Context, params *ec2sdk.AuthorizeSecurityGroupIngressInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.AuthorizeSecurityGroupIngressOutput, error)
+	AuthorizeSecurityGroupEgress(ctx context.Context, params *ec2sdk.AuthorizeSecurityGroupEgressInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.AuthorizeSecurityGroupEgressOutput, error)
+	RevokeSecurityGroupIngress(ctx context.Context, params *ec2sdk.RevokeSecurityGroupIngressInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.RevokeSecurityGroupIngressOutput, error)
+	RevokeSecurityGroupEgress(ctx context.Context, params *ec2sdk.RevokeSecurityGroupEgressInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.RevokeSecurityGroupEgressOutput, error)
+	UpdateSecurityGroupRuleDescriptionsIngress(ctx context.Context, params *ec2sdk.UpdateSecurityGroupRuleDescriptionsIngressInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.UpdateSecurityGroupRuleDescriptionsIngressOutput, error)
+	UpdateSecurityGroupRuleDescriptionsEgress(ctx context.Context, params *ec2sdk.UpdateSecurityGroupRuleDescriptionsEgressInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.UpdateSecurityGroupRuleDescriptionsEgressOutput, error)
+}
+
+// securityGroupRuleFilters maps the rule properties discovery lists by to
+// their DescribeSecurityGroupRules filter names.
+var securityGroupRuleFilters = map[string]string{
+	"GroupId": "group-id",
+}
+
+var _ prov.Provisioner = &SecurityGroupRule{}
+
+func init() {
+	operations := []resource.Operation{
+		resource.OperationRead,
+		resource.OperationCreate,
+		resource.OperationUpdate,
+		resource.OperationCheckStatus,
+		resource.OperationDelete,
+		resource.OperationList,
+	}
+	registry.Register(securityGroupIngressType, operations,
+		func(cfg *config.Config) prov.Provisioner {
+			return &SecurityGroupRule{cfg: cfg}
+		})
+	registry.Register(securityGroupEgressType, operations,
+		func(cfg *config.Config) prov.Provisioner {
+			return &SecurityGroupRule{cfg: cfg, egress: true}
+		})
+}
+
+func (s *SecurityGroupRule) resourceType() string {
+	if s.egress {
+		return securityGroupEgressType
+	}
+	return securityGroupIngressType
+}
+
+// peerKeys returns the properties a rule names a prefix list or security
+// group peer by, which differ between ingress and egress rules.
+func (s *SecurityGroupRule) peerKeys() (prefixListKey, groupKey string) {
+	if s.egress {
+		return "DestinationPrefixListId", "DestinationSecurityGroupId"
+	}
+	return "SourcePrefixListId", "SourceSecurityGroupId"
+}
+
+// securityGroupRuleKey is what identifies a rule within its group.
+type securityGroupRuleKey struct {
+	groupID  string
+	protocol string
+	fromPort int64
+	toPort   int64
+	peer     string
+}
+
+func newSecurityGroupRuleKey(groupID, protocol string, fromPort, toPort int64, peer string) securityGroupRuleKey {
+	protocol = normalizeProtocol(protocol)
+	if protocol == "-1" {
+		// Rules for all protocols span all ports whatever ports were given.
+		fromPort, toPort = -1, -1
+	}
+	if prefix, err := netip.ParsePrefix(peer); err == nil {
+		peer = prefix.String()
+	}
+	return securityGroupRuleKey{groupID: groupID, protocol: protocol, fromPort: fromPort, toPort: toPort, peer: peer}
+}
+
+// String returns the key in NativeID form.
+func (k securityGroupRuleKey) String() string {
+	return fmt.Sprintf("%s|%s|%d|%d|%s", k.groupID, k.protocol, k.fromPort, k.toPort, k.peer)
+}
+
+func parseSecurityGroupRuleKey(nativeID string) (securityGroupRuleKey, error) {
+	parts := strings.Split(nativeID, "|")
+	if len(parts) != 5 {
+		return securityGroupRuleKey{}, fmt.Errorf("invalid NativeID format: expected GroupId|IpProtocol|FromPort|ToPort|Peer, got: %s", nativeID)
+	}
+	fromPort, fromErr := strconv.ParseInt(parts[2], 10, 32)
+	toPort, toErr := strconv.ParseInt(parts[3], 10, 32)
+	if fromErr != nil || toErr != nil {
+		return securityGroupRuleKey{}, fmt.Errorf("invalid NativeID ports: %s", nativeID)
+	}
+	return newSecurityGroupRuleKey(parts[0], parts[1], fromPort, toPort, parts[4]), nil
+}
+
+// ruleKey returns the key of a rule EC2 reported.
+func ruleKey(rule ec2types.SecurityGroupRule) securityGroupRuleKey {
+	var peer string
+	switch {
+	case rule.CidrIpv4 != nil:
+		peer = aws.ToString(rule.CidrIpv4)
+	case rule.CidrIpv6 != nil:
+		peer = aws.ToString(rule.CidrIpv6)
+	case rule.PrefixListId != nil:
+		peer = aws.ToString(rule.PrefixListId)
+	case rule.ReferencedGroupInfo != nil:
+		peer = aws.ToString(rule.ReferencedGroupInfo.GroupId)
+	}
+	return newSecurityGroupRuleKey(aws.ToString(rule.GroupId), aws.ToString(rule.IpProtocol),
+		int64(aws.ToInt32(rule.FromPort)), int64(aws.ToInt32(rule.ToPort)), peer)
+}
+
+// protocolNames are the protocol numbers EC2 reports by name.
+var protocolNames = map[string]string{
+	"1":  "icmp",
+	"6":  "tcp",
+	"17": "udp",
+	"58": "icmpv6",
+}
+
+func normalizeProtocol(protocol string) string {
+	protocol = strings.ToLower(protocol)
+	if name, ok := protocolNames[protocol]; ok {
+		return name
+	}
+	return protocol
+}
+
+func isSecurityGroupRuleNotFound(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		for _, code := range securityGroupRuleNotFoundCodes {
+			if apiErr.ErrorCode() == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isDuplicatePermission(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == errCodeDuplicatePermission
+}
+
+func (s *SecurityGroupRule) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	awsCfg, err := s.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+
+	return s.listWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+// listWithClient allows DI of the EC2 client for testing.
+func (s *SecurityGroupRule) listWithClient(ctx context.Context, client describeSecurityGroupRulesClient, request *resource.ListRequest) (*resource.ListResult, error) {
+	filters, err := describeFilters(request.ResourceType, request.AdditionalProperties, securityGroupRuleFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.DescribeSecurityGroupRules(ctx, &ec2sdk.DescribeSecurityGroupRulesInput{
+		Filters:    filters,
+		MaxResults: describeMaxResults(request.PageSize),
+		NextToken:  request.PageToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security group rules: %w", err)
+	}
+
+	// A page may hold only rules of the other direction and come back empty;
+	// the next page token still advances the listing.
+	nativeIDs := []string{}
+	for _, rule := range resp.SecurityGroupRules {
+		if aws.ToBool(rule.IsEgress) != s.egress {
+			continue
+		}
+		nativeIDs = append(nativeIDs, ruleKey(rule).String())
+	}
+
+	return &resource.ListResult{
+		NativeIDs:     nativeIDs,
+		NextPageToken: resp.NextToken,
+	}, nil
+}
+
+func (s *SecurityGroupRule) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	awsCfg, err := s.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return s.createWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+func (s *SecurityGroupRule) createWithClient(ctx context.Context, client securityGroupRuleClientInterface, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+	permission, err := s.ipPermission(props)
+	if err != nil {
+		return nil, err
+	}
+	groupID, _ := utils.GetStringProperty(props, "GroupId")
+
+	var rules []ec2types.SecurityGroupRule
+	if s.egress {
+		if groupID == "" {
+			return nil, fmt.Errorf("invalid GroupId: GroupId is required")
+		}
+		resp, authErr := client.AuthorizeSecurityGroupEgress(ctx, &ec2sdk.AuthorizeSecurityGroupEgressInput{
+			GroupId:       aws.String(groupID),
+			IpPermissions: []ec2types.IpPermission{permission},
+		})
+		if err = authErr; err == nil {
+			rules = resp.SecurityGroupRules
+		}
+	} else {
+		// A default VPC security group can be given by name instead.
+		input := &ec2sdk.AuthorizeSecurityGroupIngressInput{IpPermissions: []ec2types.IpPermission{permission}}
+		if groupID != "" {
+			input.GroupId = aws.String(groupID)
+		} else if groupName, _ := utils.GetStringProperty(props, "GroupName"); groupName != "" {
+			input.GroupName = aws.String(groupName)
+		} else {
+			return nil, fmt.Errorf("invalid GroupId: one of GroupId, GroupName is required")
+		}
+		resp, authErr := client.AuthorizeSecurityGroupIngress(ctx, input)
+		if err = authErr; err == nil {
+			rules = resp.SecurityGroupRules
+		}
+	}
+	if isDuplicatePermission(err) {
+		return &resource.CreateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationCreate,
+				OperationStatus: resource.OperationStatusFailure,
+				ErrorCode:       resource.OperationErrorCodeAlreadyExists,
+				StatusMessage:   err.Error(),
+			},
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize security group rule: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("authorizing security group rule returned no rule")
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        ruleKey(rules[0]).String(),
+		},
+	}, nil
+}
+
+// ipPermission builds the permission that authorizes the rule. A rule has
+// exactly one peer: an IPv4 or IPv6 CIDR block, a prefix list, or a security
+// group.
+func (s *SecurityGroupRule) ipPermission(props map[string]any) (ec2types.IpPermission, error) {
+	protocol, err := utils.GetStringProperty(props, "IpProtocol")
+	if err != nil {
+		return ec2types.IpPermission{}, fmt.Errorf("invalid IpProtocol: %w", err)
+	}
+	prefixListKey, groupKey := s.peerKeys()
+
+	var peerKey, peer string
+	for _, key := range []string{"CidrIp", "CidrIpv6", prefixListKey, groupKey} {
+		if v, _ := utils.GetStringProperty(props, key); v != "" {
+			if peerKey != "" {
+				return ec2types.IpPermission{}, fmt.Errorf("%s and %s are mutually exclusive", peerKey, key)
+			}
+			peerKey, peer = key, v
+		}
+	}
+	// An ingress rule can name its source group instead, in a default VPC.
+	sourceGroupName, _ := utils.GetStringProperty(props, "SourceSecurityGroupName")
+	if peerKey == "" && !s.egress && sourceGroupName != "" {
+		peerKey = groupKey
+	}
+	if peerKey == "" {
+		return ec2types.IpPermission{}, fmt.Errorf("one of CidrIp, CidrIpv6, %s, %s is required", prefixListKey, groupKey)
+	}
+
+	permission := ec2types.IpPermission{IpProtocol: aws.String(protocol)}
+	if normalizeProtocol(protocol) != "-1" {
+		permission.FromPort = aws.Int32(int32(utils.GetInt64Property(props, "FromPort", -1)))
+		permission.ToPort = aws.Int32(int32(utils.GetInt64Property(props, "ToPort", -1)))
+	}
+
+	var description *string
+	if v, _ := utils.GetStringProperty(props, "Description"); v != "" {
+		description = aws.String(v)
+	}
+	switch peerKey {
+	case "CidrIp":
+		permission.IpRanges = []ec2types.IpRange{{CidrIp: aws.String(peer), Description: description}}
+	case "CidrIpv6":
+		permission.Ipv6Ranges = []ec2types.Ipv6Range{{CidrIpv6: aws.String(peer), Description: description}}
+	case prefixListKey:
+		permission.PrefixListIds = []ec2types.PrefixListId{{PrefixListId: aws.String(peer), Description: description}}
+	default:
+		pair := ec2types.UserIdGroupPair{Description: description}
+		if peer != "" {
+			pair.GroupId = aws.String(peer)
+		} else {
+			pair.GroupName = aws.String(sourceGroupName)
+		}
+		if owner, _ := utils.GetStringProperty(props, "SourceSecurityGroupOwnerId"); owner != "" && !s.egress {
+			pair.UserId = aws.String(owner)
+		}
+		permission.UserIdGroupPairs = []ec2types.UserIdGroupPair{pair}
+	}
+	return permission, nil
+}
+
+func (s *SecurityGroupRule) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	awsCfg, err := s.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return s.updateWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+// updateWithClient changes the rule's description, the only property of a
+// rule that can change in place.
+func (s *SecurityGroupRule) updateWithClient(ctx context.Context, client securityGroupRuleClientInterface, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	var props map[string]any
+	if err := json.Unmarshal(request.DesiredProperties, &props); err != nil {
+		return nil, fmt.Errorf("failed to parse desired state properties: %w", err)
+	}
+	rule, err := s.findRule(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return &resource.UpdateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationUpdate,
+				OperationStatus: resource.OperationStatusFailure,
+				ErrorCode:       resource.OperationErrorCodeNotFound,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	description, _ := utils.GetStringProperty(props, "Description")
+	descriptions := []ec2types.SecurityGroupRuleDescription{{
+		SecurityGroupRuleId: rule.SecurityGroupRuleId,
+		Description:         aws.String(description),
+	}}
+	if s.egress {
+		_, err = client.UpdateSecurityGroupRuleDescriptionsEgress(ctx, &ec2sdk.UpdateSecurityGroupRuleDescriptionsEgressInput{
+			GroupId:                       rule.GroupId,
+			SecurityGroupRuleDescriptions: descriptions,
+		})
+	} else {
+		_, err = client.UpdateSecurityGroupRuleDescriptionsIngress(ctx, &ec2sdk.UpdateSecurityGroupRuleDescriptionsIngressInput{
+			GroupId:                       rule.GroupId,
+			SecurityGroupRuleDescriptions: descriptions,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update security group rule description: %w", err)
+	}
+
+	// A rule recorded by its rule ID moves to its derived NativeID here.
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        ruleKey(*rule).String(),
+		},
+	}, nil
+}
+
+func (s *SecurityGroupRule) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	awsCfg, err := s.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return s.deleteWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+func (s *SecurityGroupRule) deleteWithClient(ctx context.Context, client securityGroupRuleClientInterface, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	rule, err := s.findRule(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if rule != nil {
+		if s.egress {
+			_, err = client.RevokeSecurityGroupEgress(ctx, &ec2sdk.RevokeSecurityGroupEgressInput{
+				GroupId:              rule.GroupId,
+				SecurityGroupRuleIds: []string{aws.ToString(rule.SecurityGroupRuleId)},
+			})
+		} else {
+			_, err = client.RevokeSecurityGroupIngress(ctx, &ec2sdk.RevokeSecurityGroupIngressInput{
+				GroupId:              rule.GroupId,
+				SecurityGroupRuleIds: []string{aws.ToString(rule.SecurityGroupRuleId)},
+			})
+		}
+		if err != nil && !isSecurityGroupRuleNotFound(err) {
+			return nil, fmt.Errorf("failed to revoke security group rule: %w", err)
+		}
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (s *SecurityGroupRule) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	awsCfg, err := s.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return s.statusWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+// statusWithClient reports the state of the rule. Rules are authorized and
+// revoked synchronously, so there is nothing to wait for.
+func (s *SecurityGroupRule) statusWithClient(ctx context.Context, client securityGroupRuleClientInterface, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	nativeID := request.NativeID
+	if nativeID == "" {
+		nativeID = request.RequestID
+	}
+	rule, err := s.findRule(ctx, client, nativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := &resource.ProgressResult{
+		Operation:       resource.OperationCheckStatus,
+		OperationStatus: resource.OperationStatusSuccess,
+		RequestID:       request.RequestID,
+		NativeID:        nativeID,
+	}
+	if rule == nil {
+		pr.OperationStatus = resource.OperationStatusFailure
+		pr.ErrorCode = resource.OperationErrorCodeNotFound
+		pr.StatusMessage = fmt.Sprintf("security group rule %s not found", nativeID)
+	} else {
+		props, err := json.Marshal(s.ruleProperties(*rule, nil))
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal security group rule properties: %w", err)
+		}
+		pr.ResourceProperties = props
+	}
+	return &resource.StatusResult{ProgressResult: pr}, nil
+}
+
+func (s *SecurityGroupRule) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	awsCfg, err := s.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return s.readWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+func (s *SecurityGroupRule) readWithClient(ctx context.Context, client describeSecurityGroupRulesClient, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	rule, err := s.findRule(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return &resource.ReadResult{
+			ResourceType: s.resourceType(),
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	propBytes, err := json.Marshal(s.ruleProperties(*rule, request.PriorProperties))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal security group rule properties: %w", err)
+	}
+	return &resource.ReadResult{
+		ResourceType: s.resourceType(),
+		Properties:   string(propBytes),
+	}, nil
+}
+
+// findRule returns the rule of this direction with the given NativeID, or nil
+// when it or its security group doesn't exist. The NativeID is either a rule
+// key or a rule ID.
+func (s *SecurityGroupRule) findRule(ctx context.Context, client describeSecurityGroupRulesClient, nativeID string) (*ec2types.SecurityGroupRule, error) {
+	if strings.HasPrefix(nativeID, "sgr-") {
+		resp, err := client.DescribeSecurityGroupRules(ctx, &ec2sdk.DescribeSecurityGroupRulesInput{
+			SecurityGroupRuleIds: []string{nativeID},
+		})
+		if isSecurityGroupRuleNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe security group rule: %w", err)
+		}
+		for _, rule := range resp.SecurityGroupRules {
+			if aws.ToBool(rule.IsEgress) == s.egress {
+				return &rule, nil
+			}
+		}
+		return nil, nil
+	}
+
+	key, err := parseSecurityGroupRuleKey(nativeID)
+	if err != nil {
+		return nil, err
+	}
+	input := &ec2sdk.DescribeSecurityGroupRulesInput{
+		Filters: []ec2types.Filter{{Name: aws.String("group-id"), Values: []string{key.groupID}}},
+	}
+	for {
+		resp, err := client.DescribeSecurityGroupRules(ctx, input)
+		if isSecurityGroupRuleNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe security group rules: %w", err)
+		}
+		for _, rule := range resp.SecurityGroupRules {
+			if aws.ToBool(rule.IsEgress) == s.egress && ruleKey(rule) == key {
+				return &rule, nil
+			}
+		}
+		if resp.NextToken == nil {
+			return nil, nil
+		}
+		input.NextToken = resp.NextToken
+	}
+}
+
+// ruleProperties builds the properties of a rule found by findRule. The
+// protocol is reported as the prior properties spell it when that is the
+// same protocol, since EC2 reports protocol numbers such as 6 by name.
+func (s *SecurityGroupRule) ruleProperties(rule ec2types.SecurityGroupRule, prior json.RawMessage) map[string]any {
+	protocol := aws.ToString(rule.IpProtocol)
+	var previous struct {
+		IpProtocol string `json:"IpProtocol"`
+	}
+	if len(prior) > 0 && json.Unmarshal(prior, &previous) == nil &&
+		previous.IpProtocol != "" && normalizeProtocol(previous.IpProtocol) == normalizeProtocol(protocol) {
+		protocol = previous.IpProtocol
+	}
+
+	props := map[string]any{
+		"Id":         aws.ToString(rule.SecurityGroupRuleId),
+		"GroupId":    aws.ToString(rule.GroupId),
+		"IpProtocol": protocol,
+		"FromPort":   aws.ToInt32(rule.FromPort),
+		"ToPort":     aws.ToInt32(rule.ToPort),
+	}
+	prefixListKey, groupKey := s.peerKeys()
+	switch {
+	case rule.CidrIpv4 != nil:
+		props["CidrIp"] = aws.ToString(rule.CidrIpv4)
+	case rule.CidrIpv6 != nil:
+		props["CidrIpv6"] = aws.ToString(rule.CidrIpv6)
+	case rule.PrefixListId != nil:
+		props[prefixListKey] = aws.ToString(rule.PrefixListId)
+	case rule.ReferencedGroupInfo != nil:
+		props[groupKey] = aws.ToString(rule.ReferencedGroupInfo.GroupId)
+		// A group in another account is named with its owner.
+		if owner := aws.ToString(rule.ReferencedGroupInfo.UserId); !s.egress && owner != "" && owner != aws.ToString(rule.GroupOwnerId) {
+			props["SourceSecurityGroupOwnerId"] = owner
+		}
+	}
+	if description := aws.ToString(rule.Description); description != "" {
+		props["Description"] = description
+	}
+	return props
+}
@@ -0,0 +1,47 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ec2
+
+import (
+	"context"
+
+	ec2sdk "github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+type mockSecurityGroupRuleClient struct {
+	mockDescribeSecurityGroupRulesClient
+}
+
+func (m *mockSecurityGroupRuleClient) AuthorizeSecurityGroupIngress(ctx context.Context, input *ec2sdk.AuthorizeSecurityGroupIngressInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.AuthorizeSecurityGroupIngressOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2sdk.AuthorizeSecurityGroupIngressOutput), args.Error(1)
+}
+
+func (m *mockSecurityGroupRuleClient) AuthorizeSecurityGroupEgress(ctx context.Context, input *ec2sdk.AuthorizeSecurityGroupEgressInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.AuthorizeSecurityGroupEgressOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2sdk.AuthorizeSecurityGroupEgressOutput), args.Error(1)
+}
+
+func (m *mockSecurityGroupRuleClient) RevokeSecurityGroupIngress(ctx context.Context, input *ec2sdk.RevokeSecurityGroupIngressInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.RevokeSecurityGroupIngressOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2sdk.RevokeSecurityGroupIngressOutput), args.Error(1)
+}
+
+func (m *mockSecurityGroupRuleClient) RevokeSecurityGroupEgress(ctx context.Context, input *ec2sdk.RevokeSecurityGroupEgressInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.RevokeSecurityGroupEgressOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2sdk.RevokeSecurityGroupEgressOutput), args.Error(1)
+}
+
+func (m *mockSecurityGroupRuleClient) UpdateSecurityGroupRuleDescriptionsIngress(ctx context.Context, input *ec2sdk.UpdateSecurityGroupRuleDescriptionsIngressInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.UpdateSecurityGroupRuleDescriptionsIngressOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2sdk.UpdateSecurityGroupRuleDescriptionsIngressOutput), args.Error(1)
+}
+
+func (m *mockSecurityGroupRuleClient) UpdateSecurityGroupRuleDescriptionsEgress(ctx context.Context, input *ec2sdk.UpdateSecurityGroupRuleDescriptionsEgressInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.UpdateSecurityGroupRuleDescriptionsEgressOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2sdk.UpdateSecurityGroupRuleDescriptionsEgressOutput), args.Error(1)
+}
@@ -0,0 +1,260 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ec2
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2sdk "github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+func TestSecurityGroupRule_List_SplitsIngressAndEgress(t *testing.T) {
+	ctx := context.Background()
+	client := &mockDescribeSecurityGroupRulesClient{}
+
+	client.On("DescribeSecurityGroupRules", ctx, mock.MatchedBy(func(input *ec2sdk.DescribeSecurityGroupRulesInput) bool {
+		return len(input.Filters) == 1 &&
+			aws.ToString(input.Filters[0].Name) == "group-id" &&
+			assert.ObjectsAreEqual([]string{"sg-123"}, input.Filters[0].Values)
+	})).Return(&ec2sdk.DescribeSecurityGroupRulesOutput{
+		SecurityGroupRules: []ec2types.SecurityGroupRule{
+			tcpRule("sgr-in-1", false, 443, "10.0.0.0/8"),
+			allTrafficRule("sgr-out-1", true, "0.0.0.0/0"),
+			tcpRule("sgr-in-2", false, 22, "192.168.0.0/16"),
+		},
+	}, nil)
+
+	request := func(resourceType string) *resource.ListRequest {
+		return &resource.ListRequest{
+			ResourceType:         resourceType,
+			AdditionalProperties: map[string]string{"GroupId": "sg-123"},
+		}
+	}
+
+	ingress, err := (&SecurityGroupRule{}).listWithClient(ctx, client, request(securityGroupIngressType))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sg-123|tcp|443|443|10.0.0.0/8", "sg-123|tcp|22|22|192.168.0.0/16"}, ingress.NativeIDs)
+	assert.Nil(t, ingress.NextPageToken)
+
+	egress, err := (&SecurityGroupRule{egress: true}).listWithClient(ctx, client, request(securityGroupEgressType))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sg-123|-1|-1|-1|0.0.0.0/0"}, egress.NativeIDs)
+}
+
+func tcpRule(id string, egress bool, port int32, cidr string) ec2types.SecurityGroupRule {
+	return ec2types.SecurityGroupRule{
+		SecurityGroupRuleId: aws.String(id),
+		GroupId:             aws.String("sg-123"),
+		IsEgress:            aws.Bool(egress),
+		IpProtocol:          aws.String("tcp"),
+		FromPort:            aws.Int32(port),
+		ToPort:              aws.Int32(port),
+		CidrIpv4:            aws.String(cidr),
+	}
+}
+
+func allTrafficRule(id string, egress bool, cidr string) ec2types.SecurityGroupRule {
+	return ec2types.SecurityGroupRule{
+		SecurityGroupRuleId: aws.String(id),
+		GroupId:             aws.String("sg-123"),
+		IsEgress:            aws.Bool(egress),
+		IpProtocol:          aws.String("-1"),
+		FromPort:            aws.Int32(-1),
+		ToPort:              aws.Int32(-1),
+		CidrIpv4:            aws.String(cidr),
+	}
+}
+
+func groupRules(rules ...ec2types.SecurityGroupRule) *ec2sdk.DescribeSecurityGroupRulesOutput {
+	return &ec2sdk.DescribeSecurityGroupRulesOutput{SecurityGroupRules: rules}
+}
+
+func TestSecurityGroupRule_Create_Ingress(t *testing.T) {
+	ctx := context.Background()
+	client := &mockSecurityGroupRuleClient{}
+	client.On("AuthorizeSecurityGroupIngress", ctx, mock.MatchedBy(func(input *ec2sdk.AuthorizeSecurityGroupIngressInput) bool {
+		p := input.IpPermissions[0]
+		return aws.ToString(input.GroupId) == "sg-123" &&
+			aws.ToString(p.IpProtocol) == "6" &&
+			aws.ToInt32(p.FromPort) == 443 && aws.ToInt32(p.ToPort) == 443 &&
+			aws.ToString(p.IpRanges[0].CidrIp) == "10.0.0.0/8" &&
+			aws.ToString(p.IpRanges[0].Description) == "https"
+	})).Return(&ec2sdk.AuthorizeSecurityGroupIngressOutput{
+		SecurityGroupRules: []ec2types.SecurityGroupRule{tcpRule("sgr-1", false, 443, "10.0.0.0/8")},
+	}, nil)
+
+	result, err := (&SecurityGroupRule{}).createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"GroupId": "sg-123", "IpProtocol": "6", "FromPort": 443, "ToPort": 443, "CidrIp": "10.0.0.0/8", "Description": "https"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "sg-123|tcp|443|443|10.0.0.0/8", result.ProgressResult.NativeID)
+	client.AssertExpectations(t)
+}
+
+func TestSecurityGroupRule_Create_EgressToSecurityGroup(t *testing.T) {
+	ctx := context.Background()
+	client := &mockSecurityGroupRuleClient{}
+	client.On("AuthorizeSecurityGroupEgress", ctx, mock.MatchedBy(func(input *ec2sdk.AuthorizeSecurityGroupEgressInput) bool {
+		p := input.IpPermissions[0]
+		return p.FromPort == nil && p.ToPort == nil &&
+			aws.ToString(p.UserIdGroupPairs[0].GroupId) == "sg-456"
+	})).Return(&ec2sdk.AuthorizeSecurityGroupEgressOutput{
+		SecurityGroupRules: []ec2types.SecurityGroupRule{{
+			SecurityGroupRuleId: aws.String("sgr-2"),
+			GroupId:             aws.String("sg-123"),
+			IsEgress:            aws.Bool(true),
+			IpProtocol:          aws.String("-1"),
+			FromPort:            aws.Int32(-1),
+			ToPort:              aws.Int32(-1),
+			ReferencedGroupInfo: &ec2types.ReferencedSecurityGroup{GroupId: aws.String("sg-456")},
+		}},
+	}, nil)
+
+	result, err := (&SecurityGroupRule{egress: true}).createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"GroupId": "sg-123", "IpProtocol": "-1", "DestinationSecurityGroupId": "sg-456"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "sg-123|-1|-1|-1|sg-456", result.ProgressResult.NativeID)
+	client.AssertExpectations(t)
+}
+
+func TestSecurityGroupRule_Create_RejectsTwoPeers(t *testing.T) {
+	_, err := (&SecurityGroupRule{}).createWithClient(context.Background(), &mockSecurityGroupRuleClient{}, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"GroupId": "sg-123", "IpProtocol": "tcp", "FromPort": 22, "ToPort": 22, "CidrIp": "10.0.0.0/8", "SourceSecurityGroupId": "sg-456"}`),
+	})
+
+	assert.ErrorContains(t, err, "CidrIp and SourceSecurityGroupId are mutually exclusive")
+}
+
+func TestSecurityGroupRule_Create_DuplicateIsAlreadyExists(t *testing.T) {
+	ctx := context.Background()
+	client := &mockSecurityGroupRuleClient{}
+	client.On("AuthorizeSecurityGroupIngress", ctx, mock.Anything).
+		Return((*ec2sdk.AuthorizeSecurityGroupIngressOutput)(nil), &smithy.GenericAPIError{Code: "InvalidPermission.Duplicate"})
+
+	result, err := (&SecurityGroupRule{}).createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"GroupId": "sg-123", "IpProtocol": "tcp", "FromPort": 22, "ToPort": 22, "CidrIp": "10.0.0.0/8"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+	assert.Equal(t, resource.OperationErrorCodeAlreadyExists, result.ProgressResult.ErrorCode)
+}
+
+func TestSecurityGroupRule_Read_ByKey(t *testing.T) {
+	ctx := context.Background()
+	client := &mockSecurityGroupRuleClient{}
+	rule := tcpRule("sgr-1", false, 443, "10.0.0.0/8")
+	rule.Description = aws.String("https")
+	client.On("DescribeSecurityGroupRules", ctx, mock.Anything).Return(groupRules(
+		tcpRule("sgr-0", true, 443, "10.0.0.0/8"),
+		tcpRule("sgr-2", false, 22, "10.0.0.0/8"),
+		rule,
+	), nil)
+
+	result, err := (&SecurityGroupRule{}).readWithClient(ctx, client, &resource.ReadRequest{
+		NativeID:        "sg-123|tcp|443|443|10.0.0.0/8",
+		PriorProperties: json.RawMessage(`{"IpProtocol": "6"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"Id": "sgr-1", "GroupId": "sg-123", "IpProtocol": "6", "FromPort": 443, "ToPort": 443, "CidrIp": "10.0.0.0/8", "Description": "https"}`, result.Properties)
+}
+
+func TestSecurityGroupRule_Read_ByRuleID(t *testing.T) {
+	ctx := context.Background()
+	client := &mockSecurityGroupRuleClient{}
+	client.On("DescribeSecurityGroupRules", ctx, mock.MatchedBy(func(input *ec2sdk.DescribeSecurityGroupRulesInput) bool {
+		return assert.ObjectsAreEqual([]string{"sgr-1"}, input.SecurityGroupRuleIds)
+	})).Return(groupRules(allTrafficRule("sgr-1", true, "0.0.0.0/0")), nil)
+
+	result, err := (&SecurityGroupRule{egress: true}).readWithClient(ctx, client, &resource.ReadRequest{NativeID: "sgr-1"})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"Id": "sgr-1", "GroupId": "sg-123", "IpProtocol": "-1", "FromPort": -1, "ToPort": -1, "CidrIp": "0.0.0.0/0"}`, result.Properties)
+}
+
+func TestSecurityGroupRule_Read_NotFound(t *testing.T) {
+	ctx := context.Background()
+	client := &mockSecurityGroupRuleClient{}
+	client.On("DescribeSecurityGroupRules", ctx, mock.Anything).Return(groupRules(tcpRule("sgr-1", false, 22, "10.0.0.0/8")), nil)
+
+	result, err := (&SecurityGroupRule{}).readWithClient(ctx, client, &resource.ReadRequest{NativeID: "sg-123|tcp|443|443|10.0.0.0/8"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+}
+
+func TestSecurityGroupRule_Update_Description(t *testing.T) {
+	ctx := context.Background()
+	client := &mockSecurityGroupRuleClient{}
+	client.On("DescribeSecurityGroupRules", ctx, mock.Anything).Return(groupRules(tcpRule("sgr-1", false, 443, "10.0.0.0/8")), nil)
+	client.On("UpdateSecurityGroupRuleDescriptionsIngress", ctx, mock.MatchedBy(func(input *ec2sdk.UpdateSecurityGroupRuleDescriptionsIngressInput) bool {
+		d := input.SecurityGroupRuleDescriptions[0]
+		return aws.ToString(input.GroupId) == "sg-123" &&
+			aws.ToString(d.SecurityGroupRuleId) == "sgr-1" &&
+			aws.ToString(d.Description) == "public https"
+	})).Return(&ec2sdk.UpdateSecurityGroupRuleDescriptionsIngressOutput{}, nil)
+
+	result, err := (&SecurityGroupRule{}).updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "sgr-1",
+		DesiredProperties: json.RawMessage(`{"GroupId": "sg-123", "IpProtocol": "tcp", "FromPort": 443, "ToPort": 443, "CidrIp": "10.0.0.0/8", "Description": "public https"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "sg-123|tcp|443|443|10.0.0.0/8", result.ProgressResult.NativeID)
+	client.AssertExpectations(t)
+}
+
+func TestSecurityGroupRule_Delete_RevokesByRuleID(t *testing.T) {
+	ctx := context.Background()
+	client := &mockSecurityGroupRuleClient{}
+	client.On("DescribeSecurityGroupRules", ctx, mock.Anything).Return(groupRules(allTrafficRule("sgr-1", true, "0.0.0.0/0")), nil)
+	client.On("RevokeSecurityGroupEgress", ctx, mock.MatchedBy(func(input *ec2sdk.RevokeSecurityGroupEgressInput) bool {
+		return aws.ToString(input.GroupId) == "sg-123" &&
+			assert.ObjectsAreEqual([]string{"sgr-1"}, input.SecurityGroupRuleIds)
+	})).Return(&ec2sdk.RevokeSecurityGroupEgressOutput{}, nil)
+
+	result, err := (&SecurityGroupRule{egress: true}).deleteWithClient(ctx, client, &resource.DeleteRequest{NativeID: "sg-123|-1|-1|-1|0.0.0.0/0"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertExpectations(t)
+}
+
+func TestSecurityGroupRule_Delete_GroupGone(t *testing.T) {
+	ctx := context.Background()
+	client := &mockSecurityGroupRuleClient{}
+	client.On("DescribeSecurityGroupRules", ctx, mock.Anything).
+		Return((*ec2sdk.DescribeSecurityGroupRulesOutput)(nil), &smithy.GenericAPIError{Code: "InvalidGroup.NotFound"})
+
+	result, err := (&SecurityGroupRule{}).deleteWithClient(ctx, client, &resource.DeleteRequest{NativeID: "sg-123|tcp|22|22|10.0.0.0/8"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertNotCalled(t, "RevokeSecurityGroupIngress", mock.Anything, mock.Anything)
+}
+
+func TestSecurityGroupRuleKey_NormalizesIpv6AndProtocol(t *testing.T) {
+	key, err := parseSecurityGroupRuleKey("sg-123|17|53|53|2001:DB8:0::/32")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "sg-123|udp|53|53|2001:db8::/32", key.String())
+}
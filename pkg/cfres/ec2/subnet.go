@@ -0,0 +1,104 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ec2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2sdk "github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+// Subnet provides a custom List that pushes the parent VPC filter to EC2.
+// Subnets are discovered per VPC, and CloudControl's ListResources ignores the
+// VpcId in the resource model, returning every subnet in the region for each
+// VPC only for the plugin to discard most of them. DescribeSubnets filters
+// server-side.
+type Subnet struct {
+	cfg *config.Config
+}
+
+type describeSubnetsClient interface {
+	DescribeSubnets(ctx context.Context, params *ec2sdk.DescribeSubnetsInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.DescribeSubnetsOutput, error)
+}
+
+// subnetFilters maps the Subnet properties discovery lists by to their
+// DescribeSubnets filter names.
+var subnetFilters = map[string]string{
+	"VpcId":            "vpc-id",
+	"AvailabilityZone": "availability-zone",
+}
+
+var _ prov.Provisioner = &Subnet{}
+
+func init() {
+	registry.Register("AWS::EC2::Subnet",
+		[]resource.Operation{resource.OperationList},
+		func(cfg *config.Config) prov.Provisioner {
+			return &Subnet{cfg: cfg}
+		})
+}
+
+func (s *Subnet) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	awsCfg, err := s.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+
+	return s.listWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+// listWithClient allows DI of the EC2 client for testing.
+func (s *Subnet) listWithClient(ctx context.Context, client describeSubnetsClient, request *resource.ListRequest) (*resource.ListResult, error) {
+	filters, err := describeFilters(request.ResourceType, request.AdditionalProperties, subnetFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.DescribeSubnets(ctx, &ec2sdk.DescribeSubnetsInput{
+		Filters:    filters,
+		MaxResults: describeMaxResults(request.PageSize),
+		NextToken:  request.PageToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subnets: %w", err)
+	}
+
+	nativeIDs := make([]string, 0, len(resp.Subnets))
+	for _, subnet := range resp.Subnets {
+		nativeIDs = append(nativeIDs, aws.ToString(subnet.SubnetId))
+	}
+
+	return &resource.ListResult{
+		NativeIDs:     nativeIDs,
+		NextPageToken: resp.NextToken,
+	}, nil
+}
+
+func (s *Subnet) Create(_ context.Context, _ *resource.CreateRequest) (*resource.CreateResult, error) {
+	return nil, fmt.Errorf("create not implemented - cloudcontrol handles this operation")
+}
+
+func (s *Subnet) Update(_ context.Context, _ *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	return nil, fmt.Errorf("update not implemented - cloudcontrol handles this operation")
+}
+
+func (s *Subnet) Delete(_ context.Context, _ *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	return nil, fmt.Errorf("delete not implemented - cloudcontrol handles this operation")
+}
+
+func (s *Subnet) Status(_ context.Context, _ *resource.StatusRequest) (*resource.StatusResult, error) {
+	return nil, fmt.Errorf("status not implemented - cloudcontrol handles this operation")
+}
+
+func (s *Subnet) Read(_ context.Context, _ *resource.ReadRequest) (*resource.ReadResult, error) {
+	return nil, fmt.Errorf("read not implemented - cloudcontrol handles this operation")
+}
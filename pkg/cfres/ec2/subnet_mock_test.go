@@ -0,0 +1,32 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ec2
+
+import (
+	"context"
+
+	ec2sdk "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockDescribeSubnetsClient struct {
+	mock.Mock
+}
+
+func (m *mockDescribeSubnetsClient) DescribeSubnets(ctx context.Context, input *ec2sdk.DescribeSubnetsInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.DescribeSubnetsOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2sdk.DescribeSubnetsOutput), args.Error(1)
+}
+
+type mockDescribeSecurityGroupRulesClient struct {
+	mock.Mock
+}
+
+func (m *mockDescribeSecurityGroupRulesClient) DescribeSecurityGroupRules(ctx context.Context, input *ec2sdk.DescribeSecurityGroupRulesInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.DescribeSecurityGroupRulesOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2sdk.DescribeSecurityGroupRulesOutput), args.Error(1)
+}
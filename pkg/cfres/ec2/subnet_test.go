@@ -0,0 +1,70 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2sdk "github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+func TestSubnet_List_FiltersByVpcServerSide(t *testing.T) {
+	ctx := context.Background()
+	client := &mockDescribeSubnetsClient{}
+
+	client.On("DescribeSubnets", ctx, mock.MatchedBy(func(input *ec2sdk.DescribeSubnetsInput) bool {
+		return len(input.Filters) == 1 &&
+			aws.ToString(input.Filters[0].Name) == "vpc-id" &&
+			assert.ObjectsAreEqual([]string{"vpc-123"}, input.Filters[0].Values) &&
+			aws.ToInt32(input.MaxResults) == 100 &&
+			aws.ToString(input.NextToken) == "page-1"
+	})).Return(&ec2sdk.DescribeSubnetsOutput{
+		Subnets: []ec2types.Subnet{
+			{SubnetId: aws.String("subnet-aaa")},
+			{SubnetId: aws.String("subnet-bbb")},
+		},
+		NextToken: aws.String("page-2"),
+	}, nil)
+
+	result, err := (&Subnet{}).listWithClient(ctx, client, &resource.ListRequest{
+		ResourceType:         "AWS::EC2::Subnet",
+		PageSize:             100,
+		PageToken:            aws.String("page-1"),
+		AdditionalProperties: map[string]string{"VpcId": "vpc-123"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"subnet-aaa", "subnet-bbb"}, result.NativeIDs)
+	assert.Equal(t, "page-2", aws.ToString(result.NextPageToken))
+	client.AssertExpectations(t)
+}
+
+func TestSubnet_List_RejectsUnsupportedFilter(t *testing.T) {
+	client := &mockDescribeSubnetsClient{}
+
+	_, err := (&Subnet{}).listWithClient(context.Background(), client, &resource.ListRequest{
+		ResourceType:         "AWS::EC2::Subnet",
+		AdditionalProperties: map[string]string{"Ipv6Native": "true"},
+	})
+
+	assert.ErrorContains(t, err, "Ipv6Native")
+	client.AssertNotCalled(t, "DescribeSubnets", mock.Anything, mock.Anything)
+}
+
+func TestDescribeMaxResults(t *testing.T) {
+	assert.Nil(t, describeMaxResults(0))
+	assert.Equal(t, int32(5), *describeMaxResults(1))
+	assert.Equal(t, int32(100), *describeMaxResults(100))
+	assert.Equal(t, int32(1000), *describeMaxResults(5000))
+}
@@ -0,0 +1,357 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package ec2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2sdk "github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/utils"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+// AWS::EC2::VolumeAttachment goes through a custom provisioner driving
+// AttachVolume and DetachVolume directly. Through Cloud Control an attachment
+// often reports success while the volume is still "attaching", so whatever
+// runs next on the instance can't see the device yet.
+//
+// Create and Delete return InProgress and Status waits for the attachment to
+// reach "attached", or to go away. The NativeID is volumeId|instanceId, the
+// same form Cloud Control reports, so attachments created before keep their
+// identity.
+const volumeAttachmentType = "AWS::EC2::VolumeAttachment"
+
+type volumeAttachmentClientInterface interface {
+	AttachVolume(ctx context.Context, params *ec2sdk.AttachVolumeInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.AttachVolumeOutput, error)
+	DetachVolume(ctx context.Context, params *ec2sdk.DetachVolumeInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.DetachVolumeOutput, error)
+	DescribeVolumes(ctx context.Context, params *ec2sdk.DescribeVolumesInput, optFns ...func(*ec2sdk.Options)) (*ec2sdk.DescribeVolumesOutput, error)
+}
+
+type VolumeAttachment struct {
+	cfg *config.Config
+}
+
+var _ prov.Provisioner = &VolumeAttachment{}
+
+func init() {
+	registry.Register(volumeAttachmentType,
+		[]resource.Operation{
+			resource.OperationRead,
+			resource.OperationCreate,
+			resource.OperationCheckStatus,
+			resource.OperationDelete,
+		},
+		func(cfg *config.Config) prov.Provisioner {
+			return &VolumeAttachment{cfg: cfg}
+		})
+}
+
+// parseVolumeAttachmentNativeID parses the composite NativeID
+// volumeId|instanceId.
+func parseVolumeAttachmentNativeID(nativeID string) (volumeID, instanceID string, err error) {
+	parts := strings.Split(nativeID, "|")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid NativeID format: expected volumeId|instanceId, got: %q", nativeID)
+	}
+	return parts[0], parts[1], nil
+}
+
+// isVolumeAttachmentNotFound reports whether err says the volume, or its
+// attachment to the instance, does not exist.
+func isVolumeAttachmentNotFound(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "InvalidVolume.NotFound", "InvalidAttachment.NotFound":
+			return true
+		}
+	}
+	return false
+}
+
+// describeVolumeAttachment returns the attachment of volumeID to instanceID,
+// or nil when there is none.
+func describeVolumeAttachment(ctx context.Context, client volumeAttachmentClientInterface, volumeID, instanceID string) (*ec2types.VolumeAttachment, error) {
+	resp, err := client.DescribeVolumes(ctx, &ec2sdk.DescribeVolumesInput{
+		VolumeIds: []string{volumeID},
+	})
+	if err != nil {
+		if isVolumeAttachmentNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("describing volume %s: %w", volumeID, err)
+	}
+	for _, volume := range resp.Volumes {
+		for i := range volume.Attachments {
+			if aws.ToString(volume.Attachments[i].InstanceId) == instanceID {
+				return &volume.Attachments[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func volumeAttachmentProps(attachment *ec2types.VolumeAttachment) map[string]any {
+	props := map[string]any{
+		"VolumeId":   aws.ToString(attachment.VolumeId),
+		"InstanceId": aws.ToString(attachment.InstanceId),
+	}
+	if attachment.Device != nil {
+		props["Device"] = *attachment.Device
+	}
+	return props
+}
+
+// isVolumeAttached reports whether an attachment has reached "attached".
+// "busy" is an attached volume the instance is still using.
+func isVolumeAttached(state ec2types.VolumeAttachmentState) bool {
+	return state == ec2types.VolumeAttachmentStateAttached || state == ec2types.VolumeAttachmentStateBusy
+}
+
+func (v *VolumeAttachment) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	awsCfg, err := v.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return v.createWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+// createWithClient attaches the volume and returns InProgress for Status to
+// wait on. A volume already attached to the instance, as after a retried
+// Create, is adopted.
+func (v *VolumeAttachment) createWithClient(ctx context.Context, client volumeAttachmentClientInterface, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("parsing properties: %w", err)
+	}
+	volumeID, err := utils.GetStringProperty(props, "VolumeId")
+	if err != nil {
+		return nil, fmt.Errorf("invalid VolumeId: %w", err)
+	}
+	instanceID, err := utils.GetStringProperty(props, "InstanceId")
+	if err != nil {
+		return nil, fmt.Errorf("invalid InstanceId: %w", err)
+	}
+	nativeID := fmt.Sprintf("%s|%s", volumeID, instanceID)
+
+	existing, err := describeVolumeAttachment(ctx, client, volumeID, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil || existing.State == ec2types.VolumeAttachmentStateDetached {
+		input := &ec2sdk.AttachVolumeInput{
+			VolumeId:   aws.String(volumeID),
+			InstanceId: aws.String(instanceID),
+		}
+		if device, _ := props["Device"].(string); device != "" {
+			input.Device = aws.String(device)
+		}
+		if _, err := client.AttachVolume(ctx, input); err != nil {
+			return nil, fmt.Errorf("attaching volume %s to instance %s: %w", volumeID, instanceID, err)
+		}
+	} else if existing.State == ec2types.VolumeAttachmentStateDetaching {
+		return &resource.CreateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationCreate,
+				OperationStatus: resource.OperationStatusFailure,
+				NativeID:        nativeID,
+				ErrorCode:       resource.OperationErrorCodeResourceConflict,
+				StatusMessage:   fmt.Sprintf("volume %s is still detaching from instance %s", volumeID, instanceID),
+			},
+		}, nil
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        nativeID,
+			RequestID:       string(resource.OperationCreate) + "|" + nativeID,
+		},
+	}, nil
+}
+
+func (v *VolumeAttachment) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	awsCfg, err := v.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return v.statusWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+// statusWithClient waits for the attachment a Create or Delete started to
+// settle. The RequestID is the operation and the NativeID, joined by "|".
+func (v *VolumeAttachment) statusWithClient(ctx context.Context, client volumeAttachmentClientInterface, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	op, nativeID := resource.OperationCheckStatus, request.NativeID
+	if prefix, rest, ok := strings.Cut(request.RequestID, "|"); ok && strings.Contains(rest, "|") {
+		op, nativeID = resource.Operation(prefix), rest
+	}
+	volumeID, instanceID, err := parseVolumeAttachmentNativeID(nativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := describeVolumeAttachment(ctx, client, volumeID, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	var state ec2types.VolumeAttachmentState
+	if attachment != nil {
+		state = attachment.State
+	}
+
+	pr := &resource.ProgressResult{
+		Operation:       op,
+		OperationStatus: resource.OperationStatusInProgress,
+		RequestID:       request.RequestID,
+		NativeID:        nativeID,
+		StatusMessage:   fmt.Sprintf("volume %s is %s on instance %s", volumeID, state, instanceID),
+	}
+	if op == resource.OperationDelete {
+		if attachment == nil || state == ec2types.VolumeAttachmentStateDetached {
+			pr.OperationStatus = resource.OperationStatusSuccess
+			pr.StatusMessage = ""
+		}
+		return &resource.StatusResult{ProgressResult: pr}, nil
+	}
+
+	switch {
+	case state == ec2types.VolumeAttachmentStateAttaching:
+		return &resource.StatusResult{ProgressResult: pr}, nil
+	case attachment == nil:
+		pr.OperationStatus = resource.OperationStatusFailure
+		pr.ErrorCode = resource.OperationErrorCodeNotFound
+		pr.StatusMessage = fmt.Sprintf("volume %s is not attached to instance %s", volumeID, instanceID)
+		return &resource.StatusResult{ProgressResult: pr}, nil
+	case !isVolumeAttached(state):
+		pr.OperationStatus = resource.OperationStatusFailure
+		pr.ErrorCode = resource.OperationErrorCodeGeneralServiceException
+		return &resource.StatusResult{ProgressResult: pr}, nil
+	}
+
+	propsJSON, err := json.Marshal(volumeAttachmentProps(attachment))
+	if err != nil {
+		return nil, fmt.Errorf("marshaling properties: %w", err)
+	}
+	pr.OperationStatus = resource.OperationStatusSuccess
+	pr.StatusMessage = ""
+	pr.ResourceProperties = propsJSON
+	return &resource.StatusResult{ProgressResult: pr}, nil
+}
+
+func (v *VolumeAttachment) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	awsCfg, err := v.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return v.readWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+// readWithClient reports an attachment that is detached, or on its way
+// there, as NotFound.
+func (v *VolumeAttachment) readWithClient(ctx context.Context, client volumeAttachmentClientInterface, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	volumeID, instanceID, err := parseVolumeAttachmentNativeID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	attachment, err := describeVolumeAttachment(ctx, client, volumeID, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil ||
+		attachment.State == ec2types.VolumeAttachmentStateDetaching ||
+		attachment.State == ec2types.VolumeAttachmentStateDetached {
+		return &resource.ReadResult{
+			ResourceType: volumeAttachmentType,
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	propsJSON, err := json.Marshal(volumeAttachmentProps(attachment))
+	if err != nil {
+		return nil, fmt.Errorf("marshaling properties: %w", err)
+	}
+	return &resource.ReadResult{
+		ResourceType: volumeAttachmentType,
+		Properties:   string(propsJSON),
+	}, nil
+}
+
+func (v *VolumeAttachment) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	awsCfg, err := v.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return v.deleteWithClient(ctx, ec2sdk.NewFromConfig(awsCfg), request)
+}
+
+// deleteWithClient detaches the volume and returns InProgress for Status to
+// wait on. An attachment that is already gone is a successful delete.
+func (v *VolumeAttachment) deleteWithClient(ctx context.Context, client volumeAttachmentClientInterface, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	volumeID, instanceID, err := parseVolumeAttachmentNativeID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	success := &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}
+
+	attachment, err := describeVolumeAttachment(ctx, client, volumeID, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil || attachment.State == ec2types.VolumeAttachmentStateDetached {
+		return success, nil
+	}
+	if attachment.State != ec2types.VolumeAttachmentStateDetaching {
+		if _, err := client.DetachVolume(ctx, &ec2sdk.DetachVolumeInput{
+			VolumeId:   aws.String(volumeID),
+			InstanceId: aws.String(instanceID),
+			Device:     attachment.Device,
+		}); err != nil {
+			if isVolumeAttachmentNotFound(err) {
+				return success, nil
+			}
+			return nil, fmt.Errorf("detaching volume %s from instance %s: %w", volumeID, instanceID, err)
+		}
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			NativeID:        request.NativeID,
+			RequestID:       string(resource.OperationDelete) + "|" + request.NativeID,
+		},
+	}, nil
+}
+
+// Update is never invoked: every schema field is createOnly, so any change is
+// a replace (Delete then Create).
+func (v *VolumeAttachment) Update(_ context.Context, _ *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	return nil, fmt.Errorf("update is not supported for %s; a change is a replace (delete then create)", volumeAttachmentType)
+}
+
+// List is not registered: the resource is not discoverable.
+func (v *VolumeAttachment) List(_ context.Context, _ *resource.ListRequest) (*resource.ListResult, error) {
+	return &resource.ListResult{
+		NativeIDs: []string{},
+	}, nil
+}
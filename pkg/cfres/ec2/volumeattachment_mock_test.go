@@ -0,0 +1,33 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockVolumeAttachmentClient struct {
+	mock.Mock
+}
+
+func (m *mockVolumeAttachmentClient) AttachVolume(ctx context.Context, input *ec2.AttachVolumeInput, optFns ...func(*ec2.Options)) (*ec2.AttachVolumeOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2.AttachVolumeOutput), args.Error(1)
+}
+
+func (m *mockVolumeAttachmentClient) DetachVolume(ctx context.Context, input *ec2.DetachVolumeInput, optFns ...func(*ec2.Options)) (*ec2.DetachVolumeOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2.DetachVolumeOutput), args.Error(1)
+}
+
+func (m *mockVolumeAttachmentClient) DescribeVolumes(ctx context.Context, input *ec2.DescribeVolumesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*ec2.DescribeVolumesOutput), args.Error(1)
+}
@@ -0,0 +1,220 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ec2
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	ec2sdk "github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+func volumeWithAttachment(state ec2types.VolumeAttachmentState) *ec2sdk.DescribeVolumesOutput {
+	return &ec2sdk.DescribeVolumesOutput{
+		Volumes: []ec2types.Volume{{
+			VolumeId: strPtr("vol-123"),
+			Attachments: []ec2types.VolumeAttachment{{
+				VolumeId:   strPtr("vol-123"),
+				InstanceId: strPtr("i-123"),
+				Device:     strPtr("/dev/sdf"),
+				State:      state,
+			}},
+		}},
+	}
+}
+
+func volumeAttachmentCreateProps() json.RawMessage {
+	b, _ := json.Marshal(map[string]any{
+		"VolumeId":   "vol-123",
+		"InstanceId": "i-123",
+		"Device":     "/dev/sdf",
+	})
+	return b
+}
+
+func TestVolumeAttachment_Create_Attaches(t *testing.T) {
+	ctx := context.Background()
+	client := &mockVolumeAttachmentClient{}
+	client.On("DescribeVolumes", ctx, mock.Anything).Return(&ec2sdk.DescribeVolumesOutput{
+		Volumes: []ec2types.Volume{{VolumeId: strPtr("vol-123")}},
+	}, nil)
+	client.On("AttachVolume", ctx, mock.MatchedBy(func(input *ec2sdk.AttachVolumeInput) bool {
+		return *input.VolumeId == "vol-123" && *input.InstanceId == "i-123" && *input.Device == "/dev/sdf"
+	})).Return(&ec2sdk.AttachVolumeOutput{}, nil)
+
+	v := &VolumeAttachment{}
+	result, err := v.createWithClient(ctx, client, &resource.CreateRequest{Properties: volumeAttachmentCreateProps()})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "vol-123|i-123", result.ProgressResult.NativeID)
+	assert.Equal(t, string(resource.OperationCreate)+"|vol-123|i-123", result.ProgressResult.RequestID)
+	client.AssertExpectations(t)
+}
+
+func TestVolumeAttachment_Create_AdoptsExistingAttachment(t *testing.T) {
+	ctx := context.Background()
+	client := &mockVolumeAttachmentClient{}
+	client.On("DescribeVolumes", ctx, mock.Anything).Return(volumeWithAttachment(ec2types.VolumeAttachmentStateAttached), nil)
+
+	v := &VolumeAttachment{}
+	result, err := v.createWithClient(ctx, client, &resource.CreateRequest{Properties: volumeAttachmentCreateProps()})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	client.AssertNotCalled(t, "AttachVolume", mock.Anything, mock.Anything)
+}
+
+func TestVolumeAttachment_Create_StillDetaching(t *testing.T) {
+	ctx := context.Background()
+	client := &mockVolumeAttachmentClient{}
+	client.On("DescribeVolumes", ctx, mock.Anything).Return(volumeWithAttachment(ec2types.VolumeAttachmentStateDetaching), nil)
+
+	v := &VolumeAttachment{}
+	result, err := v.createWithClient(ctx, client, &resource.CreateRequest{Properties: volumeAttachmentCreateProps()})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+	assert.Equal(t, resource.OperationErrorCodeResourceConflict, result.ProgressResult.ErrorCode)
+}
+
+func TestVolumeAttachment_Status_Create(t *testing.T) {
+	tests := []struct {
+		name  string
+		state ec2types.VolumeAttachmentState
+		want  resource.OperationStatus
+	}{
+		{"attaching", ec2types.VolumeAttachmentStateAttaching, resource.OperationStatusInProgress},
+		{"attached", ec2types.VolumeAttachmentStateAttached, resource.OperationStatusSuccess},
+		{"busy", ec2types.VolumeAttachmentStateBusy, resource.OperationStatusSuccess},
+		{"detached", ec2types.VolumeAttachmentStateDetached, resource.OperationStatusFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &mockVolumeAttachmentClient{}
+			client.On("DescribeVolumes", ctx, mock.MatchedBy(func(input *ec2sdk.DescribeVolumesInput) bool {
+				return len(input.VolumeIds) == 1 && input.VolumeIds[0] == "vol-123"
+			})).Return(volumeWithAttachment(tt.state), nil)
+
+			v := &VolumeAttachment{}
+			result, err := v.statusWithClient(ctx, client, &resource.StatusRequest{
+				RequestID: string(resource.OperationCreate) + "|vol-123|i-123",
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result.ProgressResult.OperationStatus)
+			assert.Equal(t, resource.OperationCreate, result.ProgressResult.Operation)
+			assert.Equal(t, "vol-123|i-123", result.ProgressResult.NativeID)
+		})
+	}
+}
+
+func TestVolumeAttachment_Status_Delete(t *testing.T) {
+	tests := []struct {
+		name   string
+		output *ec2sdk.DescribeVolumesOutput
+		want   resource.OperationStatus
+	}{
+		{"detaching", volumeWithAttachment(ec2types.VolumeAttachmentStateDetaching), resource.OperationStatusInProgress},
+		{"busy", volumeWithAttachment(ec2types.VolumeAttachmentStateBusy), resource.OperationStatusInProgress},
+		{"detached", volumeWithAttachment(ec2types.VolumeAttachmentStateDetached), resource.OperationStatusSuccess},
+		{"gone", &ec2sdk.DescribeVolumesOutput{Volumes: []ec2types.Volume{{VolumeId: strPtr("vol-123")}}}, resource.OperationStatusSuccess},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &mockVolumeAttachmentClient{}
+			client.On("DescribeVolumes", ctx, mock.Anything).Return(tt.output, nil)
+
+			v := &VolumeAttachment{}
+			result, err := v.statusWithClient(ctx, client, &resource.StatusRequest{
+				RequestID: string(resource.OperationDelete) + "|vol-123|i-123",
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result.ProgressResult.OperationStatus)
+			assert.Equal(t, resource.OperationDelete, result.ProgressResult.Operation)
+		})
+	}
+}
+
+func TestVolumeAttachment_Read(t *testing.T) {
+	ctx := context.Background()
+	client := &mockVolumeAttachmentClient{}
+	client.On("DescribeVolumes", ctx, mock.Anything).Return(volumeWithAttachment(ec2types.VolumeAttachmentStateAttached), nil)
+
+	v := &VolumeAttachment{}
+	result, err := v.readWithClient(ctx, client, &resource.ReadRequest{NativeID: "vol-123|i-123"})
+
+	assert.NoError(t, err)
+	var props map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, "vol-123", props["VolumeId"])
+	assert.Equal(t, "i-123", props["InstanceId"])
+	assert.Equal(t, "/dev/sdf", props["Device"])
+}
+
+func TestVolumeAttachment_Read_VolumeGone(t *testing.T) {
+	ctx := context.Background()
+	client := &mockVolumeAttachmentClient{}
+	client.On("DescribeVolumes", ctx, mock.Anything).Return((*ec2sdk.DescribeVolumesOutput)(nil), &fakeNIPAPIError{code: "InvalidVolume.NotFound"})
+
+	v := &VolumeAttachment{}
+	result, err := v.readWithClient(ctx, client, &resource.ReadRequest{NativeID: "vol-123|i-123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+}
+
+func TestVolumeAttachment_Delete_Detaches(t *testing.T) {
+	ctx := context.Background()
+	client := &mockVolumeAttachmentClient{}
+	client.On("DescribeVolumes", ctx, mock.Anything).Return(volumeWithAttachment(ec2types.VolumeAttachmentStateAttached), nil)
+	client.On("DetachVolume", ctx, mock.MatchedBy(func(input *ec2sdk.DetachVolumeInput) bool {
+		return *input.VolumeId == "vol-123" && *input.InstanceId == "i-123" && *input.Device == "/dev/sdf"
+	})).Return(&ec2sdk.DetachVolumeOutput{}, nil)
+
+	v := &VolumeAttachment{}
+	result, err := v.deleteWithClient(ctx, client, &resource.DeleteRequest{NativeID: "vol-123|i-123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, string(resource.OperationDelete)+"|vol-123|i-123", result.ProgressResult.RequestID)
+	client.AssertExpectations(t)
+}
+
+func TestVolumeAttachment_Delete_AlreadyDetached(t *testing.T) {
+	ctx := context.Background()
+	client := &mockVolumeAttachmentClient{}
+	client.On("DescribeVolumes", ctx, mock.Anything).Return(volumeWithAttachment(ec2types.VolumeAttachmentStateDetached), nil)
+
+	v := &VolumeAttachment{}
+	result, err := v.deleteWithClient(ctx, client, &resource.DeleteRequest{NativeID: "vol-123|i-123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertNotCalled(t, "DetachVolume", mock.Anything, mock.Anything)
+}
+
+func TestParseVolumeAttachmentNativeID(t *testing.T) {
+	volumeID, instanceID, err := parseVolumeAttachmentNativeID("vol-123|i-123")
+	assert.NoError(t, err)
+	assert.Equal(t, "vol-123", volumeID)
+	assert.Equal(t, "i-123", instanceID)
+
+	for _, invalid := range []string{"", "vol-123", "vol-123|", "|i-123", "a|b|c"} {
+		_, _, err := parseVolumeAttachmentNativeID(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/platform-engineering-labs/formae/pkg/plugin"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/arn"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ccx"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
@@ -296,12 +297,12 @@ func extractScale(desired json.RawMessage) (*ecstypes.Scale, error) {
 // lastArnSegment returns the segment after the final "/" in an ARN, matching
 // ccx.normalizeCompositeIdentifier's logic for parts[1]+ of composite IDs.
 // Non-ARN inputs are returned unchanged.
-func lastArnSegment(arn string) string {
-	if !strings.HasPrefix(arn, "arn:aws:") {
-		return arn
+func lastArnSegment(s string) string {
+	if !arn.IsArn(s) {
+		return s
 	}
-	if idx := strings.LastIndex(arn, "/"); idx >= 0 {
-		return arn[idx+1:]
+	if idx := strings.LastIndex(s, "/"); idx >= 0 {
+		return s[idx+1:]
 	}
-	return arn
+	return s
 }
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
@@ -27,8 +28,12 @@ type accessKeyClientInterface interface {
 	ListAccessKeys(ctx context.Context, params *iam.ListAccessKeysInput, optFns ...func(*iam.Options)) (*iam.ListAccessKeysOutput, error)
 }
 
+// AccessKey provisions IAM access keys natively: CloudControl can't return a
+// key's secret, which IAM only reveals when the key is created.
 type AccessKey struct {
 	cfg *config.Config
+	// now is injectable for tests of key rotation.
+	now func() time.Time
 }
 
 var _ prov.Provisioner = &AccessKey{}
@@ -43,7 +48,7 @@ func init() {
 			resource.OperationCheckStatus,
 		},
 		func(cfg *config.Config) prov.Provisioner {
-			return &AccessKey{cfg: cfg}
+			return &AccessKey{cfg: cfg, now: time.Now}
 		})
 }
 
@@ -76,12 +81,20 @@ func (ak *AccessKey) createWithClient(ctx context.Context, client accessKeyClien
 	key := output.AccessKey
 	nativeID := fmt.Sprintf("%s|%s", *key.AccessKeyId, *key.UserName)
 
+	// The secret is only ever returned here; reads carry it over from the
+	// last known properties.
 	resultProps := map[string]any{
 		"AccessKeyId":     *key.AccessKeyId,
 		"SecretAccessKey": *key.SecretAccessKey,
 		"Status":          string(key.Status),
 		"UserName":        *key.UserName,
 	}
+	if key.CreateDate != nil {
+		resultProps["CreateDate"] = key.CreateDate.UTC().Format(time.RFC3339)
+	}
+	if days, ok := rotateAfterDays(props); ok {
+		resultProps[rotateAfterDaysKey] = days
+	}
 	resultJSON, _ := json.Marshal(resultProps)
 
 	return &resource.CreateResult{
@@ -115,6 +128,11 @@ func (ak *AccessKey) readWithClient(ctx context.Context, client accessKeyClientI
 		return nil, fmt.Errorf("listing access keys for user %s: %w", userName, err)
 	}
 
+	var prior map[string]any
+	if len(request.PriorProperties) > 0 {
+		_ = json.Unmarshal(request.PriorProperties, &prior)
+	}
+
 	for _, meta := range output.AccessKeyMetadata {
 		if meta.AccessKeyId != nil && *meta.AccessKeyId == accessKeyID {
 			props := map[string]any{
@@ -122,6 +140,18 @@ func (ak *AccessKey) readWithClient(ctx context.Context, client accessKeyClientI
 				"Status":      string(meta.Status),
 				"UserName":    *meta.UserName,
 			}
+			if secret, ok := prior["SecretAccessKey"].(string); ok && secret != "" && !request.RedactSensitive {
+				props["SecretAccessKey"] = secret
+			}
+			if meta.CreateDate != nil {
+				props["CreateDate"] = meta.CreateDate.UTC().Format(time.RFC3339)
+			}
+			// A key due for rotation reads back without RotateAfterDays. The
+			// property is create-only, so the difference from the model
+			// replaces the key with a new one.
+			if days, ok := rotateAfterDays(prior); ok && !ak.rotationDue(meta.CreateDate, days) {
+				props[rotateAfterDaysKey] = days
+			}
 			propsJSON, _ := json.Marshal(props)
 			return &resource.ReadResult{
 				ResourceType: request.ResourceType,
@@ -170,8 +200,9 @@ func (ak *AccessKey) updateWithClient(ctx context.Context, client accessKeyClien
 
 	// Post-update Read to populate ResourceProperties
 	readResult, err := ak.readWithClient(ctx, client, &resource.ReadRequest{
-		NativeID:     request.NativeID,
-		ResourceType: request.ResourceType,
+		NativeID:        request.NativeID,
+		ResourceType:    request.ResourceType,
+		PriorProperties: request.PriorProperties,
 	})
 
 	var resultProps json.RawMessage
@@ -237,6 +268,27 @@ func (ak *AccessKey) List(_ context.Context, _ *resource.ListRequest) (*resource
 	return nil, fmt.Errorf("operation not implemented - cloudcontrol handles this")
 }
 
+// rotateAfterDaysKey is the create-only property giving the age in days at
+// which a key is replaced.
+const rotateAfterDaysKey = "RotateAfterDays"
+
+func rotateAfterDays(props map[string]any) (int, bool) {
+	days, ok := props[rotateAfterDaysKey].(float64)
+	if !ok || days <= 0 {
+		return 0, false
+	}
+	return int(days), true
+}
+
+// rotationDue reports whether a key created at created is at least days old.
+// A key whose creation date is unknown is never due.
+func (ak *AccessKey) rotationDue(created *time.Time, days int) bool {
+	if created == nil {
+		return false
+	}
+	return !ak.now().Before(created.AddDate(0, 0, days))
+}
+
 func parseAccessKeyNativeID(nativeID string) (string, string, error) {
 	parts := strings.SplitN(nativeID, "|", 2)
 	if len(parts) != 2 {
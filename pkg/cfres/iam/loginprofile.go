@@ -0,0 +1,335 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package iam
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+// loginPasswordLength is the length of a generated console password, raised
+// to the account password policy's minimum if that is longer.
+const loginPasswordLength = 24
+
+// The character classes a generated password draws one of each from, so it
+// meets any account password policy. The symbols are those IAM accepts.
+var loginPasswordClasses = []string{
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"abcdefghijklmnopqrstuvwxyz",
+	"0123456789",
+	"!@#$%^&*()_+-=[]{}|'",
+}
+
+type loginProfileClientInterface interface {
+	CreateLoginProfile(ctx context.Context, params *iam.CreateLoginProfileInput, optFns ...func(*iam.Options)) (*iam.CreateLoginProfileOutput, error)
+	GetLoginProfile(ctx context.Context, params *iam.GetLoginProfileInput, optFns ...func(*iam.Options)) (*iam.GetLoginProfileOutput, error)
+	UpdateLoginProfile(ctx context.Context, params *iam.UpdateLoginProfileInput, optFns ...func(*iam.Options)) (*iam.UpdateLoginProfileOutput, error)
+	DeleteLoginProfile(ctx context.Context, params *iam.DeleteLoginProfileInput, optFns ...func(*iam.Options)) (*iam.DeleteLoginProfileOutput, error)
+	GetAccountPasswordPolicy(ctx context.Context, params *iam.GetAccountPasswordPolicyInput, optFns ...func(*iam.Options)) (*iam.GetAccountPasswordPolicyOutput, error)
+}
+
+// passwordSecretWriter stores a generated password. *secretsmanager.Client
+// satisfies it.
+type passwordSecretWriter interface {
+	PutSecretValue(ctx context.Context, params *secretsmanager.PutSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.PutSecretValueOutput, error)
+}
+
+// LoginProfile gives an IAM user a console password. CloudControl can't
+// manage AWS::IAM::User's LoginProfile, whose password is write-only, so
+// this plugin-only type does it with the IAM API. The password is generated
+// here and never declared: it is written to the Secrets Manager secret named
+// by PasswordSecretId, or, without one, returned once in the create result as
+// Password and left out of every read after that.
+type LoginProfile struct {
+	cfg *config.Config
+}
+
+var _ prov.Provisioner = &LoginProfile{}
+
+func init() {
+	registry.Register("AWS::IAM::LoginProfile",
+		[]resource.Operation{
+			resource.OperationCreate,
+			resource.OperationRead,
+			resource.OperationUpdate,
+			resource.OperationDelete,
+			resource.OperationCheckStatus,
+		},
+		func(cfg *config.Config) prov.Provisioner {
+			return &LoginProfile{cfg: cfg}
+		})
+}
+
+func (lp *LoginProfile) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	awsCfg, err := lp.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return lp.createWithClients(ctx, iam.NewFromConfig(awsCfg), secretsmanager.NewFromConfig(awsCfg), request)
+}
+
+func (lp *LoginProfile) createWithClients(ctx context.Context, client loginProfileClientInterface, secrets passwordSecretWriter, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("parsing properties: %w", err)
+	}
+
+	userName, _ := props["UserName"].(string)
+	if userName == "" {
+		return nil, fmt.Errorf("UserName is required")
+	}
+	resetRequired, _ := props["PasswordResetRequired"].(bool)
+	secretID, _ := props["PasswordSecretId"].(string)
+
+	password, err := generatePassword(loginPasswordMinLength(ctx, client))
+	if err != nil {
+		return nil, fmt.Errorf("generating password: %w", err)
+	}
+
+	// The password is stored before IAM accepts it, so a user never has a
+	// password nobody can retrieve.
+	if secretID != "" {
+		if _, err := secrets.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
+			SecretId:     aws.String(secretID),
+			SecretString: aws.String(password),
+		}); err != nil {
+			return nil, fmt.Errorf("storing password for user %s in secret %s: %w", userName, secretID, err)
+		}
+	}
+
+	output, err := client.CreateLoginProfile(ctx, &iam.CreateLoginProfileInput{
+		UserName:              aws.String(userName),
+		Password:              aws.String(password),
+		PasswordResetRequired: resetRequired,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating login profile for user %s: %w", userName, err)
+	}
+
+	resultProps := loginProfileProperties(output.LoginProfile)
+	if secretID != "" {
+		resultProps["PasswordSecretId"] = secretID
+	} else {
+		resultProps["Password"] = password
+	}
+	resultJSON, _ := json.Marshal(resultProps)
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:          resource.OperationCreate,
+			OperationStatus:    resource.OperationStatusSuccess,
+			NativeID:           userName,
+			ResourceProperties: resultJSON,
+		},
+	}, nil
+}
+
+func (lp *LoginProfile) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	awsCfg, err := lp.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return lp.readWithClient(ctx, iam.NewFromConfig(awsCfg), request)
+}
+
+// readWithClient reads the login profile of the user named by NativeID. The
+// password can't be read back, and one returned by the create isn't carried
+// over either.
+func (lp *LoginProfile) readWithClient(ctx context.Context, client loginProfileClientInterface, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	output, err := client.GetLoginProfile(ctx, &iam.GetLoginProfileInput{
+		UserName: aws.String(request.NativeID),
+	})
+	if err != nil {
+		var noSuchEntity *iamtypes.NoSuchEntityException
+		if errors.As(err, &noSuchEntity) {
+			return &resource.ReadResult{
+				ResourceType: request.ResourceType,
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("reading login profile for user %s: %w", request.NativeID, err)
+	}
+
+	props := loginProfileProperties(output.LoginProfile)
+	var prior map[string]any
+	if len(request.PriorProperties) > 0 {
+		_ = json.Unmarshal(request.PriorProperties, &prior)
+	}
+	if secretID, ok := prior["PasswordSecretId"].(string); ok && secretID != "" {
+		props["PasswordSecretId"] = secretID
+	}
+	propsJSON, _ := json.Marshal(props)
+
+	return &resource.ReadResult{
+		ResourceType: request.ResourceType,
+		Properties:   string(propsJSON),
+	}, nil
+}
+
+func (lp *LoginProfile) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	awsCfg, err := lp.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return lp.updateWithClient(ctx, iam.NewFromConfig(awsCfg), request)
+}
+
+// updateWithClient applies PasswordResetRequired, the only property that
+// isn't create-only. The password itself is left as it is.
+func (lp *LoginProfile) updateWithClient(ctx context.Context, client loginProfileClientInterface, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	var desired map[string]any
+	if err := json.Unmarshal(request.DesiredProperties, &desired); err != nil {
+		return nil, fmt.Errorf("parsing desired properties: %w", err)
+	}
+	resetRequired, _ := desired["PasswordResetRequired"].(bool)
+
+	if _, err := client.UpdateLoginProfile(ctx, &iam.UpdateLoginProfileInput{
+		UserName:              aws.String(request.NativeID),
+		PasswordResetRequired: aws.Bool(resetRequired),
+	}); err != nil {
+		return nil, fmt.Errorf("updating login profile for user %s: %w", request.NativeID, err)
+	}
+
+	// Post-update Read to populate ResourceProperties
+	readResult, err := lp.readWithClient(ctx, client, &resource.ReadRequest{
+		NativeID:        request.NativeID,
+		ResourceType:    request.ResourceType,
+		PriorProperties: request.PriorProperties,
+	})
+
+	var resultProps json.RawMessage
+	if err == nil && readResult.ErrorCode == "" {
+		resultProps = json.RawMessage(readResult.Properties)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:          resource.OperationUpdate,
+			OperationStatus:    resource.OperationStatusSuccess,
+			NativeID:           request.NativeID,
+			ResourceProperties: resultProps,
+		},
+	}, nil
+}
+
+func (lp *LoginProfile) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	awsCfg, err := lp.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return lp.deleteWithClient(ctx, iam.NewFromConfig(awsCfg), request)
+}
+
+// deleteWithClient removes the user's console password. A secret the
+// password was stored in belongs to its own resource and is left in place.
+func (lp *LoginProfile) deleteWithClient(ctx context.Context, client loginProfileClientInterface, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	if _, err := client.DeleteLoginProfile(ctx, &iam.DeleteLoginProfileInput{
+		UserName: aws.String(request.NativeID),
+	}); err != nil {
+		var noSuchEntity *iamtypes.NoSuchEntityException
+		if !errors.As(err, &noSuchEntity) {
+			return nil, fmt.Errorf("deleting login profile for user %s: %w", request.NativeID, err)
+		}
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (lp *LoginProfile) Status(_ context.Context, _ *resource.StatusRequest) (*resource.StatusResult, error) {
+	return nil, fmt.Errorf("login profile operations are synchronous - status polling not needed")
+}
+
+func (lp *LoginProfile) List(_ context.Context, _ *resource.ListRequest) (*resource.ListResult, error) {
+	return nil, fmt.Errorf("list not supported for AWS::IAM::LoginProfile")
+}
+
+func loginProfileProperties(profile *iamtypes.LoginProfile) map[string]any {
+	props := map[string]any{
+		"UserName":              aws.ToString(profile.UserName),
+		"PasswordResetRequired": profile.PasswordResetRequired,
+	}
+	if profile.CreateDate != nil {
+		props["CreateDate"] = profile.CreateDate.UTC().Format(time.RFC3339)
+	}
+	return props
+}
+
+// loginPasswordMinLength returns the length to generate a password at: the
+// account password policy's minimum, if the account has a policy and it asks
+// for more than loginPasswordLength.
+func loginPasswordMinLength(ctx context.Context, client loginProfileClientInterface) int {
+	output, err := client.GetAccountPasswordPolicy(ctx, &iam.GetAccountPasswordPolicyInput{})
+	if err != nil {
+		var noSuchEntity *iamtypes.NoSuchEntityException
+		if !errors.As(err, &noSuchEntity) {
+			plugin.LoggerFromContext(ctx).Warn("IAM::LoginProfile: could not read the account password policy; using the default length",
+				"error", err)
+		}
+		return loginPasswordLength
+	}
+	if minLength := int(aws.ToInt32(output.PasswordPolicy.MinimumPasswordLength)); minLength > loginPasswordLength {
+		return minLength
+	}
+	return loginPasswordLength
+}
+
+// generatePassword returns a random password of length characters with at
+// least one character of each of loginPasswordClasses.
+func generatePassword(length int) (string, error) {
+	pick := func(chars string) (byte, error) {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		if err != nil {
+			return 0, err
+		}
+		return chars[n.Int64()], nil
+	}
+
+	all := strings.Join(loginPasswordClasses, "")
+	password := make([]byte, length)
+	for i := range password {
+		chars := all
+		if i < len(loginPasswordClasses) {
+			chars = loginPasswordClasses[i]
+		}
+		c, err := pick(chars)
+		if err != nil {
+			return "", err
+		}
+		password[i] = c
+	}
+
+	// Shuffle so the guaranteed characters aren't always at the start.
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+	return string(password), nil
+}
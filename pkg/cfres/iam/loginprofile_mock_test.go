@@ -0,0 +1,59 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package iam
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockLoginProfileClient struct {
+	mock.Mock
+}
+
+func (m *mockLoginProfileClient) CreateLoginProfile(ctx context.Context, input *iam.CreateLoginProfileInput, optFns ...func(*iam.Options)) (*iam.CreateLoginProfileOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*iam.CreateLoginProfileOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockLoginProfileClient) GetLoginProfile(ctx context.Context, input *iam.GetLoginProfileInput, optFns ...func(*iam.Options)) (*iam.GetLoginProfileOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*iam.GetLoginProfileOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockLoginProfileClient) UpdateLoginProfile(ctx context.Context, input *iam.UpdateLoginProfileInput, optFns ...func(*iam.Options)) (*iam.UpdateLoginProfileOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*iam.UpdateLoginProfileOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockLoginProfileClient) DeleteLoginProfile(ctx context.Context, input *iam.DeleteLoginProfileInput, optFns ...func(*iam.Options)) (*iam.DeleteLoginProfileOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*iam.DeleteLoginProfileOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockLoginProfileClient) GetAccountPasswordPolicy(ctx context.Context, input *iam.GetAccountPasswordPolicyInput, optFns ...func(*iam.Options)) (*iam.GetAccountPasswordPolicyOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*iam.GetAccountPasswordPolicyOutput)
+	return out, args.Error(1)
+}
+
+type mockPasswordSecretWriter struct {
+	mock.Mock
+}
+
+func (m *mockPasswordSecretWriter) PutSecretValue(ctx context.Context, input *secretsmanager.PutSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.PutSecretValueOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*secretsmanager.PutSecretValueOutput)
+	return out, args.Error(1)
+}
@@ -0,0 +1,200 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+var loginProfileCreated = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func createdLoginProfile(userName string, resetRequired bool) *iamtypes.LoginProfile {
+	return &iamtypes.LoginProfile{
+		UserName:              aws.String(userName),
+		PasswordResetRequired: resetRequired,
+		CreateDate:            aws.Time(loginProfileCreated),
+	}
+}
+
+func TestGeneratePassword(t *testing.T) {
+	for range 20 {
+		password, err := generatePassword(loginPasswordLength)
+		require.NoError(t, err)
+		assert.Len(t, password, loginPasswordLength)
+		for _, class := range loginPasswordClasses {
+			assert.True(t, strings.ContainsAny(password, class), "%q has no character of %q", password, class)
+		}
+	}
+}
+
+func TestLoginPasswordMinLength(t *testing.T) {
+	ctx := context.Background()
+
+	client := &mockLoginProfileClient{}
+	client.On("GetAccountPasswordPolicy", ctx, mock.Anything).Return(&iam.GetAccountPasswordPolicyOutput{
+		PasswordPolicy: &iamtypes.PasswordPolicy{MinimumPasswordLength: aws.Int32(40)},
+	}, nil)
+	assert.Equal(t, 40, loginPasswordMinLength(ctx, client))
+
+	client = &mockLoginProfileClient{}
+	client.On("GetAccountPasswordPolicy", ctx, mock.Anything).Return(nil, &iamtypes.NoSuchEntityException{})
+	assert.Equal(t, loginPasswordLength, loginPasswordMinLength(ctx, client))
+
+	client = &mockLoginProfileClient{}
+	client.On("GetAccountPasswordPolicy", ctx, mock.Anything).Return(nil, errors.New("AccessDenied"))
+	assert.Equal(t, loginPasswordLength, loginPasswordMinLength(ctx, client))
+}
+
+func TestLoginProfile_Create_ReturnsPasswordOnce(t *testing.T) {
+	ctx := context.Background()
+	client := &mockLoginProfileClient{}
+	client.On("GetAccountPasswordPolicy", ctx, mock.Anything).Return(nil, &iamtypes.NoSuchEntityException{})
+	var sent string
+	client.On("CreateLoginProfile", ctx, mock.MatchedBy(func(input *iam.CreateLoginProfileInput) bool {
+		sent = aws.ToString(input.Password)
+		return aws.ToString(input.UserName) == "alice" && input.PasswordResetRequired
+	})).Return(&iam.CreateLoginProfileOutput{LoginProfile: createdLoginProfile("alice", true)}, nil)
+	secrets := &mockPasswordSecretWriter{}
+
+	lp := &LoginProfile{}
+	result, err := lp.createWithClients(ctx, client, secrets, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"UserName":"alice","PasswordResetRequired":true}`),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "alice", result.ProgressResult.NativeID)
+	var props map[string]any
+	require.NoError(t, json.Unmarshal(result.ProgressResult.ResourceProperties, &props))
+	assert.Equal(t, sent, props["Password"])
+	assert.Len(t, sent, loginPasswordLength)
+	assert.Equal(t, "2026-01-02T03:04:05Z", props["CreateDate"])
+	secrets.AssertNotCalled(t, "PutSecretValue", mock.Anything, mock.Anything)
+}
+
+func TestLoginProfile_Create_StoresPasswordInSecret(t *testing.T) {
+	ctx := context.Background()
+	client := &mockLoginProfileClient{}
+	client.On("GetAccountPasswordPolicy", ctx, mock.Anything).Return(nil, &iamtypes.NoSuchEntityException{})
+	var stored string
+	secrets := &mockPasswordSecretWriter{}
+	secrets.On("PutSecretValue", ctx, mock.MatchedBy(func(input *secretsmanager.PutSecretValueInput) bool {
+		stored = aws.ToString(input.SecretString)
+		return aws.ToString(input.SecretId) == "console/alice"
+	})).Return(&secretsmanager.PutSecretValueOutput{}, nil)
+	client.On("CreateLoginProfile", ctx, mock.MatchedBy(func(input *iam.CreateLoginProfileInput) bool {
+		return aws.ToString(input.Password) == stored
+	})).Return(&iam.CreateLoginProfileOutput{LoginProfile: createdLoginProfile("alice", false)}, nil)
+
+	lp := &LoginProfile{}
+	result, err := lp.createWithClients(ctx, client, secrets, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"UserName":"alice","PasswordSecretId":"console/alice"}`),
+	})
+
+	require.NoError(t, err)
+	var props map[string]any
+	require.NoError(t, json.Unmarshal(result.ProgressResult.ResourceProperties, &props))
+	assert.NotContains(t, props, "Password")
+	assert.Equal(t, "console/alice", props["PasswordSecretId"])
+	client.AssertExpectations(t)
+}
+
+func TestLoginProfile_Create_SecretFailureCreatesNoProfile(t *testing.T) {
+	ctx := context.Background()
+	client := &mockLoginProfileClient{}
+	client.On("GetAccountPasswordPolicy", ctx, mock.Anything).Return(nil, &iamtypes.NoSuchEntityException{})
+	secrets := &mockPasswordSecretWriter{}
+	secrets.On("PutSecretValue", ctx, mock.Anything).Return(nil, errors.New("AccessDeniedException"))
+
+	lp := &LoginProfile{}
+	_, err := lp.createWithClients(ctx, client, secrets, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"UserName":"alice","PasswordSecretId":"console/alice"}`),
+	})
+
+	require.Error(t, err)
+	client.AssertNotCalled(t, "CreateLoginProfile", mock.Anything, mock.Anything)
+}
+
+func TestLoginProfile_Read_RedactsPassword(t *testing.T) {
+	ctx := context.Background()
+	client := &mockLoginProfileClient{}
+	client.On("GetLoginProfile", ctx, mock.Anything).Return(&iam.GetLoginProfileOutput{
+		LoginProfile: createdLoginProfile("alice", false),
+	}, nil)
+
+	lp := &LoginProfile{}
+	result, err := lp.readWithClient(ctx, client, &resource.ReadRequest{
+		NativeID:        "alice",
+		PriorProperties: json.RawMessage(`{"UserName":"alice","Password":"hunter2","PasswordSecretId":"console/alice"}`),
+	})
+
+	require.NoError(t, err)
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.NotContains(t, props, "Password")
+	assert.Equal(t, "console/alice", props["PasswordSecretId"])
+	assert.Equal(t, false, props["PasswordResetRequired"])
+}
+
+func TestLoginProfile_Read_NotFound(t *testing.T) {
+	ctx := context.Background()
+	client := &mockLoginProfileClient{}
+	client.On("GetLoginProfile", ctx, mock.Anything).Return(nil, &iamtypes.NoSuchEntityException{})
+
+	lp := &LoginProfile{}
+	result, err := lp.readWithClient(ctx, client, &resource.ReadRequest{NativeID: "alice"})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+}
+
+func TestLoginProfile_Update_PasswordResetRequired(t *testing.T) {
+	ctx := context.Background()
+	client := &mockLoginProfileClient{}
+	client.On("UpdateLoginProfile", ctx, mock.MatchedBy(func(input *iam.UpdateLoginProfileInput) bool {
+		return aws.ToString(input.UserName) == "alice" && aws.ToBool(input.PasswordResetRequired) && input.Password == nil
+	})).Return(&iam.UpdateLoginProfileOutput{}, nil)
+	client.On("GetLoginProfile", ctx, mock.Anything).Return(&iam.GetLoginProfileOutput{
+		LoginProfile: createdLoginProfile("alice", true),
+	}, nil)
+
+	lp := &LoginProfile{}
+	result, err := lp.updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "alice",
+		DesiredProperties: json.RawMessage(`{"UserName":"alice","PasswordResetRequired":true}`),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertExpectations(t)
+}
+
+func TestLoginProfile_Delete_AlreadyGone(t *testing.T) {
+	ctx := context.Background()
+	client := &mockLoginProfileClient{}
+	client.On("DeleteLoginProfile", ctx, mock.Anything).Return(nil, &iamtypes.NoSuchEntityException{})
+
+	lp := &LoginProfile{}
+	result, err := lp.deleteWithClient(ctx, client, &resource.DeleteRequest{NativeID: "alice"})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
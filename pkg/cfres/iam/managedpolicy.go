@@ -0,0 +1,89 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+type managedPolicyClientInterface interface {
+	ListPolicies(ctx context.Context, params *iam.ListPoliciesInput, optFns ...func(*iam.Options)) (*iam.ListPoliciesOutput, error)
+}
+
+// ManagedPolicy lists AWS::IAM::ManagedPolicy resources with IAM's
+// ListPolicies, which can be scoped to a path (see pathPrefixProperty). Only
+// List is registered; every other operation goes through CloudControl in
+// aws.go.
+type ManagedPolicy struct {
+	cfg *config.Config
+}
+
+var _ prov.Provisioner = &ManagedPolicy{}
+
+func init() {
+	registry.Register("AWS::IAM::ManagedPolicy",
+		[]resource.Operation{resource.OperationList},
+		func(cfg *config.Config) prov.Provisioner {
+			return &ManagedPolicy{cfg: cfg}
+		})
+}
+
+func (mp *ManagedPolicy) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	awsCfg, err := mp.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return mp.listWithClient(ctx, iam.NewFromConfig(awsCfg), request)
+}
+
+// listWithClient lists a page of the account's own managed policies; those
+// AWS manages can't be declared. A policy's NativeID is its ARN.
+func (mp *ManagedPolicy) listWithClient(ctx context.Context, client managedPolicyClientInterface, request *resource.ListRequest) (*resource.ListResult, error) {
+	out, err := client.ListPolicies(ctx, &iam.ListPoliciesInput{
+		Scope:      iamtypes.PolicyScopeTypeLocal,
+		PathPrefix: listPathPrefix(request),
+		MaxItems:   listPageSize(request),
+		Marker:     listMarker(request),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing managed policies: %w", err)
+	}
+
+	nativeIDs := make([]string, 0, len(out.Policies))
+	for _, policy := range out.Policies {
+		nativeIDs = append(nativeIDs, *policy.Arn)
+	}
+	return listResult(nativeIDs, out.IsTruncated, out.Marker), nil
+}
+
+// The remaining Provisioner methods are unreachable: only List is registered.
+func (mp *ManagedPolicy) Create(_ context.Context, _ *resource.CreateRequest) (*resource.CreateResult, error) {
+	return nil, fmt.Errorf("create not implemented - cloudcontrol handles this operation")
+}
+
+func (mp *ManagedPolicy) Read(_ context.Context, _ *resource.ReadRequest) (*resource.ReadResult, error) {
+	return nil, fmt.Errorf("read not implemented - cloudcontrol handles this operation")
+}
+
+func (mp *ManagedPolicy) Update(_ context.Context, _ *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	return nil, fmt.Errorf("update not implemented - cloudcontrol handles this operation")
+}
+
+func (mp *ManagedPolicy) Delete(_ context.Context, _ *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	return nil, fmt.Errorf("delete not implemented - cloudcontrol handles this operation")
+}
+
+func (mp *ManagedPolicy) Status(_ context.Context, _ *resource.StatusRequest) (*resource.StatusResult, error) {
+	return nil, fmt.Errorf("status not implemented - cloudcontrol handles this operation")
+}
@@ -0,0 +1,190 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package iam
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ccx"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+// oidcProviderCCXCreator is the CloudControl create an OIDC provider's Create
+// hands off to. *ccx.Client satisfies it.
+type oidcProviderCCXCreator interface {
+	CreateResource(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error)
+}
+
+// OIDCProvider fills in the ThumbprintList of an AWS::IAM::OIDCProvider
+// declared without one, computing the thumbprint from the issuer's TLS
+// certificate chain the way AWS documents doing it by hand with openssl. Only
+// Create is registered; the provider itself is created through CloudControl,
+// as are all other operations in aws.go.
+type OIDCProvider struct {
+	cfg *config.Config
+	// ccxClient and httpClient are injectable for testing; nil means construct
+	// the real clients.
+	ccxClient  oidcProviderCCXCreator
+	httpClient aws.HTTPClient
+}
+
+var _ prov.Provisioner = &OIDCProvider{}
+
+func init() {
+	registry.Register("AWS::IAM::OIDCProvider",
+		[]resource.Operation{resource.OperationCreate},
+		func(cfg *config.Config) prov.Provisioner {
+			return &OIDCProvider{cfg: cfg}
+		})
+}
+
+func (op *OIDCProvider) getCCXClient() (oidcProviderCCXCreator, error) {
+	if op.ccxClient != nil {
+		return op.ccxClient, nil
+	}
+	return ccx.NewClient(op.cfg)
+}
+
+// getHTTPClient returns the target's HTTP client, so the issuer is reached
+// through the same proxy and CA bundle as AWS.
+func (op *OIDCProvider) getHTTPClient(ctx context.Context) (aws.HTTPClient, error) {
+	if op.httpClient != nil {
+		return op.httpClient, nil
+	}
+	awsCfg, err := op.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	if awsCfg.HTTPClient == nil {
+		return http.DefaultClient, nil
+	}
+	return awsCfg.HTTPClient, nil
+}
+
+func (op *OIDCProvider) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	ccxClient, err := op.getCCXClient()
+	if err != nil {
+		return nil, fmt.Errorf("creating cloudcontrol client: %w", err)
+	}
+	return op.createWithClient(ctx, ccxClient, request)
+}
+
+func (op *OIDCProvider) createWithClient(ctx context.Context, ccxClient oidcProviderCCXCreator, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, fmt.Errorf("parsing properties: %w", err)
+	}
+
+	issuerURL, _ := props["Url"].(string)
+	if thumbprints, _ := props["ThumbprintList"].([]any); len(thumbprints) == 0 && issuerURL != "" {
+		httpClient, err := op.getHTTPClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		thumbprint, err := oidcThumbprint(ctx, httpClient, issuerURL)
+		if err != nil {
+			return nil, fmt.Errorf("fetching thumbprint for %s: %w", issuerURL, err)
+		}
+		plugin.LoggerFromContext(ctx).Info("IAM::OIDCProvider: using fetched thumbprint",
+			"url", issuerURL, "thumbprint", thumbprint)
+
+		props["ThumbprintList"] = []any{thumbprint}
+		properties, err := json.Marshal(props)
+		if err != nil {
+			return nil, fmt.Errorf("marshal properties: %w", err)
+		}
+		request.Properties = properties
+	}
+
+	return ccxClient.CreateResource(ctx, request)
+}
+
+// oidcThumbprint returns the thumbprint IAM expects for an OIDC issuer: the
+// hex SHA-1 fingerprint of the last certificate in the chain served by the
+// host of the issuer's jwks_uri, which is the top intermediate CA.
+func oidcThumbprint(ctx context.Context, client aws.HTTPClient, issuerURL string) (string, error) {
+	if !strings.HasPrefix(issuerURL, "https://") {
+		issuerURL = "https://" + issuerURL
+	}
+	discovery, err := httpGet(ctx, client, strings.TrimSuffix(issuerURL, "/")+"/.well-known/openid-configuration")
+	if err != nil {
+		return "", err
+	}
+	defer discovery.Body.Close()
+	if discovery.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("openid-configuration returned %s", discovery.Status)
+	}
+	var metadata struct {
+		JwksURI string `json:"jwks_uri"`
+	}
+	if err := json.NewDecoder(discovery.Body).Decode(&metadata); err != nil {
+		return "", fmt.Errorf("parsing openid-configuration: %w", err)
+	}
+	if metadata.JwksURI == "" {
+		return "", fmt.Errorf("openid-configuration has no jwks_uri")
+	}
+
+	jwks, err := httpGet(ctx, client, metadata.JwksURI)
+	if err != nil {
+		return "", err
+	}
+	defer jwks.Body.Close()
+	_, _ = io.Copy(io.Discard, jwks.Body)
+	if jwks.TLS == nil || len(jwks.TLS.PeerCertificates) == 0 {
+		return "", fmt.Errorf("%s presented no TLS certificates", metadata.JwksURI)
+	}
+
+	certs := jwks.TLS.PeerCertificates
+	sum := sha1.Sum(certs[len(certs)-1].Raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func httpGet(ctx context.Context, client aws.HTTPClient, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting %s: %w", url, err)
+	}
+	return resp, nil
+}
+
+// The remaining Provisioner methods are unreachable: only Create is
+// registered, so Read/Update/Delete/List/Status always route to CloudControl
+// in aws.go.
+func (op *OIDCProvider) Read(_ context.Context, _ *resource.ReadRequest) (*resource.ReadResult, error) {
+	return nil, fmt.Errorf("read not implemented - cloudcontrol handles this operation")
+}
+
+func (op *OIDCProvider) Update(_ context.Context, _ *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	return nil, fmt.Errorf("update not implemented - cloudcontrol handles this operation")
+}
+
+func (op *OIDCProvider) Delete(_ context.Context, _ *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	return nil, fmt.Errorf("delete not implemented - cloudcontrol handles this operation")
+}
+
+func (op *OIDCProvider) Status(_ context.Context, _ *resource.StatusRequest) (*resource.StatusResult, error) {
+	return nil, fmt.Errorf("status not implemented - cloudcontrol handles this operation")
+}
+
+func (op *OIDCProvider) List(_ context.Context, _ *resource.ListRequest) (*resource.ListResult, error) {
+	return nil, fmt.Errorf("list not implemented - cloudcontrol handles this operation")
+}
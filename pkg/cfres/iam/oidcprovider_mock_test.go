@@ -0,0 +1,25 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package iam
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+type mockOIDCProviderCCXCreator struct {
+	mock.Mock
+}
+
+func (m *mockOIDCProviderCCXCreator) CreateResource(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	args := m.Called(ctx, request)
+	out, _ := args.Get(0).(*resource.CreateResult)
+	return out, args.Error(1)
+}
@@ -0,0 +1,99 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package iam
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// newOIDCIssuer serves an issuer's discovery document over TLS, pointing
+// jwks_uri back at the same server.
+func newOIDCIssuer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"issuer":%q,"jwks_uri":%q}`, server.URL, server.URL+"/keys")
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"keys":[]}`)
+	})
+	t.Cleanup(server.Close)
+	return server
+}
+
+func serverThumbprint(server *httptest.Server) string {
+	sum := sha1.Sum(server.Certificate().Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestOIDCThumbprint(t *testing.T) {
+	server := newOIDCIssuer(t)
+
+	got, err := oidcThumbprint(context.Background(), server.Client(), server.URL+"/")
+
+	require.NoError(t, err)
+	assert.Equal(t, serverThumbprint(server), got)
+}
+
+func TestOIDCThumbprint_NoJwksURI(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"issuer":"https://example.com"}`)
+	}))
+	defer server.Close()
+
+	_, err := oidcThumbprint(context.Background(), server.Client(), server.URL)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no jwks_uri")
+}
+
+func TestOIDCProvider_Create_FetchesThumbprint(t *testing.T) {
+	ctx := context.Background()
+	server := newOIDCIssuer(t)
+	ccxClient := &mockOIDCProviderCCXCreator{}
+	ccxClient.On("CreateResource", ctx, mock.MatchedBy(func(request *resource.CreateRequest) bool {
+		var props map[string]any
+		_ = json.Unmarshal(request.Properties, &props)
+		return assert.ObjectsAreEqual([]any{serverThumbprint(server)}, props["ThumbprintList"]) &&
+			props["Url"] == server.URL
+	})).Return(&resource.CreateResult{}, nil)
+
+	op := &OIDCProvider{httpClient: server.Client()}
+	props := json.RawMessage(fmt.Sprintf(`{"Url":%q,"ClientIdList":["sts.amazonaws.com"]}`, server.URL))
+	_, err := op.createWithClient(ctx, ccxClient, &resource.CreateRequest{Properties: props})
+
+	require.NoError(t, err)
+	ccxClient.AssertExpectations(t)
+}
+
+func TestOIDCProvider_Create_KeepsDeclaredThumbprints(t *testing.T) {
+	ctx := context.Background()
+	ccxClient := &mockOIDCProviderCCXCreator{}
+	props := json.RawMessage(`{"Url":"https://token.actions.githubusercontent.com","ThumbprintList":["6938fd4d98bab03faadb97b34396831e3780aea1"]}`)
+	ccxClient.On("CreateResource", ctx, &resource.CreateRequest{Properties: props}).Return(&resource.CreateResult{}, nil)
+
+	// The issuer's URL isn't reachable from the test, so a fetch would fail.
+	op := &OIDCProvider{httpClient: http.DefaultClient}
+	_, err := op.createWithClient(ctx, ccxClient, &resource.CreateRequest{Properties: props})
+
+	require.NoError(t, err)
+	ccxClient.AssertExpectations(t)
+}
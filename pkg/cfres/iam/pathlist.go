@@ -0,0 +1,50 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package iam
+
+import (
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// pathPrefixProperty is the List additional property that scopes the listing
+// of roles, users, and managed policies to an IAM path such as "/formae/".
+// IAM filters by it, so an account with thousands of roles isn't paged
+// through in full.
+const pathPrefixProperty = "PathPrefix"
+
+// listPathPrefix returns the request's PathPrefix, or nil to list every path.
+func listPathPrefix(request *resource.ListRequest) *string {
+	if prefix := request.AdditionalProperties[pathPrefixProperty]; prefix != "" {
+		return &prefix
+	}
+	return nil
+}
+
+// listPageSize returns the request's page size, or 100 when it sets none.
+func listPageSize(request *resource.ListRequest) *int32 {
+	pageSize := request.PageSize
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	return &pageSize
+}
+
+// listMarker returns the request's page token as an IAM marker.
+func listMarker(request *resource.ListRequest) *string {
+	if request.PageToken != nil && *request.PageToken != "" {
+		return request.PageToken
+	}
+	return nil
+}
+
+// listResult returns a page of NativeIDs. IAM returns a marker only for a
+// truncated page.
+func listResult(nativeIDs []string, truncated bool, marker *string) *resource.ListResult {
+	result := &resource.ListResult{NativeIDs: nativeIDs}
+	if truncated {
+		result.NextPageToken = marker
+	}
+	return result
+}
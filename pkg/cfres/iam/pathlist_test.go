@@ -0,0 +1,105 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+func TestRole_List_PathPrefix(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRoleClient{}
+	client.On("ListRoles", ctx, mock.MatchedBy(func(input *iam.ListRolesInput) bool {
+		return aws.ToString(input.PathPrefix) == "/formae/" && aws.ToInt32(input.MaxItems) == 100 && input.Marker == nil
+	})).Return(&iam.ListRolesOutput{
+		Roles:       []iamtypes.Role{{RoleName: aws.String("app")}, {RoleName: aws.String("worker")}},
+		IsTruncated: true,
+		Marker:      aws.String("m1"),
+	}, nil)
+
+	r := &Role{}
+	result, err := r.listWithClient(ctx, client, &resource.ListRequest{
+		ResourceType:         roleType,
+		AdditionalProperties: map[string]string{"PathPrefix": "/formae/"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app", "worker"}, result.NativeIDs)
+	assert.Equal(t, "m1", aws.ToString(result.NextPageToken))
+	client.AssertExpectations(t)
+}
+
+func TestRole_List_NextPage(t *testing.T) {
+	ctx := context.Background()
+	client := &mockRoleClient{}
+	client.On("ListRoles", ctx, mock.MatchedBy(func(input *iam.ListRolesInput) bool {
+		return input.PathPrefix == nil && aws.ToString(input.Marker) == "m1" && aws.ToInt32(input.MaxItems) == 20
+	})).Return(&iam.ListRolesOutput{
+		Roles:  []iamtypes.Role{{RoleName: aws.String("last")}},
+		Marker: aws.String("ignored"),
+	}, nil)
+
+	r := &Role{}
+	result, err := r.listWithClient(ctx, client, &resource.ListRequest{
+		ResourceType: roleType,
+		PageToken:    aws.String("m1"),
+		PageSize:     20,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"last"}, result.NativeIDs)
+	assert.Nil(t, result.NextPageToken, "an untruncated page ends the listing")
+}
+
+func TestUser_List_PathPrefix(t *testing.T) {
+	ctx := context.Background()
+	client := &mockUserClient{}
+	client.On("ListUsers", ctx, mock.MatchedBy(func(input *iam.ListUsersInput) bool {
+		return aws.ToString(input.PathPrefix) == "/formae/"
+	})).Return(&iam.ListUsersOutput{
+		Users: []iamtypes.User{{UserName: aws.String("deployer")}},
+	}, nil)
+
+	u := &User{}
+	result, err := u.listWithClient(ctx, client, &resource.ListRequest{
+		ResourceType:         "AWS::IAM::User",
+		AdditionalProperties: map[string]string{"PathPrefix": "/formae/"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deployer"}, result.NativeIDs)
+	assert.Nil(t, result.NextPageToken)
+}
+
+func TestManagedPolicy_List_PathPrefix(t *testing.T) {
+	ctx := context.Background()
+	client := &mockManagedPolicyClient{}
+	client.On("ListPolicies", ctx, mock.MatchedBy(func(input *iam.ListPoliciesInput) bool {
+		return aws.ToString(input.PathPrefix) == "/formae/" && input.Scope == iamtypes.PolicyScopeTypeLocal
+	})).Return(&iam.ListPoliciesOutput{
+		Policies: []iamtypes.Policy{{Arn: aws.String("arn:aws:iam::123456789012:policy/formae/read-only")}},
+	}, nil)
+
+	mp := &ManagedPolicy{}
+	result, err := mp.listWithClient(ctx, client, &resource.ListRequest{
+		ResourceType:         "AWS::IAM::ManagedPolicy",
+		AdditionalProperties: map[string]string{"PathPrefix": "/formae/"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"arn:aws:iam::123456789012:policy/formae/read-only"}, result.NativeIDs)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/props"
 )
 
 type policyClientInterface interface {
@@ -31,6 +32,7 @@ type policyClientInterface interface {
 	PutGroupPolicy(ctx context.Context, params *iam.PutGroupPolicyInput, optFns ...func(*iam.Options)) (*iam.PutGroupPolicyOutput, error)
 	GetGroupPolicy(ctx context.Context, params *iam.GetGroupPolicyInput, optFns ...func(*iam.Options)) (*iam.GetGroupPolicyOutput, error)
 	DeleteGroupPolicy(ctx context.Context, params *iam.DeleteGroupPolicyInput, optFns ...func(*iam.Options)) (*iam.DeleteGroupPolicyOutput, error)
+	policySimulator
 }
 
 type Policy struct {
@@ -67,6 +69,9 @@ func (p *Policy) createWithClient(ctx context.Context, client policyClientInterf
 		return nil, err
 	}
 
+	if err := simulatePolicy(ctx, client, request.Properties, policyDocJSON); err != nil {
+		return nil, err
+	}
 	if err := putPolicyOnTargets(ctx, client, policyName, policyDocJSON, roles, users, groups); err != nil {
 		return nil, err
 	}
@@ -88,7 +93,7 @@ func (p *Policy) createWithClient(ctx context.Context, client policyClientInterf
 	if len(groups) > 0 {
 		resultProps["Groups"] = groups
 	}
-	resultJSON, _ := json.Marshal(resultProps)
+	resultJSON, _ := json.Marshal(withSimulation(resultProps, request.Properties))
 
 	return &resource.CreateResult{
 		ProgressResult: &resource.ProgressResult{
@@ -183,6 +188,7 @@ func (p *Policy) readWithClient(ctx context.Context, client policyClientInterfac
 
 	var policyDoc any
 	_ = json.Unmarshal([]byte(policyDocJSON), &policyDoc)
+	policyDoc = policyDocumentAsDeclared(policyDoc, request.PriorProperties)
 
 	props := map[string]any{
 		"PolicyName":     policyName,
@@ -197,7 +203,7 @@ func (p *Policy) readWithClient(ctx context.Context, client policyClientInterfac
 	if len(groups) > 0 {
 		props["Groups"] = groups
 	}
-	propsJSON, _ := json.Marshal(props)
+	propsJSON, _ := json.Marshal(withSimulation(props, request.PriorProperties))
 
 	return &resource.ReadResult{
 		ResourceType: request.ResourceType,
@@ -223,6 +229,9 @@ func (p *Policy) updateWithClient(ctx context.Context, client policyClientInterf
 	if err != nil {
 		return nil, err
 	}
+	if err := simulatePolicy(ctx, client, request.DesiredProperties, policyDocJSON); err != nil {
+		return nil, err
+	}
 
 	// Remove policy from targets no longer in the desired list
 	removedRoles := diff(currentRoles, newRoles)
@@ -273,8 +282,9 @@ func (p *Policy) updateWithClient(ctx context.Context, client policyClientInterf
 
 	// Post-update Read
 	readResult, err := p.readWithClient(ctx, client, &resource.ReadRequest{
-		NativeID:     newNativeID,
-		ResourceType: request.ResourceType,
+		NativeID:        newNativeID,
+		ResourceType:    request.ResourceType,
+		PriorProperties: request.DesiredProperties,
 	})
 	var resultProps json.RawMessage
 	if err == nil && readResult.ErrorCode == "" {
@@ -431,6 +441,16 @@ func parsePolicyProperties(raw json.RawMessage) (policyName string, policyDocJSO
 	return policyName, string(docJSON), roles, users, groups, nil
 }
 
+// policyDocumentAsDeclared reports a live policy document in the form the
+// prior model's PolicyDocument declares it, if the two are equivalent.
+func policyDocumentAsDeclared(live any, priorProperties json.RawMessage) any {
+	var prior map[string]any
+	if len(priorProperties) > 0 {
+		_ = json.Unmarshal(priorProperties, &prior)
+	}
+	return props.PolicyDocumentAsDeclared(live, prior["PolicyDocument"])
+}
+
 func putPolicyOnTargets(ctx context.Context, client policyClientInterface, policyName, policyDocJSON string, roles, users, groups []string) error {
 	for _, role := range roles {
 		if _, err := client.PutRolePolicy(ctx, &iam.PutRolePolicyInput{
@@ -61,3 +61,8 @@ func (m *mockPolicyClient) DeleteGroupPolicy(ctx context.Context, input *iam.Del
 	args := m.Called(ctx, input)
 	return args.Get(0).(*iam.DeleteGroupPolicyOutput), args.Error(1)
 }
+
+func (m *mockPolicyClient) SimulateCustomPolicy(ctx context.Context, input *iam.SimulateCustomPolicyInput, optFns ...func(*iam.Options)) (*iam.SimulateCustomPolicyOutput, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*iam.SimulateCustomPolicyOutput), args.Error(1)
+}
@@ -128,6 +128,32 @@ func TestPolicy_Read_SingleRole(t *testing.T) {
 	client.AssertExpectations(t)
 }
 
+func TestPolicy_Read_ReportsEquivalentDocumentAsDeclared(t *testing.T) {
+	ctx := context.Background()
+	client := &mockPolicyClient{}
+
+	encodedDoc := url.QueryEscape(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["*"]}]}`)
+	client.On("GetRolePolicy", ctx, mock.Anything).Return(&iam.GetRolePolicyOutput{PolicyDocument: &encodedDoc}, nil)
+
+	declared := map[string]any{
+		"Version":   "2012-10-17",
+		"Statement": map[string]any{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "*"},
+	}
+	prior, _ := json.Marshal(map[string]any{"PolicyName": "my-policy", "PolicyDocument": declared, "Roles": []string{"my-role"}})
+
+	p := &Policy{cfg: &config.Config{}}
+	result, err := p.readWithClient(ctx, client, &resource.ReadRequest{
+		NativeID:        "my-policy|R:my-role",
+		ResourceType:    "AWS::IAM::Policy",
+		PriorProperties: prior,
+	})
+
+	assert.NoError(t, err)
+	var props map[string]any
+	_ = json.Unmarshal([]byte(result.Properties), &props)
+	assert.Equal(t, declared, props["PolicyDocument"])
+}
+
 func TestPolicy_Read_NotFound(t *testing.T) {
 	ctx := context.Background()
 	client := &mockPolicyClient{}
@@ -0,0 +1,100 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+)
+
+// simulationKey is the plugin's own property declaring the actions a policy
+// is expected to allow and to deny, checked before the policy is written.
+// AWS doesn't store it, so reads report it back from the prior model.
+const simulationKey = "Simulation"
+
+// policySimulator evaluates a policy document. *iam.Client satisfies it.
+type policySimulator interface {
+	SimulateCustomPolicy(ctx context.Context, params *iam.SimulateCustomPolicyInput, optFns ...func(*iam.Options)) (*iam.SimulateCustomPolicyOutput, error)
+}
+
+// simulatePolicy runs the policy document through SimulateCustomPolicy for
+// the actions the model's Simulation expects to be allowed and denied, and
+// fails if the policy grants more or less than that. ResourceArns narrows
+// the simulation to those resources, "*" by default. A model that declares
+// no expectations isn't simulated.
+func simulatePolicy(ctx context.Context, client policySimulator, raw json.RawMessage, docJSON string) error {
+	var props map[string]any
+	if err := json.Unmarshal(raw, &props); err != nil {
+		return fmt.Errorf("parsing properties: %w", err)
+	}
+	simulation, _ := props[simulationKey].(map[string]any)
+	allowed := toStringSlice(simulation["ExpectAllowed"])
+	denied := toStringSlice(simulation["ExpectDenied"])
+	if len(allowed) == 0 && len(denied) == 0 {
+		return nil
+	}
+	for _, action := range allowed {
+		if slices.Contains(denied, action) {
+			return fmt.Errorf("%s is expected to be both allowed and denied", action)
+		}
+	}
+
+	input := &iam.SimulateCustomPolicyInput{
+		PolicyInputList: []string{docJSON},
+		ActionNames:     append(slices.Clone(allowed), denied...),
+		ResourceArns:    toStringSlice(simulation["ResourceArns"]),
+	}
+	var problems []string
+	for {
+		out, err := client.SimulateCustomPolicy(ctx, input)
+		if err != nil {
+			return fmt.Errorf("simulating policy: %w", err)
+		}
+		for _, result := range out.EvaluationResults {
+			action := aws.ToString(result.EvalActionName)
+			isAllowed := result.EvalDecision == iamtypes.PolicyEvaluationDecisionTypeAllowed
+			switch {
+			case isAllowed && slices.Contains(denied, action):
+				problems = append(problems, fmt.Sprintf("%s on %s is allowed, expected denied",
+					action, aws.ToString(result.EvalResourceName)))
+			case !isAllowed && slices.Contains(allowed, action):
+				problems = append(problems, fmt.Sprintf("%s on %s is %s, expected allowed",
+					action, aws.ToString(result.EvalResourceName), result.EvalDecision))
+			}
+		}
+		if !out.IsTruncated || out.Marker == nil {
+			break
+		}
+		input.Marker = out.Marker
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("policy simulation failed: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// withSimulation copies the Simulation of model, if it declares one, into
+// props.
+func withSimulation(props map[string]any, model json.RawMessage) map[string]any {
+	if len(model) == 0 {
+		return props
+	}
+	var m map[string]any
+	if err := json.Unmarshal(model, &m); err != nil {
+		return props
+	}
+	if simulation, ok := m[simulationKey]; ok {
+		props[simulationKey] = simulation
+	}
+	return props
+}
@@ -0,0 +1,155 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+const simulatedDoc = `{"Statement":[{"Effect":"Allow","Action":"s3:*","Resource":"*"}]}`
+
+func evaluation(action string, decision iamtypes.PolicyEvaluationDecisionType) iamtypes.EvaluationResult {
+	return iamtypes.EvaluationResult{
+		EvalActionName:   aws.String(action),
+		EvalResourceName: aws.String("*"),
+		EvalDecision:     decision,
+	}
+}
+
+func TestSimulatePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []iamtypes.EvaluationResult
+		wantErr string
+	}{
+		{"as expected", []iamtypes.EvaluationResult{
+			evaluation("s3:GetObject", iamtypes.PolicyEvaluationDecisionTypeAllowed),
+			evaluation("s3:DeleteBucket", iamtypes.PolicyEvaluationDecisionTypeExplicitDeny),
+		}, ""},
+		{"over-grants", []iamtypes.EvaluationResult{
+			evaluation("s3:GetObject", iamtypes.PolicyEvaluationDecisionTypeAllowed),
+			evaluation("s3:DeleteBucket", iamtypes.PolicyEvaluationDecisionTypeAllowed),
+		}, "s3:DeleteBucket on * is allowed, expected denied"},
+		{"under-grants", []iamtypes.EvaluationResult{
+			evaluation("s3:GetObject", iamtypes.PolicyEvaluationDecisionTypeImplicitDeny),
+			evaluation("s3:DeleteBucket", iamtypes.PolicyEvaluationDecisionTypeImplicitDeny),
+		}, "s3:GetObject on * is implicitDeny, expected allowed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &mockIAMClient{}
+			client.On("SimulateCustomPolicy", ctx, mock.MatchedBy(func(input *iam.SimulateCustomPolicyInput) bool {
+				return assert.ObjectsAreEqual([]string{simulatedDoc}, input.PolicyInputList) &&
+					assert.ObjectsAreEqual([]string{"s3:GetObject", "s3:DeleteBucket"}, input.ActionNames) &&
+					assert.ObjectsAreEqual([]string{"arn:aws:s3:::b/*"}, input.ResourceArns)
+			})).Return(&iam.SimulateCustomPolicyOutput{EvaluationResults: tt.results}, nil)
+
+			raw := json.RawMessage(`{"Simulation":{"ExpectAllowed":["s3:GetObject"],"ExpectDenied":["s3:DeleteBucket"],"ResourceArns":["arn:aws:s3:::b/*"]}}`)
+			err := simulatePolicy(ctx, client, raw, simulatedDoc)
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSimulatePolicy_Paginates(t *testing.T) {
+	ctx := context.Background()
+	client := &mockIAMClient{}
+	client.On("SimulateCustomPolicy", ctx, mock.MatchedBy(func(input *iam.SimulateCustomPolicyInput) bool {
+		return input.Marker == nil
+	})).Return(&iam.SimulateCustomPolicyOutput{
+		EvaluationResults: []iamtypes.EvaluationResult{evaluation("s3:GetObject", iamtypes.PolicyEvaluationDecisionTypeAllowed)},
+		IsTruncated:       true,
+		Marker:            aws.String("m1"),
+	}, nil).Once()
+	client.On("SimulateCustomPolicy", ctx, mock.MatchedBy(func(input *iam.SimulateCustomPolicyInput) bool {
+		return aws.ToString(input.Marker) == "m1"
+	})).Return(&iam.SimulateCustomPolicyOutput{
+		EvaluationResults: []iamtypes.EvaluationResult{evaluation("s3:DeleteBucket", iamtypes.PolicyEvaluationDecisionTypeAllowed)},
+	}, nil).Once()
+
+	raw := json.RawMessage(`{"Simulation":{"ExpectAllowed":["s3:GetObject"],"ExpectDenied":["s3:DeleteBucket"]}}`)
+	err := simulatePolicy(ctx, client, raw, simulatedDoc)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "s3:DeleteBucket")
+	client.AssertExpectations(t)
+}
+
+func TestSimulatePolicy_NothingDeclared(t *testing.T) {
+	client := &mockIAMClient{}
+
+	assert.NoError(t, simulatePolicy(context.Background(), client, json.RawMessage(`{"PolicyName":"p"}`), simulatedDoc))
+	client.AssertNotCalled(t, "SimulateCustomPolicy", mock.Anything, mock.Anything)
+}
+
+func TestSimulatePolicy_Contradictory(t *testing.T) {
+	raw := json.RawMessage(`{"Simulation":{"ExpectAllowed":["s3:GetObject"],"ExpectDenied":["s3:GetObject"]}}`)
+
+	err := simulatePolicy(context.Background(), &mockIAMClient{}, raw, simulatedDoc)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "both allowed and denied")
+}
+
+func TestPolicy_Create_SimulationFailsBeforePut(t *testing.T) {
+	ctx := context.Background()
+	client := &mockPolicyClient{}
+	client.On("SimulateCustomPolicy", ctx, mock.Anything).Return(&iam.SimulateCustomPolicyOutput{
+		EvaluationResults: []iamtypes.EvaluationResult{evaluation("iam:PassRole", iamtypes.PolicyEvaluationDecisionTypeAllowed)},
+	}, nil)
+
+	props, _ := json.Marshal(map[string]any{
+		"PolicyName":     "my-policy",
+		"PolicyDocument": map[string]any{"Statement": []any{map[string]any{"Effect": "Allow", "Action": "*", "Resource": "*"}}},
+		"Roles":          []string{"my-role"},
+		"Simulation":     map[string]any{"ExpectDenied": []string{"iam:PassRole"}},
+	})
+	p := &Policy{cfg: &config.Config{}}
+	_, err := p.createWithClient(ctx, client, &resource.CreateRequest{Properties: props})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "iam:PassRole on * is allowed, expected denied")
+	client.AssertNotCalled(t, "PutRolePolicy", mock.Anything, mock.Anything)
+}
+
+func TestRolePolicy_Create_ReportsSimulation(t *testing.T) {
+	ctx := context.Background()
+	client := &mockIAMClient{}
+	client.On("SimulateCustomPolicy", ctx, mock.Anything).Return(&iam.SimulateCustomPolicyOutput{
+		EvaluationResults: []iamtypes.EvaluationResult{evaluation("s3:GetObject", iamtypes.PolicyEvaluationDecisionTypeAllowed)},
+	}, nil)
+	client.On("PutRolePolicy", ctx, mock.Anything).Return(&iam.PutRolePolicyOutput{}, nil)
+
+	props := json.RawMessage(`{"PolicyName":"policy-1","RoleName":"role-1",` +
+		`"PolicyDocument":` + simulatedDoc + `,"Simulation":{"ExpectAllowed":["s3:GetObject"]}}`)
+	result, err := newTestRolePolicy().createWithClient(ctx, client, &resource.CreateRequest{Properties: props})
+
+	require.NoError(t, err)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(result.ProgressResult.ResourceProperties, &got))
+	assert.Equal(t, map[string]any{"ExpectAllowed": []any{"s3:GetObject"}}, got["Simulation"])
+	client.AssertExpectations(t)
+}
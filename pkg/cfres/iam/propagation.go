@@ -0,0 +1,109 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+// IAM is eventually consistent: a role CloudControl reports created can still
+// be unknown to other services for several seconds, so a Lambda function or
+// instance created with it fails with InvalidParameterValue. A created
+// principal only counts as propagated once IAM resolves it on
+// propagationConsecutiveChecks checks in a row, polled every
+// propagationPollInterval for at most propagationPollAttempts attempts.
+const (
+	propagationConsecutiveChecks = 3
+	propagationPollInterval      = 2 * time.Second
+	propagationPollAttempts      = 15
+)
+
+// principalGetter is the subset of the IAM API used to resolve a created
+// principal. *iam.Client satisfies it.
+type principalGetter interface {
+	GetRole(ctx context.Context, params *iam.GetRoleInput, optFns ...func(*iam.Options)) (*iam.GetRoleOutput, error)
+	GetUser(ctx context.Context, params *iam.GetUserInput, optFns ...func(*iam.Options)) (*iam.GetUserOutput, error)
+	GetInstanceProfile(ctx context.Context, params *iam.GetInstanceProfileInput, optFns ...func(*iam.Options)) (*iam.GetInstanceProfileOutput, error)
+	GetPolicy(ctx context.Context, params *iam.GetPolicyInput, optFns ...func(*iam.Options)) (*iam.GetPolicyOutput, error)
+}
+
+// resolvePrincipal looks up the resource created as nativeID, by name or, for
+// a managed policy, by ARN.
+var resolvePrincipal = map[string]func(ctx context.Context, client principalGetter, nativeID string) error{
+	roleType: func(ctx context.Context, client principalGetter, nativeID string) error {
+		_, err := client.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(nativeID)})
+		return err
+	},
+	"AWS::IAM::User": func(ctx context.Context, client principalGetter, nativeID string) error {
+		_, err := client.GetUser(ctx, &iam.GetUserInput{UserName: aws.String(nativeID)})
+		return err
+	},
+	"AWS::IAM::InstanceProfile": func(ctx context.Context, client principalGetter, nativeID string) error {
+		_, err := client.GetInstanceProfile(ctx, &iam.GetInstanceProfileInput{InstanceProfileName: aws.String(nativeID)})
+		return err
+	},
+	"AWS::IAM::ManagedPolicy": func(ctx context.Context, client principalGetter, nativeID string) error {
+		_, err := client.GetPolicy(ctx, &iam.GetPolicyInput{PolicyArn: aws.String(nativeID)})
+		return err
+	},
+}
+
+// WaitsForPropagation reports whether creates of resourceType are held until
+// IAM has propagated the new resource.
+func WaitsForPropagation(resourceType string) bool {
+	_, ok := resolvePrincipal[resourceType]
+	return ok
+}
+
+// WaitForPropagation waits until IAM consistently resolves the resource of
+// resourceType created as nativeID. It returns false if the poll budget runs
+// out first, in which case the caller reports the create as still in progress
+// and waits again on its next status check.
+func WaitForPropagation(ctx context.Context, cfg *config.Config, resourceType, nativeID string) (bool, error) {
+	awsCfg, err := cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return false, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return waitForPropagation(ctx, iam.NewFromConfig(awsCfg), resourceType, nativeID, propagationPollInterval)
+}
+
+func waitForPropagation(ctx context.Context, client principalGetter, resourceType, nativeID string, interval time.Duration) (bool, error) {
+	resolve, ok := resolvePrincipal[resourceType]
+	if !ok {
+		return true, nil
+	}
+
+	consecutive := 0
+	for attempt := 1; attempt <= propagationPollAttempts; attempt++ {
+		err := resolve(ctx, client, nativeID)
+		var nse *iamtypes.NoSuchEntityException
+		switch {
+		case err == nil:
+			consecutive++
+			if consecutive == propagationConsecutiveChecks {
+				return true, nil
+			}
+		case errors.As(err, &nse):
+			consecutive = 0
+		default:
+			return false, fmt.Errorf("resolving %s %s: %w", resourceType, nativeID, err)
+		}
+		select {
+		case <-ctx.Done():
+			return false, nil
+		case <-time.After(interval):
+		}
+	}
+	return false, nil
+}
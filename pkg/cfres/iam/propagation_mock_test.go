@@ -0,0 +1,42 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package iam
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockPrincipalGetter struct {
+	mock.Mock
+}
+
+func (m *mockPrincipalGetter) GetRole(ctx context.Context, input *iam.GetRoleInput, optFns ...func(*iam.Options)) (*iam.GetRoleOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*iam.GetRoleOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockPrincipalGetter) GetUser(ctx context.Context, input *iam.GetUserInput, optFns ...func(*iam.Options)) (*iam.GetUserOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*iam.GetUserOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockPrincipalGetter) GetInstanceProfile(ctx context.Context, input *iam.GetInstanceProfileInput, optFns ...func(*iam.Options)) (*iam.GetInstanceProfileOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*iam.GetInstanceProfileOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockPrincipalGetter) GetPolicy(ctx context.Context, input *iam.GetPolicyInput, optFns ...func(*iam.Options)) (*iam.GetPolicyOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*iam.GetPolicyOutput)
+	return out, args.Error(1)
+}
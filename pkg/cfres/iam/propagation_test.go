@@ -0,0 +1,76 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func matchRoleName(name string) any {
+	return mock.MatchedBy(func(input *iam.GetRoleInput) bool {
+		return aws.ToString(input.RoleName) == name
+	})
+}
+
+func TestWaitForPropagation_ConsecutiveResolves(t *testing.T) {
+	ctx := context.Background()
+	client := &mockPrincipalGetter{}
+	notFound := &iamtypes.NoSuchEntityException{Message: aws.String("role not found")}
+	// Found, then briefly unknown again on another IAM endpoint, then stable.
+	client.On("GetRole", ctx, matchRoleName("app")).Return(&iam.GetRoleOutput{}, nil).Once()
+	client.On("GetRole", ctx, matchRoleName("app")).Return(nil, notFound).Once()
+	client.On("GetRole", ctx, matchRoleName("app")).Return(&iam.GetRoleOutput{}, nil).Times(3)
+
+	propagated, err := waitForPropagation(ctx, client, roleType, "app", 0)
+
+	require.NoError(t, err)
+	assert.True(t, propagated)
+	client.AssertNumberOfCalls(t, "GetRole", 5)
+}
+
+func TestWaitForPropagation_BudgetExhausted(t *testing.T) {
+	ctx := context.Background()
+	client := &mockPrincipalGetter{}
+	client.On("GetUser", ctx, mock.Anything).Return(nil, &iamtypes.NoSuchEntityException{})
+
+	propagated, err := waitForPropagation(ctx, client, "AWS::IAM::User", "deployer", 0)
+
+	require.NoError(t, err)
+	assert.False(t, propagated)
+	client.AssertNumberOfCalls(t, "GetUser", propagationPollAttempts)
+}
+
+func TestWaitForPropagation_Error(t *testing.T) {
+	ctx := context.Background()
+	client := &mockPrincipalGetter{}
+	client.On("GetPolicy", ctx, mock.MatchedBy(func(input *iam.GetPolicyInput) bool {
+		return aws.ToString(input.PolicyArn) == "arn:aws:iam::123456789012:policy/p"
+	})).Return(nil, errors.New("AccessDenied"))
+
+	_, err := waitForPropagation(ctx, client, "AWS::IAM::ManagedPolicy", "arn:aws:iam::123456789012:policy/p", 0)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AccessDenied")
+}
+
+func TestWaitForPropagation_OtherTypes(t *testing.T) {
+	assert.True(t, WaitsForPropagation("AWS::IAM::InstanceProfile"))
+	assert.False(t, WaitsForPropagation("AWS::IAM::Group"))
+
+	propagated, err := waitForPropagation(context.Background(), &mockPrincipalGetter{}, "AWS::IAM::Group", "g", 0)
+	require.NoError(t, err)
+	assert.True(t, propagated)
+}
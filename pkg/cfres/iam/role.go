@@ -11,15 +11,18 @@ import (
 	"fmt"
 	"net/url"
 	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 
+	"github.com/platform-engineering-labs/formae/pkg/plugin"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ccx"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/props"
 )
 
 const roleType = "AWS::IAM::Role"
@@ -30,24 +33,33 @@ type roleCCXReader interface {
 	ReadResource(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error)
 }
 
-// roleClientInterface is the subset of the IAM API used to read a role's inline
-// policies. *iam.Client satisfies it.
+// rolePolicyGetter reads one of a role's inline policies. *iam.Client
+// satisfies it.
+type rolePolicyGetter interface {
+	GetRolePolicy(ctx context.Context, params *iam.GetRolePolicyInput, optFns ...func(*iam.Options)) (*iam.GetRolePolicyOutput, error)
+}
+
+// roleClientInterface is the subset of the IAM API used to list roles and read
+// their inline policies. *iam.Client satisfies it.
 type roleClientInterface interface {
+	ListRoles(ctx context.Context, params *iam.ListRolesInput, optFns ...func(*iam.Options)) (*iam.ListRolesOutput, error)
 	ListRolePolicies(ctx context.Context, params *iam.ListRolePoliciesInput, optFns ...func(*iam.Options)) (*iam.ListRolePoliciesOutput, error)
-	GetRolePolicy(ctx context.Context, params *iam.GetRolePolicyInput, optFns ...func(*iam.Options)) (*iam.GetRolePolicyOutput, error)
+	rolePolicyGetter
 }
 
-// Role provides a custom Read for AWS::IAM::Role that enriches the CloudControl
-// read with the role's inline Policies. CloudControl's read model for a role does
-// not return inline policies (AWS stores them separately and ccx strips $.Policies
-// via IgnoredFields), so without enrichment a role declaring inline `policies`
-// shows perpetual phantom drift (a spurious "add Policies" on every reconcile).
+// Role provides a custom Read for AWS::IAM::Role that reports the role's inline
+// Policies as IAM has them (ListRolePolicies + GetRolePolicy), so they take part
+// in drift detection like any other property: an inline policy added, changed,
+// or removed outside formae shows up as drift and is reconciled. Targets whose
+// teams manage inline policies as standalone AWS::IAM::RolePolicy resources set
+// IgnoreRoleInlinePolicies to leave them out of the read instead.
 //
-// Only Read is registered; Create/Update/Delete/List/Status fall through to the
-// generic CloudControl path in aws.go. The status path's post-success read also
-// routes through this enriched Read (aws.go's Plugin.Status delegates to
-// StatusResource with Plugin.Read), so the inline policies are present whenever the
-// role's state is persisted.
+// List goes to IAM's ListRoles so discovery can be scoped to a path (see
+// pathPrefixProperty), and Delete guards service-linked roles. Create, Update,
+// and Status fall through to the generic CloudControl path in aws.go. The status
+// path's post-success read also routes through this enriched Read (aws.go's
+// Plugin.Status delegates to StatusResource with Plugin.Read), so the inline
+// policies are present whenever the role's state is persisted.
 type Role struct {
 	cfg *config.Config
 	// ccxClient and iamClient are injectable for testing; nil means construct the
@@ -60,7 +72,7 @@ var _ prov.Provisioner = &Role{}
 
 func init() {
 	registry.Register(roleType,
-		[]resource.Operation{resource.OperationRead},
+		[]resource.Operation{resource.OperationRead, resource.OperationDelete, resource.OperationList},
 		func(cfg *config.Config) prov.Provisioner {
 			return &Role{cfg: cfg}
 		})
@@ -84,10 +96,8 @@ func (r *Role) getIAMClient(ctx context.Context) (roleClientInterface, error) {
 	return iam.NewFromConfig(awsCfg), nil
 }
 
-// Read reads the role via CloudControl and then enriches Properties.Policies with
-// the role's inline policies from IAM. The ordering is load-bearing: the
-// CloudControl read strips $.Policies (IgnoredFields), so enrichment must happen
-// after that strip — otherwise the injected policies would be removed again.
+// Read reads the role via CloudControl and then replaces Properties.Policies with
+// the role's inline policies from IAM, which are authoritative for them.
 func (r *Role) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
 	ccxClient, err := r.getCCXClient()
 	if err != nil {
@@ -119,11 +129,11 @@ func (r *Role) readWithClients(ctx context.Context, ccxClient roleCCXReader, iam
 		return nil, err
 	}
 
-	// Only enrich with inline policies when the caller manages them as part of
-	// the role. A caller that manages the role's policies out-of-band (standalone
-	// AWS::IAM::RolePolicy resources) has no Policies in its model, so embedding
-	// them here would show phantom drift and could drive a destructive reconcile.
-	if callerManagesPoliciesInline(request.PriorProperties) {
+	// A target that manages inline policies as standalone AWS::IAM::RolePolicy
+	// resources opts out: reporting them on the role would show drift against a
+	// model without Policies and could drive a destructive reconcile.
+	delete(props, "Policies")
+	if !r.cfg.IgnoreRoleInlinePolicies {
 		policies, notFound, err := listInlinePolicies(ctx, iamClient, roleName)
 		if err != nil {
 			return nil, err
@@ -142,6 +152,7 @@ func (r *Role) readWithClients(ctx context.Context, ccxClient roleCCXReader, iam
 			props["Policies"] = policies
 		}
 	}
+	policyDocumentsAsDeclared(props, request.PriorProperties)
 
 	out, err := json.Marshal(props)
 	if err != nil {
@@ -151,23 +162,34 @@ func (r *Role) readWithClients(ctx context.Context, ccxClient roleCCXReader, iam
 	return result, nil
 }
 
-// callerManagesPoliciesInline reports whether the caller's prior model manages
-// the role's inline policies as part of the role (an inline `policies` block),
-// rather than out-of-band via standalone AWS::IAM::RolePolicy resources. When
-// the prior model is unknown — empty PriorProperties, as on a create/status
-// read-back or discovery — it assumes inline so that roles declaring inline
-// policies do not show phantom drift. Only a known prior model that omits
-// Policies suppresses the enrichment.
-func callerManagesPoliciesInline(priorProperties json.RawMessage) bool {
-	if len(priorProperties) == 0 {
-		return true
-	}
+// policyDocumentsAsDeclared replaces the role's trust policy and inline policy
+// documents with the prior model's wherever the two are equivalent, so a
+// document IAM merely reformatted shows no drift.
+// Inline policies are matched to the prior model's by name.
+func policyDocumentsAsDeclared(properties map[string]any, priorProperties json.RawMessage) {
 	var prior map[string]any
-	if err := json.Unmarshal(priorProperties, &prior); err != nil {
-		return true
+	if len(priorProperties) > 0 {
+		_ = json.Unmarshal(priorProperties, &prior)
+	}
+
+	if doc, ok := properties["AssumeRolePolicyDocument"]; ok {
+		properties["AssumeRolePolicyDocument"] = props.PolicyDocumentAsDeclared(doc, prior["AssumeRolePolicyDocument"])
+	}
+
+	declared := make(map[string]any)
+	priorPolicies, _ := prior["Policies"].([]any)
+	for _, p := range priorPolicies {
+		if pm, ok := p.(map[string]any); ok {
+			if name, ok := pm["PolicyName"].(string); ok {
+				declared[name] = pm["PolicyDocument"]
+			}
+		}
+	}
+	policies, _ := properties["Policies"].([]map[string]any)
+	for _, p := range policies {
+		name, _ := p["PolicyName"].(string)
+		p["PolicyDocument"] = props.PolicyDocumentAsDeclared(p["PolicyDocument"], declared[name])
 	}
-	_, ok := prior["Policies"]
-	return ok
 }
 
 // roleNameForRead resolves the role name to query IAM with. It prefers the
@@ -241,7 +263,7 @@ func listInlinePolicies(ctx context.Context, client roleClientInterface, roleNam
 // parsed to a structured value so the comparison is on parsed JSON, not strings.
 // Decode and parse errors are surfaced rather than swallowed: a nil document
 // injected into actual state would be destructive drift, not a benign miss.
-func getInlinePolicyDocument(ctx context.Context, client roleClientInterface, roleName, policyName string) (any, error) {
+func getInlinePolicyDocument(ctx context.Context, client rolePolicyGetter, roleName, policyName string) (any, error) {
 	out, err := client.GetRolePolicy(ctx, &iam.GetRolePolicyInput{
 		RoleName:   &roleName,
 		PolicyName: &policyName,
@@ -271,8 +293,92 @@ func getInlinePolicyDocument(ctx context.Context, client roleClientInterface, ro
 	return doc, nil
 }
 
-// The remaining Provisioner methods are unreachable: only Read is registered, so
-// Create/Update/Delete/List/Status always route to CloudControl in aws.go.
+// serviceLinkedRolePrefix starts the name of every service-linked role. AWS
+// services create these roles and only they can delete them.
+const serviceLinkedRolePrefix = "AWSServiceRoleFor"
+
+// IsServiceLinkedRole reports whether roleName names a service-linked role.
+func IsServiceLinkedRole(roleName string) bool {
+	return strings.HasPrefix(roleName, serviceLinkedRolePrefix)
+}
+
+// roleCCXDeleter is the CloudControl delete a role's Delete hands off to.
+// *ccx.Client satisfies it.
+type roleCCXDeleter interface {
+	DeleteResource(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error)
+}
+
+// Delete deletes the role through CloudControl, except for a service-linked
+// role, which IAM refuses to delete with an error that doesn't say why. Its
+// delete fails as an invalid request instead, so it isn't retried, or with
+// SkipServiceLinkedRoleDeletes is reported done and the role left in place.
+func (r *Role) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	client, err := ccx.NewClient(r.cfg)
+	if err != nil {
+		return nil, err
+	}
+	return r.deleteWithClient(ctx, client, request)
+}
+
+func (r *Role) deleteWithClient(ctx context.Context, client roleCCXDeleter, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	if !IsServiceLinkedRole(request.NativeID) {
+		return client.DeleteResource(ctx, request)
+	}
+
+	if r.cfg.SkipServiceLinkedRoleDeletes {
+		plugin.LoggerFromContext(ctx).Warn("Not deleting service-linked role; the service that created it deletes it",
+			"roleName", request.NativeID)
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusFailure,
+			NativeID:        request.NativeID,
+			ErrorCode:       resource.OperationErrorCodeInvalidRequest,
+			StatusMessage: fmt.Sprintf("%s is a service-linked role, which only the AWS service that created it can delete; "+
+				"remove it from formae's management instead, or set skipServiceLinkedRoleDeletes", request.NativeID),
+		},
+	}, nil
+}
+
+// List lists a page of roles with IAM's ListRoles, which honours a PathPrefix
+// where CloudControl would page through every role in the account.
+func (r *Role) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	client, err := r.getIAMClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.listWithClient(ctx, client, request)
+}
+
+// listWithClient lists a page of roles. A role's NativeID is its name.
+func (r *Role) listWithClient(ctx context.Context, client roleClientInterface, request *resource.ListRequest) (*resource.ListResult, error) {
+	out, err := client.ListRoles(ctx, &iam.ListRolesInput{
+		PathPrefix: listPathPrefix(request),
+		MaxItems:   listPageSize(request),
+		Marker:     listMarker(request),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing roles: %w", err)
+	}
+
+	nativeIDs := make([]string, 0, len(out.Roles))
+	for _, role := range out.Roles {
+		nativeIDs = append(nativeIDs, *role.RoleName)
+	}
+	return listResult(nativeIDs, out.IsTruncated, out.Marker), nil
+}
+
+// The remaining Provisioner methods are unreachable: only Read, Delete, and
+// List are registered, so Create/Update/Status always route to CloudControl in
+// aws.go.
 func (r *Role) Create(_ context.Context, _ *resource.CreateRequest) (*resource.CreateResult, error) {
 	return nil, fmt.Errorf("create not implemented - cloudcontrol handles this operation")
 }
@@ -281,14 +387,6 @@ func (r *Role) Update(_ context.Context, _ *resource.UpdateRequest) (*resource.U
 	return nil, fmt.Errorf("update not implemented - cloudcontrol handles this operation")
 }
 
-func (r *Role) Delete(_ context.Context, _ *resource.DeleteRequest) (*resource.DeleteResult, error) {
-	return nil, fmt.Errorf("delete not implemented - cloudcontrol handles this operation")
-}
-
 func (r *Role) Status(_ context.Context, _ *resource.StatusRequest) (*resource.StatusResult, error) {
 	return nil, fmt.Errorf("status not implemented - cloudcontrol handles this operation")
 }
-
-func (r *Role) List(_ context.Context, _ *resource.ListRequest) (*resource.ListResult, error) {
-	return nil, fmt.Errorf("list not implemented - cloudcontrol handles this operation")
-}
@@ -16,12 +16,20 @@ import (
 )
 
 // mockRoleClient mocks the IAM calls the custom Role Read uses to enrich inline
-// policies. ListRolePolicies is shared in shape with mockIAMClient but this mock
-// also carries GetRolePolicy.
+// policies, and the custom Role List. ListRolePolicies is shared in shape with
+// mockIAMClient but this mock also carries GetRolePolicy.
 type mockRoleClient struct {
 	mock.Mock
 }
 
+func (m *mockRoleClient) ListRoles(ctx context.Context, input *iam.ListRolesInput, optFns ...func(*iam.Options)) (*iam.ListRolesOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*iam.ListRolesOutput), args.Error(1)
+}
+
 func (m *mockRoleClient) ListRolePolicies(ctx context.Context, input *iam.ListRolePoliciesInput, optFns ...func(*iam.Options)) (*iam.ListRolePoliciesOutput, error) {
 	args := m.Called(ctx, input)
 	if args.Get(0) == nil {
@@ -58,3 +66,17 @@ func matchGetRolePolicy(roleName, policyName string) any {
 			input.PolicyName != nil && *input.PolicyName == policyName
 	})
 }
+
+// mockRoleCCXDeleter mocks the CloudControl delete a role's Delete hands off
+// to.
+type mockRoleCCXDeleter struct {
+	mock.Mock
+}
+
+func (m *mockRoleCCXDeleter) DeleteResource(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	args := m.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*resource.DeleteResult), args.Error(1)
+}
@@ -31,8 +31,8 @@ func newRoleWithMocks(ccx *mockRoleCCXReader, iamc *mockRoleClient) *Role {
 	return &Role{cfg: &config.Config{Region: "us-east-1"}, ccxClient: ccx, iamClient: iamc}
 }
 
-// rolePropsJSON returns a CloudControl-style read result for a role, with the
-// inline Policies already stripped (as ccx.ReadResource does via IgnoredFields).
+// rolePropsJSON returns a CloudControl-style read result for a role, without
+// inline Policies.
 func rolePropsJSON(t *testing.T, roleName string) string {
 	t.Helper()
 	b, err := json.Marshal(map[string]any{
@@ -178,11 +178,9 @@ func readReqWithPrior(nativeID string, prior map[string]any) *resource.ReadReque
 	return req
 }
 
-// When the caller's prior model manages the role but declares no inline
-// Policies (e.g. it manages them as standalone AWS::IAM::RolePolicy resources),
-// Read must not embed the role's inline policies — doing so would show phantom
-// drift and could drive a destructive reconcile.
-func TestRole_Read_SuppressesPolicies_WhenPriorManagesThemStandalone(t *testing.T) {
+// An inline policy added outside formae to a role whose model declares none is
+// reported, so it shows up as drift.
+func TestRole_Read_ReportsOutOfBandPolicies(t *testing.T) {
 	ccx := &mockRoleCCXReader{}
 	ccx.On("ReadResource", mock.Anything, mock.Anything).Return(&resource.ReadResult{
 		ResourceType: roleType, Properties: rolePropsJSON(t, testRoleName),
@@ -190,19 +188,44 @@ func TestRole_Read_SuppressesPolicies_WhenPriorManagesThemStandalone(t *testing.
 
 	iamc := &mockRoleClient{}
 	iamc.On("ListRolePolicies", mock.Anything, matchRoleAndNoMarker(testRoleName)).Return(
-		&iam.ListRolePoliciesOutput{PolicyNames: []string{"logs-write"}}, nil)
+		&iam.ListRolePoliciesOutput{PolicyNames: []string{"added-by-hand"}}, nil)
+	iamc.On("GetRolePolicy", mock.Anything, matchGetRolePolicy(testRoleName, "added-by-hand")).Return(
+		&iam.GetRolePolicyOutput{
+			PolicyName:     aws.String("added-by-hand"),
+			PolicyDocument: escapedDoc(t, `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"*","Resource":"*"}]}`),
+		}, nil)
 
 	r := newRoleWithMocks(ccx, iamc)
-	prior := map[string]any{"RoleName": testRoleName, "Description": "managed elsewhere"}
+	prior := map[string]any{"RoleName": testRoleName}
 	res, err := r.Read(context.Background(), readReqWithPrior(testRoleName, prior))
 
+	require.NoError(t, err)
+	policies := readEnrichedPolicies(t, res.Properties)
+	require.Len(t, policies, 1)
+	assert.Equal(t, "added-by-hand", policies[0]["PolicyName"])
+}
+
+// With IgnoreRoleInlinePolicies, a target managing inline policies as
+// standalone AWS::IAM::RolePolicy resources gets none on the role, including
+// any CloudControl itself returns.
+func TestRole_Read_IgnoreRoleInlinePolicies(t *testing.T) {
+	ccx := &mockRoleCCXReader{}
+	ccx.On("ReadResource", mock.Anything, mock.Anything).Return(&resource.ReadResult{
+		ResourceType: roleType,
+		Properties:   `{"RoleName":"` + testRoleName + `","Policies":[{"PolicyName":"logs-write","PolicyDocument":{}}]}`,
+	}, nil)
+
+	iamc := &mockRoleClient{}
+	r := newRoleWithMocks(ccx, iamc)
+	r.cfg.IgnoreRoleInlinePolicies = true
+	res, err := r.Read(context.Background(), readReq(testRoleName))
+
 	require.NoError(t, err)
 	require.Empty(t, res.ErrorCode)
 	var props map[string]any
 	require.NoError(t, json.Unmarshal([]byte(res.Properties), &props))
-	assert.NotContains(t, props, "Policies",
-		"prior model manages the role without inline Policies, so Read must not embed them")
-	iamc.AssertNotCalled(t, "GetRolePolicy", mock.Anything, mock.Anything)
+	assert.NotContains(t, props, "Policies")
+	iamc.AssertNotCalled(t, "ListRolePolicies", mock.Anything, mock.Anything)
 }
 
 // When the caller's prior model declares inline Policies, Read must still embed
@@ -285,6 +308,42 @@ func TestRole_Read_ScalarActionRoundTrips(t *testing.T) {
 	assert.Equal(t, "s3:GetObject", stmt["Action"], "scalar Action must survive as a scalar")
 }
 
+func TestRole_Read_ReportsEquivalentDocumentsAsDeclared(t *testing.T) {
+	trust := map[string]any{"Statement": map[string]any{
+		"Effect": "Allow", "Action": "sts:AssumeRole", "Principal": map[string]any{"Service": "lambda.amazonaws.com"},
+	}}
+	inline := map[string]any{"Statement": map[string]any{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "*"}}
+
+	ccx := &mockRoleCCXReader{}
+	ccx.On("ReadResource", mock.Anything, mock.Anything).Return(&resource.ReadResult{
+		ResourceType: roleType,
+		Properties: `{"RoleName":"` + testRoleName + `","AssumeRolePolicyDocument":{"Statement":[` +
+			`{"Effect":"Allow","Action":["sts:AssumeRole"],"Principal":{"Service":["lambda.amazonaws.com"]}}]}}`,
+	}, nil)
+	iamc := &mockRoleClient{}
+	iamc.On("ListRolePolicies", mock.Anything, matchRoleAndNoMarker(testRoleName)).Return(
+		&iam.ListRolePoliciesOutput{PolicyNames: []string{"p"}}, nil)
+	iamc.On("GetRolePolicy", mock.Anything, matchGetRolePolicy(testRoleName, "p")).Return(
+		&iam.GetRolePolicyOutput{PolicyName: aws.String("p"),
+			PolicyDocument: escapedDoc(t, `{"Statement":[{"Resource":["*"],"Action":["s3:GetObject"],"Effect":"Allow"}]}`)}, nil)
+
+	r := newRoleWithMocks(ccx, iamc)
+	prior := map[string]any{
+		"RoleName":                 testRoleName,
+		"AssumeRolePolicyDocument": trust,
+		"Policies":                 []map[string]any{{"PolicyName": "p", "PolicyDocument": inline}},
+	}
+	res, err := r.Read(context.Background(), readReqWithPrior(testRoleName, prior))
+
+	require.NoError(t, err)
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(res.Properties), &props))
+	assert.Equal(t, trust, props["AssumeRolePolicyDocument"])
+	policies := readEnrichedPolicies(t, res.Properties)
+	require.Len(t, policies, 1)
+	assert.Equal(t, inline, policies[0]["PolicyDocument"])
+}
+
 func TestRole_Read_PunctuatedPolicyName(t *testing.T) {
 	ccx := &mockRoleCCXReader{}
 	ccx.On("ReadResource", mock.Anything, mock.Anything).Return(&resource.ReadResult{
@@ -486,3 +545,46 @@ func TestRole_IsRegisteredForRead(t *testing.T) {
 		t.Errorf("expected registry.HasProvisioner(%q, Read) == true; init() did not register the custom Role Read", roleType)
 	}
 }
+
+func TestRole_Delete_ServiceLinkedRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		skip       bool
+		wantStatus resource.OperationStatus
+		wantCode   resource.OperationErrorCode
+	}{
+		{"fails", false, resource.OperationStatusFailure, resource.OperationErrorCodeInvalidRequest},
+		{"skipped", true, resource.OperationStatusSuccess, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockRoleCCXDeleter{}
+			r := &Role{cfg: &config.Config{SkipServiceLinkedRoleDeletes: tt.skip}}
+
+			res, err := r.deleteWithClient(context.Background(), client, &resource.DeleteRequest{
+				NativeID: "AWSServiceRoleForAutoScaling", ResourceType: roleType,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, res.ProgressResult.OperationStatus)
+			assert.Equal(t, tt.wantCode, res.ProgressResult.ErrorCode)
+			client.AssertNotCalled(t, "DeleteResource", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRole_Delete_DelegatesToCloudControl(t *testing.T) {
+	ctx := context.Background()
+	request := &resource.DeleteRequest{NativeID: testRoleName, ResourceType: roleType}
+	client := &mockRoleCCXDeleter{}
+	client.On("DeleteResource", ctx, request).Return(&resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{OperationStatus: resource.OperationStatusInProgress, RequestID: "req-1"},
+	}, nil)
+
+	r := &Role{cfg: &config.Config{}}
+	res, err := r.deleteWithClient(ctx, client, request)
+
+	require.NoError(t, err)
+	assert.Equal(t, "req-1", res.ProgressResult.RequestID)
+	client.AssertExpectations(t)
+}
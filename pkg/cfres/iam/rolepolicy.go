@@ -6,9 +6,13 @@ package iam
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 
@@ -16,33 +20,274 @@ import (
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/utils"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
 )
 
+// AWS::IAM::RolePolicy is driven through PutRolePolicy / GetRolePolicy /
+// DeleteRolePolicy directly: the CloudControl handler for it is slow and
+// frequently throttled. The NativeID is policyName|roleName, as CloudControl
+// identifies it, so resources created through either path are the same.
+const rolePolicyType = "AWS::IAM::RolePolicy"
+
 type RolePolicy struct {
 	cfg *config.Config
+
+	// putAttempts bounds the retries of a PutRolePolicy that finds no role,
+	// which IAM's eventual consistency causes for a role created in the same
+	// apply; backoff is the wait between them and sleep is injectable for
+	// tests.
+	putAttempts int
+	backoff     time.Duration
+	sleep       func(time.Duration)
 }
 
 type iamClientInterface interface {
 	ListRolePolicies(ctx context.Context, params *iam.ListRolePoliciesInput, optFns ...func(*iam.Options)) (*iam.ListRolePoliciesOutput, error)
+	PutRolePolicy(ctx context.Context, params *iam.PutRolePolicyInput, optFns ...func(*iam.Options)) (*iam.PutRolePolicyOutput, error)
+	DeleteRolePolicy(ctx context.Context, params *iam.DeleteRolePolicyInput, optFns ...func(*iam.Options)) (*iam.DeleteRolePolicyOutput, error)
+	rolePolicyGetter
+	policySimulator
 }
 
 var _ prov.Provisioner = &RolePolicy{}
 
 func init() {
-	registry.Register("AWS::IAM::RolePolicy", []resource.Operation{resource.OperationList}, func(cfg *config.Config) prov.Provisioner {
-		return &RolePolicy{cfg: cfg}
-	})
+	registry.Register(rolePolicyType,
+		[]resource.Operation{
+			resource.OperationCreate,
+			resource.OperationRead,
+			resource.OperationUpdate,
+			resource.OperationDelete,
+			resource.OperationList,
+		},
+		func(cfg *config.Config) prov.Provisioner {
+			return &RolePolicy{
+				cfg:         cfg,
+				putAttempts: 5,
+				backoff:     2 * time.Second,
+				sleep:       time.Sleep,
+			}
+		})
 }
 
-func (r *RolePolicy) List(ctx context.Context, request *resource.ListRequest) (result *resource.ListResult, err error) {
+// parseRolePolicyNativeID parses the composite NativeID policyName|roleName.
+// Neither IAM policy names nor role names can contain "|".
+func parseRolePolicyNativeID(nativeID string) (policyName, roleName string, err error) {
+	parts := strings.SplitN(nativeID, "|", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid NativeID format: expected policyName|roleName, got: %q", nativeID)
+	}
+	return parts[0], parts[1], nil
+}
+
+// rolePolicyProperties extracts the policy's name, role, and document from
+// the model. The document is normalized to parsed JSON, whether the model
+// gives it as an object or as a JSON string, so the properties reported
+// compare equal to what a read returns.
+func rolePolicyProperties(raw json.RawMessage) (policyName, roleName string, doc any, err error) {
+	var props map[string]any
+	if err := json.Unmarshal(raw, &props); err != nil {
+		return "", "", nil, fmt.Errorf("parsing properties: %w", err)
+	}
+	policyName, err = utils.GetStringProperty(props, "PolicyName")
+	if err != nil {
+		return "", "", nil, fmt.Errorf("invalid PolicyName: %w", err)
+	}
+	roleName, err = utils.GetStringProperty(props, "RoleName")
+	if err != nil {
+		return "", "", nil, fmt.Errorf("invalid RoleName: %w", err)
+	}
+	doc, ok := props["PolicyDocument"]
+	if !ok || doc == nil {
+		return "", "", nil, fmt.Errorf("PolicyDocument is required")
+	}
+	if s, ok := doc.(string); ok {
+		if err := json.Unmarshal([]byte(s), &doc); err != nil {
+			return "", "", nil, fmt.Errorf("parsing PolicyDocument: %w", err)
+		}
+	}
+	return policyName, roleName, doc, nil
+}
+
+// putRolePolicy writes the policy and returns the properties it now has.
+// PutRolePolicy replaces an existing policy of the same name, so it serves
+// both Create and Update.
+func (r *RolePolicy) putRolePolicy(ctx context.Context, client iamClientInterface, raw json.RawMessage) (string, json.RawMessage, error) {
+	policyName, roleName, doc, err := rolePolicyProperties(raw)
+	if err != nil {
+		return "", nil, err
+	}
+	docJSON, err := json.Marshal(doc)
+	if err != nil {
+		return "", nil, fmt.Errorf("marshalling policy document: %w", err)
+	}
+	if err := simulatePolicy(ctx, client, raw, string(docJSON)); err != nil {
+		return "", nil, err
+	}
+
+	var putErr error
+	for attempt := 0; attempt < r.putAttempts; attempt++ {
+		_, putErr = client.PutRolePolicy(ctx, &iam.PutRolePolicyInput{
+			RoleName:       aws.String(roleName),
+			PolicyName:     aws.String(policyName),
+			PolicyDocument: aws.String(string(docJSON)),
+		})
+		if putErr == nil || !isNoSuchEntity(putErr) {
+			break
+		}
+		if attempt < r.putAttempts-1 {
+			r.sleep(r.backoff)
+		}
+	}
+	if putErr != nil {
+		return "", nil, fmt.Errorf("putting inline policy %s on role %s: %w", policyName, roleName, putErr)
+	}
+
+	props, err := json.Marshal(withSimulation(map[string]any{
+		"PolicyName":     policyName,
+		"RoleName":       roleName,
+		"PolicyDocument": doc,
+	}, raw))
+	if err != nil {
+		return "", nil, fmt.Errorf("marshaling properties: %w", err)
+	}
+	return fmt.Sprintf("%s|%s", policyName, roleName), props, nil
+}
+
+func (r *RolePolicy) client(ctx context.Context) (*iam.Client, error) {
 	cfg, err := r.cfg.ToAwsConfig(ctx)
 	if err != nil {
 		plugin.LoggerFromContext(ctx).Error("Failed to load AWS config", "error", err)
 		return nil, fmt.Errorf("unable to load aws config: %w", err)
 	}
-	client := iam.NewFromConfig(cfg)
+	return iam.NewFromConfig(cfg), nil
+}
+
+func (r *RolePolicy) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	client, err := r.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.createWithClient(ctx, client, request)
+}
+
+func (r *RolePolicy) createWithClient(ctx context.Context, client iamClientInterface, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	nativeID, props, err := r.putRolePolicy(ctx, client, request.Properties)
+	if err != nil {
+		return nil, err
+	}
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:          resource.OperationCreate,
+			OperationStatus:    resource.OperationStatusSuccess,
+			NativeID:           nativeID,
+			ResourceProperties: props,
+		},
+	}, nil
+}
+
+func (r *RolePolicy) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	client, err := r.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.updateWithClient(ctx, client, request)
+}
+
+// updateWithClient rewrites the policy document; PolicyName and RoleName are
+// createOnly, so a change to either is a replace rather than an update.
+func (r *RolePolicy) updateWithClient(ctx context.Context, client iamClientInterface, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	nativeID, props, err := r.putRolePolicy(ctx, client, request.DesiredProperties)
+	if err != nil {
+		return nil, err
+	}
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:          resource.OperationUpdate,
+			OperationStatus:    resource.OperationStatusSuccess,
+			NativeID:           nativeID,
+			ResourceProperties: props,
+		},
+	}, nil
+}
 
+func (r *RolePolicy) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	client, err := r.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.readWithClient(ctx, client, request)
+}
+
+func (r *RolePolicy) readWithClient(ctx context.Context, client iamClientInterface, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	policyName, roleName, err := parseRolePolicyNativeID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := getInlinePolicyDocument(ctx, client, roleName, policyName)
+	if err != nil {
+		// The role or the policy is gone.
+		if isNoSuchEntity(err) {
+			return &resource.ReadResult{
+				ResourceType: rolePolicyType,
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, err
+	}
+
+	props, err := json.Marshal(withSimulation(map[string]any{
+		"PolicyName":     policyName,
+		"RoleName":       roleName,
+		"PolicyDocument": policyDocumentAsDeclared(doc, request.PriorProperties),
+	}, request.PriorProperties))
+	if err != nil {
+		return nil, fmt.Errorf("marshaling properties: %w", err)
+	}
+	return &resource.ReadResult{
+		ResourceType: rolePolicyType,
+		Properties:   string(props),
+	}, nil
+}
+
+func (r *RolePolicy) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	client, err := r.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.deleteWithClient(ctx, client, request)
+}
+
+func (r *RolePolicy) deleteWithClient(ctx context.Context, client iamClientInterface, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	policyName, roleName, err := parseRolePolicyNativeID(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Idempotent: a missing role or policy means the policy is already gone.
+	if _, err := client.DeleteRolePolicy(ctx, &iam.DeleteRolePolicyInput{
+		RoleName:   aws.String(roleName),
+		PolicyName: aws.String(policyName),
+	}); err != nil && !isNoSuchEntity(err) {
+		return nil, fmt.Errorf("deleting inline policy %s from role %s: %w", policyName, roleName, err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (r *RolePolicy) List(ctx context.Context, request *resource.ListRequest) (result *resource.ListResult, err error) {
+	client, err := r.client(ctx)
+	if err != nil {
+		return nil, err
+	}
 	return r.listWithClient(ctx, client, request)
 }
 
@@ -99,22 +344,8 @@ func (r *RolePolicy) listWithClient(ctx context.Context, client iamClientInterfa
 	}, nil
 }
 
-func (r *RolePolicy) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
-	return nil, fmt.Errorf("create not implemented - cloudcontrol handles this operation")
-}
-
-func (r *RolePolicy) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
-	return nil, fmt.Errorf("update not implemented - cloudcontrol handles this operation")
-}
-
-func (r *RolePolicy) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
-	return nil, fmt.Errorf("delete not implemented - cloudcontrol handles this operation")
-}
-
-func (r *RolePolicy) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
-	return nil, fmt.Errorf("status not implemented - cloudcontrol handles this operation")
-}
-
-func (r *RolePolicy) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
-	return nil, fmt.Errorf("read not implemented - cloudcontrol handles this operation")
+// Status is not registered: the IAM calls are synchronous, so every operation
+// returns success directly with no status polling.
+func (r *RolePolicy) Status(_ context.Context, _ *resource.StatusRequest) (*resource.StatusResult, error) {
+	return nil, fmt.Errorf("status check is not implemented for %s", rolePolicyType)
 }
@@ -34,3 +34,35 @@ func matchRoleAndMarker(roleName, marker string) any {
 			input.Marker != nil && *input.Marker == marker
 	})
 }
+
+func (m *mockIAMClient) GetRolePolicy(ctx context.Context, input *iam.GetRolePolicyInput, optFns ...func(*iam.Options)) (*iam.GetRolePolicyOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*iam.GetRolePolicyOutput), args.Error(1)
+}
+
+func (m *mockIAMClient) PutRolePolicy(ctx context.Context, input *iam.PutRolePolicyInput, optFns ...func(*iam.Options)) (*iam.PutRolePolicyOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*iam.PutRolePolicyOutput), args.Error(1)
+}
+
+func (m *mockIAMClient) DeleteRolePolicy(ctx context.Context, input *iam.DeleteRolePolicyInput, optFns ...func(*iam.Options)) (*iam.DeleteRolePolicyOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*iam.DeleteRolePolicyOutput), args.Error(1)
+}
+
+func (m *mockIAMClient) SimulateCustomPolicy(ctx context.Context, input *iam.SimulateCustomPolicyInput, optFns ...func(*iam.Options)) (*iam.SimulateCustomPolicyOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*iam.SimulateCustomPolicyOutput), args.Error(1)
+}
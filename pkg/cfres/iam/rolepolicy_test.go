@@ -8,11 +8,16 @@ package iam
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
@@ -159,6 +164,190 @@ func TestRolePolicy_List_NoPermissions(t *testing.T) {
 	client.AssertExpectations(t)
 }
 
+func newTestRolePolicy() *RolePolicy {
+	return &RolePolicy{cfg: &config.Config{}, putAttempts: 3, sleep: func(time.Duration) {}}
+}
+
+func matchPutRolePolicy(roleName, policyName, doc string) any {
+	return mock.MatchedBy(func(input *iam.PutRolePolicyInput) bool {
+		return *input.RoleName == roleName && *input.PolicyName == policyName && *input.PolicyDocument == doc
+	})
+}
+
+func TestParseRolePolicyNativeID(t *testing.T) {
+	policyName, roleName, err := parseRolePolicyNativeID("policy-1|role-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "policy-1", policyName)
+	assert.Equal(t, "role-1", roleName)
+
+	for _, invalid := range []string{"", "|", "policy-1", "policy-1|", "|role-1"} {
+		_, _, err := parseRolePolicyNativeID(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestRolePolicy_Create_PutsPolicy(t *testing.T) {
+	ctx := context.Background()
+	client := &mockIAMClient{}
+	client.On("PutRolePolicy", ctx, matchPutRolePolicy("role-1", "policy-1", `{"Version":"2012-10-17"}`)).
+		Return(&iam.PutRolePolicyOutput{}, nil)
+
+	result, err := newTestRolePolicy().createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"PolicyName":"policy-1","RoleName":"role-1","PolicyDocument":{"Version":"2012-10-17"}}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	assert.Equal(t, "policy-1|role-1", result.ProgressResult.NativeID)
+	assert.JSONEq(t, `{"PolicyName":"policy-1","RoleName":"role-1","PolicyDocument":{"Version":"2012-10-17"}}`,
+		string(result.ProgressResult.ResourceProperties))
+	client.AssertExpectations(t)
+}
+
+func TestRolePolicy_Create_NormalizesStringDocument(t *testing.T) {
+	ctx := context.Background()
+	client := &mockIAMClient{}
+	client.On("PutRolePolicy", ctx, matchPutRolePolicy("role-1", "policy-1", `{"Version":"2012-10-17"}`)).
+		Return(&iam.PutRolePolicyOutput{}, nil)
+
+	result, err := newTestRolePolicy().createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"PolicyName":"policy-1","RoleName":"role-1","PolicyDocument":"{ \"Version\": \"2012-10-17\" }"}`),
+	})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"PolicyName":"policy-1","RoleName":"role-1","PolicyDocument":{"Version":"2012-10-17"}}`,
+		string(result.ProgressResult.ResourceProperties))
+}
+
+func TestRolePolicy_Create_RetriesRoleNotYetVisible(t *testing.T) {
+	ctx := context.Background()
+	client := &mockIAMClient{}
+	noSuchRole := fmt.Errorf("wrapped: %w", &iamtypes.NoSuchEntityException{Message: stringPtr("not found")})
+	client.On("PutRolePolicy", ctx, mock.Anything).Return(nil, noSuchRole).Once()
+	client.On("PutRolePolicy", ctx, mock.Anything).Return(&iam.PutRolePolicyOutput{}, nil).Once()
+
+	result, err := newTestRolePolicy().createWithClient(ctx, client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"PolicyName":"policy-1","RoleName":"role-1","PolicyDocument":{}}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertNumberOfCalls(t, "PutRolePolicy", 2)
+}
+
+func TestRolePolicy_Create_MissingDocument(t *testing.T) {
+	client := &mockIAMClient{}
+
+	_, err := newTestRolePolicy().createWithClient(context.Background(), client, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"PolicyName":"policy-1","RoleName":"role-1"}`),
+	})
+
+	assert.ErrorContains(t, err, "PolicyDocument is required")
+	client.AssertNotCalled(t, "PutRolePolicy", mock.Anything, mock.Anything)
+}
+
+func TestRolePolicy_Update_PutsDesiredDocument(t *testing.T) {
+	ctx := context.Background()
+	client := &mockIAMClient{}
+	client.On("PutRolePolicy", ctx, matchPutRolePolicy("role-1", "policy-1", `{"Statement":[]}`)).
+		Return(&iam.PutRolePolicyOutput{}, nil)
+
+	result, err := newTestRolePolicy().updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "policy-1|role-1",
+		DesiredProperties: json.RawMessage(`{"PolicyName":"policy-1","RoleName":"role-1","PolicyDocument":{"Statement":[]}}`),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationUpdate, result.ProgressResult.Operation)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertExpectations(t)
+}
+
+func TestRolePolicy_Read_DecodesDocument(t *testing.T) {
+	ctx := context.Background()
+	client := &mockIAMClient{}
+	client.On("GetRolePolicy", ctx, matchGetRolePolicy("role-1", "policy-1")).Return(&iam.GetRolePolicyOutput{
+		PolicyDocument: stringPtr("%7B%22Condition%22%3A%22a+b%22%7D"),
+	}, nil)
+
+	result, err := newTestRolePolicy().readWithClient(ctx, client, &resource.ReadRequest{NativeID: "policy-1|role-1"})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"PolicyName":"policy-1","RoleName":"role-1","PolicyDocument":{"Condition":"a+b"}}`, result.Properties)
+}
+
+func TestRolePolicy_Read_ReportsEquivalentDocumentAsDeclared(t *testing.T) {
+	tests := []struct {
+		name string
+		live string
+		want string
+	}{
+		{"reformatted", `{"Statement":[{"Resource":["*"],"Action":["s3:GetObject","s3:ListBucket"],"Effect":"Allow"}]}`,
+			`{"Statement":{"Effect":"Allow","Action":["s3:ListBucket","s3:GetObject"],"Resource":"*"}}`},
+		{"drifted", `{"Statement":[{"Resource":["*"],"Action":["s3:GetObject"],"Effect":"Allow"}]}`,
+			`{"Statement":[{"Resource":["*"],"Action":["s3:GetObject"],"Effect":"Allow"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &mockIAMClient{}
+			client.On("GetRolePolicy", ctx, matchGetRolePolicy("role-1", "policy-1")).Return(&iam.GetRolePolicyOutput{
+				PolicyDocument: stringPtr(url.PathEscape(tt.live)),
+			}, nil)
+			prior := json.RawMessage(`{"PolicyName":"policy-1","RoleName":"role-1",` +
+				`"PolicyDocument":{"Statement":{"Effect":"Allow","Action":["s3:ListBucket","s3:GetObject"],"Resource":"*"}}}`)
+
+			result, err := newTestRolePolicy().readWithClient(ctx, client, &resource.ReadRequest{
+				NativeID:        "policy-1|role-1",
+				PriorProperties: prior,
+			})
+
+			assert.NoError(t, err)
+			var props map[string]any
+			_ = json.Unmarshal([]byte(result.Properties), &props)
+			got, _ := json.Marshal(props["PolicyDocument"])
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestRolePolicy_Read_NotFound(t *testing.T) {
+	ctx := context.Background()
+	client := &mockIAMClient{}
+	client.On("GetRolePolicy", ctx, mock.Anything).Return(nil,
+		fmt.Errorf("wrapped: %w", &iamtypes.NoSuchEntityException{Message: stringPtr("not found")}))
+
+	result, err := newTestRolePolicy().readWithClient(ctx, client, &resource.ReadRequest{NativeID: "policy-1|role-1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+}
+
+func TestRolePolicy_Delete(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"deleted", nil},
+		{"already gone", &iamtypes.NoSuchEntityException{Message: stringPtr("not found")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &mockIAMClient{}
+			client.On("DeleteRolePolicy", ctx, mock.MatchedBy(func(input *iam.DeleteRolePolicyInput) bool {
+				return *input.RoleName == "role-1" && *input.PolicyName == "policy-1"
+			})).Return(&iam.DeleteRolePolicyOutput{}, tt.err)
+
+			result, err := newTestRolePolicy().deleteWithClient(ctx, client, &resource.DeleteRequest{NativeID: "policy-1|role-1"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+			client.AssertExpectations(t)
+		})
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
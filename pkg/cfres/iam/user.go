@@ -0,0 +1,85 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+type userClientInterface interface {
+	ListUsers(ctx context.Context, params *iam.ListUsersInput, optFns ...func(*iam.Options)) (*iam.ListUsersOutput, error)
+}
+
+// User lists AWS::IAM::User resources with IAM's ListUsers, which can be
+// scoped to a path (see pathPrefixProperty). Only List is registered; every
+// other operation goes through CloudControl in aws.go.
+type User struct {
+	cfg *config.Config
+}
+
+var _ prov.Provisioner = &User{}
+
+func init() {
+	registry.Register("AWS::IAM::User",
+		[]resource.Operation{resource.OperationList},
+		func(cfg *config.Config) prov.Provisioner {
+			return &User{cfg: cfg}
+		})
+}
+
+func (u *User) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	awsCfg, err := u.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return u.listWithClient(ctx, iam.NewFromConfig(awsCfg), request)
+}
+
+// listWithClient lists a page of users. A user's NativeID is its name.
+func (u *User) listWithClient(ctx context.Context, client userClientInterface, request *resource.ListRequest) (*resource.ListResult, error) {
+	out, err := client.ListUsers(ctx, &iam.ListUsersInput{
+		PathPrefix: listPathPrefix(request),
+		MaxItems:   listPageSize(request),
+		Marker:     listMarker(request),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing users: %w", err)
+	}
+
+	nativeIDs := make([]string, 0, len(out.Users))
+	for _, user := range out.Users {
+		nativeIDs = append(nativeIDs, *user.UserName)
+	}
+	return listResult(nativeIDs, out.IsTruncated, out.Marker), nil
+}
+
+// The remaining Provisioner methods are unreachable: only List is registered.
+func (u *User) Create(_ context.Context, _ *resource.CreateRequest) (*resource.CreateResult, error) {
+	return nil, fmt.Errorf("create not implemented - cloudcontrol handles this operation")
+}
+
+func (u *User) Read(_ context.Context, _ *resource.ReadRequest) (*resource.ReadResult, error) {
+	return nil, fmt.Errorf("read not implemented - cloudcontrol handles this operation")
+}
+
+func (u *User) Update(_ context.Context, _ *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	return nil, fmt.Errorf("update not implemented - cloudcontrol handles this operation")
+}
+
+func (u *User) Delete(_ context.Context, _ *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	return nil, fmt.Errorf("delete not implemented - cloudcontrol handles this operation")
+}
+
+func (u *User) Status(_ context.Context, _ *resource.StatusRequest) (*resource.StatusResult, error) {
+	return nil, fmt.Errorf("status not implemented - cloudcontrol handles this operation")
+}
@@ -0,0 +1,24 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package iam
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockUserClient struct {
+	mock.Mock
+}
+
+func (m *mockUserClient) ListUsers(ctx context.Context, input *iam.ListUsersInput, optFns ...func(*iam.Options)) (*iam.ListUsersOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*iam.ListUsersOutput)
+	return out, args.Error(1)
+}
@@ -0,0 +1,80 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package route53
+
+import (
+	"encoding/json"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// canonicalName returns the Route53 canonical form of a DNS name: escapes
+// decoded, internationalized labels in punycode, and a trailing dot. Two
+// spellings of the same name, such as "*.example.com" and the
+// "\052.example.com." Route53 returns, have the same canonical name.
+func canonicalName(name string) string {
+	name = unescapeName(name)
+	if ascii, err := idna.Punycode.ToASCII(name); err == nil {
+		name = ascii
+	}
+	if !strings.HasSuffix(name, ".") {
+		return name + "."
+	}
+	return name
+}
+
+// displayName returns a name Route53 reported the way users write it: without
+// the trailing dot, with escapes decoded and internationalized labels in
+// Unicode.
+func displayName(name string) string {
+	name = strings.TrimSuffix(unescapeName(name), ".")
+	if unicode, err := idna.Punycode.ToUnicode(name); err == nil {
+		return unicode
+	}
+	return name
+}
+
+// readName returns the name a Read reports for a live name. A name can be
+// spelled several ways, and the caller's model records one of them, so the
+// prior name is kept when it is the same DNS name as the live one; otherwise
+// the live name is reported with displayName.
+func readName(live string, prior json.RawMessage) string {
+	var previous struct {
+		Name string `json:"Name"`
+	}
+	if len(prior) > 0 && json.Unmarshal(prior, &previous) == nil &&
+		previous.Name != "" && canonicalName(previous.Name) == canonicalName(live) {
+		return previous.Name
+	}
+	return displayName(live)
+}
+
+// unescapeName decodes the escapes Route53 uses in names it returns: \NNN for
+// a character given as three octal digits, and \c for the character c.
+func unescapeName(name string) string {
+	if !strings.Contains(name, `\`) {
+		return name
+	}
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] != '\\' || i+1 == len(name) {
+			b.WriteByte(name[i])
+			continue
+		}
+		if i+3 < len(name) && isOctal(name[i+1]) && isOctal(name[i+2]) && isOctal(name[i+3]) {
+			b.WriteByte((name[i+1]-'0')<<6 | (name[i+2]-'0')<<3 | (name[i+3] - '0'))
+			i += 3
+			continue
+		}
+		b.WriteByte(name[i+1])
+		i++
+	}
+	return b.String()
+}
+
+func isOctal(c byte) bool {
+	return c >= '0' && c <= '7'
+}
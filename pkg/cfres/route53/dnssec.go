@@ -0,0 +1,344 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package route53
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/utils"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+const dnssecType = "AWS::Route53::DNSSEC"
+
+// Signing states Route53 reports for a hosted zone, and the states shared by
+// key-signing keys that need someone to step in.
+const (
+	dnssecSigning         = "SIGNING"
+	dnssecNotSigning      = "NOT_SIGNING"
+	dnssecActionNeeded    = "ACTION_NEEDED"
+	dnssecInternalFailure = "INTERNAL_FAILURE"
+)
+
+type dnssecGetter interface {
+	GetDNSSEC(ctx context.Context, params *route53.GetDNSSECInput, optFns ...func(*route53.Options)) (*route53.GetDNSSECOutput, error)
+}
+
+type hostedZoneLister interface {
+	ListHostedZones(ctx context.Context, params *route53.ListHostedZonesInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesOutput, error)
+}
+
+type dnssecClientInterface interface {
+	dnssecGetter
+	hostedZoneLister
+	EnableHostedZoneDNSSEC(ctx context.Context, params *route53.EnableHostedZoneDNSSECInput, optFns ...func(*route53.Options)) (*route53.EnableHostedZoneDNSSECOutput, error)
+	DisableHostedZoneDNSSEC(ctx context.Context, params *route53.DisableHostedZoneDNSSECInput, optFns ...func(*route53.Options)) (*route53.DisableHostedZoneDNSSECOutput, error)
+}
+
+// DNSSEC enables DNSSEC signing on a hosted zone. The resource exists while
+// the zone is signed. Route53 moves a zone into and out of signing in the
+// background, so Create and Delete return in progress and Status waits for
+// the zone's signing status to settle.
+type DNSSEC struct {
+	cfg *config.Config
+}
+
+var _ prov.Provisioner = &DNSSEC{}
+
+func init() {
+	registry.Register(dnssecType,
+		[]resource.Operation{
+			resource.OperationCreate,
+			resource.OperationRead,
+			resource.OperationUpdate,
+			resource.OperationDelete,
+			resource.OperationCheckStatus,
+			resource.OperationList,
+		},
+		func(cfg *config.Config) prov.Provisioner {
+			return &DNSSEC{cfg: cfg}
+		})
+}
+
+func (d *DNSSEC) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	cfg, err := d.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return d.createWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (d *DNSSEC) createWithClient(ctx context.Context, client dnssecClientInterface, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	var properties map[string]any
+	if err := json.Unmarshal(request.Properties, &properties); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+	hostedZoneID, err := utils.GetStringProperty(properties, "HostedZoneId")
+	if err != nil {
+		return nil, fmt.Errorf("invalid HostedZoneId: %w", err)
+	}
+
+	_, err = client.EnableHostedZoneDNSSEC(ctx, &route53.EnableHostedZoneDNSSECInput{
+		HostedZoneId: aws.String(hostedZoneID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable DNSSEC signing for hosted zone %s: %w", hostedZoneID, err)
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusInProgress,
+			RequestID:       dnssecRequestID(resource.OperationCreate, hostedZoneID),
+			NativeID:        hostedZoneID,
+		},
+	}, nil
+}
+
+func (d *DNSSEC) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	cfg, err := d.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return d.readWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (d *DNSSEC) readWithClient(ctx context.Context, client dnssecClientInterface, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	status, err := getDNSSEC(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil || servesSignature(status) == dnssecNotSigning {
+		return &resource.ReadResult{
+			ResourceType: request.ResourceType,
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	props, err := dnssecProperties(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	return &resource.ReadResult{
+		ResourceType: dnssecType,
+		Properties:   string(props),
+	}, nil
+}
+
+// Update has nothing to change: the hosted zone is the only property and
+// changing it replaces the resource.
+func (d *DNSSEC) Update(_ context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	props, err := dnssecProperties(request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:          resource.OperationUpdate,
+			OperationStatus:    resource.OperationStatusSuccess,
+			NativeID:           request.NativeID,
+			ResourceProperties: props,
+		},
+	}, nil
+}
+
+func (d *DNSSEC) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	cfg, err := d.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return d.deleteWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (d *DNSSEC) deleteWithClient(ctx context.Context, client dnssecClientInterface, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	_, err := client.DisableHostedZoneDNSSEC(ctx, &route53.DisableHostedZoneDNSSECInput{
+		HostedZoneId: aws.String(request.NativeID),
+	})
+	if err != nil {
+		var noZone *types.NoSuchHostedZone
+		var notFound *types.DNSSECNotFound
+		if errors.As(err, &noZone) || errors.As(err, &notFound) {
+			return &resource.DeleteResult{
+				ProgressResult: &resource.ProgressResult{
+					Operation:       resource.OperationDelete,
+					OperationStatus: resource.OperationStatusSuccess,
+					NativeID:        request.NativeID,
+				},
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to disable DNSSEC signing for hosted zone %s: %w", request.NativeID, err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			RequestID:       dnssecRequestID(resource.OperationDelete, request.NativeID),
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (d *DNSSEC) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	cfg, err := d.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return d.statusWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (d *DNSSEC) statusWithClient(ctx context.Context, client dnssecClientInterface, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	operation, hostedZoneID, err := parseDNSSECRequestID(request.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	status, err := getDNSSEC(ctx, client, hostedZoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := &resource.ProgressResult{
+		Operation:       operation,
+		OperationStatus: resource.OperationStatusInProgress,
+		RequestID:       request.RequestID,
+		NativeID:        hostedZoneID,
+	}
+	want := dnssecSigning
+	if operation == resource.OperationDelete {
+		want = dnssecNotSigning
+	}
+	switch {
+	case status == nil && operation == resource.OperationDelete:
+		pr.OperationStatus = resource.OperationStatusSuccess
+	case status == nil:
+		pr.OperationStatus = resource.OperationStatusFailure
+		pr.ErrorCode = resource.OperationErrorCodeNotFound
+		pr.StatusMessage = fmt.Sprintf("hosted zone %s not found", hostedZoneID)
+	case servesSignature(status) == want:
+		pr.OperationStatus = resource.OperationStatusSuccess
+		if operation != resource.OperationDelete {
+			props, err := dnssecProperties(hostedZoneID)
+			if err != nil {
+				return nil, err
+			}
+			pr.ResourceProperties = props
+		}
+	case servesSignature(status) == dnssecActionNeeded || servesSignature(status) == dnssecInternalFailure:
+		pr.OperationStatus = resource.OperationStatusFailure
+		pr.ErrorCode = resource.OperationErrorCodeGeneralServiceException
+		pr.StatusMessage = aws.ToString(status.Status.StatusMessage)
+	default:
+		pr.StatusMessage = fmt.Sprintf("hosted zone signing status is %s", servesSignature(status))
+	}
+	return &resource.StatusResult{ProgressResult: pr}, nil
+}
+
+func (d *DNSSEC) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	cfg, err := d.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return d.listWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+// listWithClient pages through the public hosted zones and returns the ones
+// that are signed.
+func (d *DNSSEC) listWithClient(ctx context.Context, client dnssecClientInterface, request *resource.ListRequest) (*resource.ListResult, error) {
+	zones, next, err := listPublicHostedZones(ctx, client, request)
+	if err != nil {
+		return nil, err
+	}
+
+	nativeIDs := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		status, err := getDNSSEC(ctx, client, zone)
+		if err != nil {
+			return nil, err
+		}
+		if status != nil && servesSignature(status) != dnssecNotSigning {
+			nativeIDs = append(nativeIDs, zone)
+		}
+	}
+	return &resource.ListResult{NativeIDs: nativeIDs, NextPageToken: next}, nil
+}
+
+func dnssecRequestID(operation resource.Operation, hostedZoneID string) string {
+	return string(operation) + "|" + hostedZoneID
+}
+
+func parseDNSSECRequestID(requestID string) (resource.Operation, string, error) {
+	parts := strings.SplitN(requestID, "|", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid RequestID %q: expected <operation>|<hostedZoneId>", requestID)
+	}
+	return resource.Operation(parts[0]), parts[1], nil
+}
+
+func dnssecProperties(hostedZoneID string) (json.RawMessage, error) {
+	data, err := json.Marshal(map[string]any{"HostedZoneId": hostedZoneID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal properties: %w", err)
+	}
+	return data, nil
+}
+
+// getDNSSEC returns the DNSSEC signing status and key-signing keys of a hosted
+// zone, or nil when the zone doesn't exist.
+func getDNSSEC(ctx context.Context, client dnssecGetter, hostedZoneID string) (*route53.GetDNSSECOutput, error) {
+	result, err := client.GetDNSSEC(ctx, &route53.GetDNSSECInput{
+		HostedZoneId: aws.String(hostedZoneID),
+	})
+	if err != nil {
+		var noZone *types.NoSuchHostedZone
+		if errors.As(err, &noZone) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get DNSSEC status for hosted zone %s: %w", hostedZoneID, err)
+	}
+	return result, nil
+}
+
+func servesSignature(status *route53.GetDNSSECOutput) string {
+	if status.Status == nil {
+		return dnssecNotSigning
+	}
+	return aws.ToString(status.Status.ServeSignature)
+}
+
+// listPublicHostedZones returns one page of public hosted zone IDs. Private
+// zones can't be signed, so DNSSEC listings skip them.
+func listPublicHostedZones(ctx context.Context, client hostedZoneLister, request *resource.ListRequest) ([]string, *string, error) {
+	input := &route53.ListHostedZonesInput{Marker: request.PageToken}
+	if request.PageSize > 0 {
+		input.MaxItems = aws.Int32(request.PageSize)
+	}
+	result, err := client.ListHostedZones(ctx, input)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list hosted zones: %w", err)
+	}
+
+	zones := make([]string, 0, len(result.HostedZones))
+	for _, zone := range result.HostedZones {
+		if zone.Config != nil && zone.Config.PrivateZone {
+			continue
+		}
+		zones = append(zones, strings.TrimPrefix(aws.ToString(zone.Id), "/hostedzone/"))
+	}
+	var next *string
+	if result.IsTruncated {
+		next = result.NextMarker
+	}
+	return zones, next, nil
+}
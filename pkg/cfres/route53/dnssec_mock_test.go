@@ -0,0 +1,66 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package route53
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockDNSSECClient struct {
+	mock.Mock
+}
+
+func (m *mockDNSSECClient) GetDNSSEC(ctx context.Context, input *route53.GetDNSSECInput, optFns ...func(*route53.Options)) (*route53.GetDNSSECOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.GetDNSSECOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockDNSSECClient) ListHostedZones(ctx context.Context, input *route53.ListHostedZonesInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.ListHostedZonesOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockDNSSECClient) EnableHostedZoneDNSSEC(ctx context.Context, input *route53.EnableHostedZoneDNSSECInput, optFns ...func(*route53.Options)) (*route53.EnableHostedZoneDNSSECOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.EnableHostedZoneDNSSECOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockDNSSECClient) DisableHostedZoneDNSSEC(ctx context.Context, input *route53.DisableHostedZoneDNSSECInput, optFns ...func(*route53.Options)) (*route53.DisableHostedZoneDNSSECOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.DisableHostedZoneDNSSECOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockDNSSECClient) CreateKeySigningKey(ctx context.Context, input *route53.CreateKeySigningKeyInput, optFns ...func(*route53.Options)) (*route53.CreateKeySigningKeyOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.CreateKeySigningKeyOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockDNSSECClient) ActivateKeySigningKey(ctx context.Context, input *route53.ActivateKeySigningKeyInput, optFns ...func(*route53.Options)) (*route53.ActivateKeySigningKeyOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.ActivateKeySigningKeyOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockDNSSECClient) DeactivateKeySigningKey(ctx context.Context, input *route53.DeactivateKeySigningKeyInput, optFns ...func(*route53.Options)) (*route53.DeactivateKeySigningKeyOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.DeactivateKeySigningKeyOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockDNSSECClient) DeleteKeySigningKey(ctx context.Context, input *route53.DeleteKeySigningKeyInput, optFns ...func(*route53.Options)) (*route53.DeleteKeySigningKeyOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.DeleteKeySigningKeyOutput)
+	return out, args.Error(1)
+}
@@ -0,0 +1,259 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package route53
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func signingStatus(serveSignature string, keys ...types.KeySigningKey) *route53.GetDNSSECOutput {
+	return &route53.GetDNSSECOutput{
+		Status:         &types.DNSSECStatus{ServeSignature: aws.String(serveSignature), StatusMessage: aws.String("check the KMS key policy")},
+		KeySigningKeys: keys,
+	}
+}
+
+func ksk(name, status string) types.KeySigningKey {
+	return types.KeySigningKey{
+		Name:          aws.String(name),
+		KmsArn:        aws.String("arn:aws:kms:us-east-1:123456789012:key/abc"),
+		Status:        aws.String(status),
+		StatusMessage: aws.String("check the KMS key policy"),
+	}
+}
+
+func TestDNSSEC_CreateWaitsForSigning(t *testing.T) {
+	m := &mockDNSSECClient{}
+	m.On("EnableHostedZoneDNSSEC", mock.Anything, mock.MatchedBy(func(in *route53.EnableHostedZoneDNSSECInput) bool {
+		return aws.ToString(in.HostedZoneId) == "Z123"
+	})).Return(&route53.EnableHostedZoneDNSSECOutput{}, nil)
+	m.On("GetDNSSEC", mock.Anything, mock.Anything).Return(signingStatus("NOT_SIGNING"), nil).Once()
+	m.On("GetDNSSEC", mock.Anything, mock.Anything).Return(signingStatus("SIGNING"), nil)
+
+	d := &DNSSEC{}
+	created, err := d.createWithClient(context.Background(), m, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"HostedZoneId": "Z123"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, created.ProgressResult.OperationStatus)
+	assert.Equal(t, "Z123", created.ProgressResult.NativeID)
+
+	status := &resource.StatusRequest{RequestID: created.ProgressResult.RequestID}
+	pending, err := d.statusWithClient(context.Background(), m, status)
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, pending.ProgressResult.OperationStatus)
+
+	signed, err := d.statusWithClient(context.Background(), m, status)
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, signed.ProgressResult.OperationStatus)
+	assert.JSONEq(t, `{"HostedZoneId": "Z123"}`, string(signed.ProgressResult.ResourceProperties))
+}
+
+func TestDNSSEC_Status_ActionNeeded(t *testing.T) {
+	m := &mockDNSSECClient{}
+	m.On("GetDNSSEC", mock.Anything, mock.Anything).Return(signingStatus("ACTION_NEEDED"), nil)
+
+	res, err := (&DNSSEC{}).statusWithClient(context.Background(), m, &resource.StatusRequest{
+		RequestID: dnssecRequestID(resource.OperationCreate, "Z123"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusFailure, res.ProgressResult.OperationStatus)
+	assert.Equal(t, "check the KMS key policy", res.ProgressResult.StatusMessage)
+}
+
+func TestDNSSEC_DeleteWaitsForNotSigning(t *testing.T) {
+	m := &mockDNSSECClient{}
+	m.On("DisableHostedZoneDNSSEC", mock.Anything, mock.Anything).Return(&route53.DisableHostedZoneDNSSECOutput{}, nil)
+	m.On("GetDNSSEC", mock.Anything, mock.Anything).Return(signingStatus("DELETING"), nil).Once()
+	m.On("GetDNSSEC", mock.Anything, mock.Anything).Return(signingStatus("NOT_SIGNING"), nil)
+
+	d := &DNSSEC{}
+	deleted, err := d.deleteWithClient(context.Background(), m, &resource.DeleteRequest{NativeID: "Z123"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, deleted.ProgressResult.OperationStatus)
+
+	status := &resource.StatusRequest{RequestID: deleted.ProgressResult.RequestID}
+	pending, err := d.statusWithClient(context.Background(), m, status)
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, pending.ProgressResult.OperationStatus)
+
+	done, err := d.statusWithClient(context.Background(), m, status)
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, done.ProgressResult.OperationStatus)
+}
+
+func TestDNSSEC_Read_NotSigningIsNotFound(t *testing.T) {
+	m := &mockDNSSECClient{}
+	m.On("GetDNSSEC", mock.Anything, mock.Anything).Return(signingStatus("NOT_SIGNING"), nil)
+
+	res, err := (&DNSSEC{}).readWithClient(context.Background(), m, &resource.ReadRequest{NativeID: "Z123"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationErrorCodeNotFound, res.ErrorCode)
+}
+
+func TestDNSSEC_List_SkipsPrivateAndUnsignedZones(t *testing.T) {
+	m := &mockDNSSECClient{}
+	m.On("ListHostedZones", mock.Anything, mock.Anything).Return(&route53.ListHostedZonesOutput{
+		HostedZones: []types.HostedZone{
+			{Id: aws.String("/hostedzone/Z1")},
+			{Id: aws.String("/hostedzone/Z2")},
+			{Id: aws.String("/hostedzone/Z3"), Config: &types.HostedZoneConfig{PrivateZone: true}},
+		},
+		IsTruncated: true,
+		NextMarker:  aws.String("Z4"),
+	}, nil)
+	m.On("GetDNSSEC", mock.Anything, mock.MatchedBy(func(in *route53.GetDNSSECInput) bool {
+		return aws.ToString(in.HostedZoneId) == "Z1"
+	})).Return(signingStatus("SIGNING"), nil)
+	m.On("GetDNSSEC", mock.Anything, mock.MatchedBy(func(in *route53.GetDNSSECInput) bool {
+		return aws.ToString(in.HostedZoneId) == "Z2"
+	})).Return(signingStatus("NOT_SIGNING"), nil)
+
+	res, err := (&DNSSEC{}).listWithClient(context.Background(), m, &resource.ListRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Z1"}, res.NativeIDs)
+	require.NotNil(t, res.NextPageToken)
+	assert.Equal(t, "Z4", *res.NextPageToken)
+}
+
+func TestKeySigningKey_CreateWaitsForActive(t *testing.T) {
+	m := &mockDNSSECClient{}
+	var captured *route53.CreateKeySigningKeyInput
+	m.On("CreateKeySigningKey", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { captured = args.Get(1).(*route53.CreateKeySigningKeyInput) }).
+		Return(&route53.CreateKeySigningKeyOutput{}, nil)
+	m.On("GetDNSSEC", mock.Anything, mock.Anything).Return(signingStatus("NOT_SIGNING", ksk("primary", "INACTIVE")), nil).Once()
+	m.On("GetDNSSEC", mock.Anything, mock.Anything).Return(signingStatus("NOT_SIGNING", ksk("primary", "ACTIVE")), nil)
+
+	k := &KeySigningKey{}
+	created, err := k.createWithClient(context.Background(), m, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"HostedZoneId": "Z123", "Name": "primary", "KeyManagementServiceArn": "arn:aws:kms:us-east-1:123456789012:key/abc"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Z123|primary", created.ProgressResult.NativeID)
+	assert.Equal(t, "ACTIVE", aws.ToString(captured.Status))
+	assert.NotEmpty(t, aws.ToString(captured.CallerReference))
+
+	status := &resource.StatusRequest{RequestID: created.ProgressResult.RequestID}
+	pending, err := k.statusWithClient(context.Background(), m, status)
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, pending.ProgressResult.OperationStatus)
+
+	active, err := k.statusWithClient(context.Background(), m, status)
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, active.ProgressResult.OperationStatus)
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal(active.ProgressResult.ResourceProperties, &props))
+	assert.Equal(t, "ACTIVE", props["Status"])
+	assert.Equal(t, "arn:aws:kms:us-east-1:123456789012:key/abc", props["KeyManagementServiceArn"])
+}
+
+func TestKeySigningKey_Create_RejectsUnknownStatus(t *testing.T) {
+	_, err := (&KeySigningKey{}).createWithClient(context.Background(), &mockDNSSECClient{}, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"HostedZoneId": "Z123", "Name": "primary", "KeyManagementServiceArn": "arn", "Status": "DELETING"}`),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid Status")
+}
+
+func TestKeySigningKey_Update_Deactivates(t *testing.T) {
+	m := &mockDNSSECClient{}
+	m.On("GetDNSSEC", mock.Anything, mock.Anything).Return(signingStatus("SIGNING", ksk("primary", "ACTIVE")), nil)
+	m.On("DeactivateKeySigningKey", mock.Anything, mock.MatchedBy(func(in *route53.DeactivateKeySigningKeyInput) bool {
+		return aws.ToString(in.HostedZoneId) == "Z123" && aws.ToString(in.Name) == "primary"
+	})).Return(&route53.DeactivateKeySigningKeyOutput{}, nil)
+
+	res, err := (&KeySigningKey{}).updateWithClient(context.Background(), m, &resource.UpdateRequest{
+		NativeID:          "Z123|primary",
+		DesiredProperties: json.RawMessage(`{"HostedZoneId": "Z123", "Name": "primary", "Status": "INACTIVE"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, res.ProgressResult.OperationStatus)
+	assert.Equal(t, "Update|Z123|primary|INACTIVE", res.ProgressResult.RequestID)
+	m.AssertExpectations(t)
+}
+
+func TestKeySigningKey_Update_Unchanged(t *testing.T) {
+	m := &mockDNSSECClient{}
+	m.On("GetDNSSEC", mock.Anything, mock.Anything).Return(signingStatus("SIGNING", ksk("primary", "ACTIVE")), nil)
+
+	res, err := (&KeySigningKey{}).updateWithClient(context.Background(), m, &resource.UpdateRequest{
+		NativeID:          "Z123|primary",
+		DesiredProperties: json.RawMessage(`{"HostedZoneId": "Z123", "Name": "primary", "Status": "ACTIVE"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, res.ProgressResult.OperationStatus)
+	m.AssertNotCalled(t, "ActivateKeySigningKey", mock.Anything, mock.Anything)
+}
+
+// An active key can't be deleted, so Delete deactivates it and Status deletes
+// it once it is inactive.
+func TestKeySigningKey_Delete_DeactivatesActiveKeyFirst(t *testing.T) {
+	m := &mockDNSSECClient{}
+	m.On("GetDNSSEC", mock.Anything, mock.Anything).Return(signingStatus("NOT_SIGNING", ksk("primary", "ACTIVE")), nil).Once()
+	m.On("DeactivateKeySigningKey", mock.Anything, mock.Anything).Return(&route53.DeactivateKeySigningKeyOutput{}, nil)
+	m.On("GetDNSSEC", mock.Anything, mock.Anything).Return(signingStatus("NOT_SIGNING", ksk("primary", "INACTIVE")), nil).Once()
+	m.On("DeleteKeySigningKey", mock.Anything, mock.Anything).Return(&route53.DeleteKeySigningKeyOutput{}, nil)
+	m.On("GetDNSSEC", mock.Anything, mock.Anything).Return(signingStatus("NOT_SIGNING"), nil)
+
+	k := &KeySigningKey{}
+	deleted, err := k.deleteWithClient(context.Background(), m, &resource.DeleteRequest{NativeID: "Z123|primary"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, deleted.ProgressResult.OperationStatus)
+	m.AssertNotCalled(t, "DeleteKeySigningKey", mock.Anything, mock.Anything)
+
+	status := &resource.StatusRequest{RequestID: deleted.ProgressResult.RequestID}
+	deleting, err := k.statusWithClient(context.Background(), m, status)
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, deleting.ProgressResult.OperationStatus)
+	m.AssertNumberOfCalls(t, "DeleteKeySigningKey", 1)
+
+	done, err := k.statusWithClient(context.Background(), m, status)
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, done.ProgressResult.OperationStatus)
+}
+
+func TestKeySigningKey_Status_InternalFailure(t *testing.T) {
+	m := &mockDNSSECClient{}
+	m.On("GetDNSSEC", mock.Anything, mock.Anything).Return(signingStatus("SIGNING", ksk("primary", "INTERNAL_FAILURE")), nil)
+
+	res, err := (&KeySigningKey{}).statusWithClient(context.Background(), m, &resource.StatusRequest{
+		RequestID: keySigningKeyRequestID(resource.OperationCreate, "Z123|primary", "ACTIVE"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusFailure, res.ProgressResult.OperationStatus)
+	assert.Equal(t, "check the KMS key policy", res.ProgressResult.StatusMessage)
+}
+
+func TestKeySigningKey_List(t *testing.T) {
+	m := &mockDNSSECClient{}
+	m.On("ListHostedZones", mock.Anything, mock.Anything).Return(&route53.ListHostedZonesOutput{
+		HostedZones: []types.HostedZone{{Id: aws.String("/hostedzone/Z1")}, {Id: aws.String("/hostedzone/Z2")}},
+	}, nil)
+	m.On("GetDNSSEC", mock.Anything, mock.MatchedBy(func(in *route53.GetDNSSECInput) bool {
+		return aws.ToString(in.HostedZoneId) == "Z1"
+	})).Return(signingStatus("SIGNING", ksk("a", "ACTIVE"), ksk("b", "INACTIVE")), nil)
+	m.On("GetDNSSEC", mock.Anything, mock.MatchedBy(func(in *route53.GetDNSSECInput) bool {
+		return aws.ToString(in.HostedZoneId) == "Z2"
+	})).Return(nil, &types.NoSuchHostedZone{})
+
+	res, err := (&KeySigningKey{}).listWithClient(context.Background(), m, &resource.ListRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Z1|a", "Z1|b"}, res.NativeIDs)
+	assert.Nil(t, res.NextPageToken)
+}
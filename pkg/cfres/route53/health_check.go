@@ -0,0 +1,515 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package route53
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/google/uuid"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+const healthCheckType = "AWS::Route53::HealthCheck"
+
+type healthCheckClientInterface interface {
+	CreateHealthCheck(ctx context.Context, params *route53.CreateHealthCheckInput, optFns ...func(*route53.Options)) (*route53.CreateHealthCheckOutput, error)
+	GetHealthCheck(ctx context.Context, params *route53.GetHealthCheckInput, optFns ...func(*route53.Options)) (*route53.GetHealthCheckOutput, error)
+	UpdateHealthCheck(ctx context.Context, params *route53.UpdateHealthCheckInput, optFns ...func(*route53.Options)) (*route53.UpdateHealthCheckOutput, error)
+	DeleteHealthCheck(ctx context.Context, params *route53.DeleteHealthCheckInput, optFns ...func(*route53.Options)) (*route53.DeleteHealthCheckOutput, error)
+	ListHealthChecks(ctx context.Context, params *route53.ListHealthChecksInput, optFns ...func(*route53.Options)) (*route53.ListHealthChecksOutput, error)
+	ChangeTagsForResource(ctx context.Context, params *route53.ChangeTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ChangeTagsForResourceOutput, error)
+	ListTagsForResource(ctx context.Context, params *route53.ListTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ListTagsForResourceOutput, error)
+}
+
+// HealthCheck provisions Route53 health checks directly through the Route53
+// API. CloudControl's handler for the type intermittently fails to stabilize,
+// and health checks are synchronous in Route53 anyway.
+type HealthCheck struct {
+	cfg *config.Config
+}
+
+var _ prov.Provisioner = &HealthCheck{}
+
+func init() {
+	registry.Register(healthCheckType,
+		[]resource.Operation{
+			resource.OperationCreate,
+			resource.OperationRead,
+			resource.OperationUpdate,
+			resource.OperationDelete,
+			resource.OperationList,
+		},
+		func(cfg *config.Config) prov.Provisioner {
+			return &HealthCheck{cfg: cfg}
+		})
+}
+
+func (h *HealthCheck) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	cfg, err := h.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return h.createWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (h *HealthCheck) createWithClient(ctx context.Context, client healthCheckClientInterface, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	var properties map[string]any
+	if err := json.Unmarshal(request.Properties, &properties); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+	rawConfig, ok := properties["HealthCheckConfig"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("HealthCheckConfig is required")
+	}
+	healthCheckConfig, err := buildHealthCheckConfig(rawConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CreateHealthCheck(ctx, &route53.CreateHealthCheckInput{
+		CallerReference:   aws.String(uuid.NewString()),
+		HealthCheckConfig: healthCheckConfig,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create health check: %w", err)
+	}
+	id := aws.ToString(result.HealthCheck.Id)
+
+	if tags := healthCheckTags(properties); len(tags) > 0 {
+		if err := changeHealthCheckTags(ctx, client, id, tags, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:          resource.OperationCreate,
+			OperationStatus:    resource.OperationStatusSuccess,
+			NativeID:           id,
+			ResourceProperties: h.readProperties(ctx, client, id),
+		},
+	}, nil
+}
+
+func (h *HealthCheck) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	cfg, err := h.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return h.readWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (h *HealthCheck) readWithClient(ctx context.Context, client healthCheckClientInterface, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	result, err := client.GetHealthCheck(ctx, &route53.GetHealthCheckInput{
+		HealthCheckId: aws.String(request.NativeID),
+	})
+	if err != nil {
+		var notFound *types.NoSuchHealthCheck
+		if errors.As(err, &notFound) {
+			return &resource.ReadResult{
+				ResourceType: request.ResourceType,
+				ErrorCode:    resource.OperationErrorCodeNotFound,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to get health check %s: %w", request.NativeID, err)
+	}
+
+	props := map[string]any{
+		"Id":                request.NativeID,
+		"HealthCheckConfig": readHealthCheckConfig(result.HealthCheck.HealthCheckConfig),
+	}
+
+	tagsResult, err := client.ListTagsForResource(ctx, &route53.ListTagsForResourceInput{
+		ResourceId:   aws.String(request.NativeID),
+		ResourceType: types.TagResourceTypeHealthcheck,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags of health check %s: %w", request.NativeID, err)
+	}
+	if tagsResult.ResourceTagSet != nil && len(tagsResult.ResourceTagSet.Tags) > 0 {
+		tags := make([]map[string]any, 0, len(tagsResult.ResourceTagSet.Tags))
+		for _, tag := range tagsResult.ResourceTagSet.Tags {
+			tags = append(tags, map[string]any{"Key": aws.ToString(tag.Key), "Value": aws.ToString(tag.Value)})
+		}
+		sort.Slice(tags, func(i, j int) bool { return tags[i]["Key"].(string) < tags[j]["Key"].(string) })
+		props["HealthCheckTags"] = tags
+	}
+
+	propBytes, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal properties: %w", err)
+	}
+	return &resource.ReadResult{
+		ResourceType: healthCheckType,
+		Properties:   string(propBytes),
+	}, nil
+}
+
+// readProperties returns the properties of health check id for an operation
+// result, or nil when they can't be read; the agent then reads them itself.
+func (h *HealthCheck) readProperties(ctx context.Context, client healthCheckClientInterface, id string) json.RawMessage {
+	readResult, err := h.readWithClient(ctx, client, &resource.ReadRequest{NativeID: id, ResourceType: healthCheckType})
+	if err != nil || readResult.ErrorCode != "" {
+		return nil
+	}
+	return json.RawMessage(readResult.Properties)
+}
+
+func (h *HealthCheck) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	cfg, err := h.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return h.updateWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (h *HealthCheck) updateWithClient(ctx context.Context, client healthCheckClientInterface, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	var priorProperties, desiredProperties map[string]any
+	if err := json.Unmarshal(request.PriorProperties, &priorProperties); err != nil {
+		return nil, fmt.Errorf("failed to parse prior state properties: %w", err)
+	}
+	if err := json.Unmarshal(request.DesiredProperties, &desiredProperties); err != nil {
+		return nil, fmt.Errorf("failed to parse desired state properties: %w", err)
+	}
+	rawConfig, ok := desiredProperties["HealthCheckConfig"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("HealthCheckConfig is required")
+	}
+	desired, err := buildHealthCheckConfig(rawConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// UpdateHealthCheck is rejected unless it names the version it was
+	// computed against.
+	current, err := client.GetHealthCheck(ctx, &route53.GetHealthCheckInput{
+		HealthCheckId: aws.String(request.NativeID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get health check %s: %w", request.NativeID, err)
+	}
+
+	input := &route53.UpdateHealthCheckInput{
+		HealthCheckId:                aws.String(request.NativeID),
+		HealthCheckVersion:           current.HealthCheck.HealthCheckVersion,
+		AlarmIdentifier:              desired.AlarmIdentifier,
+		ChildHealthChecks:            desired.ChildHealthChecks,
+		EnableSNI:                    desired.EnableSNI,
+		FailureThreshold:             desired.FailureThreshold,
+		FullyQualifiedDomainName:     desired.FullyQualifiedDomainName,
+		HealthThreshold:              desired.HealthThreshold,
+		IPAddress:                    desired.IPAddress,
+		InsufficientDataHealthStatus: desired.InsufficientDataHealthStatus,
+		Inverted:                     desired.Inverted,
+		Port:                         desired.Port,
+		Regions:                      desired.Regions,
+		ResourcePath:                 desired.ResourcePath,
+		SearchString:                 desired.SearchString,
+		ResetElements:                resetElements(current.HealthCheck.HealthCheckConfig, desired),
+	}
+	if _, err := client.UpdateHealthCheck(ctx, input); err != nil {
+		return nil, fmt.Errorf("failed to update health check: %w", err)
+	}
+
+	add, remove := diffHealthCheckTags(healthCheckTags(priorProperties), healthCheckTags(desiredProperties))
+	if len(add) > 0 || len(remove) > 0 {
+		if err := changeHealthCheckTags(ctx, client, request.NativeID, add, remove); err != nil {
+			return nil, err
+		}
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:          resource.OperationUpdate,
+			OperationStatus:    resource.OperationStatusSuccess,
+			NativeID:           request.NativeID,
+			ResourceProperties: h.readProperties(ctx, client, request.NativeID),
+		},
+	}, nil
+}
+
+// resetElements lists the optional settings set on the live health check but
+// absent from desired. UpdateHealthCheck leaves omitted settings unchanged, so
+// these have to be reset explicitly.
+func resetElements(live *types.HealthCheckConfig, desired *types.HealthCheckConfig) []types.ResettableElementName {
+	if live == nil {
+		return nil
+	}
+	var reset []types.ResettableElementName
+	if live.FullyQualifiedDomainName != nil && desired.FullyQualifiedDomainName == nil {
+		reset = append(reset, types.ResettableElementNameFullyQualifiedDomainName)
+	}
+	if len(live.Regions) > 0 && len(desired.Regions) == 0 {
+		reset = append(reset, types.ResettableElementNameRegions)
+	}
+	if live.ResourcePath != nil && desired.ResourcePath == nil {
+		reset = append(reset, types.ResettableElementNameResourcePath)
+	}
+	if len(live.ChildHealthChecks) > 0 && len(desired.ChildHealthChecks) == 0 {
+		reset = append(reset, types.ResettableElementNameChildHealthChecks)
+	}
+	return reset
+}
+
+func (h *HealthCheck) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	cfg, err := h.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return h.deleteWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (h *HealthCheck) deleteWithClient(ctx context.Context, client healthCheckClientInterface, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	_, err := client.DeleteHealthCheck(ctx, &route53.DeleteHealthCheckInput{
+		HealthCheckId: aws.String(request.NativeID),
+	})
+	if err != nil {
+		var notFound *types.NoSuchHealthCheck
+		if !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("failed to delete health check %s: %w", request.NativeID, err)
+		}
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (h *HealthCheck) Status(_ context.Context, _ *resource.StatusRequest) (*resource.StatusResult, error) {
+	return nil, fmt.Errorf("health check operations are synchronous - status polling not needed")
+}
+
+func (h *HealthCheck) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	cfg, err := h.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return h.listWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (h *HealthCheck) listWithClient(ctx context.Context, client healthCheckClientInterface, request *resource.ListRequest) (*resource.ListResult, error) {
+	input := &route53.ListHealthChecksInput{Marker: request.PageToken}
+	if request.PageSize > 0 {
+		input.MaxItems = aws.Int32(request.PageSize)
+	}
+	result, err := client.ListHealthChecks(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list health checks: %w", err)
+	}
+
+	nativeIDs := make([]string, 0, len(result.HealthChecks))
+	for _, hc := range result.HealthChecks {
+		nativeIDs = append(nativeIDs, aws.ToString(hc.Id))
+	}
+	var next *string
+	if result.IsTruncated {
+		next = result.NextMarker
+	}
+	return &resource.ListResult{
+		NativeIDs:     nativeIDs,
+		NextPageToken: next,
+	}, nil
+}
+
+// buildHealthCheckConfig converts a declared HealthCheckConfig into its AWS
+// form.
+func buildHealthCheckConfig(raw map[string]any) (*types.HealthCheckConfig, error) {
+	checkType, ok := raw["Type"].(string)
+	if !ok || checkType == "" {
+		return nil, fmt.Errorf("HealthCheckConfig.Type is required")
+	}
+
+	cfg := &types.HealthCheckConfig{
+		Type:                     types.HealthCheckType(checkType),
+		FullyQualifiedDomainName: optionalString(raw, "FullyQualifiedDomainName"),
+		IPAddress:                optionalString(raw, "IPAddress"),
+		ResourcePath:             optionalString(raw, "ResourcePath"),
+		RoutingControlArn:        optionalString(raw, "RoutingControlArn"),
+		SearchString:             optionalString(raw, "SearchString"),
+		EnableSNI:                optionalBool(raw, "EnableSNI"),
+		Inverted:                 optionalBool(raw, "Inverted"),
+		MeasureLatency:           optionalBool(raw, "MeasureLatency"),
+		FailureThreshold:         optionalInt32(raw, "FailureThreshold"),
+		HealthThreshold:          optionalInt32(raw, "HealthThreshold"),
+		Port:                     optionalInt32(raw, "Port"),
+		RequestInterval:          optionalInt32(raw, "RequestInterval"),
+	}
+	if v, ok := raw["InsufficientDataHealthStatus"].(string); ok {
+		cfg.InsufficientDataHealthStatus = types.InsufficientDataHealthStatus(v)
+	}
+	if children := stringList(raw["ChildHealthChecks"]); len(children) > 0 {
+		cfg.ChildHealthChecks = children
+	}
+	for _, region := range stringList(raw["Regions"]) {
+		cfg.Regions = append(cfg.Regions, types.HealthCheckRegion(region))
+	}
+	if alarm, ok := raw["AlarmIdentifier"].(map[string]any); ok {
+		name, _ := alarm["Name"].(string)
+		region, _ := alarm["Region"].(string)
+		if name == "" || region == "" {
+			return nil, fmt.Errorf("HealthCheckConfig.AlarmIdentifier requires Name and Region")
+		}
+		cfg.AlarmIdentifier = &types.AlarmIdentifier{
+			Name:   aws.String(name),
+			Region: types.CloudWatchRegion(region),
+		}
+	}
+	return cfg, nil
+}
+
+// readHealthCheckConfig is the inverse of buildHealthCheckConfig.
+func readHealthCheckConfig(cfg *types.HealthCheckConfig) map[string]any {
+	props := map[string]any{}
+	if cfg == nil {
+		return props
+	}
+	props["Type"] = string(cfg.Type)
+	for key, value := range map[string]*string{
+		"FullyQualifiedDomainName": cfg.FullyQualifiedDomainName,
+		"IPAddress":                cfg.IPAddress,
+		"ResourcePath":             cfg.ResourcePath,
+		"RoutingControlArn":        cfg.RoutingControlArn,
+		"SearchString":             cfg.SearchString,
+	} {
+		if value != nil {
+			props[key] = *value
+		}
+	}
+	for key, value := range map[string]*bool{
+		"EnableSNI":      cfg.EnableSNI,
+		"Inverted":       cfg.Inverted,
+		"MeasureLatency": cfg.MeasureLatency,
+	} {
+		if value != nil {
+			props[key] = *value
+		}
+	}
+	for key, value := range map[string]*int32{
+		"FailureThreshold": cfg.FailureThreshold,
+		"HealthThreshold":  cfg.HealthThreshold,
+		"Port":             cfg.Port,
+		"RequestInterval":  cfg.RequestInterval,
+	} {
+		if value != nil {
+			props[key] = *value
+		}
+	}
+	if cfg.InsufficientDataHealthStatus != "" {
+		props["InsufficientDataHealthStatus"] = string(cfg.InsufficientDataHealthStatus)
+	}
+	if len(cfg.ChildHealthChecks) > 0 {
+		props["ChildHealthChecks"] = cfg.ChildHealthChecks
+	}
+	if len(cfg.Regions) > 0 {
+		regions := make([]string, len(cfg.Regions))
+		for i, region := range cfg.Regions {
+			regions[i] = string(region)
+		}
+		props["Regions"] = regions
+	}
+	if alarm := cfg.AlarmIdentifier; alarm != nil {
+		props["AlarmIdentifier"] = map[string]any{
+			"Name":   aws.ToString(alarm.Name),
+			"Region": string(alarm.Region),
+		}
+	}
+	return props
+}
+
+// healthCheckTags returns the declared HealthCheckTags as a key/value map.
+func healthCheckTags(properties map[string]any) map[string]string {
+	raw, _ := properties["HealthCheckTags"].([]any)
+	tags := make(map[string]string, len(raw))
+	for _, item := range raw {
+		tag, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		key, _ := tag["Key"].(string)
+		value, _ := tag["Value"].(string)
+		if key != "" {
+			tags[key] = value
+		}
+	}
+	return tags
+}
+
+// diffHealthCheckTags returns the tags to set and the keys to remove to get
+// from prior to desired.
+func diffHealthCheckTags(prior, desired map[string]string) (map[string]string, []string) {
+	add := map[string]string{}
+	for key, value := range desired {
+		if priorValue, ok := prior[key]; !ok || priorValue != value {
+			add[key] = value
+		}
+	}
+	var remove []string
+	for key := range prior {
+		if _, ok := desired[key]; !ok {
+			remove = append(remove, key)
+		}
+	}
+	sort.Strings(remove)
+	return add, remove
+}
+
+func changeHealthCheckTags(ctx context.Context, client healthCheckClientInterface, id string, add map[string]string, remove []string) error {
+	input := &route53.ChangeTagsForResourceInput{
+		ResourceId:    aws.String(id),
+		ResourceType:  types.TagResourceTypeHealthcheck,
+		RemoveTagKeys: remove,
+	}
+	keys := make([]string, 0, len(add))
+	for key := range add {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		input.AddTags = append(input.AddTags, types.Tag{Key: aws.String(key), Value: aws.String(add[key])})
+	}
+	if _, err := client.ChangeTagsForResource(ctx, input); err != nil {
+		return fmt.Errorf("failed to tag health check %s: %w", id, err)
+	}
+	return nil
+}
+
+func optionalBool(properties map[string]any, key string) *bool {
+	if v, ok := properties[key].(bool); ok {
+		return aws.Bool(v)
+	}
+	return nil
+}
+
+func optionalInt32(properties map[string]any, key string) *int32 {
+	if v, ok := properties[key].(float64); ok {
+		return aws.Int32(int32(v))
+	}
+	return nil
+}
+
+func stringList(raw any) []string {
+	items, _ := raw.([]any)
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
@@ -0,0 +1,60 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package route53
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockHealthCheckClient struct {
+	mock.Mock
+}
+
+func (m *mockHealthCheckClient) CreateHealthCheck(ctx context.Context, input *route53.CreateHealthCheckInput, optFns ...func(*route53.Options)) (*route53.CreateHealthCheckOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.CreateHealthCheckOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockHealthCheckClient) GetHealthCheck(ctx context.Context, input *route53.GetHealthCheckInput, optFns ...func(*route53.Options)) (*route53.GetHealthCheckOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.GetHealthCheckOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockHealthCheckClient) UpdateHealthCheck(ctx context.Context, input *route53.UpdateHealthCheckInput, optFns ...func(*route53.Options)) (*route53.UpdateHealthCheckOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.UpdateHealthCheckOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockHealthCheckClient) DeleteHealthCheck(ctx context.Context, input *route53.DeleteHealthCheckInput, optFns ...func(*route53.Options)) (*route53.DeleteHealthCheckOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.DeleteHealthCheckOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockHealthCheckClient) ListHealthChecks(ctx context.Context, input *route53.ListHealthChecksInput, optFns ...func(*route53.Options)) (*route53.ListHealthChecksOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.ListHealthChecksOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockHealthCheckClient) ChangeTagsForResource(ctx context.Context, input *route53.ChangeTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ChangeTagsForResourceOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.ChangeTagsForResourceOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockHealthCheckClient) ListTagsForResource(ctx context.Context, input *route53.ListTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ListTagsForResourceOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.ListTagsForResourceOutput)
+	return out, args.Error(1)
+}
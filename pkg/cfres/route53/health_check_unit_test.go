@@ -0,0 +1,165 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package route53
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func httpHealthCheckConfig() *types.HealthCheckConfig {
+	return &types.HealthCheckConfig{
+		Type:                     types.HealthCheckTypeHttps,
+		FullyQualifiedDomainName: aws.String("api.example.com"),
+		ResourcePath:             aws.String("/health"),
+		Port:                     aws.Int32(443),
+		FailureThreshold:         aws.Int32(3),
+	}
+}
+
+func expectHealthCheckRead(m *mockHealthCheckClient, id string, cfg *types.HealthCheckConfig, tags ...types.Tag) {
+	m.On("GetHealthCheck", mock.Anything, mock.MatchedBy(func(in *route53.GetHealthCheckInput) bool {
+		return aws.ToString(in.HealthCheckId) == id
+	})).Return(&route53.GetHealthCheckOutput{
+		HealthCheck: &types.HealthCheck{Id: aws.String(id), HealthCheckConfig: cfg, HealthCheckVersion: aws.Int64(4)},
+	}, nil)
+	m.On("ListTagsForResource", mock.Anything, mock.Anything).Return(&route53.ListTagsForResourceOutput{
+		ResourceTagSet: &types.ResourceTagSet{Tags: tags},
+	}, nil)
+}
+
+func TestHealthCheck_Create_AppliesConfigAndTags(t *testing.T) {
+	m := &mockHealthCheckClient{}
+	var created *route53.CreateHealthCheckInput
+	m.On("CreateHealthCheck", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { created = args.Get(1).(*route53.CreateHealthCheckInput) }).
+		Return(&route53.CreateHealthCheckOutput{HealthCheck: &types.HealthCheck{Id: aws.String("hc-1")}}, nil)
+	var tagged *route53.ChangeTagsForResourceInput
+	m.On("ChangeTagsForResource", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { tagged = args.Get(1).(*route53.ChangeTagsForResourceInput) }).
+		Return(&route53.ChangeTagsForResourceOutput{}, nil)
+	expectHealthCheckRead(m, "hc-1", httpHealthCheckConfig(), types.Tag{Key: aws.String("Name"), Value: aws.String("api")})
+
+	props := json.RawMessage(`{
+		"HealthCheckConfig": {"Type": "HTTPS", "FullyQualifiedDomainName": "api.example.com", "ResourcePath": "/health", "Port": 443, "FailureThreshold": 3},
+		"HealthCheckTags": [{"Key": "Name", "Value": "api"}]
+	}`)
+	res, err := (&HealthCheck{}).createWithClient(context.Background(), m, &resource.CreateRequest{Properties: props})
+	require.NoError(t, err)
+
+	assert.Equal(t, resource.OperationStatusSuccess, res.ProgressResult.OperationStatus)
+	assert.Equal(t, "hc-1", res.ProgressResult.NativeID)
+	assert.NotEmpty(t, aws.ToString(created.CallerReference))
+	assert.Equal(t, types.HealthCheckTypeHttps, created.HealthCheckConfig.Type)
+	assert.Equal(t, int32(443), aws.ToInt32(created.HealthCheckConfig.Port))
+	assert.Equal(t, types.TagResourceTypeHealthcheck, tagged.ResourceType)
+	require.Len(t, tagged.AddTags, 1)
+	assert.Equal(t, "Name", aws.ToString(tagged.AddTags[0].Key))
+
+	var read map[string]any
+	require.NoError(t, json.Unmarshal(res.ProgressResult.ResourceProperties, &read))
+	assert.Equal(t, "hc-1", read["Id"])
+	assert.Equal(t, []any{map[string]any{"Key": "Name", "Value": "api"}}, read["HealthCheckTags"])
+}
+
+func TestHealthCheck_Create_RequiresType(t *testing.T) {
+	_, err := (&HealthCheck{}).createWithClient(context.Background(), &mockHealthCheckClient{}, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"HealthCheckConfig": {"Port": 80}}`),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Type")
+}
+
+func TestHealthCheck_Read_NotFound(t *testing.T) {
+	m := &mockHealthCheckClient{}
+	m.On("GetHealthCheck", mock.Anything, mock.Anything).Return(nil, &types.NoSuchHealthCheck{})
+
+	res, err := (&HealthCheck{}).readWithClient(context.Background(), m, &resource.ReadRequest{NativeID: "hc-gone"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationErrorCodeNotFound, res.ErrorCode)
+}
+
+// Settings dropped from the desired config must be reset explicitly: an
+// UpdateHealthCheck call leaves omitted settings as they are.
+func TestHealthCheck_Update_ResetsRemovedSettingsAndDiffsTags(t *testing.T) {
+	m := &mockHealthCheckClient{}
+	expectHealthCheckRead(m, "hc-1", httpHealthCheckConfig())
+	var updated *route53.UpdateHealthCheckInput
+	m.On("UpdateHealthCheck", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { updated = args.Get(1).(*route53.UpdateHealthCheckInput) }).
+		Return(&route53.UpdateHealthCheckOutput{}, nil)
+	var tagged *route53.ChangeTagsForResourceInput
+	m.On("ChangeTagsForResource", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { tagged = args.Get(1).(*route53.ChangeTagsForResourceInput) }).
+		Return(&route53.ChangeTagsForResourceOutput{}, nil)
+
+	res, err := (&HealthCheck{}).updateWithClient(context.Background(), m, &resource.UpdateRequest{
+		NativeID:          "hc-1",
+		PriorProperties:   json.RawMessage(`{"HealthCheckConfig": {"Type": "HTTPS"}, "HealthCheckTags": [{"Key": "Team", "Value": "web"}, {"Key": "Env", "Value": "dev"}]}`),
+		DesiredProperties: json.RawMessage(`{"HealthCheckConfig": {"Type": "HTTPS", "FullyQualifiedDomainName": "api.example.com", "Port": 443, "FailureThreshold": 5}, "HealthCheckTags": [{"Key": "Env", "Value": "prod"}]}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, res.ProgressResult.OperationStatus)
+
+	assert.Equal(t, int64(4), aws.ToInt64(updated.HealthCheckVersion))
+	assert.Equal(t, int32(5), aws.ToInt32(updated.FailureThreshold))
+	assert.Equal(t, []types.ResettableElementName{types.ResettableElementNameResourcePath}, updated.ResetElements)
+
+	assert.Equal(t, []string{"Team"}, tagged.RemoveTagKeys)
+	require.Len(t, tagged.AddTags, 1)
+	assert.Equal(t, "prod", aws.ToString(tagged.AddTags[0].Value))
+}
+
+func TestHealthCheck_Delete_SucceedsWhenAlreadyGone(t *testing.T) {
+	m := &mockHealthCheckClient{}
+	m.On("DeleteHealthCheck", mock.Anything, mock.Anything).Return(nil, &types.NoSuchHealthCheck{})
+
+	res, err := (&HealthCheck{}).deleteWithClient(context.Background(), m, &resource.DeleteRequest{NativeID: "hc-gone"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, res.ProgressResult.OperationStatus)
+}
+
+func TestHealthCheck_List_Pages(t *testing.T) {
+	m := &mockHealthCheckClient{}
+	m.On("ListHealthChecks", mock.Anything, mock.MatchedBy(func(in *route53.ListHealthChecksInput) bool {
+		return aws.ToString(in.Marker) == "m1"
+	})).Return(&route53.ListHealthChecksOutput{
+		HealthChecks: []types.HealthCheck{{Id: aws.String("hc-1")}, {Id: aws.String("hc-2")}},
+		IsTruncated:  true,
+		NextMarker:   aws.String("m2"),
+	}, nil)
+
+	res, err := (&HealthCheck{}).listWithClient(context.Background(), m, &resource.ListRequest{PageToken: aws.String("m1"), PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hc-1", "hc-2"}, res.NativeIDs)
+	assert.Equal(t, "m2", aws.ToString(res.NextPageToken))
+}
+
+func TestBuildRecordSet_HealthCheckId(t *testing.T) {
+	rrs, err := buildRecordSet(map[string]any{
+		"Name":            "api.example.com",
+		"Type":            "A",
+		"ResourceRecords": []any{"192.0.2.1"},
+		"SetIdentifier":   "primary",
+		"Failover":        "PRIMARY",
+		"HealthCheckId":   "hc-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hc-1", aws.ToString(rrs.HealthCheckId))
+
+	props := buildReadProperties(rrs, "Z123", "api.example.com.", "A")
+	assert.Equal(t, "hc-1", props["HealthCheckId"])
+}
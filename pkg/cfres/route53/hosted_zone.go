@@ -0,0 +1,84 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package route53
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/utils"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+var (
+	hostedZoneIDsMu sync.Mutex
+	// hostedZoneIDs caches the zone IDs resolved from names, keyed by target
+	// config and canonical zone name. A zone's ID never changes, and a
+	// deleted zone fails the record change that uses it.
+	hostedZoneIDs = map[string]string{}
+)
+
+// recordSetZoneID returns the HostedZoneId of a record's properties or, like
+// CloudFormation, resolves it from HostedZoneName when only the name is set.
+func recordSetZoneID(ctx context.Context, cfg *config.Config, client route53ClientInterface, properties map[string]any) (string, error) {
+	if id, ok := properties["HostedZoneId"].(string); ok && id != "" {
+		return id, nil
+	}
+	name, err := utils.GetStringProperty(properties, "HostedZoneName")
+	if err != nil {
+		return "", fmt.Errorf("invalid HostedZoneId: either HostedZoneId or HostedZoneName is required")
+	}
+	return resolveHostedZoneID(ctx, cfg, client, name)
+}
+
+// resolveHostedZoneID looks up the ID of the hosted zone called name. A name
+// shared by several zones, such as a public zone and a private one, is
+// ambiguous and has to be given as an ID instead.
+func resolveHostedZoneID(ctx context.Context, cfg *config.Config, client route53ClientInterface, name string) (string, error) {
+	name = canonicalName(strings.ToLower(name))
+	key := name
+	if cfg != nil {
+		key = cfg.Key() + "|" + name
+	}
+
+	hostedZoneIDsMu.Lock()
+	id, ok := hostedZoneIDs[key]
+	hostedZoneIDsMu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	// Zones are listed in name order starting at name, so the matches come
+	// first.
+	result, err := client.ListHostedZonesByName(ctx, &route53.ListHostedZonesByNameInput{
+		DNSName: aws.String(name),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to look up hosted zone %s: %w", name, err)
+	}
+	var matches []string
+	for _, zone := range result.HostedZones {
+		if canonicalName(strings.ToLower(aws.ToString(zone.Name))) == name {
+			matches = append(matches, strings.TrimPrefix(aws.ToString(zone.Id), "/hostedzone/"))
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("hosted zone %s not found", name)
+	case 1:
+	default:
+		return "", fmt.Errorf("hosted zone name %s is ambiguous (%s): set HostedZoneId instead", name, strings.Join(matches, ", "))
+	}
+
+	hostedZoneIDsMu.Lock()
+	hostedZoneIDs[key] = matches[0]
+	hostedZoneIDsMu.Unlock()
+	return matches[0], nil
+}
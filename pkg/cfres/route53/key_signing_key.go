@@ -0,0 +1,468 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package route53
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/google/uuid"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/utils"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+const keySigningKeyType = "AWS::Route53::KeySigningKey"
+
+// Key-signing key states a provisioner asks Route53 for.
+const (
+	keySigningKeyActive   = "ACTIVE"
+	keySigningKeyInactive = "INACTIVE"
+)
+
+type keySigningKeyClientInterface interface {
+	dnssecGetter
+	hostedZoneLister
+	CreateKeySigningKey(ctx context.Context, params *route53.CreateKeySigningKeyInput, optFns ...func(*route53.Options)) (*route53.CreateKeySigningKeyOutput, error)
+	ActivateKeySigningKey(ctx context.Context, params *route53.ActivateKeySigningKeyInput, optFns ...func(*route53.Options)) (*route53.ActivateKeySigningKeyOutput, error)
+	DeactivateKeySigningKey(ctx context.Context, params *route53.DeactivateKeySigningKeyInput, optFns ...func(*route53.Options)) (*route53.DeactivateKeySigningKeyOutput, error)
+	DeleteKeySigningKey(ctx context.Context, params *route53.DeleteKeySigningKeyInput, optFns ...func(*route53.Options)) (*route53.DeleteKeySigningKeyOutput, error)
+}
+
+// KeySigningKey provisions the KMS-backed key-signing keys of a hosted zone.
+// Its NativeID is "<hostedZoneId>|<name>", which is also the type's
+// CloudFormation Ref. Route53 activates, deactivates and deletes keys in the
+// background, so Status waits for the key to reach the requested state. An
+// active key is deactivated before it is deleted, as Route53 requires.
+type KeySigningKey struct {
+	cfg *config.Config
+}
+
+var _ prov.Provisioner = &KeySigningKey{}
+
+func init() {
+	registry.Register(keySigningKeyType,
+		[]resource.Operation{
+			resource.OperationCreate,
+			resource.OperationRead,
+			resource.OperationUpdate,
+			resource.OperationDelete,
+			resource.OperationCheckStatus,
+			resource.OperationList,
+		},
+		func(cfg *config.Config) prov.Provisioner {
+			return &KeySigningKey{cfg: cfg}
+		})
+}
+
+func keySigningKeyNativeID(hostedZoneID, name string) string {
+	return hostedZoneID + "|" + name
+}
+
+func parseKeySigningKeyNativeID(nativeID string) (string, string, error) {
+	parts := strings.SplitN(nativeID, "|", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid NativeID %q: expected <hostedZoneId>|<name>", nativeID)
+	}
+	return parts[0], parts[1], nil
+}
+
+// keySigningKeyRequestID carries the operation and the state the key should
+// end up in, so Status knows what it is waiting for. A delete has no target
+// state: it is done once the key is gone.
+func keySigningKeyRequestID(operation resource.Operation, nativeID, target string) string {
+	return string(operation) + "|" + nativeID + "|" + target
+}
+
+func parseKeySigningKeyRequestID(requestID string) (resource.Operation, string, string, error) {
+	parts := strings.Split(requestID, "|")
+	if len(parts) != 4 {
+		return "", "", "", fmt.Errorf("invalid RequestID %q: expected <operation>|<hostedZoneId>|<name>|<status>", requestID)
+	}
+	return resource.Operation(parts[0]), keySigningKeyNativeID(parts[1], parts[2]), parts[3], nil
+}
+
+func (k *KeySigningKey) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	cfg, err := k.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return k.createWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (k *KeySigningKey) createWithClient(ctx context.Context, client keySigningKeyClientInterface, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	var properties map[string]any
+	if err := json.Unmarshal(request.Properties, &properties); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+	hostedZoneID, err := utils.GetStringProperty(properties, "HostedZoneId")
+	if err != nil {
+		return nil, fmt.Errorf("invalid HostedZoneId: %w", err)
+	}
+	name, err := utils.GetStringProperty(properties, "Name")
+	if err != nil {
+		return nil, fmt.Errorf("invalid Name: %w", err)
+	}
+	kmsArn, err := utils.GetStringProperty(properties, "KeyManagementServiceArn")
+	if err != nil {
+		return nil, fmt.Errorf("invalid KeyManagementServiceArn: %w", err)
+	}
+	status, err := keySigningKeyStatus(properties)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = client.CreateKeySigningKey(ctx, &route53.CreateKeySigningKeyInput{
+		CallerReference:         aws.String(uuid.NewString()),
+		HostedZoneId:            aws.String(hostedZoneID),
+		KeyManagementServiceArn: aws.String(kmsArn),
+		Name:                    aws.String(name),
+		Status:                  aws.String(status),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key-signing key: %w", err)
+	}
+	nativeID := keySigningKeyNativeID(hostedZoneID, name)
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusInProgress,
+			RequestID:       keySigningKeyRequestID(resource.OperationCreate, nativeID, status),
+			NativeID:        nativeID,
+		},
+	}, nil
+}
+
+func (k *KeySigningKey) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	cfg, err := k.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return k.readWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (k *KeySigningKey) readWithClient(ctx context.Context, client keySigningKeyClientInterface, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	key, err := findKeySigningKey(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return &resource.ReadResult{
+			ResourceType: request.ResourceType,
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	props, err := keySigningKeyProperties(request.NativeID, key)
+	if err != nil {
+		return nil, err
+	}
+	return &resource.ReadResult{
+		ResourceType: keySigningKeyType,
+		Properties:   string(props),
+	}, nil
+}
+
+func (k *KeySigningKey) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	cfg, err := k.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return k.updateWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+// updateWithClient activates or deactivates the key. Status is the only
+// property that can change in place.
+func (k *KeySigningKey) updateWithClient(ctx context.Context, client keySigningKeyClientInterface, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	var desired map[string]any
+	if err := json.Unmarshal(request.DesiredProperties, &desired); err != nil {
+		return nil, fmt.Errorf("failed to parse desired state properties: %w", err)
+	}
+	status, err := keySigningKeyStatus(desired)
+	if err != nil {
+		return nil, err
+	}
+	key, err := findKeySigningKey(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("key-signing key %s not found", request.NativeID)
+	}
+
+	if aws.ToString(key.Status) == status {
+		props, err := keySigningKeyProperties(request.NativeID, key)
+		if err != nil {
+			return nil, err
+		}
+		return &resource.UpdateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:          resource.OperationUpdate,
+				OperationStatus:    resource.OperationStatusSuccess,
+				NativeID:           request.NativeID,
+				ResourceProperties: props,
+			},
+		}, nil
+	}
+
+	if status == keySigningKeyActive {
+		err = activateKeySigningKey(ctx, client, request.NativeID)
+	} else {
+		err = deactivateKeySigningKey(ctx, client, request.NativeID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusInProgress,
+			RequestID:       keySigningKeyRequestID(resource.OperationUpdate, request.NativeID, status),
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (k *KeySigningKey) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	cfg, err := k.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return k.deleteWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+// deleteWithClient deletes an inactive key straight away. An active key is
+// deactivated first, and Status deletes it once Route53 reports it inactive.
+func (k *KeySigningKey) deleteWithClient(ctx context.Context, client keySigningKeyClientInterface, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	key, err := findKeySigningKey(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return &resource.DeleteResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationDelete,
+				OperationStatus: resource.OperationStatusSuccess,
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
+
+	switch aws.ToString(key.Status) {
+	case keySigningKeyActive:
+		err = deactivateKeySigningKey(ctx, client, request.NativeID)
+	case keySigningKeyInactive:
+		err = deleteKeySigningKey(ctx, client, request.NativeID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			RequestID:       keySigningKeyRequestID(resource.OperationDelete, request.NativeID, ""),
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (k *KeySigningKey) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	cfg, err := k.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return k.statusWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (k *KeySigningKey) statusWithClient(ctx context.Context, client keySigningKeyClientInterface, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	operation, nativeID, target, err := parseKeySigningKeyRequestID(request.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	key, err := findKeySigningKey(ctx, client, nativeID)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := &resource.ProgressResult{
+		Operation:       operation,
+		OperationStatus: resource.OperationStatusInProgress,
+		RequestID:       request.RequestID,
+		NativeID:        nativeID,
+	}
+	status := ""
+	if key != nil {
+		status = aws.ToString(key.Status)
+	}
+	switch {
+	case key == nil && operation == resource.OperationDelete:
+		pr.OperationStatus = resource.OperationStatusSuccess
+	case key == nil:
+		pr.OperationStatus = resource.OperationStatusFailure
+		pr.ErrorCode = resource.OperationErrorCodeNotFound
+		pr.StatusMessage = fmt.Sprintf("key-signing key %s not found", nativeID)
+	case status == dnssecActionNeeded || status == dnssecInternalFailure:
+		pr.OperationStatus = resource.OperationStatusFailure
+		pr.ErrorCode = resource.OperationErrorCodeGeneralServiceException
+		pr.StatusMessage = aws.ToString(key.StatusMessage)
+	case operation == resource.OperationDelete && status == keySigningKeyInactive:
+		// The deactivation Delete started has finished.
+		if err := deleteKeySigningKey(ctx, client, nativeID); err != nil {
+			return nil, err
+		}
+		pr.StatusMessage = "deleting key-signing key"
+	case operation != resource.OperationDelete && status == target:
+		props, err := keySigningKeyProperties(nativeID, key)
+		if err != nil {
+			return nil, err
+		}
+		pr.OperationStatus = resource.OperationStatusSuccess
+		pr.ResourceProperties = props
+	default:
+		pr.StatusMessage = fmt.Sprintf("key-signing key is %s", status)
+	}
+	return &resource.StatusResult{ProgressResult: pr}, nil
+}
+
+func (k *KeySigningKey) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	cfg, err := k.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return k.listWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+// listWithClient pages through the public hosted zones and returns the keys
+// of each.
+func (k *KeySigningKey) listWithClient(ctx context.Context, client keySigningKeyClientInterface, request *resource.ListRequest) (*resource.ListResult, error) {
+	zones, next, err := listPublicHostedZones(ctx, client, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var nativeIDs []string
+	for _, zone := range zones {
+		dnssec, err := getDNSSEC(ctx, client, zone)
+		if err != nil {
+			return nil, err
+		}
+		if dnssec == nil {
+			continue
+		}
+		for _, key := range dnssec.KeySigningKeys {
+			nativeIDs = append(nativeIDs, keySigningKeyNativeID(zone, aws.ToString(key.Name)))
+		}
+	}
+	return &resource.ListResult{NativeIDs: nativeIDs, NextPageToken: next}, nil
+}
+
+func keySigningKeyStatus(properties map[string]any) (string, error) {
+	status := keySigningKeyActive
+	if s, ok := properties["Status"].(string); ok && s != "" {
+		status = s
+	}
+	if status != keySigningKeyActive && status != keySigningKeyInactive {
+		return "", fmt.Errorf("invalid Status %q: must be %s or %s", status, keySigningKeyActive, keySigningKeyInactive)
+	}
+	return status, nil
+}
+
+// findKeySigningKey returns the key with the given NativeID, or nil when it or
+// its hosted zone doesn't exist.
+func findKeySigningKey(ctx context.Context, client dnssecGetter, nativeID string) (*types.KeySigningKey, error) {
+	hostedZoneID, name, err := parseKeySigningKeyNativeID(nativeID)
+	if err != nil {
+		return nil, err
+	}
+	dnssec, err := getDNSSEC(ctx, client, hostedZoneID)
+	if err != nil || dnssec == nil {
+		return nil, err
+	}
+	for i := range dnssec.KeySigningKeys {
+		if aws.ToString(dnssec.KeySigningKeys[i].Name) == name {
+			return &dnssec.KeySigningKeys[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func activateKeySigningKey(ctx context.Context, client keySigningKeyClientInterface, nativeID string) error {
+	hostedZoneID, name, err := parseKeySigningKeyNativeID(nativeID)
+	if err != nil {
+		return err
+	}
+	_, err = client.ActivateKeySigningKey(ctx, &route53.ActivateKeySigningKeyInput{
+		HostedZoneId: aws.String(hostedZoneID),
+		Name:         aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to activate key-signing key %s: %w", nativeID, err)
+	}
+	return nil
+}
+
+func deactivateKeySigningKey(ctx context.Context, client keySigningKeyClientInterface, nativeID string) error {
+	hostedZoneID, name, err := parseKeySigningKeyNativeID(nativeID)
+	if err != nil {
+		return err
+	}
+	_, err = client.DeactivateKeySigningKey(ctx, &route53.DeactivateKeySigningKeyInput{
+		HostedZoneId: aws.String(hostedZoneID),
+		Name:         aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deactivate key-signing key %s: %w", nativeID, err)
+	}
+	return nil
+}
+
+func deleteKeySigningKey(ctx context.Context, client keySigningKeyClientInterface, nativeID string) error {
+	hostedZoneID, name, err := parseKeySigningKeyNativeID(nativeID)
+	if err != nil {
+		return err
+	}
+	_, err = client.DeleteKeySigningKey(ctx, &route53.DeleteKeySigningKeyInput{
+		HostedZoneId: aws.String(hostedZoneID),
+		Name:         aws.String(name),
+	})
+	if err != nil {
+		var notFound *types.NoSuchKeySigningKey
+		if errors.As(err, &notFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete key-signing key %s: %w", nativeID, err)
+	}
+	return nil
+}
+
+func keySigningKeyProperties(nativeID string, key *types.KeySigningKey) (json.RawMessage, error) {
+	hostedZoneID, name, err := parseKeySigningKeyNativeID(nativeID)
+	if err != nil {
+		return nil, err
+	}
+	props := map[string]any{
+		"HostedZoneId":            hostedZoneID,
+		"Name":                    name,
+		"KeyManagementServiceArn": aws.ToString(key.KmsArn),
+		"Status":                  aws.ToString(key.Status),
+	}
+	data, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal properties: %w", err)
+	}
+	return data, nil
+}
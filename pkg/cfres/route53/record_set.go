@@ -6,6 +6,7 @@ package route53
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -30,6 +31,7 @@ type MetaDataRecordSet struct {
 	HostedZoneID    string   `json:"HostedZoneId"`
 	Name            string   `json:"Name"`
 	Type            string   `json:"Type"`
+	SetIdentifier   string   `json:"SetIdentifier,omitempty"`
 	ResourceRecords []string `json:"ResourceRecords,omitempty"`
 	TTL             int64    `json:"-"` // Don't unmarshal directly
 	AliasTarget     *struct {
@@ -90,11 +92,7 @@ func (m *MetaDataRecordSet) MarshalJSON() ([]byte, error) {
 	})
 }
 func (m *MetaDataRecordSet) NativeID() string {
-	name := m.Name
-	if !strings.HasSuffix(name, ".") {
-		name = name + "."
-	}
-	return fmt.Sprintf("%s|%s|%s", m.HostedZoneID, name, m.Type)
+	return nativeID(m.HostedZoneID, m.Name, m.Type, m.SetIdentifier)
 }
 
 // ParseMetaDataRecordSet parses a metadata JSON string into a MetaDataRecordSet struct.
@@ -127,79 +125,37 @@ func (r RecordSet) Create(ctx context.Context, request *resource.CreateRequest)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load AWS config: %w", err)
 	}
+	return r.createWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
 
-	client := route53.NewFromConfig(cfg)
-
+func (r RecordSet) createWithClient(ctx context.Context, client route53ClientInterface, request *resource.CreateRequest) (*resource.CreateResult, error) {
 	// Parse properties from JSON
 	var properties map[string]any
 	if err := json.Unmarshal(request.Properties, &properties); err != nil {
 		return nil, fmt.Errorf("failed to parse properties: %w", err)
 	}
 
-	// Extract required properties with validation
-	hostedZoneID, err := utils.GetStringProperty(properties, "HostedZoneId")
-	if err != nil {
-		return nil, fmt.Errorf("invalid HostedZoneId: %w", err)
-	}
-
-	name, err := utils.GetStringProperty(properties, "Name")
+	hostedZoneID, err := recordSetZoneID(ctx, r.cfg, client, properties)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Name: %w", err)
+		return nil, err
 	}
-
-	recordType, err := utils.GetStringProperty(properties, "Type")
+	rrs, err := buildRecordSet(properties)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Type: %w", err)
+		return nil, err
 	}
 
-	// Get TTL with default value
-	ttl := utils.GetInt64Property(properties, "TTL", 300)
-
-	var aliasTarget *types.AliasTarget
-	if aliasTargetRaw, hasAlias := properties["AliasTarget"].(map[string]any); hasAlias {
-		aliasTarget, err = buildAliasTarget(aliasTargetRaw)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	// Extract and validate resource records
-	var records []types.ResourceRecord
-	if aliasTarget == nil {
-		if resourceRecordsRaw, ok := properties["ResourceRecords"].([]any); ok {
-			records = make([]types.ResourceRecord, 0, len(resourceRecordsRaw))
-			for _, record := range resourceRecordsRaw {
-				if value, ok := record.(string); ok && value != "" {
-					records = append(records, types.ResourceRecord{
-						Value: aws.String(value),
-					})
-				}
-			}
-		}
-		if len(records) == 0 {
-			return nil, fmt.Errorf("at least one valid ResourceRecord is required")
-		}
-	}
-
-	rrs := &types.ResourceRecordSet{
-		Name: aws.String(name),
-		Type: types.RRType(recordType),
-	}
-
-	if aliasTarget != nil {
-		rrs.AliasTarget = aliasTarget
-	} else {
-		rrs.ResourceRecords = records
-		rrs.TTL = aws.Int64(ttl)
+	// Create the record set. An UPSERT converges onto a record that already
+	// exists, e.g. one left behind by an interrupted apply.
+	action := types.ChangeActionCreate
+	if r.upsert(properties) {
+		action = types.ChangeActionUpsert
 	}
-
-	// Create the record set
 	input := &route53.ChangeResourceRecordSetsInput{
 		HostedZoneId: aws.String(hostedZoneID),
 		ChangeBatch: &types.ChangeBatch{
 			Changes: []types.Change{
 				{
-					Action:            types.ChangeActionCreate,
+					Action:            action,
 					ResourceRecordSet: rrs,
 				},
 			},
@@ -216,19 +172,29 @@ func (r RecordSet) Create(ctx context.Context, request *resource.CreateRequest)
 			Operation:       resource.OperationCreate,
 			OperationStatus: resource.OperationStatusInProgress,
 			RequestID:       *result.ChangeInfo.Id,
-			NativeID:        nativeID(hostedZoneID, name, recordType),
+			NativeID:        recordSetNativeID(hostedZoneID, rrs),
 		},
 	}, nil
 }
 
+// upsert reports whether Create should overwrite an existing record: the
+// resource's Upsert property when set, otherwise the target's Route53Upsert.
+func (r RecordSet) upsert(properties map[string]any) bool {
+	if v, ok := properties["Upsert"].(bool); ok {
+		return v
+	}
+	return r.cfg != nil && r.cfg.Route53Upsert
+}
+
 func (r RecordSet) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
 	cfg, err := r.cfg.ToAwsConfig(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load AWS config: %w", err)
 	}
+	return r.updateWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
 
-	client := route53.NewFromConfig(cfg)
-
+func (r RecordSet) updateWithClient(ctx context.Context, client route53ClientInterface, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
 	// Parse properties from JSON for both prior and desired states
 	var priorProperties, desiredProperties map[string]any
 	if err := json.Unmarshal(request.PriorProperties, &priorProperties); err != nil {
@@ -239,13 +205,13 @@ func (r RecordSet) Update(ctx context.Context, request *resource.UpdateRequest)
 	}
 
 	// Extract required properties for both states
-	priorHostedZoneID, err := utils.GetStringProperty(priorProperties, "HostedZoneId")
+	priorHostedZoneID, err := recordSetZoneID(ctx, r.cfg, client, priorProperties)
 	if err != nil {
-		return nil, fmt.Errorf("invalid prior HostedZoneId: %w", err)
+		return nil, fmt.Errorf("prior %w", err)
 	}
-	desiredHostedZoneID, err := utils.GetStringProperty(desiredProperties, "HostedZoneId")
+	desiredHostedZoneID, err := recordSetZoneID(ctx, r.cfg, client, desiredProperties)
 	if err != nil {
-		return nil, fmt.Errorf("invalid desired HostedZoneId: %w", err)
+		return nil, fmt.Errorf("desired %w", err)
 	}
 
 	// Verify hosted zone IDs match (can't move between zones)
@@ -253,117 +219,36 @@ func (r RecordSet) Update(ctx context.Context, request *resource.UpdateRequest)
 		return nil, fmt.Errorf("cannot update record between different hosted zones")
 	}
 
-	// Create prior ResourceRecordSet
-	priorName, err := utils.GetStringProperty(priorProperties, "Name")
-	if err != nil {
-		return nil, fmt.Errorf("invalid prior Name: %w", err)
-	}
-	priorType, err := utils.GetStringProperty(priorProperties, "Type")
-	if err != nil {
-		return nil, fmt.Errorf("invalid prior Type: %w", err)
-	}
-	priorTTL := utils.GetInt64Property(priorProperties, "TTL", 300)
-
-	// Create desired ResourceRecordSet
-	desiredName, err := utils.GetStringProperty(desiredProperties, "Name")
+	priorRrs, err := buildRecordSet(priorProperties)
 	if err != nil {
-		return nil, fmt.Errorf("invalid desired Name: %w", err)
+		return nil, fmt.Errorf("prior %w", err)
 	}
-	desiredType, err := utils.GetStringProperty(desiredProperties, "Type")
+	desiredRrs, err := buildRecordSet(desiredProperties)
 	if err != nil {
-		return nil, fmt.Errorf("invalid desired Type: %w", err)
-	}
-	desiredTTL := utils.GetInt64Property(desiredProperties, "TTL", 300)
-
-	// Handle prior AliasTarget
-	var priorAliasTarget *types.AliasTarget
-	if priorAliasTargetRaw, hasAlias := priorProperties["AliasTarget"].(map[string]any); hasAlias {
-		priorAliasTarget, err = buildAliasTarget(priorAliasTargetRaw)
-		if err != nil {
-			return nil, fmt.Errorf("prior %w", err)
-		}
+		return nil, fmt.Errorf("desired %w", err)
 	}
 
-	// Handle desired AliasTarget
-	var desiredAliasTarget *types.AliasTarget
-	if desiredAliasTargetRaw, hasAlias := desiredProperties["AliasTarget"].(map[string]any); hasAlias {
-		desiredAliasTarget, err = buildAliasTarget(desiredAliasTargetRaw)
+	// A record keeping its identity is replaced in place. One whose name,
+	// type or set identifier changes is a different record: the old one is
+	// deleted by its live values, since a delete-by-value is rejected unless
+	// it matches Route53's canonical record exactly.
+	var changes []types.Change
+	if sameRecord(priorRrs, desiredRrs) {
+		changes = []types.Change{{Action: types.ChangeActionUpsert, ResourceRecordSet: desiredRrs}}
+	} else {
+		live, err := findRecordSet(ctx, client, desiredHostedZoneID, aws.ToString(priorRrs.Name), string(priorRrs.Type), aws.ToString(priorRrs.SetIdentifier))
 		if err != nil {
-			return nil, fmt.Errorf("desired %w", err)
-		}
-	}
-
-	// Handle ResourceRecords for prior and desired if not using AliasTarget
-	var priorRecords, desiredRecords []types.ResourceRecord
-	if priorAliasTarget == nil {
-		if oldResourceRecordsRaw, ok := priorProperties["ResourceRecords"].([]any); ok {
-			priorRecords = make([]types.ResourceRecord, 0, len(oldResourceRecordsRaw))
-			for _, record := range oldResourceRecordsRaw {
-				if value, ok := record.(string); ok && value != "" {
-					priorRecords = append(priorRecords, types.ResourceRecord{
-						Value: aws.String(value),
-					})
-				}
-			}
+			return nil, fmt.Errorf("failed to read prior record set: %w", err)
 		}
-		if len(priorRecords) == 0 {
-			return nil, fmt.Errorf("at least one valid ResourceRecord is required for prior record when not using AliasTarget")
+		if live != nil {
+			changes = append(changes, types.Change{Action: types.ChangeActionDelete, ResourceRecordSet: live})
 		}
-	}
-
-	if desiredAliasTarget == nil {
-		if newResourceRecordsRaw, ok := desiredProperties["ResourceRecords"].([]any); ok {
-			desiredRecords = make([]types.ResourceRecord, 0, len(newResourceRecordsRaw))
-			for _, record := range newResourceRecordsRaw {
-				if value, ok := record.(string); ok && value != "" {
-					desiredRecords = append(desiredRecords, types.ResourceRecord{
-						Value: aws.String(value),
-					})
-				}
-			}
-		}
-		if len(desiredRecords) == 0 {
-			return nil, fmt.Errorf("at least one valid ResourceRecord is required for desired record when not using AliasTarget")
-		}
-	}
-
-	// Create the old and desired ResourceRecordSets
-	priorRrs := &types.ResourceRecordSet{
-		Name: aws.String(priorName),
-		Type: types.RRType(priorType),
-	}
-	if priorAliasTarget != nil {
-		priorRrs.AliasTarget = priorAliasTarget
-	} else {
-		priorRrs.ResourceRecords = priorRecords
-		priorRrs.TTL = aws.Int64(priorTTL)
-	}
-
-	desiredRrs := &types.ResourceRecordSet{
-		Name: aws.String(desiredName),
-		Type: types.RRType(desiredType),
-	}
-	if desiredAliasTarget != nil {
-		desiredRrs.AliasTarget = desiredAliasTarget
-	} else {
-		desiredRrs.ResourceRecords = desiredRecords
-		desiredRrs.TTL = aws.Int64(desiredTTL)
+		changes = append(changes, types.Change{Action: types.ChangeActionCreate, ResourceRecordSet: desiredRrs})
 	}
 
 	input := &route53.ChangeResourceRecordSetsInput{
 		HostedZoneId: aws.String(desiredHostedZoneID),
-		ChangeBatch: &types.ChangeBatch{
-			Changes: []types.Change{
-				{
-					Action:            types.ChangeActionDelete,
-					ResourceRecordSet: priorRrs,
-				},
-				{
-					Action:            types.ChangeActionCreate,
-					ResourceRecordSet: desiredRrs,
-				},
-			},
-		},
+		ChangeBatch:  &types.ChangeBatch{Changes: changes},
 	}
 
 	result, err := client.ChangeResourceRecordSets(ctx, input)
@@ -376,7 +261,7 @@ func (r RecordSet) Update(ctx context.Context, request *resource.UpdateRequest)
 			Operation:       resource.OperationUpdate,
 			OperationStatus: resource.OperationStatusInProgress,
 			RequestID:       *result.ChangeInfo.Id,
-			NativeID:        nativeID(desiredHostedZoneID, desiredName, desiredType),
+			NativeID:        recordSetNativeID(desiredHostedZoneID, desiredRrs),
 		},
 	}, nil
 }
@@ -386,18 +271,19 @@ func (r RecordSet) Delete(ctx context.Context, request *resource.DeleteRequest)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load AWS config: %w", err)
 	}
+	return r.deleteWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
 
-	client := route53.NewFromConfig(cfg)
-
-	// Always read the current record before attempting delete to get the exact state
-	readRes, err := r.Read(ctx, &resource.ReadRequest{
-		NativeID: request.NativeID,
-	})
+func (r RecordSet) deleteWithClient(ctx context.Context, client route53ClientInterface, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	hostedZoneID, name, recordType, setIdentifier, err := parseNativeID(request.NativeID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read record before delete: %w", err)
+		return nil, err
 	}
 
-	if readRes.ErrorCode == resource.OperationErrorCodeNotFound {
+	// Always read the current record before attempting delete: Route53 only
+	// deletes a record whose values match exactly.
+	live, err := findRecordSet(ctx, client, hostedZoneID, name, recordType, setIdentifier)
+	if err != nil || live == nil {
 		// Route does not exist, nothing to delete
 		return &resource.DeleteResult{
 			ProgressResult: &resource.ProgressResult{
@@ -408,55 +294,13 @@ func (r RecordSet) Delete(ctx context.Context, request *resource.DeleteRequest)
 		}, nil
 	}
 
-	meta, err := ParseMetaDataRecordSet(json.RawMessage(readRes.Properties))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse metadata: %w", err)
-	}
-
-	hostedZoneID := meta.HostedZoneID
-	name := meta.Name
-	if !strings.HasSuffix(name, ".") {
-		name = name + "."
-	}
-	recordType := meta.Type
-
-	rrs := &types.ResourceRecordSet{
-		Name: aws.String(name),
-		Type: types.RRType(recordType),
-	}
-
-	// Handle AliasTarget if present
-	if meta.AliasTarget != nil {
-		rrs.AliasTarget, err = buildAliasTarget(map[string]any{
-			"DNSName":              meta.AliasTarget.DNSName,
-			"HostedZoneId":         meta.AliasTarget.HostedZoneID,
-			"EvaluateTargetHealth": meta.AliasTarget.EvaluateTargetHealth,
-		})
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		rrs.TTL = aws.Int64(meta.TTL)
-		if len(meta.ResourceRecords) > 0 {
-			records := make([]types.ResourceRecord, 0, len(meta.ResourceRecords))
-			for _, value := range meta.ResourceRecords {
-				if value != "" {
-					records = append(records, types.ResourceRecord{
-						Value: aws.String(value),
-					})
-				}
-			}
-			rrs.ResourceRecords = records
-		}
-	}
-
 	input := &route53.ChangeResourceRecordSetsInput{
 		HostedZoneId: aws.String(hostedZoneID),
 		ChangeBatch: &types.ChangeBatch{
 			Changes: []types.Change{
 				{
 					Action:            types.ChangeActionDelete,
-					ResourceRecordSet: rrs,
+					ResourceRecordSet: live,
 				},
 			},
 		},
@@ -472,7 +316,7 @@ func (r RecordSet) Delete(ctx context.Context, request *resource.DeleteRequest)
 			Operation:          resource.OperationDelete,
 			OperationStatus:    resource.OperationStatusInProgress,
 			RequestID:          *result.ChangeInfo.Id,
-			NativeID:           meta.NativeID(),
+			NativeID:           recordSetNativeID(hostedZoneID, live),
 			ResourceProperties: json.RawMessage{},
 		},
 	}, nil
@@ -483,9 +327,10 @@ func (r RecordSet) Status(ctx context.Context, request *resource.StatusRequest)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load AWS config: %w", err)
 	}
+	return r.statusWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
 
-	client := route53.NewFromConfig(cfg)
-
+func (r RecordSet) statusWithClient(ctx context.Context, client route53ClientInterface, request *resource.StatusRequest) (*resource.StatusResult, error) {
 	input := &route53.GetChangeInput{
 		Id: aws.String(request.RequestID),
 	}
@@ -503,7 +348,7 @@ func (r RecordSet) Status(ctx context.Context, request *resource.StatusRequest)
 
 		// On success, read the resource to get the final properties
 		if request.NativeID != "" {
-			readRes, readErr := r.Read(ctx, &resource.ReadRequest{
+			readRes, readErr := r.readWithClient(ctx, client, &resource.ReadRequest{
 				NativeID:     request.NativeID,
 				ResourceType: request.ResourceType,
 				TargetConfig: request.TargetConfig,
@@ -529,41 +374,17 @@ func (r RecordSet) Read(ctx context.Context, request *resource.ReadRequest) (*re
 	if err != nil {
 		return nil, fmt.Errorf("unable to load AWS config: %w", err)
 	}
-	client := route53.NewFromConfig(cfg)
-
-	// Parse NativeID: format is "zoneId|name|type"
-	parts := strings.SplitN(request.NativeID, "|", 3)
-	if len(parts) != 3 {
-		return nil, fmt.Errorf("invalid NativeID format: expected 'zoneId|name|type', got: %s", request.NativeID)
-	}
-	hostedZoneID := parts[0]
-	name := parts[1]
-	recordType := parts[2]
+	return r.readWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
 
-	// Query the record set
-	resp, err := client.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
-		HostedZoneId:    aws.String(hostedZoneID),
-		StartRecordName: aws.String(name),
-		StartRecordType: types.RRType(recordType),
-	})
+func (r RecordSet) readWithClient(ctx context.Context, client route53ClientInterface, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	hostedZoneID, name, recordType, setIdentifier, err := parseNativeID(request.NativeID)
 	if err != nil {
-		//return nil, fmt.Errorf("failed to list resource record sets: %w", err)
-		return &resource.ReadResult{
-			ResourceType: request.ResourceType,
-			ErrorCode:    resource.OperationErrorCodeNotFound,
-		}, nil
+		return nil, err
 	}
 
-	// Find exact match
-	var found *types.ResourceRecordSet
-	for _, rrs := range resp.ResourceRecordSets {
-		if aws.ToString(rrs.Name) == name && string(rrs.Type) == recordType {
-			found = &rrs
-			break
-		}
-	}
-	if found == nil {
-		//return nil, fmt.Errorf("record not found: %s %s in zone %s", name, recordType, hostedZoneId)
+	found, err := findRecordSet(ctx, client, hostedZoneID, name, recordType, setIdentifier)
+	if err != nil || found == nil {
 		return &resource.ReadResult{
 			ResourceType: request.ResourceType,
 			ErrorCode:    resource.OperationErrorCodeNotFound,
@@ -572,6 +393,7 @@ func (r RecordSet) Read(ctx context.Context, request *resource.ReadRequest) (*re
 
 	// Build properties map
 	props := buildReadProperties(found, hostedZoneID, name, recordType)
+	props["Name"] = readName(name, request.PriorProperties)
 
 	// Marshal back to JSON
 	propBytes, err := json.Marshal(props)
@@ -585,37 +407,317 @@ func (r RecordSet) Read(ctx context.Context, request *resource.ReadRequest) (*re
 	}, nil
 }
 
+// findRecordSet returns the live record set with the given identity, or nil
+// when the zone holds none.
+func findRecordSet(ctx context.Context, client route53ClientInterface, hostedZoneID, name, recordType, setIdentifier string) (*types.ResourceRecordSet, error) {
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(hostedZoneID),
+		StartRecordName: aws.String(name),
+		StartRecordType: types.RRType(recordType),
+	}
+	if setIdentifier != "" {
+		input.StartRecordIdentifier = aws.String(setIdentifier)
+	}
+	resp, err := client.ListResourceRecordSets(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find exact match
+	for _, rrs := range resp.ResourceRecordSets {
+		if canonicalName(aws.ToString(rrs.Name)) == canonicalName(name) && string(rrs.Type) == recordType &&
+			aws.ToString(rrs.SetIdentifier) == setIdentifier {
+			return &rrs, nil
+		}
+	}
+	return nil, nil
+}
+
 func (r *RecordSet) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
 	cfg, err := r.cfg.ToAwsConfig(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("unable to load AWS config: %w", err)
 	}
-	client := route53.NewFromConfig(cfg)
+	return r.listWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
 
-	hostedZoneID, ok := request.AdditionalProperties["HostedZoneId"]
-	if !ok || hostedZoneID == "" {
-		return nil, fmt.Errorf("hostedZoneId must be provided in AdditionalProperties for listing record sets")
+// listWithClient lists the record sets of the HostedZoneId in the request's
+// AdditionalProperties or, without one, of every hosted zone in the account,
+// one zone after the other.
+func (r *RecordSet) listWithClient(ctx context.Context, client route53ClientInterface, request *resource.ListRequest) (*resource.ListResult, error) {
+	var token listPageToken
+	if request.PageToken != nil {
+		token = decodeListPageToken(*request.PageToken)
+	}
+
+	hostedZoneID := request.AdditionalProperties["HostedZoneId"]
+	allZones := hostedZoneID == ""
+	if allZones {
+		hostedZoneID = token.Zone
+		if hostedZoneID == "" {
+			first, err := nextHostedZone(ctx, client, "")
+			if err != nil || first == "" {
+				return &resource.ListResult{}, err
+			}
+			hostedZoneID = first
+		}
 	}
-	res, err := client.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
-		HostedZoneId:    &hostedZoneID,
-		MaxItems:        &request.PageSize,
-		StartRecordName: request.PageToken,
-	})
+
+	nativeIDs, next, err := listZoneRecordSets(ctx, client, hostedZoneID, token, request.PageSize)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list resource record sets: %w", err)
+		return nil, err
+	}
+	if next == nil && allZones {
+		// This zone is done; carry on with the next one.
+		zone, err := nextHostedZone(ctx, client, hostedZoneID)
+		if err != nil {
+			return nil, err
+		}
+		if zone != "" {
+			next = &listPageToken{}
+		}
+		hostedZoneID = zone
 	}
 
-	var nativeIDs []string
-	for _, rrs := range res.ResourceRecordSets {
-		nativeIDs = append(nativeIDs, nativeID(request.AdditionalProperties["HostedZoneId"], *rrs.Name, string(rrs.Type)))
+	result := &resource.ListResult{NativeIDs: nativeIDs}
+	if next != nil {
+		if allZones {
+			next.Zone = hostedZoneID
+		}
+		encoded, err := next.encode()
+		if err != nil {
+			return nil, err
+		}
+		result.NextPageToken = &encoded
 	}
+	return result, nil
+}
 
-	return &resource.ListResult{
-		NativeIDs:     nativeIDs,
-		NextPageToken: res.NextRecordName,
+// listZoneRecordSets lists a page of the record sets of a hosted zone starting
+// at token, returning where the next page starts, or nil after the last one.
+func listZoneRecordSets(ctx context.Context, client route53ClientInterface, hostedZoneID string, token listPageToken, pageSize int32) ([]string, *listPageToken, error) {
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId: aws.String(hostedZoneID),
+		MaxItems:     aws.Int32(pageSize),
+	}
+	if token.Name != "" {
+		input.StartRecordName = aws.String(token.Name)
+		if token.Type != "" {
+			input.StartRecordType = types.RRType(token.Type)
+		}
+		if token.Identifier != "" {
+			input.StartRecordIdentifier = aws.String(token.Identifier)
+		}
+	}
+	res, err := client.ListResourceRecordSets(ctx, input)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list resource record sets: %w", err)
+	}
+
+	var nativeIDs []string
+	for i := range res.ResourceRecordSets {
+		nativeIDs = append(nativeIDs, recordSetNativeID(hostedZoneID, &res.ResourceRecordSets[i]))
+	}
+	if !res.IsTruncated || res.NextRecordName == nil {
+		return nativeIDs, nil, nil
+	}
+	return nativeIDs, &listPageToken{
+		Name:       aws.ToString(res.NextRecordName),
+		Type:       string(res.NextRecordType),
+		Identifier: aws.ToString(res.NextRecordIdentifier),
 	}, nil
 }
 
+// nextHostedZone returns the ID of the hosted zone listed after the zone
+// after, or the first zone when after is empty. It returns "" past the last
+// zone.
+func nextHostedZone(ctx context.Context, client route53ClientInterface, after string) (string, error) {
+	// ListHostedZones starts at the zone its marker names, so the zone
+	// following after is the second one listed.
+	input := &route53.ListHostedZonesInput{MaxItems: aws.Int32(1)}
+	if after != "" {
+		input.Marker = aws.String(after)
+		input.MaxItems = aws.Int32(2)
+	}
+	res, err := client.ListHostedZones(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to list hosted zones: %w", err)
+	}
+	for _, zone := range res.HostedZones {
+		if id := strings.TrimPrefix(aws.ToString(zone.Id), "/hostedzone/"); id != after {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+// listPageToken is where the next page of a listing starts. Route53 resumes a
+// listing from a name, type and set identifier together: records of several
+// types share a name, and records with a routing policy also share their type,
+// so resuming from the name alone repeats or skips records.
+type listPageToken struct {
+	// Zone is the hosted zone being listed when a listing spans every zone.
+	Zone       string `json:"z,omitempty"`
+	Name       string `json:"n,omitempty"`
+	Type       string `json:"t,omitempty"`
+	Identifier string `json:"i,omitempty"`
+}
+
+// encode renders the token as an opaque string, base64(JSON) so record names
+// and set identifiers can hold any character.
+func (t listPageToken) encode() (string, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode page token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeListPageToken undoes encode. Anything else is taken as a bare record
+// name, the token earlier versions issued.
+func decodeListPageToken(s string) listPageToken {
+	var token listPageToken
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || json.Unmarshal(data, &token) != nil || (token.Name == "" && token.Zone == "") {
+		return listPageToken{Name: s}
+	}
+	return token
+}
+
+// buildRecordSet converts the declared properties of a RecordSet into an AWS
+// ResourceRecordSet, including its routing policy.
+func buildRecordSet(properties map[string]any) (*types.ResourceRecordSet, error) {
+	name, err := utils.GetStringProperty(properties, "Name")
+	if err != nil {
+		return nil, fmt.Errorf("invalid Name: %w", err)
+	}
+	recordType, err := utils.GetStringProperty(properties, "Type")
+	if err != nil {
+		return nil, fmt.Errorf("invalid Type: %w", err)
+	}
+
+	rrs := &types.ResourceRecordSet{
+		Name: aws.String(canonicalName(name)),
+		Type: types.RRType(recordType),
+	}
+
+	if aliasTargetRaw, hasAlias := properties["AliasTarget"].(map[string]any); hasAlias {
+		rrs.AliasTarget, err = buildAliasTarget(aliasTargetRaw)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Extract and validate resource records
+		records := extractResourceRecords(properties)
+		if len(records) == 0 {
+			return nil, fmt.Errorf("at least one valid ResourceRecord is required")
+		}
+		rrs.ResourceRecords = records
+		// Get TTL with default value
+		rrs.TTL = aws.Int64(utils.GetInt64Property(properties, "TTL", 300))
+	}
+
+	if err := applyRoutingPolicy(rrs, properties); err != nil {
+		return nil, err
+	}
+	rrs.HealthCheckId = optionalString(properties, "HealthCheckId")
+	return rrs, nil
+}
+
+// applyRoutingPolicy sets the routing policy fields of a record. Every record
+// with a routing policy needs a SetIdentifier, which tells it apart from the
+// other records sharing its name and type.
+func applyRoutingPolicy(rrs *types.ResourceRecordSet, properties map[string]any) error {
+	if v, ok := properties["SetIdentifier"].(string); ok && v != "" {
+		rrs.SetIdentifier = aws.String(v)
+	}
+	if _, ok := properties["Weight"]; ok {
+		rrs.Weight = aws.Int64(utils.GetInt64Property(properties, "Weight", 0))
+	}
+	if v, ok := properties["Region"].(string); ok && v != "" {
+		rrs.Region = types.ResourceRecordSetRegion(v)
+	}
+	if v, ok := properties["Failover"].(string); ok && v != "" {
+		rrs.Failover = types.ResourceRecordSetFailover(v)
+	}
+	if v, ok := properties["MultiValueAnswer"].(bool); ok && v {
+		rrs.MultiValueAnswer = aws.Bool(true)
+	}
+	if geo, ok := properties["GeoLocation"].(map[string]any); ok {
+		rrs.GeoLocation = &types.GeoLocation{
+			ContinentCode:   optionalString(geo, "ContinentCode"),
+			CountryCode:     optionalString(geo, "CountryCode"),
+			SubdivisionCode: optionalString(geo, "SubdivisionCode"),
+		}
+	}
+	if geo, ok := properties["GeoProximityLocation"].(map[string]any); ok {
+		rrs.GeoProximityLocation = buildGeoProximityLocation(geo)
+	}
+
+	routed := rrs.Weight != nil || rrs.Region != "" || rrs.Failover != "" || rrs.GeoLocation != nil ||
+		rrs.GeoProximityLocation != nil || rrs.MultiValueAnswer != nil
+	if routed && rrs.SetIdentifier == nil {
+		return fmt.Errorf("SetIdentifier is required for records with a routing policy")
+	}
+	return nil
+}
+
+// buildGeoProximityLocation converts a declared GeoProximityLocation. The
+// location is one of an AWS region, a Local Zone group or coordinates; Bias
+// grows or shrinks the area routed to it.
+func buildGeoProximityLocation(geo map[string]any) *types.GeoProximityLocation {
+	location := &types.GeoProximityLocation{
+		AWSRegion:      optionalString(geo, "AWSRegion"),
+		LocalZoneGroup: optionalString(geo, "LocalZoneGroup"),
+	}
+	if _, ok := geo["Bias"]; ok {
+		location.Bias = aws.Int32(int32(utils.GetInt64Property(geo, "Bias", 0)))
+	}
+	if coordinates, ok := geo["Coordinates"].(map[string]any); ok {
+		location.Coordinates = &types.Coordinates{
+			Latitude:  optionalString(coordinates, "Latitude"),
+			Longitude: optionalString(coordinates, "Longitude"),
+		}
+	}
+	return location
+}
+
+// readGeoProximityLocation is the inverse of buildGeoProximityLocation.
+func readGeoProximityLocation(location *types.GeoProximityLocation) map[string]any {
+	props := map[string]any{}
+	if location.AWSRegion != nil {
+		props["AWSRegion"] = *location.AWSRegion
+	}
+	if location.LocalZoneGroup != nil {
+		props["LocalZoneGroup"] = *location.LocalZoneGroup
+	}
+	if location.Bias != nil {
+		props["Bias"] = *location.Bias
+	}
+	if c := location.Coordinates; c != nil {
+		props["Coordinates"] = map[string]any{
+			"Latitude":  aws.ToString(c.Latitude),
+			"Longitude": aws.ToString(c.Longitude),
+		}
+	}
+	return props
+}
+
+func optionalString(properties map[string]any, key string) *string {
+	if v, ok := properties[key].(string); ok && v != "" {
+		return aws.String(v)
+	}
+	return nil
+}
+
+// sameRecord reports whether two record sets have the same identity within
+// their hosted zone.
+func sameRecord(a, b *types.ResourceRecordSet) bool {
+	return canonicalName(aws.ToString(a.Name)) == canonicalName(aws.ToString(b.Name)) &&
+		a.Type == b.Type &&
+		aws.ToString(a.SetIdentifier) == aws.ToString(b.SetIdentifier)
+}
+
 // buildAliasTarget constructs an AWS AliasTarget from declared properties.
 // Outbound normalization mirrors Read's inbound stripping: Route53 stores DNS
 // names with a trailing dot, so it is restored here for change requests — a
@@ -667,7 +769,7 @@ func isHostnameValuedRecordType(recordType string) bool {
 func buildReadProperties(found *types.ResourceRecordSet, hostedZoneID, name, recordType string) map[string]any {
 	props := map[string]any{
 		"HostedZoneId": hostedZoneID,
-		"Name":         strings.TrimSuffix(name, "."), // remove trailing dot
+		"Name":         displayName(name),
 		"Type":         recordType,
 	}
 
@@ -699,12 +801,67 @@ func buildReadProperties(found *types.ResourceRecordSet, hostedZoneID, name, rec
 		}
 	}
 
+	if found.HealthCheckId != nil {
+		props["HealthCheckId"] = aws.ToString(found.HealthCheckId)
+	}
+	if found.SetIdentifier != nil {
+		props["SetIdentifier"] = aws.ToString(found.SetIdentifier)
+	}
+	if found.Weight != nil {
+		props["Weight"] = *found.Weight
+	}
+	if found.Region != "" {
+		props["Region"] = string(found.Region)
+	}
+	if found.Failover != "" {
+		props["Failover"] = string(found.Failover)
+	}
+	if aws.ToBool(found.MultiValueAnswer) {
+		props["MultiValueAnswer"] = true
+	}
+	if geo := found.GeoLocation; geo != nil {
+		location := map[string]any{}
+		for key, value := range map[string]*string{
+			"ContinentCode":   geo.ContinentCode,
+			"CountryCode":     geo.CountryCode,
+			"SubdivisionCode": geo.SubdivisionCode,
+		} {
+			if value != nil {
+				location[key] = *value
+			}
+		}
+		props["GeoLocation"] = location
+	}
+	if found.GeoProximityLocation != nil {
+		props["GeoProximityLocation"] = readGeoProximityLocation(found.GeoProximityLocation)
+	}
+
 	return props
 }
 
-func nativeID(hostedZoneID, name, recordType string) string {
-	if !strings.HasSuffix(name, ".") {
-		name = name + "."
+// nativeID identifies a record set as "zoneId|name|type", followed by
+// "|setIdentifier" for records with a routing policy, which share their name
+// and type with the other records of the policy.
+func nativeID(hostedZoneID, name, recordType, setIdentifier string) string {
+	id := fmt.Sprintf("%s|%s|%s", hostedZoneID, canonicalName(name), recordType)
+	if setIdentifier != "" {
+		id += "|" + setIdentifier
+	}
+	return id
+}
+
+func recordSetNativeID(hostedZoneID string, rrs *types.ResourceRecordSet) string {
+	return nativeID(hostedZoneID, aws.ToString(rrs.Name), string(rrs.Type), aws.ToString(rrs.SetIdentifier))
+}
+
+// parseNativeID undoes nativeID.
+func parseNativeID(id string) (hostedZoneID, name, recordType, setIdentifier string, err error) {
+	parts := strings.SplitN(id, "|", 4)
+	if len(parts) < 3 {
+		return "", "", "", "", fmt.Errorf("invalid NativeID format: expected 'zoneId|name|type[|setIdentifier]', got: %s", id)
+	}
+	if len(parts) == 4 {
+		setIdentifier = parts[3]
 	}
-	return fmt.Sprintf("%s|%s|%s", hostedZoneID, name, recordType)
+	return parts[0], parts[1], parts[2], setIdentifier, nil
 }
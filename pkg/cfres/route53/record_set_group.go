@@ -53,10 +53,12 @@ type recordKey struct {
 	Type string `json:"Type"`
 }
 
-type recordSetGroupClientInterface interface {
+type route53ClientInterface interface {
 	ChangeResourceRecordSets(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error)
 	ListResourceRecordSets(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error)
 	GetChange(ctx context.Context, params *route53.GetChangeInput, optFns ...func(*route53.Options)) (*route53.GetChangeOutput, error)
+	ListHostedZones(ctx context.Context, params *route53.ListHostedZonesInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesOutput, error)
+	ListHostedZonesByName(ctx context.Context, params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error)
 }
 
 type RecordSetGroup struct {
@@ -79,14 +81,6 @@ func init() {
 		})
 }
 
-// canonicalName returns the Route53 canonical form of a DNS name (trailing dot).
-func canonicalName(name string) string {
-	if !strings.HasSuffix(name, ".") {
-		return name + "."
-	}
-	return name
-}
-
 // encodeRecordSetGroupNativeID builds the composite identity for a group:
 // "<hostedZoneId>|<base64url(JSON of sorted [{Name,Type}] key list)>". The key
 // list is variable-length and record names can contain delimiter characters, so
@@ -201,7 +195,7 @@ func extractResourceRecords(rec map[string]any) []types.ResourceRecord {
 }
 
 // listAllRecordSets paginates the full set of record sets in a hosted zone.
-func listAllRecordSets(ctx context.Context, client recordSetGroupClientInterface, hostedZoneID string) ([]types.ResourceRecordSet, error) {
+func listAllRecordSets(ctx context.Context, client route53ClientInterface, hostedZoneID string) ([]types.ResourceRecordSet, error) {
 	var all []types.ResourceRecordSet
 	input := &route53.ListResourceRecordSetsInput{HostedZoneId: aws.String(hostedZoneID)}
 	for {
@@ -239,7 +233,7 @@ func (r *RecordSetGroup) Create(ctx context.Context, request *resource.CreateReq
 	return r.createWithClient(ctx, route53.NewFromConfig(awsCfg), request)
 }
 
-func (r *RecordSetGroup) createWithClient(ctx context.Context, client recordSetGroupClientInterface, request *resource.CreateRequest) (*resource.CreateResult, error) {
+func (r *RecordSetGroup) createWithClient(ctx context.Context, client route53ClientInterface, request *resource.CreateRequest) (*resource.CreateResult, error) {
 	var properties map[string]any
 	if err := json.Unmarshal(request.Properties, &properties); err != nil {
 		return nil, fmt.Errorf("failed to parse properties: %w", err)
@@ -309,7 +303,7 @@ func (r *RecordSetGroup) Update(ctx context.Context, request *resource.UpdateReq
 	return r.updateWithClient(ctx, route53.NewFromConfig(awsCfg), request)
 }
 
-func (r *RecordSetGroup) updateWithClient(ctx context.Context, client recordSetGroupClientInterface, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+func (r *RecordSetGroup) updateWithClient(ctx context.Context, client route53ClientInterface, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
 	var priorProperties, desiredProperties map[string]any
 	if err := json.Unmarshal(request.PriorProperties, &priorProperties); err != nil {
 		return nil, fmt.Errorf("failed to parse prior properties: %w", err)
@@ -434,7 +428,7 @@ func (r *RecordSetGroup) Delete(ctx context.Context, request *resource.DeleteReq
 	return r.deleteWithClient(ctx, route53.NewFromConfig(awsCfg), request)
 }
 
-func (r *RecordSetGroup) deleteWithClient(ctx context.Context, client recordSetGroupClientInterface, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+func (r *RecordSetGroup) deleteWithClient(ctx context.Context, client route53ClientInterface, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
 	hostedZoneID, keys, err := decodeRecordSetGroupNativeID(request.NativeID)
 	if err != nil {
 		return nil, err
@@ -494,7 +488,7 @@ func (r *RecordSetGroup) Status(ctx context.Context, request *resource.StatusReq
 	return r.statusWithClient(ctx, route53.NewFromConfig(awsCfg), request)
 }
 
-func (r *RecordSetGroup) statusWithClient(ctx context.Context, client recordSetGroupClientInterface, request *resource.StatusRequest) (*resource.StatusResult, error) {
+func (r *RecordSetGroup) statusWithClient(ctx context.Context, client route53ClientInterface, request *resource.StatusRequest) (*resource.StatusResult, error) {
 	result, err := client.GetChange(ctx, &route53.GetChangeInput{Id: aws.String(request.RequestID)})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get change status: %w", err)
@@ -534,7 +528,7 @@ func (r *RecordSetGroup) Read(ctx context.Context, request *resource.ReadRequest
 	return r.readWithClient(ctx, route53.NewFromConfig(awsCfg), request)
 }
 
-func (r *RecordSetGroup) readWithClient(ctx context.Context, client recordSetGroupClientInterface, request *resource.ReadRequest) (*resource.ReadResult, error) {
+func (r *RecordSetGroup) readWithClient(ctx context.Context, client route53ClientInterface, request *resource.ReadRequest) (*resource.ReadResult, error) {
 	hostedZoneID, keys, err := decodeRecordSetGroupNativeID(request.NativeID)
 	if err != nil {
 		return nil, err
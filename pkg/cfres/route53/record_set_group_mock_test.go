@@ -34,3 +34,15 @@ func (m *mockRoute53Client) GetChange(ctx context.Context, input *route53.GetCha
 	out, _ := args.Get(0).(*route53.GetChangeOutput)
 	return out, args.Error(1)
 }
+
+func (m *mockRoute53Client) ListHostedZonesByName(ctx context.Context, input *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.ListHostedZonesByNameOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockRoute53Client) ListHostedZones(ctx context.Context, input *route53.ListHostedZonesInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.ListHostedZonesOutput)
+	return out, args.Error(1)
+}
@@ -7,11 +7,17 @@
 package route53
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
 	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -127,3 +133,528 @@ func TestBuildAliasTarget_HonorsEvaluateTargetHealth(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, target.EvaluateTargetHealth, "EvaluateTargetHealth should reflect the declared value")
 }
+
+func weightedRecordProps(setIdentifier string, weight int) map[string]any {
+	return map[string]any{
+		"HostedZoneId":    "Z123",
+		"Name":            "api.example.com",
+		"Type":            "A",
+		"TTL":             60,
+		"ResourceRecords": []any{"192.0.2.1"},
+		"SetIdentifier":   setIdentifier,
+		"Weight":          weight,
+	}
+}
+
+func TestBuildRecordSet_AppliesRoutingPolicy(t *testing.T) {
+	rrs, err := buildRecordSet(map[string]any{
+		"Name":            "api.example.com",
+		"Type":            "A",
+		"ResourceRecords": []any{"192.0.2.1"},
+		"SetIdentifier":   "eu",
+		"Failover":        "PRIMARY",
+		"GeoLocation":     map[string]any{"ContinentCode": "EU"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "api.example.com.", aws.ToString(rrs.Name))
+	assert.Equal(t, "eu", aws.ToString(rrs.SetIdentifier))
+	assert.Equal(t, types.ResourceRecordSetFailoverPrimary, rrs.Failover)
+	require.NotNil(t, rrs.GeoLocation)
+	assert.Equal(t, "EU", aws.ToString(rrs.GeoLocation.ContinentCode))
+	assert.Nil(t, rrs.GeoLocation.CountryCode)
+}
+
+func TestBuildRecordSet_RequiresSetIdentifierForRoutingPolicy(t *testing.T) {
+	_, err := buildRecordSet(map[string]any{
+		"Name":            "api.example.com",
+		"Type":            "A",
+		"ResourceRecords": []any{"192.0.2.1"},
+		"Region":          "us-east-1",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SetIdentifier")
+}
+
+// Two weighted records share their name and type, so the set identifier must
+// be part of the NativeID for them not to collide.
+func TestNativeID_IncludesSetIdentifier(t *testing.T) {
+	id := nativeID("Z123", "api.example.com", "A", "blue")
+	assert.Equal(t, "Z123|api.example.com.|A|blue", id)
+	assert.NotEqual(t, nativeID("Z123", "api.example.com", "A", "green"), id)
+
+	zone, name, recordType, setIdentifier, err := parseNativeID(id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Z123", "api.example.com.", "A", "blue"}, []string{zone, name, recordType, setIdentifier})
+
+	_, _, _, setIdentifier, err = parseNativeID("Z123|www.example.com.|CNAME")
+	require.NoError(t, err)
+	assert.Empty(t, setIdentifier)
+}
+
+func TestRecordSet_Create_WeightedRecord(t *testing.T) {
+	m := &mockRoute53Client{}
+	var captured *route53.ChangeResourceRecordSetsInput
+	m.On("ChangeResourceRecordSets", mock.Anything, mock.Anything).
+		Run(captureChange(&captured)).
+		Return(changeOutput("/change/C1"), nil)
+
+	props, _ := json.Marshal(weightedRecordProps("blue", 70))
+	res, err := RecordSet{}.createWithClient(context.Background(), m, &resource.CreateRequest{Properties: props})
+	require.NoError(t, err)
+	assert.Equal(t, "Z123|api.example.com.|A|blue", res.ProgressResult.NativeID)
+
+	require.Len(t, captured.ChangeBatch.Changes, 1)
+	rrs := captured.ChangeBatch.Changes[0].ResourceRecordSet
+	assert.Equal(t, "blue", aws.ToString(rrs.SetIdentifier))
+	assert.Equal(t, int64(70), aws.ToInt64(rrs.Weight))
+}
+
+func TestRecordSet_Read_MatchesSetIdentifier(t *testing.T) {
+	m := &mockRoute53Client{}
+	m.On("ListResourceRecordSets", mock.Anything, mock.MatchedBy(func(in *route53.ListResourceRecordSetsInput) bool {
+		return aws.ToString(in.StartRecordIdentifier) == "green"
+	})).Return(&route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []types.ResourceRecordSet{
+			{
+				Name: aws.String("api.example.com."), Type: types.RRTypeA, SetIdentifier: aws.String("green"),
+				Weight: aws.Int64(30), TTL: aws.Int64(60),
+				ResourceRecords: []types.ResourceRecord{{Value: aws.String("192.0.2.2")}},
+			},
+		},
+	}, nil)
+
+	res, err := RecordSet{}.readWithClient(context.Background(), m, &resource.ReadRequest{NativeID: "Z123|api.example.com.|A|green"})
+	require.NoError(t, err)
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(res.Properties), &props))
+	assert.Equal(t, "green", props["SetIdentifier"])
+	assert.EqualValues(t, 30, props["Weight"])
+}
+
+func TestRecordSet_Read_NotFoundForOtherSetIdentifier(t *testing.T) {
+	m := &mockRoute53Client{}
+	m.On("ListResourceRecordSets", mock.Anything, mock.Anything).Return(&route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []types.ResourceRecordSet{
+			{Name: aws.String("api.example.com."), Type: types.RRTypeA, SetIdentifier: aws.String("green")},
+		},
+	}, nil)
+
+	res, err := RecordSet{}.readWithClient(context.Background(), m, &resource.ReadRequest{NativeID: "Z123|api.example.com.|A|blue"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationErrorCodeNotFound, res.ErrorCode)
+}
+
+// Changing only the weight keeps the record's identity, so it is replaced in
+// place rather than deleted and recreated.
+func TestRecordSet_Update_UpsertsWhenIdentityUnchanged(t *testing.T) {
+	m := &mockRoute53Client{}
+	var captured *route53.ChangeResourceRecordSetsInput
+	m.On("ChangeResourceRecordSets", mock.Anything, mock.Anything).
+		Run(captureChange(&captured)).
+		Return(changeOutput("/change/C2"), nil)
+
+	prior, _ := json.Marshal(weightedRecordProps("blue", 70))
+	desired, _ := json.Marshal(weightedRecordProps("blue", 20))
+	_, err := RecordSet{}.updateWithClient(context.Background(), m, &resource.UpdateRequest{
+		PriorProperties:   prior,
+		DesiredProperties: desired,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, captured.ChangeBatch.Changes, 1)
+	change := captured.ChangeBatch.Changes[0]
+	assert.Equal(t, types.ChangeActionUpsert, change.Action)
+	assert.Equal(t, int64(20), aws.ToInt64(change.ResourceRecordSet.Weight))
+	m.AssertNotCalled(t, "ListResourceRecordSets", mock.Anything, mock.Anything)
+}
+
+func TestRecordSet_List_IncludesSetIdentifier(t *testing.T) {
+	m := &mockRoute53Client{}
+	m.On("ListResourceRecordSets", mock.Anything, mock.Anything).Return(&route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []types.ResourceRecordSet{
+			{Name: aws.String("api.example.com."), Type: types.RRTypeA, SetIdentifier: aws.String("blue")},
+			{Name: aws.String("www.example.com."), Type: types.RRTypeCname},
+		},
+		IsTruncated:          true,
+		NextRecordName:       aws.String("x.example.com."),
+		NextRecordType:       types.RRTypeA,
+		NextRecordIdentifier: aws.String("green"),
+	}, nil)
+
+	rs := &RecordSet{}
+	res, err := rs.listWithClient(context.Background(), m, &resource.ListRequest{
+		PageSize:             10,
+		AdditionalProperties: map[string]string{"HostedZoneId": "Z123"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Z123|api.example.com.|A|blue", "Z123|www.example.com.|CNAME"}, res.NativeIDs)
+	require.NotNil(t, res.NextPageToken)
+
+	assert.Equal(t, listPageToken{Name: "x.example.com.", Type: "A", Identifier: "green"}, decodeListPageToken(*res.NextPageToken))
+}
+
+// The next page has to start at the exact record the previous one stopped at,
+// not just its name: several records can share a name.
+func TestRecordSet_List_ResumesFromTypeAndSetIdentifier(t *testing.T) {
+	token, err := listPageToken{Name: "api.example.com.", Type: "AAAA", Identifier: "w|1"}.encode()
+	require.NoError(t, err)
+
+	m := &mockRoute53Client{}
+	m.On("ListResourceRecordSets", mock.Anything, mock.MatchedBy(func(in *route53.ListResourceRecordSetsInput) bool {
+		return aws.ToString(in.StartRecordName) == "api.example.com." &&
+			in.StartRecordType == types.RRTypeAaaa &&
+			aws.ToString(in.StartRecordIdentifier) == "w|1"
+	})).Return(&route53.ListResourceRecordSetsOutput{}, nil)
+
+	rs := &RecordSet{}
+	_, err = rs.listWithClient(context.Background(), m, &resource.ListRequest{
+		PageSize:             10,
+		PageToken:            &token,
+		AdditionalProperties: map[string]string{"HostedZoneId": "Z123"},
+	})
+	require.NoError(t, err)
+	m.AssertExpectations(t)
+}
+
+func TestDecodeListPageToken_AcceptsBareRecordName(t *testing.T) {
+	assert.Equal(t, listPageToken{Name: "www.example.com."}, decodeListPageToken("www.example.com."))
+}
+
+func TestBuildRecordSet_MultiValueAnswer(t *testing.T) {
+	rrs, err := buildRecordSet(map[string]any{
+		"Name":             "api.example.com",
+		"Type":             "A",
+		"ResourceRecords":  []any{"192.0.2.1"},
+		"SetIdentifier":    "node-1",
+		"MultiValueAnswer": true,
+	})
+	require.NoError(t, err)
+	assert.True(t, aws.ToBool(rrs.MultiValueAnswer))
+
+	props := buildReadProperties(rrs, "Z123", "api.example.com.", "A")
+	assert.Equal(t, true, props["MultiValueAnswer"])
+
+	_, err = buildRecordSet(map[string]any{
+		"Name":             "api.example.com",
+		"Type":             "A",
+		"ResourceRecords":  []any{"192.0.2.1"},
+		"MultiValueAnswer": true,
+	})
+	assert.Error(t, err, "multivalue answer records need a SetIdentifier")
+}
+
+func TestBuildRecordSet_GeoProximityLocation(t *testing.T) {
+	var properties map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"Name": "api.example.com",
+		"Type": "A",
+		"ResourceRecords": ["192.0.2.1"],
+		"SetIdentifier": "paris",
+		"GeoProximityLocation": {
+			"Bias": -20,
+			"Coordinates": {"Latitude": "48.86", "Longitude": "2.35"}
+		}
+	}`), &properties))
+
+	rrs, err := buildRecordSet(properties)
+	require.NoError(t, err)
+	require.NotNil(t, rrs.GeoProximityLocation)
+	assert.Equal(t, int32(-20), aws.ToInt32(rrs.GeoProximityLocation.Bias))
+	require.NotNil(t, rrs.GeoProximityLocation.Coordinates)
+	assert.Equal(t, "48.86", aws.ToString(rrs.GeoProximityLocation.Coordinates.Latitude))
+	assert.Nil(t, rrs.GeoProximityLocation.AWSRegion)
+
+	props := buildReadProperties(rrs, "Z123", "api.example.com.", "A")
+	assert.Equal(t, map[string]any{
+		"Bias":        int32(-20),
+		"Coordinates": map[string]any{"Latitude": "48.86", "Longitude": "2.35"},
+	}, props["GeoProximityLocation"])
+}
+
+func TestBuildRecordSet_GeoProximityLocationByRegion(t *testing.T) {
+	rrs, err := buildRecordSet(map[string]any{
+		"Name":                 "api.example.com",
+		"Type":                 "A",
+		"ResourceRecords":      []any{"192.0.2.1"},
+		"SetIdentifier":        "use1",
+		"GeoProximityLocation": map[string]any{"AWSRegion": "us-east-1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "us-east-1", aws.ToString(rrs.GeoProximityLocation.AWSRegion))
+	assert.Nil(t, rrs.GeoProximityLocation.Bias)
+}
+
+func TestRecordSet_Create_Upsert(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   bool
+		upsert   any
+		expected types.ChangeAction
+	}{
+		{"defaults to create", false, nil, types.ChangeActionCreate},
+		{"target opts in", true, nil, types.ChangeActionUpsert},
+		{"resource opts in", false, true, types.ChangeActionUpsert},
+		{"resource opts out", true, false, types.ChangeActionCreate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockRoute53Client{}
+			var captured *route53.ChangeResourceRecordSetsInput
+			m.On("ChangeResourceRecordSets", mock.Anything, mock.Anything).
+				Run(captureChange(&captured)).
+				Return(changeOutput("/change/C3"), nil)
+
+			properties := weightedRecordProps("blue", 10)
+			if tt.upsert != nil {
+				properties["Upsert"] = tt.upsert
+			}
+			props, _ := json.Marshal(properties)
+			rs := RecordSet{cfg: &config.Config{Route53Upsert: tt.target}}
+			_, err := rs.createWithClient(context.Background(), m, &resource.CreateRequest{Properties: props})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, captured.ChangeBatch.Changes[0].Action)
+		})
+	}
+}
+
+func TestRecordSet_Create_ResolvesHostedZoneName(t *testing.T) {
+	m := &mockRoute53Client{}
+	m.On("ListHostedZonesByName", mock.Anything, mock.MatchedBy(func(in *route53.ListHostedZonesByNameInput) bool {
+		return aws.ToString(in.DNSName) == "resolve.example.com."
+	})).Return(&route53.ListHostedZonesByNameOutput{
+		HostedZones: []types.HostedZone{
+			{Id: aws.String("/hostedzone/ZRESOLVED"), Name: aws.String("resolve.example.com.")},
+			{Id: aws.String("/hostedzone/ZOTHER"), Name: aws.String("s.resolve.example.com.")},
+		},
+	}, nil).Once()
+	var captured *route53.ChangeResourceRecordSetsInput
+	m.On("ChangeResourceRecordSets", mock.Anything, mock.Anything).
+		Run(captureChange(&captured)).
+		Return(changeOutput("/change/C4"), nil)
+
+	props, _ := json.Marshal(map[string]any{
+		"HostedZoneName":  "Resolve.example.com",
+		"Name":            "www.resolve.example.com",
+		"Type":            "A",
+		"ResourceRecords": []any{"192.0.2.1"},
+	})
+	rs := RecordSet{}
+	for range 2 {
+		res, err := rs.createWithClient(context.Background(), m, &resource.CreateRequest{Properties: props})
+		require.NoError(t, err)
+		assert.Equal(t, "ZRESOLVED|www.resolve.example.com.|A", res.ProgressResult.NativeID)
+		assert.Equal(t, "ZRESOLVED", aws.ToString(captured.HostedZoneId))
+	}
+	// The second create is served from the cache.
+	m.AssertNumberOfCalls(t, "ListHostedZonesByName", 1)
+}
+
+func TestResolveHostedZoneID_RejectsAmbiguousName(t *testing.T) {
+	m := &mockRoute53Client{}
+	m.On("ListHostedZonesByName", mock.Anything, mock.Anything).Return(&route53.ListHostedZonesByNameOutput{
+		HostedZones: []types.HostedZone{
+			{Id: aws.String("/hostedzone/ZPUBLIC"), Name: aws.String("split.example.com.")},
+			{Id: aws.String("/hostedzone/ZPRIVATE"), Name: aws.String("split.example.com.")},
+		},
+	}, nil)
+
+	_, err := resolveHostedZoneID(context.Background(), nil, m, "split.example.com")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ambiguous")
+}
+
+func TestResolveHostedZoneID_NotFound(t *testing.T) {
+	m := &mockRoute53Client{}
+	m.On("ListHostedZonesByName", mock.Anything, mock.Anything).Return(&route53.ListHostedZonesByNameOutput{
+		HostedZones: []types.HostedZone{{Id: aws.String("/hostedzone/ZNEXT"), Name: aws.String("next.example.com.")}},
+	}, nil)
+
+	_, err := resolveHostedZoneID(context.Background(), nil, m, "missing.example.com")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func hostedZones(ids ...string) *route53.ListHostedZonesOutput {
+	out := &route53.ListHostedZonesOutput{}
+	for _, id := range ids {
+		out.HostedZones = append(out.HostedZones, types.HostedZone{Id: aws.String("/hostedzone/" + id)})
+	}
+	return out
+}
+
+// Without a HostedZoneId, List walks every hosted zone, finishing one before
+// moving on to the next, and the page token carries the zone it is in.
+func TestRecordSet_List_AcrossHostedZones(t *testing.T) {
+	m := &mockRoute53Client{}
+	m.On("ListHostedZones", mock.Anything, mock.MatchedBy(func(in *route53.ListHostedZonesInput) bool {
+		return in.Marker == nil
+	})).Return(hostedZones("ZA"), nil)
+	m.On("ListHostedZones", mock.Anything, mock.MatchedBy(func(in *route53.ListHostedZonesInput) bool {
+		return aws.ToString(in.Marker) == "ZA"
+	})).Return(hostedZones("ZA", "ZB"), nil)
+	m.On("ListHostedZones", mock.Anything, mock.MatchedBy(func(in *route53.ListHostedZonesInput) bool {
+		return aws.ToString(in.Marker) == "ZB"
+	})).Return(hostedZones("ZB"), nil)
+
+	m.On("ListResourceRecordSets", mock.Anything, mock.MatchedBy(func(in *route53.ListResourceRecordSetsInput) bool {
+		return aws.ToString(in.HostedZoneId) == "ZA" && in.StartRecordName == nil
+	})).Return(&route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []types.ResourceRecordSet{{Name: aws.String("a.example.com."), Type: types.RRTypeA}},
+		IsTruncated:        true,
+		NextRecordName:     aws.String("b.example.com."),
+		NextRecordType:     types.RRTypeA,
+	}, nil)
+	m.On("ListResourceRecordSets", mock.Anything, mock.MatchedBy(func(in *route53.ListResourceRecordSetsInput) bool {
+		return aws.ToString(in.HostedZoneId) == "ZA" && aws.ToString(in.StartRecordName) == "b.example.com."
+	})).Return(&route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []types.ResourceRecordSet{{Name: aws.String("b.example.com."), Type: types.RRTypeA}},
+	}, nil)
+	m.On("ListResourceRecordSets", mock.Anything, mock.MatchedBy(func(in *route53.ListResourceRecordSetsInput) bool {
+		return aws.ToString(in.HostedZoneId) == "ZB"
+	})).Return(&route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []types.ResourceRecordSet{{Name: aws.String("c.example.org."), Type: types.RRTypeTxt}},
+	}, nil)
+
+	rs := &RecordSet{}
+	var all []string
+	request := &resource.ListRequest{PageSize: 1}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5, "listing should end")
+		res, err := rs.listWithClient(context.Background(), m, request)
+		require.NoError(t, err)
+		all = append(all, res.NativeIDs...)
+		if res.NextPageToken == nil {
+			break
+		}
+		request.PageToken = res.NextPageToken
+	}
+
+	assert.Equal(t, []string{"ZA|a.example.com.|A", "ZA|b.example.com.|A", "ZB|c.example.org.|TXT"}, all)
+}
+
+func TestRecordSet_List_NoHostedZones(t *testing.T) {
+	m := &mockRoute53Client{}
+	m.On("ListHostedZones", mock.Anything, mock.Anything).Return(hostedZones(), nil)
+
+	rs := &RecordSet{}
+	res, err := rs.listWithClient(context.Background(), m, &resource.ListRequest{PageSize: 10})
+	require.NoError(t, err)
+	assert.Empty(t, res.NativeIDs)
+	assert.Nil(t, res.NextPageToken)
+}
+
+// An update must carry the desired EvaluateTargetHealth, not reset it.
+func TestRecordSet_Update_PreservesEvaluateTargetHealth(t *testing.T) {
+	m := &mockRoute53Client{}
+	var captured *route53.ChangeResourceRecordSetsInput
+	m.On("ChangeResourceRecordSets", mock.Anything, mock.Anything).
+		Run(captureChange(&captured)).
+		Return(changeOutput("/change/C5"), nil)
+
+	alias := func(evaluate bool) json.RawMessage {
+		b, _ := json.Marshal(map[string]any{
+			"HostedZoneId": "Z123",
+			"Name":         "example.com",
+			"Type":         "A",
+			"AliasTarget": map[string]any{
+				"DNSName":              "lb-1.us-west-2.elb.amazonaws.com",
+				"HostedZoneId":         "Z1H1FL5HABSF5",
+				"EvaluateTargetHealth": evaluate,
+			},
+		})
+		return b
+	}
+	_, err := RecordSet{}.updateWithClient(context.Background(), m, &resource.UpdateRequest{
+		PriorProperties:   alias(false),
+		DesiredProperties: alias(true),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, captured.ChangeBatch.Changes, 1)
+	assert.True(t, captured.ChangeBatch.Changes[0].ResourceRecordSet.AliasTarget.EvaluateTargetHealth)
+
+	props := buildReadProperties(captured.ChangeBatch.Changes[0].ResourceRecordSet, "Z123", "example.com.", "A")
+	assert.Equal(t, true, props["AliasTarget"].(map[string]any)["EvaluateTargetHealth"])
+}
+
+func TestCanonicalName_NormalizesSpellings(t *testing.T) {
+	assert.Equal(t, "*.example.com.", canonicalName("*.example.com"))
+	assert.Equal(t, "*.example.com.", canonicalName(`\052.example.com.`))
+	assert.Equal(t, "xn--bcher-kva.example.com.", canonicalName("bücher.example.com"))
+	assert.Equal(t, "xn--bcher-kva.example.com.", canonicalName("xn--bcher-kva.example.com."))
+	assert.Equal(t, `back\slash.example.com.`, canonicalName(`back\\slash.example.com`))
+}
+
+func TestDisplayName_DecodesWildcardAndPunycode(t *testing.T) {
+	assert.Equal(t, "*.example.com", displayName(`\052.example.com.`))
+	assert.Equal(t, "bücher.example.com", displayName("xn--bcher-kva.example.com."))
+}
+
+// A wildcard record listed by Route53 and the same record created from its
+// desired state have the same NativeID.
+func TestNativeID_SameForEscapedWildcard(t *testing.T) {
+	listed := recordSetNativeID("Z123", &types.ResourceRecordSet{Name: aws.String(`\052.example.com.`), Type: types.RRTypeA})
+	assert.Equal(t, nativeID("Z123", "*.example.com", "A", ""), listed)
+}
+
+func TestRecordSet_Read_ReportsWildcardAsWritten(t *testing.T) {
+	m := &mockRoute53Client{}
+	m.On("ListResourceRecordSets", mock.Anything, mock.Anything).Return(&route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []types.ResourceRecordSet{
+			{
+				Name: aws.String(`\052.example.com.`), Type: types.RRTypeA, TTL: aws.Int64(300),
+				ResourceRecords: []types.ResourceRecord{{Value: aws.String("192.0.2.1")}},
+			},
+		},
+	}, nil)
+
+	res, err := RecordSet{}.readWithClient(context.Background(), m, &resource.ReadRequest{NativeID: `Z123|\052.example.com.|A`})
+	require.NoError(t, err)
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(res.Properties), &props))
+	assert.Equal(t, "*.example.com", props["Name"])
+}
+
+// An internationalized name is reported the way the caller recorded it, in
+// Unicode or in punycode, so neither spelling shows as drift.
+func TestRecordSet_Read_KeepsRecordedIDNSpelling(t *testing.T) {
+	m := &mockRoute53Client{}
+	m.On("ListResourceRecordSets", mock.Anything, mock.Anything).Return(&route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []types.ResourceRecordSet{
+			{
+				Name: aws.String("xn--bcher-kva.example.com."), Type: types.RRTypeA, TTL: aws.Int64(300),
+				ResourceRecords: []types.ResourceRecord{{Value: aws.String("192.0.2.1")}},
+			},
+		},
+	}, nil)
+
+	for _, recorded := range []string{"bücher.example.com", "xn--bcher-kva.example.com"} {
+		res, err := RecordSet{}.readWithClient(context.Background(), m, &resource.ReadRequest{
+			NativeID:        "Z123|xn--bcher-kva.example.com.|A",
+			PriorProperties: json.RawMessage(`{"Name": "` + recorded + `"}`),
+		})
+		require.NoError(t, err)
+
+		var props map[string]any
+		require.NoError(t, json.Unmarshal([]byte(res.Properties), &props))
+		assert.Equal(t, recorded, props["Name"])
+	}
+}
+
+func TestRecordSet_Create_SendsPunycodeName(t *testing.T) {
+	m := &mockRoute53Client{}
+	var captured *route53.ChangeResourceRecordSetsInput
+	m.On("ChangeResourceRecordSets", mock.Anything, mock.Anything).
+		Run(captureChange(&captured)).
+		Return(changeOutput("/change/C1"), nil)
+
+	res, err := RecordSet{cfg: &config.Config{}}.createWithClient(context.Background(), m, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"HostedZoneId": "Z123", "Name": "bücher.example.com", "Type": "A", "ResourceRecords": ["192.0.2.1"]}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "xn--bcher-kva.example.com.", aws.ToString(captured.ChangeBatch.Changes[0].ResourceRecordSet.Name))
+	assert.Equal(t, "Z123|xn--bcher-kva.example.com.|A", res.ProgressResult.NativeID)
+}
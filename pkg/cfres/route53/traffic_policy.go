@@ -0,0 +1,361 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package route53
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/utils"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+const trafficPolicyType = "AWS::Route53::TrafficPolicy"
+
+type trafficPolicyClientInterface interface {
+	CreateTrafficPolicy(ctx context.Context, params *route53.CreateTrafficPolicyInput, optFns ...func(*route53.Options)) (*route53.CreateTrafficPolicyOutput, error)
+	CreateTrafficPolicyVersion(ctx context.Context, params *route53.CreateTrafficPolicyVersionInput, optFns ...func(*route53.Options)) (*route53.CreateTrafficPolicyVersionOutput, error)
+	UpdateTrafficPolicyComment(ctx context.Context, params *route53.UpdateTrafficPolicyCommentInput, optFns ...func(*route53.Options)) (*route53.UpdateTrafficPolicyCommentOutput, error)
+	DeleteTrafficPolicy(ctx context.Context, params *route53.DeleteTrafficPolicyInput, optFns ...func(*route53.Options)) (*route53.DeleteTrafficPolicyOutput, error)
+	ListTrafficPolicies(ctx context.Context, params *route53.ListTrafficPoliciesInput, optFns ...func(*route53.Options)) (*route53.ListTrafficPoliciesOutput, error)
+	ListTrafficPolicyVersions(ctx context.Context, params *route53.ListTrafficPolicyVersionsInput, optFns ...func(*route53.Options)) (*route53.ListTrafficPolicyVersionsOutput, error)
+}
+
+// TrafficPolicy provisions Route53 traffic policies, which CloudControl does
+// not support. A policy is versioned: changing its document adds a version
+// rather than replacing the policy, so instances pinned to an earlier version
+// keep serving until they are moved to the new one. Read reports the latest
+// version.
+type TrafficPolicy struct {
+	cfg *config.Config
+}
+
+var _ prov.Provisioner = &TrafficPolicy{}
+
+func init() {
+	registry.Register(trafficPolicyType,
+		[]resource.Operation{
+			resource.OperationCreate,
+			resource.OperationRead,
+			resource.OperationUpdate,
+			resource.OperationDelete,
+			resource.OperationList,
+		},
+		func(cfg *config.Config) prov.Provisioner {
+			return &TrafficPolicy{cfg: cfg}
+		})
+}
+
+func (t *TrafficPolicy) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	cfg, err := t.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return t.createWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (t *TrafficPolicy) createWithClient(ctx context.Context, client trafficPolicyClientInterface, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	var properties map[string]any
+	if err := json.Unmarshal(request.Properties, &properties); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+	name, err := utils.GetStringProperty(properties, "Name")
+	if err != nil {
+		return nil, fmt.Errorf("invalid Name: %w", err)
+	}
+	document, err := trafficPolicyDocument(properties)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CreateTrafficPolicy(ctx, &route53.CreateTrafficPolicyInput{
+		Name:     aws.String(name),
+		Document: aws.String(document),
+		Comment:  optionalString(properties, "Comment"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create traffic policy: %w", err)
+	}
+
+	props, err := trafficPolicyProperties(result.TrafficPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:          resource.OperationCreate,
+			OperationStatus:    resource.OperationStatusSuccess,
+			NativeID:           aws.ToString(result.TrafficPolicy.Id),
+			ResourceProperties: props,
+		},
+	}, nil
+}
+
+func (t *TrafficPolicy) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	cfg, err := t.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return t.readWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (t *TrafficPolicy) readWithClient(ctx context.Context, client trafficPolicyClientInterface, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	latest, err := latestTrafficPolicyVersion(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return &resource.ReadResult{
+			ResourceType: request.ResourceType,
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	props, err := trafficPolicyProperties(latest)
+	if err != nil {
+		return nil, err
+	}
+	return &resource.ReadResult{
+		ResourceType: trafficPolicyType,
+		Properties:   string(props),
+	}, nil
+}
+
+func (t *TrafficPolicy) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	cfg, err := t.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return t.updateWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (t *TrafficPolicy) updateWithClient(ctx context.Context, client trafficPolicyClientInterface, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	var desired map[string]any
+	if err := json.Unmarshal(request.DesiredProperties, &desired); err != nil {
+		return nil, fmt.Errorf("failed to parse desired state properties: %w", err)
+	}
+	document, err := trafficPolicyDocument(desired)
+	if err != nil {
+		return nil, err
+	}
+	comment := optionalString(desired, "Comment")
+
+	latest, err := latestTrafficPolicyVersion(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("traffic policy %s not found", request.NativeID)
+	}
+
+	switch {
+	case !sameDocument(aws.ToString(latest.Document), document):
+		result, err := client.CreateTrafficPolicyVersion(ctx, &route53.CreateTrafficPolicyVersionInput{
+			Id:       aws.String(request.NativeID),
+			Document: aws.String(document),
+			Comment:  comment,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create traffic policy version: %w", err)
+		}
+		latest = result.TrafficPolicy
+	case aws.ToString(latest.Comment) != aws.ToString(comment):
+		result, err := client.UpdateTrafficPolicyComment(ctx, &route53.UpdateTrafficPolicyCommentInput{
+			Id:      aws.String(request.NativeID),
+			Version: latest.Version,
+			Comment: aws.String(aws.ToString(comment)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update traffic policy comment: %w", err)
+		}
+		latest = result.TrafficPolicy
+	}
+
+	props, err := trafficPolicyProperties(latest)
+	if err != nil {
+		return nil, err
+	}
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:          resource.OperationUpdate,
+			OperationStatus:    resource.OperationStatusSuccess,
+			NativeID:           request.NativeID,
+			ResourceProperties: props,
+		},
+	}, nil
+}
+
+func (t *TrafficPolicy) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	cfg, err := t.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return t.deleteWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+// deleteWithClient deletes every version of the policy; the policy is gone
+// once its last version is. A version still used by an instance can't be
+// deleted, so the instances have to be deleted first.
+func (t *TrafficPolicy) deleteWithClient(ctx context.Context, client trafficPolicyClientInterface, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	versions, err := trafficPolicyVersions(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	for _, version := range versions {
+		_, err := client.DeleteTrafficPolicy(ctx, &route53.DeleteTrafficPolicyInput{
+			Id:      aws.String(request.NativeID),
+			Version: version.Version,
+		})
+		var notFound *types.NoSuchTrafficPolicy
+		if err != nil && !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("failed to delete version %d of traffic policy %s: %w", aws.ToInt32(version.Version), request.NativeID, err)
+		}
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (t *TrafficPolicy) Status(_ context.Context, _ *resource.StatusRequest) (*resource.StatusResult, error) {
+	return nil, fmt.Errorf("traffic policy operations are synchronous - status polling not needed")
+}
+
+func (t *TrafficPolicy) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	cfg, err := t.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return t.listWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (t *TrafficPolicy) listWithClient(ctx context.Context, client trafficPolicyClientInterface, request *resource.ListRequest) (*resource.ListResult, error) {
+	input := &route53.ListTrafficPoliciesInput{TrafficPolicyIdMarker: request.PageToken}
+	if request.PageSize > 0 {
+		input.MaxItems = aws.Int32(request.PageSize)
+	}
+	result, err := client.ListTrafficPolicies(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list traffic policies: %w", err)
+	}
+
+	nativeIDs := make([]string, 0, len(result.TrafficPolicySummaries))
+	for _, summary := range result.TrafficPolicySummaries {
+		nativeIDs = append(nativeIDs, aws.ToString(summary.Id))
+	}
+	var next *string
+	if result.IsTruncated {
+		next = result.TrafficPolicyIdMarker
+	}
+	return &resource.ListResult{
+		NativeIDs:     nativeIDs,
+		NextPageToken: next,
+	}, nil
+}
+
+// trafficPolicyVersions returns every version of a policy, or none when the
+// policy doesn't exist.
+func trafficPolicyVersions(ctx context.Context, client trafficPolicyClientInterface, id string) ([]types.TrafficPolicy, error) {
+	var versions []types.TrafficPolicy
+	input := &route53.ListTrafficPolicyVersionsInput{Id: aws.String(id)}
+	for {
+		result, err := client.ListTrafficPolicyVersions(ctx, input)
+		if err != nil {
+			var notFound *types.NoSuchTrafficPolicy
+			if errors.As(err, &notFound) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to list versions of traffic policy %s: %w", id, err)
+		}
+		versions = append(versions, result.TrafficPolicies...)
+		if !result.IsTruncated {
+			return versions, nil
+		}
+		input.TrafficPolicyVersionMarker = result.TrafficPolicyVersionMarker
+	}
+}
+
+// latestTrafficPolicyVersion returns the highest version of a policy, or nil
+// when the policy doesn't exist.
+func latestTrafficPolicyVersion(ctx context.Context, client trafficPolicyClientInterface, id string) (*types.TrafficPolicy, error) {
+	versions, err := trafficPolicyVersions(ctx, client, id)
+	if err != nil {
+		return nil, err
+	}
+	var latest *types.TrafficPolicy
+	for i := range versions {
+		if latest == nil || aws.ToInt32(versions[i].Version) > aws.ToInt32(latest.Version) {
+			latest = &versions[i]
+		}
+	}
+	return latest, nil
+}
+
+// trafficPolicyDocument returns the declared policy document as the JSON text
+// Route53 expects. It is declared as an object, or as text already.
+func trafficPolicyDocument(properties map[string]any) (string, error) {
+	switch document := properties["Document"].(type) {
+	case string:
+		if document == "" {
+			return "", fmt.Errorf("Document is required")
+		}
+		return document, nil
+	case map[string]any:
+		data, err := json.Marshal(document)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal Document: %w", err)
+		}
+		return string(data), nil
+	default:
+		return "", fmt.Errorf("Document is required")
+	}
+}
+
+// sameDocument reports whether two policy documents are equal as JSON, so a
+// change of formatting or key order doesn't add a version.
+func sameDocument(a, b string) bool {
+	var x, y any
+	if json.Unmarshal([]byte(a), &x) != nil || json.Unmarshal([]byte(b), &y) != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func trafficPolicyProperties(policy *types.TrafficPolicy) (json.RawMessage, error) {
+	props := map[string]any{
+		"Id":      aws.ToString(policy.Id),
+		"Name":    aws.ToString(policy.Name),
+		"Version": aws.ToInt32(policy.Version),
+		"Type":    string(policy.Type),
+	}
+	var document any
+	if err := json.Unmarshal([]byte(aws.ToString(policy.Document)), &document); err == nil {
+		props["Document"] = document
+	} else {
+		props["Document"] = aws.ToString(policy.Document)
+	}
+	if policy.Comment != nil {
+		props["Comment"] = *policy.Comment
+	}
+
+	data, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal properties: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,380 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package route53
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/utils"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+const trafficPolicyInstanceType = "AWS::Route53::TrafficPolicyInstance"
+
+// Traffic policy instance states reported by Route53.
+const (
+	trafficPolicyInstanceApplied = "Applied"
+	trafficPolicyInstanceFailed  = "Failed"
+)
+
+type trafficPolicyInstanceClientInterface interface {
+	CreateTrafficPolicyInstance(ctx context.Context, params *route53.CreateTrafficPolicyInstanceInput, optFns ...func(*route53.Options)) (*route53.CreateTrafficPolicyInstanceOutput, error)
+	GetTrafficPolicyInstance(ctx context.Context, params *route53.GetTrafficPolicyInstanceInput, optFns ...func(*route53.Options)) (*route53.GetTrafficPolicyInstanceOutput, error)
+	UpdateTrafficPolicyInstance(ctx context.Context, params *route53.UpdateTrafficPolicyInstanceInput, optFns ...func(*route53.Options)) (*route53.UpdateTrafficPolicyInstanceOutput, error)
+	DeleteTrafficPolicyInstance(ctx context.Context, params *route53.DeleteTrafficPolicyInstanceInput, optFns ...func(*route53.Options)) (*route53.DeleteTrafficPolicyInstanceOutput, error)
+	ListTrafficPolicyInstances(ctx context.Context, params *route53.ListTrafficPolicyInstancesInput, optFns ...func(*route53.Options)) (*route53.ListTrafficPolicyInstancesOutput, error)
+}
+
+// TrafficPolicyInstance provisions the records a traffic policy version
+// creates under a name in a hosted zone. Route53 applies an instance in the
+// background, so Create, Update and Delete return in progress and Status
+// polls the instance's state.
+type TrafficPolicyInstance struct {
+	cfg *config.Config
+}
+
+var _ prov.Provisioner = &TrafficPolicyInstance{}
+
+func init() {
+	registry.Register(trafficPolicyInstanceType,
+		[]resource.Operation{
+			resource.OperationCreate,
+			resource.OperationRead,
+			resource.OperationUpdate,
+			resource.OperationDelete,
+			resource.OperationCheckStatus,
+			resource.OperationList,
+		},
+		func(cfg *config.Config) prov.Provisioner {
+			return &TrafficPolicyInstance{cfg: cfg}
+		})
+}
+
+// trafficPolicyInstanceRequestID carries the operation in the RequestID so
+// Status knows whether it is waiting for the instance to apply or to go away.
+func trafficPolicyInstanceRequestID(operation resource.Operation, id string) string {
+	return string(operation) + "|" + id
+}
+
+func parseTrafficPolicyInstanceRequestID(requestID string) (resource.Operation, string, error) {
+	parts := strings.SplitN(requestID, "|", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid RequestID %q: expected <operation>|<instanceId>", requestID)
+	}
+	return resource.Operation(parts[0]), parts[1], nil
+}
+
+// trafficPolicyInstanceInput is what Create and Update send for an instance.
+type trafficPolicyInstanceInput struct {
+	ttl             int64
+	trafficPolicyID string
+	version         int32
+}
+
+func parseTrafficPolicyInstanceInput(properties map[string]any) (trafficPolicyInstanceInput, error) {
+	policyID, err := utils.GetStringProperty(properties, "TrafficPolicyId")
+	if err != nil {
+		return trafficPolicyInstanceInput{}, fmt.Errorf("invalid TrafficPolicyId: %w", err)
+	}
+	version := utils.GetInt64Property(properties, "TrafficPolicyVersion", 0)
+	if version <= 0 {
+		return trafficPolicyInstanceInput{}, fmt.Errorf("TrafficPolicyVersion is required")
+	}
+	return trafficPolicyInstanceInput{
+		ttl:             utils.GetInt64Property(properties, "TTL", 300),
+		trafficPolicyID: policyID,
+		version:         int32(version),
+	}, nil
+}
+
+func (t *TrafficPolicyInstance) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	cfg, err := t.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return t.createWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (t *TrafficPolicyInstance) createWithClient(ctx context.Context, client trafficPolicyInstanceClientInterface, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	var properties map[string]any
+	if err := json.Unmarshal(request.Properties, &properties); err != nil {
+		return nil, fmt.Errorf("failed to parse properties: %w", err)
+	}
+	hostedZoneID, err := utils.GetStringProperty(properties, "HostedZoneId")
+	if err != nil {
+		return nil, fmt.Errorf("invalid HostedZoneId: %w", err)
+	}
+	name, err := utils.GetStringProperty(properties, "Name")
+	if err != nil {
+		return nil, fmt.Errorf("invalid Name: %w", err)
+	}
+	input, err := parseTrafficPolicyInstanceInput(properties)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CreateTrafficPolicyInstance(ctx, &route53.CreateTrafficPolicyInstanceInput{
+		HostedZoneId:         aws.String(hostedZoneID),
+		Name:                 aws.String(canonicalName(name)),
+		TTL:                  aws.Int64(input.ttl),
+		TrafficPolicyId:      aws.String(input.trafficPolicyID),
+		TrafficPolicyVersion: aws.Int32(input.version),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create traffic policy instance: %w", err)
+	}
+	id := aws.ToString(result.TrafficPolicyInstance.Id)
+
+	return &resource.CreateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationCreate,
+			OperationStatus: resource.OperationStatusInProgress,
+			RequestID:       trafficPolicyInstanceRequestID(resource.OperationCreate, id),
+			NativeID:        id,
+		},
+	}, nil
+}
+
+func (t *TrafficPolicyInstance) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	cfg, err := t.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return t.readWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (t *TrafficPolicyInstance) readWithClient(ctx context.Context, client trafficPolicyInstanceClientInterface, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	instance, err := getTrafficPolicyInstance(ctx, client, request.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return &resource.ReadResult{
+			ResourceType: request.ResourceType,
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	}
+
+	props, err := trafficPolicyInstanceProperties(instance)
+	if err != nil {
+		return nil, err
+	}
+	return &resource.ReadResult{
+		ResourceType: trafficPolicyInstanceType,
+		Properties:   string(props),
+	}, nil
+}
+
+func (t *TrafficPolicyInstance) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	cfg, err := t.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return t.updateWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (t *TrafficPolicyInstance) updateWithClient(ctx context.Context, client trafficPolicyInstanceClientInterface, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	var desired map[string]any
+	if err := json.Unmarshal(request.DesiredProperties, &desired); err != nil {
+		return nil, fmt.Errorf("failed to parse desired state properties: %w", err)
+	}
+	input, err := parseTrafficPolicyInstanceInput(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = client.UpdateTrafficPolicyInstance(ctx, &route53.UpdateTrafficPolicyInstanceInput{
+		Id:                   aws.String(request.NativeID),
+		TTL:                  aws.Int64(input.ttl),
+		TrafficPolicyId:      aws.String(input.trafficPolicyID),
+		TrafficPolicyVersion: aws.Int32(input.version),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update traffic policy instance: %w", err)
+	}
+
+	return &resource.UpdateResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusInProgress,
+			RequestID:       trafficPolicyInstanceRequestID(resource.OperationUpdate, request.NativeID),
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (t *TrafficPolicyInstance) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	cfg, err := t.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return t.deleteWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (t *TrafficPolicyInstance) deleteWithClient(ctx context.Context, client trafficPolicyInstanceClientInterface, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	_, err := client.DeleteTrafficPolicyInstance(ctx, &route53.DeleteTrafficPolicyInstanceInput{
+		Id: aws.String(request.NativeID),
+	})
+	if err != nil {
+		var notFound *types.NoSuchTrafficPolicyInstance
+		if errors.As(err, &notFound) {
+			return &resource.DeleteResult{
+				ProgressResult: &resource.ProgressResult{
+					Operation:       resource.OperationDelete,
+					OperationStatus: resource.OperationStatusSuccess,
+					NativeID:        request.NativeID,
+				},
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to delete traffic policy instance %s: %w", request.NativeID, err)
+	}
+
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusInProgress,
+			RequestID:       trafficPolicyInstanceRequestID(resource.OperationDelete, request.NativeID),
+			NativeID:        request.NativeID,
+		},
+	}, nil
+}
+
+func (t *TrafficPolicyInstance) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	cfg, err := t.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return t.statusWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (t *TrafficPolicyInstance) statusWithClient(ctx context.Context, client trafficPolicyInstanceClientInterface, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	operation, id, err := parseTrafficPolicyInstanceRequestID(request.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	instance, err := getTrafficPolicyInstance(ctx, client, id)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := &resource.ProgressResult{
+		Operation:       operation,
+		OperationStatus: resource.OperationStatusInProgress,
+		RequestID:       request.RequestID,
+		NativeID:        id,
+	}
+	switch {
+	case instance == nil && operation == resource.OperationDelete:
+		pr.OperationStatus = resource.OperationStatusSuccess
+	case instance == nil:
+		pr.OperationStatus = resource.OperationStatusFailure
+		pr.ErrorCode = resource.OperationErrorCodeNotFound
+		pr.StatusMessage = fmt.Sprintf("traffic policy instance %s not found", id)
+	case operation == resource.OperationDelete:
+		pr.StatusMessage = fmt.Sprintf("traffic policy instance is %s", aws.ToString(instance.State))
+	case aws.ToString(instance.State) == trafficPolicyInstanceApplied:
+		props, err := trafficPolicyInstanceProperties(instance)
+		if err != nil {
+			return nil, err
+		}
+		pr.OperationStatus = resource.OperationStatusSuccess
+		pr.ResourceProperties = props
+	case aws.ToString(instance.State) == trafficPolicyInstanceFailed:
+		pr.OperationStatus = resource.OperationStatusFailure
+		pr.ErrorCode = resource.OperationErrorCodeGeneralServiceException
+		pr.StatusMessage = aws.ToString(instance.Message)
+	default:
+		pr.StatusMessage = fmt.Sprintf("traffic policy instance is %s", aws.ToString(instance.State))
+	}
+	return &resource.StatusResult{ProgressResult: pr}, nil
+}
+
+func (t *TrafficPolicyInstance) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
+	cfg, err := t.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+	return t.listWithClient(ctx, route53.NewFromConfig(cfg), request)
+}
+
+func (t *TrafficPolicyInstance) listWithClient(ctx context.Context, client trafficPolicyInstanceClientInterface, request *resource.ListRequest) (*resource.ListResult, error) {
+	input := &route53.ListTrafficPolicyInstancesInput{}
+	if request.PageSize > 0 {
+		input.MaxItems = aws.Int32(request.PageSize)
+	}
+	// Instances are listed by zone, name and type, so the position in the
+	// listing is encoded like a record set listing's.
+	if request.PageToken != nil {
+		token := decodeListPageToken(*request.PageToken)
+		input.HostedZoneIdMarker = aws.String(token.Zone)
+		input.TrafficPolicyInstanceNameMarker = aws.String(token.Name)
+		input.TrafficPolicyInstanceTypeMarker = types.RRType(token.Type)
+	}
+	result, err := client.ListTrafficPolicyInstances(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list traffic policy instances: %w", err)
+	}
+
+	nativeIDs := make([]string, 0, len(result.TrafficPolicyInstances))
+	for _, instance := range result.TrafficPolicyInstances {
+		nativeIDs = append(nativeIDs, aws.ToString(instance.Id))
+	}
+	listResult := &resource.ListResult{NativeIDs: nativeIDs}
+	if result.IsTruncated {
+		token, err := listPageToken{
+			Zone: aws.ToString(result.HostedZoneIdMarker),
+			Name: aws.ToString(result.TrafficPolicyInstanceNameMarker),
+			Type: string(result.TrafficPolicyInstanceTypeMarker),
+		}.encode()
+		if err != nil {
+			return nil, err
+		}
+		listResult.NextPageToken = &token
+	}
+	return listResult, nil
+}
+
+// getTrafficPolicyInstance returns the instance with the given ID, or nil when
+// there is none.
+func getTrafficPolicyInstance(ctx context.Context, client trafficPolicyInstanceClientInterface, id string) (*types.TrafficPolicyInstance, error) {
+	result, err := client.GetTrafficPolicyInstance(ctx, &route53.GetTrafficPolicyInstanceInput{
+		Id: aws.String(id),
+	})
+	if err != nil {
+		var notFound *types.NoSuchTrafficPolicyInstance
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get traffic policy instance %s: %w", id, err)
+	}
+	return result.TrafficPolicyInstance, nil
+}
+
+func trafficPolicyInstanceProperties(instance *types.TrafficPolicyInstance) (json.RawMessage, error) {
+	props := map[string]any{
+		"Id":                   aws.ToString(instance.Id),
+		"HostedZoneId":         aws.ToString(instance.HostedZoneId),
+		"Name":                 displayName(aws.ToString(instance.Name)),
+		"TTL":                  aws.ToInt64(instance.TTL),
+		"TrafficPolicyId":      aws.ToString(instance.TrafficPolicyId),
+		"TrafficPolicyVersion": aws.ToInt32(instance.TrafficPolicyVersion),
+		"State":                aws.ToString(instance.State),
+	}
+	data, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal properties: %w", err)
+	}
+	return data, nil
+}
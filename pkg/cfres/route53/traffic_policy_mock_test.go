@@ -0,0 +1,84 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package route53
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockTrafficPolicyClient struct {
+	mock.Mock
+}
+
+func (m *mockTrafficPolicyClient) CreateTrafficPolicy(ctx context.Context, input *route53.CreateTrafficPolicyInput, optFns ...func(*route53.Options)) (*route53.CreateTrafficPolicyOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.CreateTrafficPolicyOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockTrafficPolicyClient) CreateTrafficPolicyVersion(ctx context.Context, input *route53.CreateTrafficPolicyVersionInput, optFns ...func(*route53.Options)) (*route53.CreateTrafficPolicyVersionOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.CreateTrafficPolicyVersionOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockTrafficPolicyClient) UpdateTrafficPolicyComment(ctx context.Context, input *route53.UpdateTrafficPolicyCommentInput, optFns ...func(*route53.Options)) (*route53.UpdateTrafficPolicyCommentOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.UpdateTrafficPolicyCommentOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockTrafficPolicyClient) DeleteTrafficPolicy(ctx context.Context, input *route53.DeleteTrafficPolicyInput, optFns ...func(*route53.Options)) (*route53.DeleteTrafficPolicyOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.DeleteTrafficPolicyOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockTrafficPolicyClient) ListTrafficPolicies(ctx context.Context, input *route53.ListTrafficPoliciesInput, optFns ...func(*route53.Options)) (*route53.ListTrafficPoliciesOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.ListTrafficPoliciesOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockTrafficPolicyClient) ListTrafficPolicyVersions(ctx context.Context, input *route53.ListTrafficPolicyVersionsInput, optFns ...func(*route53.Options)) (*route53.ListTrafficPolicyVersionsOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.ListTrafficPolicyVersionsOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockTrafficPolicyClient) CreateTrafficPolicyInstance(ctx context.Context, input *route53.CreateTrafficPolicyInstanceInput, optFns ...func(*route53.Options)) (*route53.CreateTrafficPolicyInstanceOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.CreateTrafficPolicyInstanceOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockTrafficPolicyClient) GetTrafficPolicyInstance(ctx context.Context, input *route53.GetTrafficPolicyInstanceInput, optFns ...func(*route53.Options)) (*route53.GetTrafficPolicyInstanceOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.GetTrafficPolicyInstanceOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockTrafficPolicyClient) UpdateTrafficPolicyInstance(ctx context.Context, input *route53.UpdateTrafficPolicyInstanceInput, optFns ...func(*route53.Options)) (*route53.UpdateTrafficPolicyInstanceOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.UpdateTrafficPolicyInstanceOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockTrafficPolicyClient) DeleteTrafficPolicyInstance(ctx context.Context, input *route53.DeleteTrafficPolicyInstanceInput, optFns ...func(*route53.Options)) (*route53.DeleteTrafficPolicyInstanceOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.DeleteTrafficPolicyInstanceOutput)
+	return out, args.Error(1)
+}
+
+func (m *mockTrafficPolicyClient) ListTrafficPolicyInstances(ctx context.Context, input *route53.ListTrafficPolicyInstancesInput, optFns ...func(*route53.Options)) (*route53.ListTrafficPolicyInstancesOutput, error) {
+	args := m.Called(ctx, input)
+	out, _ := args.Get(0).(*route53.ListTrafficPolicyInstancesOutput)
+	return out, args.Error(1)
+}
@@ -0,0 +1,226 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package route53
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const failoverDocument = `{"AWSPolicyFormatVersion":"2015-10-01","RecordType":"A","StartRule":"failover"}`
+
+func trafficPolicyVersion(version int32, document, comment string) types.TrafficPolicy {
+	return types.TrafficPolicy{
+		Id:       aws.String("tp-1"),
+		Name:     aws.String("failover"),
+		Type:     types.RRTypeA,
+		Version:  aws.Int32(version),
+		Document: aws.String(document),
+		Comment:  aws.String(comment),
+	}
+}
+
+func expectTrafficPolicyVersions(m *mockTrafficPolicyClient, versions ...types.TrafficPolicy) {
+	m.On("ListTrafficPolicyVersions", mock.Anything, mock.Anything).
+		Return(&route53.ListTrafficPolicyVersionsOutput{TrafficPolicies: versions}, nil)
+}
+
+func TestTrafficPolicy_Create_SendsDocumentAsJSON(t *testing.T) {
+	m := &mockTrafficPolicyClient{}
+	var captured *route53.CreateTrafficPolicyInput
+	m.On("CreateTrafficPolicy", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { captured = args.Get(1).(*route53.CreateTrafficPolicyInput) }).
+		Return(&route53.CreateTrafficPolicyOutput{TrafficPolicy: ptr(trafficPolicyVersion(1, failoverDocument, ""))}, nil)
+
+	res, err := (&TrafficPolicy{}).createWithClient(context.Background(), m, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"Name": "failover", "Document": ` + failoverDocument + `}`),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, resource.OperationStatusSuccess, res.ProgressResult.OperationStatus)
+	assert.Equal(t, "tp-1", res.ProgressResult.NativeID)
+	assert.JSONEq(t, failoverDocument, aws.ToString(captured.Document))
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal(res.ProgressResult.ResourceProperties, &props))
+	assert.EqualValues(t, 1, props["Version"])
+	assert.Equal(t, "A", props["Document"].(map[string]any)["RecordType"])
+}
+
+func TestTrafficPolicy_Read_ReportsLatestVersion(t *testing.T) {
+	m := &mockTrafficPolicyClient{}
+	expectTrafficPolicyVersions(m,
+		trafficPolicyVersion(1, failoverDocument, "first"),
+		trafficPolicyVersion(3, failoverDocument, "third"),
+		trafficPolicyVersion(2, failoverDocument, "second"),
+	)
+
+	res, err := (&TrafficPolicy{}).readWithClient(context.Background(), m, &resource.ReadRequest{NativeID: "tp-1"})
+	require.NoError(t, err)
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(res.Properties), &props))
+	assert.EqualValues(t, 3, props["Version"])
+	assert.Equal(t, "third", props["Comment"])
+}
+
+func TestTrafficPolicy_Read_NotFound(t *testing.T) {
+	m := &mockTrafficPolicyClient{}
+	m.On("ListTrafficPolicyVersions", mock.Anything, mock.Anything).Return(nil, &types.NoSuchTrafficPolicy{})
+
+	res, err := (&TrafficPolicy{}).readWithClient(context.Background(), m, &resource.ReadRequest{NativeID: "tp-gone"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationErrorCodeNotFound, res.ErrorCode)
+}
+
+// A changed document becomes a new version of the policy.
+func TestTrafficPolicy_Update_AddsVersionForNewDocument(t *testing.T) {
+	m := &mockTrafficPolicyClient{}
+	expectTrafficPolicyVersions(m, trafficPolicyVersion(1, failoverDocument, ""))
+	newDocument := `{"AWSPolicyFormatVersion":"2015-10-01","RecordType":"A","StartRule":"latency"}`
+	var captured *route53.CreateTrafficPolicyVersionInput
+	m.On("CreateTrafficPolicyVersion", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { captured = args.Get(1).(*route53.CreateTrafficPolicyVersionInput) }).
+		Return(&route53.CreateTrafficPolicyVersionOutput{TrafficPolicy: ptr(trafficPolicyVersion(2, newDocument, ""))}, nil)
+
+	res, err := (&TrafficPolicy{}).updateWithClient(context.Background(), m, &resource.UpdateRequest{
+		NativeID:          "tp-1",
+		DesiredProperties: json.RawMessage(`{"Name": "failover", "Document": ` + newDocument + `}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "tp-1", aws.ToString(captured.Id))
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal(res.ProgressResult.ResourceProperties, &props))
+	assert.EqualValues(t, 2, props["Version"])
+}
+
+// Reformatting the document, or changing only the comment, doesn't add a
+// version.
+func TestTrafficPolicy_Update_CommentOnly(t *testing.T) {
+	m := &mockTrafficPolicyClient{}
+	expectTrafficPolicyVersions(m, trafficPolicyVersion(1, failoverDocument, "old"))
+	m.On("UpdateTrafficPolicyComment", mock.Anything, mock.MatchedBy(func(in *route53.UpdateTrafficPolicyCommentInput) bool {
+		return aws.ToInt32(in.Version) == 1 && aws.ToString(in.Comment) == "new"
+	})).Return(&route53.UpdateTrafficPolicyCommentOutput{TrafficPolicy: ptr(trafficPolicyVersion(1, failoverDocument, "new"))}, nil)
+
+	_, err := (&TrafficPolicy{}).updateWithClient(context.Background(), m, &resource.UpdateRequest{
+		NativeID:          "tp-1",
+		DesiredProperties: json.RawMessage(`{"Name": "failover", "Comment": "new", "Document": {"StartRule": "failover", "RecordType": "A", "AWSPolicyFormatVersion": "2015-10-01"}}`),
+	})
+	require.NoError(t, err)
+	m.AssertExpectations(t)
+	m.AssertNotCalled(t, "CreateTrafficPolicyVersion", mock.Anything, mock.Anything)
+}
+
+func TestTrafficPolicy_Delete_DeletesEveryVersion(t *testing.T) {
+	m := &mockTrafficPolicyClient{}
+	expectTrafficPolicyVersions(m, trafficPolicyVersion(1, failoverDocument, ""), trafficPolicyVersion(2, failoverDocument, ""))
+	m.On("DeleteTrafficPolicy", mock.Anything, mock.Anything).Return(&route53.DeleteTrafficPolicyOutput{}, nil)
+
+	res, err := (&TrafficPolicy{}).deleteWithClient(context.Background(), m, &resource.DeleteRequest{NativeID: "tp-1"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, res.ProgressResult.OperationStatus)
+	m.AssertNumberOfCalls(t, "DeleteTrafficPolicy", 2)
+}
+
+func trafficPolicyInstance(state string) *types.TrafficPolicyInstance {
+	return &types.TrafficPolicyInstance{
+		Id:                   aws.String("tpi-1"),
+		HostedZoneId:         aws.String("Z123"),
+		Name:                 aws.String("www.example.com."),
+		TTL:                  aws.Int64(60),
+		TrafficPolicyId:      aws.String("tp-1"),
+		TrafficPolicyVersion: aws.Int32(2),
+		State:                aws.String(state),
+		Message:              aws.String("records conflict"),
+	}
+}
+
+func TestTrafficPolicyInstance_CreateThenStatus(t *testing.T) {
+	m := &mockTrafficPolicyClient{}
+	var captured *route53.CreateTrafficPolicyInstanceInput
+	m.On("CreateTrafficPolicyInstance", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { captured = args.Get(1).(*route53.CreateTrafficPolicyInstanceInput) }).
+		Return(&route53.CreateTrafficPolicyInstanceOutput{TrafficPolicyInstance: trafficPolicyInstance("Creating")}, nil)
+	m.On("GetTrafficPolicyInstance", mock.Anything, mock.Anything).
+		Return(&route53.GetTrafficPolicyInstanceOutput{TrafficPolicyInstance: trafficPolicyInstance("Creating")}, nil).Once()
+	m.On("GetTrafficPolicyInstance", mock.Anything, mock.Anything).
+		Return(&route53.GetTrafficPolicyInstanceOutput{TrafficPolicyInstance: trafficPolicyInstance("Applied")}, nil)
+
+	ti := &TrafficPolicyInstance{}
+	created, err := ti.createWithClient(context.Background(), m, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"HostedZoneId": "Z123", "Name": "www.example.com", "TTL": 60, "TrafficPolicyId": "tp-1", "TrafficPolicyVersion": 2}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, created.ProgressResult.OperationStatus)
+	assert.Equal(t, "www.example.com.", aws.ToString(captured.Name))
+	assert.Equal(t, int32(2), aws.ToInt32(captured.TrafficPolicyVersion))
+
+	status := &resource.StatusRequest{RequestID: created.ProgressResult.RequestID}
+	pending, err := ti.statusWithClient(context.Background(), m, status)
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, pending.ProgressResult.OperationStatus)
+
+	applied, err := ti.statusWithClient(context.Background(), m, status)
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, applied.ProgressResult.OperationStatus)
+	assert.Equal(t, "tpi-1", applied.ProgressResult.NativeID)
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal(applied.ProgressResult.ResourceProperties, &props))
+	assert.Equal(t, "www.example.com", props["Name"])
+}
+
+func TestTrafficPolicyInstance_Status_Failed(t *testing.T) {
+	m := &mockTrafficPolicyClient{}
+	m.On("GetTrafficPolicyInstance", mock.Anything, mock.Anything).
+		Return(&route53.GetTrafficPolicyInstanceOutput{TrafficPolicyInstance: trafficPolicyInstance("Failed")}, nil)
+
+	res, err := (&TrafficPolicyInstance{}).statusWithClient(context.Background(), m, &resource.StatusRequest{
+		RequestID: trafficPolicyInstanceRequestID(resource.OperationUpdate, "tpi-1"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusFailure, res.ProgressResult.OperationStatus)
+	assert.Equal(t, "records conflict", res.ProgressResult.StatusMessage)
+}
+
+func TestTrafficPolicyInstance_DeleteCompletesWhenGone(t *testing.T) {
+	m := &mockTrafficPolicyClient{}
+	m.On("DeleteTrafficPolicyInstance", mock.Anything, mock.Anything).Return(&route53.DeleteTrafficPolicyInstanceOutput{}, nil)
+	m.On("GetTrafficPolicyInstance", mock.Anything, mock.Anything).Return(nil, &types.NoSuchTrafficPolicyInstance{})
+
+	ti := &TrafficPolicyInstance{}
+	deleted, err := ti.deleteWithClient(context.Background(), m, &resource.DeleteRequest{NativeID: "tpi-1"})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, deleted.ProgressResult.OperationStatus)
+
+	res, err := ti.statusWithClient(context.Background(), m, &resource.StatusRequest{RequestID: deleted.ProgressResult.RequestID})
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, res.ProgressResult.OperationStatus)
+}
+
+func TestTrafficPolicyInstance_Create_RequiresVersion(t *testing.T) {
+	_, err := (&TrafficPolicyInstance{}).createWithClient(context.Background(), &mockTrafficPolicyClient{}, &resource.CreateRequest{
+		Properties: json.RawMessage(`{"HostedZoneId": "Z123", "Name": "www.example.com", "TrafficPolicyId": "tp-1"}`),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TrafficPolicyVersion")
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
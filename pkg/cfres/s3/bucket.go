@@ -7,9 +7,14 @@ package s3
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ccx"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
@@ -24,8 +29,10 @@ import (
 // prefix), so without this enrichment operators have to string-compose
 // the hostname themselves and lose the Resolvable edge.
 //
-// All other operations (Create / Update / Delete / List / Status) fall
-// through to CCAPI because only the Read result needs enrichment.
+// Delete empties the bucket first when the target sets
+// S3EmptyBucketsOnDelete, since CCAPI can't delete a bucket that still holds
+// objects, and then hands the delete to CCAPI. All other operations
+// (Create / Update / List / Status) fall through to CCAPI.
 type Bucket struct {
 	cfg *config.Config
 }
@@ -34,7 +41,7 @@ var _ prov.Provisioner = &Bucket{}
 
 func init() {
 	registry.Register("AWS::S3::Bucket",
-		[]resource.Operation{resource.OperationRead},
+		[]resource.Operation{resource.OperationRead, resource.OperationDelete},
 		func(cfg *config.Config) prov.Provisioner {
 			return &Bucket{cfg: cfg}
 		})
@@ -81,6 +88,76 @@ func enrichBucketProperties(props map[string]any) {
 	props["WebsiteEndpoint"] = endpoint
 }
 
+func (b *Bucket) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	if b.cfg.S3EmptyBucketsOnDelete {
+		awsCfg, err := b.cfg.ToAwsConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load AWS config: %w", err)
+		}
+		if err := emptyBucket(ctx, s3.NewFromConfig(awsCfg), request.NativeID); err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := ccx.NewClient(b.cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client.DeleteResource(ctx, request)
+}
+
+type s3BucketClient interface {
+	ListObjectVersions(ctx context.Context, params *s3.ListObjectVersionsInput, optFns ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error)
+	DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
+}
+
+// emptyBucket deletes every object version and delete marker in the bucket,
+// a page of up to 1000 at a time. A bucket that no longer exists is left for
+// the delete to report. Versions under Object Lock retention or a legal hold
+// can't be deleted, and fail the delete.
+func emptyBucket(ctx context.Context, client s3BucketClient, bucket string) error {
+	input := &s3.ListObjectVersionsInput{Bucket: aws.String(bucket)}
+	for {
+		out, err := client.ListObjectVersions(ctx, input)
+		if err != nil {
+			var apiErr smithy.APIError
+			if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchBucket" {
+				return nil
+			}
+			return fmt.Errorf("failed to list object versions in bucket %s: %w", bucket, err)
+		}
+
+		ids := make([]s3types.ObjectIdentifier, 0, len(out.Versions)+len(out.DeleteMarkers))
+		for _, v := range out.Versions {
+			ids = append(ids, s3types.ObjectIdentifier{Key: v.Key, VersionId: v.VersionId})
+		}
+		for _, m := range out.DeleteMarkers {
+			ids = append(ids, s3types.ObjectIdentifier{Key: m.Key, VersionId: m.VersionId})
+		}
+
+		if len(ids) > 0 {
+			res, err := client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+				Bucket: aws.String(bucket),
+				Delete: &s3types.Delete{Objects: ids, Quiet: aws.Bool(true)},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to delete objects in bucket %s: %w", bucket, err)
+			}
+			if len(res.Errors) > 0 {
+				e := res.Errors[0]
+				return fmt.Errorf("failed to delete version %s of object %s in bucket %s: %s: %s",
+					aws.ToString(e.VersionId), aws.ToString(e.Key), bucket, aws.ToString(e.Code), aws.ToString(e.Message))
+			}
+		}
+
+		if !aws.ToBool(out.IsTruncated) {
+			return nil
+		}
+		input.KeyMarker = out.NextKeyMarker
+		input.VersionIdMarker = out.NextVersionIdMarker
+	}
+}
+
 // The remaining operations fall through to CCAPI; they are unimplemented
 // here so the dispatcher in aws.go bypasses this provisioner for them.
 
@@ -92,9 +169,6 @@ func (b *Bucket) Update(ctx context.Context, request *resource.UpdateRequest) (*
 	return nil, fmt.Errorf("s3 bucket: update handled by cloudcontrol")
 }
 
-func (b *Bucket) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
-	return nil, fmt.Errorf("s3 bucket: delete handled by cloudcontrol")
-}
 
 func (b *Bucket) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
 	return nil, fmt.Errorf("s3 bucket: status handled by cloudcontrol")
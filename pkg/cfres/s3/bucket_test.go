@@ -7,7 +7,16 @@
 package s3
 
 import (
+	"context"
 	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // enrichBucketProperties derives WebsiteEndpoint (hostname-only) from
@@ -91,3 +100,63 @@ func TestEnrichBucketProperties_DualStackWebsiteURL_StripsCorrectly(t *testing.T
 		t.Errorf("WebsiteEndpoint = %q, want %q", got, want)
 	}
 }
+
+func TestEmptyBucket_DeletesEveryVersionAndDeleteMarker(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	client.On("ListObjectVersions", ctx, mock.MatchedBy(func(input *s3.ListObjectVersionsInput) bool {
+		return *input.Bucket == "my-bucket" && input.KeyMarker == nil
+	})).Return(&s3.ListObjectVersionsOutput{
+		Versions:            []s3types.ObjectVersion{{Key: aws.String("a.txt"), VersionId: aws.String("v1")}},
+		DeleteMarkers:       []s3types.DeleteMarkerEntry{{Key: aws.String("b.txt"), VersionId: aws.String("m1")}},
+		IsTruncated:         aws.Bool(true),
+		NextKeyMarker:       aws.String("b.txt"),
+		NextVersionIdMarker: aws.String("m1"),
+	}, nil).Once()
+	client.On("ListObjectVersions", ctx, mock.MatchedBy(func(input *s3.ListObjectVersionsInput) bool {
+		return aws.ToString(input.KeyMarker) == "b.txt" && aws.ToString(input.VersionIdMarker) == "m1"
+	})).Return(&s3.ListObjectVersionsOutput{
+		Versions:    []s3types.ObjectVersion{{Key: aws.String("c.txt"), VersionId: aws.String("v2")}},
+		IsTruncated: aws.Bool(false),
+	}, nil).Once()
+	client.On("DeleteObjects", ctx, mock.MatchedBy(func(input *s3.DeleteObjectsInput) bool {
+		return len(input.Delete.Objects) == 2 && *input.Delete.Objects[1].VersionId == "m1"
+	})).Return(&s3.DeleteObjectsOutput{}, nil).Once()
+	client.On("DeleteObjects", ctx, mock.MatchedBy(func(input *s3.DeleteObjectsInput) bool {
+		return len(input.Delete.Objects) == 1 && *input.Delete.Objects[0].Key == "c.txt"
+	})).Return(&s3.DeleteObjectsOutput{}, nil).Once()
+
+	require.NoError(t, emptyBucket(ctx, client, "my-bucket"))
+	client.AssertExpectations(t)
+}
+
+func TestEmptyBucket_ReportsVersionThatCantBeDeleted(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	client.On("ListObjectVersions", ctx, mock.Anything).Return(&s3.ListObjectVersionsOutput{
+		Versions:    []s3types.ObjectVersion{{Key: aws.String("locked.txt"), VersionId: aws.String("v1")}},
+		IsTruncated: aws.Bool(false),
+	}, nil)
+	client.On("DeleteObjects", ctx, mock.Anything).Return(&s3.DeleteObjectsOutput{
+		Errors: []s3types.Error{{Key: aws.String("locked.txt"), VersionId: aws.String("v1"), Code: aws.String("AccessDenied")}},
+	}, nil)
+
+	err := emptyBucket(ctx, client, "my-bucket")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "locked.txt")
+	assert.Contains(t, err.Error(), "AccessDenied")
+}
+
+func TestEmptyBucket_MissingBucketIsLeftForDelete(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	client.On("ListObjectVersions", ctx, mock.Anything).Return(
+		(*s3.ListObjectVersionsOutput)(nil), &smithy.GenericAPIError{Code: "NoSuchBucket"})
+
+	require.NoError(t, emptyBucket(ctx, client, "gone-bucket"))
+	client.AssertNotCalled(t, "DeleteObjects", mock.Anything, mock.Anything)
+}
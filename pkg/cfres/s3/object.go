@@ -14,12 +14,16 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
+	tmtypes "github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager/types"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
 
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
@@ -29,16 +33,29 @@ import (
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
 )
 
+// s3ObjectClient is the S3 API the Object provisioner uses. Bodies are
+// uploaded through the transfer manager, which needs the multipart calls of
+// transfermanager.S3APIClient; an s3:// Source is copied with CopyObject and
+// UploadPartCopy instead.
 type s3ObjectClient interface {
-	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
-	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	transfermanager.S3APIClient
+	CopyObject(ctx context.Context, params *s3.CopyObjectInput, optFns ...func(*s3.Options)) (*s3.CopyObjectOutput, error)
+	PutObjectLegalHold(ctx context.Context, params *s3.PutObjectLegalHoldInput, optFns ...func(*s3.Options)) (*s3.PutObjectLegalHoldOutput, error)
+	PutObjectRetention(ctx context.Context, params *s3.PutObjectRetentionInput, optFns ...func(*s3.Options)) (*s3.PutObjectRetentionOutput, error)
+	DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
+	ListObjectVersions(ctx context.Context, params *s3.ListObjectVersionsInput, optFns ...func(*s3.Options)) (*s3.ListObjectVersionsOutput, error)
+	UploadPartCopy(ctx context.Context, params *s3.UploadPartCopyInput, optFns ...func(*s3.Options)) (*s3.UploadPartCopyOutput, error)
 	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
-	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
 	GetObjectTagging(ctx context.Context, params *s3.GetObjectTaggingInput, optFns ...func(*s3.Options)) (*s3.GetObjectTaggingOutput, error)
+	GetObjectAcl(ctx context.Context, params *s3.GetObjectAclInput, optFns ...func(*s3.Options)) (*s3.GetObjectAclOutput, error)
+	PutObjectAcl(ctx context.Context, params *s3.PutObjectAclInput, optFns ...func(*s3.Options)) (*s3.PutObjectAclOutput, error)
 }
 
 type Object struct {
 	cfg *config.Config
+	// presignClient is injectable for testing; nil means presign with a
+	// client sharing the S3 client's configuration.
+	presignClient objectPresigner
 }
 
 var _ prov.Provisioner = &Object{}
@@ -70,15 +87,40 @@ func parseNativeID(nativeID string) (bucket, key string, err error) {
 	return parts[0], parts[1], nil
 }
 
+// maxDownloadBytes and maxDecompressedBytes bound a Source a zip member is
+// extracted from, which has to be held in memory; other sources are streamed.
+// fetchTimeout bounds the wait for a Source's response, not the transfer of
+// its body, which the operation's own deadline bounds.
 const (
 	maxDownloadBytes     = 256 << 20
 	maxDecompressedBytes = 256 << 20
 	fetchTimeout         = 5 * time.Minute
 )
 
+// sizedBody is a streamed Source body whose length the response announced.
+// The length lets the upload size its parts up front, so a large object
+// doesn't run out of parts.
+type sizedBody struct {
+	io.Reader
+	size int64
+}
+
+// streamBody returns the response body to stream into the upload, closed by
+// the returned closer.
+func streamBody(resp *http.Response) (io.Reader, func()) {
+	closer := func() { _ = resp.Body.Close() }
+	if resp.ContentLength > 0 {
+		return &sizedBody{Reader: resp.Body, size: resp.ContentLength}, closer
+	}
+	return resp.Body, closer
+}
+
 // resolveBodyWithCloser returns an io.Reader for the object body and a closer function.
 // Exactly one of Content, ContentBase64, or Source may be set. If none is set, returns nil reader.
-// Source may be a plain URL string (legacy) or an HttpSource map with Url/Headers/Extract keys.
+// Source may be a plain URL string (legacy), including a file:// URL of a local file,
+// or an HttpSource map with Url/Headers/Extract keys.
+// A Source is streamed from the response rather than buffered, except when a
+// zip member is extracted from it.
 func resolveBodyWithCloser(props map[string]any) (io.Reader, func(), error) {
 	content, hasContent := props["Content"]
 	contentBase64, hasBase64 := props["ContentBase64"]
@@ -113,6 +155,9 @@ func resolveBodyWithCloser(props map[string]any) (io.Reader, func(), error) {
 	if hasSource {
 		switch s := source.(type) {
 		case string:
+			if strings.HasPrefix(s, "file://") {
+				return openSourceFile(s)
+			}
 			return fetchPlainURL(s)
 		case map[string]any:
 			return fetchHTTPSource(s)
@@ -124,22 +169,53 @@ func resolveBodyWithCloser(props map[string]any) (io.Reader, func(), error) {
 	return nil, func() {}, nil
 }
 
+// hasObjectBody reports whether the model gives the object's body, as
+// content, contentBase64, or a source.
+func hasObjectBody(props map[string]any) bool {
+	for _, k := range []string{"Content", "ContentBase64", "Source"} {
+		if _, ok := props[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// openSourceFile opens the file a file:// Source names, on the host running
+// the formae agent, to stream it into the upload. The open file is seekable,
+// so the upload knows its size and can re-read a part it retries.
+func openSourceFile(sourceStr string) (io.Reader, func(), error) {
+	u, err := url.Parse(sourceStr)
+	if err != nil || (u.Host != "" && u.Host != "localhost") || u.Path == "" {
+		return nil, nil, fmt.Errorf("invalid source file URL %s: expected file:///absolute/path", sourceStr)
+	}
+	f, err := os.Open(u.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open source file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, nil, fmt.Errorf("failed to stat source file: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		_ = f.Close()
+		return nil, nil, fmt.Errorf("source file %s is not a regular file", u.Path)
+	}
+	return f, func() { _ = f.Close() }, nil
+}
+
 // fetchPlainURL fetches a URL using a plain http.Get (legacy string-Source path).
 func fetchPlainURL(sourceStr string) (io.Reader, func(), error) {
 	resp, err := http.Get(sourceStr) //nolint:gosec // Source URL is user-provided infrastructure config
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to fetch source URL %s: %w", sourceStr, err)
 	}
-	defer func() { _ = resp.Body.Close() }()
 	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
 		return nil, nil, fmt.Errorf("source URL %s returned status %d", sourceStr, resp.StatusCode)
 	}
-	// Buffer into memory so the SDK can determine Content-Length
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read source URL %s: %w", sourceStr, err)
-	}
-	return bytes.NewReader(data), func() {}, nil
+	body, closer := streamBody(resp)
+	return body, closer, nil
 }
 
 // redactErr strips the URL (including any signed query params or auth tokens)
@@ -171,15 +247,26 @@ func fetchHTTPSource(m map[string]any) (io.Reader, func(), error) {
 			}
 		}
 	}
-	resp, err := newHardenedClient(fetchTimeout).Do(req)
+	// The body may take far longer to stream than fetchTimeout, so the
+	// client only bounds the wait for the response.
+	client := newHardenedClient(0)
+	client.Transport.(*http.Transport).ResponseHeaderTimeout = fetchTimeout
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to fetch source (host %s): %w", req.URL.Host, redactErr(err))
 	}
-	defer func() { _ = resp.Body.Close() }()
 	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
 		// NEVER include headers or the full URL — status + host only
 		return nil, nil, fmt.Errorf("source fetch returned %d from host %s", resp.StatusCode, req.URL.Host)
 	}
+	member, _ := m["Extract"].(string)
+	if member == "" {
+		body, closer := streamBody(resp)
+		return body, closer, nil
+	}
+
+	defer func() { _ = resp.Body.Close() }()
 	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadBytes+1))
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed reading source: %w", err)
@@ -187,14 +274,11 @@ func fetchHTTPSource(m map[string]any) (io.Reader, func(), error) {
 	if int64(len(data)) > maxDownloadBytes {
 		return nil, nil, fmt.Errorf("source exceeds max download size")
 	}
-	if member, ok := m["Extract"].(string); ok && member != "" {
-		out, err := extractZipMember(data, member, maxDecompressedBytes)
-		if err != nil {
-			return nil, nil, err
-		}
-		return bytes.NewReader(out), func() {}, nil
+	out, err := extractZipMember(data, member, maxDecompressedBytes)
+	if err != nil {
+		return nil, nil, err
 	}
-	return bytes.NewReader(data), func() {}, nil
+	return bytes.NewReader(out), func() {}, nil
 }
 
 func (o *Object) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
@@ -221,28 +305,31 @@ func (o *Object) createWithClient(ctx context.Context, client s3ObjectClient, re
 		return nil, fmt.Errorf("invalid Key: %w", err)
 	}
 
-	body, closer, err := resolveBodyWithCloser(props)
-	if err != nil {
-		return nil, fmt.Errorf("failed to resolve body: %w", err)
+	nativeID := buildNativeID(bucket, key)
+	versionID, err := o.putObject(ctx, client, resource.OperationCreate, bucket, key, props, nil)
+	if code := conditionalWriteErrorCode(err); code != "" {
+		return &resource.CreateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationCreate,
+				OperationStatus: resource.OperationStatusFailure,
+				NativeID:        nativeID,
+				ErrorCode:       code,
+				StatusMessage:   fmt.Sprintf("an object already exists at s3://%s/%s: %v", bucket, key, err),
+			},
+		}, nil
 	}
-	defer closer()
-
-	input, err := buildPutObjectInput(bucket, key, body, props)
 	if err != nil {
 		return nil, err
 	}
-
-	_, err = client.PutObject(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("failed to put object: %w", err)
+	if err := applyObjectLock(ctx, client, bucket, key, versionID, props); err != nil {
+		return nil, err
 	}
 
-	nativeID := buildNativeID(bucket, key)
 	// Read back the created object so the agent persists the actual state
 	// (Tags, ETag, VersionId, ServerSideEncryption, …) as ResourceProperties,
 	// matching what a later sync would store. Without this the create-time
 	// stored state omits read-only/collection fields like Tags.
-	readResult, err := o.readWithClient(ctx, client, &resource.ReadRequest{NativeID: nativeID})
+	readResult, err := o.readObject(ctx, client, nativeID, versionID, props)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read back object after create: %w", err)
 	}
@@ -256,15 +343,167 @@ func (o *Object) createWithClient(ctx context.Context, client s3ObjectClient, re
 	}, nil
 }
 
-// buildPutObjectInput assembles a PutObjectInput from the resolved body and
-// properties, applying every optional object attribute. Shared by Create and
-// Update so the two paths never diverge on which fields they honour.
-func buildPutObjectInput(bucket, key string, body io.Reader, props map[string]any) (*s3.PutObjectInput, error) {
-	input := &s3.PutObjectInput{
+// putObject writes the object from its properties. An s3:// Source is copied
+// within S3 (see copyObject); any other body is resolved and uploaded, with
+// its SHA-256 recorded in the object's metadata.
+//
+// On an update, prior is the recorded model. A body that hashes the same as
+// the live object isn't uploaded again: the object is left alone if its other
+// properties are unchanged too, and otherwise rewritten in place by a copy
+// within S3.
+//
+// A create only writes the object if the key is free (If-None-Match: *), so
+// an object formae doesn't manage is never overwritten; see
+// conditionalWriteErrorCode.
+//
+// It returns the version written, which is empty if the bucket isn't
+// versioned or nothing was written.
+func (o *Object) putObject(ctx context.Context, client s3ObjectClient, op resource.Operation, bucket, key string, props, prior map[string]any) (string, error) {
+	if source, ok := props["Source"]; ok && isS3Source(source) {
+		_, hasContent := props["Content"]
+		_, hasBase64 := props["ContentBase64"]
+		if hasContent || hasBase64 {
+			return "", fmt.Errorf("failed to resolve body: content, contentBase64, and source are mutually exclusive")
+		}
+		src, err := parseS3Source(source.(string))
+		if err != nil {
+			return "", err
+		}
+		input, err := buildUploadObjectInput(bucket, key, nil, props)
+		if err != nil {
+			return "", err
+		}
+		if op == resource.OperationCreate {
+			input.IfNoneMatch = aws.String("*")
+		}
+		return o.copyObject(ctx, client, src, input, true)
+	}
+
+	// An update that gives no body only changes the object's attributes, so
+	// the stored content is rewritten in place by a copy within S3 rather
+	// than replaced by an empty body.
+	if op == resource.OperationUpdate && !hasObjectBody(props) {
+		input, err := buildUploadObjectInput(bucket, key, nil, props)
+		if err != nil {
+			return "", err
+		}
+		if input.SSECustomerKey != nil {
+			return "", fmt.Errorf("an object under a customer-provided key can't be updated without its content")
+		}
+		if prior != nil && sameObjectAttributes(props, prior) {
+			return "", nil
+		}
+		return o.copyObject(ctx, client, s3Location{bucket: bucket, key: key}, input, false)
+	}
+
+	body, closer, err := resolveBodyWithCloser(props)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve body: %w", err)
+	}
+	defer closer()
+
+	input, err := buildUploadObjectInput(bucket, key, body, props)
+	if err != nil {
+		return "", err
+	}
+	hash, err := contentSHA256(input.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash body: %w", err)
+	}
+	// An object under a customer-provided key is always uploaded: rewriting
+	// it in place would need the key it is stored under, which may not be the
+	// one the model gives now.
+	if hash != "" && prior != nil && input.SSECustomerKey == nil {
+		liveHash, err := liveContentHash(ctx, client, bucket, key)
+		if err != nil {
+			return "", err
+		}
+		if liveHash == hash {
+			if sameObjectAttributes(props, prior) {
+				return "", nil
+			}
+			return o.copyObject(ctx, client, s3Location{bucket: bucket, key: key}, input, false)
+		}
+	}
+	if hash != "" {
+		setContentHash(input, hash)
+	}
+	checksum, err := localChecksum(input.Body, input.ChecksumAlgorithm)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute checksum: %w", err)
+	}
+	if op == resource.OperationCreate {
+		input.IfNoneMatch = aws.String("*")
+	}
+	return o.upload(ctx, client, input, checksum)
+}
+
+// conditionalWriteErrorCode returns the error code a create reports when its
+// conditional write failed: AlreadyExists when the key holds an object, and
+// the recoverable ResourceConflict when another conditional write to the key
+// was in progress. It returns "" for any other error.
+func conditionalWriteErrorCode(err error) resource.OperationErrorCode {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return ""
+	}
+	switch apiErr.ErrorCode() {
+	case "PreconditionFailed":
+		return resource.OperationErrorCodeAlreadyExists
+	case "ConditionalRequestConflict":
+		return resource.OperationErrorCodeResourceConflict
+	}
+	return ""
+}
+
+// upload writes the object through the S3 transfer manager. A body smaller
+// than one part is sent with a single PutObject; a larger one is streamed as
+// a multipart upload, which also lifts PutObject's 5 GB cap. The part size
+// and the number of parts in flight come from the target (see uploadOptions).
+// A checksum computed locally under the input's ChecksumAlgorithm is
+// verified against the one S3 reports (see verifyChecksum). It returns the
+// version written, which is empty unless the bucket is versioned.
+func (o *Object) upload(ctx context.Context, client s3ObjectClient, input *transfermanager.UploadObjectInput, checksum string) (string, error) {
+	out, err := transfermanager.New(client, o.uploadOptions).UploadObject(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to put object: %w", err)
+	}
+	if err := verifyChecksum(ctx, client, input, out, checksum); err != nil {
+		return "", err
+	}
+	return aws.ToString(out.VersionID), nil
+}
+
+// uploadOptions applies the target's S3UploadPartSizeMb and
+// S3UploadConcurrency. Unset, the transfer manager uses 8 MiB parts, five at
+// a time; up to concurrency+1 parts are held in memory during an upload.
+func (o *Object) uploadOptions(opts *transfermanager.Options) {
+	if o.cfg == nil {
+		return
+	}
+	if o.cfg.S3UploadPartSizeMb > 0 {
+		opts.PartSizeBytes = int64(o.cfg.S3UploadPartSizeMb) << 20
+	}
+	if o.cfg.S3UploadConcurrency > 0 {
+		opts.Concurrency = o.cfg.S3UploadConcurrency
+	}
+}
+
+// buildUploadObjectInput assembles an UploadObjectInput from the resolved body
+// and properties, applying every optional object attribute. Shared by Create
+// and Update so the two paths never diverge on which fields they honour.
+func buildUploadObjectInput(bucket, key string, body io.Reader, props map[string]any) (*transfermanager.UploadObjectInput, error) {
+	if body == nil {
+		body = strings.NewReader("")
+	}
+	input := &transfermanager.UploadObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
 		Body:   body,
 	}
+	if sized, ok := body.(*sizedBody); ok {
+		input.ContentLength = aws.Int64(sized.size)
+	}
 
 	if ct, _ := utils.GetStringProperty(props, "ContentType"); ct != "" {
 		input.ContentType = aws.String(ct)
@@ -282,28 +521,28 @@ func buildPutObjectInput(bucket, key string, body io.Reader, props map[string]an
 		input.CacheControl = aws.String(cc)
 	}
 	if sc, _ := utils.GetStringProperty(props, "StorageClass"); sc != "" {
-		input.StorageClass = s3types.StorageClass(sc)
+		input.StorageClass = tmtypes.StorageClass(sc)
 	}
 	if sse, _ := utils.GetStringProperty(props, "ServerSideEncryption"); sse != "" {
-		input.ServerSideEncryption = s3types.ServerSideEncryption(sse)
+		input.ServerSideEncryption = tmtypes.ServerSideEncryption(sse)
 	}
 	if kmsKey, _ := utils.GetStringProperty(props, "KmsKeyId"); kmsKey != "" {
-		input.SSEKMSKeyId = aws.String(kmsKey)
+		input.SSEKMSKeyID = aws.String(kmsKey)
 	}
 	if ca, _ := utils.GetStringProperty(props, "ChecksumAlgorithm"); ca != "" {
-		input.ChecksumAlgorithm = s3types.ChecksumAlgorithm(ca)
+		input.ChecksumAlgorithm = tmtypes.ChecksumAlgorithm(ca)
 	}
 	if acl, _ := utils.GetStringProperty(props, "Acl"); acl != "" {
-		input.ACL = s3types.ObjectCannedACL(acl)
+		input.ACL = tmtypes.ObjectCannedACL(acl)
 	}
 	if wrl, _ := utils.GetStringProperty(props, "WebsiteRedirectLocation"); wrl != "" {
 		input.WebsiteRedirectLocation = aws.String(wrl)
 	}
 	if olhs, _ := utils.GetStringProperty(props, "ObjectLockLegalHoldStatus"); olhs != "" {
-		input.ObjectLockLegalHoldStatus = s3types.ObjectLockLegalHoldStatus(olhs)
+		input.ObjectLockLegalHoldStatus = tmtypes.ObjectLockLegalHoldStatus(olhs)
 	}
 	if olm, _ := utils.GetStringProperty(props, "ObjectLockMode"); olm != "" {
-		input.ObjectLockMode = s3types.ObjectLockMode(olm)
+		input.ObjectLockMode = tmtypes.ObjectLockMode(olm)
 	}
 	if olrud, _ := utils.GetStringProperty(props, "ObjectLockRetainUntilDate"); olrud != "" {
 		t, err := time.Parse(time.RFC3339, olrud)
@@ -328,6 +567,15 @@ func buildPutObjectInput(bucket, key string, body io.Reader, props map[string]an
 			input.Tagging = aws.String(buildTaggingHeader(tagList))
 		}
 	}
+	sse, err := sseCustomerKeyFrom(props)
+	if err != nil {
+		return nil, err
+	}
+	if sse != nil {
+		input.SSECustomerAlgorithm = aws.String(sse.algorithm)
+		input.SSECustomerKey = aws.String(sse.key)
+		input.SSECustomerKeyMD5 = aws.String(sse.keyMD5)
+	}
 
 	return input, nil
 }
@@ -356,34 +604,122 @@ func (o *Object) Read(ctx context.Context, request *resource.ReadRequest) (*reso
 }
 
 func (o *Object) readWithClient(ctx context.Context, client s3ObjectClient, request *resource.ReadRequest) (*resource.ReadResult, error) {
-	bucket, key, err := parseNativeID(request.NativeID)
+	// The prior model only says whether to report a presigned URL, which is
+	// left out when sensitive values are redacted.
+	var prior map[string]any
+	if len(request.PriorProperties) > 0 && !request.RedactSensitive {
+		_ = json.Unmarshal(request.PriorProperties, &prior)
+	}
+	return o.readObject(ctx, client, request.NativeID, "", prior)
+}
+
+// readObject reads the object's current version, or the given one. A create
+// or update reads back the version it wrote, so the VersionId it records is
+// that write's even if the key has been written again since. model is the
+// desired or prior model, which may ask for a presigned URL.
+func (o *Object) readObject(ctx context.Context, client s3ObjectClient, nativeID, versionID string, model map[string]any) (*resource.ReadResult, error) {
+	bucket, key, err := parseNativeID(nativeID)
 	if err != nil {
 		return nil, err
 	}
 
-	head, err := client.HeadObject(ctx, &s3.HeadObjectInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(key),
-	})
-	if err != nil {
-		var notFound *s3types.NotFound
-		if errors.As(err, &notFound) {
-			return &resource.ReadResult{
-				ResourceType: "AWS::S3::Object",
-				ErrorCode:    resource.OperationErrorCodeNotFound,
-			}, nil
+	var version *string
+	if versionID != "" {
+		version = aws.String(versionID)
+	}
+	// A model that gives a customer-provided key has it sent to read the
+	// object; a malformed one is left for the next write to report.
+	sse, _ := sseCustomerKeyFrom(model)
+	headInput := &s3.HeadObjectInput{
+		Bucket:       aws.String(bucket),
+		Key:          aws.String(key),
+		VersionId:    version,
+		ChecksumMode: s3types.ChecksumModeEnabled,
+	}
+	if sse != nil {
+		headInput.SSECustomerAlgorithm = aws.String(sse.algorithm)
+		headInput.SSECustomerKey = aws.String(sse.key)
+		headInput.SSECustomerKeyMD5 = aws.String(sse.keyMD5)
+	}
+
+	var props map[string]any
+	head, err := client.HeadObject(ctx, headInput)
+	switch {
+	case err == nil:
+		props = headObjectProperties(bucket, key, head)
+	case isObjectNotFound(err):
+		return &resource.ReadResult{
+			ResourceType: "AWS::S3::Object",
+			ErrorCode:    resource.OperationErrorCodeNotFound,
+		}, nil
+	case sse == nil && isBadRequest(err):
+		// An object encrypted with a customer-provided key can't be headed
+		// without the key, which the model may not carry.
+		props, err = listedObjectProperties(ctx, client, bucket, key, model)
+		if err != nil {
+			return nil, err
 		}
-		// Also handle HTTP 404 from smithy
-		var respErr interface{ HTTPStatusCode() int }
-		if errors.As(err, &respErr) && respErr.HTTPStatusCode() == 404 {
+		if props == nil {
 			return &resource.ReadResult{
 				ResourceType: "AWS::S3::Object",
 				ErrorCode:    resource.OperationErrorCodeNotFound,
 			}, nil
 		}
+	default:
 		return nil, fmt.Errorf("failed to head object: %w", err)
 	}
 
+	// Get tags
+	tagging, err := client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: version,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object tagging for %s/%s: %w", bucket, key, err)
+	}
+	if len(tagging.TagSet) > 0 {
+		var tags []map[string]string
+		for _, tag := range tagging.TagSet {
+			tags = append(tags, map[string]string{
+				"Key":   *tag.Key,
+				"Value": *tag.Value,
+			})
+		}
+		props["Tags"] = tags
+	}
+
+	acl, err := client.GetObjectAcl(ctx, &s3.GetObjectAclInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: version,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object ACL for %s/%s: %w", bucket, key, err)
+	}
+	declaredACL, _ := utils.GetStringProperty(model, "Acl")
+	if canned := cannedACL(acl, declaredACL); canned != "" {
+		props["Acl"] = canned
+	}
+
+	if err := o.addPresignedURL(ctx, client, bucket, key, props, model); err != nil {
+		return nil, err
+	}
+
+	propBytes, err := json.Marshal(props)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal properties: %w", err)
+	}
+
+	return &resource.ReadResult{
+		ResourceType: "AWS::S3::Object",
+		Properties:   string(propBytes),
+	}, nil
+}
+
+// headObjectProperties returns the properties a HeadObject of the object
+// reports.
+func headObjectProperties(bucket, key string, head *s3.HeadObjectOutput) map[string]any {
 	props := map[string]any{
 		"Bucket": bucket,
 		"Key":    key,
@@ -434,38 +770,25 @@ func (o *Object) readWithClient(ctx context.Context, client s3ObjectClient, requ
 	if head.ObjectLockRetainUntilDate != nil {
 		props["ObjectLockRetainUntilDate"] = head.ObjectLockRetainUntilDate.Format("2006-01-02T15:04:05Z")
 	}
-	if len(head.Metadata) > 0 {
-		props["Metadata"] = head.Metadata
-	}
-
-	// Get tags
-	tagging, err := client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(key),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get object tagging for %s/%s: %w", bucket, key, err)
+	if checksum := headChecksum(head); checksum != "" {
+		props[checksumKey] = checksum
 	}
-	if len(tagging.TagSet) > 0 {
-		var tags []map[string]string
-		for _, tag := range tagging.TagSet {
-			tags = append(tags, map[string]string{
-				"Key":   *tag.Key,
-				"Value": *tag.Value,
-			})
+	// The content hash is the plugin's own metadata entry, not the model's.
+	metadata := make(map[string]string, len(head.Metadata))
+	for k, v := range head.Metadata {
+		if k == contentHashMetadataKey {
+			props["ContentSha256"] = v
+			continue
 		}
-		props["Tags"] = tags
+		metadata[k] = v
 	}
-
-	propBytes, err := json.Marshal(props)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal properties: %w", err)
+	if len(metadata) > 0 {
+		props["Metadata"] = metadata
 	}
-
-	return &resource.ReadResult{
-		ResourceType: "AWS::S3::Object",
-		Properties:   string(propBytes),
-	}, nil
+	if head.SSECustomerAlgorithm != nil {
+		props["SseCustomerAlgorithm"] = *head.SSECustomerAlgorithm
+	}
+	return props
 }
 
 func (o *Object) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
@@ -492,26 +815,28 @@ func (o *Object) updateWithClient(ctx context.Context, client s3ObjectClient, re
 		return nil, fmt.Errorf("invalid Key: %w", err)
 	}
 
-	body, closer, err := resolveBodyWithCloser(props)
-	if err != nil {
-		return nil, fmt.Errorf("failed to resolve body: %w", err)
+	var prior map[string]any
+	if len(request.PriorProperties) > 0 {
+		if err := json.Unmarshal(request.PriorProperties, &prior); err != nil {
+			return nil, fmt.Errorf("failed to parse prior properties: %w", err)
+		}
 	}
-	defer closer()
 
-	input, err := buildPutObjectInput(bucket, key, body, props)
+	versionID, err := o.putObject(ctx, client, resource.OperationUpdate, bucket, key, props, prior)
 	if err != nil {
 		return nil, err
 	}
-
-	_, err = client.PutObject(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("failed to put object: %w", err)
+	if err := applyObjectLock(ctx, client, bucket, key, versionID, props); err != nil {
+		return nil, err
+	}
+	if err := applyObjectACL(ctx, client, bucket, key, versionID, props); err != nil {
+		return nil, err
 	}
 
 	nativeID := buildNativeID(bucket, key)
 	// Read back the updated object so the agent persists the actual state as
 	// ResourceProperties (see createWithClient).
-	readResult, err := o.readWithClient(ctx, client, &resource.ReadRequest{NativeID: nativeID})
+	readResult, err := o.readObject(ctx, client, nativeID, versionID, props)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read back object after update: %w", err)
 	}
@@ -540,12 +865,18 @@ func (o *Object) deleteWithClient(ctx context.Context, client s3ObjectClient, re
 		return nil, err
 	}
 
-	_, err = client.DeleteObject(ctx, &s3.DeleteObjectInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(key),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to delete object: %w", err)
+	if o.cfg != nil && o.cfg.S3DeleteAllObjectVersions {
+		if err := deleteAllVersions(ctx, client, bucket, key); err != nil {
+			return nil, err
+		}
+	} else {
+		_, err = client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete object: %w", err)
+		}
 	}
 
 	return &resource.DeleteResult{
@@ -557,6 +888,61 @@ func (o *Object) deleteWithClient(ctx context.Context, client s3ObjectClient, re
 	}, nil
 }
 
+// deleteAllVersions removes every version and delete marker of the key. On a
+// versioned bucket a plain delete only adds a delete marker, leaving the
+// object's versions, and their storage, behind. Versions are listed in key
+// order, so the listing stops at the first key that only has key as a prefix.
+func deleteAllVersions(ctx context.Context, client s3ObjectClient, bucket, key string) error {
+	input := &s3.ListObjectVersionsInput{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(key),
+	}
+	for {
+		out, err := client.ListObjectVersions(ctx, input)
+		if err != nil {
+			return fmt.Errorf("failed to list object versions: %w", err)
+		}
+
+		var ids []s3types.ObjectIdentifier
+		done := !aws.ToBool(out.IsTruncated)
+		for _, v := range out.Versions {
+			if aws.ToString(v.Key) != key {
+				done = true
+				continue
+			}
+			ids = append(ids, s3types.ObjectIdentifier{Key: v.Key, VersionId: v.VersionId})
+		}
+		for _, m := range out.DeleteMarkers {
+			if aws.ToString(m.Key) != key {
+				done = true
+				continue
+			}
+			ids = append(ids, s3types.ObjectIdentifier{Key: m.Key, VersionId: m.VersionId})
+		}
+
+		if len(ids) > 0 {
+			res, err := client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+				Bucket: aws.String(bucket),
+				Delete: &s3types.Delete{Objects: ids, Quiet: aws.Bool(true)},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to delete object versions: %w", err)
+			}
+			if len(res.Errors) > 0 {
+				e := res.Errors[0]
+				return fmt.Errorf("failed to delete version %s of object: %s: %s",
+					aws.ToString(e.VersionId), aws.ToString(e.Code), aws.ToString(e.Message))
+			}
+		}
+
+		if done {
+			return nil
+		}
+		input.KeyMarker = out.NextKeyMarker
+		input.VersionIdMarker = out.NextVersionIdMarker
+	}
+}
+
 // Status returns success immediately — all S3 operations are synchronous.
 func (o *Object) Status(_ context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
 	return &resource.StatusResult{
@@ -611,6 +997,14 @@ func (o *Object) listWithClient(ctx context.Context, client s3ObjectClient, requ
 	if request.PageToken != nil && *request.PageToken != "" {
 		input.ContinuationToken = request.PageToken
 	}
+	// Prefix narrows the listing to a folder of a large bucket; with a
+	// Delimiter, keys nested below the folder's own are left out.
+	if prefix := request.AdditionalProperties["Prefix"]; prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+	if delimiter := request.AdditionalProperties["Delimiter"]; delimiter != "" {
+		input.Delimiter = aws.String(delimiter)
+	}
 
 	resp, err := client.ListObjectsV2(ctx, input)
 	if err != nil {
@@ -23,10 +23,13 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -37,6 +40,7 @@ import (
 func mockReadBack(client *mockS3ObjectClient, ctx context.Context) {
 	client.On("HeadObject", ctx, mock.Anything).Return(&s3.HeadObjectOutput{}, nil).Maybe()
 	client.On("GetObjectTagging", ctx, mock.Anything).Return(&s3.GetObjectTaggingOutput{}, nil).Maybe()
+	client.On("GetObjectAcl", ctx, mock.Anything).Return(&s3.GetObjectAclOutput{}, nil).Maybe()
 }
 
 func TestBuildNativeID(t *testing.T) {
@@ -112,6 +116,39 @@ func TestResolveBody_SourceURL(t *testing.T) {
 	assert.Equal(t, "remote content", string(data))
 }
 
+func TestResolveBody_SourceFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "artifact.jar")
+	require.NoError(t, os.WriteFile(path, []byte("artifact"), 0o600))
+
+	reader, closer, err := resolveBodyWithCloser(map[string]any{"Source": "file://" + path})
+	require.NoError(t, err)
+	defer closer()
+
+	// The file is streamed from disk; it is seekable so the upload can size it.
+	_, ok := reader.(io.Seeker)
+	assert.True(t, ok, "expected a seekable body, got %T", reader)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "artifact", string(data))
+}
+
+func TestResolveBody_SourceFile_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := map[string]string{
+		"missing":   "file://" + filepath.Join(dir, "missing.jar"),
+		"directory": "file://" + dir,
+		"relative":  "file://build/artifact.jar",
+		"no path":   "file://",
+	}
+	for name, source := range tests {
+		t.Run(name, func(t *testing.T) {
+			reader, _, err := resolveBodyWithCloser(map[string]any{"Source": source})
+			assert.Error(t, err)
+			assert.Nil(t, reader)
+		})
+	}
+}
+
 func TestResolveBody_MutualExclusivity(t *testing.T) {
 	props := map[string]any{
 		"Content":       "hello",
@@ -145,7 +182,8 @@ func TestCreate_Success(t *testing.T) {
 	client.On("PutObject", ctx, mock.MatchedBy(func(input *s3.PutObjectInput) bool {
 		return *input.Bucket == "my-bucket" &&
 			*input.Key == "path/to/file.txt" &&
-			*input.ContentType == "text/plain"
+			*input.ContentType == "text/plain" &&
+			aws.ToString(input.IfNoneMatch) == "*"
 	})).Return(&s3.PutObjectOutput{}, nil)
 
 	o := &Object{}
@@ -176,6 +214,7 @@ func TestCreate_PopulatesResourcePropertiesFromReadBack(t *testing.T) {
 	client.On("GetObjectTagging", ctx, mock.Anything).Return(&s3.GetObjectTaggingOutput{
 		TagSet: []s3types.Tag{{Key: aws.String("Name"), Value: aws.String("v")}},
 	}, nil)
+	client.On("GetObjectAcl", ctx, mock.Anything).Return(&s3.GetObjectAclOutput{}, nil)
 
 	o := &Object{}
 	result, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: propsBytes})
@@ -211,6 +250,7 @@ func TestRead_Success(t *testing.T) {
 			{Key: aws.String("Name"), Value: aws.String("test-file")},
 		},
 	}, nil)
+	client.On("GetObjectAcl", ctx, mock.Anything).Return(&s3.GetObjectAclOutput{}, nil)
 
 	o := &Object{}
 	result, err := o.readWithClient(ctx, client, &resource.ReadRequest{
@@ -308,6 +348,7 @@ func TestRead_NoTags(t *testing.T) {
 	client.On("GetObjectTagging", ctx, mock.Anything).Return(&s3.GetObjectTaggingOutput{
 		TagSet: []s3types.Tag{},
 	}, nil)
+	client.On("GetObjectAcl", ctx, mock.Anything).Return(&s3.GetObjectAclOutput{}, nil)
 
 	o := &Object{}
 	result, err := o.readWithClient(ctx, client, &resource.ReadRequest{
@@ -341,7 +382,8 @@ func TestUpdate_Success(t *testing.T) {
 	client.On("PutObject", ctx, mock.MatchedBy(func(input *s3.PutObjectInput) bool {
 		return *input.Bucket == "my-bucket" &&
 			*input.Key == "path/to/file.txt" &&
-			*input.ContentType == "text/html"
+			*input.ContentType == "text/html" &&
+			input.IfNoneMatch == nil
 	})).Return(&s3.PutObjectOutput{}, nil)
 
 	o := &Object{}
@@ -379,6 +421,11 @@ func TestCreate_SetsObjectLockRetainUntilDate(t *testing.T) {
 			input.ObjectLockRetainUntilDate != nil &&
 			input.ObjectLockRetainUntilDate.Equal(expected)
 	})).Return(&s3.PutObjectOutput{}, nil)
+	// PutObject set the retention, so it isn't applied again.
+	client.On("HeadObject", ctx, mock.Anything).Return(&s3.HeadObjectOutput{
+		ObjectLockMode:            s3types.ObjectLockModeGovernance,
+		ObjectLockRetainUntilDate: &expected,
+	}, nil)
 
 	o := &Object{}
 	mockReadBack(client, ctx)
@@ -407,6 +454,11 @@ func TestUpdate_SetsObjectLockFields(t *testing.T) {
 			input.ObjectLockRetainUntilDate != nil &&
 			input.ObjectLockRetainUntilDate.Equal(expected)
 	})).Return(&s3.PutObjectOutput{}, nil)
+	// PutObject set the retention, so it isn't applied again.
+	client.On("HeadObject", ctx, mock.Anything).Return(&s3.HeadObjectOutput{
+		ObjectLockMode:            s3types.ObjectLockModeCompliance,
+		ObjectLockRetainUntilDate: &expected,
+	}, nil)
 
 	o := &Object{}
 	mockReadBack(client, ctx)
@@ -436,6 +488,115 @@ func TestCreate_InvalidObjectLockRetainUntilDate_Errors(t *testing.T) {
 	client.AssertExpectations(t) // PutObject must not be called
 }
 
+func TestCreate_LargeBodyUsesMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	// 11 MiB in 5 MiB parts: three parts.
+	props := map[string]any{
+		"Bucket":      "my-bucket",
+		"Key":         "big.bin",
+		"Content":     strings.Repeat("x", 11<<20),
+		"ContentType": "application/octet-stream",
+	}
+	propsBytes, _ := json.Marshal(props)
+
+	client.On("CreateMultipartUpload", ctx, mock.MatchedBy(func(input *s3.CreateMultipartUploadInput) bool {
+		return *input.Bucket == "my-bucket" && *input.Key == "big.bin" &&
+			*input.ContentType == "application/octet-stream"
+	})).Return(&s3.CreateMultipartUploadOutput{UploadId: aws.String("upload-1")}, nil)
+	client.On("UploadPart", ctx, mock.MatchedBy(func(input *s3.UploadPartInput) bool {
+		return *input.UploadId == "upload-1"
+	})).Return(&s3.UploadPartOutput{ETag: aws.String("etag")}, nil).Times(3)
+	client.On("CompleteMultipartUpload", ctx, mock.MatchedBy(func(input *s3.CompleteMultipartUploadInput) bool {
+		return *input.UploadId == "upload-1" && len(input.MultipartUpload.Parts) == 3
+	})).Return(&s3.CompleteMultipartUploadOutput{}, nil)
+
+	o := &Object{cfg: &config.Config{S3UploadPartSizeMb: 5, S3UploadConcurrency: 2}}
+	mockReadBack(client, ctx)
+	result, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: propsBytes})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything)
+}
+
+func TestCreate_FailedMultipartUploadIsAborted(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	props := map[string]any{"Bucket": "my-bucket", "Key": "big.bin", "Content": strings.Repeat("x", 11<<20)}
+	propsBytes, _ := json.Marshal(props)
+
+	client.On("CreateMultipartUpload", ctx, mock.Anything).Return(&s3.CreateMultipartUploadOutput{UploadId: aws.String("upload-1")}, nil)
+	client.On("UploadPart", ctx, mock.Anything).Return((*s3.UploadPartOutput)(nil), errors.New("connection reset"))
+	client.On("AbortMultipartUpload", ctx, mock.MatchedBy(func(input *s3.AbortMultipartUploadInput) bool {
+		return *input.UploadId == "upload-1"
+	})).Return(&s3.AbortMultipartUploadOutput{}, nil)
+
+	o := &Object{cfg: &config.Config{S3UploadPartSizeMb: 5, S3UploadConcurrency: 1}}
+	_, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: propsBytes})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to put object")
+	client.AssertCalled(t, "AbortMultipartUpload", ctx, mock.Anything)
+}
+
+func TestCreate_NoBodyPutsEmptyObject(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	props := map[string]any{"Bucket": "my-bucket", "Key": "empty"}
+	propsBytes, _ := json.Marshal(props)
+
+	client.On("PutObject", ctx, mock.MatchedBy(func(input *s3.PutObjectInput) bool {
+		data, _ := io.ReadAll(input.Body)
+		return len(data) == 0
+	})).Return(&s3.PutObjectOutput{}, nil)
+
+	o := &Object{}
+	mockReadBack(client, ctx)
+	_, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: propsBytes})
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestUploadOptions(t *testing.T) {
+	var opts transfermanager.Options
+	(&Object{cfg: &config.Config{S3UploadPartSizeMb: 64, S3UploadConcurrency: 10}}).uploadOptions(&opts)
+	assert.Equal(t, int64(64<<20), opts.PartSizeBytes)
+	assert.Equal(t, 10, opts.Concurrency)
+
+	// Unset values keep the transfer manager's defaults.
+	opts = transfermanager.Options{}
+	(&Object{cfg: &config.Config{}}).uploadOptions(&opts)
+	assert.Zero(t, opts.PartSizeBytes)
+	assert.Zero(t, opts.Concurrency)
+}
+
+func TestResolveBody_SourceIsStreamed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "14")
+		w.Write([]byte("remote content"))
+	}))
+	defer server.Close()
+
+	reader, closer, err := resolveBodyWithCloser(map[string]any{"Source": server.URL})
+	require.NoError(t, err)
+	defer closer()
+
+	// The response body is handed to the upload as is, with the announced
+	// length, rather than buffered into memory.
+	sized, ok := reader.(*sizedBody)
+	require.True(t, ok, "expected a streamed body, got %T", reader)
+	assert.Equal(t, int64(14), sized.size)
+
+	input, err := buildUploadObjectInput("my-bucket", "k", reader, map[string]any{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(14), aws.ToInt64(input.ContentLength))
+}
+
 func TestDelete_Success(t *testing.T) {
 	ctx := context.Background()
 	client := &mockS3ObjectClient{}
@@ -478,6 +639,129 @@ func TestDelete_NotFound_IsIdempotent(t *testing.T) {
 	client.AssertExpectations(t)
 }
 
+func TestCreate_ExistingObjectIsNotOverwritten(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want resource.OperationErrorCode
+	}{
+		{"object at key", "PreconditionFailed", resource.OperationErrorCodeAlreadyExists},
+		{"concurrent write", "ConditionalRequestConflict", resource.OperationErrorCodeResourceConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &mockS3ObjectClient{}
+
+			props := map[string]any{"Bucket": "my-bucket", "Key": "file.txt", "Content": "hello world"}
+			propsBytes, _ := json.Marshal(props)
+
+			client.On("PutObject", ctx, mock.Anything).Return((*s3.PutObjectOutput)(nil), &smithy.GenericAPIError{Code: tt.code})
+
+			o := &Object{}
+			result, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: propsBytes})
+
+			require.NoError(t, err)
+			assert.Equal(t, resource.OperationStatusFailure, result.ProgressResult.OperationStatus)
+			assert.Equal(t, tt.want, result.ProgressResult.ErrorCode)
+			assert.Contains(t, result.ProgressResult.StatusMessage, "s3://my-bucket/file.txt")
+			client.AssertNotCalled(t, "HeadObject", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreate_ReadsBackWrittenVersion(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	props := map[string]any{"Bucket": "my-bucket", "Key": "file.txt", "Content": "hello world"}
+	propsBytes, _ := json.Marshal(props)
+
+	client.On("PutObject", ctx, mock.Anything).Return(&s3.PutObjectOutput{VersionId: aws.String("v2")}, nil)
+	client.On("HeadObject", ctx, mock.MatchedBy(func(input *s3.HeadObjectInput) bool {
+		return aws.ToString(input.VersionId) == "v2"
+	})).Return(&s3.HeadObjectOutput{VersionId: aws.String("v2")}, nil)
+	client.On("GetObjectTagging", ctx, mock.MatchedBy(func(input *s3.GetObjectTaggingInput) bool {
+		return aws.ToString(input.VersionId) == "v2"
+	})).Return(&s3.GetObjectTaggingOutput{}, nil)
+	client.On("GetObjectAcl", ctx, mock.Anything).Return(&s3.GetObjectAclOutput{}, nil)
+
+	o := &Object{}
+	result, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: propsBytes})
+
+	require.NoError(t, err)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(result.ProgressResult.ResourceProperties, &got))
+	assert.Equal(t, "v2", got["VersionId"])
+	client.AssertExpectations(t)
+}
+
+func TestDelete_AllVersions(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	client.On("ListObjectVersions", ctx, mock.MatchedBy(func(input *s3.ListObjectVersionsInput) bool {
+		return *input.Prefix == "file.txt" && input.KeyMarker == nil
+	})).Return(&s3.ListObjectVersionsOutput{
+		IsTruncated:         aws.Bool(true),
+		NextKeyMarker:       aws.String("file.txt"),
+		NextVersionIdMarker: aws.String("v2"),
+		Versions: []s3types.ObjectVersion{
+			{Key: aws.String("file.txt"), VersionId: aws.String("v3")},
+			{Key: aws.String("file.txt"), VersionId: aws.String("v2")},
+		},
+		DeleteMarkers: []s3types.DeleteMarkerEntry{{Key: aws.String("file.txt"), VersionId: aws.String("m1")}},
+	}, nil).Once()
+	// The next page runs into another key, which ends the listing.
+	client.On("ListObjectVersions", ctx, mock.MatchedBy(func(input *s3.ListObjectVersionsInput) bool {
+		return aws.ToString(input.KeyMarker) == "file.txt" && aws.ToString(input.VersionIdMarker) == "v2"
+	})).Return(&s3.ListObjectVersionsOutput{
+		IsTruncated: aws.Bool(true),
+		Versions: []s3types.ObjectVersion{
+			{Key: aws.String("file.txt"), VersionId: aws.String("v1")},
+			{Key: aws.String("file.txt.bak"), VersionId: aws.String("b1")},
+		},
+	}, nil).Once()
+	client.On("DeleteObjects", ctx, mock.MatchedBy(func(input *s3.DeleteObjectsInput) bool {
+		return len(input.Delete.Objects) == 3
+	})).Return(&s3.DeleteObjectsOutput{}, nil).Once()
+	client.On("DeleteObjects", ctx, mock.MatchedBy(func(input *s3.DeleteObjectsInput) bool {
+		return len(input.Delete.Objects) == 1 && *input.Delete.Objects[0].VersionId == "v1"
+	})).Return(&s3.DeleteObjectsOutput{}, nil).Once()
+
+	o := &Object{cfg: &config.Config{S3DeleteAllObjectVersions: true}}
+	result, err := o.deleteWithClient(ctx, client, &resource.DeleteRequest{NativeID: "my-bucket|file.txt"})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "DeleteObject", mock.Anything, mock.Anything)
+}
+
+func TestDelete_AllVersions_ReportsFailedVersion(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	client.On("ListObjectVersions", ctx, mock.Anything).Return(&s3.ListObjectVersionsOutput{
+		Versions: []s3types.ObjectVersion{{Key: aws.String("file.txt"), VersionId: aws.String("v1")}},
+	}, nil)
+	client.On("DeleteObjects", ctx, mock.Anything).Return(&s3.DeleteObjectsOutput{
+		Errors: []s3types.Error{{
+			Key:       aws.String("file.txt"),
+			VersionId: aws.String("v1"),
+			Code:      aws.String("AccessDenied"),
+			Message:   aws.String("Access Denied because object protected by object lock."),
+		}},
+	}, nil)
+
+	o := &Object{cfg: &config.Config{S3DeleteAllObjectVersions: true}}
+	_, err := o.deleteWithClient(ctx, client, &resource.DeleteRequest{NativeID: "my-bucket|file.txt"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "version v1")
+	assert.Contains(t, err.Error(), "AccessDenied")
+}
+
 func TestStatus_ReturnsSuccess(t *testing.T) {
 	o := &Object{}
 	result, err := o.Status(context.Background(), &resource.StatusRequest{
@@ -555,6 +839,34 @@ func TestList_WithPagination(t *testing.T) {
 	client.AssertExpectations(t)
 }
 
+func TestList_WithPrefixAndDelimiter(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	client.On("ListObjectsV2", ctx, mock.MatchedBy(func(input *s3.ListObjectsV2Input) bool {
+		return *input.Bucket == "my-bucket" && *input.Prefix == "logs/2025/" && *input.Delimiter == "/"
+	})).Return(&s3.ListObjectsV2Output{
+		Contents:       []s3types.Object{{Key: aws.String("logs/2025/app.log")}},
+		CommonPrefixes: []s3types.CommonPrefix{{Prefix: aws.String("logs/2025/archive/")}},
+		IsTruncated:    aws.Bool(false),
+	}, nil)
+
+	o := &Object{}
+	result, err := o.listWithClient(ctx, client, &resource.ListRequest{
+		ResourceType: "AWS::S3::Object",
+		PageSize:     100,
+		AdditionalProperties: map[string]string{
+			"BucketName": "my-bucket",
+			"Prefix":     "logs/2025/",
+			"Delimiter":  "/",
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"my-bucket|logs/2025/app.log"}, result.NativeIDs)
+	client.AssertExpectations(t)
+}
+
 func TestList_CrossRegionBucket_RetriesWithRedirectedRegion(t *testing.T) {
 	ctx := context.Background()
 	client := &mockS3ObjectClient{}
@@ -0,0 +1,169 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package s3
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/utils"
+)
+
+const (
+	allUsersGroup           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	authenticatedUsersGroup = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
+
+// Grantees as canned ACLs name them, relative to the object's owner.
+const (
+	granteeOwner              = "owner"
+	granteeAllUsers           = "all-users"
+	granteeAuthenticatedUsers = "authenticated-users"
+	// Another account, such as the bucket owner's.
+	granteeAccount = "account"
+	// Anyone no canned ACL grants to, such as the log delivery group.
+	granteeOther = "other"
+)
+
+type aclGrant struct {
+	grantee    string
+	permission s3types.Permission
+}
+
+type aclGrants map[aclGrant]bool
+
+func newACLGrants(grants ...aclGrant) aclGrants {
+	set := make(aclGrants, len(grants))
+	for _, g := range grants {
+		set[g] = true
+	}
+	return set
+}
+
+var ownerFullControl = aclGrant{granteeOwner, s3types.PermissionFullControl}
+
+// The grants each canned ACL makes. aws-exec-read grants to an account of
+// Amazon's, which is told apart from bucket-owner-read only by its ID.
+var cannedACLGrants = map[string]aclGrants{
+	"private":                   newACLGrants(ownerFullControl),
+	"public-read":               newACLGrants(ownerFullControl, aclGrant{granteeAllUsers, s3types.PermissionRead}),
+	"public-read-write":         newACLGrants(ownerFullControl, aclGrant{granteeAllUsers, s3types.PermissionRead}, aclGrant{granteeAllUsers, s3types.PermissionWrite}),
+	"authenticated-read":        newACLGrants(ownerFullControl, aclGrant{granteeAuthenticatedUsers, s3types.PermissionRead}),
+	"aws-exec-read":             newACLGrants(ownerFullControl, aclGrant{granteeAccount, s3types.PermissionRead}),
+	"bucket-owner-read":         newACLGrants(ownerFullControl, aclGrant{granteeAccount, s3types.PermissionRead}),
+	"bucket-owner-full-control": newACLGrants(ownerFullControl, aclGrant{granteeAccount, s3types.PermissionFullControl}),
+}
+
+// The canned ACLs a read reports when the model doesn't declare one the
+// grants match, in order of preference.
+var cannedACLOrder = []string{
+	"private",
+	"public-read",
+	"public-read-write",
+	"authenticated-read",
+	"bucket-owner-full-control",
+	"bucket-owner-read",
+}
+
+func objectACLGrants(acl *s3.GetObjectAclOutput) aclGrants {
+	var owner string
+	if acl.Owner != nil {
+		owner = aws.ToString(acl.Owner.ID)
+	}
+	set := make(aclGrants, len(acl.Grants))
+	for _, g := range acl.Grants {
+		grantee := granteeOther
+		if g.Grantee != nil {
+			switch {
+			case g.Grantee.Type == s3types.TypeCanonicalUser && aws.ToString(g.Grantee.ID) == owner:
+				grantee = granteeOwner
+			case g.Grantee.Type == s3types.TypeCanonicalUser:
+				grantee = granteeAccount
+			case aws.ToString(g.Grantee.URI) == allUsersGroup:
+				grantee = granteeAllUsers
+			case aws.ToString(g.Grantee.URI) == authenticatedUsersGroup:
+				grantee = granteeAuthenticatedUsers
+			}
+		}
+		set[aclGrant{grantee, g.Permission}] = true
+	}
+	return set
+}
+
+// matchesCannedACL reports whether grants are the ones canned ACL acl makes.
+// The bucket-owner ACLs make no grant of their own when the object's owner
+// owns the bucket, as it does on a bucket that enforces bucket ownership.
+func matchesCannedACL(acl string, grants aclGrants) bool {
+	want, ok := cannedACLGrants[acl]
+	if !ok {
+		return false
+	}
+	if maps.Equal(want, grants) {
+		return true
+	}
+	switch acl {
+	case "bucket-owner-read", "bucket-owner-full-control":
+		return maps.Equal(cannedACLGrants["private"], grants)
+	}
+	return false
+}
+
+// cannedACL is the canned ACL an object's grants amount to: the declared one
+// if they match it, or else the first that matches, or "" for grants no
+// canned ACL makes.
+func cannedACL(acl *s3.GetObjectAclOutput, declared string) string {
+	grants := objectACLGrants(acl)
+	if declared != "" && matchesCannedACL(declared, grants) {
+		return declared
+	}
+	for _, canned := range cannedACLOrder {
+		if matchesCannedACL(canned, grants) {
+			return canned
+		}
+	}
+	return ""
+}
+
+// applyObjectACL brings the grants on the object's version in line with the
+// model's canned ACL with PutObjectAcl, for an update that writes nothing or
+// grants changed outside formae. versionID is the version written, or "" for
+// the current one. An object with no declared ACL is left as it is.
+func applyObjectACL(ctx context.Context, client s3ObjectClient, bucket, key, versionID string, props map[string]any) error {
+	declared, _ := utils.GetStringProperty(props, "Acl")
+	if declared == "" {
+		return nil
+	}
+
+	var version *string
+	if versionID != "" {
+		version = aws.String(versionID)
+	}
+	live, err := client.GetObjectAcl(ctx, &s3.GetObjectAclInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: version,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get object ACL: %w", err)
+	}
+	if matchesCannedACL(declared, objectACLGrants(live)) {
+		return nil
+	}
+
+	if _, err := client.PutObjectAcl(ctx, &s3.PutObjectAclInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: version,
+		ACL:       s3types.ObjectCannedACL(declared),
+	}); err != nil {
+		return fmt.Errorf("failed to put object ACL: %w", err)
+	}
+	return nil
+}
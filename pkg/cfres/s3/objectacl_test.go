@@ -0,0 +1,163 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func canonicalUserGrant(id string, permission s3types.Permission) s3types.Grant {
+	return s3types.Grant{
+		Grantee:    &s3types.Grantee{Type: s3types.TypeCanonicalUser, ID: aws.String(id)},
+		Permission: permission,
+	}
+}
+
+func groupGrant(uri string, permission s3types.Permission) s3types.Grant {
+	return s3types.Grant{
+		Grantee:    &s3types.Grantee{Type: s3types.TypeGroup, URI: aws.String(uri)},
+		Permission: permission,
+	}
+}
+
+func objectACL(grants ...s3types.Grant) *s3.GetObjectAclOutput {
+	return &s3.GetObjectAclOutput{
+		Owner:  &s3types.Owner{ID: aws.String("owner-id")},
+		Grants: append([]s3types.Grant{canonicalUserGrant("owner-id", s3types.PermissionFullControl)}, grants...),
+	}
+}
+
+func TestCannedACL(t *testing.T) {
+	tests := []struct {
+		name     string
+		acl      *s3.GetObjectAclOutput
+		declared string
+		want     string
+	}{
+		{"private", objectACL(), "", "private"},
+		{"public read", objectACL(groupGrant(allUsersGroup, s3types.PermissionRead)), "", "public-read"},
+		{"public read write", objectACL(
+			groupGrant(allUsersGroup, s3types.PermissionRead),
+			groupGrant(allUsersGroup, s3types.PermissionWrite),
+		), "", "public-read-write"},
+		{"authenticated read", objectACL(groupGrant(authenticatedUsersGroup, s3types.PermissionRead)), "", "authenticated-read"},
+		{"bucket owner full control", objectACL(canonicalUserGrant("bucket-owner-id", s3types.PermissionFullControl)), "", "bucket-owner-full-control"},
+		{"bucket owner read", objectACL(canonicalUserGrant("bucket-owner-id", s3types.PermissionRead)), "", "bucket-owner-read"},
+		{"declared aws-exec-read", objectACL(canonicalUserGrant("ec2-id", s3types.PermissionRead)), "aws-exec-read", "aws-exec-read"},
+		{"object owner owns the bucket", objectACL(), "bucket-owner-full-control", "bucket-owner-full-control"},
+		{"drifted from declared", objectACL(groupGrant(allUsersGroup, s3types.PermissionRead)), "private", "public-read"},
+		{"no canned ACL", objectACL(groupGrant("http://acs.amazonaws.com/groups/s3/LogDelivery", s3types.PermissionWrite)), "private", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, cannedACL(tt.acl, tt.declared))
+		})
+	}
+}
+
+func TestRead_ReportsCannedACL(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+	client.On("HeadObject", ctx, mock.Anything).Return(&s3.HeadObjectOutput{}, nil)
+	client.On("GetObjectTagging", ctx, mock.Anything).Return(&s3.GetObjectTaggingOutput{}, nil)
+	client.On("GetObjectAcl", ctx, mock.MatchedBy(func(input *s3.GetObjectAclInput) bool {
+		return *input.Bucket == "my-bucket" && *input.Key == "file.txt"
+	})).Return(objectACL(groupGrant(allUsersGroup, s3types.PermissionRead)), nil)
+
+	o := &Object{}
+	result, err := o.readWithClient(ctx, client, &resource.ReadRequest{NativeID: "my-bucket|file.txt"})
+
+	require.NoError(t, err)
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, "public-read", props["Acl"])
+}
+
+func TestApplyObjectACL(t *testing.T) {
+	tests := []struct {
+		name     string
+		live     *s3.GetObjectAclOutput
+		declared string
+		put      bool
+	}{
+		{"matches", objectACL(), "private", false},
+		{"drifted", objectACL(groupGrant(allUsersGroup, s3types.PermissionRead)), "private", true},
+		{"changed", objectACL(), "public-read", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &mockS3ObjectClient{}
+			client.On("GetObjectAcl", ctx, mock.MatchedBy(func(input *s3.GetObjectAclInput) bool {
+				return *input.VersionId == "v1"
+			})).Return(tt.live, nil)
+			client.On("PutObjectAcl", ctx, mock.MatchedBy(func(input *s3.PutObjectAclInput) bool {
+				return *input.Bucket == "my-bucket" && *input.Key == "file.txt" && *input.VersionId == "v1" &&
+					string(input.ACL) == tt.declared
+			})).Return(&s3.PutObjectAclOutput{}, nil).Maybe()
+
+			err := applyObjectACL(ctx, client, "my-bucket", "file.txt", "v1",
+				map[string]any{"Bucket": "my-bucket", "Key": "file.txt", "Acl": tt.declared})
+
+			require.NoError(t, err)
+			if tt.put {
+				client.AssertCalled(t, "PutObjectAcl", ctx, mock.Anything)
+			} else {
+				client.AssertNotCalled(t, "PutObjectAcl", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestApplyObjectACL_NotDeclared(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	err := applyObjectACL(ctx, client, "my-bucket", "file.txt", "", map[string]any{"Bucket": "my-bucket", "Key": "file.txt"})
+
+	require.NoError(t, err)
+	client.AssertNotCalled(t, "GetObjectAcl", mock.Anything, mock.Anything)
+}
+
+func TestUpdate_ACLChangeIsNotUploaded(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	desired, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "file.txt", "Content": "hello world", "Acl": "public-read",
+	})
+	prior, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "file.txt", "ContentSha256": helloWorldSHA256, "Acl": "private",
+	})
+	client.On("PutObjectAcl", ctx, mock.MatchedBy(func(input *s3.PutObjectAclInput) bool {
+		return *input.Key == "file.txt" && input.ACL == s3types.ObjectCannedACLPublicRead
+	})).Return(&s3.PutObjectAclOutput{}, nil)
+	mockLiveObject(client, ctx, helloWorldSHA256)
+
+	o := &Object{}
+	result, err := o.updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "my-bucket|file.txt",
+		DesiredProperties: desired,
+		PriorProperties:   prior,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "CopyObject", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,129 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package s3
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"hash/crc64"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
+	tmtypes "github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// checksumKey is the read-only property a read reports the object's
+// checksum as, for resources that verify what they fetch.
+const checksumKey = "Checksum"
+
+// The reversed NVME polynomial, as crc64.MakeTable takes it.
+const crc64NVMEPolynomial = 0x9a6c9329ac4bc9b5
+
+func newChecksumHash(algorithm tmtypes.ChecksumAlgorithm) (hash.Hash, error) {
+	switch s3types.ChecksumAlgorithm(algorithm) {
+	case s3types.ChecksumAlgorithmCrc32:
+		return crc32.NewIEEE(), nil
+	case s3types.ChecksumAlgorithmCrc32c:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli)), nil
+	case s3types.ChecksumAlgorithmCrc64nvme:
+		return crc64.New(crc64.MakeTable(crc64NVMEPolynomial)), nil
+	case s3types.ChecksumAlgorithmSha1:
+		return sha1.New(), nil
+	case s3types.ChecksumAlgorithmSha256:
+		return sha256.New(), nil
+	}
+	return nil, fmt.Errorf("unsupported ChecksumAlgorithm %q", algorithm)
+}
+
+// localChecksum computes the base64 checksum S3 reports for body under
+// algorithm. Like contentSHA256, it only reads a body it can rewind, and
+// returns "" for any other or when no algorithm is set.
+func localChecksum(body io.Reader, algorithm tmtypes.ChecksumAlgorithm) (string, error) {
+	rs, ok := body.(io.ReadSeeker)
+	if !ok || algorithm == "" {
+		return "", nil
+	}
+	h, err := newChecksumHash(algorithm)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(h, rs); err != nil {
+		return "", err
+	}
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
+
+// uploadedChecksum returns the checksum S3 computed for the object written
+// under algorithm. A multipart upload may report a composite checksum of
+// its parts' checksums, which a checksum of the whole body can't be compared
+// with, so "" is returned for one.
+func uploadedChecksum(out *transfermanager.UploadObjectOutput, algorithm tmtypes.ChecksumAlgorithm) string {
+	if out.ChecksumType == tmtypes.ChecksumTypeComposite {
+		return ""
+	}
+	var v *string
+	switch s3types.ChecksumAlgorithm(algorithm) {
+	case s3types.ChecksumAlgorithmCrc32:
+		v = out.ChecksumCRC32
+	case s3types.ChecksumAlgorithmCrc32c:
+		v = out.ChecksumCRC32C
+	case s3types.ChecksumAlgorithmCrc64nvme:
+		v = out.ChecksumCRC64NVME
+	case s3types.ChecksumAlgorithmSha1:
+		v = out.ChecksumSHA1
+	case s3types.ChecksumAlgorithmSha256:
+		v = out.ChecksumSHA256
+	}
+	if strings.Contains(aws.ToString(v), "-") {
+		return ""
+	}
+	return aws.ToString(v)
+}
+
+// verifyChecksum fails an upload whose object S3 reports a different
+// checksum for than the one computed locally. The version written is deleted
+// rather than left holding content that doesn't match the body sent.
+func verifyChecksum(ctx context.Context, client s3ObjectClient, input *transfermanager.UploadObjectInput,
+	out *transfermanager.UploadObjectOutput, want string) error {
+	got := uploadedChecksum(out, input.ChecksumAlgorithm)
+	if want == "" || got == "" || got == want {
+		return nil
+	}
+
+	if _, err := client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket:    input.Bucket,
+		Key:       input.Key,
+		VersionId: out.VersionID,
+	}); err != nil {
+		return fmt.Errorf("%s checksum of the object written is %s, expected %s, and deleting it failed: %w",
+			input.ChecksumAlgorithm, got, want, err)
+	}
+	return fmt.Errorf("%s checksum of the object written is %s, expected %s", input.ChecksumAlgorithm, got, want)
+}
+
+// headChecksum returns the checksum the object is stored with, which a
+// HeadObject with ChecksumMode ENABLED reports.
+func headChecksum(head *s3.HeadObjectOutput) string {
+	for _, v := range []*string{
+		head.ChecksumCRC64NVME, head.ChecksumCRC32, head.ChecksumCRC32C, head.ChecksumSHA1, head.ChecksumSHA256,
+	} {
+		if v != nil {
+			return *v
+		}
+	}
+	return ""
+}
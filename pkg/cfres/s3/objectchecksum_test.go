@@ -0,0 +1,145 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	tmtypes "github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Checksums of "hello world".
+const (
+	helloWorldCRC32Checksum  = "DUoRhQ=="
+	helloWorldSHA1Checksum   = "Kq5sNclPz7QV2+lfQIuc6R7oRu0="
+	helloWorldSHA256Checksum = "uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek="
+)
+
+func TestLocalChecksum(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		want      string
+	}{
+		{"CRC32", helloWorldCRC32Checksum},
+		{"SHA1", helloWorldSHA1Checksum},
+		{"SHA256", helloWorldSHA256Checksum},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			body := strings.NewReader("hello world")
+			got, err := localChecksum(body, tmtypes.ChecksumAlgorithm(tt.algorithm))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			// The body is rewound for the upload.
+			rest, _ := io.ReadAll(body)
+			assert.Equal(t, "hello world", string(rest))
+		})
+	}
+
+	got, err := localChecksum(io.MultiReader(strings.NewReader("hello world")), "CRC32")
+	require.NoError(t, err)
+	assert.Empty(t, got, "a body that can't be rewound isn't read")
+
+	_, err = localChecksum(strings.NewReader("hello world"), "MD5")
+	assert.Error(t, err)
+}
+
+func TestCreate_VerifiesChecksum(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	props, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "file.txt", "Content": "hello world", "ChecksumAlgorithm": "CRC32",
+	})
+	client.On("PutObject", ctx, mock.Anything).Return(&s3.PutObjectOutput{
+		ChecksumCRC32: aws.String(helloWorldCRC32Checksum),
+		ChecksumType:  s3types.ChecksumTypeFullObject,
+	}, nil)
+	mockReadBack(client, ctx)
+
+	o := &Object{}
+	result, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: props})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertNotCalled(t, "DeleteObject", mock.Anything, mock.Anything)
+}
+
+func TestCreate_ChecksumMismatchDeletesObject(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	props, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "file.txt", "Content": "hello world", "ChecksumAlgorithm": "SHA256",
+	})
+	client.On("PutObject", ctx, mock.Anything).Return(&s3.PutObjectOutput{
+		ChecksumSHA256: aws.String(helloWorldSHA1Checksum),
+		VersionId:      aws.String("v1"),
+	}, nil)
+	client.On("DeleteObject", ctx, mock.MatchedBy(func(input *s3.DeleteObjectInput) bool {
+		return *input.Bucket == "my-bucket" && *input.Key == "file.txt" && *input.VersionId == "v1"
+	})).Return(&s3.DeleteObjectOutput{}, nil)
+
+	o := &Object{}
+	_, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: props})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SHA256 checksum")
+	client.AssertExpectations(t)
+}
+
+func TestCreate_CompositeChecksumIsNotCompared(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	props, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "file.txt", "Content": "hello world", "ChecksumAlgorithm": "SHA256",
+	})
+	client.On("PutObject", ctx, mock.Anything).Return(&s3.PutObjectOutput{
+		ChecksumSHA256: aws.String(helloWorldSHA1Checksum + "-2"),
+		ChecksumType:   s3types.ChecksumTypeComposite,
+	}, nil)
+	mockReadBack(client, ctx)
+
+	o := &Object{}
+	_, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: props})
+
+	require.NoError(t, err)
+	client.AssertNotCalled(t, "DeleteObject", mock.Anything, mock.Anything)
+}
+
+func TestRead_ReportsChecksum(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	client.On("HeadObject", ctx, mock.MatchedBy(func(input *s3.HeadObjectInput) bool {
+		return input.ChecksumMode == s3types.ChecksumModeEnabled
+	})).Return(&s3.HeadObjectOutput{ChecksumSHA256: aws.String(helloWorldSHA256Checksum)}, nil)
+	client.On("GetObjectTagging", ctx, mock.Anything).Return(&s3.GetObjectTaggingOutput{}, nil)
+	client.On("GetObjectAcl", ctx, mock.Anything).Return(&s3.GetObjectAclOutput{}, nil)
+
+	o := &Object{}
+	result, err := o.readWithClient(ctx, client, &resource.ReadRequest{NativeID: "my-bucket|file.txt"})
+
+	require.NoError(t, err)
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, helloWorldSHA256Checksum, props["Checksum"])
+}
@@ -0,0 +1,352 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package s3
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// An s3:// Source is copied inside S3 rather than downloaded and uploaded
+// again. CopyObject copies objects of up to 5 GiB in one call; larger ones are
+// copied in parts with UploadPartCopy. Parts never leave S3, so they are much
+// larger than upload parts.
+const (
+	maxCopyObjectBytes     = 5 << 30
+	defaultCopyPartBytes   = 512 << 20
+	defaultCopyConcurrency = 5
+	maxUploadParts         = 10000
+)
+
+// s3Location is the object an s3://bucket/key[?versionId=...] Source names.
+type s3Location struct {
+	bucket    string
+	key       string
+	versionID string
+}
+
+func isS3Source(source any) bool {
+	s, ok := source.(string)
+	return ok && strings.HasPrefix(s, "s3://")
+}
+
+func parseS3Source(source string) (s3Location, error) {
+	u, err := url.Parse(source)
+	if err != nil || u.Host == "" || strings.TrimPrefix(u.Path, "/") == "" {
+		return s3Location{}, fmt.Errorf("invalid S3 source %s: expected s3://bucket/key", source)
+	}
+	return s3Location{
+		bucket:    u.Host,
+		key:       strings.TrimPrefix(u.Path, "/"),
+		versionID: u.Query().Get("versionId"),
+	}, nil
+}
+
+// copySource returns the location in the URL-encoded form the CopySource
+// parameters take.
+func (l s3Location) copySource() string {
+	segments := strings.Split(l.key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	source := l.bucket + "/" + strings.Join(segments, "/")
+	if l.versionID != "" {
+		source += "?versionId=" + url.QueryEscape(l.versionID)
+	}
+	return source
+}
+
+func (l s3Location) String() string {
+	return "s3://" + l.bucket + "/" + l.key
+}
+
+// replacesMetadata reports whether the object sets any of the attributes S3
+// stores as the object's metadata. A copy keeps the source's metadata unless
+// the object sets its own, and then replaces all of it.
+func replacesMetadata(dst *transfermanager.UploadObjectInput) bool {
+	return dst.CacheControl != nil || dst.ContentDisposition != nil || dst.ContentEncoding != nil ||
+		dst.ContentLanguage != nil || dst.ContentType != nil || dst.Metadata != nil ||
+		dst.WebsiteRedirectLocation != nil
+}
+
+// copyObject writes dst from the object at src instead of a body. The source
+// may be in another bucket and another region. With inherit, dst keeps the
+// source's metadata and tags where it doesn't set its own; without, dst's
+// attributes are written as they are, as when an object whose content is
+// unchanged is rewritten in place. Either way the source's content hash is
+// carried over. It returns the version written, as upload does.
+func (o *Object) copyObject(ctx context.Context, client s3ObjectClient, src s3Location, dst *transfermanager.UploadObjectInput, inherit bool) (string, error) {
+	head, srcOpts, err := headSourceObject(ctx, client, src)
+	if err != nil {
+		return "", err
+	}
+	if inherit {
+		if err := inheritSourceAttributes(ctx, client, src, srcOpts, head, dst); err != nil {
+			return "", err
+		}
+	}
+	if hash := head.Metadata[contentHashMetadataKey]; hash != "" {
+		setContentHash(dst, hash)
+	}
+
+	size := aws.ToInt64(head.ContentLength)
+	if size <= maxCopyObjectBytes {
+		out, err := client.CopyObject(ctx, copyObjectInput(src, dst))
+		if err != nil {
+			return "", fmt.Errorf("failed to copy object from %s: %w", src, err)
+		}
+		return aws.ToString(out.VersionId), nil
+	}
+	return o.multipartCopy(ctx, client, src, size, dst)
+}
+
+// inheritSourceAttributes fills in the metadata and tags dst doesn't set from
+// the source, so the copy can always replace both with dst's.
+func inheritSourceAttributes(ctx context.Context, client s3ObjectClient, src s3Location, srcOpts []func(*s3.Options),
+	head *s3.HeadObjectOutput, dst *transfermanager.UploadObjectInput) error {
+	if !replacesMetadata(dst) {
+		dst.CacheControl = head.CacheControl
+		dst.ContentDisposition = head.ContentDisposition
+		dst.ContentEncoding = head.ContentEncoding
+		dst.ContentLanguage = head.ContentLanguage
+		dst.ContentType = head.ContentType
+		dst.WebsiteRedirectLocation = head.WebsiteRedirectLocation
+		if len(head.Metadata) > 0 {
+			dst.Metadata = make(map[string]string, len(head.Metadata))
+			for k, v := range head.Metadata {
+				dst.Metadata[k] = v
+			}
+		}
+	}
+	if dst.Tagging == nil {
+		tagging, err := client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+			Bucket:    aws.String(src.bucket),
+			Key:       aws.String(src.key),
+			VersionId: head.VersionId,
+		}, srcOpts...)
+		if err != nil {
+			return fmt.Errorf("failed to get tags of source object %s: %w", src, err)
+		}
+		tags := url.Values{}
+		for _, tag := range tagging.TagSet {
+			tags.Set(aws.ToString(tag.Key), aws.ToString(tag.Value))
+		}
+		dst.Tagging = aws.String(tags.Encode())
+	}
+	return nil
+}
+
+// headSourceObject reads the source object's size and metadata. It returns
+// the client options that address the source bucket's region, which the
+// configured region may not be.
+func headSourceObject(ctx context.Context, client s3ObjectClient, src s3Location) (*s3.HeadObjectOutput, []func(*s3.Options), error) {
+	input := &s3.HeadObjectInput{
+		Bucket: aws.String(src.bucket),
+		Key:    aws.String(src.key),
+	}
+	if src.versionID != "" {
+		input.VersionId = aws.String(src.versionID)
+	}
+	var opts []func(*s3.Options)
+	head, err := client.HeadObject(ctx, input)
+	if region, ok := bucketRegionFromRedirect(err); ok {
+		opts = append(opts, func(o *s3.Options) { o.Region = region })
+		head, err = client.HeadObject(ctx, input, opts...)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read source object %s: %w", src, err)
+	}
+	return head, opts, nil
+}
+
+// copyObjectInput always replaces the copy's metadata and tags with dst's; see
+// copyObject for where they come from.
+func copyObjectInput(src s3Location, dst *transfermanager.UploadObjectInput) *s3.CopyObjectInput {
+	input := &s3.CopyObjectInput{
+		Bucket:                    dst.Bucket,
+		Key:                       dst.Key,
+		CopySource:                aws.String(src.copySource()),
+		ACL:                       s3types.ObjectCannedACL(dst.ACL),
+		CacheControl:              dst.CacheControl,
+		ChecksumAlgorithm:         s3types.ChecksumAlgorithm(dst.ChecksumAlgorithm),
+		ContentDisposition:        dst.ContentDisposition,
+		ContentEncoding:           dst.ContentEncoding,
+		ContentLanguage:           dst.ContentLanguage,
+		ContentType:               dst.ContentType,
+		Metadata:                  dst.Metadata,
+		ObjectLockLegalHoldStatus: s3types.ObjectLockLegalHoldStatus(dst.ObjectLockLegalHoldStatus),
+		ObjectLockMode:            s3types.ObjectLockMode(dst.ObjectLockMode),
+		ObjectLockRetainUntilDate: dst.ObjectLockRetainUntilDate,
+		SSEKMSKeyId:               dst.SSEKMSKeyID,
+		ServerSideEncryption:      s3types.ServerSideEncryption(dst.ServerSideEncryption),
+		StorageClass:              s3types.StorageClass(dst.StorageClass),
+		Tagging:                   dst.Tagging,
+		WebsiteRedirectLocation:   dst.WebsiteRedirectLocation,
+		IfNoneMatch:               dst.IfNoneMatch,
+		SSECustomerAlgorithm:      dst.SSECustomerAlgorithm,
+		SSECustomerKey:            dst.SSECustomerKey,
+		SSECustomerKeyMD5:         dst.SSECustomerKeyMD5,
+		MetadataDirective:         s3types.MetadataDirectiveReplace,
+		TaggingDirective:          s3types.TaggingDirectiveReplace,
+	}
+	return input
+}
+
+// multipartCopy copies a source larger than CopyObject takes in parts. A
+// failed copy is aborted so no parts are left behind.
+func (o *Object) multipartCopy(ctx context.Context, client s3ObjectClient, src s3Location, size int64,
+	dst *transfermanager.UploadObjectInput) (string, error) {
+	create := &s3.CreateMultipartUploadInput{
+		Bucket:                    dst.Bucket,
+		Key:                       dst.Key,
+		ACL:                       s3types.ObjectCannedACL(dst.ACL),
+		CacheControl:              dst.CacheControl,
+		ChecksumAlgorithm:         s3types.ChecksumAlgorithm(dst.ChecksumAlgorithm),
+		ContentDisposition:        dst.ContentDisposition,
+		ContentEncoding:           dst.ContentEncoding,
+		ContentLanguage:           dst.ContentLanguage,
+		ContentType:               dst.ContentType,
+		Metadata:                  dst.Metadata,
+		ObjectLockLegalHoldStatus: s3types.ObjectLockLegalHoldStatus(dst.ObjectLockLegalHoldStatus),
+		ObjectLockMode:            s3types.ObjectLockMode(dst.ObjectLockMode),
+		ObjectLockRetainUntilDate: dst.ObjectLockRetainUntilDate,
+		SSEKMSKeyId:               dst.SSEKMSKeyID,
+		ServerSideEncryption:      s3types.ServerSideEncryption(dst.ServerSideEncryption),
+		StorageClass:              s3types.StorageClass(dst.StorageClass),
+		Tagging:                   dst.Tagging,
+		WebsiteRedirectLocation:   dst.WebsiteRedirectLocation,
+		SSECustomerAlgorithm:      dst.SSECustomerAlgorithm,
+		SSECustomerKey:            dst.SSECustomerKey,
+		SSECustomerKeyMD5:         dst.SSECustomerKeyMD5,
+	}
+
+	upload, err := client.CreateMultipartUpload(ctx, create)
+	if err != nil {
+		return "", fmt.Errorf("failed to start copy from %s: %w", src, err)
+	}
+	var versionID string
+	parts, err := o.copyParts(ctx, client, src, size, dst, upload.UploadId)
+	if err == nil {
+		var out *s3.CompleteMultipartUploadOutput
+		out, err = client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:          dst.Bucket,
+			Key:             dst.Key,
+			UploadId:        upload.UploadId,
+			MultipartUpload: &s3types.CompletedMultipartUpload{Parts: parts},
+			IfNoneMatch:     dst.IfNoneMatch,
+		})
+		if err == nil {
+			versionID = aws.ToString(out.VersionId)
+		}
+	}
+	if err != nil {
+		// Use a fresh context: the copy may have failed because ctx is done.
+		_, _ = client.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+			Bucket:   dst.Bucket,
+			Key:      dst.Key,
+			UploadId: upload.UploadId,
+		})
+		return "", fmt.Errorf("failed to copy object from %s: %w", src, err)
+	}
+	return versionID, nil
+}
+
+// copyParts copies the source in parts, S3UploadConcurrency at a time, and
+// returns them in order for CompleteMultipartUpload.
+func (o *Object) copyParts(ctx context.Context, client s3ObjectClient, src s3Location, size int64,
+	dst *transfermanager.UploadObjectInput, uploadID *string) ([]s3types.CompletedPart, error) {
+	partSize := int64(defaultCopyPartBytes)
+	if size/partSize >= maxUploadParts {
+		partSize = size/maxUploadParts + 1
+	}
+	concurrency := defaultCopyConcurrency
+	if o.cfg != nil && o.cfg.S3UploadConcurrency > 0 {
+		concurrency = o.cfg.S3UploadConcurrency
+	}
+
+	count := int((size + partSize - 1) / partSize)
+	parts := make([]s3types.CompletedPart, count)
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+
+	for i := 0; i < count; i++ {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			if firstErr == nil {
+				firstErr = ctx.Err()
+			}
+			mu.Unlock()
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := int64(i) * partSize
+			end := min(start+partSize, size) - 1
+			out, err := client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
+				Bucket:          dst.Bucket,
+				Key:             dst.Key,
+				UploadId:        uploadID,
+				PartNumber:      aws.Int32(int32(i + 1)),
+				CopySource:      aws.String(src.copySource()),
+				CopySourceRange: aws.String(fmt.Sprintf("bytes=%d-%d", start, end)),
+				// The parts are written under the object's key, if it has one.
+				SSECustomerAlgorithm: dst.SSECustomerAlgorithm,
+				SSECustomerKey:       dst.SSECustomerKey,
+				SSECustomerKeyMD5:    dst.SSECustomerKeyMD5,
+			})
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("copying part %d: %w", i+1, err)
+				}
+				mu.Unlock()
+				return
+			}
+			part := s3types.CompletedPart{PartNumber: aws.Int32(int32(i + 1))}
+			if result := out.CopyPartResult; result != nil {
+				part.ETag = result.ETag
+				part.ChecksumCRC32 = result.ChecksumCRC32
+				part.ChecksumCRC32C = result.ChecksumCRC32C
+				part.ChecksumCRC64NVME = result.ChecksumCRC64NVME
+				part.ChecksumSHA1 = result.ChecksumSHA1
+				part.ChecksumSHA256 = result.ChecksumSHA256
+			}
+			parts[i] = part
+		}(i)
+	}
+
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
@@ -0,0 +1,191 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSourceHead stubs HeadObject on the copy source, separately from the
+// destination read-back stubbed by mockReadBack.
+func mockSourceHead(client *mockS3ObjectClient, ctx context.Context, head *s3.HeadObjectOutput) {
+	client.On("HeadObject", ctx, mock.MatchedBy(func(input *s3.HeadObjectInput) bool {
+		return *input.Bucket == "src-bucket"
+	})).Return(head, nil)
+}
+
+func TestParseS3Source(t *testing.T) {
+	loc, err := parseS3Source("s3://src-bucket/builds/app 1.zip?versionId=v1")
+	require.NoError(t, err)
+	assert.Equal(t, s3Location{bucket: "src-bucket", key: "builds/app 1.zip", versionID: "v1"}, loc)
+	assert.Equal(t, "src-bucket/builds/app%201.zip?versionId=v1", loc.copySource())
+
+	for _, invalid := range []string{"s3://", "s3://src-bucket", "s3://src-bucket/", "s3:///key"} {
+		_, err := parseS3Source(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestCreate_S3SourceIsCopied(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	props := map[string]any{"Bucket": "my-bucket", "Key": "app.zip", "Source": "s3://src-bucket/builds/app.zip"}
+	propsBytes, _ := json.Marshal(props)
+
+	// The copy keeps the source's attributes and its content hash.
+	mockSourceHead(client, ctx, &s3.HeadObjectOutput{
+		ContentLength: aws.Int64(1 << 20),
+		ContentType:   aws.String("application/zip"),
+		Metadata:      map[string]string{"build": "42", contentHashMetadataKey: "abc123"},
+	})
+	client.On("GetObjectTagging", ctx, mock.MatchedBy(func(input *s3.GetObjectTaggingInput) bool {
+		return *input.Bucket == "src-bucket"
+	})).Return(&s3.GetObjectTaggingOutput{TagSet: []s3types.Tag{{Key: aws.String("team"), Value: aws.String("infra")}}}, nil)
+	client.On("GetObjectAcl", ctx, mock.Anything).Return(&s3.GetObjectAclOutput{}, nil)
+	client.On("CopyObject", ctx, mock.MatchedBy(func(input *s3.CopyObjectInput) bool {
+		return *input.Bucket == "my-bucket" && *input.Key == "app.zip" &&
+			*input.CopySource == "src-bucket/builds/app.zip" &&
+			aws.ToString(input.IfNoneMatch) == "*" &&
+			*input.ContentType == "application/zip" &&
+			input.Metadata["build"] == "42" && input.Metadata[contentHashMetadataKey] == "abc123" &&
+			*input.Tagging == "team=infra" &&
+			input.MetadataDirective == s3types.MetadataDirectiveReplace &&
+			input.TaggingDirective == s3types.TaggingDirectiveReplace
+	})).Return(&s3.CopyObjectOutput{}, nil)
+	mockReadBack(client, ctx)
+
+	o := &Object{}
+	result, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: propsBytes})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "GetObject", mock.Anything, mock.Anything)
+}
+
+func TestCreate_S3SourceReplacesMetadataAndTags(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	props := map[string]any{
+		"Bucket":      "my-bucket",
+		"Key":         "app.zip",
+		"Source":      "s3://src-bucket/builds/app.zip",
+		"ContentType": "application/zip",
+		"Tags":        []any{map[string]any{"Key": "env", "Value": "prod"}},
+	}
+	propsBytes, _ := json.Marshal(props)
+
+	mockSourceHead(client, ctx, &s3.HeadObjectOutput{ContentLength: aws.Int64(1 << 20)})
+	client.On("CopyObject", ctx, mock.MatchedBy(func(input *s3.CopyObjectInput) bool {
+		return *input.ContentType == "application/zip" && *input.Tagging == "env=prod" &&
+			input.Metadata == nil &&
+			input.MetadataDirective == s3types.MetadataDirectiveReplace &&
+			input.TaggingDirective == s3types.TaggingDirectiveReplace
+	})).Return(&s3.CopyObjectOutput{}, nil)
+	mockReadBack(client, ctx)
+
+	o := &Object{}
+	_, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: propsBytes})
+
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestCreate_S3SourceWithContent_Errors(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	props := map[string]any{"Bucket": "my-bucket", "Key": "app.zip", "Source": "s3://src-bucket/app.zip", "Content": "x"}
+	propsBytes, _ := json.Marshal(props)
+
+	o := &Object{}
+	_, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: propsBytes})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mutually exclusive")
+	client.AssertNotCalled(t, "HeadObject", mock.Anything, mock.Anything)
+}
+
+func TestCreate_LargeS3SourceUsesMultipartCopy(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	// 6 GiB in 512 MiB parts: twelve parts.
+	props := map[string]any{"Bucket": "my-bucket", "Key": "image.raw", "Source": "s3://src-bucket/image.raw"}
+	propsBytes, _ := json.Marshal(props)
+
+	mockSourceHead(client, ctx, &s3.HeadObjectOutput{
+		ContentLength: aws.Int64(6 << 30),
+		ContentType:   aws.String("application/octet-stream"),
+	})
+	client.On("GetObjectTagging", ctx, mock.MatchedBy(func(input *s3.GetObjectTaggingInput) bool {
+		return *input.Bucket == "src-bucket"
+	})).Return(&s3.GetObjectTaggingOutput{TagSet: []s3types.Tag{{Key: aws.String("team"), Value: aws.String("infra")}}}, nil)
+	client.On("GetObjectAcl", ctx, mock.Anything).Return(&s3.GetObjectAclOutput{}, nil)
+	client.On("CreateMultipartUpload", ctx, mock.MatchedBy(func(input *s3.CreateMultipartUploadInput) bool {
+		return *input.Bucket == "my-bucket" && *input.Key == "image.raw" &&
+			*input.ContentType == "application/octet-stream" && *input.Tagging == "team=infra"
+	})).Return(&s3.CreateMultipartUploadOutput{UploadId: aws.String("upload-1")}, nil)
+	client.On("UploadPartCopy", ctx, mock.MatchedBy(func(input *s3.UploadPartCopyInput) bool {
+		return *input.UploadId == "upload-1" && *input.CopySource == "src-bucket/image.raw"
+	})).Return(&s3.UploadPartCopyOutput{CopyPartResult: &s3types.CopyPartResult{ETag: aws.String("etag")}}, nil).Times(12)
+	client.On("CompleteMultipartUpload", ctx, mock.MatchedBy(func(input *s3.CompleteMultipartUploadInput) bool {
+		parts := input.MultipartUpload.Parts
+		return *input.UploadId == "upload-1" && len(parts) == 12 && aws.ToString(input.IfNoneMatch) == "*" &&
+			*parts[0].PartNumber == 1 && *parts[11].PartNumber == 12
+	})).Return(&s3.CompleteMultipartUploadOutput{}, nil)
+	mockReadBack(client, ctx)
+
+	o := &Object{}
+	_, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: propsBytes})
+
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	client.AssertCalled(t, "UploadPartCopy", ctx, mock.MatchedBy(func(input *s3.UploadPartCopyInput) bool {
+		return *input.PartNumber == 12 && *input.CopySourceRange == "bytes=5905580032-6442450943"
+	}))
+	client.AssertNotCalled(t, "CopyObject", mock.Anything, mock.Anything)
+}
+
+func TestCreate_FailedMultipartCopyIsAborted(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	props := map[string]any{"Bucket": "my-bucket", "Key": "image.raw", "Source": "s3://src-bucket/image.raw"}
+	propsBytes, _ := json.Marshal(props)
+
+	mockSourceHead(client, ctx, &s3.HeadObjectOutput{ContentLength: aws.Int64(6 << 30)})
+	client.On("GetObjectTagging", ctx, mock.Anything).Return(&s3.GetObjectTaggingOutput{}, nil)
+	client.On("GetObjectAcl", ctx, mock.Anything).Return(&s3.GetObjectAclOutput{}, nil)
+	client.On("CreateMultipartUpload", ctx, mock.Anything).Return(&s3.CreateMultipartUploadOutput{UploadId: aws.String("upload-1")}, nil)
+	client.On("UploadPartCopy", ctx, mock.Anything).Return((*s3.UploadPartCopyOutput)(nil), errors.New("access denied"))
+	client.On("AbortMultipartUpload", mock.Anything, mock.MatchedBy(func(input *s3.AbortMultipartUploadInput) bool {
+		return *input.UploadId == "upload-1"
+	})).Return(&s3.AbortMultipartUploadOutput{}, nil)
+
+	o := &Object{}
+	_, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: propsBytes})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to copy object from s3://src-bucket/image.raw")
+	client.AssertCalled(t, "AbortMultipartUpload", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "CompleteMultipartUpload", mock.Anything, mock.Anything)
+}
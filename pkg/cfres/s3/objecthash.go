@@ -0,0 +1,120 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// contentHashMetadataKey is the user metadata entry an object's SHA-256 is
+// recorded in when the plugin writes it. Reads report it as ContentSha256
+// rather than as part of Metadata. An object overwritten outside formae loses
+// the entry, so its ContentSha256 changes with its content.
+const contentHashMetadataKey = "formae-content-sha256"
+
+// Properties a change to which doesn't call for the object to be rewritten:
+// the body, given only to write it, what a read reports about the stored
+// object, the presigned URL, which isn't stored with it, and the ACL and
+// Object Lock settings, which are changed on the stored version.
+var inPlaceKeys = map[string]bool{
+	"Content":                   true,
+	"ContentBase64":             true,
+	"Source":                    true,
+	"ContentLength":             true,
+	"ContentSha256":             true,
+	checksumKey:                 true,
+	"ETag":                      true,
+	"VersionId":                 true,
+	"Acl":                       true,
+	"ObjectLockLegalHoldStatus": true,
+	"ObjectLockMode":            true,
+	"ObjectLockRetainUntilDate": true,
+	presignedURLKey:             true,
+	presignedURLExpiresInKey:    true,
+}
+
+// contentSHA256 returns the hex SHA-256 of a body that can be rewound to be
+// uploaded after it is hashed: inline content, a file, or an extracted zip
+// member. A body streamed from a URL is read only once, so it isn't hashed
+// and "" is returned.
+func contentSHA256(body io.Reader) (string, error) {
+	rs, ok := body.(io.ReadSeeker)
+	if !ok {
+		return "", nil
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, rs); err != nil {
+		return "", err
+	}
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func setContentHash(input *transfermanager.UploadObjectInput, hash string) {
+	if input.Metadata == nil {
+		input.Metadata = map[string]string{}
+	}
+	input.Metadata[contentHashMetadataKey] = hash
+}
+
+// liveContentHash returns the content hash recorded on the stored object, or
+// "" if it has none or doesn't exist.
+func liveContentHash(ctx context.Context, client s3ObjectClient, bucket, key string) (string, error) {
+	head, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isObjectNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to head object: %w", err)
+	}
+	return head.Metadata[contentHashMetadataKey], nil
+}
+
+// sameObjectAttributes reports whether the desired model sets the same object
+// attributes as the prior one. ServerSideEncryption is only compared when it
+// is set, as a read records the bucket's default encryption.
+func sameObjectAttributes(desired, prior map[string]any) bool {
+	for _, props := range []map[string]any{desired, prior} {
+		for k := range props {
+			if inPlaceKeys[k] {
+				continue
+			}
+			if _, ok := desired[k]; !ok && k == "ServerSideEncryption" {
+				continue
+			}
+			if !reflect.DeepEqual(desired[k], prior[k]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isObjectNotFound(err error) bool {
+	var notFound *s3types.NotFound
+	if errors.As(err, &notFound) {
+		return true
+	}
+	// Also handle HTTP 404 from smithy
+	var respErr interface{ HTTPStatusCode() int }
+	return errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotFound
+}
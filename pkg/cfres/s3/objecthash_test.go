@@ -0,0 +1,242 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// sha256 of "hello world".
+const helloWorldSHA256 = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+
+func TestContentSHA256(t *testing.T) {
+	body := strings.NewReader("hello world")
+	hash, err := contentSHA256(body)
+	require.NoError(t, err)
+	assert.Equal(t, helloWorldSHA256, hash)
+
+	// The body is rewound to be uploaded.
+	data, _ := io.ReadAll(body)
+	assert.Equal(t, "hello world", string(data))
+
+	// A streamed body isn't hashed.
+	hash, err = contentSHA256(&sizedBody{Reader: strings.NewReader("hello world"), size: 11})
+	require.NoError(t, err)
+	assert.Empty(t, hash)
+}
+
+func TestCreate_RecordsContentHash(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	props := map[string]any{"Bucket": "my-bucket", "Key": "file.txt", "Content": "hello world"}
+	propsBytes, _ := json.Marshal(props)
+
+	client.On("PutObject", ctx, mock.MatchedBy(func(input *s3.PutObjectInput) bool {
+		return input.Metadata[contentHashMetadataKey] == helloWorldSHA256
+	})).Return(&s3.PutObjectOutput{}, nil)
+	mockReadBack(client, ctx)
+
+	o := &Object{}
+	_, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: propsBytes})
+
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestRead_ReportsContentHashOutsideMetadata(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	client.On("HeadObject", ctx, mock.Anything).Return(&s3.HeadObjectOutput{
+		Metadata: map[string]string{"owner": "team-a", contentHashMetadataKey: helloWorldSHA256},
+	}, nil)
+	client.On("GetObjectTagging", ctx, mock.Anything).Return(&s3.GetObjectTaggingOutput{}, nil)
+	client.On("GetObjectAcl", ctx, mock.Anything).Return(&s3.GetObjectAclOutput{}, nil)
+
+	o := &Object{}
+	result, err := o.readWithClient(ctx, client, &resource.ReadRequest{NativeID: "my-bucket|file.txt"})
+
+	require.NoError(t, err)
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	assert.Equal(t, helloWorldSHA256, props["ContentSha256"])
+	assert.Equal(t, map[string]any{"owner": "team-a"}, props["Metadata"])
+}
+
+// mockLiveObject stubs HeadObject on the object being updated, both for the
+// content hash check and for the read-back.
+func mockLiveObject(client *mockS3ObjectClient, ctx context.Context, hash string) {
+	client.On("HeadObject", ctx, mock.MatchedBy(func(input *s3.HeadObjectInput) bool {
+		return *input.Bucket == "my-bucket"
+	})).Return(&s3.HeadObjectOutput{
+		ContentLength: aws.Int64(11),
+		Metadata:      map[string]string{contentHashMetadataKey: hash},
+	}, nil)
+	client.On("GetObjectTagging", ctx, mock.Anything).Return(&s3.GetObjectTaggingOutput{}, nil).Maybe()
+	client.On("GetObjectAcl", ctx, mock.Anything).Return(&s3.GetObjectAclOutput{}, nil).Maybe()
+}
+
+func TestUpdate_UnchangedContentIsNotUploaded(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	desired, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "file.txt", "Content": "hello world", "ContentType": "text/plain",
+	})
+	prior, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "file.txt", "ContentType": "text/plain",
+		"ContentSha256": helloWorldSHA256, "ETag": "\"abc\"", "ServerSideEncryption": "AES256",
+	})
+	mockLiveObject(client, ctx, helloWorldSHA256)
+
+	o := &Object{}
+	result, err := o.updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "my-bucket|file.txt",
+		DesiredProperties: desired,
+		PriorProperties:   prior,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "CopyObject", mock.Anything, mock.Anything)
+}
+
+func TestUpdate_UnchangedContentWithNewAttributesIsCopiedInPlace(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	desired, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "file.txt", "Content": "hello world", "ContentType": "text/markdown",
+	})
+	prior, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "file.txt", "ContentType": "text/plain", "ContentSha256": helloWorldSHA256,
+	})
+	mockLiveObject(client, ctx, helloWorldSHA256)
+	client.On("CopyObject", ctx, mock.MatchedBy(func(input *s3.CopyObjectInput) bool {
+		return *input.CopySource == "my-bucket/file.txt" && *input.Key == "file.txt" && input.IfNoneMatch == nil &&
+			*input.ContentType == "text/markdown" &&
+			input.Metadata[contentHashMetadataKey] == helloWorldSHA256 &&
+			input.MetadataDirective == s3types.MetadataDirectiveReplace &&
+			input.TaggingDirective == s3types.TaggingDirectiveReplace
+	})).Return(&s3.CopyObjectOutput{}, nil)
+
+	o := &Object{}
+	_, err := o.updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "my-bucket|file.txt",
+		DesiredProperties: desired,
+		PriorProperties:   prior,
+	})
+
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything)
+}
+
+func TestUpdate_WithoutBodyIsCopiedInPlace(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	desired, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "file.txt", "CacheControl": "max-age=60", "StorageClass": "STANDARD_IA",
+		"Metadata": map[string]any{"team": "web"},
+	})
+	prior, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "file.txt", "ContentSha256": helloWorldSHA256,
+	})
+	mockLiveObject(client, ctx, helloWorldSHA256)
+	client.On("CopyObject", ctx, mock.MatchedBy(func(input *s3.CopyObjectInput) bool {
+		return *input.CopySource == "my-bucket/file.txt" && *input.Key == "file.txt" &&
+			*input.CacheControl == "max-age=60" && input.StorageClass == s3types.StorageClassStandardIa &&
+			input.Metadata["team"] == "web" && input.Metadata[contentHashMetadataKey] == helloWorldSHA256 &&
+			input.MetadataDirective == s3types.MetadataDirectiveReplace
+	})).Return(&s3.CopyObjectOutput{}, nil)
+
+	o := &Object{}
+	_, err := o.updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "my-bucket|file.txt",
+		DesiredProperties: desired,
+		PriorProperties:   prior,
+	})
+
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything)
+}
+
+func TestUpdate_WithoutBodyOrChangesIsLeftAlone(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	desired, _ := json.Marshal(map[string]any{"Bucket": "my-bucket", "Key": "file.txt", "ContentType": "text/plain"})
+	prior, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "file.txt", "ContentType": "text/plain", "ContentSha256": helloWorldSHA256,
+	})
+	mockLiveObject(client, ctx, helloWorldSHA256)
+
+	o := &Object{}
+	_, err := o.updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "my-bucket|file.txt",
+		DesiredProperties: desired,
+		PriorProperties:   prior,
+	})
+
+	require.NoError(t, err)
+	client.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "CopyObject", mock.Anything, mock.Anything)
+}
+
+func TestUpdate_ChangedContentIsUploaded(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	desired, _ := json.Marshal(map[string]any{"Bucket": "my-bucket", "Key": "file.txt", "Content": "hello world"})
+	prior, _ := json.Marshal(map[string]any{"Bucket": "my-bucket", "Key": "file.txt", "ContentSha256": "0ld"})
+	// Overwritten outside formae, or written before hashes were recorded.
+	mockLiveObject(client, ctx, "")
+	client.On("PutObject", ctx, mock.MatchedBy(func(input *s3.PutObjectInput) bool {
+		return input.Metadata[contentHashMetadataKey] == helloWorldSHA256
+	})).Return(&s3.PutObjectOutput{}, nil)
+
+	o := &Object{}
+	_, err := o.updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "my-bucket|file.txt",
+		DesiredProperties: desired,
+		PriorProperties:   prior,
+	})
+
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestSameObjectAttributes(t *testing.T) {
+	desired := map[string]any{"Bucket": "b", "Key": "k", "Content": "new", "Metadata": map[string]any{"a": "1"}}
+
+	assert.True(t, sameObjectAttributes(desired, map[string]any{
+		"Bucket": "b", "Key": "k", "Metadata": map[string]any{"a": "1"},
+		"ETag": "\"e\"", "VersionId": "v", "ContentLength": float64(3), "ServerSideEncryption": "AES256",
+	}))
+	assert.False(t, sameObjectAttributes(desired, map[string]any{
+		"Bucket": "b", "Key": "k", "Metadata": map[string]any{"a": "2"},
+	}))
+	assert.False(t, sameObjectAttributes(desired, map[string]any{
+		"Bucket": "b", "Key": "k", "Metadata": map[string]any{"a": "1"}, "CacheControl": "no-cache",
+	}))
+}
@@ -0,0 +1,100 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package s3
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/utils"
+)
+
+// applyObjectLock brings the Object Lock retention and legal hold of the
+// object's version in line with the model, using PutObjectRetention and
+// PutObjectLegalHold. A write sets both on the version it creates, but an
+// update that writes nothing, or an object already stored, only changes
+// through these calls. versionID is the version written, or "" for the
+// current one.
+//
+// Retention is only applied when the model sets it, since an object in
+// COMPLIANCE mode can't have it removed. A version in GOVERNANCE mode is
+// changed with BypassGovernanceRetention, which shortening or removing the
+// retention needs.
+func applyObjectLock(ctx context.Context, client s3ObjectClient, bucket, key, versionID string, props map[string]any) error {
+	mode, _ := utils.GetStringProperty(props, "ObjectLockMode")
+	until, _ := utils.GetStringProperty(props, "ObjectLockRetainUntilDate")
+	legalHold, _ := utils.GetStringProperty(props, "ObjectLockLegalHoldStatus")
+	if mode == "" && until == "" && legalHold == "" {
+		return nil
+	}
+
+	var version *string
+	if versionID != "" {
+		version = aws.String(versionID)
+	}
+	headInput := &s3.HeadObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: version,
+	}
+	if sse, _ := sseCustomerKeyFrom(props); sse != nil {
+		headInput.SSECustomerAlgorithm = aws.String(sse.algorithm)
+		headInput.SSECustomerKey = aws.String(sse.key)
+		headInput.SSECustomerKeyMD5 = aws.String(sse.keyMD5)
+	}
+	head, err := client.HeadObject(ctx, headInput)
+	if err != nil {
+		return fmt.Errorf("failed to head object: %w", err)
+	}
+
+	if mode != "" || until != "" {
+		if mode == "" || until == "" {
+			return fmt.Errorf("ObjectLockMode and ObjectLockRetainUntilDate must be set together")
+		}
+		retainUntil, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			return fmt.Errorf("invalid ObjectLockRetainUntilDate %q: %w", until, err)
+		}
+		if string(head.ObjectLockMode) != mode || head.ObjectLockRetainUntilDate == nil ||
+			!head.ObjectLockRetainUntilDate.Equal(retainUntil) {
+			input := &s3.PutObjectRetentionInput{
+				Bucket:    aws.String(bucket),
+				Key:       aws.String(key),
+				VersionId: version,
+				Retention: &s3types.ObjectLockRetention{
+					Mode:            s3types.ObjectLockRetentionMode(mode),
+					RetainUntilDate: aws.Time(retainUntil),
+				},
+			}
+			if head.ObjectLockMode == s3types.ObjectLockModeGovernance {
+				input.BypassGovernanceRetention = aws.Bool(true)
+			}
+			if _, err := client.PutObjectRetention(ctx, input); err != nil {
+				return fmt.Errorf("failed to put object retention: %w", err)
+			}
+		}
+	}
+
+	live := head.ObjectLockLegalHoldStatus
+	if live == "" {
+		live = s3types.ObjectLockLegalHoldStatusOff
+	}
+	if legalHold != "" && string(live) != legalHold {
+		if _, err := client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(key),
+			VersionId: version,
+			LegalHold: &s3types.ObjectLockLegalHold{Status: s3types.ObjectLockLegalHoldStatus(legalHold)},
+		}); err != nil {
+			return fmt.Errorf("failed to put object legal hold: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var retainUntil = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func lockedProps(mode, until, legalHold string) map[string]any {
+	props := map[string]any{"Bucket": "my-bucket", "Key": "locked.txt"}
+	if mode != "" {
+		props["ObjectLockMode"] = mode
+	}
+	if until != "" {
+		props["ObjectLockRetainUntilDate"] = until
+	}
+	if legalHold != "" {
+		props["ObjectLockLegalHoldStatus"] = legalHold
+	}
+	return props
+}
+
+func TestApplyObjectLock_NothingSet(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	err := applyObjectLock(ctx, client, "my-bucket", "locked.txt", "v1", lockedProps("", "", ""))
+
+	require.NoError(t, err)
+	client.AssertNotCalled(t, "HeadObject", mock.Anything, mock.Anything)
+}
+
+func TestApplyObjectLock_ExtendsGovernanceRetention(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	earlier := retainUntil.AddDate(-1, 0, 0)
+	client.On("HeadObject", ctx, mock.MatchedBy(func(input *s3.HeadObjectInput) bool {
+		return *input.VersionId == "v1"
+	})).Return(&s3.HeadObjectOutput{
+		ObjectLockMode:            s3types.ObjectLockModeGovernance,
+		ObjectLockRetainUntilDate: &earlier,
+	}, nil)
+	client.On("PutObjectRetention", ctx, mock.MatchedBy(func(input *s3.PutObjectRetentionInput) bool {
+		return *input.Bucket == "my-bucket" && *input.Key == "locked.txt" && *input.VersionId == "v1" &&
+			input.Retention.Mode == s3types.ObjectLockRetentionModeCompliance &&
+			input.Retention.RetainUntilDate.Equal(retainUntil) &&
+			aws.ToBool(input.BypassGovernanceRetention)
+	})).Return(&s3.PutObjectRetentionOutput{}, nil)
+
+	err := applyObjectLock(ctx, client, "my-bucket", "locked.txt", "v1",
+		lockedProps("COMPLIANCE", "2030-01-01T00:00:00Z", ""))
+
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestApplyObjectLock_UnchangedRetentionIsNotPut(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	client.On("HeadObject", ctx, mock.Anything).Return(&s3.HeadObjectOutput{
+		ObjectLockMode:            s3types.ObjectLockModeCompliance,
+		ObjectLockRetainUntilDate: &retainUntil,
+		ObjectLockLegalHoldStatus: s3types.ObjectLockLegalHoldStatusOn,
+	}, nil)
+
+	err := applyObjectLock(ctx, client, "my-bucket", "locked.txt", "",
+		lockedProps("COMPLIANCE", "2030-01-01T00:00:00Z", "ON"))
+
+	require.NoError(t, err)
+	client.AssertNotCalled(t, "PutObjectRetention", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "PutObjectLegalHold", mock.Anything, mock.Anything)
+}
+
+func TestApplyObjectLock_LegalHold(t *testing.T) {
+	tests := []struct {
+		name    string
+		live    s3types.ObjectLockLegalHoldStatus
+		desired string
+		put     bool
+	}{
+		{"placed", "", "ON", true},
+		{"released", s3types.ObjectLockLegalHoldStatusOn, "OFF", true},
+		{"never placed", "", "OFF", false},
+		{"already placed", s3types.ObjectLockLegalHoldStatusOn, "ON", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &mockS3ObjectClient{}
+			client.On("HeadObject", ctx, mock.Anything).Return(&s3.HeadObjectOutput{ObjectLockLegalHoldStatus: tt.live}, nil)
+			client.On("PutObjectLegalHold", ctx, mock.MatchedBy(func(input *s3.PutObjectLegalHoldInput) bool {
+				return string(input.LegalHold.Status) == tt.desired && input.VersionId == nil
+			})).Return(&s3.PutObjectLegalHoldOutput{}, nil).Maybe()
+
+			err := applyObjectLock(ctx, client, "my-bucket", "locked.txt", "", lockedProps("", "", tt.desired))
+
+			require.NoError(t, err)
+			if tt.put {
+				client.AssertCalled(t, "PutObjectLegalHold", ctx, mock.Anything)
+			} else {
+				client.AssertNotCalled(t, "PutObjectLegalHold", mock.Anything, mock.Anything)
+			}
+			client.AssertNotCalled(t, "PutObjectRetention", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestApplyObjectLock_ModeWithoutDate_Errors(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+	client.On("HeadObject", ctx, mock.Anything).Return(&s3.HeadObjectOutput{}, nil)
+
+	err := applyObjectLock(ctx, client, "my-bucket", "locked.txt", "", lockedProps("GOVERNANCE", "", ""))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be set together")
+}
+
+func TestUpdate_ObjectLockChangeIsNotUploaded(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	desired, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "file.txt", "Content": "hello world", "ObjectLockLegalHoldStatus": "ON",
+	})
+	prior, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "file.txt", "ContentSha256": helloWorldSHA256, "ObjectLockLegalHoldStatus": "OFF",
+	})
+	mockLiveObject(client, ctx, helloWorldSHA256)
+	client.On("PutObjectLegalHold", ctx, mock.MatchedBy(func(input *s3.PutObjectLegalHoldInput) bool {
+		return *input.Key == "file.txt" && input.LegalHold.Status == s3types.ObjectLockLegalHoldStatusOn
+	})).Return(&s3.PutObjectLegalHoldOutput{}, nil)
+
+	o := &Object{}
+	result, err := o.updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "my-bucket|file.txt",
+		DesiredProperties: desired,
+		PriorProperties:   prior,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	client.AssertExpectations(t)
+	client.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything)
+	client.AssertNotCalled(t, "CopyObject", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,99 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package s3
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/utils"
+)
+
+// Properties of a presigned GET URL for the object. PresignedUrlExpiresIn is
+// the plugin's own: it asks for the URL, in seconds, and reads report it back
+// from the prior model. PresignedUrl is the URL.
+const (
+	presignedURLExpiresInKey = "PresignedUrlExpiresIn"
+	presignedURLKey          = "PresignedUrl"
+)
+
+// objectPresigner presigns object requests. *s3.PresignClient satisfies it.
+type objectPresigner interface {
+	PresignGetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+}
+
+// presigner returns the presigner for client: the one injected for testing,
+// or a presign client sharing client's configuration.
+func (o *Object) presigner(client s3ObjectClient) objectPresigner {
+	if o.presignClient != nil {
+		return o.presignClient
+	}
+	if c, ok := client.(*s3.Client); ok {
+		return s3.NewPresignClient(c)
+	}
+	return nil
+}
+
+// addPresignedURL adds a presigned GET URL for the object to props when the
+// model asks for one. The URL addresses the key, not a version, so it serves
+// the object's current content.
+//
+// Every presign yields a new URL, so a read keeps the prior model's URL while
+// it has more than half its lifetime left; otherwise each read would report a
+// change to the URL, and to whatever refers to it.
+func (o *Object) addPresignedURL(ctx context.Context, client s3ObjectClient, bucket, key string, props, model map[string]any) error {
+	expiresIn := utils.GetInt64Property(model, presignedURLExpiresInKey, 0)
+	if expiresIn <= 0 {
+		return nil
+	}
+	props[presignedURLExpiresInKey] = expiresIn
+
+	if prior, _ := model[presignedURLKey].(string); freshPresignedURL(prior, expiresIn, time.Now()) {
+		props[presignedURLKey] = prior
+		return nil
+	}
+
+	presigner := o.presigner(client)
+	if presigner == nil {
+		return fmt.Errorf("failed to presign object URL: no presign client")
+	}
+	req, err := presigner.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(time.Duration(expiresIn)*time.Second))
+	if err != nil {
+		return fmt.Errorf("failed to presign object URL: %w", err)
+	}
+	props[presignedURLKey] = req.URL
+	return nil
+}
+
+// freshPresignedURL reports whether a presigned URL was signed to last
+// expiresIn seconds and has more than half of that left at now.
+func freshPresignedURL(presigned string, expiresIn int64, now time.Time) bool {
+	if presigned == "" {
+		return false
+	}
+	u, err := url.Parse(presigned)
+	if err != nil {
+		return false
+	}
+	q := u.Query()
+	if q.Get("X-Amz-Expires") != strconv.FormatInt(expiresIn, 10) {
+		return false
+	}
+	signed, err := time.Parse("20060102T150405Z", q.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+	return now.Before(signed.Add(time.Duration(expiresIn) * time.Second / 2))
+}
@@ -0,0 +1,143 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePresigner records the expiry it is asked to presign for.
+type fakePresigner struct {
+	calls   int
+	expires time.Duration
+}
+
+func (f *fakePresigner) PresignGetObject(_ context.Context, params *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error) {
+	f.calls++
+	var opts s3.PresignOptions
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+	f.expires = opts.Expires
+	return &v4.PresignedHTTPRequest{URL: "https://" + *params.Bucket + ".s3.amazonaws.com/" + *params.Key + "?X-Amz-Signature=new"}, nil
+}
+
+func presignedURLAt(signed time.Time, expiresIn int) string {
+	q := url.Values{}
+	q.Set("X-Amz-Date", signed.UTC().Format("20060102T150405Z"))
+	q.Set("X-Amz-Expires", strconv.Itoa(expiresIn))
+	return "https://my-bucket.s3.amazonaws.com/file.txt?" + q.Encode()
+}
+
+func readWithPrior(t *testing.T, o *Object, prior map[string]any, redact bool) map[string]any {
+	t.Helper()
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+	client.On("HeadObject", ctx, mock.Anything).Return(&s3.HeadObjectOutput{}, nil)
+	client.On("GetObjectTagging", ctx, mock.Anything).Return(&s3.GetObjectTaggingOutput{}, nil)
+	client.On("GetObjectAcl", ctx, mock.Anything).Return(&s3.GetObjectAclOutput{}, nil)
+
+	priorBytes, _ := json.Marshal(prior)
+	result, err := o.readWithClient(ctx, client, &resource.ReadRequest{
+		NativeID:        "my-bucket|file.txt",
+		PriorProperties: priorBytes,
+		RedactSensitive: redact,
+	})
+	require.NoError(t, err)
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	return props
+}
+
+func TestRead_PresignsURL(t *testing.T) {
+	presigner := &fakePresigner{}
+	o := &Object{presignClient: presigner}
+
+	props := readWithPrior(t, o, map[string]any{"Bucket": "my-bucket", "Key": "file.txt", "PresignedUrlExpiresIn": 3600}, false)
+
+	assert.Equal(t, "https://my-bucket.s3.amazonaws.com/file.txt?X-Amz-Signature=new", props["PresignedUrl"])
+	assert.Equal(t, float64(3600), props["PresignedUrlExpiresIn"])
+	assert.Equal(t, time.Hour, presigner.expires)
+}
+
+func TestRead_KeepsFreshPresignedURL(t *testing.T) {
+	presigner := &fakePresigner{}
+	o := &Object{presignClient: presigner}
+	prior := presignedURLAt(time.Now().Add(-10*time.Minute), 3600)
+
+	props := readWithPrior(t, o, map[string]any{"PresignedUrlExpiresIn": 3600, "PresignedUrl": prior}, false)
+
+	assert.Equal(t, prior, props["PresignedUrl"])
+	assert.Zero(t, presigner.calls)
+}
+
+func TestRead_NoPresignedURL(t *testing.T) {
+	presigner := &fakePresigner{}
+	o := &Object{presignClient: presigner}
+
+	props := readWithPrior(t, o, map[string]any{"Bucket": "my-bucket", "Key": "file.txt"}, false)
+	assert.NotContains(t, props, "PresignedUrl")
+
+	// A redacted read leaves out the URL, which grants access to the object.
+	props = readWithPrior(t, o, map[string]any{"PresignedUrlExpiresIn": 3600}, true)
+	assert.NotContains(t, props, "PresignedUrl")
+	assert.Zero(t, presigner.calls)
+}
+
+func TestFreshPresignedURL(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		presigned string
+		expiresIn int64
+		want      bool
+	}{
+		{"recent", presignedURLAt(now.Add(-10*time.Minute), 3600), 3600, true},
+		{"past half its lifetime", presignedURLAt(now.Add(-31*time.Minute), 3600), 3600, false},
+		{"different expiry", presignedURLAt(now.Add(-time.Minute), 3600), 7200, false},
+		{"empty", "", 3600, false},
+		{"not presigned", "https://my-bucket.s3.amazonaws.com/file.txt", 3600, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, freshPresignedURL(tt.presigned, tt.expiresIn, now))
+		})
+	}
+}
+
+func TestPresigner_SharesClientConfiguration(t *testing.T) {
+	client := s3.New(s3.Options{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKIDEXAMPLE", "secret", ""),
+	})
+
+	o := &Object{}
+	props := map[string]any{}
+	err := o.addPresignedURL(context.Background(), client, "my-bucket", "dir/file.txt", props,
+		map[string]any{"PresignedUrlExpiresIn": float64(900)})
+
+	require.NoError(t, err)
+	u, err := url.Parse(props["PresignedUrl"].(string))
+	require.NoError(t, err)
+	assert.Contains(t, u.Host+u.Path, "my-bucket")
+	assert.Contains(t, u.Path, "dir/file.txt")
+	assert.Equal(t, "900", u.Query().Get("X-Amz-Expires"))
+	assert.NotEmpty(t, u.Query().Get("X-Amz-Signature"))
+}
@@ -0,0 +1,117 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package s3
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// An object encrypted with a customer-provided key (SSE-C) needs the key with
+// every request that writes it or reads its content or metadata; S3 keeps only
+// a salted HMAC of it. SseCustomerKey is write-only and never reported back:
+// reads report SseCustomerAlgorithm alone.
+const (
+	sseCustomerAlgorithmKey = "SseCustomerAlgorithm"
+	sseCustomerKeyKey       = "SseCustomerKey"
+)
+
+// sseCustomerKey is a customer-provided key in the form S3's SSE-C headers
+// take it.
+type sseCustomerKey struct {
+	algorithm string
+	key       string
+	keyMD5    string
+}
+
+// sseCustomerKeyFrom returns the customer-provided key a model gives, or nil
+// if it gives none. The key is a base64-encoded 256-bit key, usually resolved
+// from a secret.
+func sseCustomerKeyFrom(props map[string]any) (*sseCustomerKey, error) {
+	key, _ := props[sseCustomerKeyKey].(string)
+	if key == "" {
+		return nil, nil
+	}
+	algorithm, _ := props[sseCustomerAlgorithmKey].(string)
+	if algorithm == "" {
+		algorithm = "AES256"
+	}
+	// The error never includes the key.
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(raw) != 32 {
+		return nil, fmt.Errorf("invalid SseCustomerKey: expected a base64-encoded 256-bit key")
+	}
+	sum := md5.Sum(raw)
+	return &sseCustomerKey{
+		algorithm: algorithm,
+		key:       key,
+		keyMD5:    base64.StdEncoding.EncodeToString(sum[:]),
+	}, nil
+}
+
+// Properties a HeadObject reports that a listing doesn't. A read that can't
+// head the object takes them from the model.
+var headOnlyKeys = []string{
+	"CacheControl",
+	checksumKey,
+	"ContentDisposition",
+	"ContentEncoding",
+	"ContentLanguage",
+	"ContentSha256",
+	"ContentType",
+	"Metadata",
+	sseCustomerAlgorithmKey,
+	"WebsiteRedirectLocation",
+}
+
+// listedObjectProperties reads the object from a listing of its key, for an
+// object encrypted with a customer-provided key the read wasn't given. It
+// returns nil if the key holds no object.
+func listedObjectProperties(ctx context.Context, client s3ObjectClient, bucket, key string, model map[string]any) (map[string]any, error) {
+	out, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucket),
+		Prefix:  aws.String(key),
+		MaxKeys: aws.Int32(1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list object: %w", err)
+	}
+	if len(out.Contents) == 0 || aws.ToString(out.Contents[0].Key) != key {
+		return nil, nil
+	}
+
+	obj := out.Contents[0]
+	props := map[string]any{
+		"Bucket": bucket,
+		"Key":    key,
+	}
+	if obj.ETag != nil {
+		props["ETag"] = *obj.ETag
+	}
+	if obj.Size != nil {
+		props["ContentLength"] = *obj.Size
+	}
+	if obj.StorageClass != "" {
+		props["StorageClass"] = string(obj.StorageClass)
+	}
+	for _, k := range headOnlyKeys {
+		if v, ok := model[k]; ok {
+			props[k] = v
+		}
+	}
+	return props, nil
+}
+
+func isBadRequest(err error) bool {
+	var respErr interface{ HTTPStatusCode() int }
+	return errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusBadRequest
+}
@@ -0,0 +1,199 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package s3
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testSSECustomerKey is a 256-bit key of zero bytes, and testSSECustomerKeyMD5
+// its MD5 digest, both base64-encoded.
+var (
+	testSSECustomerKey    = base64.StdEncoding.EncodeToString(make([]byte, 32))
+	testSSECustomerKeyMD5 = "cLyPS3KoaSFGi/joRB3OUQ=="
+)
+
+func TestSSECustomerKeyFrom(t *testing.T) {
+	sse, err := sseCustomerKeyFrom(map[string]any{"SseCustomerKey": testSSECustomerKey})
+	require.NoError(t, err)
+	assert.Equal(t, &sseCustomerKey{algorithm: "AES256", key: testSSECustomerKey, keyMD5: testSSECustomerKeyMD5}, sse)
+
+	sse, err = sseCustomerKeyFrom(map[string]any{"SseCustomerAlgorithm": "AES256"})
+	require.NoError(t, err)
+	assert.Nil(t, sse)
+
+	short := base64.StdEncoding.EncodeToString([]byte("too-short"))
+	for _, invalid := range []string{short, "not base64!"} {
+		_, err := sseCustomerKeyFrom(map[string]any{"SseCustomerKey": invalid})
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), invalid)
+	}
+}
+
+func TestCreate_SSECustomerKey(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	props := map[string]any{
+		"Bucket": "my-bucket", "Key": "secret.txt", "Content": "hello world",
+		"SseCustomerAlgorithm": "AES256", "SseCustomerKey": testSSECustomerKey,
+	}
+	propsBytes, _ := json.Marshal(props)
+
+	client.On("PutObject", ctx, mock.MatchedBy(func(input *s3.PutObjectInput) bool {
+		return aws.ToString(input.SSECustomerAlgorithm) == "AES256" &&
+			aws.ToString(input.SSECustomerKey) == testSSECustomerKey &&
+			aws.ToString(input.SSECustomerKeyMD5) == testSSECustomerKeyMD5
+	})).Return(&s3.PutObjectOutput{}, nil)
+	client.On("HeadObject", ctx, mock.MatchedBy(func(input *s3.HeadObjectInput) bool {
+		return aws.ToString(input.SSECustomerKey) == testSSECustomerKey
+	})).Return(&s3.HeadObjectOutput{SSECustomerAlgorithm: aws.String("AES256")}, nil)
+	client.On("GetObjectTagging", ctx, mock.Anything).Return(&s3.GetObjectTaggingOutput{}, nil)
+	client.On("GetObjectAcl", ctx, mock.Anything).Return(&s3.GetObjectAclOutput{}, nil)
+
+	o := &Object{}
+	result, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: propsBytes})
+
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	assert.Equal(t, "AES256", mustProps(t, string(result.ProgressResult.ResourceProperties))["SseCustomerAlgorithm"])
+	assert.NotContains(t, string(result.ProgressResult.ResourceProperties), testSSECustomerKey)
+}
+
+func TestCreate_InvalidSSECustomerKey_Errors(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	props := map[string]any{"Bucket": "my-bucket", "Key": "secret.txt", "Content": "x", "SseCustomerKey": "c2hvcnQ="}
+	propsBytes, _ := json.Marshal(props)
+
+	o := &Object{}
+	_, err := o.createWithClient(ctx, client, &resource.CreateRequest{Properties: propsBytes})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid SseCustomerKey")
+	client.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything)
+}
+
+func TestUpdate_SSECustomerKeyIsAlwaysUploaded(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	desired, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "secret.txt", "Content": "hello world", "SseCustomerKey": testSSECustomerKey,
+	})
+	prior, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "secret.txt", "ContentSha256": helloWorldSHA256, "SseCustomerAlgorithm": "AES256",
+	})
+	client.On("PutObject", ctx, mock.Anything).Return(&s3.PutObjectOutput{}, nil)
+	mockReadBack(client, ctx)
+
+	o := &Object{}
+	_, err := o.updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "my-bucket|secret.txt",
+		DesiredProperties: desired,
+		PriorProperties:   prior,
+	})
+
+	require.NoError(t, err)
+	client.AssertCalled(t, "PutObject", ctx, mock.Anything)
+	client.AssertNotCalled(t, "CopyObject", mock.Anything, mock.Anything)
+}
+
+func sseBadRequest() error {
+	return &smithyhttp.ResponseError{
+		Response: &smithyhttp.Response{Response: &http.Response{StatusCode: http.StatusBadRequest}},
+		Err:      errors.New("api error BadRequest: Bad Request"),
+	}
+}
+
+func TestRead_SSECObjectWithoutKeyIsListed(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	client.On("HeadObject", ctx, mock.Anything).Return((*s3.HeadObjectOutput)(nil), sseBadRequest())
+	client.On("ListObjectsV2", ctx, mock.MatchedBy(func(input *s3.ListObjectsV2Input) bool {
+		return *input.Prefix == "secret.txt"
+	})).Return(&s3.ListObjectsV2Output{Contents: []s3types.Object{{
+		Key:          aws.String("secret.txt"),
+		ETag:         aws.String("\"abc\""),
+		Size:         aws.Int64(11),
+		StorageClass: s3types.ObjectStorageClassStandard,
+	}}}, nil)
+	client.On("GetObjectTagging", ctx, mock.Anything).Return(&s3.GetObjectTaggingOutput{}, nil)
+	client.On("GetObjectAcl", ctx, mock.Anything).Return(&s3.GetObjectAclOutput{}, nil)
+
+	prior, _ := json.Marshal(map[string]any{"ContentType": "text/plain", "SseCustomerAlgorithm": "AES256", "Acl": "private"})
+	o := &Object{}
+	result, err := o.readWithClient(ctx, client, &resource.ReadRequest{NativeID: "my-bucket|secret.txt", PriorProperties: prior})
+
+	require.NoError(t, err)
+	props := mustProps(t, result.Properties)
+	assert.Equal(t, "\"abc\"", props["ETag"])
+	assert.Equal(t, float64(11), props["ContentLength"])
+	assert.Equal(t, "text/plain", props["ContentType"])
+	assert.Equal(t, "AES256", props["SseCustomerAlgorithm"])
+	assert.NotContains(t, props, "Acl")
+}
+
+func TestRead_SSECObjectWithoutKey_NotFound(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	client.On("HeadObject", ctx, mock.Anything).Return((*s3.HeadObjectOutput)(nil), sseBadRequest())
+	client.On("ListObjectsV2", ctx, mock.Anything).Return(&s3.ListObjectsV2Output{Contents: []s3types.Object{{
+		Key: aws.String("secret.txt.old"),
+	}}}, nil)
+
+	o := &Object{}
+	result, err := o.readWithClient(ctx, client, &resource.ReadRequest{NativeID: "my-bucket|secret.txt"})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationErrorCodeNotFound, result.ErrorCode)
+}
+
+func mustProps(t *testing.T, raw string) map[string]any {
+	t.Helper()
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(raw)), &props))
+	return props
+}
+
+func TestUpdate_WithoutBodyUnderCustomerKey_Errors(t *testing.T) {
+	ctx := context.Background()
+	client := &mockS3ObjectClient{}
+
+	desired, _ := json.Marshal(map[string]any{
+		"Bucket": "my-bucket", "Key": "file.txt", "ContentType": "text/plain",
+		"SseCustomerAlgorithm": "AES256", "SseCustomerKey": testSSECustomerKey,
+	})
+
+	o := &Object{}
+	_, err := o.updateWithClient(ctx, client, &resource.UpdateRequest{
+		NativeID:          "my-bucket|file.txt",
+		DesiredProperties: desired,
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "without its content")
+	client.AssertNotCalled(t, "CopyObject", mock.Anything, mock.Anything)
+}
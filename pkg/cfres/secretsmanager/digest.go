@@ -0,0 +1,129 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package secretsmanager
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// secretStringDigestProperty is the read-only property a secret's Read
+// reports the digest of its SecretString in. Unlike the value, the digest is
+// kept in state when sensitive values are redacted, so a value changed out of
+// band still shows up as drift.
+const secretStringDigestProperty = "SecretStringDigest"
+
+// A digest reads "hmac-sha256:<salt>:<mac>", with the base64 salt and hex
+// HMAC-SHA256 of the value keyed by it. The salt is random per secret and
+// kept across reads, so equal values in different secrets have different
+// digests and the digest can't be looked up in a precomputed table.
+const (
+	digestScheme   = "hmac-sha256"
+	digestSaltSize = 16
+)
+
+// secretStringDigest returns the digest of value under salt, or under a new
+// random salt when salt is nil.
+func secretStringDigest(value string, salt []byte) (string, error) {
+	if salt == nil {
+		salt = make([]byte, digestSaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return "", fmt.Errorf("generating digest salt: %w", err)
+		}
+	}
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(value))
+	return digestScheme + ":" + base64.RawStdEncoding.EncodeToString(salt) + ":" + hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// priorDigestSalt returns the salt of the digest in a read's prior
+// properties, so an unchanged value reads back with the digest already in
+// state. It returns nil when there is no usable prior digest.
+func priorDigestSalt(prior json.RawMessage) []byte {
+	if len(prior) == 0 {
+		return nil
+	}
+	var props map[string]any
+	if err := json.Unmarshal(prior, &props); err != nil {
+		return nil
+	}
+	digest, _ := props[secretStringDigestProperty].(string)
+	parts := strings.Split(digest, ":")
+	if len(parts) != 3 || parts[0] != digestScheme {
+		return nil
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil || len(salt) == 0 {
+		return nil
+	}
+	return salt
+}
+
+// withoutDigest drops SecretStringDigest from properties. It isn't a
+// CloudControl property, so a create or update must not send it.
+func withoutDigest(properties json.RawMessage) (json.RawMessage, error) {
+	if len(properties) == 0 {
+		return properties, nil
+	}
+	var props map[string]any
+	if err := json.Unmarshal(properties, &props); err != nil {
+		return nil, fmt.Errorf("parsing properties: %w", err)
+	}
+	if _, ok := props[secretStringDigestProperty]; !ok {
+		return properties, nil
+	}
+	delete(props, secretStringDigestProperty)
+	return json.Marshal(props)
+}
+
+// patchWithoutDigest drops the operations on SecretStringDigest from an
+// update's patch document.
+func patchWithoutDigest(patchDoc *string) (*string, error) {
+	if patchDoc == nil {
+		return nil, nil
+	}
+	var ops []map[string]any
+	if err := json.Unmarshal([]byte(*patchDoc), &ops); err != nil {
+		return nil, fmt.Errorf("decoding patch document: %w", err)
+	}
+	kept := ops[:0]
+	for _, op := range ops {
+		if op["path"] != "/"+secretStringDigestProperty {
+			kept = append(kept, op)
+		}
+	}
+	if len(kept) == len(ops) {
+		return patchDoc, nil
+	}
+	patched, err := json.Marshal(kept)
+	if err != nil {
+		return nil, err
+	}
+	s := string(patched)
+	return &s, nil
+}
+
+// stripDigestFromUpdate drops SecretStringDigest from every part of an update
+// request CloudControl sees, including the prior model a patch may be
+// computed from.
+func stripDigestFromUpdate(request *resource.UpdateRequest) error {
+	var err error
+	if request.PriorProperties, err = withoutDigest(request.PriorProperties); err != nil {
+		return err
+	}
+	if request.DesiredProperties, err = withoutDigest(request.DesiredProperties); err != nil {
+		return err
+	}
+	request.PatchDocument, err = patchWithoutDigest(request.PatchDocument)
+	return err
+}
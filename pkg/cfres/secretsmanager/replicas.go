@@ -0,0 +1,283 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package secretsmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+const (
+	replicaRegionsProperty = "ReplicaRegions"
+	replicaRegionsPath     = "/" + replicaRegionsProperty
+
+	// replicasRequestIDPrefix marks the RequestID of an update that only
+	// changed replicas, which Status follows without CloudControl.
+	replicasRequestIDPrefix = "replicas|"
+)
+
+// secretReplicator is the subset of the Secrets Manager API used to change a
+// secret's replicas and follow their replication. *secretsmanager.Client
+// satisfies it.
+type secretReplicator interface {
+	DescribeSecret(ctx context.Context, params *secretsmanager.DescribeSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DescribeSecretOutput, error)
+	ReplicateSecretToRegions(ctx context.Context, params *secretsmanager.ReplicateSecretToRegionsInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.ReplicateSecretToRegionsOutput, error)
+	RemoveRegionsFromReplication(ctx context.Context, params *secretsmanager.RemoveRegionsFromReplicationInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.RemoveRegionsFromReplicationOutput, error)
+}
+
+// replica is one entry of a secret's ReplicaRegions.
+type replica struct {
+	Region   string `json:"Region"`
+	KmsKeyId string `json:"KmsKeyId,omitempty"`
+}
+
+// replicasOf returns the ReplicaRegions of a model, keyed by region, and
+// whether the model declares them at all.
+func replicasOf(properties json.RawMessage) (map[string]replica, bool, error) {
+	if len(properties) == 0 {
+		return nil, false, nil
+	}
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal(properties, &props); err != nil {
+		return nil, false, fmt.Errorf("parsing properties: %w", err)
+	}
+	raw, ok := props[replicaRegionsProperty]
+	if !ok {
+		return nil, false, nil
+	}
+	var list []replica
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, false, fmt.Errorf("parsing %s: %w", replicaRegionsProperty, err)
+	}
+	replicas := make(map[string]replica, len(list))
+	for _, r := range list {
+		replicas[r.Region] = r
+	}
+	return replicas, true, nil
+}
+
+// desiredReplicas returns the replicas an update asks for and whether it
+// changes them. A patch is applied to the prior replicas; without one, a
+// desired model that leaves ReplicaRegions out keeps the replicas as they are.
+func desiredReplicas(request *resource.UpdateRequest) (map[string]replica, bool, error) {
+	if request.PatchDocument == nil {
+		return replicasOf(request.DesiredProperties)
+	}
+
+	var ops []map[string]any
+	if err := json.Unmarshal([]byte(*request.PatchDocument), &ops); err != nil {
+		return nil, false, fmt.Errorf("decoding patch document: %w", err)
+	}
+	var replicaOps []map[string]any
+	for _, op := range ops {
+		if isReplicaOp(op) {
+			replicaOps = append(replicaOps, op)
+		}
+	}
+	if len(replicaOps) == 0 {
+		return nil, false, nil
+	}
+
+	// The patch indexes into the prior list, so it is applied to that list as
+	// stored rather than to a rebuilt one.
+	prior := json.RawMessage(`{}`)
+	if len(request.PriorProperties) > 0 {
+		var props map[string]json.RawMessage
+		if err := json.Unmarshal(request.PriorProperties, &props); err != nil {
+			return nil, false, fmt.Errorf("parsing prior properties: %w", err)
+		}
+		if raw, ok := props[replicaRegionsProperty]; ok {
+			var err error
+			if prior, err = json.Marshal(map[string]json.RawMessage{replicaRegionsProperty: raw}); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+	encoded, err := json.Marshal(replicaOps)
+	if err != nil {
+		return nil, false, err
+	}
+	patch, err := jsonpatch.DecodePatch(encoded)
+	if err != nil {
+		return nil, false, fmt.Errorf("decoding %s patch: %w", replicaRegionsProperty, err)
+	}
+	patched, err := patch.Apply(prior)
+	if err != nil {
+		return nil, false, fmt.Errorf("applying %s patch: %w", replicaRegionsProperty, err)
+	}
+	replicas, _, err := replicasOf(patched)
+	if err != nil {
+		return nil, false, err
+	}
+	if replicas == nil {
+		replicas = map[string]replica{}
+	}
+	return replicas, true, nil
+}
+
+func isReplicaOp(op map[string]any) bool {
+	path, _ := op["path"].(string)
+	return path == replicaRegionsPath || strings.HasPrefix(path, replicaRegionsPath+"/")
+}
+
+// replicaChanges returns the replicas to add and the regions to remove to get
+// from prior to desired. Secrets Manager can't change a replica's KMS key in
+// place, so a replica whose key changed is removed and added again.
+func replicaChanges(prior, desired map[string]replica) (add []smtypes.ReplicaRegionType, remove []string) {
+	for region, p := range prior {
+		if d, ok := desired[region]; !ok || d.KmsKeyId != p.KmsKeyId {
+			remove = append(remove, region)
+		}
+	}
+	for _, d := range sortedReplicas(desired) {
+		if p, ok := prior[d.Region]; ok && p.KmsKeyId == d.KmsKeyId {
+			continue
+		}
+		r := smtypes.ReplicaRegionType{Region: aws.String(d.Region)}
+		if d.KmsKeyId != "" {
+			r.KmsKeyId = aws.String(d.KmsKeyId)
+		}
+		add = append(add, r)
+	}
+	sort.Strings(remove)
+	return add, remove
+}
+
+func sortedReplicas(replicas map[string]replica) []replica {
+	list := make([]replica, 0, len(replicas))
+	for _, r := range replicas {
+		list = append(list, r)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Region < list[j].Region })
+	return list
+}
+
+// updateReplicas brings the secret's replicas from prior to desired.
+func updateReplicas(ctx context.Context, client secretReplicator, secretID string, prior, desired map[string]replica) error {
+	add, remove := replicaChanges(prior, desired)
+	if len(remove) > 0 {
+		if _, err := client.RemoveRegionsFromReplication(ctx, &secretsmanager.RemoveRegionsFromReplicationInput{
+			SecretId:             aws.String(secretID),
+			RemoveReplicaRegions: remove,
+		}); err != nil {
+			return fmt.Errorf("removing replicas %s of secret %s: %w", strings.Join(remove, ", "), secretID, err)
+		}
+	}
+	if len(add) > 0 {
+		if _, err := client.ReplicateSecretToRegions(ctx, &secretsmanager.ReplicateSecretToRegionsInput{
+			SecretId:          aws.String(secretID),
+			AddReplicaRegions: add,
+		}); err != nil {
+			return fmt.Errorf("replicating secret %s: %w", secretID, err)
+		}
+	}
+	return nil
+}
+
+// withoutReplicas drops ReplicaRegions from the parts of an update request
+// CloudControl sees, once updateReplicas has applied them. It reports whether
+// anything is left for CloudControl to update.
+func withoutReplicas(request *resource.UpdateRequest) (bool, error) {
+	if request.PatchDocument != nil {
+		var ops []map[string]any
+		if err := json.Unmarshal([]byte(*request.PatchDocument), &ops); err != nil {
+			return false, fmt.Errorf("decoding patch document: %w", err)
+		}
+		kept := make([]map[string]any, 0, len(ops))
+		for _, op := range ops {
+			if !isReplicaOp(op) {
+				kept = append(kept, op)
+			}
+		}
+		if len(kept) == 0 {
+			return false, nil
+		}
+		if len(kept) != len(ops) {
+			patched, err := json.Marshal(kept)
+			if err != nil {
+				return false, err
+			}
+			request.PatchDocument = aws.String(string(patched))
+		}
+		return true, nil
+	}
+
+	prior, err := dropProperty(request.PriorProperties, replicaRegionsProperty)
+	if err != nil {
+		return false, err
+	}
+	desired, err := dropProperty(request.DesiredProperties, replicaRegionsProperty)
+	if err != nil {
+		return false, err
+	}
+	request.PriorProperties, request.DesiredProperties = prior, desired
+	return !sameProperties(prior, desired), nil
+}
+
+func dropProperty(properties json.RawMessage, name string) (json.RawMessage, error) {
+	if len(properties) == 0 {
+		return properties, nil
+	}
+	var props map[string]any
+	if err := json.Unmarshal(properties, &props); err != nil {
+		return nil, fmt.Errorf("parsing properties: %w", err)
+	}
+	if _, ok := props[name]; !ok {
+		return properties, nil
+	}
+	delete(props, name)
+	return json.Marshal(props)
+}
+
+func sameProperties(a, b json.RawMessage) bool {
+	var left, right any
+	if json.Unmarshal(a, &left) != nil || json.Unmarshal(b, &right) != nil {
+		return false
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+func replicasRequestID(secretID string) string {
+	return replicasRequestIDPrefix + secretID
+}
+
+// replicationStatus reports how far a secret's replication has got: success
+// once every replica is in sync, failure if any failed, and in progress
+// otherwise, with a message naming the replicas concerned.
+func replicationStatus(ctx context.Context, client secretReplicator, secretID string) (resource.OperationStatus, string, error) {
+	out, err := client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{SecretId: aws.String(secretID)})
+	if err != nil {
+		return "", "", fmt.Errorf("describing secret %s: %w", secretID, err)
+	}
+
+	var failed, pending []string
+	for _, r := range out.ReplicationStatus {
+		region := aws.ToString(r.Region)
+		switch r.Status {
+		case smtypes.StatusTypeFailed:
+			failed = append(failed, fmt.Sprintf("%s: %s", region, aws.ToString(r.StatusMessage)))
+		case smtypes.StatusTypeInProgress:
+			pending = append(pending, region)
+		}
+	}
+	switch {
+	case len(failed) > 0:
+		return resource.OperationStatusFailure, "secret replication failed in " + strings.Join(failed, "; "), nil
+	case len(pending) > 0:
+		return resource.OperationStatusInProgress, "waiting for secret replicas to sync: " + strings.Join(pending, ", "), nil
+	}
+	return resource.OperationStatusSuccess, "", nil
+}
@@ -0,0 +1,184 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package secretsmanager
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ptr"
+)
+
+const priorWithReplicas = `{"Name":"db","ReplicaRegions":[{"Region":"us-west-2"},{"Region":"eu-west-1","KmsKeyId":"alias/a"}]}`
+
+func TestReplicaChanges(t *testing.T) {
+	prior := map[string]replica{
+		"us-west-2": {Region: "us-west-2"},
+		"eu-west-1": {Region: "eu-west-1", KmsKeyId: "alias/a"},
+	}
+	desired := map[string]replica{
+		"eu-west-1":      {Region: "eu-west-1", KmsKeyId: "alias/b"},
+		"ap-southeast-2": {Region: "ap-southeast-2"},
+	}
+
+	add, remove := replicaChanges(prior, desired)
+
+	assert.Equal(t, []string{"eu-west-1", "us-west-2"}, remove)
+	assert.Equal(t, []smtypes.ReplicaRegionType{
+		{Region: aws.String("ap-southeast-2")},
+		{Region: aws.String("eu-west-1"), KmsKeyId: aws.String("alias/b")},
+	}, add)
+}
+
+func TestSecret_Update_ReplicasOnly(t *testing.T) {
+	ctx := context.Background()
+	ccxClient := &mockSecretCCXClient{}
+	secretsClient := &mockSecretsClient{}
+	secretsClient.On("RemoveRegionsFromReplication", ctx, mock.MatchedBy(func(input *secretsmanager.RemoveRegionsFromReplicationInput) bool {
+		return assert.ObjectsAreEqual([]string{"us-west-2"}, input.RemoveReplicaRegions)
+	})).Return(&secretsmanager.RemoveRegionsFromReplicationOutput{}, nil)
+	secretsClient.On("ReplicateSecretToRegions", ctx, mock.MatchedBy(func(input *secretsmanager.ReplicateSecretToRegionsInput) bool {
+		return len(input.AddReplicaRegions) == 1 && aws.ToString(input.AddReplicaRegions[0].Region) == "ca-central-1"
+	})).Return(&secretsmanager.ReplicateSecretToRegionsOutput{}, nil)
+
+	result, err := (&Secret{cfg: &config.Config{}}).updateWithClients(ctx, ccxClient, secretsClient, &resource.UpdateRequest{
+		NativeID:        secretARN,
+		PriorProperties: json.RawMessage(priorWithReplicas),
+		PatchDocument: ptr.Of(`[{"op":"replace","path":"/ReplicaRegions/0/Region","value":"ca-central-1"},` +
+			`{"op":"remove","path":"/SecretStringDigest"}]`),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusInProgress, result.ProgressResult.OperationStatus)
+	assert.Equal(t, replicasRequestID(secretARN), result.ProgressResult.RequestID)
+	ccxClient.AssertNotCalled(t, "UpdateResource", mock.Anything, mock.Anything)
+	secretsClient.AssertExpectations(t)
+}
+
+func TestSecret_Update_RestGoesToCloudControl(t *testing.T) {
+	ctx := context.Background()
+	secretsClient := &mockSecretsClient{}
+	secretsClient.On("RemoveRegionsFromReplication", ctx, mock.Anything).Return(&secretsmanager.RemoveRegionsFromReplicationOutput{}, nil)
+	ccxClient := &mockSecretCCXClient{}
+	ccxClient.On("UpdateResource", ctx, mock.MatchedBy(func(request *resource.UpdateRequest) bool {
+		return assert.JSONEq(t, `{"Name":"db","Description":"d"}`, string(request.DesiredProperties)) &&
+			assert.JSONEq(t, `{"Name":"db"}`, string(request.PriorProperties))
+	})).Return(&resource.UpdateResult{ProgressResult: &resource.ProgressResult{RequestID: "token"}}, nil)
+
+	result, err := (&Secret{cfg: &config.Config{}}).updateWithClients(ctx, ccxClient, secretsClient, &resource.UpdateRequest{
+		NativeID:          secretARN,
+		PriorProperties:   json.RawMessage(priorWithReplicas),
+		DesiredProperties: json.RawMessage(`{"Name":"db","Description":"d","ReplicaRegions":[{"Region":"us-west-2"}]}`),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "token", result.ProgressResult.RequestID)
+	secretsClient.AssertCalled(t, "RemoveRegionsFromReplication", ctx, mock.MatchedBy(func(input *secretsmanager.RemoveRegionsFromReplicationInput) bool {
+		return assert.ObjectsAreEqual([]string{"eu-west-1"}, input.RemoveReplicaRegions)
+	}))
+	secretsClient.AssertNotCalled(t, "ReplicateSecretToRegions", mock.Anything, mock.Anything)
+}
+
+func TestSecret_Update_ReplicasUndeclared(t *testing.T) {
+	ctx := context.Background()
+	secretsClient := &mockSecretsClient{}
+	ccxClient := &mockSecretCCXClient{}
+	ccxClient.On("UpdateResource", ctx, mock.Anything).Return(&resource.UpdateResult{ProgressResult: &resource.ProgressResult{}}, nil)
+
+	_, err := (&Secret{cfg: &config.Config{}}).updateWithClients(ctx, ccxClient, secretsClient, &resource.UpdateRequest{
+		NativeID:          secretARN,
+		PriorProperties:   json.RawMessage(priorWithReplicas),
+		DesiredProperties: json.RawMessage(`{"Name":"db","Description":"d"}`),
+	})
+
+	require.NoError(t, err)
+	secretsClient.AssertNotCalled(t, "RemoveRegionsFromReplication", mock.Anything, mock.Anything)
+}
+
+func replicationOutput(statuses ...smtypes.ReplicationStatusType) *secretsmanager.DescribeSecretOutput {
+	return &secretsmanager.DescribeSecretOutput{ReplicationStatus: statuses}
+}
+
+func TestSecret_Status_GatesOnReplication(t *testing.T) {
+	tests := []struct {
+		name       string
+		output     *secretsmanager.DescribeSecretOutput
+		wantStatus resource.OperationStatus
+		wantMsg    string
+	}{
+		{"in sync", replicationOutput(
+			smtypes.ReplicationStatusType{Region: aws.String("us-west-2"), Status: smtypes.StatusTypeInSync},
+		), resource.OperationStatusSuccess, ""},
+		{"syncing", replicationOutput(
+			smtypes.ReplicationStatusType{Region: aws.String("us-west-2"), Status: smtypes.StatusTypeInSync},
+			smtypes.ReplicationStatusType{Region: aws.String("eu-west-1"), Status: smtypes.StatusTypeInProgress},
+		), resource.OperationStatusInProgress, "waiting for secret replicas to sync: eu-west-1"},
+		{"failed", replicationOutput(
+			smtypes.ReplicationStatusType{Region: aws.String("eu-west-1"), Status: smtypes.StatusTypeFailed,
+				StatusMessage: aws.String("Secret with this name already exists in this region")},
+		), resource.OperationStatusFailure, "eu-west-1: Secret with this name already exists"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			request := &resource.StatusRequest{RequestID: "token", NativeID: secretARN}
+			ccxClient := &mockSecretCCXClient{}
+			ccxClient.On("StatusResource", ctx, request, mock.Anything).Return(&resource.StatusResult{
+				ProgressResult: &resource.ProgressResult{
+					Operation:          resource.OperationCreate,
+					OperationStatus:    resource.OperationStatusSuccess,
+					NativeID:           secretARN,
+					ResourceProperties: json.RawMessage(`{"Name":"db"}`),
+				},
+			}, nil)
+			secretsClient := &mockSecretsClient{}
+			secretsClient.On("DescribeSecret", ctx, mock.Anything).Return(tt.output, nil)
+
+			result, err := (&Secret{cfg: &config.Config{}}).statusWithClients(ctx, ccxClient, secretsClient, request)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, result.ProgressResult.OperationStatus)
+			assert.Contains(t, result.ProgressResult.StatusMessage, tt.wantMsg)
+			if tt.wantStatus == resource.OperationStatusSuccess {
+				assert.JSONEq(t, `{"Name":"db"}`, string(result.ProgressResult.ResourceProperties))
+			} else {
+				assert.Nil(t, result.ProgressResult.ResourceProperties)
+			}
+		})
+	}
+}
+
+func TestSecret_Status_ReplicasOnlyUpdate(t *testing.T) {
+	ctx := context.Background()
+	ccxClient := &mockSecretCCXClient{}
+	ccxClient.On("ReadResource", ctx, mock.Anything).Return(&resource.ReadResult{Properties: `{"Name":"db"}`}, nil)
+	secretsClient := &mockSecretsClient{}
+	secretsClient.On("DescribeSecret", ctx, mock.Anything).Return(replicationOutput(
+		smtypes.ReplicationStatusType{Region: aws.String("ca-central-1"), Status: smtypes.StatusTypeInSync},
+	), nil)
+	secretsClient.On("GetSecretValue", ctx, mock.Anything).Return(&secretsmanager.GetSecretValueOutput{SecretString: aws.String("v")}, nil)
+
+	result, err := (&Secret{cfg: &config.Config{}}).statusWithClients(ctx, ccxClient, secretsClient,
+		&resource.StatusRequest{RequestID: replicasRequestID(secretARN), NativeID: secretARN})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	assert.Equal(t, resource.OperationUpdate, result.ProgressResult.Operation)
+	assert.Equal(t, secretARN, result.ProgressResult.NativeID)
+	assert.Contains(t, string(result.ProgressResult.ResourceProperties), secretStringDigestProperty)
+	ccxClient.AssertNotCalled(t, "StatusResource", mock.Anything, mock.Anything, mock.Anything)
+}
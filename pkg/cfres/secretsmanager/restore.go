@@ -0,0 +1,104 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package secretsmanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+)
+
+// restoredRequestIDPrefix marks the RequestID of a create that restored a
+// secret scheduled for deletion. It wraps the RequestID of the update that
+// brought the restored secret to the declared state, which Status follows
+// and reports as the create.
+const restoredRequestIDPrefix = "restored|"
+
+// secretRestorer is the subset of the Secrets Manager API used to restore a
+// secret scheduled for deletion. *secretsmanager.Client satisfies it.
+type secretRestorer interface {
+	DescribeSecret(ctx context.Context, params *secretsmanager.DescribeSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DescribeSecretOutput, error)
+	RestoreSecret(ctx context.Context, params *secretsmanager.RestoreSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.RestoreSecretOutput, error)
+}
+
+// scheduledForDeletion returns the ARN of the secret named name if it is
+// scheduled for deletion, or "" if there is no such secret or it isn't.
+// Secrets Manager refuses to create a secret under that name until the
+// deletion completes.
+func scheduledForDeletion(ctx context.Context, client secretRestorer, name string) (string, error) {
+	out, err := client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{SecretId: aws.String(name)})
+	if err != nil {
+		var notFound *smtypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("describing secret %s: %w", name, err)
+	}
+	if out.DeletedDate == nil {
+		return "", nil
+	}
+	return aws.ToString(out.ARN), nil
+}
+
+// restoreDeleted restores the secret a create names if it is scheduled for
+// deletion, and updates it to the declared properties. It reports false,
+// leaving the create to CloudControl, if there is nothing to restore.
+func (s *Secret) restoreDeleted(ctx context.Context, ccxClient secretCCXClient, secretsClient secretsClientInterface, request *resource.CreateRequest) (*resource.CreateResult, bool, error) {
+	var props map[string]any
+	if err := json.Unmarshal(request.Properties, &props); err != nil {
+		return nil, false, fmt.Errorf("parsing properties: %w", err)
+	}
+	name, _ := props["Name"].(string)
+	if name == "" {
+		return nil, false, nil
+	}
+
+	arn, err := scheduledForDeletion(ctx, secretsClient, name)
+	if err != nil || arn == "" {
+		return nil, false, err
+	}
+	if _, err := secretsClient.RestoreSecret(ctx, &secretsmanager.RestoreSecretInput{SecretId: aws.String(arn)}); err != nil {
+		return nil, false, fmt.Errorf("restoring secret %s: %w", name, err)
+	}
+	plugin.LoggerFromContext(ctx).Info("SecretsManager: restored secret scheduled for deletion", "name", name, "arn", arn)
+
+	current, err := ccxClient.ReadResource(ctx, &resource.ReadRequest{NativeID: arn, ResourceType: request.ResourceType})
+	if err != nil {
+		return nil, false, fmt.Errorf("reading restored secret %s: %w", name, err)
+	}
+	if current.ErrorCode != "" {
+		return nil, false, fmt.Errorf("reading restored secret %s: %s", name, current.ErrorCode)
+	}
+
+	updated, err := s.updateWithClients(ctx, ccxClient, secretsClient, &resource.UpdateRequest{
+		NativeID:          arn,
+		ResourceType:      request.ResourceType,
+		Label:             request.Label,
+		PriorProperties:   json.RawMessage(current.Properties),
+		DesiredProperties: request.Properties,
+		TargetConfig:      request.TargetConfig,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	pr := updated.ProgressResult
+	pr.Operation = resource.OperationCreate
+	if pr.NativeID == "" {
+		pr.NativeID = arn
+	}
+	if pr.OperationStatus == resource.OperationStatusInProgress {
+		pr.RequestID = restoredRequestIDPrefix + pr.RequestID
+	}
+	return &resource.CreateResult{ProgressResult: pr}, true, nil
+}
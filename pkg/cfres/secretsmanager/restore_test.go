@@ -0,0 +1,128 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package secretsmanager
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+const declaredSecret = `{"Name":"db","Description":"d","SecretString":"v"}`
+
+func restoringSecret() *Secret {
+	return &Secret{cfg: &config.Config{RestoreDeletedSecrets: true}}
+}
+
+func TestSecret_Create_RestoresScheduledForDeletion(t *testing.T) {
+	ctx := context.Background()
+	secretsClient := &mockSecretsClient{}
+	secretsClient.On("DescribeSecret", ctx, mock.Anything).Return(&secretsmanager.DescribeSecretOutput{
+		ARN:         aws.String(secretARN),
+		DeletedDate: aws.Time(time.Now()),
+	}, nil)
+	secretsClient.On("RestoreSecret", ctx, &secretsmanager.RestoreSecretInput{SecretId: aws.String(secretARN)}).
+		Return(&secretsmanager.RestoreSecretOutput{}, nil)
+	ccxClient := &mockSecretCCXClient{}
+	ccxClient.On("ReadResource", ctx, mock.Anything).Return(&resource.ReadResult{Properties: `{"Id":"` + secretARN + `","Name":"db"}`}, nil)
+	ccxClient.On("UpdateResource", ctx, mock.MatchedBy(func(request *resource.UpdateRequest) bool {
+		return request.NativeID == secretARN && assert.JSONEq(t, declaredSecret, string(request.DesiredProperties))
+	})).Return(&resource.UpdateResult{ProgressResult: &resource.ProgressResult{
+		Operation:       resource.OperationUpdate,
+		OperationStatus: resource.OperationStatusInProgress,
+		RequestID:       "token",
+		NativeID:        secretARN,
+	}}, nil)
+
+	result, err := restoringSecret().createWithClients(ctx, ccxClient, secretsClient,
+		&resource.CreateRequest{ResourceType: "AWS::SecretsManager::Secret", Properties: json.RawMessage(declaredSecret)})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationCreate, result.ProgressResult.Operation)
+	assert.Equal(t, restoredRequestIDPrefix+"token", result.ProgressResult.RequestID)
+	assert.Equal(t, secretARN, result.ProgressResult.NativeID)
+	ccxClient.AssertNotCalled(t, "CreateResource", mock.Anything, mock.Anything)
+	secretsClient.AssertExpectations(t)
+}
+
+func TestSecret_Create_NothingToRestore(t *testing.T) {
+	tests := []struct {
+		name     string
+		describe func(*mock.Call)
+	}{
+		{"no such secret", func(c *mock.Call) {
+			c.Return(nil, &smtypes.ResourceNotFoundException{Message: aws.String("not found")})
+		}},
+		{"not deleted", func(c *mock.Call) {
+			c.Return(&secretsmanager.DescribeSecretOutput{ARN: aws.String(secretARN)}, nil)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			secretsClient := &mockSecretsClient{}
+			tt.describe(secretsClient.On("DescribeSecret", ctx, mock.Anything))
+			ccxClient := &mockSecretCCXClient{}
+			ccxClient.On("CreateResource", ctx, mock.Anything).Return(&resource.CreateResult{ProgressResult: &resource.ProgressResult{RequestID: "create"}}, nil)
+
+			result, err := restoringSecret().createWithClients(ctx, ccxClient, secretsClient,
+				&resource.CreateRequest{Properties: json.RawMessage(declaredSecret)})
+
+			require.NoError(t, err)
+			assert.Equal(t, "create", result.ProgressResult.RequestID)
+			secretsClient.AssertNotCalled(t, "RestoreSecret", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSecret_Create_RestoreDisabled(t *testing.T) {
+	ctx := context.Background()
+	secretsClient := &mockSecretsClient{}
+	ccxClient := &mockSecretCCXClient{}
+	ccxClient.On("CreateResource", ctx, mock.Anything).Return(&resource.CreateResult{ProgressResult: &resource.ProgressResult{}}, nil)
+
+	_, err := (&Secret{cfg: &config.Config{}}).createWithClients(ctx, ccxClient, secretsClient,
+		&resource.CreateRequest{Properties: json.RawMessage(declaredSecret)})
+
+	require.NoError(t, err)
+	secretsClient.AssertNotCalled(t, "DescribeSecret", mock.Anything, mock.Anything)
+}
+
+func TestSecret_Status_RestoredCreate(t *testing.T) {
+	ctx := context.Background()
+	ccxClient := &mockSecretCCXClient{}
+	ccxClient.On("StatusResource", ctx, mock.MatchedBy(func(request *resource.StatusRequest) bool {
+		return request.RequestID == "token"
+	}), mock.Anything).Return(&resource.StatusResult{ProgressResult: &resource.ProgressResult{
+		Operation:          resource.OperationUpdate,
+		OperationStatus:    resource.OperationStatusSuccess,
+		RequestID:          "token",
+		NativeID:           secretARN,
+		ResourceProperties: json.RawMessage(`{"Name":"db"}`),
+	}}, nil)
+	secretsClient := &mockSecretsClient{}
+	secretsClient.On("DescribeSecret", ctx, mock.Anything).Return(&secretsmanager.DescribeSecretOutput{}, nil)
+
+	result, err := restoringSecret().statusWithClients(ctx, ccxClient, secretsClient,
+		&resource.StatusRequest{RequestID: restoredRequestIDPrefix + "token"})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationCreate, result.ProgressResult.Operation)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+	assert.Equal(t, restoredRequestIDPrefix+"token", result.ProgressResult.RequestID)
+}
@@ -7,10 +7,13 @@ package secretsmanager
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
 
 	"github.com/platform-engineering-labs/formae/pkg/plugin"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
@@ -39,7 +42,53 @@ func init() {
 		})
 }
 
-// Read enhances Cloud Control read with actual secret value
+// secretCCXReader is the CloudControl read a secret's Read enriches.
+// *ccx.Client satisfies it.
+type secretCCXReader interface {
+	ReadResource(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error)
+}
+
+// secretCCXClient is the CloudControl client a secret's Read, Create, Update
+// and Status hand off to. *ccx.Client satisfies it.
+type secretCCXClient interface {
+	secretCCXReader
+	CreateResource(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error)
+	UpdateResource(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error)
+	StatusResource(ctx context.Context, request *resource.StatusRequest, readFunc func(context.Context, *resource.ReadRequest) (*resource.ReadResult, error)) (*resource.StatusResult, error)
+}
+
+// secretValueGetter is the subset of the Secrets Manager API used to read a
+// secret's value. *secretsmanager.Client satisfies it.
+type secretValueGetter interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// secretsClientInterface is the subset of the Secrets Manager API a secret's
+// provisioner uses. *secretsmanager.Client satisfies it.
+type secretsClientInterface interface {
+	secretValueGetter
+	secretReplicator
+	secretRestorer
+	secretDeleter
+}
+
+// secretDeleter deletes a secret with the Secrets Manager API.
+type secretDeleter interface {
+	DeleteSecret(ctx context.Context, params *secretsmanager.DeleteSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DeleteSecretOutput, error)
+}
+
+func (s *Secret) getSecretsClient(ctx context.Context) (secretsClientInterface, error) {
+	awsCfg, err := s.cfg.ToAwsConfig(ctx)
+	if err != nil {
+		plugin.LoggerFromContext(ctx).Error("SecretsManager: Failed to create AWS config", "error", err)
+		return nil, err
+	}
+	return secretsmanager.NewFromConfig(awsCfg), nil
+}
+
+// Read enhances Cloud Control read with actual secret value. A read that
+// redacts sensitive values reports only the SecretStringDigest of it, so
+// drift on the value is still detected without the plaintext being returned.
 func (s *Secret) Read(ctx context.Context, request *resource.ReadRequest) (*resource.ReadResult, error) {
 	ccxClient, err := ccx.NewClient(s.cfg)
 	if err != nil {
@@ -47,24 +96,23 @@ func (s *Secret) Read(ctx context.Context, request *resource.ReadRequest) (*reso
 		return nil, err
 	}
 
-	result, err := ccxClient.ReadResource(ctx, request)
+	secretsClient, err := s.getSecretsClient(ctx)
 	if err != nil {
-		plugin.LoggerFromContext(ctx).Error("SecretsManager: Cloud Control ReadResource failed", "error", err)
 		return nil, err
 	}
 
-	// Don't bother enriching with secret value when RedactSensitive is set
-	if request.RedactSensitive {
-		return result, nil
-	}
+	return s.readWithClients(ctx, ccxClient, secretsClient, request)
+}
 
-	awsCfg, err := s.cfg.ToAwsConfig(ctx)
+func (s *Secret) readWithClients(ctx context.Context, ccxClient secretCCXReader, secretsClient secretValueGetter, request *resource.ReadRequest) (*resource.ReadResult, error) {
+	result, err := ccxClient.ReadResource(ctx, request)
 	if err != nil {
-		plugin.LoggerFromContext(ctx).Error("SecretsManager: Failed to create AWS config", "error", err)
+		plugin.LoggerFromContext(ctx).Error("SecretsManager: Cloud Control ReadResource failed", "error", err)
 		return nil, err
 	}
-
-	secretsClient := secretsmanager.NewFromConfig(awsCfg)
+	if result.ErrorCode != "" {
+		return result, nil
+	}
 
 	secret, err := secretsClient.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
 		SecretId: &request.NativeID,
@@ -88,9 +136,17 @@ func (s *Secret) Read(ctx context.Context, request *resource.ReadRequest) (*reso
 	}
 
 	if secret.SecretString != nil {
-		props["SecretString"] = *secret.SecretString
+		digest, err := secretStringDigest(*secret.SecretString, priorDigestSalt(request.PriorProperties))
+		if err != nil {
+			plugin.LoggerFromContext(ctx).Error("SecretsManager: Failed to digest secret value", "error", err)
+			return nil, err
+		}
+		props[secretStringDigestProperty] = digest
+		if !request.RedactSensitive {
+			props["SecretString"] = *secret.SecretString
+		}
 	}
-	if secret.SecretBinary != nil {
+	if secret.SecretBinary != nil && !request.RedactSensitive {
 		props["SecretBinary"] = secret.SecretBinary
 	}
 
@@ -104,44 +160,222 @@ func (s *Secret) Read(ctx context.Context, request *resource.ReadRequest) (*reso
 	return result, nil
 }
 
+// Create creates the secret through CloudControl. When the target sets
+// RestoreDeletedSecrets, a secret of the same name that is scheduled for
+// deletion is restored and updated to the declared state instead, since
+// Secrets Manager won't create one under that name until it is gone.
 func (s *Secret) Create(ctx context.Context, request *resource.CreateRequest) (*resource.CreateResult, error) {
 	ccxClient, err := ccx.NewClient(s.cfg)
 	if err != nil {
 		plugin.LoggerFromContext(ctx).Error("SecretsManager: Create failed to create ccx client", "error", err)
 		return nil, err
 	}
+	secretsClient, err := s.getSecretsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createWithClients(ctx, ccxClient, secretsClient, request)
+}
+
+func (s *Secret) createWithClients(ctx context.Context, ccxClient secretCCXClient, secretsClient secretsClientInterface, request *resource.CreateRequest) (*resource.CreateResult, error) {
+	var err error
+	if request.Properties, err = withoutDigest(request.Properties); err != nil {
+		return nil, err
+	}
 
+	if s.cfg.RestoreDeletedSecrets {
+		result, restored, err := s.restoreDeleted(ctx, ccxClient, secretsClient, request)
+		if err != nil {
+			return nil, err
+		}
+		if restored {
+			return result, nil
+		}
+	}
 	return ccxClient.CreateResource(ctx, request)
 }
 
+// Update applies changes to ReplicaRegions with the Secrets Manager API and
+// the rest of the update through CloudControl. An update that only changes
+// replicas never reaches CloudControl; Status follows it by its RequestID.
 func (s *Secret) Update(ctx context.Context, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
 	ccxClient, err := ccx.NewClient(s.cfg)
 	if err != nil {
 		plugin.LoggerFromContext(ctx).Error("SecretsManager: Update failed to create ccx client", "error", err)
 		return nil, err
 	}
+	secretsClient, err := s.getSecretsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updateWithClients(ctx, ccxClient, secretsClient, request)
+}
 
+func (s *Secret) updateWithClients(ctx context.Context, ccxClient secretCCXClient, secretsClient secretReplicator, request *resource.UpdateRequest) (*resource.UpdateResult, error) {
+	if err := stripDigestFromUpdate(request); err != nil {
+		return nil, err
+	}
+
+	desired, changed, err := desiredReplicas(request)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		prior, _, err := replicasOf(request.PriorProperties)
+		if err != nil {
+			return nil, err
+		}
+		if err := updateReplicas(ctx, secretsClient, request.NativeID, prior, desired); err != nil {
+			return nil, err
+		}
+	}
+
+	remaining, err := withoutReplicas(request)
+	if err != nil {
+		return nil, err
+	}
+	if !remaining {
+		return &resource.UpdateResult{
+			ProgressResult: &resource.ProgressResult{
+				Operation:       resource.OperationUpdate,
+				OperationStatus: resource.OperationStatusInProgress,
+				RequestID:       replicasRequestID(request.NativeID),
+				NativeID:        request.NativeID,
+			},
+		}, nil
+	}
 	return ccxClient.UpdateResource(ctx, request)
 }
 
+// Delete deletes the secret through CloudControl, unless the target sets
+// SecretRecoveryWindowInDays or ForceDeleteSecretsWithoutRecovery, in which
+// case it is deleted with the Secrets Manager API accordingly.
 func (s *Secret) Delete(ctx context.Context, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
-	ccxClient, err := ccx.NewClient(s.cfg)
+	if s.cfg.SecretRecoveryWindowInDays == 0 && !s.cfg.ForceDeleteSecretsWithoutRecovery {
+		ccxClient, err := ccx.NewClient(s.cfg)
+		if err != nil {
+			plugin.LoggerFromContext(ctx).Error("SecretsManager: Delete failed to create ccx client", "error", err)
+			return nil, err
+		}
+
+		return ccxClient.DeleteResource(ctx, request)
+	}
+
+	secretsClient, err := s.getSecretsClient(ctx)
 	if err != nil {
-		plugin.LoggerFromContext(ctx).Error("SecretsManager: Delete failed to create ccx client", "error", err)
 		return nil, err
 	}
+	return s.deleteWithClient(ctx, secretsClient, request)
+}
+
+func (s *Secret) deleteWithClient(ctx context.Context, client secretDeleter, request *resource.DeleteRequest) (*resource.DeleteResult, error) {
+	input := &secretsmanager.DeleteSecretInput{SecretId: aws.String(request.NativeID)}
+	if s.cfg.ForceDeleteSecretsWithoutRecovery {
+		input.ForceDeleteWithoutRecovery = aws.Bool(true)
+	} else {
+		input.RecoveryWindowInDays = aws.Int64(int64(s.cfg.SecretRecoveryWindowInDays))
+	}
+
+	if _, err := client.DeleteSecret(ctx, input); err != nil {
+		var notFound *smtypes.ResourceNotFoundException
+		if !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("deleting secret %s: %w", request.NativeID, err)
+		}
+	}
 
-	return ccxClient.DeleteResource(ctx, request)
+	return &resource.DeleteResult{
+		ProgressResult: &resource.ProgressResult{
+			Operation:       resource.OperationDelete,
+			OperationStatus: resource.OperationStatusSuccess,
+			NativeID:        request.NativeID,
+		},
+	}, nil
 }
 
+// Status reports a create or update done only once every replica of the
+// secret is in sync, and failed if replication to any region failed.
 func (s *Secret) Status(ctx context.Context, request *resource.StatusRequest) (*resource.StatusResult, error) {
 	ccxClient, err := ccx.NewClient(s.cfg)
 	if err != nil {
 		plugin.LoggerFromContext(ctx).Error("SecretsManager: Status failed to create ccx client", "error", err)
 		return nil, err
 	}
+	secretsClient, err := s.getSecretsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.statusWithClients(ctx, ccxClient, secretsClient, request)
+}
+
+func (s *Secret) statusWithClients(ctx context.Context, ccxClient secretCCXClient, secretsClient secretsClientInterface, request *resource.StatusRequest) (*resource.StatusResult, error) {
+	read := func(ctx context.Context, readRequest *resource.ReadRequest) (*resource.ReadResult, error) {
+		return s.readWithClients(ctx, ccxClient, secretsClient, readRequest)
+	}
+
+	if strings.HasPrefix(request.RequestID, restoredRequestIDPrefix) {
+		inner := *request
+		inner.RequestID = strings.TrimPrefix(request.RequestID, restoredRequestIDPrefix)
+		result, err := s.statusWithClients(ctx, ccxClient, secretsClient, &inner)
+		if err != nil {
+			return nil, err
+		}
+		if result.ProgressResult != nil {
+			result.ProgressResult.Operation = resource.OperationCreate
+			result.ProgressResult.RequestID = request.RequestID
+		}
+		return result, nil
+	}
 
-	return ccxClient.StatusResource(ctx, request, s.Read)
+	var pr *resource.ProgressResult
+	if strings.HasPrefix(request.RequestID, replicasRequestIDPrefix) {
+		pr = &resource.ProgressResult{
+			Operation:       resource.OperationUpdate,
+			OperationStatus: resource.OperationStatusSuccess,
+			RequestID:       request.RequestID,
+			NativeID:        strings.TrimPrefix(request.RequestID, replicasRequestIDPrefix),
+		}
+	} else {
+		result, err := ccxClient.StatusResource(ctx, request, read)
+		if err != nil {
+			return nil, err
+		}
+		pr = result.ProgressResult
+		if pr == nil || pr.OperationStatus != resource.OperationStatusSuccess ||
+			(pr.Operation != resource.OperationCreate && pr.Operation != resource.OperationUpdate) {
+			return result, nil
+		}
+	}
+
+	if pr.NativeID == "" {
+		pr.NativeID = request.NativeID
+	}
+	status, message, err := replicationStatus(ctx, secretsClient, pr.NativeID)
+	if err != nil {
+		return nil, err
+	}
+	switch status {
+	case resource.OperationStatusSuccess:
+		if pr.ResourceProperties == nil {
+			readResult, err := read(ctx, &resource.ReadRequest{NativeID: pr.NativeID, ResourceType: request.ResourceType})
+			if err != nil {
+				return nil, err
+			}
+			pr.ResourceProperties = json.RawMessage(readResult.Properties)
+		}
+	case resource.OperationStatusFailure:
+		pr.OperationStatus = status
+		pr.ErrorCode = resource.OperationErrorCodeGeneralServiceException
+		pr.StatusMessage = message
+		pr.ResourceProperties = nil
+	default:
+		pr.OperationStatus = status
+		pr.StatusMessage = message
+		pr.ResourceProperties = nil
+	}
+	return &resource.StatusResult{ProgressResult: pr}, nil
 }
 
 func (s *Secret) List(ctx context.Context, request *resource.ListRequest) (*resource.ListResult, error) {
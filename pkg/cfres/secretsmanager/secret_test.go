@@ -0,0 +1,146 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package secretsmanager
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ptr"
+)
+
+const secretARN = "arn:aws:secretsmanager:us-east-1:123456789012:secret:db-AbCdEf"
+
+func readSecret(t *testing.T, value string, request *resource.ReadRequest) map[string]any {
+	t.Helper()
+	ctx := context.Background()
+	ccxClient := &mockSecretCCXClient{}
+	ccxClient.On("ReadResource", ctx, request).Return(&resource.ReadResult{
+		ResourceType: "AWS::SecretsManager::Secret",
+		Properties:   `{"Id":"` + secretARN + `","Name":"db"}`,
+	}, nil)
+	secretsClient := &mockSecretsClient{}
+	secretsClient.On("GetSecretValue", ctx, mock.Anything).Return(&secretsmanager.GetSecretValueOutput{
+		SecretString: aws.String(value),
+	}, nil)
+
+	result, err := (&Secret{cfg: &config.Config{}}).readWithClients(ctx, ccxClient, secretsClient, request)
+	require.NoError(t, err)
+
+	var props map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Properties), &props))
+	return props
+}
+
+func TestSecret_Read_RedactedReportsOnlyDigest(t *testing.T) {
+	props := readSecret(t, "hunter2", &resource.ReadRequest{NativeID: secretARN, RedactSensitive: true})
+
+	assert.NotContains(t, props, "SecretString")
+	digest, _ := props[secretStringDigestProperty].(string)
+	assert.Regexp(t, `^hmac-sha256:[A-Za-z0-9+/]+:[0-9a-f]{64}$`, digest)
+	assert.NotContains(t, digest, "hunter2")
+}
+
+func TestSecret_Read_ReusesPriorSalt(t *testing.T) {
+	first := readSecret(t, "hunter2", &resource.ReadRequest{NativeID: secretARN, RedactSensitive: true})
+	prior, _ := json.Marshal(first)
+
+	unchanged := readSecret(t, "hunter2", &resource.ReadRequest{NativeID: secretARN, RedactSensitive: true, PriorProperties: prior})
+	assert.Equal(t, first[secretStringDigestProperty], unchanged[secretStringDigestProperty])
+
+	changed := readSecret(t, "rotated", &resource.ReadRequest{NativeID: secretARN, RedactSensitive: true, PriorProperties: prior})
+	assert.NotEqual(t, first[secretStringDigestProperty], changed[secretStringDigestProperty],
+		"a value changed out of band reads back as drift")
+}
+
+func TestSecret_Read_UnredactedKeepsValue(t *testing.T) {
+	props := readSecret(t, "hunter2", &resource.ReadRequest{NativeID: secretARN})
+
+	assert.Equal(t, "hunter2", props["SecretString"])
+	assert.Contains(t, props, secretStringDigestProperty)
+}
+
+func TestSecretStringDigest_SaltedPerSecret(t *testing.T) {
+	a, err := secretStringDigest("hunter2", nil)
+	require.NoError(t, err)
+	b, err := secretStringDigest("hunter2", nil)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, a, b)
+}
+
+func TestPriorDigestSalt_Unusable(t *testing.T) {
+	assert.Nil(t, priorDigestSalt(nil))
+	assert.Nil(t, priorDigestSalt(json.RawMessage(`{"Name":"db"}`)))
+	assert.Nil(t, priorDigestSalt(json.RawMessage(`{"SecretStringDigest":"sha1:abc"}`)))
+}
+
+func TestStripDigestFromUpdate(t *testing.T) {
+	request := &resource.UpdateRequest{
+		PriorProperties:   json.RawMessage(`{"Name":"db","SecretStringDigest":"hmac-sha256:c2FsdA:00"}`),
+		DesiredProperties: json.RawMessage(`{"Name":"db","Description":"d"}`),
+		PatchDocument: ptr.Of(`[{"op":"add","path":"/Description","value":"d"},` +
+			`{"op":"remove","path":"/SecretStringDigest"}]`),
+	}
+
+	require.NoError(t, stripDigestFromUpdate(request))
+
+	assert.JSONEq(t, `{"Name":"db"}`, string(request.PriorProperties))
+	assert.JSONEq(t, `{"Name":"db","Description":"d"}`, string(request.DesiredProperties))
+	assert.JSONEq(t, `[{"op":"add","path":"/Description","value":"d"}]`, *request.PatchDocument)
+}
+
+func TestSecret_Delete_DeletionBehavior(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        *config.Config
+		wantWindow *int64
+		wantForce  *bool
+	}{
+		{"recovery window", &config.Config{SecretRecoveryWindowInDays: 7}, aws.Int64(7), nil},
+		{"force", &config.Config{ForceDeleteSecretsWithoutRecovery: true}, nil, aws.Bool(true)},
+		{"force wins", &config.Config{SecretRecoveryWindowInDays: 7, ForceDeleteSecretsWithoutRecovery: true}, nil, aws.Bool(true)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &mockSecretsClient{}
+			client.On("DeleteSecret", ctx, &secretsmanager.DeleteSecretInput{
+				SecretId:                   aws.String(secretARN),
+				RecoveryWindowInDays:       tt.wantWindow,
+				ForceDeleteWithoutRecovery: tt.wantForce,
+			}).Return(&secretsmanager.DeleteSecretOutput{}, nil)
+
+			result, err := (&Secret{cfg: tt.cfg}).deleteWithClient(ctx, client, &resource.DeleteRequest{NativeID: secretARN})
+
+			require.NoError(t, err)
+			assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSecret_Delete_AlreadyGone(t *testing.T) {
+	ctx := context.Background()
+	client := &mockSecretsClient{}
+	client.On("DeleteSecret", ctx, mock.Anything).Return(nil, &smtypes.ResourceNotFoundException{Message: aws.String("gone")})
+
+	result, err := (&Secret{cfg: &config.Config{ForceDeleteSecretsWithoutRecovery: true}}).deleteWithClient(ctx, client, &resource.DeleteRequest{NativeID: secretARN})
+
+	require.NoError(t, err)
+	assert.Equal(t, resource.OperationStatusSuccess, result.ProgressResult.OperationStatus)
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/platform-engineering-labs/formae/pkg/plugin"
 	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	awsarn "github.com/platform-engineering-labs/formae-plugin-aws/pkg/arn"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ccx"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
@@ -327,7 +328,8 @@ func (e *EmailIdentity) reconcileTags(
 	if err != nil {
 		return fmt.Errorf("ses: resolve account for tag ARN: %w", err)
 	}
-	arn := fmt.Sprintf("arn:aws:ses:%s:%s:identity/%s", e.cfg.Region, aws.ToString(caller.Account), identity)
+	arn := fmt.Sprintf("arn:%s:ses:%s:%s:identity/%s",
+		awsarn.PartitionFrom(aws.ToString(caller.Arn)), e.cfg.Region, aws.ToString(caller.Account), identity)
 
 	if len(upsert) > 0 {
 		if _, err := sesClient.TagResource(ctx, &sesv2.TagResourceInput{ResourceArn: &arn, Tags: upsert}); err != nil {
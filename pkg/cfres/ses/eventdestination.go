@@ -19,6 +19,7 @@ import (
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/prov"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/cfres/registry"
 	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/helper"
 )
 
 // EventDestination is the AWS::SES::ConfigurationSetEventDestination provisioner.
@@ -527,36 +528,36 @@ func (e *EventDestination) List(ctx context.Context, request *resource.ListReque
 		return nil, fmt.Errorf("ses eventdestination List: build SES client: %w", err)
 	}
 
-	var composites []string
-	var nextToken *string
-	for {
-		csOut, err := sesClient.ListConfigurationSets(ctx, &sesv2.ListConfigurationSetsInput{
-			NextToken: nextToken,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("ses eventdestination List: ListConfigurationSets: %w", err)
-		}
-		for _, csName := range csOut.ConfigurationSets {
-			cs := csName
-			edOut, err := sesClient.GetConfigurationSetEventDestinations(ctx, &sesv2.GetConfigurationSetEventDestinationsInput{
-				ConfigurationSetName: &cs,
+	composites, err := helper.Paginate(ctx, helper.DefaultPageInterval,
+		func(ctx context.Context, token *string) ([]string, *string, error) {
+			csOut, err := sesClient.ListConfigurationSets(ctx, &sesv2.ListConfigurationSetsInput{
+				NextToken: token,
 			})
 			if err != nil {
-				// Skip CSes we can't read (e.g. just deleted, transient
-				// AccessDenied) rather than fail the whole discovery scan.
-				continue
+				return nil, nil, fmt.Errorf("ses eventdestination List: ListConfigurationSets: %w", err)
 			}
-			for _, ed := range edOut.EventDestinations {
-				if ed.Name == nil || *ed.Name == "" {
+			var composites []string
+			for _, csName := range csOut.ConfigurationSets {
+				cs := csName
+				edOut, err := sesClient.GetConfigurationSetEventDestinations(ctx, &sesv2.GetConfigurationSetEventDestinationsInput{
+					ConfigurationSetName: &cs,
+				})
+				if err != nil {
+					// Skip CSes we can't read (e.g. just deleted, transient
+					// AccessDenied) rather than fail the whole discovery scan.
 					continue
 				}
-				composites = append(composites, cs+"|"+*ed.Name)
+				for _, ed := range edOut.EventDestinations {
+					if ed.Name == nil || *ed.Name == "" {
+						continue
+					}
+					composites = append(composites, cs+"|"+*ed.Name)
+				}
 			}
-		}
-		if csOut.NextToken == nil || *csOut.NextToken == "" {
-			break
-		}
-		nextToken = csOut.NextToken
+			return composites, csOut.NextToken, nil
+		})
+	if err != nil {
+		return nil, err
 	}
 	return &resource.ListResult{NativeIDs: composites}, nil
 }
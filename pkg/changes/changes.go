@@ -0,0 +1,227 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+// Package changes reports resources changed outside formae. An EventBridge
+// rule with EventPattern forwards every mutating CloudTrail event to an SQS
+// queue; Client drains the queue and maps each event to the resource it
+// touched, so the operator can refresh or drift-check just those resources
+// instead of rescanning the account.
+package changes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/arn"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/tagging"
+)
+
+// EventPattern is the pattern of the EventBridge rule that feeds the change
+// queue: successful, mutating API calls recorded by CloudTrail.
+const EventPattern = `{"detail-type":["AWS API Call via CloudTrail"],"detail":{"readOnly":[false],"errorCode":[{"exists":false}]}}`
+
+// cloudControlPrincipal is the invokedBy of the calls CloudControl makes to
+// other services on behalf of its callers, including this plugin.
+const cloudControlPrincipal = "cloudcontrolapi.amazonaws.com"
+
+const (
+	// maxMessages is SQS's upper bound for MaxNumberOfMessages.
+	maxMessages = 10
+	// waitTimeSeconds long-polls the queue so an idle Poll costs one call.
+	waitTimeSeconds = 20
+)
+
+// Change is an API call that modified a resource outside formae.
+type Change struct {
+	ResourceType string
+	NativeID     string
+	EventName    string
+	EventSource  string
+	// Principal is the ARN of the identity that made the call.
+	Principal string
+	Time      time.Time
+}
+
+// requestParameters maps the request parameters that name a resource in the
+// calls of common services to the resource's type, for events that carry no
+// resource ARNs. Their values are CloudControl identifiers.
+var requestParameters = map[string]string{
+	"bucketName":   "AWS::S3::Bucket",
+	"groupId":      "AWS::EC2::SecurityGroup",
+	"vpcId":        "AWS::EC2::VPC",
+	"subnetId":     "AWS::EC2::Subnet",
+	"routeTableId": "AWS::EC2::RouteTable",
+	"roleName":     "AWS::IAM::Role",
+	"tableName":    "AWS::DynamoDB::Table",
+	"queueUrl":     "AWS::SQS::Queue",
+	"functionName": "AWS::Lambda::Function",
+}
+
+// event is the subset of an EventBridge CloudTrail event used by Client.
+type event struct {
+	Time   time.Time `json:"time"`
+	Detail struct {
+		EventName    string `json:"eventName"`
+		EventSource  string `json:"eventSource"`
+		UserIdentity struct {
+			Arn       string `json:"arn"`
+			InvokedBy string `json:"invokedBy"`
+		} `json:"userIdentity"`
+		RequestParameters map[string]any `json:"requestParameters"`
+		Resources         []struct {
+			ARN string `json:"ARN"`
+		} `json:"resources"`
+	} `json:"detail"`
+}
+
+// sqsAPI defines the SQS operations used by Client.
+type sqsAPI interface {
+	ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)
+	DeleteMessageBatch(ctx context.Context, params *sqs.DeleteMessageBatchInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error)
+}
+
+type Client struct {
+	api      sqsAPI
+	queueURL string
+}
+
+var clientPool config.Pool[*Client]
+
+// NewClient returns the Client for cfg, pooled by target config like
+// ccx.NewClient. cfg must set ChangeQueueUrl.
+func NewClient(cfg *config.Config) (*Client, error) {
+	if cfg.ChangeQueueUrl == "" {
+		return nil, fmt.Errorf("the target has no ChangeQueueUrl to watch")
+	}
+	return clientPool.Get(cfg, func(cfg *config.Config) (*Client, error) {
+		awsCfg, err := cfg.ToAwsConfig(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("loading AWS config: %w", err)
+		}
+		return &Client{api: sqs.NewFromConfig(awsCfg), queueURL: cfg.ChangeQueueUrl}, nil
+	})
+}
+
+// Poll receives one batch of events from the change queue, waiting for up to
+// 20 seconds when it is empty, and returns the out-of-band changes among
+// them, one per resource with the latest change winning. Calls made by
+// formae, under its role session or through CloudControl, are skipped, as are
+// events naming no resource the plugin can identify. Received messages are
+// deleted once read.
+func (c *Client) Poll(ctx context.Context) ([]Change, error) {
+	out, err := c.api.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(c.queueURL),
+		MaxNumberOfMessages: maxMessages,
+		WaitTimeSeconds:     waitTimeSeconds,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive from change queue: %w", err)
+	}
+	if len(out.Messages) == 0 {
+		return nil, nil
+	}
+
+	latest := map[string]Change{}
+	entries := make([]sqstypes.DeleteMessageBatchRequestEntry, 0, len(out.Messages))
+	for i, msg := range out.Messages {
+		entries = append(entries, sqstypes.DeleteMessageBatchRequestEntry{
+			Id:            aws.String(fmt.Sprint(i)),
+			ReceiptHandle: msg.ReceiptHandle,
+		})
+		var e event
+		if err := json.Unmarshal([]byte(aws.ToString(msg.Body)), &e); err != nil {
+			// Not an EventBridge event; nothing to report.
+			continue
+		}
+		for _, change := range changesOf(&e) {
+			key := change.ResourceType + "|" + change.NativeID
+			if prev, ok := latest[key]; !ok || change.Time.After(prev.Time) {
+				latest[key] = change
+			}
+		}
+	}
+
+	if _, err := c.api.DeleteMessageBatch(ctx, &sqs.DeleteMessageBatchInput{
+		QueueUrl: aws.String(c.queueURL),
+		Entries:  entries,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to delete from change queue: %w", err)
+	}
+
+	changes := make([]Change, 0, len(latest))
+	for _, change := range latest {
+		changes = append(changes, change)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if !changes[i].Time.Equal(changes[j].Time) {
+			return changes[i].Time.Before(changes[j].Time)
+		}
+		return changes[i].ResourceType+changes[i].NativeID < changes[j].ResourceType+changes[j].NativeID
+	})
+	return changes, nil
+}
+
+// changesOf maps an event to the resources it changed.
+func changesOf(e *event) []Change {
+	if byFormae(e.Detail.UserIdentity.Arn, e.Detail.UserIdentity.InvokedBy) {
+		return nil
+	}
+	change := func(resourceType, nativeID string) Change {
+		return Change{
+			ResourceType: resourceType,
+			NativeID:     nativeID,
+			EventName:    e.Detail.EventName,
+			EventSource:  e.Detail.EventSource,
+			Principal:    e.Detail.UserIdentity.Arn,
+			Time:         e.Time,
+		}
+	}
+
+	var changes []Change
+	for _, r := range e.Detail.Resources {
+		resourceType, ok := tagging.TypeForArn(r.ARN)
+		if !ok {
+			continue
+		}
+		if nativeID, ok := tagging.IdentifierFromArn(resourceType, r.ARN); ok {
+			changes = append(changes, change(resourceType, nativeID))
+		}
+	}
+	if len(changes) > 0 {
+		return changes
+	}
+
+	for param, resourceType := range requestParameters {
+		value, ok := e.Detail.RequestParameters[param].(string)
+		if !ok || value == "" {
+			continue
+		}
+		// Lambda accepts a function ARN wherever it takes a name.
+		if arn.IsArn(value) {
+			if value, ok = tagging.IdentifierFromArn(resourceType, value); !ok {
+				continue
+			}
+		}
+		changes = append(changes, change(resourceType, value))
+	}
+	return changes
+}
+
+// byFormae reports whether a call was made by the plugin: under its
+// AssumeRole session, or by CloudControl on behalf of a caller.
+func byFormae(principal, invokedBy string) bool {
+	if invokedBy == cloudControlPrincipal {
+		return true
+	}
+	return strings.Contains(principal, ":assumed-role/") && strings.HasSuffix(principal, "/"+config.RoleSessionName)
+}
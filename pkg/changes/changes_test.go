@@ -0,0 +1,100 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package changes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSQSClient struct {
+	mock.Mock
+}
+
+func (m *mockSQSClient) ReceiveMessage(ctx context.Context, input *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sqs.ReceiveMessageOutput), args.Error(1)
+}
+
+func (m *mockSQSClient) DeleteMessageBatch(ctx context.Context, input *sqs.DeleteMessageBatchInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sqs.DeleteMessageBatchOutput), args.Error(1)
+}
+
+func message(handle, body string) sqstypes.Message {
+	return sqstypes.Message{ReceiptHandle: aws.String(handle), Body: aws.String(body)}
+}
+
+func TestPoll(t *testing.T) {
+	api := &mockSQSClient{}
+	api.On("ReceiveMessage", mock.Anything, mock.Anything).Return(&sqs.ReceiveMessageOutput{
+		Messages: []sqstypes.Message{
+			// A console change to a security group.
+			message("h1", `{"time":"2026-01-02T10:00:00Z","detail":{"eventName":"AuthorizeSecurityGroupIngress","eventSource":"ec2.amazonaws.com",
+				"userIdentity":{"arn":"arn:aws:sts::111122223333:assumed-role/Admin/alice"},"requestParameters":{"groupId":"sg-1"}}}`),
+			// A later change to the same group wins.
+			message("h2", `{"time":"2026-01-02T10:05:00Z","detail":{"eventName":"RevokeSecurityGroupIngress","eventSource":"ec2.amazonaws.com",
+				"userIdentity":{"arn":"arn:aws:sts::111122223333:assumed-role/Admin/alice"},"requestParameters":{"groupId":"sg-1"}}}`),
+			// Resolved from the event's resource ARNs.
+			message("h3", `{"time":"2026-01-02T09:00:00Z","detail":{"eventName":"UpdateFunctionConfiguration","eventSource":"lambda.amazonaws.com",
+				"userIdentity":{"arn":"arn:aws:iam::111122223333:user/bob"},
+				"resources":[{"ARN":"arn:aws:lambda:us-east-1:111122223333:function:handler"}]}}`),
+			// Made by formae itself.
+			message("h4", `{"time":"2026-01-02T10:00:00Z","detail":{"eventName":"PutBucketTagging","eventSource":"s3.amazonaws.com",
+				"userIdentity":{"arn":"arn:aws:sts::111122223333:assumed-role/deployer/formae"},"requestParameters":{"bucketName":"b"}}}`),
+			message("h5", `{"time":"2026-01-02T10:00:00Z","detail":{"eventName":"CreateVpc","eventSource":"ec2.amazonaws.com",
+				"userIdentity":{"arn":"arn:aws:iam::111122223333:user/ci","invokedBy":"cloudcontrolapi.amazonaws.com"},"requestParameters":{"vpcId":"vpc-1"}}}`),
+			message("h6", `not json`),
+		},
+	}, nil)
+	api.On("DeleteMessageBatch", mock.Anything, mock.MatchedBy(func(in *sqs.DeleteMessageBatchInput) bool {
+		return aws.ToString(in.QueueUrl) == "https://sqs.us-east-1.amazonaws.com/111122223333/changes" && len(in.Entries) == 6
+	})).Return(&sqs.DeleteMessageBatchOutput{}, nil)
+
+	client := &Client{api: api, queueURL: "https://sqs.us-east-1.amazonaws.com/111122223333/changes"}
+	changes, err := client.Poll(context.Background())
+	require.NoError(t, err)
+	api.AssertExpectations(t)
+
+	require.Len(t, changes, 2)
+	assert.Equal(t, Change{
+		ResourceType: "AWS::Lambda::Function",
+		NativeID:     "handler",
+		EventName:    "UpdateFunctionConfiguration",
+		EventSource:  "lambda.amazonaws.com",
+		Principal:    "arn:aws:iam::111122223333:user/bob",
+		Time:         time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC),
+	}, changes[0])
+	assert.Equal(t, "AWS::EC2::SecurityGroup", changes[1].ResourceType)
+	assert.Equal(t, "sg-1", changes[1].NativeID)
+	assert.Equal(t, "RevokeSecurityGroupIngress", changes[1].EventName)
+}
+
+func TestPoll_Empty(t *testing.T) {
+	api := &mockSQSClient{}
+	api.On("ReceiveMessage", mock.Anything, mock.Anything).Return(&sqs.ReceiveMessageOutput{}, nil)
+
+	client := &Client{api: api, queueURL: "q"}
+	changes, err := client.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	api.AssertNotCalled(t, "DeleteMessageBatch", mock.Anything, mock.Anything)
+}
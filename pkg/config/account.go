@@ -0,0 +1,80 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// accountCacheTTL bounds how long a resolved account is trusted. The
+// credentials behind a profile can be swapped for another account's while the
+// plugin runs, so the ExpectedAccountId check resolves the account again
+// rather than vouch for a stale answer indefinitely.
+const accountCacheTTL = 15 * time.Minute
+
+type accountEntry struct {
+	account string
+	expires time.Time
+}
+
+// accountCache memoizes the account resolved for a target's credentials so
+// the ExpectedAccountId check costs one STS call per target every
+// accountCacheTTL, not one per mutating operation.
+var (
+	accountCacheMu sync.Mutex
+	accountCache   = map[string]accountEntry{}
+)
+
+// identityKey identifies the set of config fields that determine which
+// principal the target's credentials resolve to.
+func (c *Config) identityKey() string {
+	return strings.Join([]string{c.Profile, c.Region, strings.Join(c.RoleChain, ","), c.RoleArn, c.ExternalId}, "|")
+}
+
+// AssertAccount verifies that the target's credentials belong to
+// ExpectedAccountId. It is a no-op when no account is expected. Callers run
+// it before mutating operations so a misconfigured profile can never
+// provision into the wrong account.
+func (c *Config) AssertAccount(ctx context.Context) error {
+	if c.ExpectedAccountId == "" {
+		return nil
+	}
+
+	key := c.identityKey()
+	accountCacheMu.Lock()
+	entry, ok := accountCache[key]
+	accountCacheMu.Unlock()
+	account := entry.account
+	if !ok || time.Now().After(entry.expires) {
+		awsCfg, err := c.ToAwsConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("loading AWS config to verify account: %w", err)
+		}
+		identity, err := callerIdentity(ctx, sts.NewFromConfig(awsCfg))
+		if err != nil {
+			return err
+		}
+		account = identity.Account
+
+		accountCacheMu.Lock()
+		accountCache[key] = accountEntry{account: account, expires: time.Now().Add(accountCacheTTL)}
+		accountCacheMu.Unlock()
+	}
+
+	return c.checkAccount(account)
+}
+
+func (c *Config) checkAccount(account string) error {
+	if account != c.ExpectedAccountId {
+		return fmt.Errorf("refusing to proceed: credentials resolve to AWS account %s but the target expects %s", account, c.ExpectedAccountId)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssertAccount_NoExpectationIsNoOp(t *testing.T) {
+	assert.NoError(t, (&Config{Region: "us-east-1"}).AssertAccount(context.Background()))
+}
+
+func TestAssertAccount_UsesCachedAccount(t *testing.T) {
+	cfg := &Config{Region: "us-east-1", Profile: "cached", ExpectedAccountId: "123456789012"}
+	accountCacheMu.Lock()
+	accountCache[cfg.identityKey()] = accountEntry{account: "123456789012", expires: time.Now().Add(time.Minute)}
+	accountCacheMu.Unlock()
+
+	assert.NoError(t, cfg.AssertAccount(context.Background()))
+}
+
+func TestAssertAccount_ResolvesExpiredAccountAgain(t *testing.T) {
+	cfg := &Config{Region: "us-east-1", Profile: "formae-test-missing-profile", ExpectedAccountId: "123456789012"}
+	accountCacheMu.Lock()
+	accountCache[cfg.identityKey()] = accountEntry{account: "123456789012", expires: time.Now().Add(-time.Minute)}
+	accountCacheMu.Unlock()
+
+	err := cfg.AssertAccount(context.Background())
+
+	assert.ErrorContains(t, err, "loading AWS config to verify account")
+}
+
+func TestAssertAccount_Mismatch(t *testing.T) {
+	cfg := &Config{Region: "us-east-1", Profile: "wrong", ExpectedAccountId: "123456789012"}
+	accountCacheMu.Lock()
+	accountCache[cfg.identityKey()] = accountEntry{account: "210987654321", expires: time.Now().Add(time.Minute)}
+	accountCacheMu.Unlock()
+
+	err := cfg.AssertAccount(context.Background())
+
+	assert.ErrorContains(t, err, "resolve to AWS account 210987654321 but the target expects 123456789012")
+}
+
+func TestIdentityKey_DistinguishesRoles(t *testing.T) {
+	base := &Config{Region: "us-east-1", Profile: "p"}
+	withRole := &Config{Region: "us-east-1", Profile: "p", RoleArn: "arn:aws:iam::123456789012:role/r"}
+
+	assert.NotEqual(t, base.identityKey(), withRole.identityKey())
+}
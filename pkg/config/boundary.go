@@ -0,0 +1,122 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// permissionsBoundaryTypes are the IAM principal types PermissionsBoundaryArn
+// is enforced on.
+var permissionsBoundaryTypes = map[string]bool{
+	"AWS::IAM::Role": true,
+	"AWS::IAM::User": true,
+}
+
+const (
+	permissionsBoundaryProperty = "PermissionsBoundary"
+	permissionsBoundaryPath     = "/" + permissionsBoundaryProperty
+	// permissionsBoundaryRule names PermissionsBoundaryArn in the
+	// PolicyViolationError a stack that conflicts with it gets.
+	permissionsBoundaryRule = "permissions-boundary"
+)
+
+// ApplyPermissionsBoundary returns the properties of an IAM role or user
+// about to be created with the target's PermissionsBoundaryArn set. A stack
+// that declares a different boundary is refused with a *PolicyViolationError
+// rather than silently overridden.
+func (c *Config) ApplyPermissionsBoundary(resourceType string, properties json.RawMessage) (json.RawMessage, error) {
+	if c.PermissionsBoundaryArn == "" || !permissionsBoundaryTypes[resourceType] {
+		return properties, nil
+	}
+	var props map[string]any
+	if len(properties) > 0 {
+		if err := json.Unmarshal(properties, &props); err != nil {
+			return nil, fmt.Errorf("parsing properties to apply the permissions boundary: %w", err)
+		}
+	}
+	if props == nil {
+		props = map[string]any{}
+	}
+	if err := c.checkPermissionsBoundary(resourceType, props[permissionsBoundaryProperty]); err != nil {
+		return nil, err
+	}
+	props[permissionsBoundaryProperty] = c.PermissionsBoundaryArn
+	return json.Marshal(props)
+}
+
+// ApplyPermissionsBoundaryToUpdate verifies that an update of an IAM role or
+// user keeps the target's PermissionsBoundaryArn: a patch or desired state
+// that removes it or sets another is refused with a *PolicyViolationError.
+// A role or user created before the boundary was enforced, whose prior state
+// doesn't carry it, has it added by the update.
+func (c *Config) ApplyPermissionsBoundaryToUpdate(resourceType string, prior, desired json.RawMessage, patchDoc *string) (json.RawMessage, *string, error) {
+	if c.PermissionsBoundaryArn == "" || !permissionsBoundaryTypes[resourceType] {
+		return desired, patchDoc, nil
+	}
+
+	if len(desired) > 0 {
+		var err error
+		if desired, err = c.ApplyPermissionsBoundary(resourceType, desired); err != nil {
+			return nil, nil, err
+		}
+	}
+	if patchDoc == nil {
+		return desired, nil, nil
+	}
+
+	var ops []map[string]any
+	if err := json.Unmarshal([]byte(*patchDoc), &ops); err != nil {
+		return nil, nil, fmt.Errorf("decoding patch document to check the permissions boundary: %w", err)
+	}
+	touched := false
+	for _, op := range ops {
+		if op["path"] != permissionsBoundaryPath {
+			continue
+		}
+		touched = true
+		if op["op"] == "remove" {
+			return nil, nil, c.permissionsBoundaryViolation(resourceType, "the update removes it")
+		}
+		if err := c.checkPermissionsBoundary(resourceType, op["value"]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var priorProps map[string]any
+	if len(prior) > 0 {
+		_ = json.Unmarshal(prior, &priorProps)
+	}
+	if !touched && priorProps[permissionsBoundaryProperty] != c.PermissionsBoundaryArn {
+		ops = append(ops, map[string]any{"op": "add", "path": permissionsBoundaryPath, "value": c.PermissionsBoundaryArn})
+		patched, err := json.Marshal(ops)
+		if err != nil {
+			return nil, nil, err
+		}
+		s := string(patched)
+		patchDoc = &s
+	}
+	return desired, patchDoc, nil
+}
+
+// checkPermissionsBoundary refuses a declared boundary other than the
+// target's. An undeclared one is fine; the target's is applied.
+func (c *Config) checkPermissionsBoundary(resourceType string, declared any) error {
+	if declared == nil || declared == "" || declared == c.PermissionsBoundaryArn {
+		return nil
+	}
+	return c.permissionsBoundaryViolation(resourceType, fmt.Sprintf("it declares %v", declared))
+}
+
+func (c *Config) permissionsBoundaryViolation(resourceType, reason string) error {
+	return &PolicyViolationError{
+		ResourceType: resourceType,
+		Violations: []PolicyViolation{{
+			Rule:    permissionsBoundaryRule,
+			Message: fmt.Sprintf("the target requires permissions boundary %s, but %s", c.PermissionsBoundaryArn, reason),
+		}},
+	}
+}
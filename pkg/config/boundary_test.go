@@ -0,0 +1,88 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ptr"
+)
+
+const testBoundary = "arn:aws:iam::123456789012:policy/platform-boundary"
+
+var boundaryTarget = &Config{PermissionsBoundaryArn: testBoundary}
+
+func TestApplyPermissionsBoundary(t *testing.T) {
+	got, err := boundaryTarget.ApplyPermissionsBoundary("AWS::IAM::Role", json.RawMessage(`{"RoleName":"app"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"RoleName":"app","PermissionsBoundary":"`+testBoundary+`"}`, string(got))
+
+	got, err = boundaryTarget.ApplyPermissionsBoundary("AWS::IAM::User",
+		json.RawMessage(`{"UserName":"u","PermissionsBoundary":"`+testBoundary+`"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"UserName":"u","PermissionsBoundary":"`+testBoundary+`"}`, string(got))
+
+	props := json.RawMessage(`{"GroupName":"g"}`)
+	got, err = boundaryTarget.ApplyPermissionsBoundary("AWS::IAM::Group", props)
+	require.NoError(t, err)
+	assert.Equal(t, props, got, "only roles and users take a boundary")
+
+	got, err = (&Config{}).ApplyPermissionsBoundary("AWS::IAM::Role", json.RawMessage(`{"RoleName":"app"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"RoleName":"app"}`, string(got))
+}
+
+func TestApplyPermissionsBoundary_RefusesAnother(t *testing.T) {
+	_, err := boundaryTarget.ApplyPermissionsBoundary("AWS::IAM::Role",
+		json.RawMessage(`{"RoleName":"app","PermissionsBoundary":"arn:aws:iam::aws:policy/AdministratorAccess"}`))
+
+	var violation *PolicyViolationError
+	require.ErrorAs(t, err, &violation)
+	assert.Equal(t, "permissions-boundary", violation.Violations[0].Rule)
+	assert.Contains(t, violation.Error(), "AdministratorAccess")
+}
+
+func TestApplyPermissionsBoundaryToUpdate(t *testing.T) {
+	prior := json.RawMessage(`{"RoleName":"app","PermissionsBoundary":"` + testBoundary + `"}`)
+
+	t.Run("keeps an untouched boundary", func(t *testing.T) {
+		patch := ptr.Of(`[{"op":"replace","path":"/Description","value":"d"}]`)
+		desired, got, err := boundaryTarget.ApplyPermissionsBoundaryToUpdate("AWS::IAM::Role", prior,
+			json.RawMessage(`{"RoleName":"app","Description":"d"}`), patch)
+		require.NoError(t, err)
+		assert.Equal(t, patch, got)
+		assert.JSONEq(t, `{"RoleName":"app","Description":"d","PermissionsBoundary":"`+testBoundary+`"}`, string(desired))
+	})
+
+	t.Run("refuses a removal", func(t *testing.T) {
+		_, _, err := boundaryTarget.ApplyPermissionsBoundaryToUpdate("AWS::IAM::Role", prior, nil,
+			ptr.Of(`[{"op":"remove","path":"/PermissionsBoundary"}]`))
+		var violation *PolicyViolationError
+		require.ErrorAs(t, err, &violation)
+		assert.Contains(t, violation.Error(), "removes it")
+	})
+
+	t.Run("refuses another boundary", func(t *testing.T) {
+		_, _, err := boundaryTarget.ApplyPermissionsBoundaryToUpdate("AWS::IAM::User", prior, nil,
+			ptr.Of(`[{"op":"replace","path":"/PermissionsBoundary","value":"arn:aws:iam::aws:policy/PowerUserAccess"}]`))
+		var violation *PolicyViolationError
+		require.ErrorAs(t, err, &violation)
+	})
+
+	t.Run("adds the boundary to a role without one", func(t *testing.T) {
+		_, got, err := boundaryTarget.ApplyPermissionsBoundaryToUpdate("AWS::IAM::Role",
+			json.RawMessage(`{"RoleName":"app"}`), nil,
+			ptr.Of(`[{"op":"replace","path":"/Description","value":"d"}]`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"op":"replace","path":"/Description","value":"d"},`+
+			`{"op":"add","path":"/PermissionsBoundary","value":"`+testBoundary+`"}]`, *got)
+	})
+}
@@ -5,18 +5,216 @@
 package config
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	pkgmodel "github.com/platform-engineering-labs/formae/pkg/model"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ratelimit"
 )
 
+// RoleSessionName is used for AssumeRole calls so CloudTrail entries
+// made on behalf of formae are easy to attribute.
+const RoleSessionName = "formae"
+
 type Config struct {
 	Region  string `json:"Region"`
 	Profile string `json:"Profile"`
+
+	// RoleArn, when set, is assumed via STS on top of the base credentials
+	// (environment, profile, instance role) before any AWS call is made.
+	RoleArn string `json:"RoleArn,omitempty"`
+	// RoleChain lists roles assumed in order before RoleArn, each hop using
+	// the credentials of the previous one (e.g. hub -> spoke -> workload).
+	RoleChain []string `json:"RoleChain,omitempty"`
+	// ExternalId is passed to AssumeRole for trust policies that require an
+	// sts:ExternalId condition (the usual pattern for third-party access).
+	ExternalId string `json:"ExternalId,omitempty"`
+	// SessionTags are attached to the assumed-role session so ABAC policies
+	// keyed on aws:PrincipalTag can be satisfied.
+	SessionTags map[string]string `json:"SessionTags,omitempty"`
+
+	// HttpProxy and HttpsProxy route plain and TLS requests through a proxy.
+	// When neither is set the standard HTTP(S)_PROXY environment applies.
+	HttpProxy  string `json:"HttpProxy,omitempty"`
+	HttpsProxy string `json:"HttpsProxy,omitempty"`
+	// CaBundlePath points to a PEM file of additional trusted CAs, needed
+	// behind TLS-intercepting proxies.
+	CaBundlePath string `json:"CaBundlePath,omitempty"`
+
+	// ExpectedAccountId, when set, must match the account the credentials
+	// resolve to before any mutating operation is allowed.
+	ExpectedAccountId string `json:"ExpectedAccountId,omitempty"`
+
+	// RetryMaxAttempts, RetryMaxBackoffSeconds and RetryMode tune the SDK
+	// retryer used for CloudControl calls. Zero values keep the defaults.
+	RetryMaxAttempts       int    `json:"RetryMaxAttempts,omitempty"`
+	RetryMaxBackoffSeconds int    `json:"RetryMaxBackoffSeconds,omitempty"`
+	RetryMode              string `json:"RetryMode,omitempty"`
+	// ThrottleCooldownSeconds is how long CloudControl calls fail fast once
+	// sustained throttling has opened the target's circuit. Zero keeps the
+	// default.
+	ThrottleCooldownSeconds int `json:"ThrottleCooldownSeconds,omitempty"`
+
+	// OperationTimeoutSeconds overrides, per resource type, the deadline for
+	// a single Create, Update, Delete or Status call (see OperationTimeout).
+	OperationTimeoutSeconds map[string]int `json:"OperationTimeoutSeconds,omitempty"`
+	// CancelRequestsAfterSeconds, when set, has Status cancel a CloudControl
+	// request still pending or in progress that long after it was accepted.
+	CancelRequestsAfterSeconds int `json:"CancelRequestsAfterSeconds,omitempty"`
+	// LogCloudControlCalls logs every CloudControl call the target makes,
+	// with its duration, AWS request ID and error classification.
+	LogCloudControlCalls bool `json:"LogCloudControlCalls,omitempty"`
+	// RateLimits overrides, per resource type, how fast the plugin calls AWS
+	// on behalf of that type, in place of the limit of the service it belongs
+	// to (see ratelimit.DefaultServiceLimits).
+	RateLimits map[string]ratelimit.Limit `json:"RateLimits,omitempty"`
+
+	// DefaultTags are merged into the tags of every taggable resource created
+	// or updated through CloudControl, so org-mandated tags don't have to be
+	// repeated in each resource definition. Tags set on the resource win.
+	DefaultTags map[string]string `json:"DefaultTags,omitempty"`
+	// TagUpdateMode selects what an Update does to the resource's tags:
+	// "replace" (the default) sets them to exactly the desired tags, "merge"
+	// keeps tags added outside formae (e.g. by cost-allocation automation)
+	// and only reconciles the keys formae manages.
+	TagUpdateMode string `json:"TagUpdateMode,omitempty"`
+
+	// Policies are guardrails evaluated against desired properties before
+	// every Create and Update (see CheckPolicies).
+	Policies []PolicyRule `json:"Policies,omitempty"`
+
+	// PermissionsBoundaryArn is the managed policy set as the permissions
+	// boundary of every IAM role and user the plugin creates, and kept on
+	// them by updates (see ApplyPermissionsBoundary).
+	PermissionsBoundaryArn string `json:"PermissionsBoundaryArn,omitempty"`
+
+	// EstimateCosts has Create look up the on-demand price of the resource
+	// types the pricing package supports and report the estimated monthly
+	// cost in the operation's status message.
+	EstimateCosts bool `json:"EstimateCosts,omitempty"`
+
+	// DiscoveryMode selects how List enumerates resources: "cloudcontrol"
+	// (the default), "tagging", which uses the Resource Groups Tagging API
+	// for the types it supports, or "config", which queries the AWS Config
+	// inventory.
+	DiscoveryMode string `json:"DiscoveryMode,omitempty"`
+	// ConfigAggregatorName names the AWS Config aggregator queried in
+	// "config" discovery mode when MemberAccountRoleArns are set, so every
+	// member account is listed with one query instead of one per account.
+	ConfigAggregatorName string `json:"ConfigAggregatorName,omitempty"`
+	// DiscoveryIncludeTags and DiscoveryExcludeTags scope every List to
+	// resources carrying all of the include tags and none of the exclude
+	// tags. Each maps a tag key to the accepted values (any value when empty).
+	DiscoveryIncludeTags map[string][]string `json:"DiscoveryIncludeTags,omitempty"`
+	DiscoveryExcludeTags map[string][]string `json:"DiscoveryExcludeTags,omitempty"`
+	// DiscoveryFilters excludes matching resources from discovery, in the
+	// same form as the plugin's built-in DiscoveryFilters (e.g. to skip
+	// Karpenter- or Batch-managed instances).
+	DiscoveryFilters []pkgmodel.MatchFilter `json:"DiscoveryFilters,omitempty"`
+	// DiscoveryResourceTypes and DiscoveryExcludeResourceTypes are the
+	// allowlist and denylist of resource types List enumerates (see
+	// Discovers).
+	DiscoveryResourceTypes        []string `json:"DiscoveryResourceTypes,omitempty"`
+	DiscoveryExcludeResourceTypes []string `json:"DiscoveryExcludeResourceTypes,omitempty"`
+	// DiscoverServiceLinkedRoles lists service-linked IAM roles, which are
+	// left out of discovery by default: the services that use them create
+	// and delete them.
+	DiscoverServiceLinkedRoles bool `json:"DiscoverServiceLinkedRoles,omitempty"`
+
+	// MemberAccountRoleArns lists roles in other accounts (typically the
+	// members of an AWS Organization) that List fans out to. Their resources
+	// are reported with account-prefixed NativeIDs (see AccountScopedID), and
+	// operations on those IDs assume the matching role.
+	MemberAccountRoleArns []string `json:"MemberAccountRoleArns,omitempty"`
+	// PeerAccountRoleArns lists roles in the accounts on the other side of
+	// VPC peering connections. Routes to a peering connection read it from
+	// the peer account with the matching role to check that it is active
+	// (see PeerAccount).
+	PeerAccountRoleArns []string `json:"PeerAccountRoleArns,omitempty"`
+
+	// ChangeQueueUrl is the SQS queue an EventBridge rule forwards mutating
+	// CloudTrail events to (see changes.EventPattern). The changes command
+	// drains it to report the resources changed outside formae.
+	ChangeQueueUrl string `json:"ChangeQueueUrl,omitempty"`
+
+	// HydrateList has List read the resources it returns ahead of the agent,
+	// with bounded concurrency, and serve the agent's follow-up reads from
+	// those results.
+	HydrateList bool `json:"HydrateList,omitempty"`
+
+	// Route53Upsert has RecordSet Create send an UPSERT instead of a CREATE,
+	// so a record left behind by an interrupted apply is taken over rather
+	// than failing the batch with InvalidChangeBatch.
+	Route53Upsert bool `json:"Route53Upsert,omitempty"`
+
+	// S3UploadPartSizeMb and S3UploadConcurrency tune the multipart uploads
+	// of S3 object bodies: the size of each part, in MiB, and how many parts
+	// are uploaded at once. Zero values keep the transfer manager's defaults.
+	S3UploadPartSizeMb  int `json:"S3UploadPartSizeMb,omitempty"`
+	S3UploadConcurrency int `json:"S3UploadConcurrency,omitempty"`
+
+	// S3DeleteAllObjectVersions deletes every version of an S3 object's key,
+	// and its delete markers, instead of adding a delete marker on a
+	// versioned bucket.
+	S3DeleteAllObjectVersions bool `json:"S3DeleteAllObjectVersions,omitempty"`
+
+	// S3EmptyBucketsOnDelete deletes every object version and delete marker
+	// in an S3 bucket before the bucket is deleted, which fails otherwise.
+	S3EmptyBucketsOnDelete bool `json:"S3EmptyBucketsOnDelete,omitempty"`
+
+	// SkipServiceLinkedRoleDeletes reports the delete of a service-linked
+	// IAM role as done, with a warning, rather than failing it. IAM only
+	// lets the service that created such a role delete it.
+	SkipServiceLinkedRoleDeletes bool `json:"SkipServiceLinkedRoleDeletes,omitempty"`
+
+	// IgnoreRoleInlinePolicies leaves inline policies out of IAM role reads,
+	// for teams that manage them as standalone AWS::IAM::RolePolicy
+	// resources rather than in the role's Policies.
+	IgnoreRoleInlinePolicies bool `json:"IgnoreRoleInlinePolicies,omitempty"`
+
+	// SecretRecoveryWindowInDays and ForceDeleteSecretsWithoutRecovery choose
+	// how Secrets Manager secrets are deleted: scheduled for deletion after
+	// that many days, or deleted at once so the name can be reused. The
+	// latter takes precedence. With neither set, secrets are deleted through
+	// CloudControl.
+	SecretRecoveryWindowInDays        int  `json:"SecretRecoveryWindowInDays,omitempty"`
+	ForceDeleteSecretsWithoutRecovery bool `json:"ForceDeleteSecretsWithoutRecovery,omitempty"`
+
+	// RestoreDeletedSecrets has creating a Secrets Manager secret whose name
+	// belongs to a secret scheduled for deletion restore that secret and
+	// update it to the declared state, rather than fail.
+	RestoreDeletedSecrets bool `json:"RestoreDeletedSecrets,omitempty"`
+
+	// ApiGatewayLambdaPermissions grants API Gateway permission to invoke the
+	// function of every Lambda integration on an AWS::ApiGateway::Method, so
+	// stacks needn't declare the AWS::Lambda::Permission themselves.
+	ApiGatewayLambdaPermissions bool `json:"ApiGatewayLambdaPermissions,omitempty"`
 }
 
+const (
+	TagUpdateModeReplace = "replace"
+	TagUpdateModeMerge   = "merge"
+)
+
+const (
+	DiscoveryModeCloudControl = "cloudcontrol"
+	DiscoveryModeTagging      = "tagging"
+	DiscoveryModeConfig       = "config"
+)
+
 func (c *Config) ToAwsConfig(ctx context.Context) (aws.Config, error) {
 	var opts []func(*awsconfig.LoadOptions) error
 
@@ -25,7 +223,152 @@ func (c *Config) ToAwsConfig(ctx context.Context) (aws.Config, error) {
 		opts = append(opts, awsconfig.WithSharedConfigProfile(c.Profile))
 	}
 
-	return awsconfig.LoadDefaultConfig(ctx, opts...)
+	httpOpts, err := c.httpOptions()
+	if err != nil {
+		return aws.Config{}, err
+	}
+	opts = append(opts, httpOpts...)
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	roles := c.roleHops()
+	for i, roleArn := range roles {
+		optFn := func(o *stscreds.AssumeRoleOptions) {
+			o.RoleSessionName = RoleSessionName
+		}
+		if i == len(roles)-1 {
+			optFn = c.assumeRoleOptions
+		}
+		// Each hop's STS client signs with the credentials of the previous
+		// hop, so the final awsCfg carries credentials for the last role.
+		awsCfg.Credentials = aws.NewCredentialsCache(
+			stscreds.NewAssumeRoleProvider(sts.NewFromConfig(awsCfg), roleArn, optFn))
+	}
+
+	// Every client built from awsCfg paces its calls against the target's
+	// per-service and per-resource-type limits.
+	awsCfg.APIOptions = append(awsCfg.APIOptions, ratelimit.ForTarget(c.Key(), c.RateLimits).AddMiddleware)
+
+	return awsCfg, nil
+}
+
+// httpOptions builds the load options for the proxy and CA bundle settings.
+// The SDK's BuildableClient is used so the custom CA bundle is applied to the
+// same transport that carries the proxy configuration.
+func (c *Config) httpOptions() ([]func(*awsconfig.LoadOptions) error, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+
+	if c.HttpProxy != "" || c.HttpsProxy != "" {
+		proxy, err := c.proxyFunc()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, awsconfig.WithHTTPClient(awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+			tr.Proxy = proxy
+		})))
+	}
+
+	if c.CaBundlePath != "" {
+		pem, err := os.ReadFile(c.CaBundlePath)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA bundle %s: %w", c.CaBundlePath, err)
+		}
+		opts = append(opts, awsconfig.WithCustomCABundle(bytes.NewReader(pem)))
+	}
+
+	return opts, nil
+}
+
+// proxyFunc returns a transport proxy selector that sends https requests
+// through HttpsProxy and http requests through HttpProxy. A scheme without a
+// configured proxy falls back to the environment.
+func (c *Config) proxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	parse := func(name, raw string) (*url.URL, error) {
+		if raw == "" {
+			return nil, nil
+		}
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid %s %q", name, raw)
+		}
+		return u, nil
+	}
+
+	httpProxy, err := parse("HttpProxy", c.HttpProxy)
+	if err != nil {
+		return nil, err
+	}
+	httpsProxy, err := parse("HttpsProxy", c.HttpsProxy)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		switch {
+		case req.URL.Scheme == "https" && httpsProxy != nil:
+			return httpsProxy, nil
+		case req.URL.Scheme == "http" && httpProxy != nil:
+			return httpProxy, nil
+		}
+		return http.ProxyFromEnvironment(req)
+	}, nil
+}
+
+// roleHops returns the roles to assume in order: the RoleChain followed by
+// RoleArn. Empty entries are skipped.
+func (c *Config) roleHops() []string {
+	var hops []string
+	for _, roleArn := range append(append([]string{}, c.RoleChain...), c.RoleArn) {
+		if roleArn != "" {
+			hops = append(hops, roleArn)
+		}
+	}
+	return hops
+}
+
+// assumeRoleOptions applies the session name, external ID and session tags
+// from the target config to an AssumeRole provider. They only apply to the
+// final hop, which is the role the trust policy of the target account gates.
+func (c *Config) assumeRoleOptions(o *stscreds.AssumeRoleOptions) {
+	o.RoleSessionName = RoleSessionName
+	if c.ExternalId != "" {
+		o.ExternalID = aws.String(c.ExternalId)
+	}
+	o.Tags = sessionTags(c.SessionTags)
+}
+
+// sessionTags converts the configured tag map into STS tags. Keys are sorted
+// so the AssumeRole request is deterministic.
+func sessionTags(tags map[string]string) []ststypes.Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]ststypes.Tag, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, ststypes.Tag{Key: aws.String(k), Value: aws.String(tags[k])})
+	}
+	return result
+}
+
+// Key returns a stable string identifying this target configuration, used to
+// share clients and caches between operations against the same target. Every
+// field participates so targets differing only in e.g. role or proxy settings
+// never share a client.
+func (c *Config) Key() string {
+	// Marshalling a struct of strings, slices and maps cannot fail, and map
+	// keys are emitted sorted, so equal configs produce equal keys.
+	b, _ := json.Marshal(c)
+	return string(b)
 }
 
 // FromTargetConfig parses the target configuration JSON into a Config struct
@@ -38,4 +381,3 @@ func FromTargetConfig(targetConfig json.RawMessage) *Config {
 
 	return config
 }
-
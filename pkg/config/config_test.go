@@ -0,0 +1,155 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package config
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ratelimit"
+)
+
+func TestFromTargetConfig_AssumeRoleFields(t *testing.T) {
+	cfg := FromTargetConfig([]byte(`{
+		"Region": "us-east-1",
+		"RoleArn": "arn:aws:iam::123456789012:role/deployer",
+		"ExternalId": "ext-123",
+		"SessionTags": {"team": "platform", "env": "prod"}
+	}`))
+
+	assert.Equal(t, "us-east-1", cfg.Region)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/deployer", cfg.RoleArn)
+	assert.Equal(t, "ext-123", cfg.ExternalId)
+	assert.Equal(t, map[string]string{"team": "platform", "env": "prod"}, cfg.SessionTags)
+}
+
+func TestAssumeRoleOptions_ExternalIdAndSortedTags(t *testing.T) {
+	cfg := &Config{
+		ExternalId:  "ext-123",
+		SessionTags: map[string]string{"team": "platform", "env": "prod"},
+	}
+
+	var o stscreds.AssumeRoleOptions
+	cfg.assumeRoleOptions(&o)
+
+	assert.Equal(t, RoleSessionName, o.RoleSessionName)
+	assert.Equal(t, "ext-123", aws.ToString(o.ExternalID))
+	if assert.Len(t, o.Tags, 2) {
+		assert.Equal(t, "env", aws.ToString(o.Tags[0].Key))
+		assert.Equal(t, "prod", aws.ToString(o.Tags[0].Value))
+		assert.Equal(t, "team", aws.ToString(o.Tags[1].Key))
+	}
+}
+
+func TestAssumeRoleOptions_NoExternalIdOrTags(t *testing.T) {
+	var o stscreds.AssumeRoleOptions
+	(&Config{}).assumeRoleOptions(&o)
+
+	assert.Nil(t, o.ExternalID)
+	assert.Nil(t, o.Tags)
+}
+
+func TestRoleHops_ChainThenRoleArn(t *testing.T) {
+	cfg := FromTargetConfig([]byte(`{
+		"RoleChain": ["arn:aws:iam::111111111111:role/hub", "", "arn:aws:iam::222222222222:role/spoke"],
+		"RoleArn": "arn:aws:iam::333333333333:role/workload"
+	}`))
+
+	assert.Equal(t, []string{
+		"arn:aws:iam::111111111111:role/hub",
+		"arn:aws:iam::222222222222:role/spoke",
+		"arn:aws:iam::333333333333:role/workload",
+	}, cfg.roleHops())
+}
+
+func TestRoleHops_ChainWithoutRoleArn(t *testing.T) {
+	cfg := &Config{RoleChain: []string{"arn:aws:iam::111111111111:role/hub"}}
+
+	assert.Equal(t, []string{"arn:aws:iam::111111111111:role/hub"}, cfg.roleHops())
+}
+
+func TestRoleHops_None(t *testing.T) {
+	assert.Empty(t, (&Config{}).roleHops())
+}
+
+func TestProxyFunc_PerScheme(t *testing.T) {
+	cfg := &Config{HttpProxy: "http://plain:3128", HttpsProxy: "http://tls:3128"}
+
+	proxy, err := cfg.proxyFunc()
+	require.NoError(t, err)
+
+	u, err := proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "cloudcontrolapi.us-east-1.amazonaws.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, "tls:3128", u.Host)
+
+	u, err = proxy(&http.Request{URL: &url.URL{Scheme: "http", Host: "example.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, "plain:3128", u.Host)
+}
+
+func TestProxyFunc_InvalidURL(t *testing.T) {
+	_, err := (&Config{HttpsProxy: "not a url"}).proxyFunc()
+
+	assert.ErrorContains(t, err, "invalid HttpsProxy")
+}
+
+func TestHTTPOptions_MissingCABundle(t *testing.T) {
+	_, err := (&Config{CaBundlePath: filepath.Join(t.TempDir(), "missing.pem")}).httpOptions()
+
+	assert.ErrorContains(t, err, "reading CA bundle")
+}
+
+func TestHTTPOptions_ProxyAndCABundle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(path, []byte("-----BEGIN CERTIFICATE-----\n"), 0o600))
+
+	opts, err := (&Config{HttpsProxy: "http://tls:3128", CaBundlePath: path}).httpOptions()
+
+	require.NoError(t, err)
+	assert.Len(t, opts, 2)
+}
+
+func TestHTTPOptions_NoneConfigured(t *testing.T) {
+	opts, err := (&Config{}).httpOptions()
+
+	require.NoError(t, err)
+	assert.Empty(t, opts)
+}
+
+func TestKey_EqualConfigsShareKey(t *testing.T) {
+	a := FromTargetConfig([]byte(`{"Region":"us-east-1","SessionTags":{"b":"2","a":"1"}}`))
+	b := FromTargetConfig([]byte(`{"SessionTags":{"a":"1","b":"2"},"Region":"us-east-1"}`))
+
+	assert.Equal(t, a.Key(), b.Key())
+}
+
+func TestKey_DiffersByRole(t *testing.T) {
+	a := &Config{Region: "us-east-1"}
+	b := &Config{Region: "us-east-1", RoleArn: "arn:aws:iam::123456789012:role/deployer"}
+
+	assert.NotEqual(t, a.Key(), b.Key())
+}
+
+func TestFromTargetConfig_RateLimits(t *testing.T) {
+	cfg := FromTargetConfig([]byte(`{
+		"Region": "us-east-1",
+		"RateLimits": {"AWS::EC2::NetworkInterface": {"RequestsPerSecond": 0.5, "Burst": 2}}
+	}`))
+
+	assert.Equal(t, map[string]ratelimit.Limit{
+		"AWS::EC2::NetworkInterface": {RequestsPerSecond: 0.5, Burst: 2},
+	}, cfg.RateLimits)
+	assert.NotEqual(t, (&Config{Region: "us-east-1"}).Key(), cfg.Key())
+}
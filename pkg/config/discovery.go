@@ -0,0 +1,28 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package config
+
+import "path"
+
+// Discovers reports whether List may enumerate resourceType on this target.
+// Entries in DiscoveryResourceTypes and DiscoveryExcludeResourceTypes are
+// type names or patterns such as "AWS::EC2::*". An empty allowlist allows
+// every type; the denylist wins over the allowlist.
+func (c *Config) Discovers(resourceType string) bool {
+	if matchesAnyType(c.DiscoveryExcludeResourceTypes, resourceType) {
+		return false
+	}
+	return len(c.DiscoveryResourceTypes) == 0 || matchesAnyType(c.DiscoveryResourceTypes, resourceType)
+}
+
+func matchesAnyType(patterns []string, resourceType string) bool {
+	for _, p := range patterns {
+		// A malformed pattern can only match literally.
+		if ok, err := path.Match(p, resourceType); ok || (err != nil && p == resourceType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscovers(t *testing.T) {
+	assert.True(t, (&Config{}).Discovers("AWS::EC2::NetworkInterface"), "no lists discover everything")
+
+	deny := &Config{DiscoveryExcludeResourceTypes: []string{"AWS::EC2::NetworkInterface", "AWS::Logs::*"}}
+	assert.False(t, deny.Discovers("AWS::EC2::NetworkInterface"))
+	assert.False(t, deny.Discovers("AWS::Logs::LogGroup"))
+	assert.True(t, deny.Discovers("AWS::EC2::VPC"))
+
+	allow := &Config{
+		DiscoveryResourceTypes:        []string{"AWS::EC2::*", "AWS::S3::Bucket"},
+		DiscoveryExcludeResourceTypes: []string{"AWS::EC2::NetworkInterface"},
+	}
+	assert.True(t, allow.Discovers("AWS::EC2::VPC"))
+	assert.True(t, allow.Discovers("AWS::S3::Bucket"))
+	assert.False(t, allow.Discovers("AWS::S3::BucketPolicy"))
+	assert.False(t, allow.Discovers("AWS::EC2::NetworkInterface"), "denylist wins")
+
+	malformed := &Config{DiscoveryExcludeResourceTypes: []string{"AWS::EC2::[VPC"}}
+	assert.False(t, malformed.Discovers("AWS::EC2::[VPC"))
+	assert.True(t, malformed.Discovers("AWS::EC2::VPC"))
+}
@@ -0,0 +1,111 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// accountIDSeparator joins a member account ID and a resource's own
+// identifier in the NativeIDs of cross-account discovery, e.g.
+// "111122223333#vpc-0abc". Account IDs are always twelve digits, which keeps
+// the prefix unambiguous.
+const accountIDSeparator = "#"
+
+// AccountScopedID prefixes nativeID with the member account it lives in.
+func AccountScopedID(accountID, nativeID string) string {
+	return accountID + accountIDSeparator + nativeID
+}
+
+// SplitAccountScopedID undoes AccountScopedID. ok is false for identifiers
+// that carry no account prefix.
+func SplitAccountScopedID(id string) (accountID, nativeID string, ok bool) {
+	accountID, nativeID, found := strings.Cut(id, accountIDSeparator)
+	if !found || !isAccountID(accountID) {
+		return "", id, false
+	}
+	return accountID, nativeID, true
+}
+
+// MemberAccounts returns the account ID of each MemberAccountRoleArns entry,
+// in order.
+func (c *Config) MemberAccounts() ([]string, error) {
+	accounts := make([]string, 0, len(c.MemberAccountRoleArns))
+	for _, roleArn := range c.MemberAccountRoleArns {
+		account, ok := accountOfRoleArn(roleArn)
+		if !ok {
+			return nil, fmt.Errorf("invalid member account role ARN %q", roleArn)
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+// MemberAccount returns the configuration for operating in a member account:
+// this target's settings with the member role assumed as the last hop, after
+// this target's own role if it has one. ExpectedAccountId is set to the member
+// account so a misrouted operation can never land in another account.
+func (c *Config) MemberAccount(accountID string) (*Config, error) {
+	for _, roleArn := range c.MemberAccountRoleArns {
+		if account, ok := accountOfRoleArn(roleArn); ok && account == accountID {
+			member := c.inAccount(roleArn, accountID)
+			// The aggregator lives in the hub account; members fall back
+			// to their own Config recorder.
+			member.ConfigAggregatorName = ""
+			return member, nil
+		}
+	}
+	return nil, fmt.Errorf("account %s is not one of the target's member accounts", accountID)
+}
+
+// PeerAccount returns the configuration for reading from a peer account, in
+// the same form as MemberAccount. ok is false when PeerAccountRoleArns has no
+// role in that account.
+func (c *Config) PeerAccount(accountID string) (peer *Config, ok bool) {
+	for _, roleArn := range c.PeerAccountRoleArns {
+		if account, ok := accountOfRoleArn(roleArn); ok && account == accountID {
+			return c.inAccount(roleArn, accountID), true
+		}
+	}
+	return nil, false
+}
+
+// inAccount returns this target's settings with roleArn, a role in
+// accountID, assumed as the last hop.
+func (c *Config) inAccount(roleArn, accountID string) *Config {
+	cfg := *c
+	cfg.RoleChain = slices.Clone(c.RoleChain)
+	if c.RoleArn != "" {
+		cfg.RoleChain = append(cfg.RoleChain, c.RoleArn)
+	}
+	cfg.RoleArn = roleArn
+	cfg.ExpectedAccountId = accountID
+	cfg.MemberAccountRoleArns = nil
+	cfg.PeerAccountRoleArns = nil
+	return &cfg
+}
+
+// accountOfRoleArn extracts the account from arn:<partition>:iam::<account>:role/<name>.
+func accountOfRoleArn(roleArn string) (string, bool) {
+	parts := strings.SplitN(roleArn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "iam" || !strings.HasPrefix(parts[5], "role/") {
+		return "", false
+	}
+	return parts[4], isAccountID(parts[4])
+}
+
+func isAccountID(s string) bool {
+	if len(s) != 12 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
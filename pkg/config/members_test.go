@@ -0,0 +1,80 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountScopedID(t *testing.T) {
+	id := AccountScopedID("111122223333", "arn:aws:s3:::bucket#1")
+	assert.Equal(t, "111122223333#arn:aws:s3:::bucket#1", id)
+
+	account, nativeID, ok := SplitAccountScopedID(id)
+	assert.True(t, ok)
+	assert.Equal(t, "111122223333", account)
+	assert.Equal(t, "arn:aws:s3:::bucket#1", nativeID)
+
+	_, nativeID, ok = SplitAccountScopedID("vpc-0abc")
+	assert.False(t, ok)
+	assert.Equal(t, "vpc-0abc", nativeID)
+
+	_, _, ok = SplitAccountScopedID("queue#1")
+	assert.False(t, ok, "prefix must be a twelve-digit account ID")
+}
+
+func TestMemberAccount(t *testing.T) {
+	cfg := &Config{
+		Region:            "us-east-1",
+		RoleChain:         []string{"arn:aws:iam::999999999999:role/hub"},
+		RoleArn:           "arn:aws:iam::000000000000:role/audit",
+		ExpectedAccountId: "000000000000",
+		MemberAccountRoleArns: []string{
+			"arn:aws:iam::111122223333:role/OrganizationAccountAccessRole",
+			"arn:aws:iam::444455556666:role/path/Discovery",
+		},
+	}
+
+	accounts, err := cfg.MemberAccounts()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"111122223333", "444455556666"}, accounts)
+
+	member, err := cfg.MemberAccount("444455556666")
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:iam::444455556666:role/path/Discovery", member.RoleArn)
+	assert.Equal(t, []string{"arn:aws:iam::999999999999:role/hub", "arn:aws:iam::000000000000:role/audit"}, member.RoleChain)
+	assert.Equal(t, "444455556666", member.ExpectedAccountId)
+	assert.Empty(t, member.MemberAccountRoleArns)
+	assert.Equal(t, []string{"arn:aws:iam::999999999999:role/hub"}, cfg.RoleChain, "target config is not modified")
+
+	_, err = cfg.MemberAccount("777788889999")
+	assert.Error(t, err)
+
+	_, err = (&Config{MemberAccountRoleArns: []string{"arn:aws:iam::123:role/x"}}).MemberAccounts()
+	assert.Error(t, err)
+}
+
+func TestPeerAccount(t *testing.T) {
+	cfg := &Config{
+		Region:              "us-east-1",
+		RoleArn:             "arn:aws:iam::999999999999:role/hub",
+		PeerAccountRoleArns: []string{"arn:aws:iam::444455556666:role/PeeringReader"},
+	}
+
+	peer, ok := cfg.PeerAccount("444455556666")
+	require.True(t, ok)
+	assert.Equal(t, "arn:aws:iam::444455556666:role/PeeringReader", peer.RoleArn)
+	assert.Equal(t, []string{"arn:aws:iam::999999999999:role/hub"}, peer.RoleChain)
+	assert.Equal(t, "444455556666", peer.ExpectedAccountId)
+	assert.Empty(t, peer.PeerAccountRoleArns)
+
+	_, ok = cfg.PeerAccount("111122223333")
+	assert.False(t, ok)
+}
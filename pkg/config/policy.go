@@ -0,0 +1,109 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	pkgmodel "github.com/platform-engineering-labs/formae/pkg/model"
+)
+
+// PolicyRule is a guardrail checked before Create and Update: a resource of
+// one of ResourceTypes whose desired properties satisfy every condition is
+// refused. Conditions use the JSONPath form of DiscoveryFilters, e.g.
+// `$.SecurityGroupIngress[?(@.CidrIp=='0.0.0.0/0' && @.FromPort<=22 && @.ToPort>=22)]`
+// with an empty PropertyValue to deny SSH open to the world.
+type PolicyRule struct {
+	Name string `json:"Name"`
+	// Message explains the violation to the user; Name is used when empty.
+	Message string `json:"Message,omitempty"`
+	// ResourceTypes are type names or patterns such as "AWS::S3::*". An
+	// empty list applies the rule to every type.
+	ResourceTypes []string                   `json:"ResourceTypes,omitempty"`
+	Conditions    []pkgmodel.FilterCondition `json:"Conditions"`
+}
+
+// PolicyViolation names a rule a resource broke.
+type PolicyViolation struct {
+	Rule    string
+	Message string
+}
+
+// PolicyViolationError is returned by CheckPolicies when desired properties
+// break one or more of the target's policies.
+type PolicyViolationError struct {
+	ResourceType string
+	Violations   []PolicyViolation
+}
+
+func (e *PolicyViolationError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, fmt.Sprintf("%s: %s", v.Rule, v.Message))
+	}
+	return fmt.Sprintf("%s violates target policy: %s", e.ResourceType, strings.Join(messages, "; "))
+}
+
+// CheckPolicies evaluates the target's Policies against the desired
+// properties of a resourceType, returning a *PolicyViolationError listing
+// every rule they break.
+func (c *Config) CheckPolicies(resourceType string, properties json.RawMessage) error {
+	if len(c.Policies) == 0 || len(properties) == 0 {
+		return nil
+	}
+
+	r := pkgmodel.Resource{Properties: properties}
+	var violations []PolicyViolation
+	for _, rule := range c.Policies {
+		if len(rule.Conditions) == 0 {
+			continue
+		}
+		if len(rule.ResourceTypes) > 0 && !matchesAnyType(rule.ResourceTypes, resourceType) {
+			continue
+		}
+		if matchesConditions(&r, rule.Conditions) {
+			message := rule.Message
+			if message == "" {
+				message = "denied"
+			}
+			violations = append(violations, PolicyViolation{Rule: rule.Name, Message: message})
+		}
+	}
+	if len(violations) > 0 {
+		return &PolicyViolationError{ResourceType: resourceType, Violations: violations}
+	}
+	return nil
+}
+
+// CheckUpdatePolicies evaluates the target's Policies against the state an
+// update leads to: desired when given, otherwise prior with patchDoc applied.
+func (c *Config) CheckUpdatePolicies(resourceType string, prior, desired json.RawMessage, patchDoc *string) error {
+	if len(c.Policies) == 0 {
+		return nil
+	}
+	if len(desired) == 0 && patchDoc != nil && len(prior) > 0 {
+		patch, err := jsonpatch.DecodePatch([]byte(*patchDoc))
+		if err != nil {
+			return fmt.Errorf("decoding patch document to check policies: %w", err)
+		}
+		if desired, err = patch.Apply(prior); err != nil {
+			return fmt.Errorf("applying patch document to check policies: %w", err)
+		}
+	}
+	return c.CheckPolicies(resourceType, desired)
+}
+
+func matchesConditions(r *pkgmodel.Resource, conditions []pkgmodel.FilterCondition) bool {
+	for _, cond := range conditions {
+		value, found := r.GetPropertyJSONPath(cond.PropertyPath)
+		if !found || (cond.PropertyValue != "" && value != cond.PropertyValue) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	pkgmodel "github.com/platform-engineering-labs/formae/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/ptr"
+)
+
+var guardrails = &Config{Policies: []PolicyRule{
+	{
+		Name:          "no-public-ssh",
+		Message:       "SSH must not be open to the internet",
+		ResourceTypes: []string{"AWS::EC2::SecurityGroup"},
+		Conditions: []pkgmodel.FilterCondition{
+			{PropertyPath: `$.SecurityGroupIngress[?(@.CidrIp=='0.0.0.0/0' && @.FromPort<=22 && @.ToPort>=22)]`},
+		},
+	},
+	{
+		Name:          "no-public-acl",
+		ResourceTypes: []string{"AWS::S3::*"},
+		Conditions: []pkgmodel.FilterCondition{
+			{PropertyPath: "$.AccessControl", PropertyValue: "PublicRead"},
+		},
+	},
+}}
+
+func TestCheckPolicies(t *testing.T) {
+	err := guardrails.CheckPolicies("AWS::EC2::SecurityGroup", json.RawMessage(
+		`{"SecurityGroupIngress":[{"CidrIp":"0.0.0.0/0","FromPort":22,"ToPort":22,"IpProtocol":"tcp"}]}`))
+
+	var violation *PolicyViolationError
+	require.ErrorAs(t, err, &violation)
+	assert.Equal(t, []PolicyViolation{{Rule: "no-public-ssh", Message: "SSH must not be open to the internet"}}, violation.Violations)
+
+	assert.NoError(t, guardrails.CheckPolicies("AWS::EC2::SecurityGroup", json.RawMessage(
+		`{"SecurityGroupIngress":[{"CidrIp":"10.0.0.0/8","FromPort":22,"ToPort":22,"IpProtocol":"tcp"}]}`)))
+	assert.NoError(t, guardrails.CheckPolicies("AWS::EC2::VPC", json.RawMessage(`{"AccessControl":"PublicRead"}`)),
+		"rules only apply to their resource types")
+	assert.ErrorAs(t, guardrails.CheckPolicies("AWS::S3::Bucket", json.RawMessage(`{"AccessControl":"PublicRead"}`)), &violation)
+	assert.Equal(t, "denied", violation.Violations[0].Message)
+}
+
+func TestCheckUpdatePolicies_AppliesPatch(t *testing.T) {
+	err := guardrails.CheckUpdatePolicies("AWS::S3::Bucket",
+		json.RawMessage(`{"BucketName":"b","AccessControl":"Private"}`), nil,
+		ptr.Of(`[{"op":"replace","path":"/AccessControl","value":"PublicRead"}]`))
+
+	var violation *PolicyViolationError
+	require.ErrorAs(t, err, &violation)
+	assert.Equal(t, "no-public-acl", violation.Violations[0].Rule)
+}
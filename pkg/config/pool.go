@@ -0,0 +1,38 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package config
+
+import "sync"
+
+// Pool holds one client per distinct target config (see Config.Key), so
+// every operation against a target shares the client and its state. The zero
+// Pool is ready to use. Failed constructions are not cached, so a transient
+// config load error is retried on the next call.
+type Pool[T any] struct {
+	mu      sync.Mutex
+	clients map[string]T
+}
+
+// Get returns the client pooled for cfg, building it with build the first
+// time cfg is seen.
+func (p *Pool[T]) Get(cfg *Config, build func(*Config) (T, error)) (T, error) {
+	key := cfg.Key()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if client, ok := p.clients[key]; ok {
+		return client, nil
+	}
+
+	client, err := build(cfg)
+	if err != nil {
+		return client, err
+	}
+	if p.clients == nil {
+		p.clients = map[string]T{}
+	}
+	p.clients[key] = client
+	return client, nil
+}
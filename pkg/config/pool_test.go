@@ -0,0 +1,49 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool_BuildsOncePerTarget(t *testing.T) {
+	var pool Pool[*string]
+	builds := 0
+	build := func(cfg *Config) (*string, error) {
+		builds++
+		region := cfg.Region
+		return &region, nil
+	}
+
+	first, err := pool.Get(&Config{Region: "us-east-1"}, build)
+	require.NoError(t, err)
+	again, err := pool.Get(&Config{Region: "us-east-1"}, build)
+	require.NoError(t, err)
+	other, err := pool.Get(&Config{Region: "eu-west-1"}, build)
+	require.NoError(t, err)
+
+	assert.Same(t, first, again)
+	assert.NotSame(t, first, other)
+	assert.Equal(t, 2, builds)
+}
+
+func TestPool_RetriesFailedBuild(t *testing.T) {
+	var pool Pool[*string]
+	cfg := &Config{Region: "us-east-1"}
+
+	_, err := pool.Get(cfg, func(*Config) (*string, error) { return nil, errors.New("no credentials") })
+	require.Error(t, err)
+
+	region := "us-east-1"
+	client, err := pool.Get(cfg, func(*Config) (*string, error) { return &region, nil })
+	require.NoError(t, err)
+	assert.Same(t, &region, client)
+}
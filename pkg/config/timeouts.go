@@ -0,0 +1,42 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package config
+
+import "time"
+
+// defaultOperationTimeout bounds a single Create, Update, Delete or Status
+// call. CloudControl operations are asynchronous, so the call itself only
+// covers submitting the request or polling it once, plus any synchronous
+// waiting a native provisioner does. Five minutes covers the ccx retry budget
+// for reads under sustained throttling (about three minutes) while making a
+// wedged call fail instead of hanging the operator.
+const defaultOperationTimeout = 5 * time.Minute
+
+// operationTimeouts raises the budget for resource types whose calls are
+// legitimately slow: native provisioners that wait synchronously for AWS to
+// settle, and services whose control planes respond slowly under load.
+var operationTimeouts = map[string]time.Duration{
+	"AWS::CloudFront::Distribution":        30 * time.Minute,
+	"AWS::CertificateManager::Certificate": 15 * time.Minute,
+	"AWS::RDS::DBCluster":                  15 * time.Minute,
+	"AWS::RDS::DBInstance":                 15 * time.Minute,
+	"AWS::CodeBuild::ImageBuild":           15 * time.Minute,
+	"AWS::ECS::Service":                    15 * time.Minute,
+	"AWS::Route53::RecordSetGroup":         10 * time.Minute,
+	"AWS::S3::Object":                      30 * time.Minute,
+}
+
+// OperationTimeout returns the per-call deadline for resourceType: the
+// target's OperationTimeoutSeconds override if set, otherwise the built-in
+// table, otherwise defaultOperationTimeout.
+func (c *Config) OperationTimeout(resourceType string) time.Duration {
+	if seconds := c.OperationTimeoutSeconds[resourceType]; seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if d, ok := operationTimeouts[resourceType]; ok {
+		return d
+	}
+	return defaultOperationTimeout
+}
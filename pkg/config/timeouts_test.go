@@ -0,0 +1,27 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationTimeout(t *testing.T) {
+	cfg := &Config{OperationTimeoutSeconds: map[string]int{
+		"AWS::CloudFront::Distribution": 3600,
+		"AWS::SQS::Queue":               30,
+	}}
+
+	assert.Equal(t, time.Hour, cfg.OperationTimeout("AWS::CloudFront::Distribution"), "override beats built-in table")
+	assert.Equal(t, 30*time.Second, cfg.OperationTimeout("AWS::SQS::Queue"), "override beats default")
+	assert.Equal(t, 15*time.Minute, cfg.OperationTimeout("AWS::RDS::DBCluster"), "built-in table")
+	assert.Equal(t, defaultOperationTimeout, cfg.OperationTimeout("AWS::S3::Bucket"))
+	assert.Equal(t, defaultOperationTimeout, (&Config{}).OperationTimeout(""))
+}
@@ -0,0 +1,101 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// regionPattern matches commercial, GovCloud, China and ISO region names
+// (us-east-1, us-gov-west-1, cn-north-1, us-isob-east-1, ...).
+var regionPattern = regexp.MustCompile(`^[a-z]{2}(-gov|-iso[a-z]?)?-[a-z]+-\d+$`)
+
+// ValidationStage identifies which step of target validation failed.
+type ValidationStage string
+
+const (
+	ValidationStageRegion      ValidationStage = "Region"
+	ValidationStageCredentials ValidationStage = "Credentials"
+	ValidationStageIdentity    ValidationStage = "Identity"
+	ValidationStageService     ValidationStage = "Service"
+)
+
+// ValidationError is returned by Validate so callers can tell a bad region
+// apart from unresolvable credentials or a failing STS call.
+type ValidationError struct {
+	Stage   ValidationStage
+	Message string
+	Err     error
+}
+
+func (e *ValidationError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("invalid AWS target (%s): %s: %v", e.Stage, e.Message, e.Err)
+	}
+	return fmt.Sprintf("invalid AWS target (%s): %s", e.Stage, e.Message)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Identity describes the principal the target's credentials resolve to.
+type Identity struct {
+	Account string
+	Arn     string
+	UserId  string
+}
+
+// stsAPI defines the STS operations used by target validation.
+type stsAPI interface {
+	GetCallerIdentity(ctx context.Context, params *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+// Validate checks that the region is well formed, that credentials resolve
+// and that sts:GetCallerIdentity succeeds with them, so misconfiguration
+// surfaces as a structured error instead of an opaque SDK failure.
+func (c *Config) Validate(ctx context.Context) (*Identity, error) {
+	if err := c.validateRegion(); err != nil {
+		return nil, err
+	}
+
+	awsCfg, err := c.ToAwsConfig(ctx)
+	if err != nil {
+		return nil, &ValidationError{Stage: ValidationStageCredentials, Message: "loading AWS config", Err: err}
+	}
+	if _, err = awsCfg.Credentials.Retrieve(ctx); err != nil {
+		return nil, &ValidationError{Stage: ValidationStageCredentials, Message: "resolving credentials", Err: err}
+	}
+
+	return callerIdentity(ctx, sts.NewFromConfig(awsCfg))
+}
+
+func (c *Config) validateRegion() error {
+	if c.Region == "" {
+		return &ValidationError{Stage: ValidationStageRegion, Message: "region is required"}
+	}
+	if !regionPattern.MatchString(c.Region) {
+		return &ValidationError{Stage: ValidationStageRegion, Message: fmt.Sprintf("%q is not a valid AWS region", c.Region)}
+	}
+	return nil
+}
+
+// callerIdentity allows for DI of the STS client for testing
+func callerIdentity(ctx context.Context, client stsAPI) (*Identity, error) {
+	out, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, &ValidationError{Stage: ValidationStageIdentity, Message: "sts:GetCallerIdentity failed", Err: err}
+	}
+	return &Identity{
+		Account: aws.ToString(out.Account),
+		Arn:     aws.ToString(out.Arn),
+		UserId:  aws.ToString(out.UserId),
+	}, nil
+}
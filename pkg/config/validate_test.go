@@ -0,0 +1,82 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStsClient struct {
+	mock.Mock
+}
+
+func (m *mockStsClient) GetCallerIdentity(ctx context.Context, input *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sts.GetCallerIdentityOutput), args.Error(1)
+}
+
+func TestValidateRegion(t *testing.T) {
+	for _, region := range []string{"us-east-1", "eu-central-2", "us-gov-west-1", "cn-north-1", "us-isob-east-1"} {
+		assert.NoError(t, (&Config{Region: region}).validateRegion(), region)
+	}
+
+	for _, region := range []string{"", "us-east", "US-EAST-1", "useast1"} {
+		err := (&Config{Region: region}).validateRegion()
+		var vErr *ValidationError
+		require.ErrorAs(t, err, &vErr, region)
+		assert.Equal(t, ValidationStageRegion, vErr.Stage)
+	}
+}
+
+func TestValidate_InvalidRegionSkipsAWS(t *testing.T) {
+	_, err := (&Config{Region: "nowhere"}).Validate(context.Background())
+
+	var vErr *ValidationError
+	require.ErrorAs(t, err, &vErr)
+	assert.Equal(t, ValidationStageRegion, vErr.Stage)
+}
+
+func TestCallerIdentity_Success(t *testing.T) {
+	ctx := context.Background()
+	client := &mockStsClient{}
+	client.On("GetCallerIdentity", ctx, mock.Anything).Return(&sts.GetCallerIdentityOutput{
+		Account: aws.String("123456789012"),
+		Arn:     aws.String("arn:aws:sts::123456789012:assumed-role/deployer/formae"),
+		UserId:  aws.String("AROAEXAMPLE:formae"),
+	}, nil)
+
+	id, err := callerIdentity(ctx, client)
+
+	require.NoError(t, err)
+	assert.Equal(t, "123456789012", id.Account)
+	assert.Equal(t, "arn:aws:sts::123456789012:assumed-role/deployer/formae", id.Arn)
+}
+
+func TestCallerIdentity_Failure(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("ExpiredToken")
+	client := &mockStsClient{}
+	client.On("GetCallerIdentity", ctx, mock.Anything).Return(nil, cause)
+
+	_, err := callerIdentity(ctx, client)
+
+	var vErr *ValidationError
+	require.ErrorAs(t, err, &vErr)
+	assert.Equal(t, ValidationStageIdentity, vErr.Stage)
+	assert.ErrorIs(t, err, cause)
+}
@@ -0,0 +1,107 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+// Package drift compares the live state of managed resources with the
+// properties formae last recorded for them and reports which fields moved.
+// The reads themselves are left to the caller, which batches them through
+// ccx.Client.ReadResources so a sweep stays within the target's rate limits.
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/props"
+)
+
+// Resource is a managed resource and the properties last recorded for it.
+type Resource struct {
+	ResourceType string
+	NativeID     string
+	Properties   json.RawMessage
+}
+
+// FieldDrift is a top-level property whose live value differs from the
+// recorded one. Actual is nil when the property is gone.
+type FieldDrift struct {
+	Field    string
+	Expected any
+	Actual   any
+}
+
+// Report is the outcome of checking one resource. A resource that no longer
+// exists is reported as Deleted; one that couldn't be read carries Err.
+type Report struct {
+	ResourceType string
+	NativeID     string
+	Deleted      bool
+	Fields       []FieldDrift
+	Err          error
+}
+
+// Drifted reports whether the resource changed or disappeared.
+func (r Report) Drifted() bool {
+	return r.Deleted || len(r.Fields) > 0
+}
+
+// IgnoredFields returns the fields that can't be compared with a read, given
+// a type's write-only properties as JSON pointers: AWS never returns them.
+// Only top-level properties are fields here; a read carries nested write-only
+// values over from the recorded properties, so those compare equal.
+func IgnoredFields(writeOnly []string) []string {
+	var fields []string
+	for _, pointer := range writeOnly {
+		field, ok := strings.CutPrefix(pointer, "/")
+		if !ok || field == "" || strings.Contains(field, "/") || slices.Contains(fields, field) {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Diff compares the recorded properties of a resource with a read of it.
+// Only fields present in expected are compared, like props.Match, so
+// properties AWS fills in with defaults aren't reported; fields in ignore are
+// skipped. Tags are compared as key/value pairs, regardless of order, and
+// policy documents canonicalized.
+func Diff(expected json.RawMessage, actual string, ignore []string) ([]FieldDrift, error) {
+	if match, err := props.Match(expected, actual); err != nil || match {
+		return nil, err
+	}
+
+	var expectedMap, actualMap map[string]any
+	if err := json.Unmarshal(expected, &expectedMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal recorded properties: %w", err)
+	}
+	if err := json.Unmarshal([]byte(actual), &actualMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal read properties: %w", err)
+	}
+
+	var drifts []FieldDrift
+	for field, want := range expectedMap {
+		if slices.Contains(ignore, field) {
+			continue
+		}
+		got, ok := actualMap[field]
+		if ok && equal(field, want, got) {
+			continue
+		}
+		drifts = append(drifts, FieldDrift{Field: field, Expected: want, Actual: got})
+	}
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].Field < drifts[j].Field })
+	return drifts, nil
+}
+
+func equal(field string, want, got any) bool {
+	if field == props.TagsField {
+		return reflect.DeepEqual(props.TagsToMap(want), props.TagsToMap(got))
+	}
+	return reflect.DeepEqual(props.CanonicalProperty(field, want), props.CanonicalProperty(field, got))
+}
@@ -0,0 +1,73 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package drift
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	expected := json.RawMessage(`{
+		"GroupDescription": "web",
+		"SecurityGroupIngress": [{"CidrIp": "10.0.0.0/8", "FromPort": 443}],
+		"Tags": [{"Key": "a", "Value": "1"}, {"Key": "b", "Value": "2"}],
+		"Password": "secret",
+		"Removed": "x"
+	}`)
+	actual := `{
+		"GroupDescription": "web",
+		"SecurityGroupIngress": [{"CidrIp": "0.0.0.0/0", "FromPort": 443}],
+		"Tags": [{"Key": "b", "Value": "2"}, {"Key": "a", "Value": "1"}],
+		"GroupId": "sg-1"
+	}`
+
+	drifts, err := Diff(expected, actual, []string{"Password"})
+	require.NoError(t, err)
+	require.Len(t, drifts, 2)
+	assert.Equal(t, "Removed", drifts[0].Field)
+	assert.Nil(t, drifts[0].Actual)
+	assert.Equal(t, "SecurityGroupIngress", drifts[1].Field)
+	assert.Equal(t, []any{map[string]any{"CidrIp": "0.0.0.0/0", "FromPort": float64(443)}}, drifts[1].Actual)
+}
+
+func TestDiff_NoDrift(t *testing.T) {
+	drifts, err := Diff(json.RawMessage(`{"BucketName":"b"}`), `{"BucketName":"b","Arn":"arn:aws:s3:::b"}`, nil)
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+}
+
+func TestDiff_PolicyDocumentReformatted(t *testing.T) {
+	expected := json.RawMessage(`{
+		"RoleName": "app",
+		"AssumeRolePolicyDocument": {"Statement": {"Effect": "Allow", "Action": "sts:AssumeRole"}}
+	}`)
+	actual := `{
+		"RoleName": "app-2",
+		"AssumeRolePolicyDocument": {"Statement": [{"Action": ["sts:AssumeRole"], "Effect": "Allow"}]}
+	}`
+
+	drifts, err := Diff(expected, actual, nil)
+	require.NoError(t, err)
+	require.Len(t, drifts, 1)
+	assert.Equal(t, "RoleName", drifts[0].Field)
+}
+
+func TestIgnoredFields(t *testing.T) {
+	fields := IgnoredFields([]string{"/MasterUserPassword", "/LoginProfile/Password", "/AdminPassword", "/MasterUserPassword"})
+
+	assert.Equal(t, []string{"AdminPassword", "MasterUserPassword"}, fields)
+}
+
+func TestReport_Drifted(t *testing.T) {
+	assert.False(t, Report{}.Drifted())
+	assert.True(t, Report{Deleted: true}.Drifted())
+	assert.True(t, Report{Fields: []FieldDrift{{Field: "Tags"}}}.Drifted())
+}
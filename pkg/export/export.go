@@ -0,0 +1,205 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+// Package export renders discovered resources for other infrastructure-as-code
+// tools, so teams moving between tools can reuse the plugin's discovery:
+// Terraform import blocks for the awscc provider, which addresses resources by
+// their CloudControl identifier like this plugin does, and a CloudFormation
+// template suitable for a resource import.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+const (
+	FormatTerraform      = "terraform"
+	FormatCloudFormation = "cloudformation"
+)
+
+// Resource is a discovered resource to export. Properties should already be
+// a desired state (see ccx.Client.NormalizeForImport).
+type Resource struct {
+	ResourceType string
+	NativeID     string
+	Label        string
+	Properties   json.RawMessage
+}
+
+// Render exports resources in format.
+func Render(format string, resources []Resource) (string, error) {
+	switch format {
+	case FormatTerraform:
+		return TerraformImports(resources), nil
+	case FormatCloudFormation:
+		return CloudFormationTemplate(resources)
+	default:
+		return "", fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// TerraformImports renders one import block per resource, addressed as an
+// awscc resource named after its label. A resource discovered in a member
+// account, whose NativeID carries the account prefix, is imported by its
+// CloudControl identifier through the awscc provider aliased after that
+// account (see TerraformProviderAlias), which the configuration has to define.
+func TerraformImports(resources []Resource) string {
+	var b strings.Builder
+	names := uniqueNames{}
+	for i, r := range resources {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		typ := TerraformType(r.ResourceType)
+		name := names.take(typ, terraformName(r.Label))
+		account, id, scoped := config.SplitAccountScopedID(r.NativeID)
+		if scoped {
+			alias := TerraformProviderAlias(account)
+			fmt.Fprintf(&b, "# Member account %s, imported through the awscc provider aliased %s.\n", account, alias)
+			fmt.Fprintf(&b, "import {\n  to = %s.%s\n  provider = awscc.%s\n  id = %s\n}\n", typ, name, alias, strconv.Quote(id))
+			continue
+		}
+		fmt.Fprintf(&b, "import {\n  to = %s.%s\n  id = %s\n}\n", typ, name, strconv.Quote(id))
+	}
+	return b.String()
+}
+
+// TerraformProviderAlias returns the awscc provider alias member account
+// imports use, e.g. "account_111122223333".
+func TerraformProviderAlias(accountID string) string {
+	return "account_" + accountID
+}
+
+// TerraformType returns the awscc provider resource type for a CloudFormation
+// type, e.g. "awscc_ec2_vpc_endpoint" for AWS::EC2::VPCEndpoint.
+func TerraformType(resourceType string) string {
+	parts := strings.Split(resourceType, "::")
+	if len(parts) != 3 {
+		return snakeCase(resourceType)
+	}
+	return "awscc_" + strings.ToLower(parts[1]) + "_" + snakeCase(parts[2])
+}
+
+// snakeCase splits a CloudFormation type name into words, keeping acronyms
+// together: "VPCEndpoint" becomes "vpc_endpoint", "DBInstance" "db_instance".
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// terraformName turns a label into a Terraform identifier.
+func terraformName(label string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(label) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	name := b.String()
+	if name == "" || !unicode.IsLetter(rune(name[0])) && name[0] != '_' {
+		name = "r_" + name
+	}
+	return name
+}
+
+// template is the subset of a CloudFormation template written by
+// CloudFormationTemplate.
+type template struct {
+	AWSTemplateFormatVersion string                      `json:"AWSTemplateFormatVersion"`
+	Resources                map[string]templateResource `json:"Resources"`
+}
+
+type templateResource struct {
+	Type           string          `json:"Type"`
+	DeletionPolicy string          `json:"DeletionPolicy"`
+	Properties     json.RawMessage `json:"Properties,omitempty"`
+}
+
+// CloudFormationTemplate renders the resources as a JSON template, with
+// logical IDs derived from their labels. Every resource is retained on
+// deletion, which CloudFormation requires to import resources into a stack.
+func CloudFormationTemplate(resources []Resource) (string, error) {
+	t := template{AWSTemplateFormatVersion: "2010-09-09", Resources: map[string]templateResource{}}
+	names := uniqueNames{}
+	for _, r := range resources {
+		logicalID := names.take("", logicalID(r.Label))
+		t.Resources[logicalID] = templateResource{
+			Type:           r.ResourceType,
+			DeletionPolicy: "Retain",
+			Properties:     r.Properties,
+		}
+	}
+	out, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal template: %w", err)
+	}
+	return string(out), nil
+}
+
+// logicalID turns a label into an alphanumeric CloudFormation logical ID,
+// capitalizing each word: "web-vpc" becomes "WebVpc".
+func logicalID(label string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range label {
+		if r >= unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	id := b.String()
+	if id == "" || !unicode.IsLetter(rune(id[0])) {
+		id = "Resource" + id
+	}
+	return id
+}
+
+// uniqueNames hands out names that are unique within a scope by suffixing
+// repeats with a counter.
+type uniqueNames map[string]int
+
+func (u uniqueNames) take(scope, name string) string {
+	key := scope + "." + name
+	u[key]++
+	if n := u[key]; n > 1 {
+		return name + strconv.Itoa(n)
+	}
+	return name
+}
+
+// Sort orders resources by type and identifier so exports are stable across
+// runs.
+func Sort(resources []Resource) {
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].ResourceType != resources[j].ResourceType {
+			return resources[i].ResourceType < resources[j].ResourceType
+		}
+		return resources[i].NativeID < resources[j].NativeID
+	})
+}
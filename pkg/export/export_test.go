@@ -0,0 +1,85 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package export
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerraformType(t *testing.T) {
+	assert.Equal(t, "awscc_ec2_vpc", TerraformType("AWS::EC2::VPC"))
+	assert.Equal(t, "awscc_ec2_vpc_endpoint", TerraformType("AWS::EC2::VPCEndpoint"))
+	assert.Equal(t, "awscc_rds_db_instance", TerraformType("AWS::RDS::DBInstance"))
+	assert.Equal(t, "awscc_s3_bucket", TerraformType("AWS::S3::Bucket"))
+	assert.Equal(t, "awscc_elasticloadbalancingv2_load_balancer", TerraformType("AWS::ElasticLoadBalancingV2::LoadBalancer"))
+}
+
+func TestTerraformImports(t *testing.T) {
+	out := TerraformImports([]Resource{
+		{ResourceType: "AWS::EC2::VPC", NativeID: "vpc-1", Label: "Web VPC"},
+		{ResourceType: "AWS::EC2::VPC", NativeID: "vpc-2", Label: "web vpc"},
+		{ResourceType: "AWS::S3::Bucket", NativeID: "1-bucket", Label: "1-bucket"},
+	})
+
+	assert.Equal(t, `import {
+  to = awscc_ec2_vpc.web_vpc
+  id = "vpc-1"
+}
+
+import {
+  to = awscc_ec2_vpc.web_vpc2
+  id = "vpc-2"
+}
+
+import {
+  to = awscc_s3_bucket.r_1-bucket
+  id = "1-bucket"
+}
+`, out)
+}
+
+func TestTerraformImports_MemberAccount(t *testing.T) {
+	out := TerraformImports([]Resource{
+		{ResourceType: "AWS::EC2::VPC", NativeID: "111122223333#vpc-1", Label: "shared"},
+	})
+
+	assert.Equal(t, `# Member account 111122223333, imported through the awscc provider aliased account_111122223333.
+import {
+  to = awscc_ec2_vpc.shared
+  provider = awscc.account_111122223333
+  id = "vpc-1"
+}
+`, out)
+}
+
+func TestCloudFormationTemplate(t *testing.T) {
+	out, err := CloudFormationTemplate([]Resource{
+		{ResourceType: "AWS::S3::Bucket", NativeID: "my-bucket", Label: "my-bucket", Properties: json.RawMessage(`{"BucketName":"my-bucket"}`)},
+		{ResourceType: "AWS::S3::Bucket", NativeID: "other", Label: "my bucket", Properties: json.RawMessage(`{"BucketName":"other"}`)},
+	})
+	require.NoError(t, err)
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &parsed))
+	resources := parsed["Resources"].(map[string]any)
+	assert.Len(t, resources, 2)
+
+	bucket := resources["MyBucket"].(map[string]any)
+	assert.Equal(t, "AWS::S3::Bucket", bucket["Type"])
+	assert.Equal(t, "Retain", bucket["DeletionPolicy"])
+	assert.Equal(t, map[string]any{"BucketName": "my-bucket"}, bucket["Properties"])
+	assert.Contains(t, resources, "MyBucket2")
+}
+
+func TestRender_UnknownFormat(t *testing.T) {
+	_, err := Render("pulumi", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,47 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package helper
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultPageInterval paces successive page requests to stay within
+// CloudControl's two requests per second (see ratelimit.DefaultServiceLimits).
+const DefaultPageInterval = 500 * time.Millisecond
+
+// PageFunc fetches the page that starts at token (nil for the first page) and
+// returns its items and the token of the next page.
+type PageFunc[T any] func(ctx context.Context, token *string) (items []T, next *string, err error)
+
+// Paginate walks a paginated API until it returns no next token, waiting at
+// least interval between the start of successive page requests. A nil or
+// empty next token ends the walk. It stops at the first error, returning the
+// items gathered so far together with it.
+func Paginate[T any](ctx context.Context, interval time.Duration, page PageFunc[T]) ([]T, error) {
+	var all []T
+	var token *string
+	for {
+		started := time.Now()
+		items, next, err := page(ctx, token)
+		all = append(all, items...)
+		if err != nil {
+			return all, err
+		}
+		if next == nil || *next == "" {
+			return all, nil
+		}
+		token = next
+
+		if wait := interval - time.Since(started); wait > 0 {
+			select {
+			case <-ctx.Done():
+				return all, ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+	}
+}
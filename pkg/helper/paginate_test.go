@@ -0,0 +1,81 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package helper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pages(contents map[string][]int, order ...string) PageFunc[int] {
+	next := map[string]*string{}
+	for i := 0; i < len(order)-1; i++ {
+		n := order[i+1]
+		next[order[i]] = &n
+	}
+	return func(_ context.Context, token *string) ([]int, *string, error) {
+		key := ""
+		if token != nil {
+			key = *token
+		}
+		return contents[key], next[key], nil
+	}
+}
+
+func TestPaginate_WalksAllPages(t *testing.T) {
+	page := pages(map[string][]int{"": {1, 2}, "b": {3}, "c": {4, 5}}, "", "b", "c")
+
+	start := time.Now()
+	items, err := Paginate(context.Background(), 10*time.Millisecond, page)
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond, "two waits between three pages")
+}
+
+func TestPaginate_EmptyTokenEndsWalk(t *testing.T) {
+	calls := 0
+	items, err := Paginate(context.Background(), 0, func(_ context.Context, token *string) ([]int, *string, error) {
+		calls++
+		empty := ""
+		return []int{calls}, &empty, nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, items)
+}
+
+func TestPaginate_StopsOnError(t *testing.T) {
+	calls := 0
+	items, err := Paginate(context.Background(), 0, func(_ context.Context, token *string) ([]int, *string, error) {
+		calls++
+		if calls == 2 {
+			return nil, nil, errors.New("AccessDenied")
+		}
+		next := "next"
+		return []int{calls}, &next, nil
+	})
+
+	assert.ErrorContains(t, err, "AccessDenied")
+	assert.Equal(t, []int{1}, items)
+}
+
+func TestPaginate_HonoursCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := Paginate(ctx, time.Hour, func(_ context.Context, token *string) ([]int, *string, error) {
+		cancel()
+		next := "next"
+		return nil, &next, nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,265 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+// Package inventory implements discovery through the AWS Config inventory.
+// One advanced query returns every recorded resource of a type, and through an
+// aggregator it covers a whole organization at once, which is far faster than
+// listing each account with CloudControl. Config only knows the types it
+// records, so accounts without a recorder, or without the aggregator, fall
+// back to CloudControl (see ErrUnavailable).
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/configservice"
+	"github.com/aws/smithy-go"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/tagging"
+)
+
+// maxResultsPerPage is AWS Config's upper bound for the Limit of a query.
+const maxResultsPerPage = 100
+
+// ErrUnavailable is returned by List when the target has no Config recorder
+// running, or its aggregator doesn't exist, so the caller can list through
+// CloudControl instead.
+var ErrUnavailable = errors.New("AWS Config inventory is not available")
+
+// identifierFields maps the types listed through Config to the query field
+// holding their CloudControl primary identifier: the resource ID for most
+// types, the name for types whose Config ID is an internal one (such as an
+// IAM role's AROA ID or an RDS instance's DbiResourceId).
+var identifierFields = map[string]string{
+	"AWS::CloudFront::Distribution": "resourceId",
+	"AWS::DynamoDB::Table":          "resourceName",
+	"AWS::EC2::Instance":            "resourceId",
+	"AWS::EC2::InternetGateway":     "resourceId",
+	"AWS::EC2::NatGateway":          "resourceId",
+	"AWS::EC2::NetworkAcl":          "resourceId",
+	"AWS::EC2::NetworkInterface":    "resourceId",
+	"AWS::EC2::RouteTable":          "resourceId",
+	"AWS::EC2::SecurityGroup":       "resourceId",
+	"AWS::EC2::Subnet":              "resourceId",
+	"AWS::EC2::VPC":                 "resourceId",
+	"AWS::EC2::Volume":              "resourceId",
+	"AWS::ECR::Repository":          "resourceName",
+	"AWS::ECS::Cluster":             "resourceName",
+	"AWS::EFS::FileSystem":          "resourceId",
+	"AWS::EKS::Cluster":             "resourceName",
+	"AWS::IAM::Role":                "resourceName",
+	"AWS::IAM::User":                "resourceName",
+	"AWS::KMS::Key":                 "resourceId",
+	"AWS::Lambda::Function":         "resourceName",
+	"AWS::Logs::LogGroup":           "resourceName",
+	"AWS::RDS::DBCluster":           "resourceName",
+	"AWS::RDS::DBInstance":          "resourceName",
+	"AWS::S3::Bucket":               "resourceName",
+	"AWS::SecretsManager::Secret":   "resourceId",
+}
+
+// Supports reports whether resourceType can be discovered through Config.
+func Supports(resourceType string) bool {
+	_, ok := identifierFields[resourceType]
+	return ok
+}
+
+// configAPI defines the AWS Config operations used by Client.
+type configAPI interface {
+	SelectResourceConfig(ctx context.Context, params *configservice.SelectResourceConfigInput, optFns ...func(*configservice.Options)) (*configservice.SelectResourceConfigOutput, error)
+	SelectAggregateResourceConfig(ctx context.Context, params *configservice.SelectAggregateResourceConfigInput, optFns ...func(*configservice.Options)) (*configservice.SelectAggregateResourceConfigOutput, error)
+	DescribeConfigurationRecorderStatus(ctx context.Context, params *configservice.DescribeConfigurationRecorderStatusInput, optFns ...func(*configservice.Options)) (*configservice.DescribeConfigurationRecorderStatusOutput, error)
+	DescribeConfigurationAggregators(ctx context.Context, params *configservice.DescribeConfigurationAggregatorsInput, optFns ...func(*configservice.Options)) (*configservice.DescribeConfigurationAggregatorsOutput, error)
+}
+
+type Client struct {
+	api        configAPI
+	region     string
+	aggregator string
+
+	mu        sync.Mutex
+	available *bool
+}
+
+var clientPool config.Pool[*Client]
+
+// NewClient returns the Client for cfg, pooled by target config like
+// ccx.NewClient.
+func NewClient(cfg *config.Config) (*Client, error) {
+	return clientPool.Get(cfg, func(cfg *config.Config) (*Client, error) {
+		awsCfg, err := cfg.ToAwsConfig(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("loading AWS config: %w", err)
+		}
+		return &Client{
+			api:        configservice.NewFromConfig(awsCfg),
+			region:     cfg.Region,
+			aggregator: cfg.ConfigAggregatorName,
+		}, nil
+	})
+}
+
+// Aggregated reports whether the client queries an aggregator rather than
+// the target account's own recorder.
+func (c *Client) Aggregated() bool {
+	return c.aggregator != ""
+}
+
+// queryResult is one row of a query selecting the fields List needs.
+type queryResult struct {
+	ResourceID   string `json:"resourceId"`
+	ResourceName string `json:"resourceName"`
+	AccountID    string `json:"accountId"`
+	Tags         []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	} `json:"tags"`
+}
+
+// List returns one page of CloudControl identifiers of request.ResourceType
+// in the target's region whose tags pass filter. With accounts, the
+// aggregator is queried for those accounts and identifiers are prefixed with
+// their account (see config.AccountScopedID). It returns ErrUnavailable when
+// Config can't answer for the target.
+func (c *Client) List(ctx context.Context, request *resource.ListRequest, filter tagging.Filter, accounts []string) (*resource.ListResult, error) {
+	field, ok := identifierFields[request.ResourceType]
+	if !ok {
+		return nil, fmt.Errorf("%s cannot be discovered through AWS Config", request.ResourceType)
+	}
+	if len(accounts) > 0 && !c.Aggregated() {
+		return nil, fmt.Errorf("listing member accounts through AWS Config requires an aggregator")
+	}
+	available, err := c.isAvailable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !available {
+		return nil, ErrUnavailable
+	}
+
+	limit := request.PageSize
+	if limit <= 0 || limit > maxResultsPerPage {
+		limit = maxResultsPerPage
+	}
+	expression := query(request.ResourceType, c.region, accounts)
+
+	var rows []string
+	var next *string
+	if len(accounts) > 0 {
+		out, err := c.api.SelectAggregateResourceConfig(ctx, &configservice.SelectAggregateResourceConfigInput{
+			ConfigurationAggregatorName: aws.String(c.aggregator),
+			Expression:                  aws.String(expression),
+			Limit:                       limit,
+			NextToken:                   request.PageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query aggregator %s for %s: %w", c.aggregator, request.ResourceType, err)
+		}
+		rows, next = out.Results, out.NextToken
+	} else {
+		out, err := c.api.SelectResourceConfig(ctx, &configservice.SelectResourceConfigInput{
+			Expression: aws.String(expression),
+			Limit:      limit,
+			NextToken:  request.PageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query AWS Config for %s: %w", request.ResourceType, err)
+		}
+		rows, next = out.Results, out.NextToken
+	}
+
+	nativeIDs := []string{}
+	for _, row := range rows {
+		var r queryResult
+		if err := json.Unmarshal([]byte(row), &r); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal AWS Config result: %w", err)
+		}
+		tags := make(map[string]string, len(r.Tags))
+		for _, t := range r.Tags {
+			tags[t.Key] = t.Value
+		}
+		if !filter.Matches(tags) {
+			continue
+		}
+		id := r.ResourceID
+		if field == "resourceName" {
+			id = r.ResourceName
+		}
+		if id == "" {
+			continue
+		}
+		if len(accounts) > 0 {
+			id = config.AccountScopedID(r.AccountID, id)
+		}
+		nativeIDs = append(nativeIDs, id)
+	}
+
+	if aws.ToString(next) == "" {
+		next = nil
+	}
+	return &resource.ListResult{
+		NativeIDs:     nativeIDs,
+		NextPageToken: next,
+	}, nil
+}
+
+// query builds the advanced query selecting the resources of resourceType in
+// region, restricted to accounts when given.
+func query(resourceType, region string, accounts []string) string {
+	var b strings.Builder
+	b.WriteString("SELECT resourceId, resourceName, accountId, tags")
+	fmt.Fprintf(&b, " WHERE resourceType = '%s' AND awsRegion = '%s'", resourceType, region)
+	if len(accounts) > 0 {
+		quoted := make([]string, len(accounts))
+		for i, account := range accounts {
+			quoted[i] = "'" + account + "'"
+		}
+		fmt.Fprintf(&b, " AND accountId IN (%s)", strings.Join(quoted, ", "))
+	}
+	return b.String()
+}
+
+// isAvailable reports, once per client, whether the aggregator exists or,
+// without one, whether a configuration recorder is recording in the target's
+// account and region.
+func (c *Client) isAvailable(ctx context.Context) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.available != nil {
+		return *c.available, nil
+	}
+
+	var available bool
+	if c.Aggregated() {
+		out, err := c.api.DescribeConfigurationAggregators(ctx, &configservice.DescribeConfigurationAggregatorsInput{
+			ConfigurationAggregatorNames: []string{c.aggregator},
+		})
+		var apiErr smithy.APIError
+		switch {
+		case errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchConfigurationAggregatorException":
+		case err != nil:
+			return false, fmt.Errorf("failed to describe aggregator %s: %w", c.aggregator, err)
+		default:
+			available = len(out.ConfigurationAggregators) > 0
+		}
+	} else {
+		out, err := c.api.DescribeConfigurationRecorderStatus(ctx, &configservice.DescribeConfigurationRecorderStatusInput{})
+		if err != nil {
+			return false, fmt.Errorf("failed to describe configuration recorders: %w", err)
+		}
+		for _, status := range out.ConfigurationRecordersStatus {
+			available = available || status.Recording
+		}
+	}
+	c.available = &available
+	return available, nil
+}
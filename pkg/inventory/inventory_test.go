@@ -0,0 +1,135 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/configservice"
+	cfgtypes "github.com/aws/aws-sdk-go-v2/service/configservice/types"
+	"github.com/aws/smithy-go"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/tagging"
+)
+
+type mockConfigClient struct {
+	mock.Mock
+}
+
+func (m *mockConfigClient) SelectResourceConfig(ctx context.Context, input *configservice.SelectResourceConfigInput, optFns ...func(*configservice.Options)) (*configservice.SelectResourceConfigOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*configservice.SelectResourceConfigOutput), args.Error(1)
+}
+
+func (m *mockConfigClient) SelectAggregateResourceConfig(ctx context.Context, input *configservice.SelectAggregateResourceConfigInput, optFns ...func(*configservice.Options)) (*configservice.SelectAggregateResourceConfigOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*configservice.SelectAggregateResourceConfigOutput), args.Error(1)
+}
+
+func (m *mockConfigClient) DescribeConfigurationRecorderStatus(ctx context.Context, input *configservice.DescribeConfigurationRecorderStatusInput, optFns ...func(*configservice.Options)) (*configservice.DescribeConfigurationRecorderStatusOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*configservice.DescribeConfigurationRecorderStatusOutput), args.Error(1)
+}
+
+func (m *mockConfigClient) DescribeConfigurationAggregators(ctx context.Context, input *configservice.DescribeConfigurationAggregatorsInput, optFns ...func(*configservice.Options)) (*configservice.DescribeConfigurationAggregatorsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*configservice.DescribeConfigurationAggregatorsOutput), args.Error(1)
+}
+
+func recording(on bool) *configservice.DescribeConfigurationRecorderStatusOutput {
+	return &configservice.DescribeConfigurationRecorderStatusOutput{
+		ConfigurationRecordersStatus: []cfgtypes.ConfigurationRecorderStatus{{Recording: on}},
+	}
+}
+
+func TestList_Recorder(t *testing.T) {
+	api := &mockConfigClient{}
+	api.On("DescribeConfigurationRecorderStatus", mock.Anything, mock.Anything).Return(recording(true), nil).Once()
+	api.On("SelectResourceConfig", mock.Anything, mock.MatchedBy(func(in *configservice.SelectResourceConfigInput) bool {
+		return aws.ToString(in.Expression) == "SELECT resourceId, resourceName, accountId, tags WHERE resourceType = 'AWS::IAM::Role' AND awsRegion = 'us-east-1'"
+	})).Return(&configservice.SelectResourceConfigOutput{
+		Results: []string{
+			`{"resourceId":"AROA1","resourceName":"deployer","accountId":"111122223333","tags":[{"key":"Team","value":"payments"}]}`,
+			`{"resourceId":"AROA2","resourceName":"other","accountId":"111122223333","tags":[]}`,
+		},
+		NextToken: aws.String(""),
+	}, nil)
+
+	client := &Client{api: api, region: "us-east-1"}
+	filter := tagging.Filter{Include: map[string][]string{"Team": {"payments"}}}
+	result, err := client.List(context.Background(), &resource.ListRequest{ResourceType: "AWS::IAM::Role"}, filter, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deployer"}, result.NativeIDs)
+	assert.Nil(t, result.NextPageToken)
+
+	// Availability is only checked once per client.
+	_, err = client.List(context.Background(), &resource.ListRequest{ResourceType: "AWS::IAM::Role"}, tagging.Filter{}, nil)
+	require.NoError(t, err)
+	api.AssertExpectations(t)
+}
+
+func TestList_Aggregator(t *testing.T) {
+	api := &mockConfigClient{}
+	api.On("DescribeConfigurationAggregators", mock.Anything, mock.Anything).Return(&configservice.DescribeConfigurationAggregatorsOutput{
+		ConfigurationAggregators: []cfgtypes.ConfigurationAggregator{{ConfigurationAggregatorName: aws.String("org")}},
+	}, nil)
+	api.On("SelectAggregateResourceConfig", mock.Anything, mock.MatchedBy(func(in *configservice.SelectAggregateResourceConfigInput) bool {
+		return aws.ToString(in.ConfigurationAggregatorName) == "org" &&
+			aws.ToString(in.Expression) == "SELECT resourceId, resourceName, accountId, tags WHERE resourceType = 'AWS::EC2::VPC' AND awsRegion = 'eu-west-1' AND accountId IN ('111122223333', '444455556666')"
+	})).Return(&configservice.SelectAggregateResourceConfigOutput{
+		Results: []string{
+			`{"resourceId":"vpc-1","accountId":"111122223333"}`,
+			`{"resourceId":"vpc-2","accountId":"444455556666"}`,
+		},
+		NextToken: aws.String("next"),
+	}, nil)
+
+	client := &Client{api: api, region: "eu-west-1", aggregator: "org"}
+	result, err := client.List(context.Background(), &resource.ListRequest{ResourceType: "AWS::EC2::VPC"}, tagging.Filter{}, []string{"111122223333", "444455556666"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"111122223333#vpc-1", "444455556666#vpc-2"}, result.NativeIDs)
+	assert.Equal(t, "next", aws.ToString(result.NextPageToken))
+}
+
+func TestList_Unavailable(t *testing.T) {
+	t.Run("no recorder", func(t *testing.T) {
+		api := &mockConfigClient{}
+		api.On("DescribeConfigurationRecorderStatus", mock.Anything, mock.Anything).Return(recording(false), nil)
+
+		client := &Client{api: api, region: "us-east-1"}
+		_, err := client.List(context.Background(), &resource.ListRequest{ResourceType: "AWS::EC2::VPC"}, tagging.Filter{}, nil)
+		assert.ErrorIs(t, err, ErrUnavailable)
+	})
+
+	t.Run("no aggregator", func(t *testing.T) {
+		api := &mockConfigClient{}
+		api.On("DescribeConfigurationAggregators", mock.Anything, mock.Anything).
+			Return(nil, &smithy.GenericAPIError{Code: "NoSuchConfigurationAggregatorException"})
+
+		client := &Client{api: api, region: "us-east-1", aggregator: "org"}
+		_, err := client.List(context.Background(), &resource.ListRequest{ResourceType: "AWS::EC2::VPC"}, tagging.Filter{}, []string{"111122223333"})
+		assert.ErrorIs(t, err, ErrUnavailable)
+	})
+}
@@ -0,0 +1,226 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+// Package pricing estimates what a resource will cost before it is created,
+// from the on-demand list prices of the AWS Pricing API. Only the resource
+// types whose cost is dominated by an hourly rate are covered; storage, data
+// transfer and request charges are not included.
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awspricing "github.com/aws/aws-sdk-go-v2/service/pricing"
+	pricingtypes "github.com/aws/aws-sdk-go-v2/service/pricing/types"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+// hoursPerMonth is the average number of hours in a month, the figure AWS
+// uses in its own pricing calculator.
+const hoursPerMonth = 730
+
+// pricingRegion hosts the Pricing API endpoint. Prices for every region are
+// served from it.
+const pricingRegion = "us-east-1"
+
+// Estimate is the expected on-demand cost of a resource.
+type Estimate struct {
+	HourlyUSD  float64
+	MonthlyUSD float64
+}
+
+func (e Estimate) String() string {
+	return fmt.Sprintf("estimated cost: %.2f USD/month", e.MonthlyUSD)
+}
+
+// product selects the Pricing API service and filters that identify the price
+// of a resource from its desired properties. ok is false when the properties
+// lack what the price depends on.
+type product func(properties map[string]any) (serviceCode string, filters map[string]string, ok bool)
+
+var products = map[string]product{
+	"AWS::EC2::Instance":   ec2Instance,
+	"AWS::EC2::NatGateway": natGateway,
+	"AWS::RDS::DBInstance": rdsInstance,
+}
+
+// Supports reports whether the cost of resourceType can be estimated.
+func Supports(resourceType string) bool {
+	_, ok := products[resourceType]
+	return ok
+}
+
+func ec2Instance(properties map[string]any) (string, map[string]string, bool) {
+	instanceType, ok := properties["InstanceType"].(string)
+	if !ok {
+		return "", nil, false
+	}
+	tenancy := "Shared"
+	if properties["Tenancy"] == "dedicated" {
+		tenancy = "Dedicated"
+	}
+	return "AmazonEC2", map[string]string{
+		"instanceType":    instanceType,
+		"operatingSystem": "Linux",
+		"preInstalledSw":  "NA",
+		"tenancy":         tenancy,
+		"capacitystatus":  "Used",
+	}, true
+}
+
+func natGateway(properties map[string]any) (string, map[string]string, bool) {
+	// Private NAT gateways are charged at the same hourly rate.
+	return "AmazonEC2", map[string]string{
+		"productFamily": "NAT Gateway",
+		"group":         "NGW:NatGateway",
+	}, true
+}
+
+// rdsEngines maps CloudFormation engine names to the Pricing API's
+// databaseEngine attribute.
+var rdsEngines = map[string]string{
+	"mysql":             "MySQL",
+	"mariadb":           "MariaDB",
+	"postgres":          "PostgreSQL",
+	"oracle-ee":         "Oracle",
+	"sqlserver-ex":      "SQL Server",
+	"sqlserver-web":     "SQL Server",
+	"sqlserver-se":      "SQL Server",
+	"sqlserver-ee":      "SQL Server",
+	"aurora-mysql":      "Aurora MySQL",
+	"aurora-postgresql": "Aurora PostgreSQL",
+}
+
+func rdsInstance(properties map[string]any) (string, map[string]string, bool) {
+	class, ok := properties["DBInstanceClass"].(string)
+	if !ok {
+		return "", nil, false
+	}
+	engine, ok := properties["Engine"].(string)
+	if !ok || rdsEngines[engine] == "" {
+		return "", nil, false
+	}
+	deployment := "Single-AZ"
+	if properties["MultiAZ"] == true {
+		deployment = "Multi-AZ"
+	}
+	return "AmazonRDS", map[string]string{
+		"instanceType":     class,
+		"databaseEngine":   rdsEngines[engine],
+		"deploymentOption": deployment,
+	}, true
+}
+
+// pricingAPI defines the Pricing API operations used by Client.
+type pricingAPI interface {
+	GetProducts(ctx context.Context, params *awspricing.GetProductsInput, optFns ...func(*awspricing.Options)) (*awspricing.GetProductsOutput, error)
+}
+
+type Client struct {
+	api    pricingAPI
+	region string
+}
+
+var clientPool config.Pool[*Client]
+
+// NewClient returns the Client for cfg, pooled by target config like
+// ccx.NewClient.
+func NewClient(cfg *config.Config) (*Client, error) {
+	return clientPool.Get(cfg, func(cfg *config.Config) (*Client, error) {
+		awsCfg, err := cfg.ToAwsConfig(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("loading AWS config: %w", err)
+		}
+		return &Client{
+			api: awspricing.NewFromConfig(awsCfg, func(o *awspricing.Options) {
+				o.Region = pricingRegion
+			}),
+			region: cfg.Region,
+		}, nil
+	})
+}
+
+// Estimate returns the on-demand cost of a resourceType with the given
+// desired properties in the target's region. It returns nil when the type
+// isn't supported or the properties don't determine a price.
+func (c *Client) Estimate(ctx context.Context, resourceType string, properties json.RawMessage) (*Estimate, error) {
+	product, ok := products[resourceType]
+	if !ok {
+		return nil, nil
+	}
+	var propsMap map[string]any
+	if err := json.Unmarshal(properties, &propsMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal properties: %w", err)
+	}
+	serviceCode, attributes, ok := product(propsMap)
+	if !ok {
+		return nil, nil
+	}
+
+	filters := []pricingtypes.Filter{{
+		Type:  pricingtypes.FilterTypeTermMatch,
+		Field: aws.String("regionCode"),
+		Value: aws.String(c.region),
+	}}
+	for field, value := range attributes {
+		filters = append(filters, pricingtypes.Filter{
+			Type:  pricingtypes.FilterTypeTermMatch,
+			Field: aws.String(field),
+			Value: aws.String(value),
+		})
+	}
+
+	out, err := c.api.GetProducts(ctx, &awspricing.GetProductsInput{
+		ServiceCode: aws.String(serviceCode),
+		Filters:     filters,
+		MaxResults:  aws.Int32(1),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pricing:GetProducts failed for %s: %w", resourceType, err)
+	}
+	if len(out.PriceList) == 0 {
+		return nil, nil
+	}
+
+	hourly, err := hourlyRate(out.PriceList[0])
+	if err != nil {
+		return nil, fmt.Errorf("reading price of %s: %w", resourceType, err)
+	}
+	return &Estimate{HourlyUSD: hourly, MonthlyUSD: hourly * hoursPerMonth}, nil
+}
+
+// priceListItem is the subset of a Pricing API price list entry holding the
+// on-demand rates.
+type priceListItem struct {
+	Terms struct {
+		OnDemand map[string]struct {
+			PriceDimensions map[string]struct {
+				Unit         string            `json:"unit"`
+				PricePerUnit map[string]string `json:"pricePerUnit"`
+			} `json:"priceDimensions"`
+		} `json:"OnDemand"`
+	} `json:"terms"`
+}
+
+// hourlyRate extracts the hourly on-demand USD rate from a price list entry.
+func hourlyRate(item string) (float64, error) {
+	var parsed priceListItem
+	if err := json.Unmarshal([]byte(item), &parsed); err != nil {
+		return 0, err
+	}
+	for _, term := range parsed.Terms.OnDemand {
+		for _, dimension := range term.PriceDimensions {
+			if dimension.Unit != "Hrs" {
+				continue
+			}
+			return strconv.ParseFloat(dimension.PricePerUnit["USD"], 64)
+		}
+	}
+	return 0, fmt.Errorf("no hourly on-demand rate")
+}
@@ -0,0 +1,96 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awspricing "github.com/aws/aws-sdk-go-v2/service/pricing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPricingClient struct {
+	mock.Mock
+}
+
+func (m *mockPricingClient) GetProducts(ctx context.Context, input *awspricing.GetProductsInput, optFns ...func(*awspricing.Options)) (*awspricing.GetProductsOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*awspricing.GetProductsOutput), args.Error(1)
+}
+
+const t3MicroPrice = `{
+	"product": {"attributes": {"instanceType": "t3.micro"}},
+	"terms": {"OnDemand": {"ABC.JRTCKXETXF": {"priceDimensions": {
+		"ABC.JRTCKXETXF.6YS6EN2CT7": {"unit": "Hrs", "pricePerUnit": {"USD": "0.0104000000"}}
+	}}}}
+}`
+
+func filterValue(input *awspricing.GetProductsInput, field string) string {
+	for _, f := range input.Filters {
+		if aws.ToString(f.Field) == field {
+			return aws.ToString(f.Value)
+		}
+	}
+	return ""
+}
+
+func TestEstimate_EC2Instance(t *testing.T) {
+	api := new(mockPricingClient)
+	client := &Client{api: api, region: "eu-west-1"}
+
+	api.On("GetProducts", mock.Anything, mock.MatchedBy(func(in *awspricing.GetProductsInput) bool {
+		return aws.ToString(in.ServiceCode) == "AmazonEC2" &&
+			filterValue(in, "regionCode") == "eu-west-1" &&
+			filterValue(in, "instanceType") == "t3.micro"
+	})).Return(&awspricing.GetProductsOutput{PriceList: []string{t3MicroPrice}}, nil)
+
+	estimate, err := client.Estimate(context.Background(), "AWS::EC2::Instance", json.RawMessage(`{"InstanceType":"t3.micro"}`))
+
+	require.NoError(t, err)
+	require.NotNil(t, estimate)
+	assert.InDelta(t, 0.0104, estimate.HourlyUSD, 1e-9)
+	assert.InDelta(t, 7.592, estimate.MonthlyUSD, 1e-9)
+	assert.Equal(t, "estimated cost: 7.59 USD/month", estimate.String())
+}
+
+func TestEstimate_UndeterminedOrUnsupported(t *testing.T) {
+	client := &Client{api: new(mockPricingClient), region: "eu-west-1"}
+
+	estimate, err := client.Estimate(context.Background(), "AWS::EC2::Instance", json.RawMessage(`{"LaunchTemplate":{}}`))
+	require.NoError(t, err)
+	assert.Nil(t, estimate)
+
+	estimate, err = client.Estimate(context.Background(), "AWS::S3::Bucket", json.RawMessage(`{}`))
+	require.NoError(t, err)
+	assert.Nil(t, estimate)
+}
+
+func TestEstimate_RDSInstance(t *testing.T) {
+	api := new(mockPricingClient)
+	client := &Client{api: api, region: "us-east-1"}
+
+	api.On("GetProducts", mock.Anything, mock.MatchedBy(func(in *awspricing.GetProductsInput) bool {
+		return aws.ToString(in.ServiceCode) == "AmazonRDS" &&
+			filterValue(in, "databaseEngine") == "PostgreSQL" &&
+			filterValue(in, "deploymentOption") == "Multi-AZ"
+	})).Return(nil, errors.New("AccessDeniedException"))
+
+	_, err := client.Estimate(context.Background(), "AWS::RDS::DBInstance",
+		json.RawMessage(`{"DBInstanceClass":"db.t3.micro","Engine":"postgres","MultiAZ":true}`))
+
+	require.ErrorContains(t, err, "AccessDeniedException")
+	api.AssertExpectations(t)
+}
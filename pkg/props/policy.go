@@ -0,0 +1,171 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package props
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+)
+
+// Properties holding an IAM policy document, which AWS may return formatted,
+// ordered, or shaped differently from how it was written.
+var policyDocumentFields = map[string]bool{
+	"PolicyDocument":           true,
+	"AssumeRolePolicyDocument": true,
+}
+
+// Statement elements whose value is a string or a list of strings.
+var policyListElements = []string{"Action", "NotAction", "Resource", "NotResource"}
+
+// CanonicalPolicyDocument returns doc in a form that compares equal for
+// documents IAM treats the same: a document given as a JSON string is
+// parsed, a single Statement becomes a list, and the string-or-list values
+// of Action, Resource, Principal, and Condition entries become sorted lists
+// without duplicates. Statement order is kept. A value that isn't a policy
+// document is returned as it is.
+func CanonicalPolicyDocument(doc any) any {
+	if s, ok := doc.(string); ok {
+		var parsed any
+		if err := json.Unmarshal([]byte(s), &parsed); err != nil {
+			return doc
+		}
+		doc = parsed
+	}
+	m, ok := doc.(map[string]any)
+	if !ok {
+		return doc
+	}
+
+	canonical := make(map[string]any, len(m))
+	for k, v := range m {
+		canonical[k] = v
+	}
+	switch statements := m["Statement"].(type) {
+	case map[string]any:
+		canonical["Statement"] = []any{canonicalStatement(statements)}
+	case []any:
+		list := make([]any, len(statements))
+		for i, s := range statements {
+			if sm, ok := s.(map[string]any); ok {
+				list[i] = canonicalStatement(sm)
+			} else {
+				list[i] = s
+			}
+		}
+		canonical["Statement"] = list
+	}
+	return canonical
+}
+
+func canonicalStatement(statement map[string]any) map[string]any {
+	canonical := make(map[string]any, len(statement))
+	for k, v := range statement {
+		canonical[k] = v
+	}
+	for _, k := range policyListElements {
+		if v, ok := statement[k]; ok {
+			canonical[k] = canonicalStringList(v)
+		}
+	}
+	for _, k := range []string{"Principal", "NotPrincipal"} {
+		if principals, ok := statement[k].(map[string]any); ok {
+			canonical[k] = canonicalStringLists(principals)
+		}
+	}
+	if conditions, ok := statement["Condition"].(map[string]any); ok {
+		c := make(map[string]any, len(conditions))
+		for operator, v := range conditions {
+			if entries, ok := v.(map[string]any); ok {
+				c[operator] = canonicalStringLists(entries)
+			} else {
+				c[operator] = v
+			}
+		}
+		canonical["Condition"] = c
+	}
+	return canonical
+}
+
+func canonicalStringLists(m map[string]any) map[string]any {
+	canonical := make(map[string]any, len(m))
+	for k, v := range m {
+		canonical[k] = canonicalStringList(v)
+	}
+	return canonical
+}
+
+// canonicalStringList turns a string, or a list of strings, into a sorted
+// list without duplicates. Any other value is returned as it is.
+func canonicalStringList(v any) any {
+	var values []string
+	switch t := v.(type) {
+	case string:
+		values = []string{t}
+	case []any:
+		for _, e := range t {
+			s, ok := e.(string)
+			if !ok {
+				return v
+			}
+			values = append(values, s)
+		}
+	default:
+		return v
+	}
+	slices.Sort(values)
+	values = slices.Compact(values)
+	list := make([]any, len(values))
+	for i, s := range values {
+		list[i] = s
+	}
+	return list
+}
+
+// EquivalentPolicyDocuments reports whether a and b are the same policy
+// once canonicalized.
+func EquivalentPolicyDocuments(a, b any) bool {
+	return reflect.DeepEqual(CanonicalPolicyDocument(a), CanonicalPolicyDocument(b))
+}
+
+// PolicyDocumentAsDeclared returns the declared document when the live one
+// is equivalent to it, so a read reports the policy in the form it was
+// written and shows no drift; otherwise it returns the live document as it is.
+func PolicyDocumentAsDeclared(live, declared any) any {
+	if declared != nil && EquivalentPolicyDocuments(live, declared) {
+		return declared
+	}
+	return live
+}
+
+// CanonicalProperty canonicalizes the policy documents a property holds: a
+// policy document field, or the PolicyDocument of each entry of a list of
+// inline Policies.
+func CanonicalProperty(key string, v any) any {
+	if policyDocumentFields[key] {
+		return CanonicalPolicyDocument(v)
+	}
+	policies, ok := v.([]any)
+	if key != "Policies" || !ok {
+		return v
+	}
+	canonical := make([]any, len(policies))
+	for i, p := range policies {
+		pm, ok := p.(map[string]any)
+		if !ok {
+			canonical[i] = p
+			continue
+		}
+		c := make(map[string]any, len(pm))
+		for k, v := range pm {
+			c[k] = v
+		}
+		if doc, ok := pm["PolicyDocument"]; ok {
+			c["PolicyDocument"] = CanonicalPolicyDocument(doc)
+		}
+		canonical[i] = c
+	}
+	return canonical
+}
@@ -0,0 +1,84 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package props
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEquivalentPolicyDocuments(t *testing.T) {
+	declared := map[string]any{
+		"Version": "2012-10-17",
+		"Statement": map[string]any{
+			"Effect":    "Allow",
+			"Action":    "s3:GetObject",
+			"Resource":  []any{"arn:aws:s3:::b/*", "arn:aws:s3:::a/*"},
+			"Principal": map[string]any{"AWS": "arn:aws:iam::123456789012:root"},
+			"Condition": map[string]any{"StringEquals": map[string]any{"aws:SourceVpc": "vpc-1"}},
+		},
+	}
+	live := `{
+		"Statement": [{
+			"Condition": {"StringEquals": {"aws:SourceVpc": ["vpc-1"]}},
+			"Principal": {"AWS": ["arn:aws:iam::123456789012:root"]},
+			"Resource": ["arn:aws:s3:::a/*", "arn:aws:s3:::b/*"],
+			"Action": ["s3:GetObject"],
+			"Effect": "Allow"
+		}],
+		"Version": "2012-10-17"
+	}`
+	assert.True(t, EquivalentPolicyDocuments(live, declared))
+
+	changed := map[string]any{
+		"Version":   "2012-10-17",
+		"Statement": map[string]any{"Effect": "Allow", "Action": "s3:PutObject", "Resource": "*"},
+	}
+	assert.False(t, EquivalentPolicyDocuments(live, changed))
+}
+
+func TestCanonicalPolicyDocument_KeepsStatementOrder(t *testing.T) {
+	doc := map[string]any{"Statement": []any{
+		map[string]any{"Sid": "B", "Effect": "Deny"},
+		map[string]any{"Sid": "A", "Effect": "Allow"},
+	}}
+	canonical := CanonicalPolicyDocument(doc).(map[string]any)
+	statements := canonical["Statement"].([]any)
+	assert.Equal(t, "B", statements[0].(map[string]any)["Sid"])
+	assert.Equal(t, "not a policy", CanonicalPolicyDocument("not a policy"))
+}
+
+func TestPolicyDocumentAsDeclared(t *testing.T) {
+	declared := map[string]any{"Statement": map[string]any{"Effect": "Allow", "Action": "s3:GetObject"}}
+	live := map[string]any{"Statement": []any{map[string]any{"Effect": "Allow", "Action": []any{"s3:GetObject"}}}}
+	assert.Equal(t, declared, PolicyDocumentAsDeclared(live, declared))
+
+	drifted := map[string]any{"Statement": []any{map[string]any{"Effect": "Deny", "Action": []any{"s3:GetObject"}}}}
+	assert.Equal(t, drifted, PolicyDocumentAsDeclared(drifted, declared))
+	assert.Equal(t, live, PolicyDocumentAsDeclared(live, nil))
+}
+
+func TestMatch_CanonicalizesPolicyDocuments(t *testing.T) {
+	oaProperties := json.RawMessage(`{
+		"AssumeRolePolicyDocument": {"Statement": {"Effect": "Allow", "Action": "sts:AssumeRole"}},
+		"Policies": [{"PolicyName": "p", "PolicyDocument": {"Statement": {"Effect": "Allow", "Action": "s3:GetObject"}}}]
+	}`)
+	rProperties := `{
+		"AssumeRolePolicyDocument": {"Statement": [{"Action": ["sts:AssumeRole"], "Effect": "Allow"}]},
+		"Policies": [{"PolicyName": "p", "PolicyDocument": "{\"Statement\":[{\"Action\":[\"s3:GetObject\"],\"Effect\":\"Allow\"}]}"}]
+	}`
+
+	match, err := Match(oaProperties, rProperties)
+	assert.NoError(t, err)
+	assert.True(t, match)
+
+	match, err = Match(oaProperties, `{"AssumeRolePolicyDocument": {"Statement": [{"Action": "sts:AssumeRole", "Effect": "Deny"}]}}`)
+	assert.NoError(t, err)
+	assert.False(t, match)
+}
@@ -7,11 +7,23 @@ package props
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
+	"sort"
 )
 
 const TagsField = "Tags"
 
+// Tags formae stamps on the resources it manages.
+const (
+	ResourceLabelTag = "FormaeResourceLabel"
+	StackLabelTag    = "FormaeStackLabel"
+)
+
+// Match reports whether every property of oaProperties has the same value in
+// rProperties. Policy documents are compared canonicalized (see
+// CanonicalPolicyDocument), as AWS returns them reformatted.
 func Match(oaProperties json.RawMessage, rProperties string) (bool, error) {
 	var propsOA map[string]any
 	if err := json.Unmarshal(oaProperties, &propsOA); err != nil {
@@ -23,7 +35,7 @@ func Match(oaProperties json.RawMessage, rProperties string) (bool, error) {
 	}
 	for key, valOA := range propsOA {
 		valR, exists := propsR[key]
-		if !exists || !reflect.DeepEqual(valOA, valR) {
+		if !exists || !reflect.DeepEqual(CanonicalProperty(key, valOA), CanonicalProperty(key, valR)) {
 			return false, nil
 		}
 	}
@@ -82,3 +94,100 @@ func TransformTagsToArray(properties map[string]any) error {
 	properties["Tags"] = tagsArray
 	return nil
 }
+
+// MergeTags returns tags with every entry of defaults whose key it doesn't
+// already set. tags may be an array of {Key, Value} or a key/value map and
+// keeps its shape; when it is absent, asMap selects the shape of the result.
+// Keys already present keep the caller's value.
+func MergeTags(tags any, defaults map[string]string, asMap bool) any {
+	keys := make([]string, 0, len(defaults))
+	for k := range defaults {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	switch existing := tags.(type) {
+	case map[string]any:
+		merged := make(map[string]any, len(existing)+len(keys))
+		maps.Copy(merged, existing)
+		for _, k := range keys {
+			if _, ok := merged[k]; !ok {
+				merged[k] = defaults[k]
+			}
+		}
+		return merged
+	case []any:
+		seen := map[string]bool{}
+		for _, tag := range existing {
+			if tagMap, ok := tag.(map[string]any); ok {
+				if key, ok := tagMap["Key"].(string); ok {
+					seen[key] = true
+				}
+			}
+		}
+		merged := append(make([]any, 0, len(existing)+len(keys)), existing...)
+		for _, k := range keys {
+			if !seen[k] {
+				merged = append(merged, map[string]any{"Key": k, "Value": defaults[k]})
+			}
+		}
+		return merged
+	}
+
+	if asMap {
+		return MergeTags(map[string]any{}, defaults, true)
+	}
+	return MergeTags([]any{}, defaults, false)
+}
+
+// TagsToMap returns the key/value pairs of tags, an array of {Key, Value} or
+// a key/value map. Entries that aren't strings are skipped.
+func TagsToMap(tags any) map[string]string {
+	result := map[string]string{}
+	switch v := tags.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if strValue, ok := value.(string); ok {
+				result[key] = strValue
+			}
+		}
+	case []any:
+		for _, tag := range v {
+			if tagMap, ok := tag.(map[string]any); ok {
+				key, keyOk := tagMap["Key"].(string)
+				value, valueOk := tagMap["Value"].(string)
+				if keyOk && valueOk {
+					result[key] = value
+				}
+			}
+		}
+	}
+	return result
+}
+
+// RemoveTags returns tags, an array of {Key, Value} or a key/value map,
+// without the entries whose key is in keys.
+func RemoveTags(tags any, keys ...string) any {
+	switch v := tags.(type) {
+	case map[string]any:
+		kept := make(map[string]any, len(v))
+		for key, value := range v {
+			if !slices.Contains(keys, key) {
+				kept[key] = value
+			}
+		}
+		return kept
+	case []any:
+		kept := make([]any, 0, len(v))
+		for _, tag := range v {
+			if tagMap, ok := tag.(map[string]any); ok {
+				if key, ok := tagMap["Key"].(string); ok && slices.Contains(keys, key) {
+					continue
+				}
+			}
+			kept = append(kept, tag)
+		}
+		return kept
+	}
+	return tags
+}
@@ -27,3 +27,29 @@ func TestMatch(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, match)
 }
+
+func TestMergeTags(t *testing.T) {
+	defaults := map[string]string{"team": "platform", "env": "dev"}
+
+	merged := MergeTags([]any{map[string]any{"Key": "env", "Value": "prod"}}, defaults, false)
+	assert.Equal(t, []any{
+		map[string]any{"Key": "env", "Value": "prod"},
+		map[string]any{"Key": "team", "Value": "platform"},
+	}, merged)
+
+	merged = MergeTags(map[string]any{"env": "prod"}, defaults, false)
+	assert.Equal(t, map[string]any{"env": "prod", "team": "platform"}, merged)
+
+	assert.Equal(t, map[string]any{"env": "dev", "team": "platform"}, MergeTags(nil, defaults, true))
+	assert.Len(t, MergeTags(nil, defaults, false), 2)
+}
+
+func TestRemoveTags(t *testing.T) {
+	tags := []any{
+		map[string]any{"Key": "FormaeResourceLabel", "Value": "orders"},
+		map[string]any{"Key": "Env", "Value": "prod"},
+	}
+	assert.Equal(t, []any{map[string]any{"Key": "Env", "Value": "prod"}}, RemoveTags(tags, ResourceLabelTag, StackLabelTag))
+
+	assert.Equal(t, map[string]any{"Env": "prod"}, RemoveTags(map[string]any{"Env": "prod", "FormaeStackLabel": "shop"}, StackLabelTag))
+}
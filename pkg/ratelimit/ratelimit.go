@@ -0,0 +1,205 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+// Package ratelimit paces the plugin's own AWS calls per service and per
+// resource type. The agent enforces a single namespace-wide rate on plugin
+// operations; AWS quotas differ widely between services (Route 53 allows five
+// requests per second per account, EC2 Describe calls far more), so the
+// budget of each service is enforced here instead, on every call made with a
+// target's aws.Config.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Limit is a token bucket: RequestsPerSecond is the sustained rate and Burst
+// the number of calls that may be made at once after a quiet period. A zero
+// Burst allows one second's worth of calls.
+type Limit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// DefaultServiceLimits are keyed by the SDK service ID. Services not listed
+// are only bounded by the namespace rate the agent applies.
+var DefaultServiceLimits = map[string]Limit{
+	// CloudControl keeps the two requests per second the plugin used to
+	// declare namespace-wide.
+	"CloudControl": {RequestsPerSecond: 2, Burst: 2},
+	// Route 53 allows five requests per second per account.
+	"Route 53": {RequestsPerSecond: 5, Burst: 5},
+	// EC2 meters Describe calls with a bucket of 100 refilled at 20 per
+	// second; mutating calls have smaller buckets but are rare in comparison.
+	"EC2": {RequestsPerSecond: 20, Burst: 100},
+}
+
+// Limiter holds one token bucket per service and resource type limit.
+type Limiter struct {
+	services      map[string]Limit
+	resourceTypes map[string]Limit
+	now           func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// New returns a Limiter for the given service and resource type limits. A
+// resource type limit takes precedence over the limit of its service, for
+// calls to that service and to CloudControl (see servesType).
+func New(services, resourceTypes map[string]Limit) *Limiter {
+	return &Limiter{
+		services:      services,
+		resourceTypes: resourceTypes,
+		now:           time.Now,
+		buckets:       map[string]*bucket{},
+	}
+}
+
+var (
+	limiterPoolMu sync.Mutex
+	limiterPool   = map[string]*Limiter{}
+)
+
+// ForTarget returns the Limiter shared by all clients built for the target
+// identified by key (see config.Config.Key), so calls made by separate
+// operations against the same target draw from the same buckets. The
+// target's resourceTypes limits are applied on top of DefaultServiceLimits.
+func ForTarget(key string, resourceTypes map[string]Limit) *Limiter {
+	limiterPoolMu.Lock()
+	defer limiterPoolMu.Unlock()
+	if l, ok := limiterPool[key]; ok {
+		return l
+	}
+	l := New(DefaultServiceLimits, resourceTypes)
+	limiterPool[key] = l
+	return l
+}
+
+type resourceTypeKey struct{}
+
+// WithResourceType records the resource type an operation acts on, so calls
+// made under ctx are paced by that type's limit when one is set.
+func WithResourceType(ctx context.Context, resourceType string) context.Context {
+	return context.WithValue(ctx, resourceTypeKey{}, resourceType)
+}
+
+func resourceTypeFrom(ctx context.Context) string {
+	resourceType, _ := ctx.Value(resourceTypeKey{}).(string)
+	return resourceType
+}
+
+// Wait blocks until a call to serviceID on behalf of resourceType may proceed,
+// or ctx is done. Calls with no matching limit proceed immediately.
+func (l *Limiter) Wait(ctx context.Context, serviceID, resourceType string) error {
+	b := l.bucketFor(serviceID, resourceType)
+	if b == nil {
+		return nil
+	}
+	wait := b.reserve(l.now())
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (l *Limiter) bucketFor(serviceID, resourceType string) *bucket {
+	key := "type:" + resourceType + "|" + serviceID
+	limit, ok := l.resourceTypes[resourceType]
+	if !ok || !servesType(serviceID, resourceType) {
+		key = "service:" + serviceID
+		limit, ok = l.services[serviceID]
+	}
+	if !ok || limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = newBucket(limit, l.now())
+		l.buckets[key] = b
+	}
+	return b
+}
+
+// servesType reports whether serviceID provisions resourceType: CloudControl,
+// which provisions every type, or the service the type is named after, such as
+// "Route 53" for AWS::Route53::RecordSet. A type's limit applies only to calls
+// to those services; the other calls its operations make, such as STS or IAM
+// lookups, keep their own service's limit.
+func servesType(serviceID, resourceType string) bool {
+	if serviceID == "CloudControl" {
+		return true
+	}
+	parts := strings.Split(resourceType, "::")
+	if len(parts) != 3 {
+		return false
+	}
+	return strings.EqualFold(strings.ReplaceAll(serviceID, " ", ""), parts[1])
+}
+
+// AddMiddleware paces every attempt of a call, retries included, so it is
+// installed in the Finalize step after the SDK retryer.
+func (l *Limiter) AddMiddleware(stack *middleware.Stack) error {
+	return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc("FormaeRateLimit",
+		func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			if err := l.Wait(ctx, awsmiddleware.GetServiceID(ctx), resourceTypeFrom(ctx)); err != nil {
+				return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+			}
+			return next.HandleFinalize(ctx, in)
+		}), "Retry", middleware.After)
+}
+
+// bucket is a token bucket whose balance may go negative: each caller takes a
+// token straight away and waits until the balance it left behind has been
+// refilled, which keeps concurrent callers in arrival order without a queue.
+type bucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(limit Limit, now time.Time) *bucket {
+	burst := float64(limit.Burst)
+	if burst <= 0 {
+		burst = math.Ceil(limit.RequestsPerSecond)
+	}
+	burst = math.Max(burst, 1)
+	return &bucket{rate: limit.RequestsPerSecond, burst: burst, tokens: burst, last: now}
+}
+
+// reserve takes a token and returns how long the caller has to wait for it.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
@@ -0,0 +1,100 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucket_BurstThenRate(t *testing.T) {
+	start := time.Unix(0, 0)
+	b := newBucket(Limit{RequestsPerSecond: 2, Burst: 2}, start)
+
+	assert.Zero(t, b.reserve(start))
+	assert.Zero(t, b.reserve(start))
+	assert.Equal(t, 500*time.Millisecond, b.reserve(start))
+	assert.Equal(t, time.Second, b.reserve(start))
+
+	// After two quiet seconds the bucket is full again, but no fuller.
+	later := start.Add(10 * time.Second)
+	assert.Zero(t, b.reserve(later))
+	assert.Zero(t, b.reserve(later))
+	assert.Equal(t, 500*time.Millisecond, b.reserve(later))
+}
+
+func TestLimiter_ResourceTypeLimitTakesPrecedence(t *testing.T) {
+	l := New(
+		map[string]Limit{"EC2": {RequestsPerSecond: 20, Burst: 100}},
+		map[string]Limit{"AWS::EC2::NetworkInterface": {RequestsPerSecond: 1, Burst: 1}},
+	)
+
+	eni := l.bucketFor("EC2", "AWS::EC2::NetworkInterface")
+	vpc := l.bucketFor("EC2", "AWS::EC2::VPC")
+	assert.Equal(t, 1.0, eni.rate)
+	assert.Equal(t, 20.0, vpc.rate)
+	assert.Same(t, vpc, l.bucketFor("EC2", ""))
+	assert.Nil(t, l.bucketFor("S3", "AWS::S3::Bucket"))
+}
+
+func TestLimiter_ResourceTypeLimitScopedToItsService(t *testing.T) {
+	l := New(
+		map[string]Limit{"CloudControl": {RequestsPerSecond: 2, Burst: 2}, "EC2": {RequestsPerSecond: 20, Burst: 100}},
+		map[string]Limit{"AWS::EC2::NetworkInterface": {RequestsPerSecond: 1, Burst: 1}},
+	)
+
+	ec2 := l.bucketFor("EC2", "AWS::EC2::NetworkInterface")
+	cloudControl := l.bucketFor("CloudControl", "AWS::EC2::NetworkInterface")
+	assert.Equal(t, 1.0, ec2.rate)
+	assert.Equal(t, 1.0, cloudControl.rate)
+	assert.NotSame(t, ec2, cloudControl)
+
+	// Other services called on behalf of the type keep their own limits.
+	assert.Nil(t, l.bucketFor("STS", "AWS::EC2::NetworkInterface"))
+}
+
+func TestServesType(t *testing.T) {
+	assert.True(t, servesType("CloudControl", "AWS::SQS::Queue"))
+	assert.True(t, servesType("Route 53", "AWS::Route53::RecordSet"))
+	assert.True(t, servesType("Elastic Load Balancing v2", "AWS::ElasticLoadBalancingV2::Listener"))
+	assert.False(t, servesType("IAM", "AWS::EC2::Instance"))
+	assert.False(t, servesType("EC2", ""))
+}
+
+func TestBucket_DefaultBurst(t *testing.T) {
+	start := time.Unix(0, 0)
+	assert.Equal(t, 3.0, newBucket(Limit{RequestsPerSecond: 2.5}, start).burst)
+	assert.Equal(t, 1.0, newBucket(Limit{RequestsPerSecond: 0.2}, start).burst)
+	assert.Equal(t, 10.0, newBucket(Limit{RequestsPerSecond: 1, Burst: 10}, start).burst)
+}
+
+func TestLimiter_WaitHonoursContext(t *testing.T) {
+	l := New(map[string]Limit{"Route 53": {RequestsPerSecond: 0.1, Burst: 1}}, nil)
+
+	assert.NoError(t, l.Wait(context.Background(), "Route 53", ""))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.Wait(ctx, "Route 53", ""), context.DeadlineExceeded)
+
+	// Services without a limit are never held up.
+	assert.NoError(t, l.Wait(ctx, "S3", ""))
+}
+
+func TestForTarget_SharesLimiterPerTarget(t *testing.T) {
+	assert.Same(t, ForTarget("a", nil), ForTarget("a", nil))
+	assert.NotSame(t, ForTarget("a", nil), ForTarget("b", nil))
+}
+
+func TestWithResourceType(t *testing.T) {
+	assert.Empty(t, resourceTypeFrom(context.Background()))
+	ctx := WithResourceType(context.Background(), "AWS::Route53::RecordSet")
+	assert.Equal(t, "AWS::Route53::RecordSet", resourceTypeFrom(ctx))
+}
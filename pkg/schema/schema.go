@@ -0,0 +1,387 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+// Schema holds the property classifications from a CloudFormation registry
+// resource schema. Paths are JSON pointers relative to the resource's
+// properties (the "/properties" prefix is removed), e.g. "/Arn" or
+// "/Tags/*/Value", where "*" matches every element of an array.
+type Schema struct {
+	ReadOnly   []string
+	WriteOnly  []string
+	CreateOnly []string
+
+	// TagProperty is the JSON pointer of the property holding the resource's
+	// tags (usually "/Tags"), or "" when the type isn't taggable.
+	TagProperty string
+	// TagsAsMap is true when TagProperty is a key/value object rather than an
+	// array of {Key, Value} (e.g. AWS::SSM::Parameter).
+	TagsAsMap bool
+
+	// Required lists the top-level properties a desired state must set.
+	Required []string
+	// Enums maps top-level properties to the values they are restricted to.
+	Enums map[string][]any
+}
+
+// rawSchema is the subset of the registry schema document we care about.
+type rawSchema struct {
+	ReadOnlyProperties   []string `json:"readOnlyProperties"`
+	WriteOnlyProperties  []string `json:"writeOnlyProperties"`
+	CreateOnlyProperties []string `json:"createOnlyProperties"`
+	Required             []string `json:"required"`
+
+	Tagging *struct {
+		Taggable    *bool  `json:"taggable"`
+		TagProperty string `json:"tagProperty"`
+	} `json:"tagging"`
+	Properties  map[string]rawProperty `json:"properties"`
+	Definitions map[string]rawProperty `json:"definitions"`
+}
+
+type rawProperty struct {
+	Type any    `json:"type"`
+	Ref  string `json:"$ref"`
+	Enum []any  `json:"enum"`
+}
+
+// Parse extracts the readOnly, writeOnly and createOnly property lists, the
+// tag property and the top-level constraints from a registry schema document.
+func Parse(doc []byte) (*Schema, error) {
+	var raw rawSchema
+	if err := json.Unmarshal(doc, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resource schema: %w", err)
+	}
+	s := &Schema{
+		ReadOnly:   trimPointers(raw.ReadOnlyProperties),
+		WriteOnly:  trimPointers(raw.WriteOnlyProperties),
+		CreateOnly: trimPointers(raw.CreateOnlyProperties),
+	}
+	s.TagProperty, s.TagsAsMap = raw.tagProperty()
+	s.Required = raw.Required
+	for name := range raw.Properties {
+		if prop := raw.resolve(name); len(prop.Enum) > 0 {
+			if s.Enums == nil {
+				s.Enums = map[string][]any{}
+			}
+			s.Enums[name] = prop.Enum
+		}
+	}
+	return s, nil
+}
+
+// resolve returns the definition of a top-level property, following a $ref
+// into the schema's definitions.
+func (raw *rawSchema) resolve(name string) rawProperty {
+	prop := raw.Properties[name]
+	if prop.Type == nil && strings.HasPrefix(prop.Ref, "#/definitions/") {
+		return raw.Definitions[strings.TrimPrefix(prop.Ref, "#/definitions/")]
+	}
+	return prop
+}
+
+// tagProperty resolves the tag property from the schema's tagging section.
+// Older schemas without one are treated as taggable when they declare a Tags
+// property. Only top-level tag properties are supported.
+func (raw *rawSchema) tagProperty() (string, bool) {
+	pointer := "/Tags"
+	if raw.Tagging != nil {
+		if raw.Tagging.Taggable != nil && !*raw.Tagging.Taggable {
+			return "", false
+		}
+		if raw.Tagging.TagProperty != "" {
+			pointer = strings.TrimPrefix(raw.Tagging.TagProperty, "/properties")
+		}
+	}
+	parts := split(pointer)
+	if len(parts) != 1 {
+		return "", false
+	}
+	if _, ok := raw.Properties[parts[0]]; !ok {
+		return "", false
+	}
+	return pointer, raw.resolve(parts[0]).Type == "object"
+}
+
+func trimPointers(pointers []string) []string {
+	out := make([]string, 0, len(pointers))
+	for _, p := range pointers {
+		out = append(out, strings.TrimPrefix(p, "/properties"))
+	}
+	return out
+}
+
+// IsReadOnly reports whether path (a JSON pointer such as a patch operation's
+// path) is, or is nested under, a readOnly property.
+func (s *Schema) IsReadOnly(path string) bool {
+	return matchesAny(s.ReadOnly, path)
+}
+
+// IsWriteOnly reports whether path is, or is nested under, a writeOnly
+// property.
+func (s *Schema) IsWriteOnly(path string) bool {
+	return matchesAny(s.WriteOnly, path)
+}
+
+// StripReadOnly removes readOnly properties from props in place.
+func (s *Schema) StripReadOnly(props map[string]any) {
+	for _, p := range s.ReadOnly {
+		remove(props, split(p))
+	}
+}
+
+// IsCreateOnly reports whether changing path requires replacing the resource:
+// path is, or is nested under, a createOnly property, or path is a parent
+// object that contains one.
+func (s *Schema) IsCreateOnly(path string) bool {
+	if matchesAny(s.CreateOnly, path) {
+		return true
+	}
+	target := split(path)
+	for _, p := range s.CreateOnly {
+		if parts := split(p); len(parts) > len(target) && hasPrefix(parts, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// CarryWriteOnly copies writeOnly property values from prior into props where
+// props lacks them. CloudControl never returns writeOnly properties from a
+// read, so without this every resource with one (a password, a secret value)
+// would show drift against the caller's stored model on each read. Pointers
+// that traverse arrays are skipped; there is no reliable way to line prior
+// elements up with the current ones.
+func (s *Schema) CarryWriteOnly(prior, props map[string]any) {
+	for _, p := range s.WriteOnly {
+		parts := split(p)
+		if len(parts) == 0 || slices.Contains(parts, "*") {
+			continue
+		}
+		value, ok := lookup(prior, parts)
+		if !ok {
+			continue
+		}
+		set(props, parts, value)
+	}
+}
+
+// Violation is a way a desired state breaks its resource schema.
+type Violation struct {
+	Path    string
+	Message string
+}
+
+func (v Violation) String() string {
+	return v.Path + ": " + v.Message
+}
+
+// Validate checks desired properties against the schema's required
+// properties and enums, returning every violation found. Nested properties
+// are not checked.
+func (s *Schema) Validate(props map[string]any) []Violation {
+	var violations []Violation
+	for _, name := range s.Required {
+		if value, ok := props[name]; !ok || value == nil {
+			violations = append(violations, Violation{Path: "/" + name, Message: "required property is missing"})
+		}
+	}
+
+	names := make([]string, 0, len(s.Enums))
+	for name := range s.Enums {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		value, ok := props[name]
+		if !ok || value == nil {
+			continue
+		}
+		if !slices.ContainsFunc(s.Enums[name], func(allowed any) bool { return reflect.DeepEqual(allowed, value) }) {
+			violations = append(violations, Violation{Path: "/" + name, Message: fmt.Sprintf("%v is not one of %v", value, s.Enums[name])})
+		}
+	}
+	return violations
+}
+
+func lookup(node map[string]any, parts []string) (any, bool) {
+	var cur any = node
+	for _, part := range parts {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func set(node map[string]any, parts []string, value any) {
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := node[part].(map[string]any)
+		if !ok {
+			if _, exists := node[part]; exists {
+				return
+			}
+			next = map[string]any{}
+			node[part] = next
+		}
+		node = next
+	}
+	last := parts[len(parts)-1]
+	if _, exists := node[last]; !exists {
+		node[last] = value
+	}
+}
+
+func matchesAny(pointers []string, path string) bool {
+	target := split(path)
+	for _, p := range pointers {
+		if hasPrefix(target, split(p)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPrefix reports whether path starts with pattern, treating "*" in pattern
+// as matching any single segment (an array index in a patch path).
+func hasPrefix(path, pattern []string) bool {
+	if len(pattern) == 0 || len(path) < len(pattern) {
+		return false
+	}
+	for i, seg := range pattern {
+		if seg != "*" && seg != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func split(pointer string) []string {
+	pointer = strings.TrimPrefix(pointer, "/")
+	if pointer == "" {
+		return nil
+	}
+	return strings.Split(pointer, "/")
+}
+
+func remove(node any, parts []string) {
+	if len(parts) == 0 {
+		return
+	}
+	switch v := node.(type) {
+	case map[string]any:
+		if len(parts) == 1 {
+			delete(v, parts[0])
+			return
+		}
+		remove(v[parts[0]], parts[1:])
+	case []any:
+		if parts[0] != "*" {
+			return
+		}
+		for _, elem := range v {
+			remove(elem, parts[1:])
+		}
+	}
+}
+
+// describeTypeAPI defines the CloudFormation operation used by Registry.
+type describeTypeAPI interface {
+	DescribeType(ctx context.Context, params *cloudformation.DescribeTypeInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeTypeOutput, error)
+}
+
+// failureTTL bounds how long a failed schema lookup is remembered. Long
+// enough that a role without cloudformation:DescribeType doesn't pay a failing
+// call on every operation, short enough that a transient error clears soon.
+const failureTTL = time.Minute
+
+// Registry fetches resource schemas from the CloudFormation registry and
+// caches them for the lifetime of the process. Schemas only change when AWS
+// publishes a new resource provider version, which doesn't happen mid-deploy.
+// Failed lookups are cached for failureTTL, then retried.
+type Registry struct {
+	api describeTypeAPI
+	now func() time.Time
+
+	mu       sync.Mutex
+	cache    map[string]*Schema
+	failures map[string]failedLookup
+}
+
+type failedLookup struct {
+	err     error
+	expires time.Time
+}
+
+func NewRegistry(cfg aws.Config) *Registry {
+	return newRegistry(cloudformation.NewFromConfig(cfg))
+}
+
+func newRegistry(api describeTypeAPI) *Registry {
+	return &Registry{api: api, now: time.Now, cache: map[string]*Schema{}, failures: map[string]failedLookup{}}
+}
+
+// Get returns the schema for typeName, calling cloudformation:DescribeType on
+// the first request for each type. A failed lookup returns the same error
+// until it expires.
+func (r *Registry) Get(ctx context.Context, typeName string) (*Schema, error) {
+	r.mu.Lock()
+	cached, ok := r.cache[typeName]
+	failed, hasFailed := r.failures[typeName]
+	r.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+	if hasFailed && r.now().Before(failed.expires) {
+		return nil, failed.err
+	}
+
+	s, err := r.describe(ctx, typeName)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.failures[typeName] = failedLookup{err: err, expires: r.now().Add(failureTTL)}
+		return nil, err
+	}
+	delete(r.failures, typeName)
+	r.cache[typeName] = s
+	return s, nil
+}
+
+func (r *Registry) describe(ctx context.Context, typeName string) (*Schema, error) {
+	out, err := r.api.DescribeType(ctx, &cloudformation.DescribeTypeInput{
+		Type:     cftypes.RegistryTypeResource,
+		TypeName: aws.String(typeName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe type %s: %w", typeName, err)
+	}
+
+	s, err := Parse([]byte(aws.ToString(out.Schema)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema for %s: %w", typeName, err)
+	}
+	return s, nil
+}
@@ -0,0 +1,191 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package schema
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCloudFormationClient struct {
+	mock.Mock
+}
+
+func (m *mockCloudFormationClient) DescribeType(ctx context.Context, input *cloudformation.DescribeTypeInput, optFns ...func(*cloudformation.Options)) (*cloudformation.DescribeTypeOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cloudformation.DescribeTypeOutput), args.Error(1)
+}
+
+const dbClusterSchema = `{
+	"typeName": "AWS::RDS::DBCluster",
+	"readOnlyProperties": ["/properties/DBClusterArn", "/properties/Endpoint/Address", "/properties/Tags/*/Generated"],
+	"writeOnlyProperties": ["/properties/MasterUserPassword", "/properties/Credentials/Secret"],
+	"createOnlyProperties": ["/properties/DBClusterIdentifier"]
+}`
+
+func TestParse(t *testing.T) {
+	s, err := Parse([]byte(dbClusterSchema))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/DBClusterArn", "/Endpoint/Address", "/Tags/*/Generated"}, s.ReadOnly)
+	assert.Equal(t, []string{"/MasterUserPassword", "/Credentials/Secret"}, s.WriteOnly)
+	assert.Equal(t, []string{"/DBClusterIdentifier"}, s.CreateOnly)
+}
+
+func TestIsReadOnly(t *testing.T) {
+	s, err := Parse([]byte(dbClusterSchema))
+	require.NoError(t, err)
+
+	assert.True(t, s.IsReadOnly("/DBClusterArn"))
+	assert.True(t, s.IsReadOnly("/Endpoint/Address"))
+	assert.True(t, s.IsReadOnly("/Tags/3/Generated"))
+	assert.False(t, s.IsReadOnly("/Endpoint"))
+	assert.False(t, s.IsReadOnly("/Tags/3/Key"))
+	assert.True(t, s.IsWriteOnly("/MasterUserPassword"))
+}
+
+func TestStripReadOnly(t *testing.T) {
+	s, err := Parse([]byte(dbClusterSchema))
+	require.NoError(t, err)
+	props := map[string]any{
+		"DBClusterArn": "arn:aws:rds:us-east-1:123456789012:cluster:db",
+		"Endpoint":     map[string]any{"Address": "db.example.com", "Port": "5432"},
+		"Tags":         []any{map[string]any{"Key": "env", "Generated": true}},
+		"Engine":       "aurora-postgresql",
+	}
+
+	s.StripReadOnly(props)
+
+	assert.Equal(t, map[string]any{
+		"Endpoint": map[string]any{"Port": "5432"},
+		"Tags":     []any{map[string]any{"Key": "env"}},
+		"Engine":   "aurora-postgresql",
+	}, props)
+}
+
+func TestCarryWriteOnly(t *testing.T) {
+	s, err := Parse([]byte(dbClusterSchema))
+	require.NoError(t, err)
+	prior := map[string]any{
+		"MasterUserPassword": "hunter2",
+		"Credentials":        map[string]any{"Secret": "s3cret"},
+	}
+	props := map[string]any{"Engine": "aurora-postgresql"}
+
+	s.CarryWriteOnly(prior, props)
+
+	assert.Equal(t, "hunter2", props["MasterUserPassword"])
+	assert.Equal(t, map[string]any{"Secret": "s3cret"}, props["Credentials"])
+}
+
+func TestRegistry_CachesSchemas(t *testing.T) {
+	ctx := context.Background()
+	client := &mockCloudFormationClient{}
+	client.On("DescribeType", ctx, mock.Anything).Return(&cloudformation.DescribeTypeOutput{
+		Schema: aws.String(dbClusterSchema),
+	}, nil).Once()
+	r := newRegistry(client)
+
+	first, err := r.Get(ctx, "AWS::RDS::DBCluster")
+	require.NoError(t, err)
+	second, err := r.Get(ctx, "AWS::RDS::DBCluster")
+	require.NoError(t, err)
+
+	assert.Same(t, first, second)
+	client.AssertExpectations(t)
+}
+
+func TestRegistry_CachesFailuresBriefly(t *testing.T) {
+	ctx := context.Background()
+	client := &mockCloudFormationClient{}
+	client.On("DescribeType", ctx, mock.Anything).Return(nil, errors.New("AccessDenied")).Once()
+	client.On("DescribeType", ctx, mock.Anything).Return(&cloudformation.DescribeTypeOutput{
+		Schema: aws.String(dbClusterSchema),
+	}, nil).Once()
+	r := newRegistry(client)
+	at := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return at }
+
+	_, err := r.Get(ctx, "AWS::RDS::DBCluster")
+	require.Error(t, err)
+	_, err = r.Get(ctx, "AWS::RDS::DBCluster")
+	require.ErrorContains(t, err, "AccessDenied")
+
+	at = at.Add(failureTTL + time.Second)
+	_, err = r.Get(ctx, "AWS::RDS::DBCluster")
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestIsCreateOnly(t *testing.T) {
+	s := &Schema{CreateOnly: []string{"/DBClusterIdentifier", "/StorageConfig/Encrypted"}}
+
+	assert.True(t, s.IsCreateOnly("/DBClusterIdentifier"))
+	assert.True(t, s.IsCreateOnly("/StorageConfig/Encrypted"))
+	assert.True(t, s.IsCreateOnly("/StorageConfig"))
+	assert.False(t, s.IsCreateOnly("/StorageConfig/Iops"))
+	assert.False(t, s.IsCreateOnly("/Engine"))
+}
+
+func TestParse_TagProperty(t *testing.T) {
+	s, err := Parse([]byte(`{
+		"properties": {"Tags": {"type": "array"}},
+		"tagging": {"taggable": true, "tagProperty": "/properties/Tags"}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, "/Tags", s.TagProperty)
+	assert.False(t, s.TagsAsMap)
+
+	s, err = Parse([]byte(`{
+		"definitions": {"Tags": {"type": "object"}},
+		"properties": {"Tags": {"$ref": "#/definitions/Tags"}}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, "/Tags", s.TagProperty)
+	assert.True(t, s.TagsAsMap)
+
+	s, err = Parse([]byte(`{
+		"properties": {"Tags": {"type": "array"}},
+		"tagging": {"taggable": false}
+	}`))
+	require.NoError(t, err)
+	assert.Empty(t, s.TagProperty)
+
+	s, err = Parse([]byte(dbClusterSchema))
+	require.NoError(t, err)
+	assert.Empty(t, s.TagProperty)
+}
+
+func TestValidate(t *testing.T) {
+	s, err := Parse([]byte(`{
+		"definitions": {"Mode": {"type": "string", "enum": ["A", "B"]}},
+		"properties": {
+			"Name": {"type": "string"},
+			"Mode": {"$ref": "#/definitions/Mode"},
+			"Size": {"type": "integer", "enum": [1, 2]}
+		},
+		"required": ["Name"]
+	}`))
+	require.NoError(t, err)
+
+	assert.Empty(t, s.Validate(map[string]any{"Name": "x", "Mode": "A", "Size": float64(2)}))
+	assert.Equal(t, []Violation{
+		{Path: "/Name", Message: "required property is missing"},
+		{Path: "/Mode", Message: "C is not one of [A B]"},
+	}, s.Validate(map[string]any{"Mode": "C"}))
+}
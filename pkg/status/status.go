@@ -40,6 +40,10 @@ func FromOperationStatus(operationStatus types.OperationStatus) resource.Operati
 		result = resource.OperationStatusInProgress
 	case types.OperationStatusPending:
 		result = resource.OperationStatusPending
+	case types.OperationStatusCancelInProgress:
+		result = resource.OperationStatusInProgress
+	case types.OperationStatusCancelComplete:
+		result = resource.OperationStatusCanceled
 	}
 
 	return result
@@ -0,0 +1,77 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+package tagging
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// Filter scopes discovery by tag. A resource is discovered when it matches
+// every Include entry and no Exclude entry. An entry maps a tag key to the
+// accepted values; an empty value list matches any value of the key.
+type Filter struct {
+	Include map[string][]string
+	Exclude map[string][]string
+}
+
+// IsEmpty reports whether the filter accepts every resource.
+func (f Filter) IsEmpty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// Matches reports whether a resource with the given tags passes the filter.
+func (f Filter) Matches(tags map[string]string) bool {
+	for key, values := range f.Include {
+		if !tagMatches(tags, key, values) {
+			return false
+		}
+	}
+	for key, values := range f.Exclude {
+		if tagMatches(tags, key, values) {
+			return false
+		}
+	}
+	return true
+}
+
+func tagMatches(tags map[string]string, key string, values []string) bool {
+	value, ok := tags[key]
+	if !ok {
+		return false
+	}
+	return len(values) == 0 || slices.Contains(values, value)
+}
+
+// TagsOf extracts the tags from a resource's CloudControl properties. Tags are
+// either a list of Key/Value objects (most types) or a plain map (e.g.
+// AWS::EKS::Nodegroup). ok is false when the properties carry no Tags at all,
+// which for list output usually means the list handler only returned the
+// primary identifier and the resource has to be read to learn its tags.
+func TagsOf(properties string) (tags map[string]string, ok bool) {
+	var props struct {
+		Tags json.RawMessage `json:"Tags"`
+	}
+	if err := json.Unmarshal([]byte(properties), &props); err != nil || len(props.Tags) == 0 {
+		return nil, false
+	}
+
+	var list []struct {
+		Key   string `json:"Key"`
+		Value string `json:"Value"`
+	}
+	if err := json.Unmarshal(props.Tags, &list); err == nil {
+		tags = make(map[string]string, len(list))
+		for _, t := range list {
+			tags[t.Key] = t.Value
+		}
+		return tags, true
+	}
+
+	if err := json.Unmarshal(props.Tags, &tags); err == nil {
+		return tags, true
+	}
+	return nil, false
+}
@@ -0,0 +1,51 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package tagging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter_Matches(t *testing.T) {
+	filter := Filter{
+		Include: map[string][]string{"Team": {"payments", "billing"}, "Env": nil},
+		Exclude: map[string][]string{"karpenter.sh/nodepool": nil},
+	}
+
+	assert.True(t, filter.Matches(map[string]string{"Team": "payments", "Env": "prod"}))
+	assert.True(t, filter.Matches(map[string]string{"Team": "billing", "Env": "dev", "Owner": "x"}))
+	assert.False(t, filter.Matches(map[string]string{"Team": "search", "Env": "prod"}))
+	assert.False(t, filter.Matches(map[string]string{"Team": "payments"}))
+	assert.False(t, filter.Matches(map[string]string{"Team": "payments", "Env": "prod", "karpenter.sh/nodepool": "default"}))
+	assert.False(t, filter.Matches(nil))
+
+	excludeOnly := Filter{Exclude: map[string][]string{"Env": {"scratch"}}}
+	assert.True(t, excludeOnly.Matches(nil))
+	assert.True(t, excludeOnly.Matches(map[string]string{"Env": "prod"}))
+	assert.False(t, excludeOnly.Matches(map[string]string{"Env": "scratch"}))
+
+	assert.True(t, Filter{}.IsEmpty())
+	assert.False(t, excludeOnly.IsEmpty())
+}
+
+func TestTagsOf(t *testing.T) {
+	tags, ok := TagsOf(`{"VpcId":"vpc-1","Tags":[{"Key":"Team","Value":"payments"}]}`)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"Team": "payments"}, tags)
+
+	tags, ok = TagsOf(`{"NodegroupName":"ng","Tags":{"Team":"payments"}}`)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"Team": "payments"}, tags)
+
+	_, ok = TagsOf(`{"VpcId":"vpc-1"}`)
+	assert.False(t, ok)
+
+	_, ok = TagsOf(`not json`)
+	assert.False(t, ok)
+}
@@ -0,0 +1,270 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+// Package tagging implements discovery through the Resource Groups Tagging
+// API. One GetResources call pages through every tagged resource of a type in
+// the region, filtered by tag server-side, which is far cheaper on large
+// accounts than CloudControl's ListResources. Only resources that carry (or
+// once carried) a tag are visible to the Tagging API, so this mode suits
+// accounts with consistent tagging and is opt-in per target.
+package tagging
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	rgt "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	rgttypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+
+	"github.com/platform-engineering-labs/formae-plugin-aws/pkg/config"
+)
+
+// maxResourcesPerPage is the Tagging API's upper bound for ResourcesPerPage.
+const maxResourcesPerPage = 100
+
+// typeMapping ties a CloudFormation type to its Tagging API resource type
+// filter and to the way its CloudControl primary identifier is derived from
+// the resource ARN.
+type typeMapping struct {
+	filter     string
+	identifier func(arn string) string
+}
+
+// typeMappings covers the types whose identifier can be derived from the ARN
+// alone. Types not listed here are always discovered through CloudControl.
+var typeMappings = map[string]typeMapping{
+	"AWS::CloudFront::Distribution":             {"cloudfront:distribution", lastSegment},
+	"AWS::DynamoDB::Table":                      {"dynamodb:table", lastSegment},
+	"AWS::EC2::Instance":                        {"ec2:instance", lastSegment},
+	"AWS::EC2::InternetGateway":                 {"ec2:internet-gateway", lastSegment},
+	"AWS::EC2::NatGateway":                      {"ec2:natgateway", lastSegment},
+	"AWS::EC2::RouteTable":                      {"ec2:route-table", lastSegment},
+	"AWS::EC2::SecurityGroup":                   {"ec2:security-group", lastSegment},
+	"AWS::EC2::Subnet":                          {"ec2:subnet", lastSegment},
+	"AWS::EC2::VPC":                             {"ec2:vpc", lastSegment},
+	"AWS::EC2::Volume":                          {"ec2:volume", lastSegment},
+	"AWS::ECR::Repository":                      {"ecr:repository", resourcePath},
+	"AWS::ECS::Cluster":                         {"ecs:cluster", lastSegment},
+	"AWS::EFS::FileSystem":                      {"elasticfilesystem:file-system", lastSegment},
+	"AWS::EKS::Cluster":                         {"eks:cluster", lastSegment},
+	"AWS::ElasticLoadBalancingV2::LoadBalancer": {"elasticloadbalancing:loadbalancer", fullArn},
+	"AWS::ElasticLoadBalancingV2::TargetGroup":  {"elasticloadbalancing:targetgroup", fullArn},
+	"AWS::IAM::Role":                            {"iam:role", lastSegment},
+	"AWS::KMS::Key":                             {"kms:key", lastSegment},
+	"AWS::Lambda::Function":                     {"lambda:function", lastSegment},
+	"AWS::Logs::LogGroup":                       {"logs:log-group", logGroupName},
+	"AWS::RDS::DBCluster":                       {"rds:cluster", lastSegment},
+	"AWS::RDS::DBInstance":                      {"rds:db", lastSegment},
+	"AWS::S3::Bucket":                           {"s3", lastSegment},
+	"AWS::SecretsManager::Secret":               {"secretsmanager:secret", fullArn},
+	"AWS::SQS::Queue":                           {"sqs", queueURL},
+}
+
+// Supports reports whether resourceType can be discovered through the Tagging
+// API.
+func Supports(resourceType string) bool {
+	_, ok := typeMappings[resourceType]
+	return ok
+}
+
+// TypeForArn maps a resource ARN back to its CloudFormation type, for ARNs
+// of the types listed in typeMappings.
+func TypeForArn(arn string) (string, bool) {
+	service, resourceType, ok := splitArn(arn)
+	if !ok {
+		return "", false
+	}
+	for cfnType, m := range typeMappings {
+		fService, fType, _ := strings.Cut(m.filter, ":")
+		if fService == service && (fType == "" || fType == resourceType) {
+			return cfnType, true
+		}
+	}
+	return "", false
+}
+
+// IdentifierFromArn derives the CloudControl identifier of a resource of
+// resourceType from its ARN, for the types listed in typeMappings.
+func IdentifierFromArn(resourceType, arn string) (string, bool) {
+	m, ok := typeMappings[resourceType]
+	if !ok {
+		return "", false
+	}
+	return m.identifier(arn), true
+}
+
+// taggingAPI defines the Tagging API operations used by Client.
+type taggingAPI interface {
+	GetResources(ctx context.Context, params *rgt.GetResourcesInput, optFns ...func(*rgt.Options)) (*rgt.GetResourcesOutput, error)
+}
+
+type Client struct {
+	api taggingAPI
+}
+
+var clientPool config.Pool[*Client]
+
+// NewClient returns the Client for cfg, pooled by target config like
+// ccx.NewClient.
+func NewClient(cfg *config.Config) (*Client, error) {
+	return clientPool.Get(cfg, func(cfg *config.Config) (*Client, error) {
+		awsCfg, err := cfg.ToAwsConfig(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("loading AWS config: %w", err)
+		}
+		return &Client{api: rgt.NewFromConfig(awsCfg)}, nil
+	})
+}
+
+// List returns one page of CloudControl identifiers of resourceType whose
+// tags pass filter. Include entries are also sent to the Tagging API so it
+// filters server-side; Exclude entries can only be checked against the tags
+// returned with each resource.
+func (c *Client) List(ctx context.Context, request *resource.ListRequest, filter Filter) (*resource.ListResult, error) {
+	m, ok := typeMappings[request.ResourceType]
+	if !ok {
+		return nil, fmt.Errorf("%s cannot be discovered through the Tagging API", request.ResourceType)
+	}
+
+	perPage := request.PageSize
+	if perPage <= 0 || perPage > maxResourcesPerPage {
+		perPage = maxResourcesPerPage
+	}
+
+	out, err := c.api.GetResources(ctx, &rgt.GetResourcesInput{
+		ResourceTypeFilters: []string{m.filter},
+		TagFilters:          toTagFilters(filter.Include),
+		ResourcesPerPage:    aws.Int32(perPage),
+		PaginationToken:     request.PageToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tagged %s resources: %w", request.ResourceType, err)
+	}
+
+	nativeIDs := []string{}
+	for _, r := range out.ResourceTagMappingList {
+		arn := aws.ToString(r.ResourceARN)
+		// "elasticloadbalancing:loadbalancer" also matches Classic Load
+		// Balancers, which aren't ELBv2 resources.
+		if request.ResourceType == "AWS::ElasticLoadBalancingV2::LoadBalancer" && !isElbv2LoadBalancer(arn) {
+			continue
+		}
+		if !filter.Matches(tagMap(r.Tags)) {
+			continue
+		}
+		nativeIDs = append(nativeIDs, m.identifier(arn))
+	}
+
+	// The Tagging API returns an empty token on the last page.
+	var next *string
+	if aws.ToString(out.PaginationToken) != "" {
+		next = out.PaginationToken
+	}
+
+	return &resource.ListResult{
+		NativeIDs:     nativeIDs,
+		NextPageToken: next,
+	}, nil
+}
+
+func tagMap(tags []rgttypes.Tag) map[string]string {
+	out := make(map[string]string, len(tags))
+	for _, t := range tags {
+		out[aws.ToString(t.Key)] = aws.ToString(t.Value)
+	}
+	return out
+}
+
+func toTagFilters(filters map[string][]string) []rgttypes.TagFilter {
+	keys := make([]string, 0, len(filters))
+	for k := range filters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make([]rgttypes.TagFilter, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, rgttypes.TagFilter{Key: aws.String(k), Values: filters[k]})
+	}
+	return out
+}
+
+// splitArn returns the service and the resource-type prefix of the resource
+// part of an ARN ("" for services such as S3 and SQS whose ARNs have none).
+func splitArn(arn string) (service, resourceType string, ok bool) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return "", "", false
+	}
+	res := parts[5]
+	if i := strings.IndexAny(res, "/:"); i >= 0 {
+		return parts[2], res[:i], true
+	}
+	return parts[2], "", true
+}
+
+func resourcePart(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 {
+		return arn
+	}
+	return parts[5]
+}
+
+// lastSegment returns the final "/" or ":" separated segment of the ARN's
+// resource part: vpc/vpc-123 -> vpc-123, function:name -> name, bucket ->
+// bucket, role/path/name -> name.
+func lastSegment(arn string) string {
+	res := resourcePart(arn)
+	if i := strings.LastIndexAny(res, "/:"); i >= 0 {
+		return res[i+1:]
+	}
+	return res
+}
+
+// resourcePath strips the resource type from the resource part, keeping any
+// "/" in the name (ECR repository names may be namespaced: team/app).
+func resourcePath(arn string) string {
+	res := resourcePart(arn)
+	if _, name, ok := strings.Cut(res, "/"); ok {
+		return name
+	}
+	return res
+}
+
+func fullArn(arn string) string {
+	return arn
+}
+
+// logGroupName handles arn:...:log-group:/aws/lambda/fn:* where the name may
+// contain "/" and the ARN may carry a trailing ":*".
+func logGroupName(arn string) string {
+	name := strings.TrimPrefix(resourcePart(arn), "log-group:")
+	return strings.TrimSuffix(name, ":*")
+}
+
+// queueURL converts arn:aws:sqs:us-east-1:123456789012:name into the queue
+// URL, which is the SQS queue's CloudControl identifier.
+func queueURL(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 {
+		return arn
+	}
+	domain := "amazonaws.com"
+	if parts[1] == "aws-cn" {
+		domain = "amazonaws.com.cn"
+	}
+	return fmt.Sprintf("https://sqs.%s.%s/%s/%s", parts[3], domain, parts[4], parts[5])
+}
+
+func isElbv2LoadBalancer(arn string) bool {
+	res := resourcePart(arn)
+	return strings.HasPrefix(res, "loadbalancer/app/") ||
+		strings.HasPrefix(res, "loadbalancer/net/") ||
+		strings.HasPrefix(res, "loadbalancer/gwy/")
+}
@@ -0,0 +1,159 @@
+// © 2025 Platform Engineering Labs Inc.
+//
+// SPDX-License-Identifier: FSL-1.1-ALv2
+
+//go:build unit
+
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	rgt "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	rgttypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/platform-engineering-labs/formae/pkg/plugin/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaggingClient struct {
+	mock.Mock
+}
+
+func (m *mockTaggingClient) GetResources(ctx context.Context, input *rgt.GetResourcesInput, optFns ...func(*rgt.Options)) (*rgt.GetResourcesOutput, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*rgt.GetResourcesOutput), args.Error(1)
+}
+
+func mappings(arns ...string) []rgttypes.ResourceTagMapping {
+	out := make([]rgttypes.ResourceTagMapping, 0, len(arns))
+	for _, arn := range arns {
+		out = append(out, rgttypes.ResourceTagMapping{ResourceARN: aws.String(arn)})
+	}
+	return out
+}
+
+func tags(kv ...string) []rgttypes.Tag {
+	out := make([]rgttypes.Tag, 0, len(kv)/2)
+	for i := 0; i < len(kv); i += 2 {
+		out = append(out, rgttypes.Tag{Key: aws.String(kv[i]), Value: aws.String(kv[i+1])})
+	}
+	return out
+}
+
+func TestIdentifierFromArn(t *testing.T) {
+	tests := []struct {
+		resourceType string
+		arn          string
+		want         string
+	}{
+		{"AWS::EC2::VPC", "arn:aws:ec2:us-east-1:123456789012:vpc/vpc-0abc", "vpc-0abc"},
+		{"AWS::S3::Bucket", "arn:aws:s3:::my-bucket", "my-bucket"},
+		{"AWS::Lambda::Function", "arn:aws:lambda:us-east-1:123456789012:function:handler", "handler"},
+		{"AWS::IAM::Role", "arn:aws:iam::123456789012:role/service/deployer", "deployer"},
+		{"AWS::ECR::Repository", "arn:aws:ecr:us-east-1:123456789012:repository/team/app", "team/app"},
+		{"AWS::Logs::LogGroup", "arn:aws:logs:us-east-1:123456789012:log-group:/aws/lambda/handler:*", "/aws/lambda/handler"},
+		{"AWS::SQS::Queue", "arn:aws:sqs:eu-west-1:123456789012:jobs", "https://sqs.eu-west-1.amazonaws.com/123456789012/jobs"},
+		{"AWS::SecretsManager::Secret", "arn:aws:secretsmanager:us-east-1:123456789012:secret:db-AbCdEf", "arn:aws:secretsmanager:us-east-1:123456789012:secret:db-AbCdEf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resourceType, func(t *testing.T) {
+			assert.Equal(t, tt.want, typeMappings[tt.resourceType].identifier(tt.arn))
+		})
+	}
+}
+
+func TestTypeForArn(t *testing.T) {
+	typ, ok := TypeForArn("arn:aws:ec2:us-east-1:123456789012:security-group/sg-123")
+	assert.True(t, ok)
+	assert.Equal(t, "AWS::EC2::SecurityGroup", typ)
+
+	typ, ok = TypeForArn("arn:aws:s3:::my-bucket")
+	assert.True(t, ok)
+	assert.Equal(t, "AWS::S3::Bucket", typ)
+
+	_, ok = TypeForArn("arn:aws:ec2:us-east-1:123456789012:transit-gateway/tgw-123")
+	assert.False(t, ok)
+
+	_, ok = TypeForArn("not-an-arn")
+	assert.False(t, ok)
+}
+
+func TestList_PassesFiltersAndPaging(t *testing.T) {
+	api := &mockTaggingClient{}
+	client := &Client{api: api}
+
+	api.On("GetResources", mock.Anything, mock.MatchedBy(func(in *rgt.GetResourcesInput) bool {
+		return assert.ObjectsAreEqual([]string{"ec2:vpc"}, in.ResourceTypeFilters) &&
+			len(in.TagFilters) == 2 &&
+			aws.ToString(in.TagFilters[0].Key) == "env" &&
+			assert.ObjectsAreEqual([]string{"prod"}, in.TagFilters[0].Values) &&
+			aws.ToString(in.TagFilters[1].Key) == "team" &&
+			len(in.TagFilters[1].Values) == 0 &&
+			aws.ToInt32(in.ResourcesPerPage) == maxResourcesPerPage &&
+			aws.ToString(in.PaginationToken) == "page-1"
+	})).Return(&rgt.GetResourcesOutput{
+		ResourceTagMappingList: []rgttypes.ResourceTagMapping{
+			{ResourceARN: aws.String("arn:aws:ec2:us-east-1:123456789012:vpc/vpc-1"), Tags: tags("env", "prod", "team", "a")},
+			{ResourceARN: aws.String("arn:aws:ec2:us-east-1:123456789012:vpc/vpc-2"), Tags: tags("env", "prod", "team", "b")},
+			{ResourceARN: aws.String("arn:aws:ec2:us-east-1:123456789012:vpc/vpc-3"), Tags: tags("env", "prod", "team", "a", "scratch", "yes")},
+		},
+		PaginationToken: aws.String("page-2"),
+	}, nil)
+
+	result, err := client.List(context.Background(), &resource.ListRequest{
+		ResourceType: "AWS::EC2::VPC",
+		PageSize:     500,
+		PageToken:    aws.String("page-1"),
+	}, Filter{
+		Include: map[string][]string{"team": nil, "env": {"prod"}},
+		Exclude: map[string][]string{"scratch": nil},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vpc-1", "vpc-2"}, result.NativeIDs)
+	assert.Equal(t, "page-2", aws.ToString(result.NextPageToken))
+	api.AssertExpectations(t)
+}
+
+func TestList_LastPageAndClassicLoadBalancers(t *testing.T) {
+	api := &mockTaggingClient{}
+	client := &Client{api: api}
+
+	alb := "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/web/50dc6c495c0c9188"
+	api.On("GetResources", mock.Anything, mock.Anything).Return(&rgt.GetResourcesOutput{
+		ResourceTagMappingList: mappings(
+			alb,
+			"arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/legacy",
+		),
+		PaginationToken: aws.String(""),
+	}, nil)
+
+	result, err := client.List(context.Background(), &resource.ListRequest{
+		ResourceType: "AWS::ElasticLoadBalancingV2::LoadBalancer",
+	}, Filter{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{alb}, result.NativeIDs)
+	assert.Nil(t, result.NextPageToken)
+}
+
+func TestList_Errors(t *testing.T) {
+	api := &mockTaggingClient{}
+	client := &Client{api: api}
+
+	_, err := client.List(context.Background(), &resource.ListRequest{ResourceType: "AWS::EC2::TransitGateway"}, Filter{})
+	assert.Error(t, err)
+
+	api.On("GetResources", mock.Anything, mock.Anything).Return(nil, errors.New("AccessDeniedException"))
+	_, err = client.List(context.Background(), &resource.ListRequest{ResourceType: "AWS::S3::Bucket"}, Filter{})
+	assert.ErrorContains(t, err, "AccessDeniedException")
+}